@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Print the catalog of tools, resources and prompts",
+	Long:  `Print every toolset with the definitions of its tools, resource templates and prompts, including their parameter schemas, as JSON or Markdown. The catalog is generated from the registered toolsets and reflects the --read-only, --locale and --tool-overrides-file flags, so it matches what the server offers with the same flags.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "json" && format != "markdown" {
+			return fmt.Errorf("unknown format %q, use json or markdown", format)
+		}
+
+		t, _, err := translations.LocaleTranslationHelper(viper.GetString("locale"), viper.GetString("locales-dir"))
+		if err != nil {
+			return fmt.Errorf("failed to load translations: %w", err)
+		}
+		var toolOverrides map[string]toolsets.ToolOverride
+		if path := viper.GetString("tool-overrides-file"); path != "" {
+			toolOverrides, err = toolsets.LoadToolOverrides(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		c, err := buildCatalog(viper.GetBool("read-only"), t, toolOverrides)
+		if err != nil {
+			return err
+		}
+
+		var out string
+		if format == "markdown" {
+			out = c.markdown()
+		} else {
+			data, err := json.MarshalIndent(c, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal catalog: %w", err)
+			}
+			out = string(data)
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), out)
+		return err
+	},
+}
+
+func init() {
+	catalogCmd.Flags().String("format", "json", "Output format, json or markdown")
+	rootCmd.AddCommand(catalogCmd)
+}
+
+// catalog is the interface of the server. Tools, resource templates and prompts are in the shape of the MCP list
+// responses, so clients can validate against them directly.
+type catalog struct {
+	Version  string           `json:"version"`
+	ReadOnly bool             `json:"read_only"`
+	Toolsets []catalogToolset `json:"toolsets"`
+}
+
+type catalogToolset struct {
+	Name              string                 `json:"name"`
+	Description       string                 `json:"description"`
+	Default           bool                   `json:"default"`
+	Tools             []mcp.Tool             `json:"tools"`
+	ResourceTemplates []mcp.ResourceTemplate `json:"resource_templates"`
+	Prompts           []mcp.Prompt           `json:"prompts"`
+}
+
+// buildCatalog lists every toolset, including the dynamic toolset, sorted by name with their tools, resource
+// templates and prompts sorted by name.
+func buildCatalog(readOnly bool, t translations.TranslationHelperFunc, toolOverrides map[string]toolsets.ToolOverride) (catalog, error) {
+	tsg := github.DefaultToolsetGroup(readOnly, mockGetClient, mockGetGQLClient, mockGetRawClient, mockGetClient, t, 5000)
+	if err := tsg.ApplyToolOverrides(toolOverrides); err != nil {
+		return catalog{}, fmt.Errorf("failed to apply tool overrides: %w", err)
+	}
+
+	all := make([]*toolsets.Toolset, 0, len(tsg.Toolsets)+1)
+	for _, toolset := range tsg.Toolsets {
+		all = append(all, toolset)
+	}
+	all = append(all, github.InitDynamicToolset(github.NewServer(version), tsg, t))
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	defaults := github.GetDefaultToolsetIDs()
+	c := catalog{Version: version, ReadOnly: readOnly, Toolsets: make([]catalogToolset, 0, len(all))}
+	for _, toolset := range all {
+		entry := catalogToolset{
+			Name:              toolset.Name,
+			Description:       toolset.Description,
+			Default:           contains(defaults, toolset.Name),
+			Tools:             []mcp.Tool{},
+			ResourceTemplates: []mcp.ResourceTemplate{},
+			Prompts:           []mcp.Prompt{},
+		}
+		for _, tool := range toolset.GetAvailableTools() {
+			entry.Tools = append(entry.Tools, tool.Tool)
+		}
+		sort.Slice(entry.Tools, func(i, j int) bool { return entry.Tools[i].Name < entry.Tools[j].Name })
+		for _, template := range toolset.GetAvailableResourceTemplates() {
+			entry.ResourceTemplates = append(entry.ResourceTemplates, template.Template)
+		}
+		sort.Slice(entry.ResourceTemplates, func(i, j int) bool {
+			return entry.ResourceTemplates[i].Name < entry.ResourceTemplates[j].Name
+		})
+		for _, prompt := range toolset.GetAvailablePrompts() {
+			entry.Prompts = append(entry.Prompts, prompt.Prompt)
+		}
+		sort.Slice(entry.Prompts, func(i, j int) bool { return entry.Prompts[i].Name < entry.Prompts[j].Name })
+		c.Toolsets = append(c.Toolsets, entry)
+	}
+	return c, nil
+}
+
+// markdown renders the catalog in the format of the tool list of the README.
+func (c catalog) markdown() string {
+	var sections []string
+	sections = append(sections, fmt.Sprintf("# GitHub MCP Server catalog\n\nVersion: %s, read-only: %t", c.Version, c.ReadOnly))
+
+	for _, toolset := range c.Toolsets {
+		header := fmt.Sprintf("## %s (`%s`)\n\n%s", formatToolsetName(toolset.Name), toolset.Name, toolset.Description)
+		if toolset.Default {
+			header += " (enabled by default)"
+		}
+		sections = append(sections, header)
+
+		if len(toolset.Tools) > 0 {
+			docs := make([]string, 0, len(toolset.Tools))
+			for _, tool := range toolset.Tools {
+				docs = append(docs, generateToolDoc(tool))
+			}
+			sections = append(sections, "### Tools\n\n"+strings.Join(docs, "\n\n"))
+		}
+
+		if len(toolset.ResourceTemplates) > 0 {
+			lines := make([]string, 0, len(toolset.ResourceTemplates))
+			for _, template := range toolset.ResourceTemplates {
+				lines = append(lines, fmt.Sprintf("- **%s** - `%s`", template.Name, template.URITemplate.Raw()))
+			}
+			sections = append(sections, "### Resource templates\n\n"+strings.Join(lines, "\n"))
+		}
+
+		if len(toolset.Prompts) > 0 {
+			docs := make([]string, 0, len(toolset.Prompts))
+			for _, prompt := range toolset.Prompts {
+				docs = append(docs, generatePromptDoc(prompt))
+			}
+			sections = append(sections, "### Prompts\n\n"+strings.Join(docs, "\n\n"))
+		}
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func generatePromptDoc(prompt mcp.Prompt) string {
+	lines := []string{fmt.Sprintf("- **%s** - %s", prompt.Name, prompt.Description)}
+	if len(prompt.Arguments) == 0 {
+		lines = append(lines, "  - No arguments required")
+	}
+	for _, arg := range prompt.Arguments {
+		requiredStr := "optional"
+		if arg.Required {
+			requiredStr = "required"
+		}
+		lines = append(lines, fmt.Sprintf("  - `%s`: %s (%s)", arg.Name, arg.Description, requiredStr))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportTranslationKeysCmd = &cobra.Command{
+	Use:   "export-translation-keys",
+	Short: "Print the translation keys of the tools as JSON",
+	Long:  `Print every translation key of the tool titles and descriptions with its current value as a JSON object, to use as a template for a locale file or github-mcp-server-config.json. The values reflect the selected locale and any overrides.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		t, _, err := translations.LocaleTranslationHelper(viper.GetString("locale"), viper.GetString("locales-dir"))
+		if err != nil {
+			return fmt.Errorf("failed to load translations: %w", err)
+		}
+
+		keys, err := exportTranslationKeys(t)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), keys)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportTranslationKeysCmd)
+}
+
+// exportTranslationKeys returns the translation keys requested by the tools, resources and prompts of every
+// toolset, with their value translated by t, as an indented JSON object sorted by key.
+func exportTranslationKeys(t translations.TranslationHelperFunc) (string, error) {
+	keys := map[string]string{}
+	record := func(key string, defaultValue string) string {
+		value := t(key, defaultValue)
+		keys[strings.ToUpper(key)] = value
+		return value
+	}
+
+	// Building the toolsets, including the dynamic and app tools, looks up every key
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, mockGetClient, record, 5000)
+	github.InitDynamicToolset(github.NewServer(version), tsg, record)
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal translation keys: %w", err)
+	}
+	return string(data), nil
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -50,10 +52,64 @@ var (
 				enabledToolsets = []string{github.ToolsetMetadataDefault.ID}
 			}
 
+			// The owner and repository patterns are comma-separated too, so they're read like the toolsets
+			policyPatterns := map[string][]string{}
+			for _, key := range []string{"allow-owners", "deny-owners", "allow-repos", "deny-repos"} {
+				var patterns []string
+				if err := viper.UnmarshalKey(key, &patterns); err != nil {
+					return fmt.Errorf("failed to unmarshal %s: %w", key, err)
+				}
+				policyPatterns[key] = patterns
+			}
+
+			var appPrivateKey []byte
+			if path := viper.GetString("app-private-key-file"); path != "" {
+				var err error
+				appPrivateKey, err = os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read GitHub App private key: %w", err)
+				}
+			}
+
+			var toolOverrides map[string]toolsets.ToolOverride
+			if path := viper.GetString("tool-overrides-file"); path != "" {
+				var err error
+				toolOverrides, err = toolsets.LoadToolOverrides(path)
+				if err != nil {
+					return err
+				}
+			}
+
+			var httpHeaders github.HTTPHeaders
+			if path := viper.GetString("http-headers-file"); path != "" {
+				var err error
+				httpHeaders, err = github.LoadHTTPHeaders(path)
+				if err != nil {
+					return err
+				}
+			}
+			// The API version flag takes precedence over the version in the headers file
+			if apiVersion := viper.GetString("api-version"); apiVersion != "" {
+				if httpHeaders.Headers == nil {
+					httpHeaders.Headers = map[string]string{}
+				}
+				for name := range httpHeaders.Headers {
+					if strings.EqualFold(name, github.APIVersionHeader) {
+						delete(httpHeaders.Headers, name)
+					}
+				}
+				httpHeaders.Headers[github.APIVersionHeader] = apiVersion
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
 				Version:              version,
 				Host:                 viper.GetString("host"),
 				Token:                token,
+				AppID:                viper.GetInt64("app-id"),
+				AppPrivateKey:        appPrivateKey,
+				Locale:               viper.GetString("locale"),
+				LocalesDir:           viper.GetString("locales-dir"),
+				ToolOverrides:        toolOverrides,
 				EnabledToolsets:      enabledToolsets,
 				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
 				ReadOnly:             viper.GetBool("read-only"),
@@ -61,6 +117,23 @@ var (
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
 				ContentWindowSize:    viper.GetInt("content-window-size"),
+				ShutdownTimeout:      viper.GetDuration("shutdown-timeout"),
+				JournalFile:          viper.GetString("journal-file"),
+				JournalMaxEntries:    viper.GetInt("journal-max-entries"),
+				AllowOwners:          policyPatterns["allow-owners"],
+				DenyOwners:           policyPatterns["deny-owners"],
+				AllowRepos:           policyPatterns["allow-repos"],
+				DenyRepos:            policyPatterns["deny-repos"],
+				WriteLimits: github.WriteLimits{
+					MaxWritesPerMinute:   viper.GetInt("max-writes-per-minute"),
+					MaxFilesPerCommit:    viper.GetInt("max-files-per-commit"),
+					MaxDeletesPerSession: viper.GetInt("max-deletes-per-session"),
+				},
+				SearchCacheTTL:            viper.GetDuration("search-cache-ttl"),
+				SecondaryRateLimitMaxWait: viper.GetDuration("secondary-rate-limit-max-wait"),
+				OfflineQueueRetryInterval: viper.GetDuration("offline-queue-retry-interval"),
+				HTTPHeaders:               httpHeaders,
+				OutputFormat:              viper.GetString("output-format"),
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -82,6 +155,27 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().String("locale", "", "Language of the tool titles and descriptions (e.g. 'ja' or 'pt-BR'), read from <locale>.json in the locales directory")
+	rootCmd.PersistentFlags().String("locales-dir", "locales", "Directory containing the locale translation files")
+	rootCmd.PersistentFlags().String("tool-overrides-file", "", "Path to a JSON file overriding the descriptions and annotations of tools")
+	rootCmd.PersistentFlags().Int64("app-id", 0, "ID of a GitHub App to mint installation tokens with")
+	rootCmd.PersistentFlags().String("app-private-key-file", "", "Path to the PEM encoded private key of the GitHub App")
+	rootCmd.PersistentFlags().Duration("shutdown-timeout", 30*time.Second, "How long to wait for running tool calls to finish, and queued operations to be retried, on shutdown")
+	rootCmd.PersistentFlags().String("journal-file", "", "Path to a file to record calls of tools that change GitHub in, enabling the get_recent_operations tool")
+	rootCmd.PersistentFlags().Int("journal-max-entries", 1000, "Number of most recent calls to keep in the journal")
+	rootCmd.PersistentFlags().StringSlice("allow-owners", nil, "Only allow tool calls on these owners (glob patterns, e.g. 'my-org')")
+	rootCmd.PersistentFlags().StringSlice("deny-owners", nil, "Reject tool calls on these owners (glob patterns)")
+	rootCmd.PersistentFlags().StringSlice("allow-repos", nil, "Only allow tool calls on these repositories, in addition to --allow-owners (owner/repo glob patterns, e.g. 'my-org/service-*')")
+	rootCmd.PersistentFlags().StringSlice("deny-repos", nil, "Reject tool calls on these repositories (owner/repo glob patterns)")
+	rootCmd.PersistentFlags().Int("max-writes-per-minute", 0, "Maximum number of calls of tools that change GitHub per minute (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-files-per-commit", 0, "Maximum number of files a single commit may change (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-deletes-per-session", 0, "Maximum number of items the delete tools may delete while the server runs (0 for no limit)")
+	rootCmd.PersistentFlags().Duration("search-cache-ttl", 0, "How long to cache the results of searches, e.g. 1m (0 to disable)")
+	rootCmd.PersistentFlags().Duration("secondary-rate-limit-max-wait", time.Minute, "How long requests wait for the pause after a secondary rate limit to end before failing")
+	rootCmd.PersistentFlags().Duration("offline-queue-retry-interval", 0, "How often to retry comments and label changes that failed while GitHub was unavailable (0 to fail them instead)")
+	rootCmd.PersistentFlags().String("http-headers-file", "", "Path to a JSON file with extra headers to send to GitHub, globally and per toolset")
+	rootCmd.PersistentFlags().String("api-version", "", "Version of the GitHub REST API to request, sent as the X-GitHub-Api-Version header")
+	rootCmd.PersistentFlags().String("output-format", "compact", "How tools write their JSON results: compact, pretty, or markdown for clients showing them to people")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -92,6 +186,27 @@ func init() {
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
+	_ = viper.BindPFlag("locales-dir", rootCmd.PersistentFlags().Lookup("locales-dir"))
+	_ = viper.BindPFlag("tool-overrides-file", rootCmd.PersistentFlags().Lookup("tool-overrides-file"))
+	_ = viper.BindPFlag("app-id", rootCmd.PersistentFlags().Lookup("app-id"))
+	_ = viper.BindPFlag("app-private-key-file", rootCmd.PersistentFlags().Lookup("app-private-key-file"))
+	_ = viper.BindPFlag("shutdown-timeout", rootCmd.PersistentFlags().Lookup("shutdown-timeout"))
+	_ = viper.BindPFlag("journal-file", rootCmd.PersistentFlags().Lookup("journal-file"))
+	_ = viper.BindPFlag("journal-max-entries", rootCmd.PersistentFlags().Lookup("journal-max-entries"))
+	_ = viper.BindPFlag("allow-owners", rootCmd.PersistentFlags().Lookup("allow-owners"))
+	_ = viper.BindPFlag("deny-owners", rootCmd.PersistentFlags().Lookup("deny-owners"))
+	_ = viper.BindPFlag("allow-repos", rootCmd.PersistentFlags().Lookup("allow-repos"))
+	_ = viper.BindPFlag("deny-repos", rootCmd.PersistentFlags().Lookup("deny-repos"))
+	_ = viper.BindPFlag("max-writes-per-minute", rootCmd.PersistentFlags().Lookup("max-writes-per-minute"))
+	_ = viper.BindPFlag("max-files-per-commit", rootCmd.PersistentFlags().Lookup("max-files-per-commit"))
+	_ = viper.BindPFlag("max-deletes-per-session", rootCmd.PersistentFlags().Lookup("max-deletes-per-session"))
+	_ = viper.BindPFlag("search-cache-ttl", rootCmd.PersistentFlags().Lookup("search-cache-ttl"))
+	_ = viper.BindPFlag("secondary-rate-limit-max-wait", rootCmd.PersistentFlags().Lookup("secondary-rate-limit-max-wait"))
+	_ = viper.BindPFlag("offline-queue-retry-interval", rootCmd.PersistentFlags().Lookup("offline-queue-retry-interval"))
+	_ = viper.BindPFlag("http-headers-file", rootCmd.PersistentFlags().Lookup("http-headers-file"))
+	_ = viper.BindPFlag("api-version", rootCmd.PersistentFlags().Lookup("api-version"))
+	_ = viper.BindPFlag("output-format", rootCmd.PersistentFlags().Lookup("output-format"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
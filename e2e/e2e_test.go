@@ -169,7 +169,7 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 		// so that there is a shared setup mechanism, but let's wait till we feel more friction.
 		enabledToolsets := opts.enabledToolsets
 		if enabledToolsets == nil {
-			enabledToolsets = github.DefaultTools
+			enabledToolsets = github.GetDefaultToolsetIDs()
 		}
 
 		ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
@@ -0,0 +1,382 @@
+//go:build sandbox
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v74/github"
+	mcpClient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// sandbox runs tool handlers in-process against a real organization reserved for testing. Every flow creates its
+// own private repository in the organization and deletes it when the test ends, so flows can run in parallel and
+// a failed run leaves nothing behind but, at worst, repositories prefixed with "mcp-sandbox-".
+type sandbox struct {
+	org    string
+	client *mcpClient.Client
+	rest   *gogithub.Client
+}
+
+// newSandbox starts an in-process server with every toolset enabled, configured from the environment:
+//
+//   - GITHUB_MCP_SERVER_SANDBOX_TOKEN: token with admin access to the organization (required)
+//   - GITHUB_MCP_SERVER_SANDBOX_ORG: login of the organization (required)
+//   - GITHUB_MCP_SERVER_SANDBOX_HOST: GitHub host, for GitHub Enterprise (optional)
+func newSandbox(t *testing.T) *sandbox {
+	t.Helper()
+
+	token := os.Getenv("GITHUB_MCP_SERVER_SANDBOX_TOKEN")
+	if token == "" {
+		t.Fatalf("GITHUB_MCP_SERVER_SANDBOX_TOKEN environment variable is not set")
+	}
+	org := os.Getenv("GITHUB_MCP_SERVER_SANDBOX_ORG")
+	if org == "" {
+		t.Fatalf("GITHUB_MCP_SERVER_SANDBOX_ORG environment variable is not set")
+	}
+	host := os.Getenv("GITHUB_MCP_SERVER_SANDBOX_HOST")
+
+	ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
+		Version:         "sandbox",
+		Host:            host,
+		Token:           token,
+		EnabledToolsets: []string{"all"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err, "expected to construct MCP server successfully")
+
+	client, err := mcpClient.NewInProcessClient(ghServer)
+	require.NoError(t, err, "expected to create in-process client successfully")
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(), "expected to close client successfully")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = "2025-03-26"
+	request.Params.ClientInfo = mcp.Implementation{
+		Name:    "sandbox-test-client",
+		Version: "0.0.1",
+	}
+	_, err = client.Initialize(ctx, request)
+	require.NoError(t, err, "failed to initialize client")
+
+	rest := gogithub.NewClient(nil).WithAuthToken(token)
+	if host != "" && host != "https://github.com" {
+		rest, err = rest.WithEnterpriseURLs(host, host)
+		require.NoError(t, err, "expected to create GitHub client with host")
+	}
+
+	return &sandbox{org: org, client: client, rest: rest}
+}
+
+// callTool calls a tool and returns the text of its result, failing the test if the tool reports an error. Tools
+// returning resources lead with a text summary, which is what is returned for them.
+func (s *sandbox) callTool(t *testing.T, name string, args map[string]any) string {
+	t.Helper()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+
+	t.Logf("Calling %s...", name)
+	result, err := s.client.CallTool(context.Background(), request)
+	require.NoError(t, err, "expected to call '%s' tool successfully", name)
+	require.NotEmpty(t, result.Content, "expected '%s' to return content", name)
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "expected '%s' to return text content", name)
+	require.False(t, result.IsError, "expected '%s' not to return an error: %s", name, textContent.Text)
+
+	return textContent.Text
+}
+
+// callToolJSON calls a tool and unmarshals the text of its result into v.
+func (s *sandbox) callToolJSON(t *testing.T, name string, args map[string]any, v any) {
+	t.Helper()
+	require.NoError(t, json.Unmarshal([]byte(s.callTool(t, name, args)), v), "expected '%s' to return JSON", name)
+}
+
+// createRepo creates a private repository with a README in the organization, deleted when the test ends.
+func (s *sandbox) createRepo(t *testing.T) string {
+	t.Helper()
+
+	repo := fmt.Sprintf("mcp-sandbox-%s-%d", t.Name(), time.Now().UnixMilli())
+	s.callTool(t, "create_repository", map[string]any{
+		"name":         repo,
+		"organization": s.org,
+		"private":      true,
+		"autoInit":     true,
+	})
+
+	// The MCP server doesn't delete repositories, so cleanup goes through the REST client
+	t.Cleanup(func() {
+		t.Logf("Deleting repository %s/%s...", s.org, repo)
+		_, err := s.rest.Repositories.Delete(context.Background(), s.org, repo)
+		require.NoError(t, err, "expected to delete repository successfully")
+	})
+
+	return repo
+}
+
+// numberFromURL returns the issue or pull request number at the end of its URL.
+func numberFromURL(t *testing.T, url string) int {
+	t.Helper()
+	number, err := strconv.Atoi(path.Base(url))
+	require.NoError(t, err, "expected %q to end with a number", url)
+	return number
+}
+
+func TestSandboxRepositoryFlow(t *testing.T) {
+	t.Parallel()
+
+	s := newSandbox(t)
+	ctx := context.Background()
+	repo := s.createRepo(t)
+
+	// Create a branch and a file on it
+	s.callTool(t, "create_branch", map[string]any{
+		"owner":  s.org,
+		"repo":   repo,
+		"branch": "sandbox",
+	})
+	s.callTool(t, "create_or_update_file", map[string]any{
+		"owner":   s.org,
+		"repo":    repo,
+		"path":    "docs/sandbox.md",
+		"content": "first version\n",
+		"message": "Add sandbox file",
+		"branch":  "sandbox",
+	})
+
+	// Modify it with the SHA of the current version
+	file, _, resp, err := s.rest.Repositories.GetContents(ctx, s.org, repo, "docs/sandbox.md", &gogithub.RepositoryContentGetOptions{Ref: "sandbox"})
+	require.NoError(t, err, "expected to get file contents successfully")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	s.callTool(t, "create_or_update_file", map[string]any{
+		"owner":   s.org,
+		"repo":    repo,
+		"path":    "docs/sandbox.md",
+		"content": "second version\n",
+		"message": "Update sandbox file",
+		"branch":  "sandbox",
+		"sha":     file.GetSHA(),
+	})
+
+	// Both the tool and the API see the new version on the branch only
+	resource := s.callTool(t, "get_file_contents", map[string]any{
+		"owner": s.org,
+		"repo":  repo,
+		"path":  "docs/sandbox.md",
+		"ref":   "refs/heads/sandbox",
+	})
+	require.Contains(t, resource, "successfully downloaded text file", "expected get_file_contents to find the file")
+
+	file, _, _, err = s.rest.Repositories.GetContents(ctx, s.org, repo, "docs/sandbox.md", &gogithub.RepositoryContentGetOptions{Ref: "sandbox"})
+	require.NoError(t, err, "expected to get file contents successfully")
+	content, err := file.GetContent()
+	require.NoError(t, err, "expected to decode file contents successfully")
+	require.Equal(t, "second version\n", content)
+
+	_, _, resp, err = s.rest.Repositories.GetContents(ctx, s.org, repo, "docs/sandbox.md", nil)
+	require.Error(t, err, "expected the file not to exist on the default branch")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSandboxIssueFlow(t *testing.T) {
+	t.Parallel()
+
+	s := newSandbox(t)
+	ctx := context.Background()
+	repo := s.createRepo(t)
+
+	var created struct {
+		URL string `json:"url"`
+	}
+	s.callToolJSON(t, "issue_write", map[string]any{
+		"method": "create",
+		"owner":  s.org,
+		"repo":   repo,
+		"title":  "Sandbox issue",
+		"body":   "Created by the sandbox tests",
+	}, &created)
+	issueNumber := numberFromURL(t, created.URL)
+
+	s.callTool(t, "add_issue_comment", map[string]any{
+		"owner":        s.org,
+		"repo":         repo,
+		"issue_number": issueNumber,
+		"body":         "Sandbox comment",
+	})
+	s.callTool(t, "issue_write", map[string]any{
+		"method":       "update",
+		"owner":        s.org,
+		"repo":         repo,
+		"issue_number": issueNumber,
+		"title":        "Sandbox issue (done)",
+		"state":        "closed",
+		"state_reason": "completed",
+	})
+
+	issue, _, err := s.rest.Issues.Get(ctx, s.org, repo, issueNumber)
+	require.NoError(t, err, "expected to get issue successfully")
+	require.Equal(t, "Sandbox issue (done)", issue.GetTitle())
+	require.Equal(t, "closed", issue.GetState())
+	require.Equal(t, "completed", issue.GetStateReason())
+	require.Equal(t, 1, issue.GetComments())
+
+	var comments []struct {
+		Body string `json:"body"`
+	}
+	s.callToolJSON(t, "issue_read", map[string]any{
+		"method":       "get_comments",
+		"owner":        s.org,
+		"repo":         repo,
+		"issue_number": issueNumber,
+	}, &comments)
+	require.Len(t, comments, 1)
+	require.Equal(t, "Sandbox comment", comments[0].Body)
+}
+
+func TestSandboxPullRequestFlow(t *testing.T) {
+	t.Parallel()
+
+	s := newSandbox(t)
+	ctx := context.Background()
+	repo := s.createRepo(t)
+
+	s.callTool(t, "create_branch", map[string]any{
+		"owner":  s.org,
+		"repo":   repo,
+		"branch": "sandbox",
+	})
+	s.callTool(t, "create_or_update_file", map[string]any{
+		"owner":   s.org,
+		"repo":    repo,
+		"path":    "sandbox.txt",
+		"content": "pull request change\n",
+		"message": "Add sandbox change",
+		"branch":  "sandbox",
+	})
+
+	// The default branch name follows the organization's settings
+	repository, _, err := s.rest.Repositories.Get(ctx, s.org, repo)
+	require.NoError(t, err, "expected to get repository successfully")
+
+	var created struct {
+		URL string `json:"url"`
+	}
+	s.callToolJSON(t, "create_pull_request", map[string]any{
+		"owner": s.org,
+		"repo":  repo,
+		"title": "Sandbox pull request",
+		"head":  "sandbox",
+		"base":  repository.GetDefaultBranch(),
+	}, &created)
+	pullNumber := numberFromURL(t, created.URL)
+
+	s.callTool(t, "update_pull_request", map[string]any{
+		"owner":      s.org,
+		"repo":       repo,
+		"pullNumber": pullNumber,
+		"title":      "Sandbox pull request (ready)",
+		"body":       "Updated by the sandbox tests",
+	})
+	s.callTool(t, "merge_pull_request", map[string]any{
+		"owner":        s.org,
+		"repo":         repo,
+		"pullNumber":   pullNumber,
+		"merge_method": "squash",
+	})
+
+	pr, _, err := s.rest.PullRequests.Get(ctx, s.org, repo, pullNumber)
+	require.NoError(t, err, "expected to get pull request successfully")
+	require.Equal(t, "Sandbox pull request (ready)", pr.GetTitle())
+	require.True(t, pr.GetMerged(), "expected the pull request to be merged")
+
+	_, _, _, err = s.rest.Repositories.GetContents(ctx, s.org, repo, "sandbox.txt", nil)
+	require.NoError(t, err, "expected the change to be on the default branch")
+}
+
+// TestSandboxProjectFlow needs GITHUB_MCP_SERVER_SANDBOX_PROJECT, the number of a project of the organization the
+// test can add items to and remove them from.
+func TestSandboxProjectFlow(t *testing.T) {
+	t.Parallel()
+
+	projectNumber, err := strconv.Atoi(os.Getenv("GITHUB_MCP_SERVER_SANDBOX_PROJECT"))
+	if err != nil {
+		t.Skip("Skipping test because GITHUB_MCP_SERVER_SANDBOX_PROJECT is not set to a project number")
+	}
+
+	s := newSandbox(t)
+	ctx := context.Background()
+	repo := s.createRepo(t)
+
+	var created struct {
+		URL string `json:"url"`
+	}
+	s.callToolJSON(t, "issue_write", map[string]any{
+		"method": "create",
+		"owner":  s.org,
+		"repo":   repo,
+		"title":  "Sandbox project item",
+	}, &created)
+	issue, _, err := s.rest.Issues.Get(ctx, s.org, repo, numberFromURL(t, created.URL))
+	require.NoError(t, err, "expected to get issue successfully")
+
+	var item struct {
+		ID int64 `json:"id"`
+	}
+	s.callToolJSON(t, "add_project_item", map[string]any{
+		"owner_type":     "org",
+		"owner":          s.org,
+		"project_number": projectNumber,
+		"item_type":      "issue",
+		"item_id":        issue.GetID(),
+	}, &item)
+	require.NotZero(t, item.ID, "expected the project item to have an ID")
+
+	var fetched struct {
+		ID          int64  `json:"id"`
+		ContentType string `json:"content_type"`
+	}
+	s.callToolJSON(t, "get_project_item", map[string]any{
+		"owner_type":     "org",
+		"owner":          s.org,
+		"project_number": projectNumber,
+		"item_id":        item.ID,
+	}, &fetched)
+	require.Equal(t, item.ID, fetched.ID)
+	require.Equal(t, "Issue", fetched.ContentType)
+
+	s.callTool(t, "delete_project_item", map[string]any{
+		"owner_type":     "org",
+		"owner":          s.org,
+		"project_number": projectNumber,
+		"item_id":        item.ID,
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_project_item"
+	request.Params.Arguments = map[string]any{
+		"owner_type":     "org",
+		"owner":          s.org,
+		"project_number": projectNumber,
+		"item_id":        item.ID,
+	}
+	result, err := s.client.CallTool(ctx, request)
+	require.NoError(t, err, "expected to call 'get_project_item' tool successfully")
+	require.True(t, result.IsError, "expected the deleted project item not to be found")
+}
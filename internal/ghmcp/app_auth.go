@@ -0,0 +1,100 @@
+package ghmcp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is how long the JSON Web Tokens authenticating as a GitHub App are valid. GitHub rejects
+	// tokens valid for more than 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the tokens to allow for clock drift between the server and GitHub.
+	appJWTClockSkew = time.Minute
+)
+
+// parseAppPrivateKey parses the PEM encoded private key of a GitHub App, which GitHub generates in PKCS #1 form.
+func parseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// appAuthTransport authenticates requests as a GitHub App, with a JSON Web Token signed by the app's private
+// key. The token is reused until shortly before it expires.
+type appAuthTransport struct {
+	transport http.RoundTripper
+	appID     int64
+	key       *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (t *appAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.jwt(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
+
+// jwt returns a token valid for at least another minute at now.
+func (t *appAuthTransport) jwt(now time.Time) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && now.Add(time.Minute).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	expiresAt := now.Add(appJWTLifetime)
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": expiresAt.Unix(),
+		"iss": strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App token: %w", err)
+	}
+
+	t.token = signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	t.expiresAt = expiresAt
+	return t.token, nil
+}
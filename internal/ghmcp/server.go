@@ -18,6 +18,7 @@ import (
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -35,6 +36,11 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// AppID and AppPrivateKey are the credentials of a GitHub App, used to mint installation tokens.
+	// Both are optional.
+	AppID         int64
+	AppPrivateKey []byte
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -49,8 +55,43 @@ type MCPServerConfig struct {
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 
+	// ToolOverrides replace the descriptions and annotations of tools, keyed by tool name
+	ToolOverrides map[string]toolsets.ToolOverride
+
 	// Content window size
 	ContentWindowSize int
+
+	// Logger, if set, receives the estimated token cost of the enabled toolsets at startup and the stacks of
+	// panics recovered from tool handlers. Without it, panics are logged to the default logger.
+	Logger *slog.Logger
+
+	// InFlightCalls, if set, tracks the running tool calls, so they can be drained on shutdown
+	InFlightCalls *github.InFlightCalls
+
+	// Journal, if set, records the calls of tools that change GitHub and enables the get_recent_operations tool
+	Journal *github.Journal
+
+	// OwnerPolicy, if set, rejects tool calls on owners and repositories it doesn't allow
+	OwnerPolicy *github.OwnerPolicy
+
+	// WriteLimiter, if set, rejects calls of tools that change GitHub once they exceed its limits
+	WriteLimiter *github.WriteLimiter
+
+	// SearchCache, if set, answers repeated searches from its cache
+	SearchCache *github.SearchCache
+
+	// SecondaryRateLimiter, if set, pauses all requests to GitHub once one of them hits a secondary rate limit
+	SecondaryRateLimiter *github.SecondaryRateLimiter
+
+	// OfflineQueue, if set, queues the comments and label changes that fail while GitHub is unavailable and enables
+	// the list_pending_operations tool. Its operations are only retried while its Run method runs.
+	OfflineQueue *github.OfflineQueue
+
+	// RequestHeaders, if set, adds extra headers to the requests to GitHub, globally and per toolset
+	RequestHeaders *github.RequestHeaders
+
+	// ResultFormat is how the JSON results of tools are written. Empty is compact.
+	ResultFormat github.ResultFormat
 }
 
 const stdioServerLogPrefix = "stdioserver"
@@ -61,8 +102,17 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	// All clients share the transport, so a secondary rate limit hit by one pauses the requests of all of them
+	var transport http.RoundTripper = http.DefaultTransport
+	if cfg.RequestHeaders != nil {
+		transport = cfg.RequestHeaders.Transport(transport)
+	}
+	if cfg.SecondaryRateLimiter != nil {
+		transport = cfg.SecondaryRateLimiter.Transport(transport)
+	}
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{Transport: transport}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -72,12 +122,34 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: transport,
 			token:     cfg.Token,
 		},
 	} // We're going to wrap the Transport later in beforeInit
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
 
+	// Construct a REST client authenticated as the GitHub App, if one is configured
+	var appClient *gogithub.Client
+	if cfg.AppID != 0 || len(cfg.AppPrivateKey) > 0 {
+		if cfg.AppID == 0 || len(cfg.AppPrivateKey) == 0 {
+			return nil, fmt.Errorf("both the GitHub App ID and private key must be provided")
+		}
+		key, err := parseAppPrivateKey(cfg.AppPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+		}
+		appClient = gogithub.NewClient(&http.Client{
+			Transport: &appAuthTransport{
+				transport: transport,
+				appID:     cfg.AppID,
+				key:       key,
+			},
+		})
+		appClient.UserAgent = restClient.UserAgent
+		appClient.BaseURL = apiHost.baseRESTURL
+		appClient.UploadURL = apiHost.uploadURL
+	}
+
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
 		userAgent := fmt.Sprintf(
@@ -88,6 +160,9 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		)
 
 		restClient.UserAgent = userAgent
+		if appClient != nil {
+			appClient.UserAgent = userAgent
+		}
 
 		gqlHTTPClient.Transport = &userAgentTransport{
 			transport: gqlHTTPClient.Transport,
@@ -132,10 +207,56 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// Generate instructions based on enabled toolsets
 	instructions := github.GenerateInstructions(enabledToolsets)
 
-	ghServer := github.NewServer(cfg.Version,
+	panicLogger := cfg.Logger
+	if panicLogger == nil {
+		panicLogger = slog.Default()
+	}
+
+	// Middlewares run in the order they are added, the first one outermost. Panics are recovered first so that a
+	// panic in any of the others doesn't kill the server either.
+	serverOpts := []server.ServerOption{
 		server.WithInstructions(instructions),
 		server.WithHooks(hooks),
-	)
+		server.WithToolHandlerMiddleware(github.RecoverToolPanics(panicLogger)),
+	}
+	// Queued calls are retried through the middlewares added after the queue, so that retries are checked again
+	if cfg.OfflineQueue != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.OfflineQueue.Middleware()))
+	}
+	if cfg.InFlightCalls != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.InFlightCalls.Middleware()))
+	}
+	if cfg.ResultFormat != "" && cfg.ResultFormat != github.ResultFormatCompact {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.UseResultFormat(cfg.ResultFormat)))
+	}
+	if cfg.OwnerPolicy != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.OwnerPolicy.Middleware()))
+	}
+	if cfg.SearchCache != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.SearchCache.Middleware()))
+	}
+	// The tools that change GitHub are only known once the toolsets are created below, before the server starts
+	writeTools := map[string]bool{}
+	if cfg.WriteLimiter != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.WriteLimiter.Middleware(
+			func(tool string) bool { return writeTools[tool] },
+		)))
+	}
+	if cfg.Journal != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.Journal.Middleware(
+			func(tool string) bool { return writeTools[tool] },
+			func(err error) { panicLogger.Error("failed to record tool call in journal", "error", err) },
+		)))
+	}
+	// Like the write tools, the toolset of each tool is only known once the toolsets are created
+	toolToolsets := map[string]string{}
+	if cfg.RequestHeaders != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(cfg.RequestHeaders.Middleware(
+			func(tool string) string { return toolToolsets[tool] },
+		)))
+	}
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
 
 	getClient := func(_ context.Context) (*gogithub.Client, error) {
 		return restClient, nil // closing over client
@@ -153,14 +274,62 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
+	var getAppClient github.GetClientFn
+	if appClient != nil {
+		getAppClient = func(_ context.Context) (*gogithub.Client, error) {
+			return appClient, nil // closing over client
+		}
+	}
+
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, getAppClient, cfg.Translator, cfg.ContentWindowSize)
 	err = tsg.EnableToolsets(enabledToolsets, nil)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
+	if err := tsg.ApplyToolOverrides(cfg.ToolOverrides); err != nil {
+		return nil, fmt.Errorf("failed to apply tool overrides: %w", err)
+	}
+
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if readOnly := tool.Tool.Annotations.ReadOnlyHint; readOnly == nil || !*readOnly {
+				writeTools[tool.Tool.Name] = true
+			}
+			toolToolsets[tool.Tool.Name] = name
+		}
+	}
+
+	if cfg.RequestHeaders != nil {
+		for _, id := range cfg.RequestHeaders.Toolsets() {
+			if _, err := tsg.GetToolset(id); err != nil {
+				return nil, fmt.Errorf("failed to apply HTTP headers: %w", err)
+			}
+		}
+	}
+
+	if cfg.Journal != nil {
+		contextToolset, err := tsg.GetToolset(github.ToolsetMetadataContext.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add journal tool: %w", err)
+		}
+		contextToolset.AddReadTools(toolsets.NewServerTool(github.GetRecentOperations(cfg.Journal, cfg.Translator)))
+	}
+
+	if cfg.OfflineQueue != nil {
+		contextToolset, err := tsg.GetToolset(github.ToolsetMetadataContext.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add offline queue tool: %w", err)
+		}
+		contextToolset.AddReadTools(toolsets.NewServerTool(github.ListPendingOperations(cfg.OfflineQueue, cfg.Translator)))
+	}
+
+	if cfg.Logger != nil {
+		logTokenEstimates(cfg.Logger, tsg)
+	}
+
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
 
@@ -172,6 +341,21 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	return ghServer, nil
 }
 
+// logTokenEstimates logs the estimated number of tokens the tool definitions of each enabled toolset take up in the
+// context of a model, so the cost of a configuration can be seen without calling get_tool_catalog.
+func logTokenEstimates(logger *slog.Logger, tsg *toolsets.ToolsetGroup) {
+	var tools, tokens int
+	for _, estimate := range tsg.TokenEstimates() {
+		if !estimate.Enabled {
+			continue
+		}
+		logger.Info("toolset token estimate", "toolset", estimate.Name, "tools", len(estimate.Tools), "estimatedTokens", estimate.Tokens)
+		tools += len(estimate.Tools)
+		tokens += estimate.Tokens
+	}
+	logger.Info("total token estimate", "tools", tools, "estimatedTokens", tokens)
+}
+
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
@@ -182,6 +366,11 @@ type StdioServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// AppID and AppPrivateKey are the credentials of a GitHub App, used to mint installation tokens.
+	// Both are optional.
+	AppID         int64
+	AppPrivateKey []byte
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -193,6 +382,14 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// Locale selects the translations of the tool titles and descriptions, read from the
+	// <Locale>.json file in LocalesDir. English is used if empty.
+	Locale     string
+	LocalesDir string
+
+	// ToolOverrides replace the descriptions and annotations of tools, keyed by tool name
+	ToolOverrides map[string]toolsets.ToolOverride
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -205,32 +402,59 @@ type StdioServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// ShutdownTimeout is how long to wait for running tool calls to finish, and to retry the queued operations, after
+	// a shutdown signal.
+	// Calls still running after it are logged as abandoned.
+	ShutdownTimeout time.Duration
+
+	// JournalFile, if set, is the path of the journal of calls of tools that change GitHub, which keeps
+	// JournalMaxEntries entries.
+	JournalFile       string
+	JournalMaxEntries int
+
+	// AllowOwners, DenyOwners, AllowRepos and DenyRepos are glob patterns of the owners and owner/repo
+	// repositories tools may be called on. Denying takes precedence, and everything is allowed if none are set.
+	AllowOwners []string
+	DenyOwners  []string
+	AllowRepos  []string
+	DenyRepos   []string
+
+	// WriteLimits caps the calls of tools that change GitHub. Zero values disable the limits.
+	WriteLimits github.WriteLimits
+
+	// SearchCacheTTL is how long the results of searches are cached. Zero disables the cache.
+	SearchCacheTTL time.Duration
+
+	// SecondaryRateLimitMaxWait is the longest a request waits for the pause of requests after a secondary rate
+	// limit to end, rather than failing at once.
+	SecondaryRateLimitMaxWait time.Duration
+
+	// OfflineQueueRetryInterval is how often comments and label changes that failed while GitHub was unavailable
+	// are retried. Zero disables the queue.
+	OfflineQueueRetryInterval time.Duration
+
+	// HTTPHeaders are extra headers to send with the requests to GitHub, globally and per toolset
+	HTTPHeaders github.HTTPHeaders
+
+	// OutputFormat is how the JSON results of tools are written: compact, pretty or markdown. Empty is compact.
+	OutputFormat string
 }
 
 // RunStdioServer is not concurrent safe.
 func RunStdioServer(cfg StdioServerConfig) error {
-	// Create app context
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	// The signal context only tells us when to shut down. Tool calls run on the server context, which is cancelled
+	// after running calls had the chance to finish, so a signal doesn't cut off a write halfway.
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	t, dumpTranslations := translations.TranslationHelper()
-
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-	})
+	t, dumpTranslations, err := translations.LocaleTranslationHelper(cfg.Locale, cfg.LocalesDir)
 	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
+		return fmt.Errorf("failed to load translations: %w", err)
 	}
 
-	stdioServer := server.NewStdioServer(ghServer)
-
 	var slogHandler slog.Handler
 	var logOutput io.Writer
 	if cfg.LogFilePath != "" {
@@ -238,6 +462,7 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		if err != nil {
 			return fmt.Errorf("failed to open log file: %w", err)
 		}
+		defer func() { _ = file.Close() }()
 		logOutput = file
 		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelDebug})
 	} else {
@@ -246,6 +471,61 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
+
+	ownerPolicy, err := github.NewOwnerPolicy(cfg.AllowOwners, cfg.DenyOwners, cfg.AllowRepos, cfg.DenyRepos)
+	if err != nil {
+		return fmt.Errorf("failed to parse owner policy: %w", err)
+	}
+
+	var journal *github.Journal
+	if cfg.JournalFile != "" {
+		journal, err = github.OpenJournal(cfg.JournalFile, cfg.JournalMaxEntries)
+		if err != nil {
+			return err
+		}
+	}
+
+	offlineQueue := github.NewOfflineQueue(cfg.OfflineQueueRetryInterval)
+
+	requestHeaders, err := github.NewRequestHeaders(cfg.HTTPHeaders)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTTP headers: %w", err)
+	}
+
+	resultFormat, err := github.ParseResultFormat(cfg.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	inFlight := github.NewInFlightCalls()
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:              cfg.Version,
+		Host:                 cfg.Host,
+		Token:                cfg.Token,
+		AppID:                cfg.AppID,
+		AppPrivateKey:        cfg.AppPrivateKey,
+		EnabledToolsets:      cfg.EnabledToolsets,
+		DynamicToolsets:      cfg.DynamicToolsets,
+		ReadOnly:             cfg.ReadOnly,
+		Translator:           t,
+		ToolOverrides:        cfg.ToolOverrides,
+		ContentWindowSize:    cfg.ContentWindowSize,
+		Logger:               logger,
+		InFlightCalls:        inFlight,
+		Journal:              journal,
+		OwnerPolicy:          ownerPolicy,
+		WriteLimiter:         github.NewWriteLimiter(cfg.WriteLimits),
+		SearchCache:          github.NewSearchCache(cfg.SearchCacheTTL),
+		SecondaryRateLimiter: github.NewSecondaryRateLimiter(cfg.SecondaryRateLimitMaxWait),
+		OfflineQueue:         offlineQueue,
+		RequestHeaders:       requestHeaders,
+		ResultFormat:         resultFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
@@ -268,13 +548,25 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		errC <- stdioServer.Listen(ctx, in, out)
 	}()
 
+	if offlineQueue != nil {
+		go offlineQueue.Run(ctx, logger)
+	}
+
 	// Output github-mcp-server string
 	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
 
 	// Wait for shutdown signal
 	select {
-	case <-ctx.Done():
-		logger.Info("shutting down server", "signal", "context done")
+	case <-signalCtx.Done():
+		// Restore the default signal handling, so a second signal kills the server without waiting
+		stop()
+		logger.Info("shutting down server", "signal", "context done", "timeout", cfg.ShutdownTimeout)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		drainInFlightCalls(shutdownCtx, logger, inFlight)
+		if offlineQueue != nil {
+			flushOfflineQueue(shutdownCtx, logger, offlineQueue, journal)
+		}
 	case err := <-errC:
 		if err != nil {
 			logger.Error("error running server", "error", err)
@@ -285,6 +577,38 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
+// drainInFlightCalls stops accepting tool calls and waits until ctx is done for the running ones to finish. Calls that
+// are still running are logged, so that partially applied multi-step writes leave a record.
+func drainInFlightCalls(ctx context.Context, logger *slog.Logger, inFlight *github.InFlightCalls) {
+	abandoned := inFlight.Drain(ctx)
+	for _, call := range abandoned {
+		logger.Warn("abandoning running tool call", "tool", call.Tool, "startedAt", call.StartedAt, "runningFor", time.Since(call.StartedAt))
+	}
+	logger.Info("server stopped", "abandonedCalls", len(abandoned))
+}
+
+// flushOfflineQueue retries the queued operations a last time until ctx is done. The operations still pending are
+// given up: each is logged with its arguments and recorded in the journal, if any, so that it can be redone once
+// GitHub is available again.
+func flushOfflineQueue(ctx context.Context, logger *slog.Logger, queue *github.OfflineQueue, journal *github.Journal) {
+	for _, operation := range queue.Flush(ctx, logger) {
+		logger.Warn("dropping queued operation that GitHub was unavailable for", "id", operation.ID, "tool", operation.Tool, "arguments", operation.Arguments, "queuedAt", operation.QueuedAt, "lastError", operation.LastError)
+		if journal == nil {
+			continue
+		}
+		err := journal.Record(github.JournalEntry{
+			Time:      time.Now().UTC(),
+			Tool:      operation.Tool,
+			Arguments: operation.Arguments,
+			IsError:   true,
+			Result:    fmt.Sprintf("dropped from the offline queue when the server stopped, after %d attempts: %s", operation.Attempts, operation.LastError),
+		})
+		if err != nil {
+			logger.Error("failed to record dropped queued operation in the journal", "id", operation.ID, "error", err)
+		}
+	}
+}
+
 type apiHost struct {
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
@@ -2,9 +2,9 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -73,12 +73,7 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflows)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, workflows), nil
 		}
 }
 
@@ -212,12 +207,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflowRuns)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, workflowRuns), nil
 		}
 }
 
@@ -311,12 +301,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				"status_code":   resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -367,12 +352,7 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflowRun)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, workflowRun), nil
 		}
 }
 
@@ -433,12 +413,7 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				"optimization_tip": "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -521,12 +496,7 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				"optimization_tip": "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first",
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -650,8 +620,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 			"total_jobs":  len(jobs.Jobs),
 			"failed_jobs": 0,
 		}
-		r, _ := json.Marshal(result)
-		return mcp.NewToolResultText(string(r)), nil
+		return MarshalledTextResult(ctx, result), nil
 	}
 
 	// Collect logs for all failed jobs
@@ -681,12 +650,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		"return_format": map[string]bool{"content": returnContent, "urls": !returnContent},
 	}
 
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, result), nil
 }
 
 // handleSingleJobLogs gets logs for a single job
@@ -696,12 +660,7 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil
 	}
 
-	r, err := json.Marshal(jobResult)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, jobResult), nil
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
@@ -832,12 +791,7 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -895,12 +849,7 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -960,12 +909,7 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -1029,12 +973,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(artifacts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, artifacts), nil
 		}
 }
 
@@ -1094,12 +1033,7 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				"artifact_id":  artifactID,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -1158,12 +1092,7 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 				"status_code": resp.StatusCode,
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -1214,11 +1143,885 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(usage)
+			return MarshalledTextResult(ctx, usage), nil
+		}
+}
+
+// RepositorySecretsInventory lists the names of Actions secrets and variables defined on a repository.
+type RepositorySecretsInventory struct {
+	Repository string   `json:"repository"`
+	Secrets    []string `json:"secrets"`
+	Variables  []string `json:"variables"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// listAllRepoSecretNames pages through all Actions secrets of a repository and returns their names.
+func listAllRepoSecretNames(ctx context.Context, client *github.Client, owner, repo string) ([]string, error) {
+	names := []string{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		secrets, resp, err := client.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, s := range secrets.Secrets {
+			names = append(names, s.Name)
+		}
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listAllRepoVariableNames pages through all Actions variables of a repository and returns their names.
+func listAllRepoVariableNames(ctx context.Context, client *github.Client, owner, repo string) ([]string, error) {
+	names := []string{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		variables, resp, err := client.Actions.ListRepoVariables(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list variables: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, v := range variables.Variables {
+			names = append(names, v.Name)
+		}
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListOrgSecretsInventory creates a tool to enumerate which repositories in an organization define which
+// Actions secrets and variables. Only names are returned, never values.
+func ListOrgSecretsInventory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_secrets_inventory",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SECRETS_INVENTORY_DESCRIPTION", "List the names of repository-level GitHub Actions secrets and variables for each repository in an organization. Secret and variable values are never returned. Pagination applies to the organization's repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_SECRETS_INVENTORY_USER_TITLE", "List organization secrets and variables inventory"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Type of repositories to include"),
+				mcp.Enum("all", "public", "private", "forks", "sources", "member"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, &github.RepositoryListByOrgOptions{
+				Type: repoType,
+				Sort: "full_name",
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			repos = slices.DeleteFunc(repos, func(repo *github.Repository) bool {
+				return !repositoryAllowed(ctx, org, repo.GetName())
+			})
+
+			inventory := make([]RepositorySecretsInventory, len(repos))
+			errs := fanOut(ctx, repos, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo *github.Repository) error {
+				secrets, err := listAllRepoSecretNames(ctx, client, org, repo.GetName())
+				if err != nil {
+					return err
+				}
+				variables, err := listAllRepoVariableNames(ctx, client, org, repo.GetName())
+				if err != nil {
+					return err
+				}
+				inventory[i].Secrets = secrets
+				inventory[i].Variables = variables
+				return nil
+			})
+			for i, repo := range repos {
+				inventory[i].Repository = repo.GetName()
+				if errs[i] != nil {
+					inventory[i].Error = errs[i].Error()
+				}
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"org":          org,
+				"repositories": inventory,
+				"page":         pagination.Page,
+				"hasNextPage":  resp.NextPage != 0,
+			}), nil
+		}
+}
+
+// MinimalActionsSecret is the trimmed output type for Actions secrets, which never include their value.
+type MinimalActionsSecret struct {
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// MinimalActionsVariable is the trimmed output type for Actions variables.
+type MinimalActionsVariable struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ListActionsSecrets creates a tool to list the Actions secrets of a repository or of one of its environments.
+// Only names and timestamps are returned, never values.
+func ListActionsSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_actions_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ACTIONS_SECRETS_DESCRIPTION", "List the GitHub Actions secrets of a repository, or of one of its deployment environments, with when they were created and last updated. Secret values are never returned. A job running in an environment sees its secrets in addition to the repository ones, and an environment secret takes precedence over a repository secret with the same name.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ACTIONS_SECRETS_USER_TITLE", "List Actions secrets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("List the secrets of this deployment environment instead of the repository-level ones"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var secrets *github.Secrets
+			var resp *github.Response
+			if environment == "" {
+				secrets, resp, err = client.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list repository secrets",
+						resp,
+						err,
+					), nil
+				}
+			} else {
+				// The environment secrets endpoint takes the ID of the repository rather than its name
+				repository, repoResp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+						repoResp,
+						err,
+					), nil
+				}
+				_ = repoResp.Body.Close()
+
+				secrets, resp, err = client.Actions.ListEnvSecrets(ctx, int(repository.GetID()), environment, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list secrets of environment '%s'", environment),
+						resp,
+						err,
+					), nil
+				}
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalActionsSecret, 0, len(secrets.Secrets))
+			for _, secret := range secrets.Secrets {
+				result = append(result, MinimalActionsSecret{
+					Name:      secret.Name,
+					CreatedAt: formatOptionalTimestamp(&secret.CreatedAt),
+					UpdatedAt: formatOptionalTimestamp(&secret.UpdatedAt),
+				})
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count": secrets.TotalCount,
+				"secrets":     result,
+			}), nil
+		}
+}
+
+// ListActionsVariables creates a tool to list the Actions variables of a repository or of one of its environments,
+// with their values.
+func ListActionsVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_actions_variables",
+			mcp.WithDescription(t("TOOL_LIST_ACTIONS_VARIABLES_DESCRIPTION", "List the GitHub Actions configuration variables of a repository, or of one of its deployment environments, with their values. A job running in an environment sees its variables in addition to the repository ones, and an environment variable takes precedence over a repository variable with the same name.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ACTIONS_VARIABLES_USER_TITLE", "List Actions variables"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("List the variables of this deployment environment instead of the repository-level ones"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var variables *github.ActionsVariables
+			var resp *github.Response
+			if environment == "" {
+				variables, resp, err = client.Actions.ListRepoVariables(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list repository variables",
+						resp,
+						err,
+					), nil
+				}
+			} else {
+				variables, resp, err = client.Actions.ListEnvVariables(ctx, owner, repo, environment, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list variables of environment '%s'", environment),
+						resp,
+						err,
+					), nil
+				}
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalActionsVariable, 0, len(variables.Variables))
+			for _, variable := range variables.Variables {
+				result = append(result, MinimalActionsVariable{
+					Name:      variable.Name,
+					Value:     variable.Value,
+					CreatedAt: formatOptionalTimestamp(variable.CreatedAt),
+					UpdatedAt: formatOptionalTimestamp(variable.UpdatedAt),
+				})
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count": variables.TotalCount,
+				"variables":   result,
+			}), nil
+		}
+}
+
+// GetOIDCSubjectClaim creates a tool to get the OIDC subject claim customization of an organization or repository
+func GetOIDCSubjectClaim(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_oidc_subject_claim",
+			mcp.WithDescription(t("TOOL_GET_OIDC_SUBJECT_CLAIM_DESCRIPTION", "Get the customization template of the OIDC subject claim used by GitHub Actions when federating with cloud providers. Returns the repository template when 'repo' is provided, the organization template otherwise.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_OIDC_SUBJECT_CLAIM_USER_TITLE", "Get OIDC subject claim customization"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization name, or repository owner when 'repo' is provided"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var template *github.OIDCSubjectClaimCustomTemplate
+			var resp *github.Response
+			if repo != "" {
+				template, resp, err = client.Actions.GetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo)
+			} else {
+				template, resp, err = client.Actions.GetOrgOIDCSubjectClaimCustomTemplate(ctx, owner)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get OIDC subject claim customization", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, template), nil
+		}
+}
+
+// SetOIDCSubjectClaim creates a tool to set the OIDC subject claim customization of an organization or repository
+func SetOIDCSubjectClaim(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_oidc_subject_claim",
+			mcp.WithDescription(t("TOOL_SET_OIDC_SUBJECT_CLAIM_DESCRIPTION", "Set the customization template of the OIDC subject claim used by GitHub Actions. Changing the subject claim can break existing cloud provider trust policies. Updates the repository template when 'repo' is provided, the organization template otherwise.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_OIDC_SUBJECT_CLAIM_USER_TITLE", "Set OIDC subject claim customization"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization name, or repository owner when 'repo' is provided"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("use_default",
+				mcp.Description("Repository only: reset the subject claim to the default, or the organization template if one is set. When true, 'include_claim_keys' is ignored."),
+			),
+			mcp.WithArray("include_claim_keys",
+				mcp.Description("Claim keys that make up the subject claim, e.g. ['repo', 'context', 'job_workflow_ref']"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			useDefault, err := OptionalParam[bool](request, "use_default")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			claimKeys, err := OptionalStringArrayParam(request, "include_claim_keys")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			template := &github.OIDCSubjectClaimCustomTemplate{}
+			switch {
+			case repo == "" && useDefault:
+				return mcp.NewToolResultError("use_default is only supported for repositories"), nil
+			case useDefault:
+				template.UseDefault = github.Ptr(true)
+			case len(claimKeys) == 0:
+				return mcp.NewToolResultError("include_claim_keys is required unless use_default is true"), nil
+			default:
+				template.IncludeClaimKeys = claimKeys
+				if repo != "" {
+					template.UseDefault = github.Ptr(false)
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo != "" {
+				resp, err = client.Actions.SetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo, template)
+			} else {
+				resp, err = client.Actions.SetOrgOIDCSubjectClaimCustomTemplate(ctx, owner, template)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to set OIDC subject claim customization", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, template), nil
+		}
+}
+
+// ListDeploymentProtectionRules creates a tool to list the deployment protection rules of an environment
+func ListDeploymentProtectionRules(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployment_protection_rules",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENT_PROTECTION_RULES_DESCRIPTION", "List the custom deployment protection rules enabled on a repository environment, optionally with the GitHub App integrations that could be added.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENT_PROTECTION_RULES_USER_TITLE", "List deployment protection rules"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithBoolean("include_available_integrations",
+				mcp.Description("Also list the custom deployment protection rule integrations available for the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAvailable, err := OptionalParam[bool](request, "include_available_integrations")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rules, resp, err := client.Repositories.GetAllDeploymentProtectionRules(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list deployment protection rules", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"total_count":      rules.GetTotalCount(),
+				"protection_rules": rules.ProtectionRules,
+			}
+
+			if includeAvailable {
+				integrations, resp, err := client.Repositories.ListCustomDeploymentRuleIntegrations(ctx, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list available deployment protection rule integrations", resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				result["available_integrations"] = integrations.AvailableIntegrations
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ManageDeploymentProtectionRule creates a tool to enable or disable a custom deployment protection rule on an environment
+func ManageDeploymentProtectionRule(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("manage_deployment_protection_rule",
+			mcp.WithDescription(t("TOOL_MANAGE_DEPLOYMENT_PROTECTION_RULE_DESCRIPTION", "Enable a custom deployment protection rule integration on a repository environment, or disable an existing rule.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MANAGE_DEPLOYMENT_PROTECTION_RULE_USER_TITLE", "Manage deployment protection rule"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithString("action",
+				mcp.Required(),
+				mcp.Description("Action to perform: 'enable' a GitHub App integration or 'disable' an existing rule"),
+				mcp.Enum("enable", "disable"),
+			),
+			mcp.WithNumber("integration_id",
+				mcp.Description("ID of the GitHub App integration to enable. Required for 'enable'."),
+			),
+			mcp.WithNumber("protection_rule_id",
+				mcp.Description("ID of the protection rule to disable. Required for 'disable'."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			action, err := RequiredParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			switch action {
+			case "enable":
+				integrationID, err := RequiredInt(request, "integration_id")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				rule, resp, err := client.Repositories.CreateCustomDeploymentProtectionRule(ctx, owner, repo, environment, &github.CustomDeploymentProtectionRuleRequest{
+					IntegrationID: github.Ptr(int64(integrationID)),
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to enable deployment protection rule", resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(ctx, rule), nil
+			case "disable":
+				ruleID, err := RequiredInt(request, "protection_rule_id")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				resp, err := client.Repositories.DisableCustomDeploymentProtectionRule(ctx, owner, repo, environment, int64(ruleID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to disable deployment protection rule", resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return mcp.NewToolResultText(fmt.Sprintf("Deployment protection rule %d disabled on environment %s", ruleID, environment)), nil
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unknown action: %s", action)), nil
+			}
+		}
+}
+
+// PendingWorkflowApproval is a workflow run waiting on a reviewer to approve an environment deployment.
+type PendingWorkflowApproval struct {
+	Run                MinimalWorkflowRun          `json:"run"`
+	PendingDeployments []*github.PendingDeployment `json:"pending_deployments"`
+	Error              string                      `json:"error,omitempty"`
+}
+
+// ListPendingWorkflowApprovals creates a tool to list workflow runs waiting for approval
+func ListPendingWorkflowApprovals(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_workflow_approvals",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_WORKFLOW_APPROVALS_DESCRIPTION", "List workflow runs waiting for approval in a repository: runs from fork pull requests that need a maintainer to approve them, and runs waiting on environment protection reviews, with the environments pending approval.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_WORKFLOW_APPROVALS_USER_TITLE", "List pending workflow approvals"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			listOptions := github.ListOptions{
+				PerPage: pagination.PerPage,
+				Page:    pagination.Page,
+			}
+
+			// Runs triggered from forks wait with the action_required status until a maintainer approves them.
+			forkRuns, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+				Status:      "action_required",
+				ListOptions: listOptions,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs awaiting approval", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			waitingRuns, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+				Status:      "waiting",
+				ListOptions: listOptions,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs waiting on environments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			forkApprovals := make([]MinimalWorkflowRun, 0, len(forkRuns.WorkflowRuns))
+			for _, run := range forkRuns.WorkflowRuns {
+				forkApprovals = append(forkApprovals, convertToMinimalWorkflowRun(run))
+			}
+
+			environmentApprovals := make([]PendingWorkflowApproval, len(waitingRuns.WorkflowRuns))
+			errs := fanOut(ctx, waitingRuns.WorkflowRuns, DefaultFanOutConcurrency, func(ctx context.Context, i int, run *github.WorkflowRun) error {
+				deployments, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, run.GetID())
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				environmentApprovals[i].PendingDeployments = deployments
+				return nil
+			})
+			for i, run := range waitingRuns.WorkflowRuns {
+				environmentApprovals[i].Run = convertToMinimalWorkflowRun(run)
+				if errs[i] != nil {
+					environmentApprovals[i].Error = errs[i].Error()
+				}
+			}
+
+			result := map[string]any{
+				"fork_pull_request_runs": forkApprovals,
+				"environment_approvals":  environmentApprovals,
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ApproveWorkflowRun creates a tool to approve a workflow run from a fork pull request
+func ApproveWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("approve_workflow_run",
+			mcp.WithDescription(t("TOOL_APPROVE_WORKFLOW_RUN_DESCRIPTION", "Approve a workflow run triggered by a pull request from a fork, allowing it to run. To reject such a run, cancel it instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPROVE_WORKFLOW_RUN_USER_TITLE", "Approve workflow run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap the approve endpoint, so the request is built manually.
+			url := fmt.Sprintf("repos/%s/%s/actions/runs/%d/approve", owner, repo, runID)
+			req, err := client.NewRequest("POST", url, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			resp, err := client.Do(ctx, req, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to approve workflow run", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message": "Workflow run has been approved",
+				"run_id":  runID,
+				"status":  resp.Status,
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ReviewPendingDeployments creates a tool to approve or reject environment deployments a workflow run is waiting on
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject the environment deployments a workflow run is waiting on. The caller must be a required reviewer of the environments.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithArray("environment_ids",
+				mcp.Required(),
+				mcp.Description("IDs of the environments to approve or reject"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("A comment to accompany the review"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			rawIDs, ok := request.GetArguments()["environment_ids"].([]any)
+			if !ok || len(rawIDs) == 0 {
+				return mcp.NewToolResultError("environment_ids must be a non-empty array of numbers"), nil
+			}
+			environmentIDs := make([]int64, 0, len(rawIDs))
+			for _, rawID := range rawIDs {
+				id, ok := rawID.(float64)
+				if !ok {
+					return mcp.NewToolResultError("environment_ids must be a non-empty array of numbers"), nil
+				}
+				environmentIDs = append(environmentIDs, int64(id))
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, &github.PendingDeploymentsRequest{
+				EnvironmentIDs: environmentIDs,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, deployments), nil
 		}
 }
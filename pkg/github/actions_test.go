@@ -11,6 +11,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/profiler"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
@@ -1319,3 +1320,934 @@ func Test_MemoryUsage_SlidingWindow_vs_NoWindow(t *testing.T) {
 	t.Logf("Sliding window: %s", profile1.String())
 	t.Logf("No window: %s", profile2.String())
 }
+
+func Test_ListOrgSecretsInventory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSecretsInventory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_secrets_inventory", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockRepos := []*github.Repository{
+		{Name: github.Ptr("api")},
+		{Name: github.Ptr("web")},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedInventory []RepositorySecretsInventory
+	}{
+		{
+			name: "successful inventory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					mockRepos,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsSecretsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						secrets := &github.Secrets{Secrets: []*github.Secret{}}
+						if strings.Contains(r.URL.Path, "/api/") {
+							secrets.TotalCount = 1
+							secrets.Secrets = append(secrets.Secrets, &github.Secret{Name: "DEPLOY_KEY"})
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(secrets)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						variables := &github.ActionsVariables{Variables: []*github.ActionsVariable{}}
+						if strings.Contains(r.URL.Path, "/web/") {
+							variables.TotalCount = 1
+							variables.Variables = append(variables.Variables, &github.ActionsVariable{Name: "REGION", Value: "eu-west-1"})
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(variables)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectError: false,
+			expectedInventory: []RepositorySecretsInventory{
+				{Repository: "api", Secrets: []string{"DEPLOY_KEY"}, Variables: []string{}},
+				{Repository: "web", Secrets: []string{}, Variables: []string{"REGION"}},
+			},
+		},
+		{
+			name: "per-repository failure is reported inline",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					mockRepos[:1],
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsSecretsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectError: false,
+			expectedInventory: []RepositorySecretsInventory{
+				{Repository: "api", Error: "failed to list secrets"},
+			},
+		},
+		{
+			name: "repository listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "missing-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories for organization 'missing-org'",
+		},
+		{
+			name:           "missing required parameter org",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgSecretsInventory(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			// Values of variables must never be returned
+			assert.NotContains(t, textContent.Text, "eu-west-1")
+
+			var response struct {
+				Org          string                       `json:"org"`
+				Repositories []RepositorySecretsInventory `json:"repositories"`
+				HasNextPage  bool                         `json:"hasNextPage"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "octo-org", response.Org)
+			require.Len(t, response.Repositories, len(tc.expectedInventory))
+			for i, expected := range tc.expectedInventory {
+				actual := response.Repositories[i]
+				assert.Equal(t, expected.Repository, actual.Repository)
+				if expected.Error != "" {
+					assert.Contains(t, actual.Error, expected.Error)
+					continue
+				}
+				assert.Empty(t, actual.Error)
+				assert.Equal(t, expected.Secrets, actual.Secrets)
+				assert.Equal(t, expected.Variables, actual.Variables)
+			}
+		})
+	}
+}
+
+func Test_ListActionsSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListActionsSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_actions_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	created := github.Timestamp{Time: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)}
+	secrets := &github.Secrets{
+		TotalCount: 1,
+		Secrets:    []*github.Secret{{Name: "DEPLOY_KEY", CreatedAt: created, UpdatedAt: created}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository secrets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsSecretsByOwnerByRepo, secrets),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "environment secrets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{ID: github.Ptr(int64(1296269))}),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/repositories/1296269/environments/production/secrets",
+						Method:  "GET",
+					},
+					mockResponse(t, http.StatusOK, secrets),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			},
+		},
+		{
+			name: "missing environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{ID: github.Ptr(int64(1296269))}),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/repositories/1296269/environments/staging/secrets",
+						Method:  "GET",
+					},
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "staging",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list secrets of environment 'staging'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListActionsSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				TotalCount int                    `json:"total_count"`
+				Secrets    []MinimalActionsSecret `json:"secrets"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 1, response.TotalCount)
+			assert.Equal(t, []MinimalActionsSecret{{Name: "DEPLOY_KEY", CreatedAt: "2025-01-15T10:00:00Z", UpdatedAt: "2025-01-15T10:00:00Z"}}, response.Secrets)
+			assert.NotContains(t, getTextResult(t, result).Text, "value")
+		})
+	}
+}
+
+func Test_ListActionsVariables(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListActionsVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_actions_variables", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectedVariables []MinimalActionsVariable
+	}{
+		{
+			name: "repository variables",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsVariablesByOwnerByRepo, &github.ActionsVariables{
+					TotalCount: 1,
+					Variables:  []*github.ActionsVariable{{Name: "REGION", Value: "eu-west-1"}},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedVariables: []MinimalActionsVariable{{Name: "REGION", Value: "eu-west-1"}},
+		},
+		{
+			name: "environment variables",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName, &github.ActionsVariables{
+					TotalCount: 1,
+					Variables:  []*github.ActionsVariable{{Name: "REGION", Value: "us-east-1"}},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			},
+			expectedVariables: []MinimalActionsVariable{{Name: "REGION", Value: "us-east-1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListActionsVariables(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				TotalCount int                      `json:"total_count"`
+				Variables  []MinimalActionsVariable `json:"variables"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 1, response.TotalCount)
+			assert.Equal(t, tc.expectedVariables, response.Variables)
+		})
+	}
+}
+
+func Test_GetOIDCSubjectClaim(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOIDCSubjectClaim(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_oidc_subject_claim", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedTemplate *github.OIDCSubjectClaimCustomTemplate
+	}{
+		{
+			name: "organization template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					&github.OIDCSubjectClaimCustomTemplate{IncludeClaimKeys: []string{"repo", "context"}},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+			},
+			expectError:      false,
+			expectedTemplate: &github.OIDCSubjectClaimCustomTemplate{IncludeClaimKeys: []string{"repo", "context"}},
+		},
+		{
+			name: "repository template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					&github.OIDCSubjectClaimCustomTemplate{UseDefault: github.Ptr(true)},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "repo",
+			},
+			expectError:      false,
+			expectedTemplate: &github.OIDCSubjectClaimCustomTemplate{UseDefault: github.Ptr(true)},
+		},
+		{
+			name: "fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get OIDC subject claim customization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOIDCSubjectClaim(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var template github.OIDCSubjectClaimCustomTemplate
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &template))
+			assert.Equal(t, *tc.expectedTemplate, template)
+		})
+	}
+}
+
+func Test_SetOIDCSubjectClaim(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOIDCSubjectClaim(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_oidc_subject_claim", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "use_default")
+	assert.Contains(t, tool.InputSchema.Properties, "include_claim_keys")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "set organization claim keys",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsOidcCustomizationSubByOrg,
+					expectRequestBody(t, map[string]any{
+						"include_claim_keys": []any{"repo", "context"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":              "octo-org",
+				"include_claim_keys": []any{"repo", "context"},
+			},
+			expectError: false,
+		},
+		{
+			name: "reset repository to default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"use_default": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"repo":        "repo",
+				"use_default": true,
+			},
+			expectError: false,
+		},
+		{
+			name:         "use_default on organization",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"use_default": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "use_default is only supported for repositories",
+		},
+		{
+			name:         "missing claim keys",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "include_claim_keys is required unless use_default is true",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetOIDCSubjectClaim(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+		})
+	}
+}
+
+func Test_ListDeploymentProtectionRules(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeploymentProtectionRules(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_deployment_protection_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.Contains(t, tool.InputSchema.Properties, "include_available_integrations")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentName,
+			&github.ListDeploymentProtectionRuleResponse{
+				TotalCount: github.Ptr(1),
+				ProtectionRules: []*github.CustomDeploymentProtectionRule{
+					{ID: github.Ptr(int64(3)), Enabled: github.Ptr(true), App: &github.CustomDeploymentProtectionRuleApp{Slug: github.Ptr("datadog")}},
+				},
+			},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposEnvironmentsDeploymentProtectionRulesAppsByOwnerByRepoByEnvironmentName,
+			&github.ListCustomDeploymentRuleIntegrationsResponse{
+				TotalCount: github.Ptr(1),
+				AvailableIntegrations: []*github.CustomDeploymentProtectionRuleApp{
+					{ID: github.Ptr(int64(7)), Slug: github.Ptr("honeycomb")},
+				},
+			},
+		),
+	))
+	_, handler := ListDeploymentProtectionRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":                          "owner",
+		"repo":                           "repo",
+		"environment":                    "production",
+		"include_available_integrations": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		TotalCount            int                                         `json:"total_count"`
+		ProtectionRules       []*github.CustomDeploymentProtectionRule    `json:"protection_rules"`
+		AvailableIntegrations []*github.CustomDeploymentProtectionRuleApp `json:"available_integrations"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 1, response.TotalCount)
+	require.Len(t, response.ProtectionRules, 1)
+	assert.Equal(t, "datadog", response.ProtectionRules[0].GetApp().GetSlug())
+	require.Len(t, response.AvailableIntegrations, 1)
+	assert.Equal(t, "honeycomb", response.AvailableIntegrations[0].GetSlug())
+}
+
+func Test_ManageDeploymentProtectionRule(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ManageDeploymentProtectionRule(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "manage_deployment_protection_rule", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "action")
+	assert.Contains(t, tool.InputSchema.Properties, "integration_id")
+	assert.Contains(t, tool.InputSchema.Properties, "protection_rule_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment", "action"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "enable integration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"integration_id": float64(7),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CustomDeploymentProtectionRule{ID: github.Ptr(int64(11)), Enabled: github.Ptr(true)}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"environment":    "production",
+				"action":         "enable",
+				"integration_id": float64(7),
+			},
+			expectError:  false,
+			expectedText: `"id":11`,
+		},
+		{
+			name: "disable rule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentNameByProtectionRuleId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"environment":        "production",
+				"action":             "disable",
+				"protection_rule_id": float64(11),
+			},
+			expectError:  false,
+			expectedText: "Deployment protection rule 11 disabled on environment production",
+		},
+		{
+			name:         "enable without integration",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"action":      "enable",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: integration_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ManageDeploymentProtectionRule(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_ListPendingWorkflowApprovals(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingWorkflowApprovals(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_workflow_approvals", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "fork runs and environment approvals",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						runs := &github.WorkflowRuns{}
+						switch r.URL.Query().Get("status") {
+						case "action_required":
+							runs.WorkflowRuns = []*github.WorkflowRun{{
+								ID:             github.Ptr(int64(1)),
+								Event:          github.Ptr("pull_request"),
+								HeadRepository: &github.Repository{FullName: github.Ptr("contributor/repo")},
+								Actor:          &github.User{Login: github.Ptr("contributor")},
+							}}
+						case "waiting":
+							runs.WorkflowRuns = []*github.WorkflowRun{{ID: github.Ptr(int64(2)), Event: github.Ptr("push")}}
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(runs)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{{
+						Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(9)), Name: github.Ptr("production")},
+						CurrentUserCanApprove: github.Ptr(true),
+					}},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs awaiting approval",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPendingWorkflowApprovals(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				ForkPullRequestRuns  []MinimalWorkflowRun      `json:"fork_pull_request_runs"`
+				EnvironmentApprovals []PendingWorkflowApproval `json:"environment_approvals"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.ForkPullRequestRuns, 1)
+			assert.Equal(t, int64(1), response.ForkPullRequestRuns[0].ID)
+			assert.Equal(t, "contributor/repo", response.ForkPullRequestRuns[0].HeadRepository)
+			assert.Equal(t, "contributor", response.ForkPullRequestRuns[0].Actor.Login)
+			require.Len(t, response.EnvironmentApprovals, 1)
+			assert.Equal(t, int64(2), response.EnvironmentApprovals[0].Run.ID)
+			require.Len(t, response.EnvironmentApprovals[0].PendingDeployments, 1)
+			assert.Equal(t, "production", response.EnvironmentApprovals[0].PendingDeployments[0].GetEnvironment().GetName())
+		})
+	}
+}
+
+func Test_ApproveWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApproveWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "approve_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful approval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte(`{}`))
+					}),
+				),
+			),
+			expectError: false,
+		},
+		{
+			name: "run does not need approval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "This run is not from a fork pull request"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to approve workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ApproveWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Workflow run has been approved", response["message"])
+			assert.Equal(t, float64(12345), response["run_id"])
+		})
+	}
+}
+
+func Test_ReviewPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "environment_ids", "state", "comment"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approve deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(9)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{{ID: github.Ptr(int64(55)), Environment: github.Ptr("production")}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(2),
+				"environment_ids": []any{float64(9)},
+				"state":           "approved",
+				"comment":         "Ship it",
+			},
+			expectError: false,
+		},
+		{
+			name:         "invalid environment ids",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(2),
+				"environment_ids": []any{"production"},
+				"state":           "approved",
+				"comment":         "Ship it",
+			},
+			expectError:    true,
+			expectedErrMsg: "environment_ids must be a non-empty array of numbers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var deployments []*github.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deployments))
+			require.Len(t, deployments, 1)
+			assert.Equal(t, int64(55), deployments[0].GetID())
+		})
+	}
+}
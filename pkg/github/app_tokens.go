@@ -0,0 +1,164 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxInstallationTokenRepositories is the number of repositories an installation token can be scoped to.
+const maxInstallationTokenRepositories = 500
+
+// parseInstallationPermissions converts the permissions argument of a tool call, rejecting unknown permissions
+// and access levels so that a typo doesn't silently widen or drop a grant.
+func parseInstallationPermissions(arg map[string]any) (*github.InstallationPermissions, error) {
+	for name, level := range arg {
+		if level != "read" && level != "write" && level != "admin" {
+			return nil, fmt.Errorf("invalid access level for permission '%s': must be 'read', 'write' or 'admin'", name)
+		}
+	}
+	raw, err := json.Marshal(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid permissions: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	permissions := &github.InstallationPermissions{}
+	if err := decoder.Decode(permissions); err != nil {
+		return nil, fmt.Errorf("invalid permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// CreateInstallationToken creates a tool to mint a short-lived installation token scoped to a set of
+// repositories and, optionally, permissions. It requires a client authenticated as a GitHub App.
+func CreateInstallationToken(getAppClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_installation_token",
+			mcp.WithDescription(t("TOOL_CREATE_INSTALLATION_TOKEN_DESCRIPTION", "Mint a GitHub App installation token that expires after one hour, scoped to the given repositories and permissions of the app's installation on an owner, to hand a narrowly scoped credential to a job. The token can't have more access than the installation. Treat the returned token as a secret: pass it on without printing or storing it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_INSTALLATION_TOKEN_USER_TITLE", "Create installation token"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization or user the app is installed on"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Names of the repositories of the owner the token can access, at most %d", maxInstallationTokenRepositories)),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithObject("permissions",
+				mcp.Description("Permissions of the token, mapping permission names such as 'contents', 'issues' or 'pull_requests' to 'read', 'write' or 'admin'. Defaults to every permission of the installation"),
+				mcp.AdditionalProperties(map[string]any{
+					"type": "string",
+					"enum": []string{"read", "write", "admin"},
+				}),
+			),
+			mcp.WithNumber("installation_id",
+				mcp.Description("ID of the installation, which must be installed on the owner. Defaults to the app's installation on the first repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(repositories) > maxInstallationTokenRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be given", maxInstallationTokenRepositories)), nil
+			}
+			var permissions *github.InstallationPermissions
+			if arg, ok := request.GetArguments()["permissions"]; ok && arg != nil {
+				permissionsArg, ok := arg.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("permissions must be an object"), nil
+				}
+				if len(permissionsArg) > 0 {
+					if permissions, err = parseInstallationPermissions(permissionsArg); err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				}
+			}
+			installationID, err := OptionalIntParam(request, "installation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getAppClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App client: %w", err)
+			}
+
+			id := int64(installationID)
+			if id == 0 {
+				installation, resp, err := client.Apps.FindRepositoryInstallation(ctx, owner, repositories[0])
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to find the app's installation on '%s/%s'", owner, repositories[0]),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				id = installation.GetID()
+			} else {
+				installation, resp, err := client.Apps.GetInstallation(ctx, id)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get app installation %d", id),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				// Only the owner is checked by the owner policy, so the token must not come from another account
+				if account := installation.GetAccount().GetLogin(); !strings.EqualFold(account, owner) {
+					return mcp.NewToolResultError(fmt.Sprintf("app installation %d is installed on '%s', not '%s'", id, account, owner)), nil
+				}
+			}
+
+			token, resp, err := client.Apps.CreateInstallationToken(ctx, id, &github.InstallationTokenOptions{
+				Repositories: repositories,
+				Permissions:  permissions,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create token for app installation %d", id),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			tokenRepositories := make([]string, 0, len(token.Repositories))
+			for _, repo := range token.Repositories {
+				tokenRepositories = append(tokenRepositories, repo.GetFullName())
+			}
+			sort.Strings(tokenRepositories)
+			return MarshalledTextResult(ctx, map[string]any{
+				"token":           token.GetToken(),
+				"expires_at":      formatOptionalTimestamp(token.ExpiresAt),
+				"installation_id": id,
+				"permissions":     token.Permissions,
+				"repositories":    tokenRepositories,
+			}), nil
+		}
+}
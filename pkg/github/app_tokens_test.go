@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateInstallationToken(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateInstallationToken(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_installation_token", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "permissions")
+	assert.Contains(t, tool.InputSchema.Properties, "installation_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repositories"})
+
+	expiresAt := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	createToken := func(expectedBody map[string]any, token *github.InstallationToken) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostAppInstallationsAccessTokensByInstallationId,
+			expectPath(t, "/app/installations/42/access_tokens").andThen(
+				expectRequestBody(t, expectedBody).andThen(
+					mockResponse(t, http.StatusCreated, token),
+				),
+			),
+		)
+	}
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name: "token scoped to repositories and permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposInstallationByOwnerByRepo,
+					&github.Installation{ID: github.Ptr(int64(42))},
+				),
+				createToken(map[string]any{
+					"repositories": []any{"api", "web"},
+					"permissions":  map[string]any{"contents": "read", "checks": "write"},
+				}, &github.InstallationToken{
+					Token:       github.Ptr("ghs_scoped"),
+					ExpiresAt:   &github.Timestamp{Time: expiresAt},
+					Permissions: &github.InstallationPermissions{Contents: github.Ptr("read"), Checks: github.Ptr("write")},
+					Repositories: []*github.Repository{
+						{FullName: github.Ptr("octo-org/web")},
+						{FullName: github.Ptr("octo-org/api")},
+					},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api", "web"},
+				"permissions":  map[string]any{"contents": "read", "checks": "write"},
+			},
+			expected: map[string]any{
+				"token":           "ghs_scoped",
+				"expires_at":      "2026-10-16T10:00:00Z",
+				"installation_id": float64(42),
+				"permissions":     map[string]any{"contents": "read", "checks": "write"},
+				"repositories":    []any{"octo-org/api", "octo-org/web"},
+			},
+		},
+		{
+			name: "given installation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetAppInstallationsByInstallationId,
+					&github.Installation{ID: github.Ptr(int64(42)), Account: &github.User{Login: github.Ptr("Octo-Org")}},
+				),
+				createToken(map[string]any{
+					"repositories": []any{"api"},
+				}, &github.InstallationToken{
+					Token:        github.Ptr("ghs_api"),
+					ExpiresAt:    &github.Timestamp{Time: expiresAt},
+					Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/api")}},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":           "octo-org",
+				"repositories":    []any{"api"},
+				"installation_id": float64(42),
+			},
+			expected: map[string]any{
+				"token":           "ghs_api",
+				"expires_at":      "2026-10-16T10:00:00Z",
+				"installation_id": float64(42),
+				"permissions":     nil,
+				"repositories":    []any{"octo-org/api"},
+			},
+		},
+		{
+			name: "given installation of another owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetAppInstallationsByInstallationId,
+					&github.Installation{ID: github.Ptr(int64(42)), Account: &github.User{Login: github.Ptr("other-org")}},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "octo-org",
+				"repositories":    []any{"api"},
+				"installation_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "app installation 42 is installed on 'other-org', not 'octo-org'",
+		},
+		{
+			name: "app not installed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposInstallationByOwnerByRepo, notFound),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to find the app's installation on 'octo-org/api'",
+		},
+		{
+			name:         "token for every repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"permissions": map[string]any{"issues": "write"},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+		{
+			name:         "unknown permission",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api"},
+				"permissions":  map[string]any{"content": "read"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid permissions: json: unknown field "content"`,
+		},
+		{
+			name:         "invalid access level",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api"},
+				"permissions":  map[string]any{"contents": "all"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid access level for permission 'contents'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateInstallationToken(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
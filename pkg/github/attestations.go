@@ -0,0 +1,399 @@
+package github
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SLSAProvenancePredicateType is the predicate type of the build provenance attestations created by
+// actions/attest-build-provenance.
+const SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+
+// AttestationSubject is an artifact an attestation is about.
+type AttestationSubject struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// AttestationSummary is the decoded content of an artifact attestation: what it is about, who signed it, and for
+// provenance attestations, how the artifact was built.
+type AttestationSummary struct {
+	RepositoryID       int64                `json:"repository_id,omitempty"`
+	PredicateType      string               `json:"predicate_type"`
+	Subjects           []AttestationSubject `json:"subjects"`
+	SignerIdentity     string               `json:"signer_identity,omitempty"`
+	CertificateIssuer  string               `json:"certificate_issuer,omitempty"`
+	TransparencyLogged bool                 `json:"transparency_logged"`
+	BuilderID          string               `json:"builder_id,omitempty"`
+	SourceRepository   string               `json:"source_repository,omitempty"`
+	SourceRef          string               `json:"source_ref,omitempty"`
+	SourceCommit       string               `json:"source_commit,omitempty"`
+	Workflow           string               `json:"workflow,omitempty"`
+	BuildTrigger       string               `json:"build_trigger,omitempty"`
+	RunInvocation      string               `json:"run_invocation,omitempty"`
+}
+
+// sigstoreBundle is the subset of a Sigstore bundle needed to summarize an attestation.
+type sigstoreBundle struct {
+	VerificationMaterial struct {
+		Certificate *struct {
+			RawBytes string `json:"rawBytes"`
+		} `json:"certificate"`
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes string `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		TlogEntries []json.RawMessage `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope struct {
+		Payload     string            `json:"payload"`
+		PayloadType string            `json:"payloadType"`
+		Signatures  []json.RawMessage `json:"signatures"`
+	} `json:"dsseEnvelope"`
+}
+
+// inTotoStatement is the subset of an in-toto statement, with a SLSA v1 provenance predicate, needed to summarize
+// an attestation.
+type inTotoStatement struct {
+	Subject       []AttestationSubject `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     struct {
+		BuildDefinition struct {
+			ExternalParameters struct {
+				Workflow struct {
+					Ref        string `json:"ref"`
+					Repository string `json:"repository"`
+					Path       string `json:"path"`
+				} `json:"workflow"`
+			} `json:"externalParameters"`
+			InternalParameters struct {
+				GitHub struct {
+					EventName string `json:"event_name"`
+				} `json:"github"`
+			} `json:"internalParameters"`
+			ResolvedDependencies []struct {
+				URI    string            `json:"uri"`
+				Digest map[string]string `json:"digest"`
+			} `json:"resolvedDependencies"`
+		} `json:"buildDefinition"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+			Metadata struct {
+				InvocationID string `json:"invocationId"`
+			} `json:"metadata"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+// summarizeAttestation decodes the Sigstore bundle of an attestation. It extracts the signing identity from the
+// certificate and the statement from the DSSE envelope, but does not verify any signature.
+func summarizeAttestation(attestation *github.Attestation) (*AttestationSummary, error) {
+	var bundle sigstoreBundle
+	if err := json.Unmarshal(attestation.Bundle, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation bundle: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(bundle.DSSEEnvelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode attestation payload: %w", err)
+	}
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation statement: %w", err)
+	}
+
+	summary := &AttestationSummary{
+		RepositoryID:       attestation.RepositoryID,
+		PredicateType:      statement.PredicateType,
+		Subjects:           statement.Subject,
+		TransparencyLogged: len(bundle.VerificationMaterial.TlogEntries) > 0,
+		BuilderID:          statement.Predicate.RunDetails.Builder.ID,
+		SourceRepository:   strings.TrimPrefix(statement.Predicate.BuildDefinition.ExternalParameters.Workflow.Repository, "https://github.com/"),
+		SourceRef:          statement.Predicate.BuildDefinition.ExternalParameters.Workflow.Ref,
+		Workflow:           statement.Predicate.BuildDefinition.ExternalParameters.Workflow.Path,
+		BuildTrigger:       statement.Predicate.BuildDefinition.InternalParameters.GitHub.EventName,
+		RunInvocation:      statement.Predicate.RunDetails.Metadata.InvocationID,
+	}
+	for _, dependency := range statement.Predicate.BuildDefinition.ResolvedDependencies {
+		if commit, ok := dependency.Digest["gitCommit"]; ok {
+			summary.SourceCommit = commit
+			break
+		}
+	}
+
+	// Bundles up to v0.2 carry a certificate chain, later ones only the leaf certificate.
+	rawCertificate := ""
+	if bundle.VerificationMaterial.Certificate != nil {
+		rawCertificate = bundle.VerificationMaterial.Certificate.RawBytes
+	} else if chain := bundle.VerificationMaterial.X509CertificateChain; chain != nil && len(chain.Certificates) > 0 {
+		rawCertificate = chain.Certificates[0].RawBytes
+	}
+	if rawCertificate != "" {
+		der, err := base64.StdEncoding.DecodeString(rawCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode attestation certificate: %w", err)
+		}
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse attestation certificate: %w", err)
+		}
+		// Fulcio certificates identify the signing workflow by its URI.
+		if len(certificate.URIs) > 0 {
+			summary.SignerIdentity = certificate.URIs[0].String()
+		}
+		summary.CertificateIssuer = certificate.Issuer.CommonName
+	}
+
+	return summary, nil
+}
+
+// listAttestations fetches the attestations for a subject digest, from a repository when repo is set and from an
+// organization otherwise.
+func listAttestations(ctx context.Context, client *github.Client, owner, repo, subjectDigest string, opts *github.ListOptions) (*github.AttestationsResponse, *github.Response, error) {
+	if repo == "" {
+		return client.Organizations.ListAttestations(ctx, owner, subjectDigest, opts)
+	}
+	return client.Repositories.ListAttestations(ctx, owner, repo, subjectDigest, opts)
+}
+
+// validateSubjectDigest checks that a digest has the "algorithm:hex" form expected by the attestations API, and
+// returns its parts.
+func validateSubjectDigest(subjectDigest string) (string, string, error) {
+	algorithm, value, ok := strings.Cut(subjectDigest, ":")
+	if !ok || algorithm == "" || value == "" {
+		return "", "", fmt.Errorf("subject_digest must have the form 'sha256:<hex>', got '%s'", subjectDigest)
+	}
+	return algorithm, value, nil
+}
+
+func ListAttestations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_attestations",
+			mcp.WithDescription(t("TOOL_LIST_ATTESTATIONS_DESCRIPTION", "List the artifact attestations for a subject digest, such as the SHA-256 of a release asset or container image, in a repository or across an organization. Each attestation is decoded to show its predicate type, subjects, signing workflow and build provenance.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ATTESTATIONS_USER_TITLE", "List artifact attestations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or organization when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. When omitted, attestations of every repository of the organization are listed."),
+			),
+			mcp.WithString("subject_digest",
+				mcp.Required(),
+				mcp.Description("Digest of the artifact, in the form 'sha256:<hex>'"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectDigest, err := RequiredParam[string](request, "subject_digest")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, _, err := validateSubjectDigest(subjectDigest); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			attestations, resp, err := listAttestations(ctx, client, owner, repo, subjectDigest, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list attestations for '%s'", subjectDigest),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			summaries := []*AttestationSummary{}
+			for _, attestation := range attestations.Attestations {
+				summary, err := summarizeAttestation(attestation)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				summaries = append(summaries, summary)
+			}
+
+			return MarshalledTextResult(ctx, summaries), nil
+		}
+}
+
+// AttestationCheck is the outcome of checking the claims of a single attestation against a provenance policy. The
+// signature of the attestation isn't verified.
+type AttestationCheck struct {
+	Attestation *AttestationSummary `json:"attestation"`
+	ClaimsMatch bool                `json:"claims_match"`
+	Failures    []string            `json:"failures,omitempty"`
+}
+
+// githubURIPath strips the GitHub host and the ref from a workflow URI, leaving "owner/repo/path".
+func githubURIPath(uri string) string {
+	uri = strings.TrimPrefix(uri, "https://github.com/")
+	uri, _, _ = strings.Cut(uri, "@")
+	return uri
+}
+
+// CheckArtifactProvenance creates a tool to check the claims of the provenance attestations of an artifact against a
+// policy, without verifying their signatures.
+func CheckArtifactProvenance(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_artifact_provenance",
+			mcp.WithDescription(t("TOOL_CHECK_ARTIFACT_PROVENANCE_DESCRIPTION", "Check the claims of the build provenance attestations GitHub stores for a release artifact or container image against a policy: the attestation covers the artifact digest, was built from the expected source repository, and optionally by the expected workflow and from the expected ref. claims_match is true when the claims of at least one attestation pass every check. The Sigstore signatures of the attestations are not verified, so a match doesn't prove the provenance of the artifact: use 'gh attestation verify' for that before deploying to production.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_ARTIFACT_PROVENANCE_USER_TITLE", "Check artifact provenance claims"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository the artifact must have been built from"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository the artifact must have been built from"),
+			),
+			mcp.WithString("subject_digest",
+				mcp.Required(),
+				mcp.Description("Digest of the artifact, in the form 'sha256:<hex>'"),
+			),
+			mcp.WithString("signer_workflow",
+				mcp.Description("Workflow that must have signed the attestation, as 'owner/repo/.github/workflows/file.yml'. Use it for builds delegated to a reusable workflow."),
+			),
+			mcp.WithString("source_ref",
+				mcp.Description("Git ref the artifact must have been built from, e.g. 'refs/tags/v1.2.3' or 'refs/heads/main'"),
+			),
+			mcp.WithString("predicate_type",
+				mcp.Description("Predicate type the attestation must have. Defaults to SLSA build provenance v1."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectDigest, err := RequiredParam[string](request, "subject_digest")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			algorithm, digest, err := validateSubjectDigest(subjectDigest)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			signerWorkflow, err := OptionalParam[string](request, "signer_workflow")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sourceRef, err := OptionalParam[string](request, "source_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			predicateType, err := OptionalParam[string](request, "predicate_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if predicateType == "" {
+				predicateType = SLSAProvenancePredicateType
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			attestations, resp, err := client.Repositories.ListAttestations(ctx, owner, repo, subjectDigest, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list attestations for '%s'", subjectDigest),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			sourceRepository := fmt.Sprintf("%s/%s", owner, repo)
+			checks := []*AttestationCheck{}
+			claimsMatch := false
+			for _, attestation := range attestations.Attestations {
+				summary, err := summarizeAttestation(attestation)
+				if err != nil {
+					checks = append(checks, &AttestationCheck{Failures: []string{err.Error()}})
+					continue
+				}
+
+				var failures []string
+				coversDigest := false
+				for _, subject := range summary.Subjects {
+					if strings.EqualFold(subject.Digest[algorithm], digest) {
+						coversDigest = true
+						break
+					}
+				}
+				if !coversDigest {
+					failures = append(failures, fmt.Sprintf("no subject has digest %s", subjectDigest))
+				}
+				if summary.PredicateType != predicateType {
+					failures = append(failures, fmt.Sprintf("predicate type is %q, expected %q", summary.PredicateType, predicateType))
+				}
+				if summary.SignerIdentity == "" {
+					failures = append(failures, "attestation is not signed by a certificate")
+				}
+				if !strings.EqualFold(summary.SourceRepository, sourceRepository) {
+					failures = append(failures, fmt.Sprintf("built from repository %q, expected %q", summary.SourceRepository, sourceRepository))
+				}
+				if signerWorkflow != "" && !strings.EqualFold(githubURIPath(summary.SignerIdentity), githubURIPath(signerWorkflow)) {
+					failures = append(failures, fmt.Sprintf("signed by workflow %q, expected %q", summary.SignerIdentity, signerWorkflow))
+				}
+				if sourceRef != "" && summary.SourceRef != sourceRef {
+					failures = append(failures, fmt.Sprintf("built from ref %q, expected %q", summary.SourceRef, sourceRef))
+				}
+
+				checks = append(checks, &AttestationCheck{
+					Attestation: summary,
+					ClaimsMatch: len(failures) == 0,
+					Failures:    failures,
+				})
+				claimsMatch = claimsMatch || len(failures) == 0
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"subject_digest":      subjectDigest,
+				"claims_match":        claimsMatch,
+				"signatures_verified": false,
+				"attestations":        checks,
+			}), nil
+		}
+}
@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubjectDigest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+// newTestAttestation builds a provenance attestation for the test digest, signed by a certificate for the given
+// workflow URI and built from the given repository and ref.
+func newTestAttestation(t *testing.T, signer, repository, ref string) *github.Attestation {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signerURI, err := url.Parse(signer)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Issuer:       pkix.Name{CommonName: "sigstore-intermediate"},
+		Subject:      pkix.Name{CommonName: "sigstore-intermediate"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		URIs:         []*url.URL{signerURI},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	statement := map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"subject":       []map[string]any{{"name": "app.tar.gz", "digest": map[string]string{"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}}},
+		"predicateType": SLSAProvenancePredicateType,
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{
+				"externalParameters": map[string]any{
+					"workflow": map[string]any{"ref": ref, "repository": "https://github.com/" + repository, "path": ".github/workflows/release.yml"},
+				},
+				"internalParameters": map[string]any{
+					"github": map[string]any{"event_name": "push"},
+				},
+				"resolvedDependencies": []map[string]any{
+					{"uri": "git+https://github.com/" + repository + "@" + ref, "digest": map[string]string{"gitCommit": "abc123"}},
+				},
+			},
+			"runDetails": map[string]any{
+				"builder":  map[string]any{"id": signer},
+				"metadata": map[string]any{"invocationId": "https://github.com/" + repository + "/actions/runs/1/attempts/1"},
+			},
+		},
+	}
+	payload, err := json.Marshal(statement)
+	require.NoError(t, err)
+
+	bundle, err := json.Marshal(map[string]any{
+		"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": map[string]any{
+			"certificate": map[string]any{"rawBytes": base64.StdEncoding.EncodeToString(der)},
+			"tlogEntries": []map[string]any{{"logIndex": "42"}},
+		},
+		"dsseEnvelope": map[string]any{
+			"payload":     base64.StdEncoding.EncodeToString(payload),
+			"payloadType": "application/vnd.in-toto+json",
+			"signatures":  []map[string]any{{"sig": "c2lnbmF0dXJl"}},
+		},
+	})
+	require.NoError(t, err)
+
+	return &github.Attestation{Bundle: bundle, RepositoryID: 1}
+}
+
+func Test_ListAttestations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAttestations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_attestations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "subject_digest")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "subject_digest"})
+
+	signer := "https://github.com/owner/repo/.github/workflows/release.yml@refs/tags/v1.0.0"
+	attestations := &github.AttestationsResponse{
+		Attestations: []*github.Attestation{newTestAttestation(t, signer, "owner/repo", "refs/tags/v1.0.0")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list repository attestations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAttestationsByOwnerByRepoBySubjectDigest,
+					expectPath(t, "/repos/owner/repo/attestations/"+testSubjectDigest).andThen(
+						mockResponse(t, http.StatusOK, attestations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"subject_digest": testSubjectDigest,
+			},
+		},
+		{
+			name: "list organization attestations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAttestationsByOrgBySubjectDigest,
+					expectPath(t, "/orgs/owner/attestations/"+testSubjectDigest).andThen(
+						mockResponse(t, http.StatusOK, attestations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"subject_digest": testSubjectDigest,
+			},
+		},
+		{
+			name:         "invalid digest",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"subject_digest": "2c26b46b",
+			},
+			expectError:    true,
+			expectedErrMsg: "subject_digest must have the form 'sha256:<hex>'",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAttestationsByOwnerByRepoBySubjectDigest,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"subject_digest": testSubjectDigest,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list attestations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAttestations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var summaries []AttestationSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summaries))
+			require.Len(t, summaries, 1)
+			summary := summaries[0]
+			assert.Equal(t, SLSAProvenancePredicateType, summary.PredicateType)
+			assert.Equal(t, signer, summary.SignerIdentity)
+			assert.Equal(t, "sigstore-intermediate", summary.CertificateIssuer)
+			assert.True(t, summary.TransparencyLogged)
+			assert.Equal(t, "owner/repo", summary.SourceRepository)
+			assert.Equal(t, "refs/tags/v1.0.0", summary.SourceRef)
+			assert.Equal(t, "abc123", summary.SourceCommit)
+			assert.Equal(t, ".github/workflows/release.yml", summary.Workflow)
+			assert.Equal(t, "push", summary.BuildTrigger)
+			require.Len(t, summary.Subjects, 1)
+			assert.Equal(t, "app.tar.gz", summary.Subjects[0].Name)
+		})
+	}
+}
+
+func Test_CheckArtifactProvenance(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckArtifactProvenance(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_artifact_provenance", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "subject_digest")
+	assert.Contains(t, tool.InputSchema.Properties, "signer_workflow")
+	assert.Contains(t, tool.InputSchema.Properties, "source_ref")
+	assert.Contains(t, tool.InputSchema.Properties, "predicate_type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_digest"})
+
+	signer := "https://github.com/owner/repo/.github/workflows/release.yml@refs/tags/v1.0.0"
+	attestations := &github.AttestationsResponse{
+		Attestations: []*github.Attestation{newTestAttestation(t, signer, "owner/repo", "refs/tags/v1.0.0")},
+	}
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedMatch    bool
+		expectedFailures []string
+	}{
+		{
+			name: "matching provenance",
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"subject_digest":  testSubjectDigest,
+				"signer_workflow": "owner/repo/.github/workflows/release.yml",
+				"source_ref":      "refs/tags/v1.0.0",
+			},
+			expectedMatch: true,
+		},
+		{
+			name: "wrong ref and signer",
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"subject_digest":  testSubjectDigest,
+				"signer_workflow": "owner/shared/.github/workflows/build.yml",
+				"source_ref":      "refs/heads/main",
+			},
+			expectedMatch: false,
+			expectedFailures: []string{
+				`signed by workflow "` + signer + `", expected "owner/shared/.github/workflows/build.yml"`,
+				`built from ref "refs/tags/v1.0.0", expected "refs/heads/main"`,
+			},
+		},
+		{
+			name: "digest not covered",
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"subject_digest": "sha256:ffff",
+			},
+			expectedMatch:    false,
+			expectedFailures: []string{"no subject has digest sha256:ffff"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAttestationsByOwnerByRepoBySubjectDigest,
+					attestations,
+				),
+			))
+			_, handler := CheckArtifactProvenance(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				ClaimsMatch        bool                `json:"claims_match"`
+				SignaturesVerified bool                `json:"signatures_verified"`
+				Attestations       []*AttestationCheck `json:"attestations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedMatch, response.ClaimsMatch)
+			assert.False(t, response.SignaturesVerified)
+			require.Len(t, response.Attestations, 1)
+			assert.Equal(t, tc.expectedMatch, response.Attestations[0].ClaimsMatch)
+			assert.Equal(t, tc.expectedFailures, response.Attestations[0].Failures)
+		})
+	}
+}
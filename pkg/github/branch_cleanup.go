@@ -0,0 +1,347 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// DefaultStaleBranchDays is the default age after which a merged branch is reported as stale.
+	DefaultStaleBranchDays = 30
+	// maxDeleteBranches is the number of branches delete_branches deletes in one call at most.
+	maxDeleteBranches = 100
+)
+
+// staleBranchesQuery reads the branches of a repository with their last commit, how far they are behind the
+// base branch and their most recently updated pull request.
+type staleBranchesQuery struct {
+	Repository struct {
+		Refs struct {
+			Nodes []struct {
+				Name                 githubv4.String
+				BranchProtectionRule *struct {
+					Pattern githubv4.String
+				}
+				Target struct {
+					Commit struct {
+						OID           githubv4.GitObjectID
+						CommittedDate githubv4.DateTime
+						Committer     struct {
+							Name githubv4.String
+							User *struct {
+								Login githubv4.String
+							}
+						}
+					} `graphql:"... on Commit"`
+				}
+				Compare struct {
+					BehindBy githubv4.Int
+				} `graphql:"compare(headRef: $base)"`
+				AssociatedPullRequests struct {
+					Nodes []struct {
+						Number githubv4.Int
+						State  githubv4.PullRequestState
+						URL    githubv4.URI
+					}
+				} `graphql:"associatedPullRequests(first: 1, orderBy: {field: UPDATED_AT, direction: DESC})"`
+			}
+			PageInfo PageInfoFragment
+		} `graphql:"refs(refPrefix: \"refs/heads/\", first: 50, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// StaleBranch is a branch that can be deleted because its work has landed or was abandoned.
+type StaleBranch struct {
+	Name          string `json:"name"`
+	SHA           string `json:"sha"`
+	LastCommitAt  string `json:"last_commit_at"`
+	LastCommitter string `json:"last_committer"`
+	// Reason is "merged" when the base branch contains all commits of the branch, otherwise "pr_merged" or
+	// "pr_closed" after the state of the branch's pull request.
+	Reason      string              `json:"reason"`
+	PullRequest *StaleBranchRequest `json:"pull_request,omitempty"`
+}
+
+// StaleBranchRequest is the pull request of a stale branch.
+type StaleBranchRequest struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// ListStaleBranches creates a tool to list branches that are merged or whose pull requests are closed.
+func ListStaleBranches(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_stale_branches",
+			mcp.WithDescription(t("TOOL_LIST_STALE_BRANCHES_DESCRIPTION", "List the branches of a GitHub repository that can be cleaned up: branches fully merged into the base branch, or whose pull request was merged or closed, with no commits for a number of days. Protected branches and the base branch are never listed. Use delete_branches with their names and SHAs to delete them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_STALE_BRANCHES_USER_TITLE", "List stale branches"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to check branches are merged into. Defaults to the default branch of the repository."),
+			),
+			mcp.WithNumber("older_than_days",
+				mcp.Description(fmt.Sprintf("Only list branches whose last commit is older than this many days (default %d)", DefaultStaleBranchDays)),
+				mcp.Min(0),
+			),
+			mcp.WithBoolean("include_closed_pull_requests",
+				mcp.Description("Also list unmerged branches whose pull request was merged or closed, e.g. after a squash merge. Default is true."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			olderThanDays, err := OptionalIntParamWithDefault(request, "older_than_days", DefaultStaleBranchDays)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if olderThanDays < 0 {
+				return mcp.NewToolResultError("older_than_days must not be negative"), nil
+			}
+			includeClosedPRs, err := OptionalBoolParamWithDefault(request, "include_closed_pull_requests", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if base == "" {
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"base":  githubv4.String(base),
+				"after": (*githubv4.String)(nil),
+			}
+			branches := []StaleBranch{}
+			scanned := 0
+			for {
+				var query staleBranchesQuery
+				if err := gqlClient.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list branches", err), nil
+				}
+
+				refs := query.Repository.Refs
+				for _, ref := range refs.Nodes {
+					scanned++
+					commit := ref.Target.Commit
+					if string(ref.Name) == base || ref.BranchProtectionRule != nil || !commit.CommittedDate.Before(cutoff) {
+						continue
+					}
+
+					branch := StaleBranch{
+						Name:          string(ref.Name),
+						SHA:           string(commit.OID),
+						LastCommitAt:  commit.CommittedDate.UTC().Format(time.RFC3339),
+						LastCommitter: string(commit.Committer.Name),
+					}
+					if commit.Committer.User != nil {
+						branch.LastCommitter = string(commit.Committer.User.Login)
+					}
+					if pulls := ref.AssociatedPullRequests.Nodes; len(pulls) > 0 {
+						branch.PullRequest = &StaleBranchRequest{
+							Number: int(pulls[0].Number),
+							State:  string(pulls[0].State),
+							URL:    pulls[0].URL.String(),
+						}
+					}
+
+					switch {
+					case ref.Compare.BehindBy == 0:
+						branch.Reason = "merged"
+					case includeClosedPRs && branch.PullRequest != nil && branch.PullRequest.State == string(githubv4.PullRequestStateMerged):
+						branch.Reason = "pr_merged"
+					case includeClosedPRs && branch.PullRequest != nil && branch.PullRequest.State == string(githubv4.PullRequestStateClosed):
+						branch.Reason = "pr_closed"
+					default:
+						continue
+					}
+					branches = append(branches, branch)
+				}
+
+				if !refs.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = githubv4.String(refs.PageInfo.EndCursor)
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"base":             base,
+				"older_than_days":  olderThanDays,
+				"scanned_branches": scanned,
+				"stale_branches":   branches,
+			}), nil
+		}
+}
+
+// DeleteBranches creates a tool to delete branches of a repository in batch.
+func DeleteBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_branches",
+			mcp.WithDescription(t("TOOL_DELETE_BRANCHES_DESCRIPTION", "Delete branches of a GitHub repository in batch, e.g. the branches returned by list_stale_branches. By default it only reports the branches that would be deleted with their head SHAs: show them to the user and call it again with dry_run set to false and head_shas once they confirm. A branch whose head moved since, e.g. because it was pushed to, is not deleted. Returns the branches that were deleted and the ones that could not be.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_BRANCHES_USER_TITLE", "Delete branches"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("branches",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Names of the branches to delete, at most %d", maxDeleteBranches)),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("head_shas",
+				mcp.Description("Head SHAs of the branches, in the same order, as listed by list_stale_branches or a dry run. Required unless dry_run is true."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only report the branches that would be deleted with their head SHAs, without deleting them (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branches, err := OptionalStringArrayParam(request, "branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(branches) == 0 {
+				return mcp.NewToolResultError("missing required parameter: branches"), nil
+			}
+			if len(branches) > maxDeleteBranches {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d branches can be deleted at once", maxDeleteBranches)), nil
+			}
+			headSHAs, err := OptionalStringArrayParam(request, "head_shas")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, err := OptionalBoolParamWithDefault(request, "dry_run", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !dryRun && len(headSHAs) != len(branches) {
+				return mcp.NewToolResultError("head_shas must list the head SHA of every branch, in the same order as branches"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The head of every branch is read again right before deleting it, so that a branch pushed to since it
+			// was listed isn't deleted with the new commits
+			heads := make([]string, len(branches))
+			errs := fanOut(ctx, branches, DefaultFanOutConcurrency, func(ctx context.Context, i int, branch string) error {
+				ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				heads[i] = ref.GetObject().GetSHA()
+				if dryRun {
+					return nil
+				}
+				if heads[i] != headSHAs[i] {
+					return fmt.Errorf("the head of the branch moved to %s since it was listed at %s, list it again to check it can still be deleted", heads[i], headSHAs[i])
+				}
+
+				resp, err = client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			deleted := []string{}
+			wouldDelete := []map[string]string{}
+			var failures []map[string]string
+			for i, branch := range branches {
+				switch {
+				case errs[i] != nil:
+					failures = append(failures, map[string]string{"branch": branch, "error": errs[i].Error()})
+				case dryRun:
+					wouldDelete = append(wouldDelete, map[string]string{"branch": branch, "head_sha": heads[i]})
+				default:
+					deleted = append(deleted, branch)
+				}
+			}
+
+			response := map[string]any{
+				"dry_run": dryRun,
+			}
+			if dryRun {
+				response["would_delete"] = wouldDelete
+			} else {
+				response["deleted"] = deleted
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,333 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStaleBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStaleBranches(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stale_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "older_than_days")
+	assert.Contains(t, tool.InputSchema.Properties, "include_closed_pull_requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	old := "2024-01-01T00:00:00Z"
+	recent := time.Now().UTC().Format(time.RFC3339)
+	ref := func(name, committedDate string, behindBy int, protected bool, prState string) map[string]any {
+		node := map[string]any{
+			"name": name,
+			"target": map[string]any{
+				"oid":           name + "-sha",
+				"committedDate": committedDate,
+				"committer": map[string]any{
+					"name": "Build Bot",
+					"user": nil,
+				},
+			},
+			"compare":                map[string]any{"behindBy": behindBy},
+			"associatedPullRequests": map[string]any{"nodes": []any{}},
+			"branchProtectionRule":   nil,
+		}
+		if name != "bot-branch" {
+			node["target"].(map[string]any)["committer"].(map[string]any)["user"] = map[string]any{"login": "alice"}
+		}
+		if protected {
+			node["branchProtectionRule"] = map[string]any{"pattern": "release/*"}
+		}
+		if prState != "" {
+			node["associatedPullRequests"] = map[string]any{"nodes": []any{
+				map[string]any{"number": 7, "state": prState, "url": "https://github.com/owner/repo/pull/7"},
+			}}
+		}
+		return node
+	}
+	refsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"refs": map[string]any{
+				"nodes": []any{
+					ref("main", old, 0, false, ""),
+					ref("bot-branch", old, 0, false, ""),
+					ref("squashed", old, 3, false, "MERGED"),
+					ref("abandoned", old, 2, false, "CLOSED"),
+					ref("active", recent, 0, false, ""),
+					ref("wip", old, 1, false, "OPEN"),
+					ref("release/1.0", old, 0, true, ""),
+				},
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+			},
+		},
+	})
+	vars := func(base string) map[string]any {
+		return map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"base":  githubv4.String(base),
+			"after": (*githubv4.String)(nil),
+		}
+	}
+	pr := &StaleBranchRequest{Number: 7, URL: "https://github.com/owner/repo/pull/7"}
+	withState := func(state string) *StaleBranchRequest {
+		p := *pr
+		p.State = state
+		return &p
+	}
+
+	tests := []struct {
+		name             string
+		restClient       *http.Client
+		gqlClient        *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedBase     string
+		expectedBranches []StaleBranch
+	}{
+		{
+			name: "merged and closed branches against default branch",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(staleBranchesQuery{}, vars("main"), refsResponse),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedBase: "main",
+			expectedBranches: []StaleBranch{
+				{Name: "bot-branch", SHA: "bot-branch-sha", LastCommitAt: old, LastCommitter: "Build Bot", Reason: "merged"},
+				{Name: "squashed", SHA: "squashed-sha", LastCommitAt: old, LastCommitter: "alice", Reason: "pr_merged", PullRequest: withState("MERGED")},
+				{Name: "abandoned", SHA: "abandoned-sha", LastCommitAt: old, LastCommitter: "alice", Reason: "pr_closed", PullRequest: withState("CLOSED")},
+			},
+		},
+		{
+			name:       "only merged branches against given base",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(staleBranchesQuery{}, vars("develop"), refsResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":                        "owner",
+				"repo":                         "repo",
+				"base":                         "develop",
+				"include_closed_pull_requests": false,
+			},
+			expectedBase: "develop",
+			expectedBranches: []StaleBranch{
+				{Name: "main", SHA: "main-sha", LastCommitAt: old, LastCommitter: "alice", Reason: "merged"},
+				{Name: "bot-branch", SHA: "bot-branch-sha", LastCommitAt: old, LastCommitter: "Build Bot", Reason: "merged"},
+			},
+		},
+		{
+			name:       "query fails",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(staleBranchesQuery{}, vars("main"), githubv4mock.ErrorResponse("repository not found")),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list branches",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := ListStaleBranches(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Base            string        `json:"base"`
+				ScannedBranches int           `json:"scanned_branches"`
+				StaleBranches   []StaleBranch `json:"stale_branches"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedBase, response.Base)
+			assert.Equal(t, 7, response.ScannedBranches)
+			assert.Equal(t, tc.expectedBranches, response.StaleBranches)
+		})
+	}
+}
+
+func Test_DeleteBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, tool.InputSchema.Properties, "branches")
+	assert.Contains(t, tool.InputSchema.Properties, "head_shas")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branches"})
+
+	// fix-typo was pushed to after it was listed at sha-typo
+	getRef := mock.WithRequestMatchHandler(
+		mock.GetReposGitRefByOwnerByRepoByRef,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			branch := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/git/ref/heads/")
+			sha := map[string]string{"feature/done": "sha-done", "main": "sha-main", "fix-typo": "sha-typo-2"}[branch]
+			mockResponse(t, http.StatusOK, &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + branch),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})(w, r)
+		}),
+	)
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]any
+		expectError         bool
+		expectedErrMsg      string
+		expectedDeleted     []string
+		expectedWouldDelete []map[string]string
+		expectedFailures    []string
+	}{
+		{
+			name: "dry run by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRef,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+						t.Error("a dry run must not delete branches")
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branches": []any{"feature/done", "fix-typo"},
+			},
+			expectedWouldDelete: []map[string]string{
+				{"branch": "feature/done", "head_sha": "sha-done"},
+				{"branch": "fix-typo", "head_sha": "sha-typo-2"},
+			},
+		},
+		{
+			name: "deletes branches and reports failures",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRef,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.HasSuffix(r.URL.Path, "/refs/heads/main") {
+							w.WriteHeader(http.StatusUnprocessableEntity)
+							_, _ = w.Write([]byte(`{"message": "Cannot delete the default branch"}`))
+							return
+						}
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branches":  []any{"feature/done", "main", "fix-typo"},
+				"head_shas": []any{"sha-done", "sha-main", "sha-typo"},
+				"dry_run":   false,
+			},
+			expectedDeleted:  []string{"feature/done"},
+			expectedFailures: []string{"main", "fix-typo"},
+		},
+		{
+			name:         "deleting without head SHAs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branches": []any{"feature/done"},
+				"dry_run":  false,
+			},
+			expectError:    true,
+			expectedErrMsg: "head_shas must list the head SHA of every branch",
+		},
+		{
+			name:         "no branches",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branches": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: branches",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Deleted     []string            `json:"deleted"`
+				WouldDelete []map[string]string `json:"would_delete"`
+				Errors      []map[string]string `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedDeleted, response.Deleted)
+			assert.Equal(t, tc.expectedWouldDelete, response.WouldDelete)
+			var failed []string
+			for _, failure := range response.Errors {
+				failed = append(failed, failure["branch"])
+			}
+			assert.Equal(t, tc.expectedFailures, failed)
+		})
+	}
+}
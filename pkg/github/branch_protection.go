@@ -0,0 +1,584 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalBranchProtection is the trimmed output type for the protection of a branch.
+type MinimalBranchProtection struct {
+	Branch                         string                              `json:"branch"`
+	Protected                      bool                                `json:"protected"`
+	RequiredPullRequestReviews     *MinimalRequiredPullRequestReviews  `json:"required_pull_request_reviews,omitempty"`
+	RequiredStatusChecks           *MinimalRequiredStatusChecks        `json:"required_status_checks,omitempty"`
+	RequiredSignatures             bool                                `json:"required_signatures"`
+	EnforceAdmins                  bool                                `json:"enforce_admins"`
+	RequireLinearHistory           bool                                `json:"require_linear_history"`
+	RequiredConversationResolution bool                                `json:"required_conversation_resolution"`
+	AllowForcePushes               bool                                `json:"allow_force_pushes"`
+	AllowDeletions                 bool                                `json:"allow_deletions"`
+	LockBranch                     bool                                `json:"lock_branch"`
+	Restrictions                   *MinimalBranchProtectionRestriction `json:"restrictions,omitempty"`
+}
+
+// MinimalRequiredPullRequestReviews is the pull request review requirement of a protected branch.
+type MinimalRequiredPullRequestReviews struct {
+	RequiredApprovingReviewCount int                                 `json:"required_approving_review_count"`
+	RequireCodeOwnerReviews      bool                                `json:"require_code_owner_reviews"`
+	DismissStaleReviews          bool                                `json:"dismiss_stale_reviews"`
+	RequireLastPushApproval      bool                                `json:"require_last_push_approval"`
+	DismissalRestrictions        *MinimalBranchProtectionRestriction `json:"dismissal_restrictions,omitempty"`
+	BypassAllowances             *MinimalBranchProtectionRestriction `json:"bypass_allowances,omitempty"`
+}
+
+// MinimalRequiredStatusChecks is the status check requirement of a protected branch.
+type MinimalRequiredStatusChecks struct {
+	Strict bool     `json:"strict"`
+	Checks []string `json:"checks"`
+}
+
+// MinimalBranchProtectionRestriction lists the users, teams and apps a protection setting applies to.
+type MinimalBranchProtectionRestriction struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+func convertToMinimalBranchProtection(branch string, protection *github.Protection) MinimalBranchProtection {
+	result := MinimalBranchProtection{Branch: branch}
+	if protection == nil {
+		return result
+	}
+	result.Protected = true
+
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		result.RequiredPullRequestReviews = &MinimalRequiredPullRequestReviews{
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireLastPushApproval:      reviews.RequireLastPushApproval,
+		}
+		if restrictions := reviews.DismissalRestrictions; restrictions != nil {
+			users, teams, apps := actorNames(restrictions.Users, restrictions.Teams, restrictions.Apps)
+			result.RequiredPullRequestReviews.DismissalRestrictions = &MinimalBranchProtectionRestriction{Users: users, Teams: teams, Apps: apps}
+		}
+		if allowances := reviews.BypassPullRequestAllowances; allowances != nil {
+			users, teams, apps := actorNames(allowances.Users, allowances.Teams, allowances.Apps)
+			result.RequiredPullRequestReviews.BypassAllowances = &MinimalBranchProtectionRestriction{Users: users, Teams: teams, Apps: apps}
+		}
+	}
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		result.RequiredStatusChecks = &MinimalRequiredStatusChecks{Strict: checks.Strict, Checks: statusCheckNames(checks)}
+	}
+	if restrictions := protection.Restrictions; restrictions != nil {
+		users, teams, apps := actorNames(restrictions.Users, restrictions.Teams, restrictions.Apps)
+		result.Restrictions = &MinimalBranchProtectionRestriction{Users: users, Teams: teams, Apps: apps}
+	}
+	// Settings the API omits are nil, and have no getter for their Enabled field.
+	result.RequiredSignatures = protection.GetRequiredSignatures().GetEnabled()
+	result.LockBranch = protection.GetLockBranch().GetEnabled()
+	if setting := protection.EnforceAdmins; setting != nil {
+		result.EnforceAdmins = setting.Enabled
+	}
+	if setting := protection.RequireLinearHistory; setting != nil {
+		result.RequireLinearHistory = setting.Enabled
+	}
+	if setting := protection.RequiredConversationResolution; setting != nil {
+		result.RequiredConversationResolution = setting.Enabled
+	}
+	if setting := protection.AllowForcePushes; setting != nil {
+		result.AllowForcePushes = setting.Enabled
+	}
+	if setting := protection.AllowDeletions; setting != nil {
+		result.AllowDeletions = setting.Enabled
+	}
+	return result
+}
+
+// branchProtectionSettings is the protection settings given to a tool call. Settings that are nil weren't given.
+type branchProtectionSettings struct {
+	RequirePullRequest             *bool
+	RequiredApprovingReviewCount   *int
+	RequireCodeOwnerReviews        *bool
+	DismissStaleReviews            *bool
+	RequireLastPushApproval        *bool
+	RequiredStatusChecks           *[]string
+	StrictStatusChecks             *bool
+	EnforceAdmins                  *bool
+	RequiredSignatures             *bool
+	RequireLinearHistory           *bool
+	RequiredConversationResolution *bool
+	AllowForcePushes               *bool
+	AllowDeletions                 *bool
+}
+
+// WithBranchProtectionSettings adds the protection settings the create and update tools accept.
+func WithBranchProtectionSettings() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithBoolean("require_pull_request",
+			mcp.Description("Require a pull request before merging. Implied by the review settings; set to false to drop the requirement"),
+		)(tool)
+		mcp.WithNumber("required_approving_review_count",
+			mcp.Description("Number of approving reviews required to merge"),
+			mcp.Min(0),
+			mcp.Max(6),
+		)(tool)
+		mcp.WithBoolean("require_code_owner_reviews",
+			mcp.Description("Require a review from the code owners of the changed files"),
+		)(tool)
+		mcp.WithBoolean("dismiss_stale_reviews",
+			mcp.Description("Dismiss approvals when new commits are pushed"),
+		)(tool)
+		mcp.WithBoolean("require_last_push_approval",
+			mcp.Description("Require the most recent push to be approved by someone other than its author"),
+		)(tool)
+		mcp.WithArray("required_status_checks",
+			mcp.Description("Names of the status checks that must pass before merging. An empty list drops the requirement"),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+		mcp.WithBoolean("strict_status_checks",
+			mcp.Description("Require branches to be up to date with the base branch before merging"),
+		)(tool)
+		mcp.WithBoolean("enforce_admins",
+			mcp.Description("Enforce the protection for repository administrators too"),
+		)(tool)
+		mcp.WithBoolean("required_signatures",
+			mcp.Description("Require signed commits"),
+		)(tool)
+		mcp.WithBoolean("require_linear_history",
+			mcp.Description("Prevent merge commits from being pushed"),
+		)(tool)
+		mcp.WithBoolean("required_conversation_resolution",
+			mcp.Description("Require review conversations to be resolved before merging"),
+		)(tool)
+		mcp.WithBoolean("allow_force_pushes",
+			mcp.Description("Allow force pushes to the branch"),
+		)(tool)
+		mcp.WithBoolean("allow_deletions",
+			mcp.Description("Allow the branch to be deleted"),
+		)(tool)
+	}
+}
+
+func optionalBoolPtr(r mcp.CallToolRequest, p string) (*bool, error) {
+	value, ok, err := OptionalParamOK[bool](r, p)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func branchProtectionSettingsParams(request mcp.CallToolRequest) (branchProtectionSettings, error) {
+	var settings branchProtectionSettings
+	for param, field := range map[string]**bool{
+		"require_pull_request":             &settings.RequirePullRequest,
+		"require_code_owner_reviews":       &settings.RequireCodeOwnerReviews,
+		"dismiss_stale_reviews":            &settings.DismissStaleReviews,
+		"require_last_push_approval":       &settings.RequireLastPushApproval,
+		"strict_status_checks":             &settings.StrictStatusChecks,
+		"enforce_admins":                   &settings.EnforceAdmins,
+		"required_signatures":              &settings.RequiredSignatures,
+		"require_linear_history":           &settings.RequireLinearHistory,
+		"required_conversation_resolution": &settings.RequiredConversationResolution,
+		"allow_force_pushes":               &settings.AllowForcePushes,
+		"allow_deletions":                  &settings.AllowDeletions,
+	} {
+		value, err := optionalBoolPtr(request, param)
+		if err != nil {
+			return settings, err
+		}
+		*field = value
+	}
+
+	count, ok, err := OptionalParamOK[float64](request, "required_approving_review_count")
+	if err != nil {
+		return settings, err
+	}
+	if ok {
+		if count < 0 || count > 6 {
+			return settings, fmt.Errorf("required_approving_review_count must be between 0 and 6")
+		}
+		settings.RequiredApprovingReviewCount = github.Ptr(int(count))
+	}
+
+	if _, ok := request.GetArguments()["required_status_checks"]; ok {
+		checks, err := OptionalStringArrayParam(request, "required_status_checks")
+		if err != nil {
+			return settings, err
+		}
+		settings.RequiredStatusChecks = &checks
+	}
+
+	reviewSettings := settings.RequiredApprovingReviewCount != nil || settings.RequireCodeOwnerReviews != nil ||
+		settings.DismissStaleReviews != nil || settings.RequireLastPushApproval != nil
+	if reviewSettings && settings.RequirePullRequest != nil && !*settings.RequirePullRequest {
+		return settings, fmt.Errorf("review settings can't be given when require_pull_request is false")
+	}
+	if settings.StrictStatusChecks != nil && *settings.StrictStatusChecks && settings.RequiredStatusChecks != nil && len(*settings.RequiredStatusChecks) == 0 {
+		return settings, fmt.Errorf("strict_status_checks requires at least one required status check")
+	}
+	return settings, nil
+}
+
+// isEmpty reports whether no setting was given.
+func (s branchProtectionSettings) isEmpty() bool {
+	return s == branchProtectionSettings{}
+}
+
+// apply changes the protection request to the given settings, keeping the settings that weren't given.
+func (s branchProtectionSettings) apply(request *github.ProtectionRequest) {
+	if s.RequirePullRequest != nil && !*s.RequirePullRequest {
+		request.RequiredPullRequestReviews = nil
+	} else if s.RequirePullRequest != nil || s.RequiredApprovingReviewCount != nil || s.RequireCodeOwnerReviews != nil ||
+		s.DismissStaleReviews != nil || s.RequireLastPushApproval != nil {
+		reviews := request.RequiredPullRequestReviews
+		if reviews == nil {
+			reviews = &github.PullRequestReviewsEnforcementRequest{}
+		}
+		if s.RequiredApprovingReviewCount != nil {
+			reviews.RequiredApprovingReviewCount = *s.RequiredApprovingReviewCount
+		}
+		if s.RequireCodeOwnerReviews != nil {
+			reviews.RequireCodeOwnerReviews = *s.RequireCodeOwnerReviews
+		}
+		if s.DismissStaleReviews != nil {
+			reviews.DismissStaleReviews = *s.DismissStaleReviews
+		}
+		if s.RequireLastPushApproval != nil {
+			reviews.RequireLastPushApproval = s.RequireLastPushApproval
+		}
+		request.RequiredPullRequestReviews = reviews
+	}
+
+	if s.RequiredStatusChecks != nil && len(*s.RequiredStatusChecks) == 0 {
+		request.RequiredStatusChecks = nil
+	} else if s.RequiredStatusChecks != nil || s.StrictStatusChecks != nil {
+		checks := statusCheckNames(request.RequiredStatusChecks)
+		if s.RequiredStatusChecks != nil {
+			checks = append([]string{}, *s.RequiredStatusChecks...)
+			sort.Strings(checks)
+		}
+		strict := request.RequiredStatusChecks != nil && request.RequiredStatusChecks.Strict
+		if s.StrictStatusChecks != nil {
+			strict = *s.StrictStatusChecks
+		}
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: strict, Contexts: &checks}
+	}
+
+	if s.EnforceAdmins != nil {
+		request.EnforceAdmins = *s.EnforceAdmins
+	}
+	if s.RequireLinearHistory != nil {
+		request.RequireLinearHistory = s.RequireLinearHistory
+	}
+	if s.RequiredConversationResolution != nil {
+		request.RequiredConversationResolution = s.RequiredConversationResolution
+	}
+	if s.AllowForcePushes != nil {
+		request.AllowForcePushes = s.AllowForcePushes
+	}
+	if s.AllowDeletions != nil {
+		request.AllowDeletions = s.AllowDeletions
+	}
+}
+
+// setBranchProtection replaces the protection of a branch with the request, then requires or stops requiring
+// signed commits, which the protection endpoint doesn't set. It returns the resulting protection.
+func setBranchProtection(ctx context.Context, client *github.Client, owner, repo, branch string, request *github.ProtectionRequest, requiredSignatures *bool) (*github.Protection, *github.Response, error) {
+	protection, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, request)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to update protection of branch %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+
+	if requiredSignatures != nil {
+		if *requiredSignatures {
+			_, resp, err = client.Repositories.RequireSignaturesOnProtectedBranch(ctx, owner, repo, branch)
+		} else {
+			resp, err = client.Repositories.OptionalSignaturesOnProtectedBranch(ctx, owner, repo, branch)
+		}
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to update required signatures of branch %s: %w", branch, err)
+		}
+		_ = resp.Body.Close()
+		protection.RequiredSignatures = &github.SignaturesProtectedBranch{Enabled: requiredSignatures}
+	}
+	return protection, resp, nil
+}
+
+// GetBranchProtection creates a tool to get the protection of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the protection rules of a branch: required pull request reviews, required status checks, signed commits, admin enforcement, linear history, force push and deletion rules, and push restrictions. Reports an unprotected branch as not protected. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if err != nil && !errors.Is(err, github.ErrBranchNotProtected) {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			if resp != nil {
+				defer func() { _ = resp.Body.Close() }()
+			}
+
+			return MarshalledTextResult(ctx, convertToMinimalBranchProtection(branch, protection)), nil
+		}
+}
+
+// CreateBranchProtection creates a tool to protect an unprotected branch.
+func CreateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_branch_protection",
+			mcp.WithDescription(t("TOOL_CREATE_BRANCH_PROTECTION_DESCRIPTION", "Protect a branch that isn't protected yet. Only the given rules are enabled. Use 'update_branch_protection' to change the rules of a protected branch. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_BRANCH_PROTECTION_USER_TITLE", "Create branch protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			WithBranchProtectionSettings(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			settings, err := branchProtectionSettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Protecting the branch through the same endpoint would silently replace existing rules.
+			_, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s is already protected, use update_branch_protection to change its rules", branch)), nil
+			case !errors.Is(err, github.ErrBranchNotProtected):
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			create := &github.ProtectionRequest{}
+			settings.apply(create)
+			protection, resp, err := setBranchProtection(ctx, client, owner, repo, branch, create, settings.RequiredSignatures)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create branch protection", resp, err), nil
+			}
+
+			return MarshalledTextResult(ctx, convertToMinimalBranchProtection(branch, protection)), nil
+		}
+}
+
+// UpdateBranchProtection creates a tool to change the protection rules of a protected branch.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Change the protection rules of a protected branch. Only the given rules change; the others, including push restrictions and review dismissal restrictions, are kept. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			WithBranchProtectionSettings(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			settings, err := branchProtectionSettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if settings.isEmpty() {
+				return mcp.NewToolResultError("no protection rules to update were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s is not protected, use create_branch_protection to protect it", branch)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			update := protectionRequest(current)
+			settings.apply(update)
+			protection, resp, err := setBranchProtection(ctx, client, owner, repo, branch, update, settings.RequiredSignatures)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update branch protection", resp, err), nil
+			}
+			if settings.RequiredSignatures == nil {
+				// The update doesn't touch signed commits, so keep reporting the current requirement.
+				protection.RequiredSignatures = current.RequiredSignatures
+			}
+
+			return MarshalledTextResult(ctx, convertToMinimalBranchProtection(branch, protection)), nil
+		}
+}
+
+// DeleteBranchProtection creates a tool to remove the protection of a branch.
+func DeleteBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_branch_protection",
+			mcp.WithDescription(t("TOOL_DELETE_BRANCH_PROTECTION_DESCRIPTION", "Remove all protection rules from a branch, allowing anyone with write access to push to it, force push and delete it. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_BRANCH_PROTECTION_USER_TITLE", "Delete branch protection"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s is not protected or doesn't exist", branch)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("protection of branch %s removed", branch)), nil
+		}
+}
@@ -0,0 +1,459 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var branchNotProtected = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+})
+
+// mockProtection is the protection of a branch of an organization repository with every kind of rule.
+func mockProtection() *github.Protection {
+	return &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			RequireCodeOwnerReviews:      true,
+			DismissalRestrictions: &github.DismissalRestrictions{
+				Teams: []*github.Team{{Slug: github.Ptr("maintainers")}},
+			},
+		},
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "test"}, {Context: "build"}},
+		},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: false},
+		RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+		AllowForcePushes:     &github.AllowForcePushes{Enabled: false},
+		AllowDeletions:       &github.AllowDeletions{Enabled: false},
+		RequiredSignatures:   &github.SignaturesProtectedBranch{Enabled: github.Ptr(true)},
+		Restrictions: &github.BranchRestrictions{
+			Users: []*github.User{{Login: github.Ptr("release-bot")}},
+		},
+	}
+}
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       MinimalBranchProtection
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					expectPath(t, "/repos/octo-org/api/branches/main/protection").andThen(
+						mockResponse(t, http.StatusOK, mockProtection()),
+					),
+				),
+			),
+			expected: MinimalBranchProtection{
+				Branch:    "main",
+				Protected: true,
+				RequiredPullRequestReviews: &MinimalRequiredPullRequestReviews{
+					RequiredApprovingReviewCount: 1,
+					RequireCodeOwnerReviews:      true,
+					DismissalRestrictions: &MinimalBranchProtectionRestriction{
+						Users: []string{},
+						Teams: []string{"maintainers"},
+						Apps:  []string{},
+					},
+				},
+				RequiredStatusChecks: &MinimalRequiredStatusChecks{Strict: true, Checks: []string{"build", "test"}},
+				RequiredSignatures:   true,
+				RequireLinearHistory: true,
+				Restrictions: &MinimalBranchProtectionRestriction{
+					Users: []string{"release-bot"},
+					Teams: []string{},
+					Apps:  []string{},
+				},
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, branchNotProtected),
+			),
+			expected: MinimalBranchProtection{Branch: "main"},
+		},
+		{
+			name: "no admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":  "octo-org",
+				"repo":   "api",
+				"branch": "main",
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalBranchProtection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_CreateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_branch_protection", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "required_approving_review_count")
+	assert.Contains(t, tool.InputSchema.Properties, "required_status_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "required_signatures")
+	assert.Contains(t, tool.InputSchema.Properties, "enforce_admins")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       MinimalBranchProtection
+	}{
+		{
+			name: "protect branch with reviews, checks and signed commits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, branchNotProtected),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{
+						"required_pull_request_reviews": map[string]any{
+							"required_approving_review_count": float64(2),
+							"require_code_owner_reviews":      false,
+							"dismiss_stale_reviews":           true,
+						},
+						"required_status_checks": map[string]any{
+							"strict":   true,
+							"contexts": []any{"build", "test"},
+						},
+						"enforce_admins": true,
+						"restrictions":   nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Protection{
+							RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2, DismissStaleReviews: true},
+							RequiredStatusChecks:       &github.RequiredStatusChecks{Strict: true, Contexts: &[]string{"build", "test"}},
+							EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesProtectionRequiredSignaturesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusOK, &github.SignaturesProtectedBranch{Enabled: github.Ptr(true)}),
+				),
+			),
+			requestArgs: map[string]any{
+				"required_approving_review_count": float64(2),
+				"dismiss_stale_reviews":           true,
+				"required_status_checks":          []any{"test", "build"},
+				"strict_status_checks":            true,
+				"enforce_admins":                  true,
+				"required_signatures":             true,
+			},
+			expected: MinimalBranchProtection{
+				Branch:    "main",
+				Protected: true,
+				RequiredPullRequestReviews: &MinimalRequiredPullRequestReviews{
+					RequiredApprovingReviewCount: 2,
+					DismissStaleReviews:          true,
+				},
+				RequiredStatusChecks: &MinimalRequiredStatusChecks{Strict: true, Checks: []string{"build", "test"}},
+				RequiredSignatures:   true,
+				EnforceAdmins:        true,
+			},
+		},
+		{
+			name: "already protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, mockProtection()),
+			),
+			requestArgs:    map[string]any{"enforce_admins": true},
+			expectError:    true,
+			expectedErrMsg: "branch main is already protected, use update_branch_protection to change its rules",
+		},
+		{
+			name:         "review settings without pull requests",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"require_pull_request":       false,
+				"require_code_owner_reviews": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "review settings can't be given when require_pull_request is false",
+		},
+		{
+			name:           "too many required reviews",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"required_approving_review_count": float64(7)},
+			expectError:    true,
+			expectedErrMsg: "required_approving_review_count must be between 0 and 6",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "octo-org", "repo": "api", "branch": "main"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalBranchProtection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "change some rules and keep the others",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, mockProtection()),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body github.ProtectionRequest
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						// The given rules change
+						assert.Equal(t, 2, body.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+						assert.True(t, body.EnforceAdmins)
+						assert.False(t, body.GetAllowDeletions())
+						// The others are kept
+						assert.True(t, body.RequiredPullRequestReviews.RequireCodeOwnerReviews)
+						assert.Equal(t, &[]string{"maintainers"}, body.RequiredPullRequestReviews.DismissalRestrictionsRequest.Teams)
+						assert.Equal(t, []*github.RequiredStatusCheck{{Context: "test"}, {Context: "build"}}, *body.RequiredStatusChecks.Checks)
+						assert.True(t, body.GetRequireLinearHistory())
+						assert.Equal(t, []string{"release-bot"}, body.Restrictions.Users)
+
+						protection := mockProtection()
+						protection.RequiredSignatures = nil
+						protection.RequiredPullRequestReviews.RequiredApprovingReviewCount = 2
+						protection.EnforceAdmins.Enabled = true
+						mockResponse(t, http.StatusOK, protection)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"required_approving_review_count": float64(2),
+				"enforce_admins":                  true,
+				"allow_deletions":                 false,
+			},
+		},
+		{
+			name: "drop the pull request and status check requirements",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, mockProtection()),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Nil(t, body["required_pull_request_reviews"])
+						assert.Nil(t, body["required_status_checks"])
+						mockResponse(t, http.StatusOK, &github.Protection{})(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposBranchesProtectionRequiredSignaturesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"require_pull_request":   false,
+				"required_status_checks": []any{},
+				"required_signatures":    false,
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, branchNotProtected),
+			),
+			requestArgs:    map[string]any{"enforce_admins": true},
+			expectError:    true,
+			expectedErrMsg: "branch main is not protected, use create_branch_protection to protect it",
+		},
+		{
+			name:           "nothing to update",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "no protection rules to update were given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "octo-org", "repo": "api", "branch": "main"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalBranchProtection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.True(t, returned.Protected)
+			if _, ok := tc.requestArgs["required_signatures"]; ok {
+				assert.False(t, returned.RequiredSignatures)
+			} else {
+				// Signed commits weren't touched, so the current requirement is reported.
+				assert.True(t, returned.RequiredSignatures)
+				assert.Equal(t, 2, returned.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+			}
+		})
+	}
+}
+
+func Test_DeleteBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_branch_protection", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "protection removed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposBranchesProtectionByOwnerByRepoByBranch,
+					expectPath(t, "/repos/octo-org/api/branches/main/protection").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteReposBranchesProtectionByOwnerByRepoByBranch, branchNotProtected),
+			),
+			expectError:    true,
+			expectedErrMsg: "branch main is not protected or doesn't exist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "octo-org",
+				"repo":   "api",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, "protection of branch main removed", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,843 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCheckRunAnnotations is the number of annotations get_check_run returns.
+const maxCheckRunAnnotations = 100
+
+// MinimalCheckRun is the trimmed output type for check run objects.
+type MinimalCheckRun struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	HeadSHA     string `json:"head_sha"`
+	Status      string `json:"status"`
+	Conclusion  string `json:"conclusion,omitempty"`
+	App         string `json:"app,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	DetailsURL  string `json:"details_url,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Annotations int    `json:"annotations_count"`
+	CheckSuite  int64  `json:"check_suite_id,omitempty"`
+}
+
+// MinimalCheckSuite is the trimmed output type for check suite objects.
+type MinimalCheckSuite struct {
+	ID         int64  `json:"id"`
+	HeadBranch string `json:"head_branch,omitempty"`
+	HeadSHA    string `json:"head_sha"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	App        string `json:"app,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// MinimalCheckRunAnnotation is the trimmed output type for check run annotation objects.
+type MinimalCheckRunAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Level     string `json:"level"`
+	Title     string `json:"title,omitempty"`
+	Message   string `json:"message"`
+	Details   string `json:"raw_details,omitempty"`
+}
+
+func convertToMinimalCheckRun(run *github.CheckRun) MinimalCheckRun {
+	return MinimalCheckRun{
+		ID:          run.GetID(),
+		Name:        run.GetName(),
+		HeadSHA:     run.GetHeadSHA(),
+		Status:      run.GetStatus(),
+		Conclusion:  run.GetConclusion(),
+		App:         run.GetApp().GetSlug(),
+		HTMLURL:     run.GetHTMLURL(),
+		DetailsURL:  run.GetDetailsURL(),
+		StartedAt:   formatOptionalTimestamp(run.StartedAt),
+		CompletedAt: formatOptionalTimestamp(run.CompletedAt),
+		Title:       run.GetOutput().GetTitle(),
+		Summary:     run.GetOutput().GetSummary(),
+		Annotations: run.GetOutput().GetAnnotationsCount(),
+		CheckSuite:  run.GetCheckSuite().GetID(),
+	}
+}
+
+func convertToMinimalCheckSuite(suite *github.CheckSuite) MinimalCheckSuite {
+	return MinimalCheckSuite{
+		ID:         suite.GetID(),
+		HeadBranch: suite.GetHeadBranch(),
+		HeadSHA:    suite.GetHeadSHA(),
+		Status:     suite.GetStatus(),
+		Conclusion: suite.GetConclusion(),
+		App:        suite.GetApp().GetSlug(),
+		CreatedAt:  formatOptionalTimestamp(suite.CreatedAt),
+		UpdatedAt:  formatOptionalTimestamp(suite.UpdatedAt),
+	}
+}
+
+// ListCheckRuns creates a tool to list the check runs of a ref.
+func ListCheckRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_runs",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_RUNS_DESCRIPTION", "List the check runs of a commit, branch or tag, from GitHub Actions and any other GitHub App, such as external CI or code analysis services. Use it to find out which checks make a pull request fail, then get_check_run for their annotations.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_RUNS_USER_TITLE", "List check runs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name, or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only return check runs with this name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only return check runs with this status"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Whether to return only the most recent check run of each name, or all of them including reruns. Defaults to latest."),
+				mcp.Enum("latest", "all"),
+			),
+			mcp.WithNumber("app_id",
+				mcp.Description("Only return check runs created by the GitHub App with this ID"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			appID, err := OptionalIntParam(request, "app_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckRunsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if checkName != "" {
+				opts.CheckName = github.Ptr(checkName)
+			}
+			if status != "" {
+				opts.Status = github.Ptr(status)
+			}
+			if filter != "" {
+				opts.Filter = github.Ptr(filter)
+			}
+			if appID != 0 {
+				opts.AppID = github.Ptr(int64(appID))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list check runs of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			checkRuns := make([]MinimalCheckRun, 0, len(result.CheckRuns))
+			for _, run := range result.CheckRuns {
+				checkRuns = append(checkRuns, convertToMinimalCheckRun(run))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count": result.GetTotal(),
+				"check_runs":  checkRuns,
+			}), nil
+		}
+}
+
+// GetCheckRun creates a tool to get a check run with its annotations.
+func GetCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_check_run",
+			mcp.WithDescription(t("TOOL_GET_CHECK_RUN_DESCRIPTION", fmt.Sprintf("Get a check run with its output and up to %d of its annotations, which point at the lines of code a check complains about.", maxCheckRunAnnotations))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CHECK_RUN_USER_TITLE", "Get check run"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The ID of the check run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			run, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, int64(checkRunID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get check run %d", checkRunID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			annotations := []MinimalCheckRunAnnotation{}
+			if run.GetOutput().GetAnnotationsCount() > 0 {
+				opts := &github.ListOptions{PerPage: 100}
+				for len(annotations) < maxCheckRunAnnotations {
+					page, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, run.GetID(), opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to list annotations of check run %d", checkRunID),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, annotation := range page {
+						annotations = append(annotations, MinimalCheckRunAnnotation{
+							Path:      annotation.GetPath(),
+							StartLine: annotation.GetStartLine(),
+							EndLine:   annotation.GetEndLine(),
+							Level:     annotation.GetAnnotationLevel(),
+							Title:     annotation.GetTitle(),
+							Message:   annotation.GetMessage(),
+							Details:   annotation.GetRawDetails(),
+						})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"check_run":             convertToMinimalCheckRun(run),
+				"text":                  run.GetOutput().GetText(),
+				"annotations":           annotations[:min(len(annotations), maxCheckRunAnnotations)],
+				"annotations_truncated": run.GetOutput().GetAnnotationsCount() > maxCheckRunAnnotations,
+			}), nil
+		}
+}
+
+// ListCheckSuites creates a tool to list the check suites of a ref.
+func ListCheckSuites(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_suites",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_SUITES_DESCRIPTION", "List the check suites of a commit, branch or tag. Each GitHub App that checks a commit, such as GitHub Actions or an external CI service, creates a check suite grouping its check runs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_SUITES_USER_TITLE", "List check suites"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name, or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only return check suites with a check run of this name"),
+			),
+			mcp.WithNumber("app_id",
+				mcp.Description("Only return the check suite of the GitHub App with this ID"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			appID, err := OptionalIntParam(request, "app_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckSuiteOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if checkName != "" {
+				opts.CheckName = github.Ptr(checkName)
+			}
+			if appID != 0 {
+				opts.AppID = github.Ptr(int64(appID))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list check suites of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			checkSuites := make([]MinimalCheckSuite, 0, len(result.CheckSuites))
+			for _, suite := range result.CheckSuites {
+				checkSuites = append(checkSuites, convertToMinimalCheckSuite(suite))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count":  result.GetTotal(),
+				"check_suites": checkSuites,
+			}), nil
+		}
+}
+
+// maxCheckRunAnnotationsPerRequest is the number of annotations the API accepts per request. More annotations are
+// added to a check run with further requests.
+const maxCheckRunAnnotationsPerRequest = 50
+
+// checkRunConclusions are the conclusions a check run can be completed with.
+var checkRunConclusions = []string{"action_required", "cancelled", "failure", "neutral", "skipped", "success", "timed_out"}
+
+// withCheckRunOutput adds the parameters of the output of a check run to a tool.
+func withCheckRunOutput() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("title",
+			mcp.Description("Title of the check run output, shown next to the check. Required with the output"),
+		)(tool)
+		mcp.WithString("summary",
+			mcp.Description("Summary of the check run output, in Markdown. Required with the output"),
+		)(tool)
+		mcp.WithString("text",
+			mcp.Description("Details of the check run output, in Markdown"),
+		)(tool)
+		mcp.WithArray("annotations",
+			mcp.Description(fmt.Sprintf("Annotations pointing at lines of code, shown in the diff of pull requests. They're added to those of the check run, %d per request", maxCheckRunAnnotationsPerRequest)),
+			mcp.Items(map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"path", "start_line", "end_line", "annotation_level", "message"},
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "path of the file, relative to the root of the repository",
+					},
+					"start_line": map[string]interface{}{
+						"type":        "number",
+						"description": "first line of the annotation",
+					},
+					"end_line": map[string]interface{}{
+						"type":        "number",
+						"description": "last line of the annotation",
+					},
+					"annotation_level": map[string]interface{}{
+						"type":        "string",
+						"description": "level of the annotation",
+						"enum":        []string{"notice", "warning", "failure"},
+					},
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "what the annotation is about",
+					},
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "title of the annotation",
+					},
+					"raw_details": map[string]interface{}{
+						"type":        "string",
+						"description": "details of the annotation, such as the output of a tool",
+					},
+				},
+			}),
+		)(tool)
+	}
+}
+
+// parseCheckRunAnnotations converts the annotations argument of a tool call.
+func parseCheckRunAnnotations(arg []interface{}) ([]*github.CheckRunAnnotation, error) {
+	annotations := make([]*github.CheckRunAnnotation, 0, len(arg))
+	for i, item := range arg {
+		annotationMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("annotation %d must be an object", i)
+		}
+		path, _ := annotationMap["path"].(string)
+		message, _ := annotationMap["message"].(string)
+		if path == "" || message == "" {
+			return nil, fmt.Errorf("annotation %d must have a path and a message", i)
+		}
+		startLine, okStart := annotationMap["start_line"].(float64)
+		endLine, okEnd := annotationMap["end_line"].(float64)
+		if !okStart || !okEnd || startLine < 1 || endLine < startLine {
+			return nil, fmt.Errorf("annotation %d must have a start_line and an end_line not before it", i)
+		}
+		level, _ := annotationMap["annotation_level"].(string)
+		if level != "notice" && level != "warning" && level != "failure" {
+			return nil, fmt.Errorf("annotation_level of annotation %d must be notice, warning or failure", i)
+		}
+
+		annotation := &github.CheckRunAnnotation{
+			Path:            github.Ptr(path),
+			StartLine:       github.Ptr(int(startLine)),
+			EndLine:         github.Ptr(int(endLine)),
+			AnnotationLevel: github.Ptr(level),
+			Message:         github.Ptr(message),
+		}
+		if title, _ := annotationMap["title"].(string); title != "" {
+			annotation.Title = github.Ptr(title)
+		}
+		if details, _ := annotationMap["raw_details"].(string); details != "" {
+			annotation.RawDetails = github.Ptr(details)
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations, nil
+}
+
+// checkRunOutputParams returns the output of a check run given in a tool call, without its annotations, and the
+// annotations. The title and summary default to those of current, as the API requires them with any output. The
+// output is nil if none is given.
+func checkRunOutputParams(request mcp.CallToolRequest, current *github.CheckRunOutput) (*github.CheckRunOutput, []*github.CheckRunAnnotation, error) {
+	title, err := OptionalParam[string](request, "title")
+	if err != nil {
+		return nil, nil, err
+	}
+	summary, err := OptionalParam[string](request, "summary")
+	if err != nil {
+		return nil, nil, err
+	}
+	text, err := OptionalParam[string](request, "text")
+	if err != nil {
+		return nil, nil, err
+	}
+	var annotations []*github.CheckRunAnnotation
+	if arg, ok := request.GetArguments()["annotations"]; ok && arg != nil {
+		annotationsArg, ok := arg.([]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("annotations must be an array of objects")
+		}
+		if annotations, err = parseCheckRunAnnotations(annotationsArg); err != nil {
+			return nil, nil, err
+		}
+	}
+	if title == "" && summary == "" && text == "" && len(annotations) == 0 {
+		return nil, nil, nil
+	}
+
+	if title == "" {
+		title = current.GetTitle()
+	}
+	if summary == "" {
+		summary = current.GetSummary()
+	}
+	if title == "" || summary == "" {
+		return nil, nil, fmt.Errorf("the output of a check run needs a title and a summary")
+	}
+	output := &github.CheckRunOutput{Title: github.Ptr(title), Summary: github.Ptr(summary)}
+	if text != "" {
+		output.Text = github.Ptr(text)
+	}
+	return output, annotations, nil
+}
+
+// checkRunStatusParams returns the status and conclusion given in a tool call, checking that a completed check run
+// has a conclusion.
+func checkRunStatusParams(request mcp.CallToolRequest) (string, string, error) {
+	status, err := OptionalParam[string](request, "status")
+	if err != nil {
+		return "", "", err
+	}
+	conclusion, err := OptionalParam[string](request, "conclusion")
+	if err != nil {
+		return "", "", err
+	}
+	switch status {
+	case "", "queued", "in_progress", "completed":
+	default:
+		return "", "", fmt.Errorf("invalid status: %s. Supported statuses are: queued, in_progress, completed", status)
+	}
+	if conclusion != "" && !slices.Contains(checkRunConclusions, conclusion) {
+		return "", "", fmt.Errorf("invalid conclusion: %s. Supported conclusions are: %s", conclusion, strings.Join(checkRunConclusions, ", "))
+	}
+	if status == "completed" && conclusion == "" {
+		return "", "", fmt.Errorf("a completed check run needs a conclusion")
+	}
+	if conclusion != "" && status != "" && status != "completed" {
+		return "", "", fmt.Errorf("a check run with a conclusion is completed, not %s", status)
+	}
+	return status, conclusion, nil
+}
+
+// addCheckRunAnnotations adds annotations to a check run in batches of the size the API accepts. The output's title
+// and summary are sent along, as the API requires them.
+func addCheckRunAnnotations(ctx context.Context, client *github.Client, owner, repo string, run *github.CheckRun, output *github.CheckRunOutput, annotations []*github.CheckRunAnnotation) (*github.CheckRun, *github.Response, error) {
+	for start := 0; start < len(annotations); start += maxCheckRunAnnotationsPerRequest {
+		batch := annotations[start:min(start+maxCheckRunAnnotationsPerRequest, len(annotations))]
+		updated, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, run.GetID(), github.UpdateCheckRunOptions{
+			Name: run.GetName(),
+			Output: &github.CheckRunOutput{
+				Title:       output.Title,
+				Summary:     output.Summary,
+				Annotations: batch,
+			},
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		run = updated
+	}
+	return run, nil, nil
+}
+
+// CreateCheckRun creates a tool to create a check run on a commit.
+func CreateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_check_run",
+			mcp.WithDescription(t("TOOL_CREATE_CHECK_RUN_DESCRIPTION", "Create a check run on a commit to publish the results of an analysis, with a Markdown summary and annotations on lines of code that show in the diff of pull requests. Only GitHub Apps can create check runs, so the server must be authenticated with an installation token, such as one from create_installation_token.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CHECK_RUN_USER_TITLE", "Create check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the check, e.g. 'code-review'"),
+			),
+			mcp.WithString("head_sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to check"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Status of the check run. Defaults to queued, or completed with a conclusion"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("conclusion",
+				mcp.Description("Conclusion of the check run, which completes it. Required if the status is completed"),
+				mcp.Enum(checkRunConclusions...),
+			),
+			mcp.WithString("details_url",
+				mcp.Description("URL of the full details of the check"),
+			),
+			mcp.WithString("external_id",
+				mcp.Description("Reference of the check run in the system that runs it"),
+			),
+			withCheckRunOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headSHA, err := RequiredParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, conclusion, err := checkRunStatusParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			detailsURL, err := OptionalParam[string](request, "details_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			externalID, err := OptionalParam[string](request, "external_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			output, annotations, err := checkRunOutputParams(request, nil)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := github.CreateCheckRunOptions{
+				Name:    name,
+				HeadSHA: headSHA,
+				Output:  output,
+			}
+			if status != "" {
+				opts.Status = github.Ptr(status)
+			}
+			if conclusion != "" {
+				opts.Conclusion = github.Ptr(conclusion)
+			}
+			if detailsURL != "" {
+				opts.DetailsURL = github.Ptr(detailsURL)
+			}
+			if externalID != "" {
+				opts.ExternalID = github.Ptr(externalID)
+			}
+			if output != nil {
+				output.Annotations = annotations[:min(len(annotations), maxCheckRunAnnotationsPerRequest)]
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			run, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create check run %s", name),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if len(annotations) > maxCheckRunAnnotationsPerRequest {
+				updated, updateResp, err := addCheckRunAnnotations(ctx, client, owner, repo, run, output, annotations[maxCheckRunAnnotationsPerRequest:])
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("created check run %s, but failed to add all of its annotations", name),
+						updateResp,
+						err,
+					), nil
+				}
+				run = updated
+			}
+
+			return MarshalledTextResult(ctx, convertToMinimalCheckRun(run)), nil
+		}
+}
+
+// UpdateCheckRun creates a tool to update a check run.
+func UpdateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_check_run",
+			mcp.WithDescription(t("TOOL_UPDATE_CHECK_RUN_DESCRIPTION", "Update a check run created by the same GitHub App, e.g. to complete it with a conclusion once an analysis is done. Annotations are added to those it already has. The server must be authenticated with an installation token of the app, such as one from create_installation_token.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CHECK_RUN_USER_TITLE", "Update check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The ID of the check run"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name of the check"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Status of the check run"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("conclusion",
+				mcp.Description("Conclusion of the check run, which completes it. Required if the status is completed"),
+				mcp.Enum(checkRunConclusions...),
+			),
+			mcp.WithString("details_url",
+				mcp.Description("URL of the full details of the check"),
+			),
+			withCheckRunOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, conclusion, err := checkRunStatusParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			detailsURL, err := OptionalParam[string](request, "details_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API requires the name with every update, and the title and summary with any output
+			current, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, int64(checkRunID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get check run %d", checkRunID),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			output, annotations, err := checkRunOutputParams(request, current.GetOutput())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if name == "" {
+				name = current.GetName()
+			}
+			opts := github.UpdateCheckRunOptions{
+				Name:   name,
+				Output: output,
+			}
+			if status != "" {
+				opts.Status = github.Ptr(status)
+			}
+			if conclusion != "" {
+				opts.Conclusion = github.Ptr(conclusion)
+			}
+			if detailsURL != "" {
+				opts.DetailsURL = github.Ptr(detailsURL)
+			}
+			if output != nil {
+				output.Annotations = annotations[:min(len(annotations), maxCheckRunAnnotationsPerRequest)]
+			}
+
+			run, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, int64(checkRunID), opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update check run %d", checkRunID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if len(annotations) > maxCheckRunAnnotationsPerRequest {
+				updated, updateResp, err := addCheckRunAnnotations(ctx, client, owner, repo, run, output, annotations[maxCheckRunAnnotationsPerRequest:])
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("updated check run %d, but failed to add all of its annotations", checkRunID),
+						updateResp,
+						err,
+					), nil
+				}
+				run = updated
+			}
+
+			return MarshalledTextResult(ctx, convertToMinimalCheckRun(run)), nil
+		}
+}
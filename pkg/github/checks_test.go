@@ -0,0 +1,753 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCheckRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "check_name")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "app_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(1),
+		CheckRuns: []*github.CheckRun{
+			{
+				ID:          github.Ptr(int64(11)),
+				Name:        github.Ptr("lint"),
+				HeadSHA:     github.Ptr("abc123"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("failure"),
+				App:         &github.App{Slug: github.Ptr("external-ci")},
+				HTMLURL:     github.Ptr("https://github.com/owner/repo/runs/11"),
+				DetailsURL:  github.Ptr("https://ci.example.com/11"),
+				StartedAt:   &github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+				CompletedAt: &github.Timestamp{Time: time.Date(2024, 5, 1, 12, 3, 0, 0, time.UTC)},
+				Output: &github.CheckRunOutput{
+					Title:            github.Ptr("2 problems"),
+					Summary:          github.Ptr("Lint failed"),
+					AnnotationsCount: github.Ptr(2),
+				},
+				CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(5))},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRuns   []MinimalCheckRun
+	}{
+		{
+			name: "check runs of a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"check_name": "lint",
+						"status":     "completed",
+						"filter":     "all",
+						"app_id":     "42",
+						"page":       "1",
+						"per_page":   "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCheckRuns),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "abc123",
+				"check_name": "lint",
+				"status":     "completed",
+				"filter":     "all",
+				"app_id":     float64(42),
+			},
+			expectedRuns: []MinimalCheckRun{
+				{
+					ID:          11,
+					Name:        "lint",
+					HeadSHA:     "abc123",
+					Status:      "completed",
+					Conclusion:  "failure",
+					App:         "external-ci",
+					HTMLURL:     "https://github.com/owner/repo/runs/11",
+					DetailsURL:  "https://ci.example.com/11",
+					StartedAt:   "2024-05-01T12:00:00Z",
+					CompletedAt: "2024-05-01T12:03:00Z",
+					Title:       "2 problems",
+					Summary:     "Lint failed",
+					Annotations: 2,
+					CheckSuite:  5,
+				},
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs of missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCheckRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TotalCount int               `json:"total_count"`
+				CheckRuns  []MinimalCheckRun `json:"check_runs"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, len(tc.expectedRuns), response.TotalCount)
+			assert.Equal(t, tc.expectedRuns, response.CheckRuns)
+		})
+	}
+}
+
+func Test_GetCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "check_run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	mockCheckRun := &github.CheckRun{
+		ID:         github.Ptr(int64(11)),
+		Name:       github.Ptr("lint"),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("failure"),
+		Output: &github.CheckRunOutput{
+			Title:            github.Ptr("1 problem"),
+			Text:             github.Ptr("Run golangci-lint to reproduce"),
+			AnnotationsCount: github.Ptr(1),
+		},
+	}
+	mockAnnotations := []*github.CheckRunAnnotation{
+		{
+			Path:            github.Ptr("main.go"),
+			StartLine:       github.Ptr(10),
+			EndLine:         github.Ptr(12),
+			AnnotationLevel: github.Ptr("failure"),
+			Title:           github.Ptr("errcheck"),
+			Message:         github.Ptr("error return value is not checked"),
+		},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedAnnotations []MinimalCheckRunAnnotation
+	}{
+		{
+			name: "check run with annotations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					mockCheckRun,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					expectPath(t, "/repos/owner/repo/check-runs/11/annotations").andThen(
+						mockResponse(t, http.StatusOK, mockAnnotations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(11),
+			},
+			expectedAnnotations: []MinimalCheckRunAnnotation{
+				{Path: "main.go", StartLine: 10, EndLine: 12, Level: "failure", Title: "errcheck", Message: "error return value is not checked"},
+			},
+		},
+		{
+			name: "check run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get check run 99",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				CheckRun    MinimalCheckRun             `json:"check_run"`
+				Text        string                      `json:"text"`
+				Annotations []MinimalCheckRunAnnotation `json:"annotations"`
+				Truncated   bool                        `json:"annotations_truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, int64(11), response.CheckRun.ID)
+			assert.Equal(t, "failure", response.CheckRun.Conclusion)
+			assert.Equal(t, "Run golangci-lint to reproduce", response.Text)
+			assert.Equal(t, tc.expectedAnnotations, response.Annotations)
+			assert.False(t, response.Truncated)
+		})
+	}
+}
+
+func Test_ListCheckSuites(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckSuites(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_suites", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "check_name")
+	assert.Contains(t, tool.InputSchema.Properties, "app_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedSuites []MinimalCheckSuite
+	}{
+		{
+			name: "check suites of a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"app_id":   "42",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ListCheckSuiteResults{
+							Total: github.Ptr(1),
+							CheckSuites: []*github.CheckSuite{
+								{
+									ID:         github.Ptr(int64(5)),
+									HeadBranch: github.Ptr("main"),
+									HeadSHA:    github.Ptr("abc123"),
+									Status:     github.Ptr("completed"),
+									Conclusion: github.Ptr("failure"),
+									App:        &github.App{Slug: github.Ptr("external-ci")},
+									CreatedAt:  &github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"ref":    "main",
+				"app_id": float64(42),
+			},
+			expectedSuites: []MinimalCheckSuite{
+				{ID: 5, HeadBranch: "main", HeadSHA: "abc123", Status: "completed", Conclusion: "failure", App: "external-ci", CreatedAt: "2024-05-01T12:00:00Z"},
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check suites of missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCheckSuites(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TotalCount  int                 `json:"total_count"`
+				CheckSuites []MinimalCheckSuite `json:"check_suites"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, 1, response.TotalCount)
+			assert.Equal(t, tc.expectedSuites, response.CheckSuites)
+		})
+	}
+}
+
+func Test_CreateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "head_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "conclusion")
+	assert.Contains(t, tool.InputSchema.Properties, "summary")
+	assert.Contains(t, tool.InputSchema.Properties, "annotations")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "head_sha"})
+
+	annotation := map[string]interface{}{
+		"path":             "main.go",
+		"start_line":       float64(10),
+		"end_line":         float64(12),
+		"annotation_level": "warning",
+		"message":          "unchecked error",
+	}
+	manyAnnotations := make([]interface{}, 60)
+	for i := range manyAnnotations {
+		manyAnnotations[i] = annotation
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRun    MinimalCheckRun
+	}{
+		{
+			name: "completed check run with output",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "code-review",
+						"head_sha":    "abc123",
+						"conclusion":  "neutral",
+						"details_url": "https://example.com/reviews/1",
+						"output": map[string]interface{}{
+							"title":   "1 suggestion",
+							"summary": "## Review\nOne suggestion",
+							"annotations": []interface{}{
+								map[string]interface{}{
+									"path":             "main.go",
+									"start_line":       float64(10),
+									"end_line":         float64(12),
+									"annotation_level": "warning",
+									"message":          "unchecked error",
+								},
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CheckRun{
+							ID:         github.Ptr(int64(11)),
+							Name:       github.Ptr("code-review"),
+							HeadSHA:    github.Ptr("abc123"),
+							Status:     github.Ptr("completed"),
+							Conclusion: github.Ptr("neutral"),
+							Output: &github.CheckRunOutput{
+								Title:            github.Ptr("1 suggestion"),
+								Summary:          github.Ptr("## Review\nOne suggestion"),
+								AnnotationsCount: github.Ptr(1),
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "code-review",
+				"head_sha":    "abc123",
+				"conclusion":  "neutral",
+				"details_url": "https://example.com/reviews/1",
+				"title":       "1 suggestion",
+				"summary":     "## Review\nOne suggestion",
+				"annotations": []interface{}{annotation},
+			},
+			expectedRun: MinimalCheckRun{ID: 11, Name: "code-review", HeadSHA: "abc123", Status: "completed", Conclusion: "neutral", Title: "1 suggestion", Summary: "## Review\nOne suggestion", Annotations: 1},
+		},
+		{
+			name: "annotations beyond the request limit are added by updates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body github.CreateCheckRunOptions
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Len(t, body.Output.Annotations, 50)
+						mockResponse(t, http.StatusCreated, &github.CheckRun{
+							ID:   github.Ptr(int64(12)),
+							Name: github.Ptr("lint"),
+						})(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCheckRunsByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body github.UpdateCheckRunOptions
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "lint", body.Name)
+						assert.Equal(t, "Lint", body.Output.GetTitle())
+						assert.Len(t, body.Output.Annotations, 10)
+						mockResponse(t, http.StatusOK, &github.CheckRun{
+							ID:     github.Ptr(int64(12)),
+							Name:   github.Ptr("lint"),
+							Status: github.Ptr("in_progress"),
+							Output: &github.CheckRunOutput{AnnotationsCount: github.Ptr(60)},
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "lint",
+				"head_sha":    "abc123",
+				"status":      "in_progress",
+				"title":       "Lint",
+				"summary":     "60 warnings",
+				"annotations": manyAnnotations,
+			},
+			expectedRun: MinimalCheckRun{ID: 12, Name: "lint", Status: "in_progress", Annotations: 60},
+		},
+		{
+			name:         "completed without conclusion",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+				"status":   "completed",
+			},
+			expectError:    true,
+			expectedErrMsg: "a completed check run needs a conclusion",
+		},
+		{
+			name:         "output without summary",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+				"title":    "Lint",
+			},
+			expectError:    true,
+			expectedErrMsg: "the output of a check run needs a title and a summary",
+		},
+		{
+			name:         "invalid annotation",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+				"title":    "Lint",
+				"summary":  "1 warning",
+				"annotations": []interface{}{
+					map[string]interface{}{"path": "main.go", "start_line": float64(12), "end_line": float64(10), "annotation_level": "warning", "message": "unchecked error"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "annotation 0 must have a start_line and an end_line not before it",
+		},
+		{
+			name: "not authenticated as an app",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "You must authenticate via a GitHub App."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create check run lint",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var run MinimalCheckRun
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &run))
+			assert.Equal(t, tc.expectedRun, run)
+		})
+	}
+}
+
+func Test_UpdateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "check_run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "conclusion")
+	assert.Contains(t, tool.InputSchema.Properties, "annotations")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	currentRun := &github.CheckRun{
+		ID:     github.Ptr(int64(11)),
+		Name:   github.Ptr("code-review"),
+		Status: github.Ptr("in_progress"),
+		Output: &github.CheckRunOutput{
+			Title:   github.Ptr("Reviewing"),
+			Summary: github.Ptr("Review in progress"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRun    MinimalCheckRun
+	}{
+		{
+			name: "complete with annotations keeping the output",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					currentRun,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCheckRunsByOwnerByRepoByCheckRunId,
+					expectRequestBody(t, map[string]interface{}{
+						"name":       "code-review",
+						"conclusion": "failure",
+						"output": map[string]interface{}{
+							"title":   "Reviewing",
+							"summary": "Review in progress",
+							"annotations": []interface{}{
+								map[string]interface{}{
+									"path":             "main.go",
+									"start_line":       float64(3),
+									"end_line":         float64(3),
+									"annotation_level": "failure",
+									"message":          "nil dereference",
+									"title":            "bug",
+								},
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.CheckRun{
+							ID:         github.Ptr(int64(11)),
+							Name:       github.Ptr("code-review"),
+							Status:     github.Ptr("completed"),
+							Conclusion: github.Ptr("failure"),
+							Output: &github.CheckRunOutput{
+								Title:            github.Ptr("Reviewing"),
+								Summary:          github.Ptr("Review in progress"),
+								AnnotationsCount: github.Ptr(1),
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(11),
+				"conclusion":   "failure",
+				"annotations": []interface{}{
+					map[string]interface{}{"path": "main.go", "start_line": float64(3), "end_line": float64(3), "annotation_level": "failure", "message": "nil dereference", "title": "bug"},
+				},
+			},
+			expectedRun: MinimalCheckRun{ID: 11, Name: "code-review", Status: "completed", Conclusion: "failure", Title: "Reviewing", Summary: "Review in progress", Annotations: 1},
+		},
+		{
+			name:         "invalid conclusion",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(11),
+				"conclusion":   "passed",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid conclusion: passed",
+		},
+		{
+			name: "check run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(99),
+				"status":       "in_progress",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get check run 99",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var run MinimalCheckRun
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &run))
+			assert.Equal(t, tc.expectedRun, run)
+		})
+	}
+}
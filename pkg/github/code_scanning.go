@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -71,12 +72,7 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alert: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, alert), nil
 		}
 }
 
@@ -159,11 +155,361 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(alerts)
+			return MarshalledTextResult(ctx, alerts), nil
+		}
+}
+
+func GetCodeScanningDefaultSetup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_scanning_default_setup",
+			mcp.WithDescription(t("TOOL_GET_CODE_SCANNING_DEFAULT_SETUP_DESCRIPTION", "Get the code scanning default setup configuration (state, query suite and languages) of a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_SCANNING_DEFAULT_SETUP_USER_TITLE", "Get code scanning default setup"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			config, resp, err := client.CodeScanning.GetDefaultSetupConfiguration(ctx, owner, repo)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get code scanning default setup",
+					resp,
+					err,
+				), nil
 			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, config), nil
+		}
+}
+
+// withDefaultSetupOptions adds the parameters shared by the tools that update code scanning default setup.
+func withDefaultSetupOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("state",
+			mcp.Required(),
+			mcp.Description("Whether code scanning default setup should be enabled ('configured') or disabled ('not-configured')."),
+			mcp.Enum("configured", "not-configured"),
+		)(tool)
+		mcp.WithString("query_suite",
+			mcp.Description("CodeQL query suite to run."),
+			mcp.Enum("default", "extended"),
+		)(tool)
+		mcp.WithArray("languages",
+			mcp.Description("CodeQL languages to analyze. When omitted, GitHub analyzes all supported languages detected in the repository."),
+			mcp.Items(
+				map[string]any{
+					"type": "string",
+					"enum": []string{"actions", "c-cpp", "csharp", "go", "java-kotlin", "javascript-typescript", "python", "ruby", "swift"},
+				},
+			),
+		)(tool)
+	}
+}
+
+// defaultSetupOptionsFromRequest reads the parameters added by withDefaultSetupOptions.
+func defaultSetupOptionsFromRequest(request mcp.CallToolRequest) (*github.UpdateDefaultSetupConfigurationOptions, error) {
+	state, err := RequiredParam[string](request, "state")
+	if err != nil {
+		return nil, err
+	}
+	querySuite, err := OptionalParam[string](request, "query_suite")
+	if err != nil {
+		return nil, err
+	}
+	languages, err := OptionalStringArrayParam(request, "languages")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.UpdateDefaultSetupConfigurationOptions{
+		State:     state,
+		Languages: languages,
+	}
+	if querySuite != "" {
+		opts.QuerySuite = github.Ptr(querySuite)
+	}
+	return opts, nil
+}
+
+// updateDefaultSetup updates the code scanning default setup of a repository. GitHub answers with
+// 202 Accepted while the setup run is scheduled, which go-github surfaces as an AcceptedError.
+func updateDefaultSetup(ctx context.Context, client *github.Client, owner, repo string, opts *github.UpdateDefaultSetupConfigurationOptions) (*github.UpdateDefaultSetupConfigurationResponse, *github.Response, error) {
+	result, resp, err := client.CodeScanning.UpdateDefaultSetupConfiguration(ctx, owner, repo, opts)
+	if err != nil {
+		var acceptedErr *github.AcceptedError
+		if !errors.As(err, &acceptedErr) {
+			return nil, resp, err
+		}
+		result = &github.UpdateDefaultSetupConfigurationResponse{}
+		if len(acceptedErr.Raw) > 0 {
+			if err := json.Unmarshal(acceptedErr.Raw, result); err != nil {
+				return nil, resp, fmt.Errorf("failed to unmarshal default setup response: %w", err)
+			}
+		}
+	}
+	return result, resp, nil
+}
+
+func UpdateCodeScanningDefaultSetup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_code_scanning_default_setup",
+			mcp.WithDescription(t("TOOL_UPDATE_CODE_SCANNING_DEFAULT_SETUP_DESCRIPTION", "Enable, disable or reconfigure CodeQL code scanning default setup for a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CODE_SCANNING_DEFAULT_SETUP_USER_TITLE", "Update code scanning default setup"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			withDefaultSetupOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts, err := defaultSetupOptionsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result, resp, err := updateDefaultSetup(ctx, client, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update code scanning default setup",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// RepositoryDefaultSetup is the code scanning default setup outcome for a single repository of an org-wide operation.
+type RepositoryDefaultSetup struct {
+	Repository    string                                          `json:"repository"`
+	Configuration *github.DefaultSetupConfiguration               `json:"configuration,omitempty"`
+	Update        *github.UpdateDefaultSetupConfigurationResponse `json:"update,omitempty"`
+	Error         string                                          `json:"error,omitempty"`
+}
+
+// setRepositoryDefaultSetupErrors sets the repository names of the outcomes of an org-wide operation, and the errors
+// of the repositories it failed on.
+func setRepositoryDefaultSetupErrors(results []RepositoryDefaultSetup, names []string, errs []error) {
+	for i, name := range names {
+		results[i].Repository = name
+		if errs[i] != nil {
+			results[i].Error = errs[i].Error()
+		}
+	}
+}
+
+// listOrgRepositoryNames returns the names of the given repositories, or a page of the organization's
+// repositories allowed by the owner policy when none are given. The returned bool reports whether more pages are
+// available.
+func listOrgRepositoryNames(ctx context.Context, client *github.Client, org string, repos []string, pagination PaginationParams) ([]string, bool, *github.Response, error) {
+	if len(repos) > 0 {
+		return repos, false, nil, nil
+	}
+
+	list, resp, err := client.Repositories.ListByOrg(ctx, org, &github.RepositoryListByOrgOptions{
+		Sort: "full_name",
+		ListOptions: github.ListOptions{
+			Page:    pagination.Page,
+			PerPage: pagination.PerPage,
+		},
+	})
+	if err != nil {
+		return nil, false, resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	names := make([]string, 0, len(list))
+	for _, repo := range list {
+		if repo.GetArchived() || !repositoryAllowed(ctx, org, repo.GetName()) {
+			continue
+		}
+		names = append(names, repo.GetName())
+	}
+	return names, resp.NextPage != 0, resp, nil
+}
+
+func ListOrgCodeScanningDefaultSetup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_code_scanning_default_setup",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CODE_SCANNING_DEFAULT_SETUP_DESCRIPTION", "Get the code scanning default setup configuration for many repositories of an organization at once. Archived repositories are skipped. Pagination applies to the organization's repositories when 'repositories' is not provided.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CODE_SCANNING_DEFAULT_SETUP_USER_TITLE", "List organization code scanning default setup"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to inspect. Defaults to the organization's repositories."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			names, hasNextPage, resp, err := listOrgRepositoryNames(ctx, client, org, repos, pagination)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+
+			results := make([]RepositoryDefaultSetup, len(names))
+			errs := fanOut(ctx, names, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				config, resp, err := client.CodeScanning.GetDefaultSetupConfiguration(ctx, org, repo)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				results[i].Configuration = config
+				return nil
+			})
+			setRepositoryDefaultSetupErrors(results, names, errs)
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"org":          org,
+				"repositories": results,
+				"hasNextPage":  hasNextPage,
+			}), nil
+		}
+}
+
+func UpdateOrgCodeScanningDefaultSetup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_org_code_scanning_default_setup",
+			mcp.WithDescription(t("TOOL_UPDATE_ORG_CODE_SCANNING_DEFAULT_SETUP_DESCRIPTION", "Enable, disable or reconfigure CodeQL code scanning default setup for many repositories of an organization at once. Archived repositories are skipped. Pagination applies to the organization's repositories when 'repositories' is not provided. Failures are reported per repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ORG_CODE_SCANNING_DEFAULT_SETUP_USER_TITLE", "Update organization code scanning default setup"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to update. Defaults to the organization's repositories."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			withDefaultSetupOptions(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts, err := defaultSetupOptionsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			names, hasNextPage, resp, err := listOrgRepositoryNames(ctx, client, org, repos, pagination)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+
+			results := make([]RepositoryDefaultSetup, len(names))
+			errs := fanOut(ctx, names, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				update, resp, err := updateDefaultSetup(ctx, client, org, repo, opts)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				results[i].Update = update
+				return nil
+			})
+			setRepositoryDefaultSetupErrors(results, names, errs)
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, map[string]any{
+				"org":          org,
+				"repositories": results,
+				"hasNextPage":  hasNextPage,
+			}), nil
 		}
 }
@@ -247,3 +247,416 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCodeScanningDefaultSetup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeScanningDefaultSetup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_code_scanning_default_setup", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockConfig := &github.DefaultSetupConfiguration{
+		State:      github.Ptr("configured"),
+		Languages:  []string{"go", "python"},
+		QuerySuite: github.Ptr("default"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedConfig *github.DefaultSetupConfiguration
+		expectedErrMsg string
+	}{
+		{
+			name: "successful default setup fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+					mockConfig,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedConfig: mockConfig,
+		},
+		{
+			name: "default setup fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Code scanning is not enabled"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get code scanning default setup",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeScanningDefaultSetup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returnedConfig github.DefaultSetupConfiguration
+			err = json.Unmarshal([]byte(textContent.Text), &returnedConfig)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedConfig.State, *returnedConfig.State)
+			assert.Equal(t, *tc.expectedConfig.QuerySuite, *returnedConfig.QuerySuite)
+			assert.Equal(t, tc.expectedConfig.Languages, returnedConfig.Languages)
+		})
+	}
+}
+
+func Test_UpdateCodeScanningDefaultSetup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCodeScanningDefaultSetup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_code_scanning_default_setup", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "query_suite")
+	assert.Contains(t, tool.InputSchema.Properties, "languages")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRunID  int64
+		expectedErrMsg string
+	}{
+		{
+			name: "setup run scheduled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"state":       "configured",
+						"query_suite": "extended",
+						"languages":   []any{"go"},
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.UpdateDefaultSetupConfigurationResponse{
+							RunID:  github.Ptr(int64(42)),
+							RunURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/runs/42"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"state":       "configured",
+				"query_suite": "extended",
+				"languages":   []any{"go"},
+			},
+			expectError:   false,
+			expectedRunID: 42,
+		},
+		{
+			name: "setup disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"state": "not-configured",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.UpdateDefaultSetupConfigurationResponse{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"state": "not-configured",
+			},
+			expectError: false,
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Code scanning default setup is already being updated"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"state": "configured",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update code scanning default setup",
+		},
+		{
+			name:         "missing required parameter state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: state",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateCodeScanningDefaultSetup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			var returned github.UpdateDefaultSetupConfigurationResponse
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRunID, returned.GetRunID())
+		})
+	}
+}
+
+func Test_ListOrgCodeScanningDefaultSetup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCodeScanningDefaultSetup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_code_scanning_default_setup", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsReposByOrg,
+			[]*github.Repository{
+				{Name: github.Ptr("api")},
+				{Name: github.Ptr("legacy"), Archived: github.Ptr(true)},
+				{Name: github.Ptr("web")},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/octo-org/web/code-scanning/default-setup" {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message": "Advanced Security must be enabled"}`))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&github.DefaultSetupConfiguration{State: github.Ptr("configured")})
+			}),
+		),
+	))
+	_, handler := ListOrgCodeScanningDefaultSetup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org": "octo-org",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		Repositories []RepositoryDefaultSetup `json:"repositories"`
+		HasNextPage  bool                     `json:"hasNextPage"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	require.Len(t, response.Repositories, 2)
+	assert.Equal(t, "api", response.Repositories[0].Repository)
+	assert.Equal(t, "configured", response.Repositories[0].Configuration.GetState())
+	assert.Equal(t, "web", response.Repositories[1].Repository)
+	assert.Nil(t, response.Repositories[1].Configuration)
+	assert.Contains(t, response.Repositories[1].Error, "Advanced Security must be enabled")
+	assert.False(t, response.HasNextPage)
+}
+
+func Test_UpdateOrgCodeScanningDefaultSetup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateOrgCodeScanningDefaultSetup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_org_code_scanning_default_setup", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "query_suite")
+	assert.Contains(t, tool.InputSchema.Properties, "languages")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []string
+	}{
+		{
+			name: "explicit repositories are updated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"state": "configured",
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.UpdateDefaultSetupConfigurationResponse{
+							RunID: github.Ptr(int64(7)),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "octo-org",
+				"repositories": []any{"api", "web"},
+				"state":        "configured",
+			},
+			expectError:   false,
+			expectedRepos: []string{"api", "web"},
+		},
+		{
+			name: "organization repositories are listed when none are given",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					[]*github.Repository{{Name: github.Ptr("api")}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, &github.UpdateDefaultSetupConfigurationResponse{
+						RunID: github.Ptr(int64(7)),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "octo-org",
+				"state": "configured",
+			},
+			expectError:   false,
+			expectedRepos: []string{"api"},
+		},
+		{
+			name: "repository listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "octo-org",
+				"state": "configured",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateOrgCodeScanningDefaultSetup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Repositories []RepositoryDefaultSetup `json:"repositories"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Repositories, len(tc.expectedRepos))
+			for i, repo := range tc.expectedRepos {
+				assert.Equal(t, repo, response.Repositories[i].Repository)
+				assert.Empty(t, response.Repositories[i].Error)
+				assert.Equal(t, int64(7), response.Repositories[i].Update.GetRunID())
+			}
+		})
+	}
+}
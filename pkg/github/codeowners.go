@@ -0,0 +1,446 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultCodeownersMaxPaths is the default number of unowned paths get_codeowners_coverage lists.
+	DefaultCodeownersMaxPaths = 100
+	// maxCodeownersLookupPaths is the number of paths get_codeowners looks up in one call at most.
+	maxCodeownersLookupPaths = 100
+)
+
+// codeownersLocations are the paths GitHub looks for a CODEOWNERS file at, in order.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a line of a CODEOWNERS file. A rule without owners makes the paths it matches unowned.
+type codeownersRule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// codeownersPatternRegexp converts a CODEOWNERS pattern, which follows the gitignore rules, to a regular expression
+// matching the file paths it applies to.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	// A pattern with a slash other than at its end is relative to the root, otherwise it matches at any depth.
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored && !strings.HasPrefix(trimmed, "**") {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			b.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(trimmed[i : i+1]))
+		}
+	}
+	// A pattern matching a directory applies to everything in it. A pattern ending in a slash only matches
+	// directories, and one ending in "/*" only the files directly in a directory.
+	switch {
+	case strings.HasSuffix(pattern, "/"):
+		b.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
+
+// parseCodeowners parses the rules of a CODEOWNERS file. Lines with invalid patterns are skipped, as GitHub does.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for i, line := range strings.Split(content, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 && (comment == 0 || line[comment-1] != '\\') {
+			line = line[:comment]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		re, err := codeownersPatternRegexp(pattern)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeownersRule{Line: i + 1, Pattern: pattern, Owners: fields[1:], re: re})
+	}
+	return rules
+}
+
+// matchCodeowners returns the rule that applies to a path, which is the last matching one, or nil if none matches.
+func matchCodeowners(rules []codeownersRule, filePath string) *codeownersRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(filePath) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// findCodeowners returns the path and content of the CODEOWNERS file GitHub uses at a ref, given the paths of the
+// files at the ref. The path is empty if the ref has no CODEOWNERS file.
+func findCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string, files map[string]bool) (string, string, *github.Response, error) {
+	for _, location := range codeownersLocations {
+		if !files[location] {
+			continue
+		}
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, location, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			return "", "", resp, fmt.Errorf("failed to get %s: %w", location, err)
+		}
+		_ = resp.Body.Close()
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", resp, fmt.Errorf("failed to decode %s: %w", location, err)
+		}
+		return location, content, resp, nil
+	}
+	return "", "", nil, nil
+}
+
+// probeCodeowners returns the path and content of the CODEOWNERS file GitHub uses at a ref, trying each location
+// in turn when the files at the ref aren't known. The path is empty if the ref has no CODEOWNERS file.
+func probeCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) (string, string, *github.Response, error) {
+	for _, location := range codeownersLocations {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, location, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", "", resp, fmt.Errorf("failed to get %s: %w", location, err)
+		}
+		_ = resp.Body.Close()
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", resp, fmt.Errorf("failed to decode %s: %w", location, err)
+		}
+		return location, content, resp, nil
+	}
+	return "", "", nil, nil
+}
+
+// collapseUnownedPaths reports the unowned files as the topmost directories in which no file is owned, and the
+// unowned files outside of such directories.
+func collapseUnownedPaths(files []string, owned map[string]bool) []string {
+	// ownedDirs are the directories containing an owned file.
+	ownedDirs := map[string]bool{}
+	for _, file := range files {
+		if owned[file] {
+			for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+				ownedDirs[dir] = true
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	var paths []string
+	for _, file := range files {
+		if owned[file] {
+			continue
+		}
+		unowned := file
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			if !ownedDirs[dir] {
+				unowned = dir + "/"
+			}
+		}
+		if !seen[unowned] {
+			seen[unowned] = true
+			paths = append(paths, unowned)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// GetCodeownersCoverage creates a tool to report the paths of a repository that have no code owner, and the errors
+// in its CODEOWNERS file.
+func GetCodeownersCoverage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners_coverage",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_COVERAGE_DESCRIPTION", "Compare the files of a repository against its CODEOWNERS rules. Reports the paths that have no code owner, collapsed to the topmost directories in which no file is owned, and the errors GitHub found in the CODEOWNERS file, such as owners that are unknown users or teams.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_COVERAGE_USER_TITLE", "Get CODEOWNERS coverage"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to check. Defaults to the default branch"),
+			),
+			mcp.WithNumber("max_paths",
+				mcp.Description(fmt.Sprintf("Maximum number of unowned paths to list (default %d)", DefaultCodeownersMaxPaths)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxPaths, err := OptionalIntParamWithDefault(request, "max_paths", DefaultCodeownersMaxPaths)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPaths < 1 || maxPaths > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_paths must be between 1 and %d", maxExportItems)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			var files []string
+			fileSet := map[string]bool{}
+			for _, entry := range tree.Entries {
+				if entry.GetType() == "blob" {
+					files = append(files, entry.GetPath())
+					fileSet[entry.GetPath()] = true
+				}
+			}
+
+			location, content, resp, err := findCodeowners(ctx, client, owner, repo, ref, fileSet)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS file",
+					resp,
+					err,
+				), nil
+			}
+			if location == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("no CODEOWNERS file found at %s on %s", strings.Join(codeownersLocations, ", "), ref)), nil
+			}
+
+			codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS errors",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			rules := parseCodeowners(content)
+			owned := map[string]bool{}
+			unownedFiles := 0
+			for _, file := range files {
+				if rule := matchCodeowners(rules, file); rule != nil && len(rule.Owners) > 0 {
+					owned[file] = true
+				} else {
+					unownedFiles++
+				}
+			}
+
+			unownedPaths := collapseUnownedPaths(files, owned)
+			truncated := len(unownedPaths) > maxPaths
+			if truncated {
+				unownedPaths = unownedPaths[:maxPaths]
+			}
+
+			result := map[string]any{
+				"ref":               ref,
+				"codeowners_file":   location,
+				"rules":             len(rules),
+				"files":             len(files),
+				"unowned_files":     unownedFiles,
+				"unowned_paths":     unownedPaths,
+				"truncated":         truncated,
+				"codeowners_errors": codeownersErrors.Errors,
+			}
+			// The recursive tree API returns a limited number of entries, so files beyond it are not checked.
+			if tree.GetTruncated() {
+				result["tree_truncated"] = true
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// PathOwners are the code owners of a path, and the CODEOWNERS rule they come from. A path without owners has no
+// code owner, either because no rule matches it or because the rule that does lists no owners.
+type PathOwners struct {
+	Path    string   `json:"path"`
+	Owners  []string `json:"owners"`
+	Line    int      `json:"line,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+}
+
+// GetCodeowners creates a tool to look up the code owners of paths of a repository.
+func GetCodeowners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_DESCRIPTION", "Look up the code owners of files of a repository from its CODEOWNERS file, the way GitHub does: the last matching rule wins. Returns the owners and matching rule of each path, the paths of each owner, and the errors GitHub found in the CODEOWNERS file, such as owners that are unknown users or teams. Use it to pick reviewers or report ownership instead of reading the CODEOWNERS file.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_USER_TITLE", "Get code owners"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithArray("paths",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Paths of the files to look up, relative to the root of the repository, at most %d", maxCodeownersLookupPaths)),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the CODEOWNERS file from. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 {
+				return mcp.NewToolResultError("missing required parameter: paths"), nil
+			}
+			if len(paths) > maxCodeownersLookupPaths {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d paths can be looked up at once", maxCodeownersLookupPaths)), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			location, content, resp, err := probeCodeowners(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS file",
+					resp,
+					err,
+				), nil
+			}
+			if location == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("no CODEOWNERS file found at %s", strings.Join(codeownersLocations, ", "))), nil
+			}
+
+			codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get CODEOWNERS errors",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			rules := parseCodeowners(content)
+			pathOwners := make([]PathOwners, 0, len(paths))
+			byOwner := map[string][]string{}
+			var unowned []string
+			for _, filePath := range paths {
+				filePath = strings.TrimPrefix(filePath, "/")
+				result := PathOwners{Path: filePath, Owners: []string{}}
+				if rule := matchCodeowners(rules, filePath); rule != nil {
+					result.Owners = rule.Owners
+					result.Line = rule.Line
+					result.Pattern = rule.Pattern
+				}
+				for _, codeowner := range result.Owners {
+					byOwner[codeowner] = append(byOwner[codeowner], filePath)
+				}
+				if len(result.Owners) == 0 {
+					unowned = append(unowned, filePath)
+				}
+				pathOwners = append(pathOwners, result)
+			}
+
+			result := map[string]any{
+				"codeowners_file":   location,
+				"paths":             pathOwners,
+				"by_owner":          byOwner,
+				"codeowners_errors": codeownersErrors.Errors,
+			}
+			if len(unowned) > 0 {
+				result["unowned_paths"] = unowned
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
@@ -0,0 +1,336 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_matchCodeowners(t *testing.T) {
+	rules := parseCodeowners(`# Default owners
+*               @org/everyone
+*.go            @org/gophers   # Go code
+/build/         @org/infra
+docs/*          @org/writers
+**/testdata     @org/qa
+apps/legacy
+\#notes.txt     @alice
+`)
+	require.Len(t, rules, 7)
+	assert.Equal(t, codeownersRule{Line: 2, Pattern: "*", Owners: []string{"@org/everyone"}}, withoutRegexp(rules[0]))
+	assert.Equal(t, "#notes.txt", rules[6].Pattern)
+
+	tests := []struct {
+		path           string
+		expectedOwners []string
+	}{
+		{"README.md", []string{"@org/everyone"}},
+		{"cmd/server/main.go", []string{"@org/gophers"}},
+		{"build/ci/Dockerfile", []string{"@org/infra"}},
+		{"tools/build/script.sh", []string{"@org/everyone"}},
+		{"docs/index.md", []string{"@org/writers"}},
+		{"docs/guides/setup.md", []string{"@org/everyone"}},
+		{"pkg/parser/testdata/input.txt", []string{"@org/qa"}},
+		{"apps/legacy/main.go", []string{}},
+		{"#notes.txt", []string{"@alice"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			rule := matchCodeowners(rules, tc.path)
+			require.NotNil(t, rule)
+			assert.Equal(t, tc.expectedOwners, rule.Owners)
+		})
+	}
+
+	assert.Nil(t, matchCodeowners(parseCodeowners("/src/ @org/dev"), "README.md"))
+}
+
+// withoutRegexp returns a rule without its compiled pattern, to compare it.
+func withoutRegexp(rule codeownersRule) codeownersRule {
+	rule.re = nil
+	return rule
+}
+
+func Test_collapseUnownedPaths(t *testing.T) {
+	files := []string{
+		"README.md",
+		"LICENSE",
+		"scripts/release/publish.sh",
+		"scripts/release/notes.sh",
+		"scripts/lint.sh",
+		"src/api/server.go",
+		"src/api/unowned.go",
+		"src/web/app.js",
+	}
+	owned := map[string]bool{
+		"README.md":         true,
+		"src/api/server.go": true,
+	}
+	assert.Equal(t, []string{
+		"LICENSE",
+		"scripts/",
+		"src/api/unowned.go",
+		"src/web/",
+	}, collapseUnownedPaths(files, owned))
+}
+
+func Test_GetCodeownersCoverage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeownersCoverage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners_coverage", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "max_paths")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	blob := func(path string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(path), Type: github.Ptr("blob")}
+	}
+	tree := &github.Tree{Entries: []*github.TreeEntry{
+		blob(".github/CODEOWNERS"),
+		{Path: github.Ptr("src"), Type: github.Ptr("tree")},
+		blob("src/main.go"),
+		blob("scripts/deploy.sh"),
+		blob("scripts/lint.sh"),
+		blob("Makefile"),
+	}}
+	codeowners := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Encoding: github.Ptr("base64"),
+		// "*.go @org/gophers @org/ghosts\n.github/ @org/admins\n"
+		Content: github.Ptr("Ki5nbyBAb3JnL2dvcGhlcnMgQG9yZy9naG9zdHMKLmdpdGh1Yi8gQG9yZy9hZG1pbnMK"),
+	}
+	codeownersErrors := &github.CodeownersErrors{Errors: []*github.CodeownersError{{
+		Line:    1,
+		Column:  19,
+		Kind:    "Unknown owner",
+		Source:  "*.go @org/gophers @org/ghosts",
+		Message: "Unknown owner on line 1: make sure the team @org/ghosts exists, is publicly visible, and has write access to the repository",
+		Path:    ".github/CODEOWNERS",
+	}}}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedPaths  []string
+		truncated      bool
+	}{
+		{
+			name: "unowned paths of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/git/trees/main", r.URL.Path)
+						mockResponse(t, http.StatusOK, tree)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusOK, codeowners),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, codeownersErrors),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPaths: []string{"Makefile", "scripts/"},
+		},
+		{
+			name: "limited paths",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, tree),
+				mock.WithRequestMatch(mock.GetReposContentsByOwnerByRepoByPath, codeowners),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, codeownersErrors),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "release",
+				"max_paths": float64(1),
+			},
+			expectedPaths: []string{"Makefile"},
+			truncated:     true,
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, &github.Tree{Entries: []*github.TreeEntry{blob("README.md")}}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "no CODEOWNERS file found at .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS on main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeownersCoverage(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				File             string                    `json:"codeowners_file"`
+				Files            int                       `json:"files"`
+				UnownedFiles     int                       `json:"unowned_files"`
+				UnownedPaths     []string                  `json:"unowned_paths"`
+				Truncated        bool                      `json:"truncated"`
+				CodeownersErrors []*github.CodeownersError `json:"codeowners_errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, ".github/CODEOWNERS", response.File)
+			assert.Equal(t, 5, response.Files)
+			assert.Equal(t, 3, response.UnownedFiles)
+			assert.Equal(t, tc.expectedPaths, response.UnownedPaths)
+			assert.Equal(t, tc.truncated, response.Truncated)
+			assert.Equal(t, codeownersErrors.Errors, response.CodeownersErrors)
+		})
+	}
+}
+
+func Test_GetCodeowners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeowners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "paths"})
+
+	codeowners := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Encoding: github.Ptr("base64"),
+		// "* @org/core\n*.go @org/gophers\n/docs/ @alice @bob\n/docs/generated/\n"
+		Content: github.Ptr("KiBAb3JnL2NvcmUKKi5nbyBAb3JnL2dvcGhlcnMKL2RvY3MvIEBhbGljZSBAYm9iCi9kb2NzL2dlbmVyYXRlZC8K"),
+	}
+	// The CODEOWNERS file is at the root, .github/CODEOWNERS is looked up first
+	contentsHandler := expectQueryParams(t, map[string]string{"ref": "release"}).andThen(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/owner/repo/contents/CODEOWNERS" {
+				mockResponse(t, http.StatusOK, codeowners)(w, r)
+				return
+			}
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+		},
+	)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedPaths    []PathOwners
+		expectedByOwner  map[string][]string
+		expectedUnowned  []string
+		expectedErrCount int
+	}{
+		{
+			name: "owners of paths",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, &github.CodeownersErrors{Errors: []*github.CodeownersError{{
+					Line:    3,
+					Kind:    "Unknown owner",
+					Message: "Unknown owner on line 3: make sure @bob exists and has write access to the repository",
+					Path:    "CODEOWNERS",
+				}}}),
+			),
+			expectedPaths: []PathOwners{
+				{Path: "cmd/main.go", Owners: []string{"@org/gophers"}, Line: 2, Pattern: "*.go"},
+				{Path: "docs/guide.md", Owners: []string{"@alice", "@bob"}, Line: 3, Pattern: "/docs/"},
+				{Path: "docs/generated/api.md", Owners: []string{}, Line: 4, Pattern: "/docs/generated/"},
+				{Path: "Makefile", Owners: []string{"@org/core"}, Line: 1, Pattern: "*"},
+			},
+			expectedByOwner: map[string][]string{
+				"@org/gophers": {"cmd/main.go"},
+				"@alice":       {"docs/guide.md"},
+				"@bob":         {"docs/guide.md"},
+				"@org/core":    {"Makefile"},
+			},
+			expectedUnowned:  []string{"docs/generated/api.md"},
+			expectedErrCount: 1,
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "no CODEOWNERS file found at .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeowners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "release",
+				"paths": []any{"cmd/main.go", "/docs/guide.md", "docs/generated/api.md", "Makefile"},
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				CodeownersFile   string                    `json:"codeowners_file"`
+				Paths            []PathOwners              `json:"paths"`
+				ByOwner          map[string][]string       `json:"by_owner"`
+				UnownedPaths     []string                  `json:"unowned_paths"`
+				CodeownersErrors []*github.CodeownersError `json:"codeowners_errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "CODEOWNERS", response.CodeownersFile)
+			assert.Equal(t, tc.expectedPaths, response.Paths)
+			assert.Equal(t, tc.expectedByOwner, response.ByOwner)
+			assert.Equal(t, tc.expectedUnowned, response.UnownedPaths)
+			assert.Len(t, response.CodeownersErrors, tc.expectedErrCount)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCommitStatus is the trimmed output type for commit status objects.
+type MinimalCommitStatus struct {
+	ID          int64  `json:"id"`
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+// MinimalCombinedStatus is the combined state of the latest status of each context of a ref.
+type MinimalCombinedStatus struct {
+	SHA        string                `json:"sha"`
+	State      string                `json:"state"`
+	TotalCount int                   `json:"total_count"`
+	Statuses   []MinimalCommitStatus `json:"statuses"`
+}
+
+func convertToMinimalCommitStatus(status *github.RepoStatus) MinimalCommitStatus {
+	minimalStatus := MinimalCommitStatus{
+		ID:          status.GetID(),
+		Context:     status.GetContext(),
+		State:       status.GetState(),
+		Description: status.GetDescription(),
+		TargetURL:   status.GetTargetURL(),
+		Creator:     status.GetCreator().GetLogin(),
+	}
+	if status.GetUpdatedAt().IsZero() {
+		return minimalStatus
+	}
+	minimalStatus.UpdatedAt = status.GetUpdatedAt().Format(time.RFC3339)
+	return minimalStatus
+}
+
+// GetCombinedStatus creates a tool to get the combined commit status of a ref.
+func GetCombinedStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_combined_status",
+			mcp.WithDescription(t("TOOL_GET_COMBINED_STATUS_DESCRIPTION", "Get the combined commit status of a ref: the latest status of each context, such as a CI system or deployment, and an overall state that is failure if any context errored or failed, pending if any is pending or none were reported, and success otherwise. Statuses set by check runs, such as those of GitHub Actions, aren't included.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMBINED_STATUS_USER_TITLE", "Get combined commit status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name, or tag name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get combined status of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			combined := MinimalCombinedStatus{
+				SHA:        status.GetSHA(),
+				State:      status.GetState(),
+				TotalCount: status.GetTotalCount(),
+				Statuses:   make([]MinimalCommitStatus, 0, len(status.Statuses)),
+			}
+			for _, s := range status.Statuses {
+				combined.Statuses = append(combined.Statuses, convertToMinimalCommitStatus(s))
+			}
+
+			return MarshalledTextResult(ctx, combined), nil
+		}
+}
+
+// CreateCommitStatus creates a tool to set the status of a commit for a context.
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set the status of a commit for a context, such as the result of a build or review run outside of GitHub Actions. A new status for the same context replaces the previous one in the combined status, and statuses can be required by branch protection.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_STATUS_USER_TITLE", "Create commit status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Full SHA of the commit"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the status"),
+				mcp.Enum("error", "failure", "pending", "success"),
+			),
+			mcp.WithString("context",
+				mcp.Description("Label telling this status apart from those of other systems, e.g. 'ci/build'. Defaults to 'default'."),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status"),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("URL of the details of the status, such as a build log"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch state {
+			case "error", "failure", "pending", "success":
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s. Supported states are: error, failure, pending, success", state)), nil
+			}
+			statusContext, err := OptionalParam[string](request, "context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetURL, err := OptionalParam[string](request, "target_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.RepoStatus{State: github.Ptr(state)}
+			if statusContext != "" {
+				status.Context = github.Ptr(statusContext)
+			}
+			if description != "" {
+				status.Description = github.Ptr(description)
+			}
+			if targetURL != "" {
+				status.TargetURL = github.Ptr(targetURL)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create status for commit %s", sha),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalCommitStatus(created)), nil
+		}
+}
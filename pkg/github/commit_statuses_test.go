@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCombinedStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCombinedStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_combined_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	mockStatus := &github.CombinedStatus{
+		SHA:        github.Ptr("abc123"),
+		State:      github.Ptr("failure"),
+		TotalCount: github.Ptr(2),
+		Statuses: []*github.RepoStatus{
+			{
+				ID:          github.Ptr(int64(1)),
+				Context:     github.Ptr("ci/build"),
+				State:       github.Ptr("success"),
+				Description: github.Ptr("Build passed"),
+				TargetURL:   github.Ptr("https://ci.example.com/builds/1"),
+				Creator:     &github.User{Login: github.Ptr("ci-bot")},
+				UpdatedAt:   &github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+			},
+			{
+				ID:      github.Ptr(int64(2)),
+				Context: github.Ptr("ci/lint"),
+				State:   github.Ptr("failure"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus MinimalCombinedStatus
+	}{
+		{
+			name: "combined status of a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main/status").andThen(
+						mockResponse(t, http.StatusOK, mockStatus),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedStatus: MinimalCombinedStatus{
+				SHA:        "abc123",
+				State:      "failure",
+				TotalCount: 2,
+				Statuses: []MinimalCommitStatus{
+					{ID: 1, Context: "ci/build", State: "success", Description: "Build passed", TargetURL: "https://ci.example.com/builds/1", Creator: "ci-bot", UpdatedAt: "2024-05-01T12:00:00Z"},
+					{ID: 2, Context: "ci/lint", State: "failure"},
+				},
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "No commit found for SHA: missing"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status of missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCombinedStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var status MinimalCombinedStatus
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &status))
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "target_url")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus MinimalCommitStatus
+	}{
+		{
+			name: "create status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]interface{}{
+						"state":       "pending",
+						"context":     "ci/review",
+						"description": "Review in progress",
+						"target_url":  "https://ci.example.com/reviews/7",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:          github.Ptr(int64(7)),
+							Context:     github.Ptr("ci/review"),
+							State:       github.Ptr("pending"),
+							Description: github.Ptr("Review in progress"),
+							TargetURL:   github.Ptr("https://ci.example.com/reviews/7"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"state":       "pending",
+				"context":     "ci/review",
+				"description": "Review in progress",
+				"target_url":  "https://ci.example.com/reviews/7",
+			},
+			expectedStatus: MinimalCommitStatus{ID: 7, Context: "ci/review", State: "pending", Description: "Review in progress", TargetURL: "https://ci.example.com/reviews/7"},
+		},
+		{
+			name: "default context",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "success",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:      github.Ptr(int64(8)),
+							Context: github.Ptr("default"),
+							State:   github.Ptr("success"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "success",
+			},
+			expectedStatus: MinimalCommitStatus{ID: 8, Context: "default", State: "success"},
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "passed",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid state: passed",
+		},
+		{
+			name: "unknown commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "No commit found for SHA: abc123"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "success",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create status for commit abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var status MinimalCommitStatus
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &status))
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
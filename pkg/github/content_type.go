@@ -0,0 +1,72 @@
+package github
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// textFileExtensions are extensions of source and configuration files that are text, but for which the raw
+// content host often reports a generic content type.
+var textFileExtensions = map[string]bool{
+	".bash": true, ".bat": true, ".c": true, ".cc": true, ".cfg": true, ".clj": true, ".cmake": true,
+	".conf": true, ".cpp": true, ".cs": true, ".css": true, ".csv": true, ".dart": true, ".diff": true,
+	".dockerfile": true, ".el": true, ".env": true, ".erl": true, ".ex": true, ".exs": true, ".fs": true,
+	".go": true, ".gradle": true, ".graphql": true, ".groovy": true, ".h": true, ".hcl": true, ".hpp": true,
+	".hs": true, ".html": true, ".ini": true, ".ipynb": true, ".java": true, ".js": true, ".json": true,
+	".jsx": true, ".kt": true, ".kts": true, ".less": true, ".lock": true, ".lua": true, ".m": true,
+	".md": true, ".mdx": true, ".mjs": true, ".ml": true, ".mod": true, ".patch": true, ".php": true,
+	".pl": true, ".properties": true, ".proto": true, ".ps1": true, ".py": true, ".r": true, ".rb": true,
+	".rs": true, ".rst": true, ".sass": true, ".scala": true, ".scss": true, ".sh": true, ".sql": true,
+	".sum": true, ".svelte": true, ".swift": true, ".tf": true, ".toml": true, ".ts": true, ".tsx": true,
+	".txt": true, ".vue": true, ".xml": true, ".yaml": true, ".yml": true, ".zsh": true,
+}
+
+// textFileNames are names of common extensionless text files.
+var textFileNames = map[string]bool{
+	"BUILD": true, "CODEOWNERS": true, "Dockerfile": true, "Gemfile": true, "LICENSE": true,
+	"Makefile": true, "Procfile": true, "Rakefile": true, "Vagrantfile": true, "WORKSPACE": true,
+	".editorconfig": true, ".gitattributes": true, ".gitignore": true, ".npmrc": true,
+}
+
+// detectContentType returns the content type of raw file content. When the reported content type is missing
+// or generic, it is derived from the file name for known source files, and otherwise sniffed from the bytes.
+func detectContentType(path, reported string, content []byte) string {
+	mediaType, _, err := mime.ParseMediaType(reported)
+	if reported != "" && err == nil && mediaType != "application/octet-stream" {
+		return reported
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if textFileExtensions[ext] || textFileNames[filepath.Base(path)] {
+		return "text/plain; charset=utf-8"
+	}
+
+	if reported == "" {
+		if byExtension := mime.TypeByExtension(ext); byExtension != "" {
+			return byExtension
+		}
+	}
+	return http.DetectContentType(content)
+}
+
+// isTextContentType reports whether content of the given content type should be returned as text.
+func isTextContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-sh",
+		"application/x-yaml", "application/yaml", "application/toml", "application/sql", "application/graphql":
+		return true
+	}
+	return false
+}
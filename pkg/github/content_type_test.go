@@ -0,0 +1,80 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name         string
+		path         string
+		reported     string
+		content      []byte
+		expectedType string
+		expectedText bool
+	}{
+		{
+			name:         "specific reported type is kept",
+			path:         "logo.png",
+			reported:     "image/png",
+			content:      png,
+			expectedType: "image/png",
+		},
+		{
+			name:         "source file served as octet-stream",
+			path:         "cmd/server/main.go",
+			reported:     "application/octet-stream",
+			content:      []byte("package main\n"),
+			expectedType: "text/plain; charset=utf-8",
+			expectedText: true,
+		},
+		{
+			name:         "missing content type for source file",
+			path:         "web/app.ts",
+			content:      []byte("export {}\n"),
+			expectedType: "text/plain; charset=utf-8",
+			expectedText: true,
+		},
+		{
+			name:         "extensionless text file",
+			path:         "Makefile",
+			reported:     "application/octet-stream",
+			content:      []byte("all:\n\tgo build ./...\n"),
+			expectedType: "text/plain; charset=utf-8",
+			expectedText: true,
+		},
+		{
+			name:         "unknown extension sniffed as text",
+			path:         "notes.unknown",
+			reported:     "application/octet-stream",
+			content:      []byte("plain words"),
+			expectedType: "text/plain; charset=utf-8",
+			expectedText: true,
+		},
+		{
+			name:         "binary bytes sniffed",
+			path:         "asset.bin",
+			reported:     "application/octet-stream",
+			content:      png,
+			expectedType: "image/png",
+		},
+		{
+			name:         "missing content type uses extension",
+			path:         "document.pdf",
+			content:      []byte("%PDF-1.7"),
+			expectedType: "application/pdf",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			contentType := detectContentType(tc.path, tc.reported, tc.content)
+			assert.Equal(t, tc.expectedType, contentType)
+			assert.Equal(t, tc.expectedText, isTextContentType(contentType))
+		})
+	}
+}
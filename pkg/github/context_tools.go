@@ -5,6 +5,7 @@ import (
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -86,7 +87,7 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			},
 		}
 
-		return MarshalledTextResult(minimalUser), nil
+		return MarshalledTextResult(ctx, minimalUser), nil
 	})
 
 	return tool, handler
@@ -186,7 +187,7 @@ func GetTeams(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations
 				organizations = append(organizations, orgTeams)
 			}
 
-			return MarshalledTextResult(organizations), nil
+			return MarshalledTextResult(ctx, organizations), nil
 		}
 }
 
@@ -246,6 +247,50 @@ func GetTeamMembers(getGQLClient GetGQLClientFn, t translations.TranslationHelpe
 				members = append(members, string(member.Login))
 			}
 
-			return MarshalledTextResult(members), nil
+			return MarshalledTextResult(ctx, members), nil
 		}
 }
+
+// ToolCatalog is the estimated context cost of the toolsets of the server.
+type ToolCatalog struct {
+	// EstimatedTokens is the total over the enabled toolsets only, which is what the current configuration costs.
+	EstimatedTokens int                             `json:"estimated_tokens"`
+	Toolsets        []toolsets.ToolsetTokenEstimate `json:"toolsets"`
+}
+
+// GetToolCatalog creates a tool to list the tools of every toolset with an estimate of the tokens their
+// definitions take up, so the context cost of a configuration can be checked.
+func GetToolCatalog(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("get_tool_catalog",
+		mcp.WithDescription(t("TOOL_GET_TOOL_CATALOG_DESCRIPTION", "List the tools of the toolsets of this server with an estimate of the tokens each tool definition takes up, aggregated per toolset. The total only counts the enabled toolsets.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_GET_TOOL_CATALOG_USER_TITLE", "Get tool catalog"),
+			ReadOnlyHint: ToBoolPtr(true),
+		}),
+		mcp.WithBoolean("include_disabled",
+			mcp.Description("Also list the toolsets that aren't enabled"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		includeDisabled, err := OptionalBoolParamWithDefault(request, "include_disabled", false)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		catalog := ToolCatalog{Toolsets: []toolsets.ToolsetTokenEstimate{}}
+		for _, estimate := range toolsetGroup.TokenEstimates() {
+			if estimate.Enabled {
+				catalog.EstimatedTokens += estimate.Tokens
+			} else if !includeDisabled {
+				continue
+			}
+			catalog.Toolsets = append(catalog.Toolsets, estimate)
+		}
+
+		return MarshalledTextResult(ctx, catalog), nil
+	}
+
+	return tool, handler
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -497,3 +498,75 @@ func Test_GetTeamMembers(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetToolCatalog(t *testing.T) {
+	t.Parallel()
+
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("context", "Context").
+		AddReadTools(toolsets.NewServerTool(GetMe(nil, translations.NullTranslationHelper))))
+	tsg.AddToolset(toolsets.NewToolset("gists", "Gists").
+		AddReadTools(toolsets.NewServerTool(ListGists(nil, translations.NullTranslationHelper))).
+		AddWriteTools(toolsets.NewServerTool(CreateGist(nil, translations.NullTranslationHelper))))
+	require.NoError(t, tsg.EnableToolset("context"))
+
+	tool, handler := GetToolCatalog(tsg, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_tool_catalog", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_tool_catalog tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "include_disabled")
+
+	getMeTokens := toolsets.EstimateToolTokens(tsg.Toolsets["context"].GetAvailableTools()[0].Tool)
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]any
+		expectedToolsets []string
+	}{
+		{
+			name:             "enabled toolsets only",
+			requestArgs:      map[string]any{},
+			expectedToolsets: []string{"context"},
+		},
+		{
+			name:             "include disabled toolsets",
+			requestArgs:      map[string]any{"include_disabled": true},
+			expectedToolsets: []string{"context", "gists"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var catalog ToolCatalog
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &catalog))
+
+			// The total only counts enabled toolsets, whether or not disabled ones are listed
+			assert.Equal(t, getMeTokens, catalog.EstimatedTokens)
+
+			names := make([]string, 0, len(catalog.Toolsets))
+			for _, ts := range catalog.Toolsets {
+				names = append(names, ts.Name)
+			}
+			assert.Equal(t, tc.expectedToolsets, names)
+
+			contextToolset := catalog.Toolsets[0]
+			assert.True(t, contextToolset.Enabled)
+			require.Len(t, contextToolset.Tools, 1)
+			assert.Equal(t, "get_me", contextToolset.Tools[0].Name)
+			assert.Equal(t, getMeTokens, contextToolset.Tokens)
+
+			if len(catalog.Toolsets) > 1 {
+				gists := catalog.Toolsets[1]
+				assert.False(t, gists.Enabled)
+				assert.Len(t, gists.Tools, 2)
+				assert.Positive(t, gists.Tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultContributorRecentItems is the default number of recent items get_contributor_context lists per kind.
+	DefaultContributorRecentItems = 5
+	// maxContributorRecentItems is the number of recent items get_contributor_context lists per kind at most.
+	maxContributorRecentItems = 20
+	// regularContributorMergedPullRequests is the number of merged pull requests from which a contributor is
+	// considered a regular.
+	regularContributorMergedPullRequests = 10
+)
+
+// ContributionItem is an issue or pull request of a contributor.
+type ContributionItem struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	HTMLURL   string `json:"html_url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ContributionSummary is how many issues or pull requests of a kind a contributor has, with the most recent ones.
+type ContributionSummary struct {
+	Count  int                `json:"count"`
+	Recent []ContributionItem `json:"recent,omitempty"`
+}
+
+// ContributorAccount is the public profile of a contributor, to tell new accounts apart.
+type ContributorAccount struct {
+	Name           string `json:"name,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	AccountAgeDays int    `json:"account_age_days"`
+	PublicRepos    int    `json:"public_repos"`
+	Followers      int    `json:"followers"`
+}
+
+// ContributorContext sums up the prior contributions of a user to a repository.
+type ContributorContext struct {
+	Username   string `json:"username"`
+	Repository string `json:"repository"`
+	// ContributorType is "first_time" when the user has no merged pull request in the repository, "regular" from
+	// regularContributorMergedPullRequests merged pull requests, and "returning" in between.
+	ContributorType            string              `json:"contributor_type"`
+	Account                    ContributorAccount  `json:"account"`
+	MergedPullRequests         ContributionSummary `json:"merged_pull_requests"`
+	OpenPullRequests           ContributionSummary `json:"open_pull_requests"`
+	ClosedUnmergedPullRequests ContributionSummary `json:"closed_unmerged_pull_requests"`
+	Issues                     ContributionSummary `json:"issues"`
+	// ReviewedPullRequests are the pull requests of others the user reviewed.
+	ReviewedPullRequests ContributionSummary `json:"reviewed_pull_requests"`
+}
+
+// contributorType classifies a contributor from their number of merged pull requests.
+func contributorType(merged int) string {
+	switch {
+	case merged == 0:
+		return "first_time"
+	case merged < regularContributorMergedPullRequests:
+		return "returning"
+	default:
+		return "regular"
+	}
+}
+
+// contributionSearch is a search for a kind of contribution, and the summary it fills.
+type contributionSearch struct {
+	query   string
+	summary *ContributionSummary
+}
+
+// searchContributions counts the issues and pull requests matching a search and lists the most recent ones.
+func searchContributions(ctx context.Context, client *github.Client, query string, recent int) (ContributionSummary, *github.Response, error) {
+	result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+		Sort:        "created",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: recent},
+	})
+	if err != nil {
+		return ContributionSummary{}, resp, err
+	}
+	_ = resp.Body.Close()
+
+	summary := ContributionSummary{Count: result.GetTotal()}
+	for _, issue := range result.Issues {
+		summary.Recent = append(summary.Recent, ContributionItem{
+			Number:    issue.GetNumber(),
+			Title:     issue.GetTitle(),
+			State:     issue.GetState(),
+			HTMLURL:   issue.GetHTMLURL(),
+			CreatedAt: formatOptionalTimestamp(issue.CreatedAt),
+		})
+	}
+	return summary, resp, nil
+}
+
+// GetContributorContext creates a tool to sum up the prior contributions of a user to a repository.
+func GetContributorContext(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contributor_context",
+			mcp.WithDescription(t("TOOL_GET_CONTRIBUTOR_CONTEXT_DESCRIPTION", "Sum up the prior contributions of a user to a repository: their merged, open and closed unmerged pull requests, the issues they opened and the pull requests of others they reviewed, with counts and the most recent ones, and their account age. The contributor is classified as first_time (no merged pull request), returning or regular. Use it to welcome first-time contributors and tailor responses to returning ones.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTRIBUTOR_CONTEXT_USER_TITLE", "Get contributor context"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the contributor"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("recent",
+				mcp.Description(fmt.Sprintf("Number of most recent items to list for each kind of contribution (default %d)", DefaultContributorRecentItems)),
+				mcp.Min(1),
+				mcp.Max(maxContributorRecentItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recent, err := OptionalIntParamWithDefault(request, "recent", DefaultContributorRecentItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if recent < 1 || recent > maxContributorRecentItems {
+				return mcp.NewToolResultError(fmt.Sprintf("recent must be between 1 and %d", maxContributorRecentItems)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			user, resp, err := client.Users.Get(ctx, username)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("user %s not found", username)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get user %s", username),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			contributor := ContributorContext{
+				Username:   user.GetLogin(),
+				Repository: fmt.Sprintf("%s/%s", owner, repo),
+				Account: ContributorAccount{
+					Name:        user.GetName(),
+					CreatedAt:   formatOptionalTimestamp(user.CreatedAt),
+					PublicRepos: user.GetPublicRepos(),
+					Followers:   user.GetFollowers(),
+				},
+			}
+			if user.CreatedAt != nil {
+				contributor.Account.AccountAgeDays = int(time.Since(user.CreatedAt.Time).Hours() / 24)
+			}
+
+			scope := fmt.Sprintf("repo:%s/%s", owner, repo)
+			searches := []contributionSearch{
+				{fmt.Sprintf("is:pr is:merged author:%s %s", username, scope), &contributor.MergedPullRequests},
+				{fmt.Sprintf("is:pr is:open author:%s %s", username, scope), &contributor.OpenPullRequests},
+				{fmt.Sprintf("is:pr is:closed is:unmerged author:%s %s", username, scope), &contributor.ClosedUnmergedPullRequests},
+				{fmt.Sprintf("is:issue author:%s %s", username, scope), &contributor.Issues},
+				{fmt.Sprintf("is:pr reviewed-by:%s -author:%s %s", username, username, scope), &contributor.ReviewedPullRequests},
+			}
+			resps := make([]*github.Response, len(searches))
+			errs := fanOut(ctx, searches, DefaultFanOutConcurrency, func(ctx context.Context, i int, search contributionSearch) error {
+				var err error
+				*search.summary, resps[i], err = searchContributions(ctx, client, search.query, recent)
+				return err
+			})
+			for i, err := range errs {
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to search the contributions of %s", username),
+						resps[i],
+						err,
+					), nil
+				}
+			}
+
+			contributor.ContributorType = contributorType(contributor.MergedPullRequests.Count)
+			return MarshalledTextResult(ctx, contributor), nil
+		}
+}
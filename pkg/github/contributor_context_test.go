@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_contributorType(t *testing.T) {
+	assert.Equal(t, "first_time", contributorType(0))
+	assert.Equal(t, "returning", contributorType(1))
+	assert.Equal(t, "returning", contributorType(regularContributorMergedPullRequests-1))
+	assert.Equal(t, "regular", contributorType(regularContributorMergedPullRequests))
+}
+
+func Test_GetContributorContext(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetContributorContext(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contributor_context", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username", "owner", "repo"})
+
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &github.User{
+		Login:       github.Ptr("octocat"),
+		Name:        github.Ptr("The Octocat"),
+		CreatedAt:   &github.Timestamp{Time: createdAt},
+		PublicRepos: github.Ptr(8),
+		Followers:   github.Ptr(20),
+	}
+	mergedPR := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Fix typo"),
+		State:     github.Ptr("closed"),
+		HTMLURL:   github.Ptr("https://github.com/acme/web/pull/42"),
+		CreatedAt: &github.Timestamp{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	// searchHandler answers each contribution search with the given total, listing mergedPR for merged pull requests
+	searchHandler := func(totals map[string]int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			assert.Equal(t, "created", query.Get("sort"))
+			assert.Equal(t, "desc", query.Get("order"))
+			assert.Equal(t, "5", query.Get("per_page"))
+			q := query.Get("q")
+			assert.Contains(t, q, "repo:acme/web")
+			for prefix, total := range totals {
+				if strings.HasPrefix(q, prefix) {
+					result := &github.IssuesSearchResult{Total: github.Ptr(total), Issues: []*github.Issue{}}
+					if prefix == "is:pr is:merged" && total > 0 {
+						result.Issues = []*github.Issue{mergedPR}
+					}
+					mockResponse(t, http.StatusOK, result)(w, r)
+					return
+				}
+			}
+			t.Errorf("unexpected search %q", q)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedType     string
+		expectedMerged   int
+		expectedReviewed int
+	}{
+		{
+			name: "returning contributor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, user),
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler(map[string]int{
+					"is:pr is:merged":             3,
+					"is:pr is:open":               1,
+					"is:pr is:closed is:unmerged": 0,
+					"is:issue":                    2,
+					"is:pr reviewed-by:octocat":   4,
+				})),
+			),
+			expectedType:     "returning",
+			expectedMerged:   3,
+			expectedReviewed: 4,
+		},
+		{
+			name: "first-time contributor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, user),
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler(map[string]int{
+					"is:pr is:merged":             0,
+					"is:pr is:open":               1,
+					"is:pr is:closed is:unmerged": 0,
+					"is:issue":                    0,
+					"is:pr reviewed-by:octocat":   0,
+				})),
+			),
+			expectedType: "first_time",
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "user octocat not found",
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, user),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "API rate limit exceeded"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to search the contributions of octocat",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetContributorContext(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"username": "octocat",
+				"owner":    "acme",
+				"repo":     "web",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var contributor ContributorContext
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &contributor))
+			assert.Equal(t, "octocat", contributor.Username)
+			assert.Equal(t, "acme/web", contributor.Repository)
+			assert.Equal(t, tc.expectedType, contributor.ContributorType)
+			assert.Equal(t, "The Octocat", contributor.Account.Name)
+			assert.Equal(t, "2020-01-01T00:00:00Z", contributor.Account.CreatedAt)
+			assert.Greater(t, contributor.Account.AccountAgeDays, 365)
+			assert.Equal(t, tc.expectedMerged, contributor.MergedPullRequests.Count)
+			assert.Equal(t, tc.expectedReviewed, contributor.ReviewedPullRequests.Count)
+			assert.Equal(t, 1, contributor.OpenPullRequests.Count)
+			if tc.expectedMerged > 0 {
+				assert.Equal(t, []ContributionItem{{
+					Number:    42,
+					Title:     "Fix typo",
+					State:     "closed",
+					HTMLURL:   "https://github.com/acme/web/pull/42",
+					CreatedAt: "2025-03-01T00:00:00Z",
+				}}, contributor.MergedPullRequests.Recent)
+			}
+		})
+	}
+}
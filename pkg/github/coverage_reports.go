@@ -0,0 +1,353 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// FileCoverage is the coverage of a file, or of a set of files. For Go cover profiles statements are counted
+// instead of lines.
+type FileCoverage struct {
+	Path    string  `json:"path,omitempty"`
+	Covered int     `json:"covered"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+func newFileCoverage(path string, covered, total int) FileCoverage {
+	coverage := FileCoverage{Path: path, Covered: covered, Total: total}
+	if total > 0 {
+		coverage.Percent = math.Round(float64(covered)/float64(total)*10000) / 100
+	}
+	return coverage
+}
+
+// lineHits records how often each line of each file was hit. Lines reported more than once keep their highest count.
+type lineHits map[string]map[int]int
+
+func (h lineHits) add(file string, line, hits int) {
+	if h[file] == nil {
+		h[file] = map[int]int{}
+	}
+	h[file][line] = max(h[file][line], hits)
+}
+
+func (h lineHits) coverage() map[string]FileCoverage {
+	files := make(map[string]FileCoverage, len(h))
+	for file, lines := range h {
+		covered := 0
+		for _, hits := range lines {
+			if hits > 0 {
+				covered++
+			}
+		}
+		files[file] = newFileCoverage(file, covered, len(lines))
+	}
+	return files
+}
+
+// parseLcov reads an lcov tracefile.
+func parseLcov(content []byte) (map[string]FileCoverage, error) {
+	hits := lineHits{}
+	file := ""
+	records := 0
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+			records++
+		case strings.HasPrefix(line, "DA:") && file != "":
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				continue
+			}
+			number, err1 := strconv.Atoi(fields[0])
+			count, err2 := strconv.Atoi(fields[1])
+			if err1 == nil && err2 == nil {
+				hits.add(file, number, count)
+			}
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read lcov report: %w", err)
+	}
+	if records == 0 {
+		return nil, fmt.Errorf("not an lcov report")
+	}
+	return hits.coverage(), nil
+}
+
+type coberturaReport struct {
+	XMLName  xml.Name
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int `xml:"number,attr"`
+				Hits   int `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// parseCobertura reads a Cobertura XML report.
+func parseCobertura(content []byte) (map[string]FileCoverage, error) {
+	var report coberturaReport
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse Cobertura report: %w", err)
+	}
+	if report.XMLName.Local != "coverage" {
+		return nil, fmt.Errorf("not a Cobertura report: root element is <%s>", report.XMLName.Local)
+	}
+	hits := lineHits{}
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			for _, line := range class.Lines {
+				hits.add(class.Filename, line.Number, line.Hits)
+			}
+		}
+	}
+	return hits.coverage(), nil
+}
+
+// parseGoCoverProfile reads a Go cover profile, as written by 'go test -coverprofile'.
+func parseGoCoverProfile(content []byte) (map[string]FileCoverage, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "mode: ") {
+		return nil, fmt.Errorf("not a Go cover profile")
+	}
+
+	type block struct{ statements, count int }
+	// Blocks are keyed by their position, as profiles merged from several test binaries repeat them.
+	blocks := map[string]map[string]block{}
+	for scanner.Scan() {
+		// Each line is "file:startLine.startCol,endLine.endCol statements count".
+		line := strings.TrimSpace(scanner.Text())
+		colon := strings.LastIndex(line, ":")
+		if colon < 0 {
+			continue
+		}
+		fields := strings.Fields(line[colon+1:])
+		if len(fields) != 3 {
+			continue
+		}
+		statements, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		file := line[:colon]
+		if blocks[file] == nil {
+			blocks[file] = map[string]block{}
+		}
+		previous := blocks[file][fields[0]]
+		blocks[file][fields[0]] = block{statements: statements, count: max(previous.count, count)}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Go cover profile: %w", err)
+	}
+
+	files := make(map[string]FileCoverage, len(blocks))
+	for file, fileBlocks := range blocks {
+		covered, total := 0, 0
+		for _, b := range fileBlocks {
+			total += b.statements
+			if b.count > 0 {
+				covered += b.statements
+			}
+		}
+		files[file] = newFileCoverage(file, covered, total)
+	}
+	return files, nil
+}
+
+// parseCoverageReport reads a coverage report in any of the supported formats, detected from its content.
+func parseCoverageReport(content []byte) (map[string]FileCoverage, string, error) {
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode: ")):
+		files, err := parseGoCoverProfile(trimmed)
+		return files, "go", err
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		files, err := parseCobertura(trimmed)
+		return files, "cobertura", err
+	default:
+		files, err := parseLcov(trimmed)
+		return files, "lcov", err
+	}
+}
+
+// isCoverageReportFile reports whether a file in an artifact can be a coverage report.
+func isCoverageReportFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".info", ".lcov", ".xml", ".out", ".txt", ".cov", ".coverprofile":
+		return true
+	}
+	return false
+}
+
+// matchesRepositoryPath reports whether a path from a coverage report, which can be absolute or prefixed with a
+// module path, is the repository path.
+func matchesRepositoryPath(reportPath, repoPath string) bool {
+	reportPath = strings.ReplaceAll(reportPath, "\\", "/")
+	return reportPath == repoPath || strings.HasSuffix(reportPath, "/"+repoPath)
+}
+
+// GetCoverageReport creates a tool to summarize the coverage reports in a workflow run artifact.
+func GetCoverageReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_coverage_report",
+			mcp.WithDescription(t("TOOL_GET_COVERAGE_REPORT_DESCRIPTION", "Download a workflow run artifact containing coverage reports, lcov, Cobertura XML or Go cover profiles, and return the overall coverage. With a pull request number, also return the coverage of each file the pull request changed. Use list_workflow_run_artifacts to find the artifact.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COVERAGE_REPORT_USER_TITLE", "Get coverage report"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the artifact"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Description("Pull request number to report the coverage of the changed files for"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID, err := RequiredInt(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			files, resp, err := downloadArtifactFiles(ctx, client, owner, repo, int64(artifactID), isCoverageReportFile)
+			if err != nil {
+				if resp != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to download artifact", resp, err), nil
+				}
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			coverage := map[string]FileCoverage{}
+			reports := []map[string]string{}
+			for _, file := range files {
+				// Files that aren't coverage reports, such as test reports, are skipped.
+				fileCoverage, format, err := parseCoverageReport(file.Content)
+				if err != nil {
+					continue
+				}
+				reports = append(reports, map[string]string{"file": file.Name, "format": format})
+				for name, c := range fileCoverage {
+					coverage[name] = newFileCoverage(name, coverage[name].Covered+c.Covered, coverage[name].Total+c.Total)
+				}
+			}
+			if len(reports) == 0 {
+				return mcp.NewToolResultError("the artifact contains no lcov, Cobertura or Go coverage reports"), nil
+			}
+
+			covered, total := 0, 0
+			for _, c := range coverage {
+				covered += c.Covered
+				total += c.Total
+			}
+			result := map[string]any{
+				"artifact_id":   artifactID,
+				"reports":       reports,
+				"overall":       newFileCoverage("", covered, total),
+				"files_covered": len(coverage),
+			}
+
+			if pullNumber != 0 {
+				// Removed files have no coverage, so only the files the pull request adds or modifies are reported.
+				var changedFiles []string
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to get pull request files",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, file := range files {
+						if file.GetStatus() != "removed" {
+							changedFiles = append(changedFiles, file.GetFilename())
+						}
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+				sort.Strings(changedFiles)
+
+				changedCoverage := []FileCoverage{}
+				notInReport := []string{}
+				changedCovered, changedTotal := 0, 0
+				for _, name := range changedFiles {
+					// A file can be in several reports, under different paths, so all matches are added up.
+					found := false
+					fileCovered, fileTotal := 0, 0
+					for reportPath, c := range coverage {
+						if matchesRepositoryPath(reportPath, name) {
+							fileCovered += c.Covered
+							fileTotal += c.Total
+							found = true
+						}
+					}
+					if !found {
+						notInReport = append(notInReport, name)
+						continue
+					}
+					changedCoverage = append(changedCoverage, newFileCoverage(name, fileCovered, fileTotal))
+					changedCovered += fileCovered
+					changedTotal += fileTotal
+				}
+				result["changed_files"] = newFileCoverage("", changedCovered, changedTotal)
+				result["changed_file_coverage"] = changedCoverage
+				result["changed_files_not_in_report"] = notInReport
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
@@ -0,0 +1,253 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseCoverageReport(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedFormat string
+		expected       map[string]FileCoverage
+		expectError    bool
+	}{
+		{
+			name: "lcov",
+			content: `TN:
+SF:/home/runner/work/repo/repo/src/cache.js
+DA:1,4
+DA:2,0
+DA:3,1
+DA:4,0
+LF:4
+LH:2
+end_of_record
+SF:/home/runner/work/repo/repo/src/util.js
+DA:1,1
+end_of_record
+`,
+			expectedFormat: "lcov",
+			expected: map[string]FileCoverage{
+				"/home/runner/work/repo/repo/src/cache.js": {Path: "/home/runner/work/repo/repo/src/cache.js", Covered: 2, Total: 4, Percent: 50},
+				"/home/runner/work/repo/repo/src/util.js":  {Path: "/home/runner/work/repo/repo/src/util.js", Covered: 1, Total: 1, Percent: 100},
+			},
+		},
+		{
+			name: "cobertura",
+			content: `<?xml version="1.0" ?>
+<coverage line-rate="0.33" version="7.4">
+  <packages>
+    <package name="app">
+      <classes>
+        <class name="api.py" filename="app/api.py" line-rate="0.33">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0"/>
+            <line number="3" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`,
+			expectedFormat: "cobertura",
+			expected: map[string]FileCoverage{
+				"app/api.py": {Path: "app/api.py", Covered: 1, Total: 3, Percent: 33.33},
+			},
+		},
+		{
+			name: "go cover profile",
+			content: `mode: atomic
+example.com/mod/cache/cache.go:10.30,12.2 2 5
+example.com/mod/cache/cache.go:14.30,16.2 1 0
+example.com/mod/cache/cache.go:14.30,16.2 1 3
+example.com/mod/cache/cache.go:18.30,20.2 3 0
+`,
+			expectedFormat: "go",
+			expected: map[string]FileCoverage{
+				"example.com/mod/cache/cache.go": {Path: "example.com/mod/cache/cache.go", Covered: 3, Total: 6, Percent: 50},
+			},
+		},
+		{
+			name:           "JUnit report",
+			content:        `<testsuite name="api"><testcase name="testCreate"/></testsuite>`,
+			expectedFormat: "cobertura",
+			expectError:    true,
+		},
+		{
+			name:           "plain text",
+			content:        "build succeeded\n",
+			expectedFormat: "lcov",
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files, format, err := parseCoverageReport([]byte(tc.content))
+			assert.Equal(t, tc.expectedFormat, format)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+func Test_GetCoverageReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCoverageReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_coverage_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+
+	reports := zipArchive(t, map[string]string{
+		"coverage.out":     "mode: set\ngithub.com/owner/repo/pkg/cache/cache.go:3.20,5.2 3 1\ngithub.com/owner/repo/pkg/cache/cache.go:7.20,9.2 1 0\n",
+		"lcov.info":        "SF:/home/runner/work/repo/repo/web/app.js\nDA:1,1\nDA:2,0\nend_of_record\n",
+		"junit/report.xml": `<testsuite name="api"><testcase name="testCreate"/></testsuite>`,
+	})
+	noReports := zipArchive(t, map[string]string{"build.log": "ok\n"})
+	downloads := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/empty.zip" {
+			_, _ = w.Write(noReports)
+			return
+		}
+		_, _ = w.Write(reports)
+	}))
+	defer downloads.Close()
+
+	artifactHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/artifacts/1/zip":
+			w.Header().Set("Location", downloads.URL+"/coverage.zip")
+			w.WriteHeader(http.StatusFound)
+		case "/repos/owner/repo/actions/artifacts/2/zip":
+			w.Header().Set("Location", downloads.URL+"/empty.zip")
+			w.WriteHeader(http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	prFiles := []*github.CommitFile{
+		{Filename: github.Ptr("pkg/cache/cache.go"), Status: github.Ptr("modified")},
+		{Filename: github.Ptr("web/app.js"), Status: github.Ptr("added")},
+		{Filename: github.Ptr("README.md"), Status: github.Ptr("modified")},
+		{Filename: github.Ptr("web/legacy.js"), Status: github.Ptr("removed")},
+	}
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedOverall  FileCoverage
+		expectedChanged  *FileCoverage
+		expectedFiles    []FileCoverage
+		expectedNotFound []string
+	}{
+		{
+			name: "overall coverage",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(1),
+			},
+			expectedOverall: FileCoverage{Covered: 4, Total: 6, Percent: 66.67},
+		},
+		{
+			name: "coverage of changed files",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(1),
+				"pullNumber":  float64(42),
+			},
+			expectedOverall: FileCoverage{Covered: 4, Total: 6, Percent: 66.67},
+			expectedChanged: &FileCoverage{Covered: 4, Total: 6, Percent: 66.67},
+			expectedFiles: []FileCoverage{
+				{Path: "pkg/cache/cache.go", Covered: 3, Total: 4, Percent: 75},
+				{Path: "web/app.js", Covered: 1, Total: 2, Percent: 50},
+			},
+			expectedNotFound: []string{"README.md"},
+		},
+		{
+			name: "artifact without coverage reports",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "the artifact contains no lcov, Cobertura or Go coverage reports",
+		},
+		{
+			name: "artifact not found",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to download artifact",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat, artifactHandler),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, prFiles),
+			))
+			_, handler := GetCoverageReport(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Reports []map[string]string `json:"reports"`
+				Overall FileCoverage        `json:"overall"`
+				Changed *FileCoverage       `json:"changed_files"`
+				Files   []FileCoverage      `json:"changed_file_coverage"`
+				Missing []string            `json:"changed_files_not_in_report"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, []map[string]string{
+				{"file": "coverage.out", "format": "go"},
+				{"file": "lcov.info", "format": "lcov"},
+			}, response.Reports)
+			assert.Equal(t, tc.expectedOverall, response.Overall)
+			assert.Equal(t, tc.expectedChanged, response.Changed)
+			assert.Equal(t, tc.expectedFiles, response.Files)
+			assert.Equal(t, tc.expectedNotFound, response.Missing)
+		})
+	}
+}
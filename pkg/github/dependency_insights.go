@@ -0,0 +1,324 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// manifestDependenciesPageSize is the number of dependencies read per manifest. Manifests declaring more
+// dependencies are reported as incomplete.
+const manifestDependenciesPageSize = 100
+
+// dependencyManifestsQuery reads the dependency graph manifests of a repository with their dependencies.
+type dependencyManifestsQuery struct {
+	Repository struct {
+		DependencyGraphManifests struct {
+			Nodes []struct {
+				Filename          githubv4.String
+				DependenciesCount githubv4.Int
+				Dependencies      struct {
+					Nodes []struct {
+						PackageName    githubv4.String
+						PackageManager githubv4.String
+						Requirements   githubv4.String
+					}
+				} `graphql:"dependencies(first: 100)"`
+			}
+			PageInfo PageInfoFragment
+		} `graphql:"dependencyGraphManifests(first: 50, withDependencies: true, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// PackageDependent is a manifest of a repository that depends on a package.
+type PackageDependent struct {
+	Repository     string `json:"repository"`
+	Manifest       string `json:"manifest"`
+	PackageManager string `json:"packageManager"`
+	Requirements   string `json:"requirements"`
+	Version        string `json:"version,omitempty"`
+	// VersionUnknown is set when a version range was given but no version could be read from the requirements.
+	VersionUnknown bool `json:"versionUnknown,omitempty"`
+}
+
+var requirementVersionRE = regexp.MustCompile(`\d+(\.\d+)*(-[0-9A-Za-z.-]+)?`)
+
+// requirementVersion returns the first version in a manifest requirement such as "= 2.14.1", "^1.2.0" or
+// ">= 1.0, < 2.0".
+func requirementVersion(requirements string) string {
+	return requirementVersionRE.FindString(requirements)
+}
+
+// compareVersions compares two dotted versions numerically, a version with a pre-release suffix sorting
+// before the same version without one.
+func compareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(a, "-")
+	bCore, bPre, _ := strings.Cut(b, "-")
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
+
+// versionConstraint is one comparison of a version range, such as ">=2.0.0".
+type versionConstraint struct {
+	op      string
+	version string
+}
+
+// parseVersionRange parses a version range made of comma separated constraints, e.g. ">=2.0.0, <2.17.1".
+// A bare version matches only itself.
+func parseVersionRange(versionRange string) ([]versionConstraint, error) {
+	var constraints []versionConstraint
+	for _, part := range strings.Split(versionRange, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		op := "="
+		for _, candidate := range []string{">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				part = strings.TrimSpace(strings.TrimPrefix(part, candidate))
+				break
+			}
+		}
+		if requirementVersionRE.FindString(part) != part {
+			return nil, fmt.Errorf("invalid version '%s' in version_range", part)
+		}
+		constraints = append(constraints, versionConstraint{op: op, version: part})
+	}
+	return constraints, nil
+}
+
+func versionInRange(version string, constraints []versionConstraint) bool {
+	for _, c := range constraints {
+		cmp := compareVersions(version, c.version)
+		var ok bool
+		switch c.op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FindOrgPackageDependents creates a tool to find the repositories of an organization depending on a package.
+func FindOrgPackageDependents(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("find_org_package_dependents",
+			mcp.WithDescription(t("TOOL_FIND_ORG_PACKAGE_DEPENDENTS_DESCRIPTION", "Find the repositories of an organization that depend on a package, optionally within a version range, using the dependency graph. Returns each dependent repository with the manifest paths and requirements declaring the package. Archived repositories are skipped. Pagination applies to the organization's repositories when 'repositories' is not provided.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_ORG_PACKAGE_DEPENDENTS_USER_TITLE", "Find organization package dependents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("package",
+				mcp.Required(),
+				mcp.Description("The package name as it appears in manifests, e.g. 'lodash' or 'org.apache.logging.log4j:log4j-core'. Matched case-insensitively."),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Only match packages of this package manager"),
+				mcp.Enum("ACTIONS", "COMPOSER", "GO", "MAVEN", "NPM", "NUGET", "PIP", "PUB", "RUBYGEMS", "RUST", "SWIFT"),
+			),
+			mcp.WithString("version_range",
+				mcp.Description("Only match requirements whose version is within this range of comma separated constraints, e.g. '>=2.0.0, <2.17.1'. The first version in a requirement is compared, requirements without a version are included and flagged."),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to inspect. Defaults to the organization's repositories."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := RequiredParam[string](request, "package")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionRange, err := OptionalParam[string](request, "version_range")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			constraints, err := parseVersionRange(versionRange)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			names, hasNextPage, resp, err := listOrgRepositoryNames(ctx, client, org, repos, pagination)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+
+			type repositoryResult struct {
+				dependents []PackageDependent
+				incomplete []string
+			}
+			results := make([]repositoryResult, len(names))
+			errs := fanOut(ctx, names, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				vars := map[string]any{
+					"owner": githubv4.String(org),
+					"repo":  githubv4.String(repo),
+					"after": (*githubv4.String)(nil),
+				}
+				for {
+					var query dependencyManifestsQuery
+					if err := gqlClient.Query(ctx, &query, vars); err != nil {
+						return err
+					}
+					manifests := query.Repository.DependencyGraphManifests
+					for _, manifest := range manifests.Nodes {
+						if int(manifest.DependenciesCount) > manifestDependenciesPageSize {
+							results[i].incomplete = append(results[i].incomplete, string(manifest.Filename))
+						}
+						for _, dep := range manifest.Dependencies.Nodes {
+							if !strings.EqualFold(string(dep.PackageName), packageName) {
+								continue
+							}
+							if ecosystem != "" && !strings.EqualFold(string(dep.PackageManager), ecosystem) {
+								continue
+							}
+							dependent := PackageDependent{
+								Repository:     repo,
+								Manifest:       string(manifest.Filename),
+								PackageManager: string(dep.PackageManager),
+								Requirements:   string(dep.Requirements),
+								Version:        requirementVersion(string(dep.Requirements)),
+							}
+							if len(constraints) > 0 {
+								if dependent.Version == "" {
+									dependent.VersionUnknown = true
+								} else if !versionInRange(dependent.Version, constraints) {
+									continue
+								}
+							}
+							results[i].dependents = append(results[i].dependents, dependent)
+						}
+					}
+					if !manifests.PageInfo.HasNextPage {
+						return nil
+					}
+					vars["after"] = githubv4.String(manifests.PageInfo.EndCursor)
+				}
+			})
+
+			dependents := []PackageDependent{}
+			dependentRepos := 0
+			var failures []map[string]string
+			var incomplete []map[string]any
+			for i, result := range results {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"repository": names[i], "error": errs[i].Error()})
+					continue
+				}
+				if len(result.dependents) > 0 {
+					dependentRepos++
+				}
+				dependents = append(dependents, result.dependents...)
+				if len(result.incomplete) > 0 {
+					incomplete = append(incomplete, map[string]any{"repository": names[i], "manifests": result.incomplete})
+				}
+			}
+
+			response := map[string]any{
+				"org":                   org,
+				"package":               packageName,
+				"scannedRepositories":   len(names),
+				"dependentRepositories": dependentRepos,
+				"dependents":            dependents,
+				"hasNextPage":           hasNextPage,
+			}
+			if ecosystem != "" {
+				response["ecosystem"] = ecosystem
+			}
+			if versionRange != "" {
+				response["versionRange"] = versionRange
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			// Manifests with more dependencies than were read may hide further matches.
+			if len(incomplete) > 0 {
+				response["incompleteManifests"] = incomplete
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VersionInRange(t *testing.T) {
+	constraints, err := parseVersionRange(">=2.0.0, <2.17.1")
+	require.NoError(t, err)
+
+	assert.True(t, versionInRange("2.0.0", constraints))
+	assert.True(t, versionInRange("2.14.1", constraints))
+	assert.True(t, versionInRange("2.17.1-rc1", constraints))
+	assert.False(t, versionInRange("2.17.1", constraints))
+	assert.False(t, versionInRange("1.2.17", constraints))
+
+	exact, err := parseVersionRange("1.2")
+	require.NoError(t, err)
+	assert.True(t, versionInRange("1.2.0", exact))
+	assert.False(t, versionInRange("1.2.1", exact))
+
+	_, err = parseVersionRange(">= latest")
+	assert.EqualError(t, err, "invalid version 'latest' in version_range")
+
+	assert.Equal(t, "2.14.1", requirementVersion("= 2.14.1"))
+	assert.Equal(t, "1.0", requirementVersion(">= 1.0, < 2.0"))
+	assert.Equal(t, "", requirementVersion("*"))
+}
+
+func Test_FindOrgPackageDependents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindOrgPackageDependents(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_org_package_dependents", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "package")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.Contains(t, tool.InputSchema.Properties, "version_range")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package"})
+
+	manifests := func(nodes ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"dependencyGraphManifests": map[string]any{
+					"nodes":    nodes,
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				},
+			},
+		})
+	}
+	manifest := func(filename string, count int, deps ...map[string]any) map[string]any {
+		return map[string]any{
+			"filename":          filename,
+			"dependenciesCount": count,
+			"dependencies":      map[string]any{"nodes": deps},
+		}
+	}
+	dep := func(name, manager, requirements string) map[string]any {
+		return map[string]any{"packageName": name, "packageManager": manager, "requirements": requirements}
+	}
+	vars := func(repo string) map[string]any {
+		return map[string]any{
+			"owner": githubv4.String("octo-org"),
+			"repo":  githubv4.String(repo),
+			"after": (*githubv4.String)(nil),
+		}
+	}
+	log4j := "org.apache.logging.log4j:log4j-core"
+
+	tests := []struct {
+		name               string
+		restClient         *http.Client
+		gqlClient          *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedDependents []PackageDependent
+		expectedErrors     int
+		expectedIncomplete int
+	}{
+		{
+			name: "dependents across organization repositories",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					[]*github.Repository{
+						{Name: github.Ptr("api")},
+						{Name: github.Ptr("legacy"), Archived: github.Ptr(true)},
+					},
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(dependencyManifestsQuery{}, vars("api"), manifests(
+					manifest("pom.xml", 2, dep(log4j, "MAVEN", "= 2.14.1"), dep("junit:junit", "MAVEN", "= 4.13")),
+					manifest("tools/pom.xml", 150, dep(log4j, "MAVEN", "= 2.17.2")),
+					manifest("build.gradle", 1, dep("org.apache.logging.log4j:LOG4J-CORE", "MAVEN", "")),
+				)),
+			),
+			requestArgs: map[string]any{
+				"org":           "octo-org",
+				"package":       log4j,
+				"version_range": ">=2.0.0, <2.17.1",
+			},
+			expectedDependents: []PackageDependent{
+				{Repository: "api", Manifest: "pom.xml", PackageManager: "MAVEN", Requirements: "= 2.14.1", Version: "2.14.1"},
+				{Repository: "api", Manifest: "build.gradle", PackageManager: "MAVEN", VersionUnknown: true},
+			},
+			expectedIncomplete: 1,
+		},
+		{
+			// Only "site" is mocked, so the query for "missing" fails.
+			name:       "explicit repositories with ecosystem filter and failure",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(dependencyManifestsQuery{}, vars("site"), manifests(
+					manifest("package.json", 1, dep("lodash", "NPM", "^4.17.20")),
+					manifest("vendor/package.json", 1, dep("lodash", "PIP", "4.17.20")),
+				)),
+			),
+			requestArgs: map[string]any{
+				"org":          "octo-org",
+				"package":      "lodash",
+				"ecosystem":    "NPM",
+				"repositories": []any{"site", "missing"},
+			},
+			expectedDependents: []PackageDependent{
+				{Repository: "site", Manifest: "package.json", PackageManager: "NPM", Requirements: "^4.17.20", Version: "4.17.20"},
+			},
+			expectedErrors: 1,
+		},
+		{
+			name:       "invalid version range",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":           "octo-org",
+				"package":       "lodash",
+				"version_range": "~> 4",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid version '~> 4' in version_range",
+		},
+		{
+			name: "listing repositories fails",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org":     "octo-org",
+				"package": "lodash",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := FindOrgPackageDependents(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Dependents          []PackageDependent `json:"dependents"`
+				Errors              []map[string]string
+				IncompleteManifests []map[string]any `json:"incompleteManifests"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedDependents, response.Dependents)
+			assert.Len(t, response.Errors, tc.expectedErrors)
+			assert.Len(t, response.IncompleteManifests, tc.expectedIncomplete)
+		})
+	}
+}
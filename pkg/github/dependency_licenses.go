@@ -0,0 +1,352 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	licenseCategoryPermissive   = "permissive"
+	licenseCategoryWeakCopyleft = "weak_copyleft"
+	licenseCategoryCopyleft     = "copyleft"
+	licenseCategoryOther        = "other"
+	licenseCategoryUnknown      = "unknown"
+)
+
+// licenseCategoryRank orders the license categories from least to most in need of legal review.
+var licenseCategoryRank = map[string]int{
+	licenseCategoryPermissive:   0,
+	licenseCategoryOther:        1,
+	licenseCategoryWeakCopyleft: 2,
+	licenseCategoryCopyleft:     3,
+	licenseCategoryUnknown:      4,
+}
+
+// SPDX identifier prefixes of well known licenses. Anything not listed is reported as "other".
+var (
+	copyleftLicensePrefixes     = []string{"AGPL-", "GPL-", "SSPL-", "OSL-", "EUPL-", "CC-BY-SA-", "CPAL-", "RPL-", "Sleepycat"}
+	weakCopyleftLicensePrefixes = []string{"LGPL-", "MPL-", "EPL-", "CDDL-", "CPL-", "MS-RL", "APSL-", "CECILL-"}
+	permissiveLicensePrefixes   = []string{"MIT", "Apache-", "BSD-", "0BSD", "ISC", "Unlicense", "Zlib", "CC0-", "CC-BY-", "Python-", "PSF-", "BSL-1.0", "WTFPL", "X11", "Artistic-2.0", "BlueOak-", "MS-PL", "PostgreSQL", "NCSA", "Unicode-"}
+)
+
+// DependencyLicense is the license of a single dependency of a repository.
+type DependencyLicense struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+	License   string `json:"license,omitempty"`
+	Category  string `json:"category"`
+	// Source is "sbom" when the license comes from the dependency graph, or "repository" when it was
+	// resolved from the license of the dependency's GitHub repository.
+	Source string `json:"source,omitempty"`
+}
+
+// classifySPDXIdentifier classifies a single SPDX license identifier.
+func classifySPDXIdentifier(id string) string {
+	switch {
+	case id == "" || id == "NOASSERTION" || id == "NONE" || strings.HasPrefix(id, "LicenseRef-"):
+		return licenseCategoryUnknown
+	case hasAnyPrefix(id, copyleftLicensePrefixes):
+		return licenseCategoryCopyleft
+	case hasAnyPrefix(id, weakCopyleftLicensePrefixes):
+		return licenseCategoryWeakCopyleft
+	case hasAnyPrefix(id, permissiveLicensePrefixes):
+		return licenseCategoryPermissive
+	default:
+		return licenseCategoryOther
+	}
+}
+
+// classifyLicense classifies an SPDX license expression. A choice between licenses ("OR") takes the least
+// restrictive alternative, while a combination ("AND") takes the most restrictive one. License exceptions
+// ("WITH") are ignored. Malformed expressions are reported as unknown.
+func classifyLicense(expression string) string {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+	if len(tokens) == 0 {
+		return licenseCategoryUnknown
+	}
+	parser := &licenseExpressionParser{tokens: tokens}
+	category := parser.parseOr()
+	if parser.invalid || parser.pos != len(tokens) {
+		return licenseCategoryUnknown
+	}
+	return category
+}
+
+// licenseExpressionParser evaluates an SPDX license expression into a license category.
+type licenseExpressionParser struct {
+	tokens  []string
+	pos     int
+	invalid bool
+}
+
+func (p *licenseExpressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *licenseExpressionParser) parseOr() string {
+	best := p.parseAnd()
+	for strings.EqualFold(p.peek(), "OR") {
+		p.pos++
+		if category := p.parseAnd(); licenseCategoryRank[category] < licenseCategoryRank[best] {
+			best = category
+		}
+	}
+	return best
+}
+
+func (p *licenseExpressionParser) parseAnd() string {
+	worst := p.parseLicense()
+	for strings.EqualFold(p.peek(), "AND") {
+		p.pos++
+		if category := p.parseLicense(); licenseCategoryRank[category] > licenseCategoryRank[worst] {
+			worst = category
+		}
+	}
+	return worst
+}
+
+func (p *licenseExpressionParser) parseLicense() string {
+	token := p.peek()
+	p.pos++
+	switch token {
+	case "(":
+		category := p.parseOr()
+		if p.peek() != ")" {
+			p.invalid = true
+		}
+		p.pos++
+		return category
+	case "", ")":
+		p.invalid = true
+		return licenseCategoryUnknown
+	}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.pos += 2
+	}
+	return classifySPDXIdentifier(token)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// packageURL returns the package URL of a dependency, if the dependency graph knows it.
+func packageURL(pkg *github.RepoDependencies) string {
+	for _, ref := range pkg.ExternalRefs {
+		if ref != nil && ref.ReferenceType == "purl" {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+// githubRepositoryOf guesses the GitHub repository hosting a dependency from its name, package URL or download
+// location, e.g. Go modules and GitHub Actions are named after their repository.
+func githubRepositoryOf(pkg *github.RepoDependencies, purl string) (string, string, bool) {
+	if rest, ok := strings.CutPrefix(purl, "pkg:github/"); ok {
+		rest, _, _ = strings.Cut(rest, "@")
+		if owner, repo, ok := strings.Cut(rest, "/"); ok {
+			repo, _, _ = strings.Cut(repo, "/")
+			return owner, repo, owner != "" && repo != ""
+		}
+	}
+	for _, candidate := range []string{pkg.GetName(), pkg.GetDownloadLocation()} {
+		_, rest, ok := strings.Cut(candidate, "github.com/")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(rest, "/")
+		if len(parts) < 2 {
+			continue
+		}
+		repo, _, _ := strings.Cut(parts[1], "@")
+		repo = strings.TrimSuffix(repo, ".git")
+		if parts[0] != "" && repo != "" {
+			return parts[0], repo, true
+		}
+	}
+	return "", "", false
+}
+
+func GetDependencyLicenseReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependency_license_report",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_LICENSE_REPORT_DESCRIPTION", "Report the licenses of the dependencies of a GitHub repository, from its dependency graph. Each license is classified as permissive, weak_copyleft, copyleft, other or unknown, and every dependency that is not permissive is flagged for review. Dependencies without license metadata that are hosted on GitHub are resolved from their repository license. This is a first-pass report, not legal advice.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDENCY_LICENSE_REPORT_USER_TITLE", "Get dependency license report"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithBoolean("resolve_unknown",
+				mcp.Description("Resolve dependencies without license metadata from the license of their GitHub repository. Defaults to true."),
+			),
+			mcp.WithNumber("max_license_lookups",
+				mcp.Description("Maximum number of repository license lookups when resolving unknown licenses. Defaults to 25."),
+				mcp.Min(0),
+				mcp.Max(100),
+			),
+			mcp.WithBoolean("include_all",
+				mcp.Description("Include every dependency in the report, not only the flagged ones. Defaults to false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolveUnknown, err := OptionalBoolParamWithDefault(request, "resolve_unknown", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxLookups, err := OptionalIntParamWithDefault(request, "max_license_lookups", 25)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAll, err := OptionalBoolParamWithDefault(request, "include_all", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sbom, resp, err := client.DependencyGraph.GetSBOM(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get dependency graph for '%s/%s'", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var packages []*github.RepoDependencies
+			var describes []string
+			if sbom.SBOM != nil {
+				packages = sbom.SBOM.Packages
+				describes = sbom.SBOM.DocumentDescribes
+			}
+
+			dependencies := make([]*DependencyLicense, 0, len(packages))
+			type licenseLookup struct {
+				index       int
+				owner, repo string
+			}
+			var lookups []licenseLookup
+			for _, pkg := range packages {
+				if pkg == nil {
+					continue
+				}
+				// The repository itself is listed as a package, and is not one of its dependencies.
+				isRoot := false
+				for _, id := range describes {
+					if id == pkg.GetSPDXID() {
+						isRoot = true
+						break
+					}
+				}
+				if isRoot {
+					continue
+				}
+
+				license := pkg.GetLicenseConcluded()
+				if license == "" || license == "NOASSERTION" {
+					license = pkg.GetLicenseDeclared()
+				}
+				purl := packageURL(pkg)
+				ecosystem := ""
+				if rest, ok := strings.CutPrefix(purl, "pkg:"); ok {
+					ecosystem, _, _ = strings.Cut(rest, "/")
+				}
+				dependency := &DependencyLicense{
+					Name:      pkg.GetName(),
+					Version:   pkg.GetVersionInfo(),
+					Ecosystem: ecosystem,
+					Category:  classifyLicense(license),
+				}
+				if dependency.Category != licenseCategoryUnknown {
+					dependency.License = license
+					dependency.Source = "sbom"
+				} else if resolveUnknown && len(lookups) < maxLookups {
+					if depOwner, depRepo, ok := githubRepositoryOf(pkg, purl); ok {
+						lookups = append(lookups, licenseLookup{index: len(dependencies), owner: depOwner, repo: depRepo})
+					}
+				}
+				dependencies = append(dependencies, dependency)
+			}
+
+			// Lookups that fail leave the dependency unknown, which is the conservative outcome for a review.
+			_ = fanOut(ctx, lookups, DefaultFanOutConcurrency, func(ctx context.Context, _ int, lookup licenseLookup) error {
+				license, resp, err := client.Repositories.License(ctx, lookup.owner, lookup.repo)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				spdxID := license.GetLicense().GetSPDXID()
+				if category := classifyLicense(spdxID); category != licenseCategoryUnknown {
+					dependencies[lookup.index].License = spdxID
+					dependencies[lookup.index].Category = category
+					dependencies[lookup.index].Source = "repository"
+				}
+				return nil
+			})
+
+			summary := map[string]int{}
+			licenses := map[string]int{}
+			flagged := []*DependencyLicense{}
+			for _, dependency := range dependencies {
+				summary[dependency.Category]++
+				if dependency.License != "" {
+					licenses[dependency.License]++
+				}
+				if dependency.Category != licenseCategoryPermissive {
+					flagged = append(flagged, dependency)
+				}
+			}
+			sort.SliceStable(flagged, func(i, j int) bool {
+				return licenseCategoryRank[flagged[i].Category] > licenseCategoryRank[flagged[j].Category]
+			})
+
+			report := map[string]any{
+				"repository":        fmt.Sprintf("%s/%s", owner, repo),
+				"totalDependencies": len(dependencies),
+				"summary":           summary,
+				"licenses":          licenses,
+				"flagged":           flagged,
+			}
+			if includeAll {
+				report["dependencies"] = dependencies
+			}
+
+			return MarshalledTextResult(ctx, report), nil
+		}
+}
@@ -0,0 +1,207 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClassifyLicense(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"MIT", licenseCategoryPermissive},
+		{"Apache-2.0", licenseCategoryPermissive},
+		{"GPL-3.0-only", licenseCategoryCopyleft},
+		{"AGPL-3.0-or-later", licenseCategoryCopyleft},
+		{"LGPL-2.1-only", licenseCategoryWeakCopyleft},
+		{"MPL-2.0", licenseCategoryWeakCopyleft},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", licenseCategoryCopyleft},
+		{"MIT OR GPL-3.0-only", licenseCategoryPermissive},
+		{"MIT AND GPL-3.0-only", licenseCategoryCopyleft},
+		{"(MIT OR Apache-2.0) AND MPL-2.0", licenseCategoryWeakCopyleft},
+		{"NOASSERTION", licenseCategoryUnknown},
+		{"LicenseRef-proprietary", licenseCategoryUnknown},
+		{"", licenseCategoryUnknown},
+		{"(MIT OR", licenseCategoryUnknown},
+		{"Elastic-2.0", licenseCategoryOther},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expression, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifyLicense(tc.expression))
+		})
+	}
+}
+
+func Test_GetDependencyLicenseReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependencyLicenseReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_dependency_license_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "resolve_unknown")
+	assert.Contains(t, tool.InputSchema.Properties, "max_license_lookups")
+	assert.Contains(t, tool.InputSchema.Properties, "include_all")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	purl := func(locator string) []*github.PackageExternalRef {
+		return []*github.PackageExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: locator}}
+	}
+	sbom := &github.SBOM{
+		SBOM: &github.SBOMInfo{
+			DocumentDescribes: []string{"SPDXRef-github-owner-repo"},
+			Packages: []*github.RepoDependencies{
+				{SPDXID: github.Ptr("SPDXRef-github-owner-repo"), Name: github.Ptr("com.github.owner/repo"), LicenseConcluded: github.Ptr("GPL-3.0-only")},
+				{SPDXID: github.Ptr("SPDXRef-npm-left-pad"), Name: github.Ptr("npm:left-pad"), VersionInfo: github.Ptr("1.3.0"), LicenseConcluded: github.Ptr("MIT"), ExternalRefs: purl("pkg:npm/left-pad@1.3.0")},
+				{SPDXID: github.Ptr("SPDXRef-npm-gpl-lib"), Name: github.Ptr("npm:gpl-lib"), VersionInfo: github.Ptr("2.0.0"), LicenseDeclared: github.Ptr("GPL-2.0-or-later"), ExternalRefs: purl("pkg:npm/gpl-lib@2.0.0")},
+				{SPDXID: github.Ptr("SPDXRef-go-cobra"), Name: github.Ptr("go:github.com/spf13/cobra"), VersionInfo: github.Ptr("1.8.1"), LicenseConcluded: github.Ptr("NOASSERTION"), ExternalRefs: purl("pkg:golang/github.com/spf13/cobra@1.8.1")},
+				{SPDXID: github.Ptr("SPDXRef-npm-mystery"), Name: github.Ptr("npm:mystery"), VersionInfo: github.Ptr("0.1.0"), ExternalRefs: purl("pkg:npm/mystery@0.1.0")},
+			},
+		},
+	}
+	cobraLicense := &github.RepositoryLicense{
+		License: &github.License{Key: github.Ptr("apache-2.0"), SPDXID: github.Ptr("Apache-2.0")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedReport func(t *testing.T, report map[string]any)
+	}{
+		{
+			name: "report with license resolution",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					sbom,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					expectPath(t, "/repos/spf13/cobra/license").andThen(
+						mockResponse(t, http.StatusOK, cobraLicense),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedReport: func(t *testing.T, report map[string]any) {
+				assert.Equal(t, "owner/repo", report["repository"])
+				assert.Equal(t, float64(4), report["totalDependencies"])
+				assert.Equal(t, map[string]any{
+					"permissive": float64(2),
+					"copyleft":   float64(1),
+					"unknown":    float64(1),
+				}, report["summary"])
+				assert.Equal(t, map[string]any{
+					"MIT":              float64(1),
+					"GPL-2.0-or-later": float64(1),
+					"Apache-2.0":       float64(1),
+				}, report["licenses"])
+				assert.NotContains(t, report, "dependencies")
+
+				flagged, ok := report["flagged"].([]any)
+				require.True(t, ok)
+				require.Len(t, flagged, 2)
+				unknown := flagged[0].(map[string]any)
+				assert.Equal(t, "npm:mystery", unknown["name"])
+				assert.Equal(t, "unknown", unknown["category"])
+				copyleft := flagged[1].(map[string]any)
+				assert.Equal(t, "npm:gpl-lib", copyleft["name"])
+				assert.Equal(t, "npm", copyleft["ecosystem"])
+				assert.Equal(t, "copyleft", copyleft["category"])
+				assert.Equal(t, "sbom", copyleft["source"])
+			},
+		},
+		{
+			name: "report without license resolution",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					sbom,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"resolve_unknown": false,
+				"include_all":     true,
+			},
+			expectedReport: func(t *testing.T, report map[string]any) {
+				assert.Equal(t, map[string]any{
+					"permissive": float64(1),
+					"copyleft":   float64(1),
+					"unknown":    float64(2),
+				}, report["summary"])
+				dependencies, ok := report["dependencies"].([]any)
+				require.True(t, ok)
+				assert.Len(t, dependencies, 4)
+				flagged, ok := report["flagged"].([]any)
+				require.True(t, ok)
+				assert.Len(t, flagged, 3)
+			},
+		},
+		{
+			name: "dependency graph not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get dependency graph for 'owner/repo'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDependencyLicenseReport(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var report map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+			tc.expectedReport(t, report)
+		})
+	}
+}
@@ -0,0 +1,1100 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxDeploymentsScanned is the number of most recent deployments of an environment get_deploy_status looks
+	// through for successful deployments.
+	maxDeploymentsScanned = 100
+	// maxDeployedCommits is the number of commits between two deployments get_deploy_status links pull requests for.
+	maxDeployedCommits = 250
+)
+
+// DeployedVersion is a deployment that succeeded.
+type DeployedVersion struct {
+	ID             int64  `json:"id"`
+	SHA            string `json:"sha"`
+	Ref            string `json:"ref"`
+	Creator        string `json:"creator,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	DeployedAt     string `json:"deployed_at,omitempty"`
+	State          string `json:"state"`
+	EnvironmentURL string `json:"environment_url,omitempty"`
+	LogURL         string `json:"log_url,omitempty"`
+}
+
+// DeployedPullRequest is a pull request included in a deployment.
+type DeployedPullRequest struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	Author   string `json:"author"`
+	MergedAt string `json:"merged_at,omitempty"`
+	URL      string `json:"url"`
+}
+
+// DeployStatus is what is deployed to an environment, and what changed since the deployment before.
+type DeployStatus struct {
+	Environment           string                `json:"environment"`
+	Current               *DeployedVersion      `json:"current"`
+	Previous              *DeployedVersion      `json:"previous,omitempty"`
+	CommitsSincePrevious  int                   `json:"commits_since_previous,omitempty"`
+	PullRequests          []DeployedPullRequest `json:"pull_requests,omitempty"`
+	PullRequestsTruncated bool                  `json:"pull_requests_truncated,omitempty"`
+}
+
+// findSuccessfulDeployments returns the most recent deployments of an environment that succeeded, newest first, up
+// to limit. A deployment succeeded if any of its statuses is "success", as an older deployment becomes "inactive"
+// once a newer one succeeds.
+func findSuccessfulDeployments(ctx context.Context, client *github.Client, owner, repo, environment string, limit int) ([]*DeployedVersion, *github.Response, error) {
+	deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: maxDeploymentsScanned},
+	})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	var versions []*DeployedVersion
+	for _, deployment := range deployments {
+		statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to list statuses of deployment %d: %w", deployment.GetID(), err)
+		}
+		_ = resp.Body.Close()
+
+		// Statuses are listed newest first.
+		for _, status := range statuses {
+			if status.GetState() != "success" {
+				continue
+			}
+			versions = append(versions, &DeployedVersion{
+				ID:             deployment.GetID(),
+				SHA:            deployment.GetSHA(),
+				Ref:            deployment.GetRef(),
+				Creator:        deployment.GetCreator().GetLogin(),
+				CreatedAt:      formatOptionalTimestamp(deployment.CreatedAt),
+				DeployedAt:     formatOptionalTimestamp(status.CreatedAt),
+				State:          statuses[0].GetState(),
+				EnvironmentURL: status.GetEnvironmentURL(),
+				LogURL:         status.GetLogURL(),
+			})
+			break
+		}
+		if len(versions) == limit {
+			break
+		}
+	}
+	return versions, resp, nil
+}
+
+// getDeployStatus returns what is deployed to an environment, with the pull requests included since the deployment
+// before. The environment has no current deployment if none of its recent deployments succeeded.
+func getDeployStatus(ctx context.Context, client *github.Client, owner, repo, environment string) (*DeployStatus, *github.Response, error) {
+	versions, resp, err := findSuccessfulDeployments(ctx, client, owner, repo, environment, 2)
+	if err != nil {
+		return nil, resp, err
+	}
+	status := &DeployStatus{Environment: environment}
+	if len(versions) == 0 {
+		return status, resp, nil
+	}
+	status.Current = versions[0]
+	if len(versions) == 1 {
+		return status, resp, nil
+	}
+	status.Previous = versions[1]
+	if status.Previous.SHA == status.Current.SHA {
+		return status, resp, nil
+	}
+
+	comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, status.Previous.SHA, status.Current.SHA, &github.ListOptions{PerPage: maxDeployedCommits})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to compare deployed commits: %w", err)
+	}
+	_ = resp.Body.Close()
+	status.CommitsSincePrevious = comparison.GetTotalCommits()
+	commits := comparison.Commits
+	if len(commits) > maxDeployedCommits {
+		commits = commits[:maxDeployedCommits]
+	}
+	status.PullRequestsTruncated = comparison.GetTotalCommits() > len(commits)
+
+	// Commits don't link their pull requests, so they are looked up for every commit.
+	commitPulls := make([][]*github.PullRequest, len(commits))
+	commitErrs := fanOut(ctx, commits, DefaultFanOutConcurrency, func(ctx context.Context, i int, commit *github.RepositoryCommit) error {
+		pulls, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, commit.GetSHA(), &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		commitPulls[i] = pulls
+		return nil
+	})
+
+	pullsByNumber := map[int]DeployedPullRequest{}
+	for i := range commits {
+		if commitErrs[i] != nil {
+			status.PullRequestsTruncated = true
+			continue
+		}
+		for _, pull := range commitPulls[i] {
+			pullsByNumber[pull.GetNumber()] = DeployedPullRequest{
+				Number:   pull.GetNumber(),
+				Title:    pull.GetTitle(),
+				Author:   pull.GetUser().GetLogin(),
+				MergedAt: formatOptionalTimestamp(pull.MergedAt),
+				URL:      pull.GetHTMLURL(),
+			}
+		}
+	}
+	status.PullRequests = make([]DeployedPullRequest, 0, len(pullsByNumber))
+	for _, pull := range pullsByNumber {
+		status.PullRequests = append(status.PullRequests, pull)
+	}
+	sort.Slice(status.PullRequests, func(i, j int) bool { return status.PullRequests[i].Number > status.PullRequests[j].Number })
+	return status, resp, nil
+}
+
+// GetDeployStatus creates a tool to report what is deployed to the environments of a repository.
+func GetDeployStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_deploy_status",
+			mcp.WithDescription(t("TOOL_GET_DEPLOY_STATUS_DESCRIPTION", "Answer \"what is deployed to an environment?\". Finds the latest successful deployment of an environment, or of every environment of the repository, with its ref and commit SHA, and the pull requests included since the successful deployment before it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPLOY_STATUS_USER_TITLE", "Get deploy status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("The name of the environment, such as 'production'. If not provided, all environments of the repository are reported"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if environment != "" {
+				status, resp, err := getDeployStatus(ctx, client, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get deploy status of environment '%s'", environment),
+						resp,
+						err,
+					), nil
+				}
+				return MarshalledTextResult(ctx, status), nil
+			}
+
+			var environments []string
+			opts := &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				envs, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list environments",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, env := range envs.Environments {
+					environments = append(environments, env.GetName())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			statuses := make([]*DeployStatus, len(environments))
+			errs := fanOut(ctx, environments, DefaultFanOutConcurrency, func(ctx context.Context, i int, environment string) error {
+				var err error
+				statuses[i], _, err = getDeployStatus(ctx, client, owner, repo, environment) //nolint:bodyclose // Response bodies are closed in getDeployStatus
+				return err
+			})
+
+			result := []*DeployStatus{}
+			var failures []map[string]string
+			for i, environment := range environments {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"environment": environment, "error": errs[i].Error()})
+					continue
+				}
+				result = append(result, statuses[i])
+			}
+
+			response := map[string]any{
+				"environments": result,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
+
+// deploymentStatusStates are the states a deployment status can have.
+var deploymentStatusStates = []string{"error", "failure", "inactive", "in_progress", "queued", "pending", "success"}
+
+// MinimalDeployment is the trimmed output type for deployment objects.
+type MinimalDeployment struct {
+	ID          int64  `json:"id"`
+	SHA         string `json:"sha"`
+	Ref         string `json:"ref"`
+	Task        string `json:"task,omitempty"`
+	Environment string `json:"environment"`
+	Description string `json:"description,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+// MinimalDeploymentStatus is the trimmed output type for deployment status objects.
+type MinimalDeploymentStatus struct {
+	ID             int64  `json:"id"`
+	State          string `json:"state"`
+	Description    string `json:"description,omitempty"`
+	Environment    string `json:"environment,omitempty"`
+	EnvironmentURL string `json:"environment_url,omitempty"`
+	LogURL         string `json:"log_url,omitempty"`
+	Creator        string `json:"creator,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+}
+
+// EnvironmentReviewer is a user or team that must approve deployments to an environment.
+type EnvironmentReviewer struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// CustomProtectionRule is a GitHub App that must approve deployments to an environment.
+type CustomProtectionRule struct {
+	ID      int64  `json:"id"`
+	App     string `json:"app"`
+	Enabled bool   `json:"enabled"`
+}
+
+// MinimalEnvironment is the trimmed output type for environment objects, with their protection rules.
+type MinimalEnvironment struct {
+	Name                   string                 `json:"name"`
+	HTMLURL                string                 `json:"html_url,omitempty"`
+	WaitTimer              int                    `json:"wait_timer,omitempty"`
+	Reviewers              []EnvironmentReviewer  `json:"reviewers,omitempty"`
+	PreventSelfReview      bool                   `json:"prevent_self_review,omitempty"`
+	DeploymentBranchPolicy string                 `json:"deployment_branch_policy"`
+	CanAdminsBypass        bool                   `json:"can_admins_bypass"`
+	CustomProtectionRules  []CustomProtectionRule `json:"custom_protection_rules,omitempty"`
+	CreatedAt              string                 `json:"created_at,omitempty"`
+	UpdatedAt              string                 `json:"updated_at,omitempty"`
+}
+
+func convertToMinimalDeployment(deployment *github.Deployment) MinimalDeployment {
+	return MinimalDeployment{
+		ID:          deployment.GetID(),
+		SHA:         deployment.GetSHA(),
+		Ref:         deployment.GetRef(),
+		Task:        deployment.GetTask(),
+		Environment: deployment.GetEnvironment(),
+		Description: deployment.GetDescription(),
+		Creator:     deployment.GetCreator().GetLogin(),
+		CreatedAt:   formatOptionalTimestamp(deployment.CreatedAt),
+		UpdatedAt:   formatOptionalTimestamp(deployment.UpdatedAt),
+	}
+}
+
+// deploymentBranchPolicy names the branches that may deploy to an environment: all, protected or custom.
+func deploymentBranchPolicy(policy *github.BranchPolicy) string {
+	switch {
+	case policy.GetProtectedBranches():
+		return "protected"
+	case policy.GetCustomBranchPolicies():
+		return "custom"
+	default:
+		return "all"
+	}
+}
+
+func convertToMinimalEnvironment(env *github.Environment) MinimalEnvironment {
+	minimalEnv := MinimalEnvironment{
+		Name:                   env.GetName(),
+		HTMLURL:                env.GetHTMLURL(),
+		DeploymentBranchPolicy: deploymentBranchPolicy(env.DeploymentBranchPolicy),
+		CanAdminsBypass:        env.GetCanAdminsBypass(),
+		CreatedAt:              formatOptionalTimestamp(env.CreatedAt),
+		UpdatedAt:              formatOptionalTimestamp(env.UpdatedAt),
+	}
+	for _, rule := range env.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			minimalEnv.WaitTimer = rule.GetWaitTimer()
+		case "required_reviewers":
+			minimalEnv.PreventSelfReview = rule.GetPreventSelfReview()
+			for _, reviewer := range rule.Reviewers {
+				switch r := reviewer.Reviewer.(type) {
+				case *github.User:
+					minimalEnv.Reviewers = append(minimalEnv.Reviewers, EnvironmentReviewer{Type: "User", Name: r.GetLogin()})
+				case *github.Team:
+					minimalEnv.Reviewers = append(minimalEnv.Reviewers, EnvironmentReviewer{Type: "Team", Name: r.GetSlug()})
+				}
+			}
+		}
+	}
+	return minimalEnv
+}
+
+// environmentReviewers returns the reviewers of an environment as the API takes them.
+func environmentReviewers(env *github.Environment) []*github.EnvReviewers {
+	var reviewers []*github.EnvReviewers
+	for _, rule := range env.ProtectionRules {
+		for _, reviewer := range rule.Reviewers {
+			switch r := reviewer.Reviewer.(type) {
+			case *github.User:
+				reviewers = append(reviewers, &github.EnvReviewers{Type: github.Ptr("User"), ID: r.ID})
+			case *github.Team:
+				reviewers = append(reviewers, &github.EnvReviewers{Type: github.Ptr("Team"), ID: r.ID})
+			}
+		}
+	}
+	return reviewers
+}
+
+// resolveEnvironmentReviewers looks up the IDs of reviewers given as user logins or org/team-slug team names.
+func resolveEnvironmentReviewers(ctx context.Context, client *github.Client, names []string) ([]*github.EnvReviewers, *github.Response, error) {
+	reviewers := make([]*github.EnvReviewers, 0, len(names))
+	for _, name := range names {
+		if org, slug, isTeam := strings.Cut(name, "/"); isTeam {
+			team, resp, err := client.Teams.GetTeamBySlug(ctx, org, slug)
+			if err != nil {
+				return nil, resp, fmt.Errorf("failed to get team %s: %w", name, err)
+			}
+			_ = resp.Body.Close()
+			reviewers = append(reviewers, &github.EnvReviewers{Type: github.Ptr("Team"), ID: team.ID})
+			continue
+		}
+		user, resp, err := client.Users.Get(ctx, name)
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to get user %s: %w", name, err)
+		}
+		_ = resp.Body.Close()
+		reviewers = append(reviewers, &github.EnvReviewers{Type: github.Ptr("User"), ID: user.ID})
+	}
+	return reviewers, nil, nil
+}
+
+// ListDeployments creates a tool to list the deployments of a repository.
+func ListDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployments",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENTS_DESCRIPTION", "List the deployments of a repository, newest first, optionally only those of an environment, ref, commit or task.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENTS_USER_TITLE", "List deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Only list deployments to this environment, such as 'production'"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list deployments of this branch, tag or SHA"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Only list deployments of this commit SHA"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Only list deployments of this task, such as 'deploy' or 'deploy:migrations'"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			task, err := OptionalParam[string](request, "task")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+				Environment: environment,
+				Ref:         ref,
+				SHA:         sha,
+				Task:        task,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list deployments",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalDeployment, 0, len(deployments))
+			for _, deployment := range deployments {
+				result = append(result, convertToMinimalDeployment(deployment))
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// CreateDeployment creates a tool to create a deployment of a ref to an environment.
+func CreateDeployment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_DESCRIPTION", "Create a deployment of a branch, tag or SHA to an environment. GitHub doesn't deploy anything itself: the deployment triggers the workflows and integrations listening for deployment events, which report progress with deployment statuses. By default, the commit statuses and check runs of the ref must pass first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_USER_TITLE", "Create deployment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or SHA to deploy"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Environment to deploy to. Defaults to 'production'"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Task to run, such as 'deploy:migrations'. Defaults to 'deploy'"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the deployment"),
+			),
+			mcp.WithBoolean("auto_merge",
+				mcp.Description("Merge the default branch into the ref first if it's behind. Defaults to true"),
+			),
+			mcp.WithArray("required_contexts",
+				mcp.Description("Status and check contexts that must pass before deploying. Defaults to all of them, an empty array deploys without checking any"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithBoolean("production_environment",
+				mcp.Description("Whether the environment is one end users interact with. Defaults to true for 'production'"),
+			),
+			mcp.WithBoolean("transient_environment",
+				mcp.Description("Whether the environment will no longer exist at some point, such as a review app"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			task, err := OptionalParam[string](request, "task")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			deploymentRequest := &github.DeploymentRequest{Ref: github.Ptr(ref)}
+			if environment != "" {
+				deploymentRequest.Environment = github.Ptr(environment)
+			}
+			if task != "" {
+				deploymentRequest.Task = github.Ptr(task)
+			}
+			if description != "" {
+				deploymentRequest.Description = github.Ptr(description)
+			}
+			if autoMerge, ok, err := OptionalParamOK[bool](request, "auto_merge"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				deploymentRequest.AutoMerge = github.Ptr(autoMerge)
+			}
+			if production, ok, err := OptionalParamOK[bool](request, "production_environment"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				deploymentRequest.ProductionEnvironment = github.Ptr(production)
+			}
+			if transient, ok, err := OptionalParamOK[bool](request, "transient_environment"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				deploymentRequest.TransientEnvironment = github.Ptr(transient)
+			}
+			// An empty array of required contexts is meaningful, so it's told apart from a missing one
+			if _, ok := request.GetArguments()["required_contexts"]; ok {
+				contexts, err := OptionalStringArrayParam(request, "required_contexts")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if contexts == nil {
+					contexts = []string{}
+				}
+				deploymentRequest.RequiredContexts = &contexts
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployment, resp, err := client.Repositories.CreateDeployment(ctx, owner, repo, deploymentRequest)
+			// GitHub answers with 202 Accepted when it merged the default branch into the ref instead of deploying
+			var acceptedErr *github.AcceptedError
+			if errors.As(err, &acceptedErr) {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultText(fmt.Sprintf("GitHub merged the default branch into %s, which was behind, instead of deploying it. Wait for the checks of the merge commit, then create the deployment again.", ref)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create deployment of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalDeployment(deployment)), nil
+		}
+}
+
+// CreateDeploymentStatus creates a tool to report the state of a deployment.
+func CreateDeploymentStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment_status",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_STATUS_DESCRIPTION", "Report the state of a deployment, such as in_progress while deploying and success or failure once done. A successful deployment makes the previous ones of its environment inactive.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_STATUS_USER_TITLE", "Create deployment status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("deployment_id",
+				mcp.Required(),
+				mcp.Description("The ID of the deployment"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the deployment"),
+				mcp.Enum(deploymentStatusStates...),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status"),
+			),
+			mcp.WithString("environment_url",
+				mcp.Description("URL of the deployed environment"),
+			),
+			mcp.WithString("log_url",
+				mcp.Description("URL of the output of the deployment"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Environment the deployment is moved to, if it changed"),
+			),
+			mcp.WithBoolean("auto_inactive",
+				mcp.Description("Whether a success makes the previous deployments of the environment inactive. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deploymentID, err := RequiredInt(request, "deployment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(deploymentStatusStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s. Supported states are: %s", state, strings.Join(deploymentStatusStates, ", "))), nil
+			}
+
+			statusRequest := &github.DeploymentStatusRequest{State: github.Ptr(state)}
+			for param, field := range map[string]**string{
+				"description":     &statusRequest.Description,
+				"environment_url": &statusRequest.EnvironmentURL,
+				"log_url":         &statusRequest.LogURL,
+				"environment":     &statusRequest.Environment,
+			} {
+				value, err := OptionalParam[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					*field = github.Ptr(value)
+				}
+			}
+			if autoInactive, ok, err := OptionalParamOK[bool](request, "auto_inactive"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				statusRequest.AutoInactive = github.Ptr(autoInactive)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			status, resp, err := client.Repositories.CreateDeploymentStatus(ctx, owner, repo, int64(deploymentID), statusRequest)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create status for deployment %d", deploymentID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, MinimalDeploymentStatus{
+				ID:             status.GetID(),
+				State:          status.GetState(),
+				Description:    status.GetDescription(),
+				Environment:    status.GetEnvironment(),
+				EnvironmentURL: status.GetEnvironmentURL(),
+				LogURL:         status.GetLogURL(),
+				Creator:        status.GetCreator().GetLogin(),
+				CreatedAt:      formatOptionalTimestamp(status.CreatedAt),
+			}), nil
+		}
+}
+
+// ListEnvironments creates a tool to list the environments of a repository.
+func ListEnvironments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environments",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENTS_DESCRIPTION", "List the deployment environments of a repository with their protection rules: required reviewers, wait timer and the branches allowed to deploy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENTS_USER_TITLE", "List environments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			envs, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list environments",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			environments := make([]MinimalEnvironment, 0, len(envs.Environments))
+			for _, env := range envs.Environments {
+				environments = append(environments, convertToMinimalEnvironment(env))
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count":  envs.GetTotalCount(),
+				"environments": environments,
+			}), nil
+		}
+}
+
+// GetEnvironment creates a tool to get an environment with its protection rules.
+func GetEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_environment",
+			mcp.WithDescription(t("TOOL_GET_ENVIRONMENT_DESCRIPTION", "Get a deployment environment of a repository with its protection rules: required reviewers, wait timer, the branches allowed to deploy and the custom protection rules of GitHub Apps.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENVIRONMENT_USER_TITLE", "Get environment"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get environment '%s'", environment),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			rules, resp, err := client.Repositories.GetAllDeploymentProtectionRules(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list custom protection rules of environment '%s'", environment),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := convertToMinimalEnvironment(env)
+			for _, rule := range rules.ProtectionRules {
+				result.CustomProtectionRules = append(result.CustomProtectionRules, CustomProtectionRule{
+					ID:      rule.GetID(),
+					App:     rule.GetApp().GetSlug(),
+					Enabled: rule.GetEnabled(),
+				})
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// CreateOrUpdateEnvironment creates a tool to create an environment or change its protection rules.
+func CreateOrUpdateEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_environment",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_DESCRIPTION", "Create a deployment environment, or change the protection rules of an existing one. Rules that aren't given are kept as they are.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_USER_TITLE", "Create or update environment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithNumber("wait_timer",
+				mcp.Description("Minutes to wait before deployments proceed, from 0 to 43200 (30 days)"),
+				mcp.Min(0),
+				mcp.Max(43200),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description("Users, by login, and teams, as org/team-slug, one of whom must approve deployments. At most 6, an empty array removes them"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithBoolean("prevent_self_review",
+				mcp.Description("Whether the user who triggered a deployment may not approve it"),
+			),
+			mcp.WithBoolean("can_admins_bypass",
+				mcp.Description("Whether repository administrators may deploy without the protection rules"),
+			),
+			mcp.WithString("deployment_branch_policy",
+				mcp.Description("Branches that may deploy: all, protected branches only, or branches matching custom patterns, which are managed separately"),
+				mcp.Enum("all", "protected", "custom"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			waitTimer, hasWaitTimer, err := OptionalParamOK[float64](request, "wait_timer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if waitTimer < 0 || waitTimer > 43200 {
+				return mcp.NewToolResultError("wait_timer must be between 0 and 43200 minutes"), nil
+			}
+			_, hasReviewers := request.GetArguments()["reviewers"]
+			reviewerNames, err := OptionalStringArrayParam(request, "reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(reviewerNames) > 6 {
+				return mcp.NewToolResultError("an environment can have at most 6 reviewers"), nil
+			}
+			preventSelfReview, hasPreventSelfReview, err := OptionalParamOK[bool](request, "prevent_self_review")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			canAdminsBypass, hasCanAdminsBypass, err := OptionalParamOK[bool](request, "can_admins_bypass")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branchPolicy, err := OptionalParam[string](request, "deployment_branch_policy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if branchPolicy != "" && branchPolicy != "all" && branchPolicy != "protected" && branchPolicy != "custom" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid deployment_branch_policy: %s. Supported policies are: all, protected, custom", branchPolicy)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API replaces all protection rules, so the current ones are kept unless they're given
+			opts := &github.CreateUpdateEnvironment{}
+			current, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				minimalEnv := convertToMinimalEnvironment(current)
+				opts.WaitTimer = github.Ptr(minimalEnv.WaitTimer)
+				opts.Reviewers = environmentReviewers(current)
+				opts.PreventSelfReview = github.Ptr(minimalEnv.PreventSelfReview)
+				opts.CanAdminsBypass = github.Ptr(minimalEnv.CanAdminsBypass)
+				opts.DeploymentBranchPolicy = current.DeploymentBranchPolicy
+			case resp == nil || resp.StatusCode != http.StatusNotFound:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get environment '%s'", environment),
+					resp,
+					err,
+				), nil
+			default:
+				_ = resp.Body.Close()
+			}
+
+			if hasWaitTimer {
+				opts.WaitTimer = github.Ptr(int(waitTimer))
+			}
+			if hasReviewers {
+				reviewers, resp, err := resolveEnvironmentReviewers(ctx, client, reviewerNames)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to resolve reviewers",
+						resp,
+						err,
+					), nil
+				}
+				opts.Reviewers = reviewers
+			}
+			if hasPreventSelfReview {
+				opts.PreventSelfReview = github.Ptr(preventSelfReview)
+			}
+			if hasCanAdminsBypass {
+				opts.CanAdminsBypass = github.Ptr(canAdminsBypass)
+			}
+			switch branchPolicy {
+			case "all":
+				opts.DeploymentBranchPolicy = nil
+			case "protected":
+				opts.DeploymentBranchPolicy = &github.BranchPolicy{ProtectedBranches: github.Ptr(true), CustomBranchPolicies: github.Ptr(false)}
+			case "custom":
+				opts.DeploymentBranchPolicy = &github.BranchPolicy{ProtectedBranches: github.Ptr(false), CustomBranchPolicies: github.Ptr(true)}
+			}
+
+			env, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, environment, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create or update environment '%s'", environment),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalEnvironment(env)), nil
+		}
+}
+
+// DeleteEnvironment creates a tool to delete an environment.
+func DeleteEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_environment",
+			mcp.WithDescription(t("TOOL_DELETE_ENVIRONMENT_DESCRIPTION", "Delete a deployment environment of a repository, with its protection rules, secrets and variables. Deployments to it are kept.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_ENVIRONMENT_USER_TITLE", "Delete environment"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteEnvironment(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete environment '%s'", environment),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Environment '%s' deleted", environment)), nil
+		}
+}
@@ -0,0 +1,637 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetDeployStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDeployStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_deploy_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	deployment := func(id int64, sha string) *github.Deployment {
+		return &github.Deployment{
+			ID:      github.Ptr(id),
+			SHA:     github.Ptr(sha),
+			Ref:     github.Ptr("main"),
+			Creator: &github.User{Login: github.Ptr("deployer")},
+		}
+	}
+	deployments := map[string][]*github.Deployment{
+		"production": {deployment(3, "ccc"), deployment(2, "bbb"), deployment(1, "aaa")},
+		"staging":    {deployment(4, "ddd")},
+	}
+	statuses := map[string][]*github.DeploymentStatus{
+		// The newest deployment failed, so production still runs bbb.
+		"/repos/owner/repo/deployments/3/statuses": {{State: github.Ptr("failure")}, {State: github.Ptr("in_progress")}},
+		"/repos/owner/repo/deployments/2/statuses": {{State: github.Ptr("success"), EnvironmentURL: github.Ptr("https://example.com")}},
+		"/repos/owner/repo/deployments/1/statuses": {{State: github.Ptr("inactive")}, {State: github.Ptr("success")}},
+		"/repos/owner/repo/deployments/4/statuses": {{State: github.Ptr("error")}},
+	}
+	commitPulls := map[string][]*github.PullRequest{
+		"/repos/owner/repo/commits/b1/pulls": {{Number: github.Ptr(10), Title: github.Ptr("Add cache"), User: &github.User{Login: github.Ptr("alice")}}},
+		"/repos/owner/repo/commits/b2/pulls": {{Number: github.Ptr(10), Title: github.Ptr("Add cache"), User: &github.User{Login: github.Ptr("alice")}}},
+		"/repos/owner/repo/commits/b3/pulls": {{Number: github.Ptr(12), Title: github.Ptr("Fix login"), User: &github.User{Login: github.Ptr("bob")}}},
+	}
+
+	newClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, &github.EnvResponse{
+				TotalCount: github.Ptr(2),
+				Environments: []*github.Environment{
+					{Name: github.Ptr("production")},
+					{Name: github.Ptr("staging")},
+				},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposDeploymentsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mockResponse(t, http.StatusOK, deployments[r.URL.Query().Get("environment")])(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mockResponse(t, http.StatusOK, statuses[r.URL.Path])(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCompareByOwnerByRepoByBasehead,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.True(t, strings.HasSuffix(r.URL.Path, "/aaa...bbb"))
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{
+						TotalCommits: github.Ptr(3),
+						Commits: []*github.RepositoryCommit{
+							{SHA: github.Ptr("b1")},
+							{SHA: github.Ptr("b2")},
+							{SHA: github.Ptr("b3")},
+						},
+					})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mockResponse(t, http.StatusOK, commitPulls[r.URL.Path])(w, r)
+				}),
+			),
+		))
+	}
+
+	production := DeployStatus{
+		Environment: "production",
+		Current: &DeployedVersion{
+			ID:             2,
+			SHA:            "bbb",
+			Ref:            "main",
+			Creator:        "deployer",
+			State:          "success",
+			EnvironmentURL: "https://example.com",
+		},
+		Previous: &DeployedVersion{
+			ID:      1,
+			SHA:     "aaa",
+			Ref:     "main",
+			Creator: "deployer",
+			State:   "inactive",
+		},
+		CommitsSincePrevious: 3,
+		PullRequests: []DeployedPullRequest{
+			{Number: 12, Title: "Fix login", Author: "bob"},
+			{Number: 10, Title: "Add cache", Author: "alice"},
+		},
+	}
+
+	t.Run("single environment", func(t *testing.T) {
+		_, handler := GetDeployStatus(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"environment": "production",
+		}))
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		var response DeployStatus
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, production, response)
+	})
+
+	t.Run("all environments", func(t *testing.T) {
+		_, handler := GetDeployStatus(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		var response struct {
+			Environments []DeployStatus `json:"environments"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, []DeployStatus{production, {Environment: "staging"}}, response.Environments)
+	})
+
+	t.Run("deployments not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposDeploymentsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+		))
+		_, handler := GetDeployStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"environment": "production",
+		}))
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "failed to get deploy status of environment 'production'")
+	})
+}
+
+func Test_ListDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposDeploymentsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"environment": "production",
+				"ref":         "main",
+				"page":        "1",
+				"per_page":    "30",
+			}).andThen(mockResponse(t, http.StatusOK, []*github.Deployment{
+				{
+					ID:          github.Ptr(int64(42)),
+					SHA:         github.Ptr("abc123"),
+					Ref:         github.Ptr("main"),
+					Task:        github.Ptr("deploy"),
+					Environment: github.Ptr("production"),
+					Creator:     &github.User{Login: github.Ptr("deployer")},
+				},
+			})),
+		),
+	))
+	_, handler := ListDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"environment": "production",
+		"ref":         "main",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var deployments []MinimalDeployment
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deployments))
+	require.Len(t, deployments, 1)
+	assert.Equal(t, int64(42), deployments[0].ID)
+	assert.Equal(t, "abc123", deployments[0].SHA)
+	assert.Equal(t, "deployer", deployments[0].Creator)
+}
+
+func Test_CreateDeployment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeployment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "required_contexts")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "deployment created without checking contexts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref":               "v1.2.0",
+						"environment":       "staging",
+						"auto_merge":        false,
+						"required_contexts": []any{},
+					}).andThen(mockResponse(t, http.StatusCreated, &github.Deployment{
+						ID:          github.Ptr(int64(7)),
+						Ref:         github.Ptr("v1.2.0"),
+						Environment: github.Ptr("staging"),
+					})),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"ref":               "v1.2.0",
+				"environment":       "staging",
+				"auto_merge":        false,
+				"required_contexts": []any{},
+			},
+			expectedText: `"id":7`,
+		},
+		{
+			name: "default branch merged instead",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "feature",
+					}).andThen(mockResponse(t, http.StatusAccepted, map[string]string{"message": "Auto-merged main into feature on deployment."})),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "feature",
+			},
+			expectedText: "instead of deploying it",
+		},
+		{
+			name: "failing contexts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict: Commit status checks failed for main."}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create deployment of main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeployment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_CreateDeploymentStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeploymentStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "deployment_id", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "status created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+					expectRequestBody(t, map[string]any{
+						"state":           "success",
+						"environment_url": "https://staging.example.com",
+					}).andThen(mockResponse(t, http.StatusCreated, &github.DeploymentStatus{
+						ID:             github.Ptr(int64(99)),
+						State:          github.Ptr("success"),
+						EnvironmentURL: github.Ptr("https://staging.example.com"),
+					})),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"deployment_id":   float64(7),
+				"state":           "success",
+				"environment_url": "https://staging.example.com",
+			},
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"deployment_id": float64(7),
+				"state":         "done",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid state: done",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeploymentStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var status MinimalDeploymentStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, int64(99), status.ID)
+			assert.Equal(t, "success", status.State)
+		})
+	}
+}
+
+// protectedEnvironment is a production environment requiring a review by alice or the ops team after 10 minutes.
+func protectedEnvironment() *github.Environment {
+	return &github.Environment{
+		Name:            github.Ptr("production"),
+		CanAdminsBypass: github.Ptr(false),
+		DeploymentBranchPolicy: &github.BranchPolicy{
+			ProtectedBranches:    github.Ptr(true),
+			CustomBranchPolicies: github.Ptr(false),
+		},
+		ProtectionRules: []*github.ProtectionRule{
+			{Type: github.Ptr("wait_timer"), WaitTimer: github.Ptr(10)},
+			{
+				Type:              github.Ptr("required_reviewers"),
+				PreventSelfReview: github.Ptr(true),
+				Reviewers: []*github.RequiredReviewer{
+					{Type: github.Ptr("User"), Reviewer: &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("alice")}},
+					{Type: github.Ptr("Team"), Reviewer: &github.Team{ID: github.Ptr(int64(2)), Slug: github.Ptr("ops")}},
+				},
+			},
+			{Type: github.Ptr("branch_policy")},
+		},
+	}
+}
+
+func Test_ListEnvironments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, &github.EnvResponse{
+			TotalCount:   github.Ptr(2),
+			Environments: []*github.Environment{protectedEnvironment(), {Name: github.Ptr("staging")}},
+		}),
+	))
+	_, handler := ListEnvironments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		TotalCount   int                  `json:"total_count"`
+		Environments []MinimalEnvironment `json:"environments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 2, response.TotalCount)
+	require.Len(t, response.Environments, 2)
+	assert.Equal(t, 10, response.Environments[0].WaitTimer)
+	assert.Equal(t, "all", response.Environments[1].DeploymentBranchPolicy)
+}
+
+func Test_GetEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, protectedEnvironment()),
+		mock.WithRequestMatch(mock.GetReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentName, &github.ListDeploymentProtectionRuleResponse{
+			TotalCount: github.Ptr(1),
+			ProtectionRules: []*github.CustomDeploymentProtectionRule{
+				{ID: github.Ptr(int64(5)), Enabled: github.Ptr(true), App: &github.CustomDeploymentProtectionRuleApp{Slug: github.Ptr("security-gate")}},
+			},
+		}),
+	))
+	_, handler := GetEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"environment": "production",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var env MinimalEnvironment
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &env))
+	assert.Equal(t, "production", env.Name)
+	assert.Equal(t, 10, env.WaitTimer)
+	assert.True(t, env.PreventSelfReview)
+	assert.Equal(t, []EnvironmentReviewer{{Type: "User", Name: "alice"}, {Type: "Team", Name: "ops"}}, env.Reviewers)
+	assert.Equal(t, "protected", env.DeploymentBranchPolicy)
+	assert.Equal(t, []CustomProtectionRule{{ID: 5, App: "security-gate", Enabled: true}}, env.CustomProtectionRules)
+}
+
+func Test_CreateOrUpdateEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "reviewers")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "wait timer changed, other rules kept",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, protectedEnvironment()),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer": float64(30),
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "Team", "id": float64(2)},
+						},
+						"prevent_self_review": true,
+						"can_admins_bypass":   false,
+						"deployment_branch_policy": map[string]any{
+							"protected_branches":     true,
+							"custom_branch_policies": false,
+						},
+					}).andThen(mockResponse(t, http.StatusOK, protectedEnvironment())),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"wait_timer":  float64(30),
+			},
+		},
+		{
+			name: "new environment with reviewers resolved",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+				mock.WithRequestMatch(mock.GetUsersByUsername, &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("alice")}),
+				mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, &github.Team{ID: github.Ptr(int64(2)), Slug: github.Ptr("ops")}),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer": float64(0),
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "Team", "id": float64(2)},
+						},
+						"can_admins_bypass":        true,
+						"deployment_branch_policy": nil,
+					}).andThen(mockResponse(t, http.StatusOK, &github.Environment{Name: github.Ptr("production")})),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"reviewers":   []any{"alice", "acme/ops"},
+			},
+		},
+		{
+			name:         "too many reviewers",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"reviewers":   []any{"a", "b", "c", "d", "e", "f", "g"},
+			},
+			expectError:    true,
+			expectedErrMsg: "at most 6 reviewers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Contains(t, getTextResult(t, result).Text, `"name":"production"`)
+		})
+	}
+}
+
+func Test_DeleteEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_environment", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposEnvironmentsByOwnerByRepoByEnvironmentName,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	))
+	_, handler := DeleteEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"environment": "review-42",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Environment 'review-42' deleted", getTextResult(t, result).Text)
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -18,6 +17,7 @@ const DefaultGraphQLPageSize = 30
 // Common interface for all discussion query types
 type DiscussionQueryResult interface {
 	GetDiscussionFragment() DiscussionFragment
+	GetRateLimit() RateLimitFragment
 }
 
 // Implement the interface for all query types
@@ -25,18 +25,34 @@ func (q *BasicNoOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *BasicNoOrder) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *BasicWithOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *BasicWithOrder) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *WithCategoryAndOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *WithCategoryAndOrder) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *WithCategoryNoOrder) GetDiscussionFragment() DiscussionFragment {
 	return q.Repository.Discussions
 }
 
+func (q *WithCategoryNoOrder) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 type DiscussionFragment struct {
 	Nodes      []NodeFragment
 	PageInfo   PageInfoFragment
@@ -64,28 +80,52 @@ type PageInfoFragment struct {
 	EndCursor       githubv4.String
 }
 
+// RateLimitFragment is the GraphQL rate limit status, queried along with paginated connections so that
+// callers walking many pages can see what each page costs and how much budget is left.
+type RateLimitFragment struct {
+	Cost      githubv4.Int
+	Remaining githubv4.Int
+	ResetAt   githubv4.DateTime
+}
+
+// rateLimitResponse converts a RateLimitFragment for use in tool responses.
+func rateLimitResponse(rateLimit RateLimitFragment) map[string]interface{} {
+	response := map[string]interface{}{
+		"cost":      int(rateLimit.Cost),
+		"remaining": int(rateLimit.Remaining),
+	}
+	if !rateLimit.ResetAt.IsZero() {
+		response["resetAt"] = rateLimit.ResetAt.Format("2006-01-02T15:04:05Z")
+	}
+	return response
+}
+
 type BasicNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after)"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 type BasicWithOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, orderBy: { field: $orderByField, direction: $orderByDirection })"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 type WithCategoryAndOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, categoryId: $categoryId, orderBy: { field: $orderByField, direction: $orderByDirection })"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 type WithCategoryNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after, categoryId: $categoryId)"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 func fragmentToDiscussion(fragment NodeFragment) *github.Discussion {
@@ -119,7 +159,7 @@ func getQueryType(useOrdering bool, categoryID *githubv4.ID) any {
 
 func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_discussions",
-			mcp.WithDescription(t("TOOL_LIST_DISCUSSIONS_DESCRIPTION", "List discussions for a repository or organisation.")),
+			mcp.WithDescription(t("TOOL_LIST_DISCUSSIONS_DESCRIPTION", "List discussions for a repository or organisation. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter, and check 'rateLimit' for the cost of each page and the remaining GraphQL budget. Pages too costly to query at once are queried in several smaller queries.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_DISCUSSIONS_USER_TITLE", "List discussions"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -218,22 +258,39 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				vars["categoryId"] = *categoryID
 			}
 
-			discussionQuery := getQueryType(useOrdering, categoryID)
-			if err := client.Query(ctx, discussionQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Extract and convert all discussion nodes using the common interface
+			// Extract and convert all discussion nodes using the common interface. Discussions that are too
+			// costly to query at once are queried in smaller pages, whose page info is combined.
 			var discussions []*github.Discussion
 			var pageInfo PageInfoFragment
 			var totalCount githubv4.Int
-			if queryResult, ok := discussionQuery.(DiscussionQueryResult); ok {
-				fragment := queryResult.GetDiscussionFragment()
-				for _, node := range fragment.Nodes {
-					discussions = append(discussions, fragmentToDiscussion(node))
-				}
-				pageInfo = fragment.PageInfo
-				totalCount = fragment.TotalCount
+			cost, err := queryGraphQLPages(ctx, client, vars, *paginationParams.First,
+				func() any { return getQueryType(useOrdering, categoryID) },
+				func(discussionQuery any) graphQLPage {
+					queryResult, ok := discussionQuery.(DiscussionQueryResult)
+					if !ok {
+						return graphQLPage{}
+					}
+					fragment := queryResult.GetDiscussionFragment()
+					if discussions == nil {
+						pageInfo.HasPreviousPage = fragment.PageInfo.HasPreviousPage
+						pageInfo.StartCursor = fragment.PageInfo.StartCursor
+					}
+					for _, node := range fragment.Nodes {
+						discussions = append(discussions, fragmentToDiscussion(node))
+					}
+					pageInfo.HasNextPage = fragment.PageInfo.HasNextPage
+					pageInfo.EndCursor = fragment.PageInfo.EndCursor
+					totalCount = fragment.TotalCount
+					return graphQLPage{
+						nodes:       len(fragment.Nodes),
+						hasNextPage: fragment.PageInfo.HasNextPage,
+						endCursor:   fragment.PageInfo.EndCursor,
+						rateLimit:   queryResult.GetRateLimit(),
+					}
+				},
+			)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Create response with pagination info
@@ -246,13 +303,10 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 					"endCursor":       string(pageInfo.EndCursor),
 				},
 				"totalCount": totalCount,
+				"rateLimit":  cost.response(),
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal discussions: %w", err)
-			}
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -324,12 +378,7 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 					Name: github.Ptr(string(d.Category.Name)),
 				},
 			}
-			out, err := json.Marshal(discussion)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal discussion: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, discussion), nil
 		}
 }
 
@@ -432,12 +481,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				"totalCount": q.Repository.Discussion.Comments.TotalCount,
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal comments: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -522,10 +566,6 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				"totalCount": q.Repository.DiscussionCategories.TotalCount,
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
-			}
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
@@ -67,7 +66,7 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 		),
-		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
 
 			payload := []map[string]string{}
@@ -84,12 +83,7 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				}
 			}
 
-			r, err := json.Marshal(payload)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal features: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, payload), nil
 		}
 }
 
@@ -106,7 +100,7 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				ToolsetEnum(toolsetGroup),
 			),
 		),
-		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
 			toolsetName, err := RequiredParam[string](request, "toolset")
 			if err != nil {
@@ -128,11 +122,6 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				payload = append(payload, tool)
 			}
 
-			r, err := json.Marshal(payload)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal features: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, payload), nil
 		}
 }
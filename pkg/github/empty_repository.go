@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// isEmptyRepository reports whether a failed call was answered with the 409 that the Git database API
+// returns for repositories without any commits.
+func isEmptyRepository(resp *github.Response, err error) bool {
+	if resp == nil || resp.StatusCode != http.StatusConflict {
+		return false
+	}
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) {
+		return strings.Contains(strings.ToLower(errResp.Message), "empty")
+	}
+	return true
+}
+
+// emptyRepositoryError explains that a repository has no commits yet and how to get past that.
+func emptyRepositoryError(owner, repo string, canInitialize bool) *mcp.CallToolResult {
+	message := fmt.Sprintf("repository '%s/%s' is empty: it has no commits and no branches yet.", owner, repo)
+	if canInitialize {
+		message += " Call again with initialize set to true to create an initial commit on the default branch first,"
+	} else {
+		message += " Create an initial commit first, for example with push_files and initialize set to true,"
+	}
+	message += " or create repositories with autoInit set to true."
+	return mcp.NewToolResultError(message)
+}
+
+// initializeEmptyRepository creates an initial commit holding a README on the default branch of an empty
+// repository and returns the name of that branch. The Git database API rejects trees and commits in empty
+// repositories, while the contents API does not.
+func initializeEmptyRepository(ctx context.Context, client *github.Client, owner, repo string) (string, *github.Response, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", resp, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	defaultBranch := repository.GetDefaultBranch()
+	if defaultBranch == "" {
+		defaultBranch = "main"
+	}
+
+	_, resp, err = client.Repositories.CreateFile(ctx, owner, repo, "README.md", &github.RepositoryContentFileOptions{
+		Message: github.Ptr("Initial commit"),
+		Content: []byte(fmt.Sprintf("# %s\n", repo)),
+		Branch:  github.Ptr(defaultBranch),
+	})
+	if err != nil {
+		return "", resp, fmt.Errorf("failed to create initial commit: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return defaultBranch, nil, nil
+}
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultFanOutConcurrency is the number of concurrent API calls made by tools that
+// aggregate data across many repositories.
+const DefaultFanOutConcurrency = 5
+
+// fanOut calls fn for every item using at most concurrency goroutines, waits for all calls to return, and returns
+// the error of every item by index. A panic in a call becomes the error of its item rather than killing the server,
+// as it happens outside of the goroutine of the tool handler. Callers are expected to write results into a
+// pre-sized slice using the provided index, which keeps the output order stable regardless of completion order.
+// Items not yet started when ctx is cancelled are skipped.
+func fanOut[T any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, i int, item T) error) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("internal error: %v. This is a bug in the server, please report it", r)
+				}
+			}()
+			errs[i] = fn(ctx, i, item)
+		}(i, item)
+	}
+	wg.Wait()
+	return errs
+}
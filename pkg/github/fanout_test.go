@@ -0,0 +1,32 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fanOut(t *testing.T) {
+	items := []string{"api", "web", "docs"}
+	results := make([]string, len(items))
+	errs := fanOut(context.Background(), items, 2, func(_ context.Context, i int, item string) error {
+		switch item {
+		case "web":
+			var repo *struct{ name string }
+			results[i] = repo.name // nil pointer dereference
+		case "docs":
+			return errors.New("not found")
+		}
+		results[i] = item
+		return nil
+	})
+
+	require.Len(t, errs, len(items))
+	assert.NoError(t, errs[0])
+	assert.Equal(t, "api", results[0])
+	assert.ErrorContains(t, errs[1], "internal error: runtime error: invalid memory address or nil pointer dereference")
+	assert.EqualError(t, errs[2], "not found")
+}
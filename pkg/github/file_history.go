@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultFileHistoryMaxCommits is the default number of commits listed by get_file_history.
+	DefaultFileHistoryMaxCommits = 100
+	// maxFileHistoryCommits bounds the commits listed by get_file_history.
+	maxFileHistoryCommits = 1000
+)
+
+// FileHistoryCommit is a commit touching a file or directory, with the path it had at that commit.
+type FileHistoryCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+	Path    string `json:"path"`
+	URL     string `json:"url"`
+}
+
+// FileHistoryRename is a commit renaming a file or directory.
+type FileHistoryRename struct {
+	SHA  string `json:"sha"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// renamedFrom returns the path that path had before the commit with the given files, if the commit renamed it. A
+// directory is renamed when a file under it was renamed from the same relative path under another directory.
+func renamedFrom(files []*github.CommitFile, path string) (string, bool) {
+	for _, file := range files {
+		if file.GetStatus() != "renamed" || file.GetPreviousFilename() == "" {
+			continue
+		}
+		if file.GetFilename() == path {
+			return file.GetPreviousFilename(), true
+		}
+		rel, ok := strings.CutPrefix(file.GetFilename(), path+"/")
+		if !ok {
+			continue
+		}
+		if previous, ok := strings.CutSuffix(file.GetPreviousFilename(), "/"+rel); ok && previous != path {
+			return previous, true
+		}
+	}
+	return "", false
+}
+
+// GetFileHistory creates a tool to list the commits that touched a file or directory, following its renames.
+func GetFileHistory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_history",
+			mcp.WithDescription(t("TOOL_GET_FILE_HISTORY_DESCRIPTION", "List the commits that touched a file or directory of a GitHub repository, newest first, following the renames of the path back to its earlier names. Use it to find when and by whom a file changed, e.g. before reading the diff of a commit with get_commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_HISTORY_USER_TITLE", "Get file history"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file or directory, e.g. 'pkg/github/server.go'"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Commit SHA, branch or tag name to read history from. Defaults to the default branch of the repository."),
+			),
+			mcp.WithBoolean("follow_renames",
+				mcp.Description("Continue the history under the previous path when the path was renamed (default true)"),
+			),
+			mcp.WithNumber("max_commits",
+				mcp.Description(fmt.Sprintf("Maximum number of commits to list (default %d, max %d)", DefaultFileHistoryMaxCommits, maxFileHistoryCommits)),
+				mcp.Min(1),
+				mcp.Max(maxFileHistoryCommits),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path = strings.Trim(path, "/")
+			if path == "" {
+				return mcp.NewToolResultError("path must not be the root of the repository"), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			followRenames, err := OptionalBoolParamWithDefault(request, "follow_renames", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "max_commits", DefaultFileHistoryMaxCommits)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxCommits < 1 || maxCommits > maxFileHistoryCommits {
+				return mcp.NewToolResultError(fmt.Sprintf("max_commits must be between 1 and %d", maxFileHistoryCommits)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commits := []FileHistoryCommit{}
+			renames := []FileHistoryRename{}
+			truncated := false
+			currentPath, ref := path, sha
+			for {
+				// The commits list API stops at the commit that created a path, so the history is listed one name
+				// at a time, continuing from the parent of the commit that renamed it.
+				opts := &github.CommitsListOptions{
+					SHA:         ref,
+					Path:        currentPath,
+					ListOptions: github.ListOptions{PerPage: 100},
+				}
+				var oldest *github.RepositoryCommit
+				for {
+					page, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to list commits for path '%s'", currentPath),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+
+					for _, commit := range page {
+						if len(commits) == maxCommits {
+							truncated = true
+							break
+						}
+						message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+						commits = append(commits, FileHistoryCommit{
+							SHA:     commit.GetSHA(),
+							Message: message,
+							Author:  commitAuthorName(commit),
+							Date:    formatOptionalTimestamp(commit.GetCommit().GetAuthor().Date),
+							Path:    currentPath,
+							URL:     commit.GetHTMLURL(),
+						})
+						oldest = commit
+					}
+					if truncated || resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+				if truncated || oldest == nil || !followRenames || len(oldest.Parents) == 0 {
+					break
+				}
+
+				// The commits in the list don't have their files, so the oldest one is fetched to see if it
+				// renamed the path
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, oldest.GetSHA(), &github.ListOptions{PerPage: 300})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get commit '%s'", oldest.GetSHA()),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				previous, ok := renamedFrom(commit.Files, currentPath)
+				if !ok {
+					break
+				}
+				renames = append(renames, FileHistoryRename{SHA: oldest.GetSHA(), From: previous, To: currentPath})
+				currentPath, ref = previous, oldest.Parents[0].GetSHA()
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"path":      path,
+				"commits":   commits,
+				"renames":   renames,
+				"truncated": truncated,
+			}), nil
+		}
+}
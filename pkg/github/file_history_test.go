@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_renamedFrom(t *testing.T) {
+	renamed := func(from, to string) *github.CommitFile {
+		return &github.CommitFile{Filename: github.Ptr(to), PreviousFilename: github.Ptr(from), Status: github.Ptr("renamed")}
+	}
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("README.md"), Status: github.Ptr("modified")},
+		renamed("lib/old.go", "lib/new.go"),
+		renamed("internal/api/client.go", "pkg/api/client.go"),
+	}
+
+	previous, ok := renamedFrom(files, "lib/new.go")
+	assert.True(t, ok)
+	assert.Equal(t, "lib/old.go", previous)
+
+	previous, ok = renamedFrom(files, "pkg/api")
+	assert.True(t, ok)
+	assert.Equal(t, "internal/api", previous)
+
+	_, ok = renamedFrom(files, "README.md")
+	assert.False(t, ok)
+
+	_, ok = renamedFrom(files, "lib")
+	assert.False(t, ok)
+}
+
+func Test_GetFileHistory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetFileHistory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_file_history", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "follow_renames")
+	assert.Contains(t, tool.InputSchema.Properties, "max_commits")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	commit := func(sha, login, message string, day int, parent string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:     github.Ptr(sha),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/" + sha),
+			Author:  &github.User{Login: github.Ptr(login)},
+			Commit: &github.Commit{
+				Message: github.Ptr(message),
+				Author: &github.CommitAuthor{
+					Name: github.Ptr(login),
+					Date: &github.Timestamp{Time: time.Date(2024, 3, day, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+			Parents: []*github.Commit{{SHA: github.Ptr(parent)}},
+		}
+	}
+
+	// cmd/server.go was renamed from server.go in c2
+	history := map[string][]*github.RepositoryCommit{
+		"cmd/server.go": {
+			commit("c3", "alice", "Handle signals\n\nDetails", 5, "c2"),
+			commit("c2", "bob", "Move server to cmd", 4, "c1"),
+		},
+		"server.go": {
+			commit("c1", "carol", "Add server", 3, "c0"),
+		},
+	}
+	listCommits := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("path") == "server.go" {
+			assert.Equal(t, "c1", query.Get("sha"))
+		}
+		mockResponse(t, http.StatusOK, history[query.Get("path")])(w, r)
+	})
+	getCommit := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		files := map[string][]*github.CommitFile{
+			"/repos/owner/repo/commits/c2": {{
+				Filename:         github.Ptr("cmd/server.go"),
+				PreviousFilename: github.Ptr("server.go"),
+				Status:           github.Ptr("renamed"),
+			}},
+			"/repos/owner/repo/commits/c1": {{
+				Filename: github.Ptr("server.go"),
+				Status:   github.Ptr("added"),
+			}},
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryCommit{Files: files[r.URL.Path]})(w, r)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]any
+	}{
+		{
+			name: "follows renames",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepoByRef, getCommit),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "/cmd/server.go",
+			},
+			expectedResult: map[string]any{
+				"path": "cmd/server.go",
+				"commits": []any{
+					map[string]any{"sha": "c3", "message": "Handle signals", "author": "alice", "date": "2024-03-05T10:00:00Z", "path": "cmd/server.go", "url": "https://github.com/owner/repo/commit/c3"},
+					map[string]any{"sha": "c2", "message": "Move server to cmd", "author": "bob", "date": "2024-03-04T10:00:00Z", "path": "cmd/server.go", "url": "https://github.com/owner/repo/commit/c2"},
+					map[string]any{"sha": "c1", "message": "Add server", "author": "carol", "date": "2024-03-03T10:00:00Z", "path": "server.go", "url": "https://github.com/owner/repo/commit/c1"},
+				},
+				"renames": []any{
+					map[string]any{"sha": "c2", "from": "server.go", "to": "cmd/server.go"},
+				},
+				"truncated": false,
+			},
+		},
+		{
+			name: "without following renames",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"path":           "cmd/server.go",
+				"follow_renames": false,
+			},
+			expectedResult: map[string]any{
+				"path": "cmd/server.go",
+				"commits": []any{
+					map[string]any{"sha": "c3", "message": "Handle signals", "author": "alice", "date": "2024-03-05T10:00:00Z", "path": "cmd/server.go", "url": "https://github.com/owner/repo/commit/c3"},
+					map[string]any{"sha": "c2", "message": "Move server to cmd", "author": "bob", "date": "2024-03-04T10:00:00Z", "path": "cmd/server.go", "url": "https://github.com/owner/repo/commit/c2"},
+				},
+				"renames":   []any{},
+				"truncated": false,
+			},
+		},
+		{
+			name: "stops at max_commits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "cmd/server.go",
+				"max_commits": float64(1),
+			},
+			expectedResult: map[string]any{
+				"path": "cmd/server.go",
+				"commits": []any{
+					map[string]any{"sha": "c3", "message": "Handle signals", "author": "alice", "date": "2024-03-05T10:00:00Z", "path": "cmd/server.go", "url": "https://github.com/owner/repo/commit/c3"},
+				},
+				"renames":   []any{},
+				"truncated": true,
+			},
+		},
+		{
+			name:         "root path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "/",
+			},
+			expectError:    true,
+			expectedErrMsg: "path must not be the root of the repository",
+		},
+		{
+			name: "listing commits fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "cmd/server.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits for path 'cmd/server.go'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetFileHistory(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
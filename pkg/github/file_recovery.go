@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RecoverDeletedFile creates a tool to get the content of a deleted file from the last commit that contained it.
+func RecoverDeletedFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("recover_deleted_file",
+			mcp.WithDescription(t("TOOL_RECOVER_DELETED_FILE_DESCRIPTION", "Get the content of a file that was deleted from a GitHub repository. Finds the commit that deleted the path and returns the file as it was in that commit's parent, with the commit that deleted it. Use create_or_update_file with the returned content to restore the file.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RECOVER_DELETED_FILE_USER_TITLE", "Recover deleted file"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the deleted file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA the file was deleted from. Defaults to the default branch of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path = strings.Trim(path, "/")
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+			if err == nil {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("'%s' exists, use get_file_contents to read it", path)), nil
+			}
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to check whether '%s' exists", path),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// The most recent commit touching a path that no longer exists is the one that deleted it.
+			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+				SHA:         ref,
+				Path:        path,
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list commits for '%s'", path),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if len(commits) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no commit in the history touched '%s'", path)), nil
+			}
+			deletion := commits[0]
+			if len(deletion.Parents) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("commit %s that deleted '%s' has no parent", deletion.GetSHA(), path)), nil
+			}
+			parentSHA := deletion.Parents[0].GetSHA()
+
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: parentSHA})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get '%s' at commit %s", path, parentSHA),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if fileContent == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("'%s' was a directory at commit %s", path, parentSHA)), nil
+			}
+
+			// The blob is fetched rather than the content, which the contents API omits for large files.
+			content, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, fileContent.GetSHA())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get blob %s", fileContent.GetSHA()),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			resourceURI, err := url.JoinPath("repo://", owner, repo, "sha", parentSHA, "contents", path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create resource URI: %w", err)
+			}
+			message, _, _ := strings.Cut(deletion.GetCommit().GetMessage(), "\n")
+			summary := fmt.Sprintf("recovered '%s' (blob SHA: %s) from commit %s; it was deleted in commit %s by %s on %s: %s",
+				path,
+				fileContent.GetSHA(),
+				parentSHA,
+				deletion.GetSHA(),
+				commitAuthorName(deletion),
+				formatOptionalTimestamp(deletion.GetCommit().GetAuthor().Date),
+				message,
+			)
+
+			contentType := detectContentType(path, "", content)
+			if isTextContentType(contentType) {
+				return mcp.NewToolResultResource(summary, mcp.TextResourceContents{
+					URI:      resourceURI,
+					Text:     string(content),
+					MIMEType: contentType,
+				}), nil
+			}
+			return mcp.NewToolResultResource(summary, mcp.BlobResourceContents{
+				URI:      resourceURI,
+				Blob:     base64.StdEncoding.EncodeToString(content),
+				MIMEType: contentType,
+			}), nil
+		}
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecoverDeletedFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RecoverDeletedFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "recover_deleted_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	deletion := []*github.RepositoryCommit{
+		{
+			SHA: github.Ptr("del123"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Remove legacy config\n\nNo longer used"),
+				Author: &github.CommitAuthor{
+					Name: github.Ptr("Alice"),
+					Date: &github.Timestamp{Time: time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC)},
+				},
+			},
+			Author:  &github.User{Login: github.Ptr("alice")},
+			Parents: []*github.Commit{{SHA: github.Ptr("parent456")}},
+		},
+	}
+	// The path only exists in the parent of the deleting commit.
+	contentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "parent456" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type: github.Ptr("file"),
+			SHA:  github.Ptr("blob789"),
+		})(w, r)
+	})
+	summary := "recovered 'config/legacy.yml' (blob SHA: blob789) from commit parent456; it was deleted in commit del123 by alice on 2024-04-02T09:00:00Z: Remove legacy config"
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedSummary  string
+		expectedResource mcp.ResourceContents
+	}{
+		{
+			name: "recovers text file from parent of deleting commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"path":     "config/legacy.yml",
+						"sha":      "main",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, deletion),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/git/blobs/blob789", r.URL.Path)
+						_, _ = w.Write([]byte("enabled: true\n"))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "config/legacy.yml",
+				"ref":   "main",
+			},
+			expectedSummary: summary,
+			expectedResource: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/parent456/contents/config/legacy.yml",
+				MIMEType: "text/plain; charset=utf-8",
+				Text:     "enabled: true\n",
+			},
+		},
+		{
+			name: "recovers binary file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepo, deletion),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n\x00\x00"))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "assets/logo.png",
+			},
+			expectedSummary: "recovered 'assets/logo.png' (blob SHA: blob789) from commit parent456; it was deleted in commit del123 by alice on 2024-04-02T09:00:00Z: Remove legacy config",
+			expectedResource: mcp.BlobResourceContents{
+				URI:      "repo://owner/repo/sha/parent456/contents/assets/logo.png",
+				MIMEType: "image/png",
+				Blob:     base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n\x00\x00")),
+			},
+		},
+		{
+			name: "file still exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("README.md")},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+			},
+			expectError:    true,
+			expectedErrMsg: "'README.md' exists, use get_file_contents to read it",
+		},
+		{
+			name: "path never existed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepo, []*github.RepositoryCommit{}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "missing.txt",
+			},
+			expectError:    true,
+			expectedErrMsg: "no commit in the history touched 'missing.txt'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RecoverDeletedFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+			assert.Equal(t, tc.expectedSummary, result.Content[0].(mcp.TextContent).Text)
+			resource, ok := result.Content[1].(mcp.EmbeddedResource)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedResource, resource.Resource)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// notebookText is notebook text, which nbformat allows to be a string or a list of lines.
+type notebookText string
+
+func (n *notebookText) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*n = notebookText(strings.Join(lines, ""))
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*n = notebookText(text)
+	return nil
+}
+
+type notebookOutput struct {
+	OutputType string                     `json:"output_type"`
+	Text       notebookText               `json:"text"`
+	Data       map[string]json.RawMessage `json:"data"`
+	EName      string                     `json:"ename"`
+	EValue     string                     `json:"evalue"`
+}
+
+type notebook struct {
+	Cells []struct {
+		CellType       string           `json:"cell_type"`
+		Source         notebookText     `json:"source"`
+		ExecutionCount *int             `json:"execution_count"`
+		Outputs        []notebookOutput `json:"outputs"`
+	} `json:"cells"`
+	Metadata struct {
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+// renderNotebook renders a Jupyter notebook as Markdown, one section per cell with the cell's source and
+// textual outputs. Binary outputs such as images are replaced by a note. It returns the number of cells.
+func renderNotebook(content []byte) (string, int, error) {
+	var nb notebook
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return "", 0, fmt.Errorf("failed to parse notebook: %w", err)
+	}
+	language := nb.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = nb.Metadata.KernelSpec.Language
+	}
+
+	var b strings.Builder
+	for i, cell := range nb.Cells {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		source := strings.TrimRight(string(cell.Source), "\n")
+		switch cell.CellType {
+		case "code":
+			if cell.ExecutionCount != nil {
+				fmt.Fprintf(&b, "## Cell %d (code, execution %d)\n\n", i+1, *cell.ExecutionCount)
+			} else {
+				fmt.Fprintf(&b, "## Cell %d (code)\n\n", i+1)
+			}
+			fmt.Fprintf(&b, "```%s\n%s\n```\n", language, source)
+			for _, output := range cell.Outputs {
+				b.WriteString(renderNotebookOutput(output))
+			}
+		default:
+			fmt.Fprintf(&b, "## Cell %d (%s)\n\n%s\n", i+1, cell.CellType, source)
+		}
+	}
+	return b.String(), len(nb.Cells), nil
+}
+
+func renderNotebookOutput(output notebookOutput) string {
+	switch output.OutputType {
+	case "stream":
+		return fmt.Sprintf("\nOutput:\n```\n%s\n```\n", strings.TrimRight(string(output.Text), "\n"))
+	case "error":
+		return fmt.Sprintf("\nError: %s: %s\n", output.EName, output.EValue)
+	}
+
+	var b strings.Builder
+	var text notebookText
+	hasText := false
+	if raw, ok := output.Data["text/plain"]; ok && json.Unmarshal(raw, &text) == nil {
+		hasText = true
+		fmt.Fprintf(&b, "\nOutput:\n```\n%s\n```\n", strings.TrimRight(string(text), "\n"))
+	}
+	mimeTypes := make([]string, 0, len(output.Data))
+	for mimeType := range output.Data {
+		if mimeType != "text/plain" && (!hasText || strings.HasPrefix(mimeType, "image/")) {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+	sort.Strings(mimeTypes)
+	for _, mimeType := range mimeTypes {
+		fmt.Fprintf(&b, "\n[%s output omitted]\n", mimeType)
+	}
+	return b.String()
+}
+
+// splitFrontMatter splits the YAML front matter delimited by '---' lines from the start of a Markdown document.
+// ok is false when the document has no front matter.
+func splitFrontMatter(document string) (frontMatter string, body string, ok bool) {
+	normalized := strings.ReplaceAll(document, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return "", document, false
+	}
+	rest := normalized[len("---\n"):]
+	for _, delimiter := range []string{"---", "..."} {
+		if strings.HasPrefix(rest, delimiter+"\n") {
+			return "", strings.TrimPrefix(rest[len(delimiter)+1:], "\n"), true
+		}
+		if end := strings.Index(rest, "\n"+delimiter+"\n"); end >= 0 {
+			return rest[:end+1], strings.TrimPrefix(rest[end+len(delimiter)+2:], "\n"), true
+		}
+		if strings.HasSuffix(rest, "\n"+delimiter) {
+			return rest[:len(rest)-len(delimiter)], "", true
+		}
+	}
+	return "", document, false
+}
+
+// lineRange is a range of lines of a file, counted from 1. An End of 0 is the end of the file.
+type lineRange struct {
+	Start   int
+	End     int
+	Context int
+}
+
+// sliceLines returns the lines of a text in a range, widened by its context lines, with the first and last line
+// returned and the number of lines of the text.
+func sliceLines(text string, r lineRange) (slice string, first, last, total int, err error) {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	total = len(lines)
+
+	start := max(r.Start, 1)
+	if start > total {
+		return "", 0, 0, total, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", start, total)
+	}
+	end := total
+	if r.End > 0 {
+		end = min(r.End, total)
+	}
+	first = max(start-r.Context, 1)
+	last = min(end+r.Context, total)
+	return strings.Join(lines[first-1:last], ""), first, last, total, nil
+}
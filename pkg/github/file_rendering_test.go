@@ -0,0 +1,166 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenderNotebook(t *testing.T) {
+	content := []byte(`{
+		"metadata": {"language_info": {"name": "python"}},
+		"nbformat": 4,
+		"cells": [
+			{"cell_type": "markdown", "source": ["# Analysis\n", "Load the data."]},
+			{"cell_type": "code", "execution_count": 1, "source": "import pandas as pd\ndf = pd.read_csv('data.csv')\ndf.head()", "outputs": [
+				{"output_type": "stream", "name": "stdout", "text": ["loaded 3 rows\n"]},
+				{"output_type": "execute_result", "data": {"text/plain": ["   a  b\n", "0  1  2"], "text/html": "<table></table>"}}
+			]},
+			{"cell_type": "code", "execution_count": 2, "source": "df.plot()", "outputs": [
+				{"output_type": "display_data", "data": {"image/png": "iVBORw0KGgo=", "text/plain": "<Figure>"}},
+				{"output_type": "error", "ename": "KeyError", "evalue": "'c'", "traceback": ["\u001b[0;31mKeyError\u001b[0m"]}
+			]},
+			{"cell_type": "code", "execution_count": null, "source": "", "outputs": []}
+		]
+	}`)
+
+	rendered, cells, err := renderNotebook(content)
+	require.NoError(t, err)
+	assert.Equal(t, 4, cells)
+	assert.Equal(t, "## Cell 1 (markdown)\n\n# Analysis\nLoad the data.\n"+
+		"\n## Cell 2 (code, execution 1)\n\n```python\nimport pandas as pd\ndf = pd.read_csv('data.csv')\ndf.head()\n```\n"+
+		"\nOutput:\n```\nloaded 3 rows\n```\n"+
+		"\nOutput:\n```\n   a  b\n0  1  2\n```\n"+
+		"\n## Cell 3 (code, execution 2)\n\n```python\ndf.plot()\n```\n"+
+		"\nOutput:\n```\n<Figure>\n```\n"+
+		"\n[image/png output omitted]\n"+
+		"\nError: KeyError: 'c'\n"+
+		"\n## Cell 4 (code)\n\n```python\n\n```\n", rendered)
+
+	_, _, err = renderNotebook([]byte("not json"))
+	assert.ErrorContains(t, err, "failed to parse notebook")
+}
+
+func Test_SplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name                string
+		document            string
+		expectedFrontMatter string
+		expectedBody        string
+		expectedOK          bool
+	}{
+		{
+			name:                "front matter and body",
+			document:            "---\ntitle: Hello\ntags: [a, b]\n---\n\n# Hello\n",
+			expectedFrontMatter: "title: Hello\ntags: [a, b]\n",
+			expectedBody:        "# Hello\n",
+			expectedOK:          true,
+		},
+		{
+			name:                "windows line endings and dots terminator",
+			document:            "---\r\ntitle: Hello\r\n...\r\nBody\r\n",
+			expectedFrontMatter: "title: Hello\n",
+			expectedBody:        "Body\n",
+			expectedOK:          true,
+		},
+		{
+			name:                "front matter only",
+			document:            "---\ntitle: Hello\n---",
+			expectedFrontMatter: "title: Hello\n",
+			expectedOK:          true,
+		},
+		{
+			name:         "no front matter",
+			document:     "# Hello\n---\nfooter\n",
+			expectedBody: "# Hello\n---\nfooter\n",
+		},
+		{
+			name:         "unterminated front matter",
+			document:     "---\ntitle: Hello\n",
+			expectedBody: "---\ntitle: Hello\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frontMatter, body, ok := splitFrontMatter(tc.document)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedFrontMatter, frontMatter)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func Test_SliceLines(t *testing.T) {
+	text := "one\ntwo\nthree\nfour\nfive\n"
+	tests := []struct {
+		name           string
+		text           string
+		lines          lineRange
+		expectedSlice  string
+		expectedFirst  int
+		expectedLast   int
+		expectedTotal  int
+		expectedErrMsg string
+	}{
+		{
+			name:          "range",
+			text:          text,
+			lines:         lineRange{Start: 2, End: 3},
+			expectedSlice: "two\nthree\n",
+			expectedFirst: 2,
+			expectedLast:  3,
+			expectedTotal: 5,
+		},
+		{
+			name:          "range with context clamped to the file",
+			text:          text,
+			lines:         lineRange{Start: 2, End: 4, Context: 2},
+			expectedSlice: text,
+			expectedFirst: 1,
+			expectedLast:  5,
+			expectedTotal: 5,
+		},
+		{
+			name:          "to the end of a file without final newline",
+			text:          "one\ntwo\nthree",
+			lines:         lineRange{Start: 3},
+			expectedSlice: "three",
+			expectedFirst: 3,
+			expectedLast:  3,
+			expectedTotal: 3,
+		},
+		{
+			name:          "from the start",
+			text:          text,
+			lines:         lineRange{End: 1, Context: 1},
+			expectedSlice: "one\ntwo\n",
+			expectedFirst: 1,
+			expectedLast:  2,
+			expectedTotal: 5,
+		},
+		{
+			name:           "past the end",
+			text:           text,
+			lines:          lineRange{Start: 6},
+			expectedTotal:  5,
+			expectedErrMsg: "start_line 6 is past the end of the file, which has 5 lines",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			slice, first, last, total, err := sliceLines(tc.text, tc.lines)
+			assert.Equal(t, tc.expectedTotal, total)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSlice, slice)
+			assert.Equal(t, tc.expectedFirst, first)
+			assert.Equal(t, tc.expectedLast, last)
+		})
+	}
+}
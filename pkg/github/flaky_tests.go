@@ -0,0 +1,243 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultFlakyTestMaxRuns is the default number of workflow runs find_flaky_tests scans.
+	DefaultFlakyTestMaxRuns = 30
+	// maxFlakyTestRuns is the number of workflow runs find_flaky_tests scans at most.
+	maxFlakyTestRuns = 100
+)
+
+// logTimestampPrefix matches the timestamp GitHub Actions writes at the start of every log line.
+var logTimestampPrefix = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[\d:.]+Z\s`)
+
+// failedTestPatterns match the line a test runner prints for a failed test, capturing the test name.
+var failedTestPatterns = []*regexp.Regexp{
+	// go test: "--- FAIL: TestName/subtest (0.01s)"
+	regexp.MustCompile(`^\s*--- FAIL: (\S+)`),
+	// pytest: "FAILED tests/test_api.py::test_create - AssertionError"
+	regexp.MustCompile(`^FAILED (\S+::\S+)`),
+	// Maven Surefire: "[ERROR] com.example.ApiTest.testCreate -- Time elapsed: 0.01 s <<< FAILURE!"
+	regexp.MustCompile(`^\[ERROR\]\s+(\S+)\s+(?:--\s+)?Time elapsed:.*<<< (?:FAILURE|ERROR)!`),
+}
+
+// extractFailedTests returns the names of the failed tests reported in a job log, in order of appearance.
+func extractFailedTests(log string) []string {
+	seen := map[string]bool{}
+	var tests []string
+	for _, line := range strings.Split(log, "\n") {
+		line = logTimestampPrefix.ReplaceAllString(strings.TrimRight(line, "\r"), "")
+		for _, pattern := range failedTestPatterns {
+			if match := pattern.FindStringSubmatch(line); match != nil && !seen[match[1]] {
+				seen[match[1]] = true
+				tests = append(tests, match[1])
+			}
+		}
+	}
+	return tests
+}
+
+// FlakyTest is a test that failed in a job which passed on another attempt or run for the same commit.
+type FlakyTest struct {
+	Name       string   `json:"name"`
+	Job        string   `json:"job"`
+	Failures   int      `json:"failures"`
+	Commits    []string `json:"commits"`
+	FailedJobs []string `json:"failed_jobs"`
+}
+
+// FindFlakyTests creates a tool to find tests that both failed and passed on the same commit across the
+// recent runs of a workflow.
+func FindFlakyTests(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_flaky_tests",
+			mcp.WithDescription(t("TOOL_FIND_FLAKY_TESTS_DESCRIPTION", "Find flaky tests in the recent runs of a GitHub Actions workflow. Reads the logs of failed jobs, including earlier attempts of re-run jobs, to extract the failed tests (go test, pytest and Maven Surefire output), and reports the tests that failed in a job that passed on another attempt or run for the same commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_FLAKY_TESTS_USER_TITLE", "Find flaky tests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID or workflow file name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Only scan runs for this branch"),
+			),
+			mcp.WithNumber("max_runs",
+				mcp.Description(fmt.Sprintf("Number of most recent completed runs to scan (default %d)", DefaultFlakyTestMaxRuns)),
+				mcp.Min(1),
+				mcp.Max(maxFlakyTestRuns),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRuns, err := OptionalIntParamWithDefault(request, "max_runs", DefaultFlakyTestMaxRuns)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxRuns < 1 || maxRuns > maxFlakyTestRuns {
+				return mcp.NewToolResultError(fmt.Sprintf("max_runs must be between 1 and %d", maxFlakyTestRuns)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			runs, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, &github.ListWorkflowRunsOptions{
+				Branch:      branch,
+				Status:      "completed",
+				ListOptions: github.ListOptions{PerPage: maxRuns},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list workflow runs",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// The jobs of all attempts are listed, so that a job that failed and passed when re-run is found.
+			runJobs := make([][]*github.WorkflowJob, len(runs.WorkflowRuns))
+			errs := fanOut(ctx, runs.WorkflowRuns, DefaultFanOutConcurrency, func(ctx context.Context, i int, run *github.WorkflowRun) error {
+				opts := &github.ListWorkflowJobsOptions{Filter: "all", ListOptions: github.ListOptions{PerPage: 100}}
+				for {
+					jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, run.GetID(), opts)
+					if err != nil {
+						return fmt.Errorf("failed to list jobs of run %d: %w", run.GetID(), err)
+					}
+					_ = resp.Body.Close()
+					runJobs[i] = append(runJobs[i], jobs.Jobs...)
+					if resp.NextPage == 0 {
+						return nil
+					}
+					opts.Page = resp.NextPage
+				}
+			})
+
+			// passed records the jobs that succeeded for each commit, keyed by commit and job name.
+			passed := map[string]bool{}
+			var failedJobs []*github.WorkflowJob
+			var failures []map[string]string
+			for i, run := range runs.WorkflowRuns {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"run": run.GetHTMLURL(), "error": errs[i].Error()})
+					continue
+				}
+				for _, job := range runJobs[i] {
+					switch job.GetConclusion() {
+					case "success":
+						passed[job.GetHeadSHA()+"\x00"+job.GetName()] = true
+					case "failure":
+						failedJobs = append(failedJobs, job)
+					}
+				}
+			}
+
+			// Only failed jobs that passed for the same commit can contain flaky tests, so only their logs are read.
+			var retriedJobs []*github.WorkflowJob
+			for _, job := range failedJobs {
+				if passed[job.GetHeadSHA()+"\x00"+job.GetName()] {
+					retriedJobs = append(retriedJobs, job)
+				}
+			}
+			jobTests := make([][]string, len(retriedJobs))
+			logErrs := fanOut(ctx, retriedJobs, DefaultFanOutConcurrency, func(ctx context.Context, i int, job *github.WorkflowJob) error {
+				url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, job.GetID(), 1)
+				if err != nil {
+					return fmt.Errorf("failed to get logs of job %d: %w", job.GetID(), err)
+				}
+				_ = resp.Body.Close()
+				content, _, _, err := downloadLogContent(ctx, url.String(), contentWindowSize, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadLogContent
+				if err != nil {
+					return fmt.Errorf("failed to download logs of job %d: %w", job.GetID(), err)
+				}
+				jobTests[i] = extractFailedTests(content)
+				return nil
+			})
+
+			flaky := map[string]*FlakyTest{}
+			for i, job := range retriedJobs {
+				if logErrs[i] != nil {
+					failures = append(failures, map[string]string{"job": job.GetHTMLURL(), "error": logErrs[i].Error()})
+					continue
+				}
+				for _, name := range jobTests[i] {
+					key := job.GetName() + "\x00" + name
+					test := flaky[key]
+					if test == nil {
+						test = &FlakyTest{Name: name, Job: job.GetName(), Commits: []string{}, FailedJobs: []string{}}
+						flaky[key] = test
+					}
+					test.Failures++
+					if !slices.Contains(test.Commits, job.GetHeadSHA()) {
+						test.Commits = append(test.Commits, job.GetHeadSHA())
+					}
+					test.FailedJobs = append(test.FailedJobs, job.GetHTMLURL())
+				}
+			}
+
+			tests := make([]*FlakyTest, 0, len(flaky))
+			for _, test := range flaky {
+				tests = append(tests, test)
+			}
+			sort.Slice(tests, func(i, j int) bool {
+				if tests[i].Failures != tests[j].Failures {
+					return tests[i].Failures > tests[j].Failures
+				}
+				if tests[i].Job != tests[j].Job {
+					return tests[i].Job < tests[j].Job
+				}
+				return tests[i].Name < tests[j].Name
+			})
+
+			response := map[string]any{
+				"workflow":     workflowID,
+				"scanned_runs": len(runs.WorkflowRuns),
+				"failed_jobs":  len(failedJobs),
+				"retried_jobs": len(retriedJobs),
+				"flaky_tests":  tests,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_extractFailedTests(t *testing.T) {
+	log := strings.Join([]string{
+		"2024-05-01T10:00:00.0000000Z === RUN   TestCache",
+		"2024-05-01T10:00:01.0000000Z --- FAIL: TestCache (0.10s)",
+		"2024-05-01T10:00:01.0000000Z     --- FAIL: TestCache/expiry (0.05s)",
+		"2024-05-01T10:00:01.0000000Z --- PASS: TestServer (0.01s)",
+		"2024-05-01T10:00:02.0000000Z FAILED tests/test_api.py::test_create - AssertionError: 500 != 201",
+		"2024-05-01T10:00:03.0000000Z [ERROR] com.example.ApiTest.testCreate -- Time elapsed: 0.011 s <<< FAILURE!",
+		"2024-05-01T10:00:03.0000000Z [ERROR] Tests run: 3, Failures: 1, Errors: 0, Skipped: 0, Time elapsed: 0.2 s <<< FAILURE! -- in com.example.ApiTest",
+		"2024-05-01T10:00:04.0000000Z --- FAIL: TestCache (0.10s)",
+	}, "\n")
+
+	assert.Equal(t, []string{
+		"TestCache",
+		"TestCache/expiry",
+		"tests/test_api.py::test_create",
+		"com.example.ApiTest.testCreate",
+	}, extractFailedTests(log))
+}
+
+func Test_FindFlakyTests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindFlakyTests(stubGetClientFn(mockClient), translations.NullTranslationHelper, 5000)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_flaky_tests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "max_runs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	logs := map[string]string{
+		"/11": "2024-05-01T10:00:01.0000000Z --- FAIL: TestCache (0.10s)\n2024-05-01T10:00:02.0000000Z FAILED tests/test_api.py::test_create - AssertionError",
+		"/21": "2024-05-02T10:00:01.0000000Z --- FAIL: TestCache (0.20s)",
+	}
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(logs[r.URL.Path]))
+	}))
+	defer logServer.Close()
+
+	job := func(id int64, name, sha, conclusion string) *github.WorkflowJob {
+		return &github.WorkflowJob{
+			ID:         github.Ptr(id),
+			Name:       github.Ptr(name),
+			HeadSHA:    github.Ptr(sha),
+			Conclusion: github.Ptr(conclusion),
+			HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/1/job/" + github.Stringify(id)),
+		}
+	}
+	jobs := map[string]*github.Jobs{
+		// The test job failed, then passed when re-run.
+		"/repos/owner/repo/actions/runs/1/jobs": {Jobs: []*github.WorkflowJob{
+			job(11, "test", "aaa", "failure"),
+			job(12, "test", "aaa", "success"),
+		}},
+		"/repos/owner/repo/actions/runs/2/jobs": {Jobs: []*github.WorkflowJob{
+			job(21, "test", "aaa", "failure"),
+		}},
+		// Failed for a commit it never passed for, so it's a real failure and its logs are not read.
+		"/repos/owner/repo/actions/runs/3/jobs": {Jobs: []*github.WorkflowJob{
+			job(31, "test", "bbb", "failure"),
+			job(32, "lint", "bbb", "success"),
+		}},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			expectQueryParams(t, map[string]string{
+				"branch":   "main",
+				"status":   "completed",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+					TotalCount: github.Ptr(3),
+					WorkflowRuns: []*github.WorkflowRun{
+						{ID: github.Ptr(int64(1)), HeadSHA: github.Ptr("aaa")},
+						{ID: github.Ptr(int64(2)), HeadSHA: github.Ptr("aaa")},
+						{ID: github.Ptr(int64(3)), HeadSHA: github.Ptr("bbb")},
+					},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "all", r.URL.Query().Get("filter"))
+				mockResponse(t, http.StatusOK, jobs[r.URL.Path])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				jobID := r.URL.Path[strings.LastIndex(r.URL.Path, "/jobs/")+len("/jobs/") : strings.LastIndex(r.URL.Path, "/logs")]
+				assert.NotEqual(t, "31", jobID)
+				w.Header().Set("Location", logServer.URL+"/"+jobID)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	))
+	_, handler := FindFlakyTests(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+	request := createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"workflow_id": "ci.yml",
+		"branch":      "main",
+		"max_runs":    float64(10),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var response struct {
+		ScannedRuns int         `json:"scanned_runs"`
+		FailedJobs  int         `json:"failed_jobs"`
+		RetriedJobs int         `json:"retried_jobs"`
+		FlakyTests  []FlakyTest `json:"flaky_tests"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, 3, response.ScannedRuns)
+	assert.Equal(t, 3, response.FailedJobs)
+	assert.Equal(t, 2, response.RetriedJobs)
+	assert.Equal(t, []FlakyTest{
+		{
+			Name:     "TestCache",
+			Job:      "test",
+			Failures: 2,
+			Commits:  []string{"aaa"},
+			FailedJobs: []string{
+				"https://github.com/owner/repo/actions/runs/1/job/11",
+				"https://github.com/owner/repo/actions/runs/1/job/21",
+			},
+		},
+		{
+			Name:       "tests/test_api.py::test_create",
+			Job:        "test",
+			Failures:   1,
+			Commits:    []string{"aaa"},
+			FailedJobs: []string{"https://github.com/owner/repo/actions/runs/1/job/11"},
+		},
+	}, response.FlakyTests)
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -80,12 +79,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list gists: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(gists)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, gists), nil
 		}
 }
 
@@ -170,12 +164,7 @@ func CreateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				URL: createdGist.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalResponse), nil
 		}
 }
 
@@ -259,11 +248,6 @@ func UpdateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				URL: updatedGist.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalResponse), nil
 		}
 }
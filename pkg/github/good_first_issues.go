@@ -0,0 +1,373 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultGoodFirstIssueLabel is the label GitHub suggests to newcomers in a repository's contribute page.
+	DefaultGoodFirstIssueLabel = "good first issue"
+	// DefaultGoodFirstIssueMaxIssues is the default number of open unassigned issues find_good_first_issues reads.
+	DefaultGoodFirstIssueMaxIssues = 300
+	// DefaultGoodFirstIssueMinScore is the default number of signals an issue needs to be suggested.
+	DefaultGoodFirstIssueMinScore = 3
+	// maxGoodFirstIssueComments is the number of comments above which an issue is considered a long discussion.
+	maxGoodFirstIssueComments = 5
+	// maxGoodFirstIssueBodyLength is the length above which the body of an issue is considered too large a task.
+	maxGoodFirstIssueBodyLength = 2000
+	// maxLabelIssues is the number of issues label_good_first_issues labels in one call at most.
+	maxLabelIssues = 100
+)
+
+var (
+	// reproductionPattern matches the sections and code blocks of an issue body reproducing a problem.
+	reproductionPattern = regexp.MustCompile("(?i)(steps to reproduce|to reproduce|reproduction|repro\\b|expected behavio|actual behavio|```)")
+	// issueReferencePattern matches the references to other issues and pull requests in an issue body.
+	issueReferencePattern = regexp.MustCompile(`(^|\s)#\d+\b`)
+	// excludedGoodFirstIssueLabels are parts of label names that make an issue unsuitable for a newcomer.
+	excludedGoodFirstIssueLabels = []string{"blocked", "duplicate", "invalid", "wontfix", "won't fix", "question", "discussion", "epic", "needs design", "needs-design", "security"}
+	// smallGoodFirstIssueLabels are parts of label names that mark an issue as a small task.
+	smallGoodFirstIssueLabels = []string{"documentation", "docs", "typo", "easy", "beginner", "small", "size/xs", "size/s", "size: xs", "size: s"}
+)
+
+// GoodFirstIssueCandidate is an open unassigned issue suggested as a good first issue.
+type GoodFirstIssueCandidate struct {
+	Number   int      `json:"number"`
+	Title    string   `json:"title"`
+	HTMLURL  string   `json:"html_url"`
+	Labels   []string `json:"labels"`
+	Comments int      `json:"comments"`
+	// Signals are why the issue is suggested: "small" for a label marking a small task, "has_reproduction" for
+	// steps to reproduce or a code block, "self_contained" for a short body without task lists referencing at
+	// most one other issue, "quiet" for at most a few comments, and "unassigned" which every candidate has.
+	Signals []string `json:"signals"`
+	Score   int      `json:"score"`
+}
+
+// goodFirstIssueSignals returns why an issue makes a good first issue, or nil when one of its labels rules it out.
+func goodFirstIssueSignals(issue *github.Issue) []string {
+	small := false
+	for _, label := range issue.Labels {
+		name := strings.ToLower(label.GetName())
+		for _, excluded := range excludedGoodFirstIssueLabels {
+			if strings.Contains(name, excluded) {
+				return nil
+			}
+		}
+		for _, marker := range smallGoodFirstIssueLabels {
+			if strings.Contains(name, marker) {
+				small = true
+			}
+		}
+	}
+
+	signals := []string{"unassigned"}
+	body := issue.GetBody()
+	if small {
+		signals = append(signals, "small")
+	}
+	if reproductionPattern.MatchString(body) {
+		signals = append(signals, "has_reproduction")
+	}
+	if len(body) <= maxGoodFirstIssueBodyLength && !strings.Contains(body, "- [ ]") && len(issueReferencePattern.FindAllString(body, -1)) <= 1 {
+		signals = append(signals, "self_contained")
+	}
+	if issue.GetComments() <= maxGoodFirstIssueComments {
+		signals = append(signals, "quiet")
+	}
+	return signals
+}
+
+// hasLabel reports whether an issue has a label, compared case-insensitively.
+func hasLabel(issue *github.Issue, name string) bool {
+	for _, label := range issue.Labels {
+		if strings.EqualFold(label.GetName(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindGoodFirstIssues creates a tool to find open issues of a repository that could be labeled as good first issues.
+func FindGoodFirstIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_good_first_issues",
+			mcp.WithDescription(t("TOOL_FIND_GOOD_FIRST_ISSUES_DESCRIPTION", "Find candidate good first issues in a repository: open issues without an assignee that look small, self-contained and reproducible, judging from their labels, body and number of comments. Issues already labeled, and issues with labels such as blocked, question or duplicate, are left out. Candidates are returned best first with the signals they match; review them and use label_good_first_issues to label the chosen ones.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_GOOD_FIRST_ISSUES_USER_TITLE", "Find good first issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("label",
+				mcp.Description(fmt.Sprintf("Label marking good first issues in the repository (default '%s')", DefaultGoodFirstIssueLabel)),
+			),
+			mcp.WithNumber("min_score",
+				mcp.Description(fmt.Sprintf("Number of signals an issue must match to be suggested, out of unassigned, small, has_reproduction, self_contained and quiet (default %d)", DefaultGoodFirstIssueMinScore)),
+				mcp.Min(1),
+				mcp.Max(5),
+			),
+			mcp.WithNumber("max_issues",
+				mcp.Description(fmt.Sprintf("Number of open unassigned issues to read, most recently updated first (default %d)", DefaultGoodFirstIssueMaxIssues)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if label == "" {
+				label = DefaultGoodFirstIssueLabel
+			}
+			minScore, err := OptionalIntParamWithDefault(request, "min_score", DefaultGoodFirstIssueMinScore)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if minScore < 1 || minScore > 5 {
+				return mcp.NewToolResultError("min_score must be between 1 and 5"), nil
+			}
+			maxIssues, err := OptionalIntParamWithDefault(request, "max_issues", DefaultGoodFirstIssueMaxIssues)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxIssues < 1 || maxIssues > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_issues must be between 1 and %d", maxExportItems)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.IssueListByRepoOptions{
+				State:       "open",
+				Assignee:    "none",
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: min(maxIssues, 100)},
+			}
+			var issues []*github.Issue
+			truncated := false
+			for {
+				page, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list issues of %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, issue := range page {
+					// The issues API lists pull requests too
+					if !issue.IsPullRequest() {
+						issues = append(issues, issue)
+					}
+				}
+				if len(issues) >= maxIssues {
+					truncated = len(issues) > maxIssues || resp.NextPage != 0
+					issues = issues[:maxIssues]
+					break
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.ListOptions.Page = resp.NextPage
+			}
+
+			candidates := []GoodFirstIssueCandidate{}
+			alreadyLabeled := 0
+			for _, issue := range issues {
+				if hasLabel(issue, label) {
+					alreadyLabeled++
+					continue
+				}
+				signals := goodFirstIssueSignals(issue)
+				if len(signals) < minScore {
+					continue
+				}
+				labels := make([]string, 0, len(issue.Labels))
+				for _, l := range issue.Labels {
+					labels = append(labels, l.GetName())
+				}
+				candidates = append(candidates, GoodFirstIssueCandidate{
+					Number:   issue.GetNumber(),
+					Title:    issue.GetTitle(),
+					HTMLURL:  issue.GetHTMLURL(),
+					Labels:   labels,
+					Comments: issue.GetComments(),
+					Signals:  signals,
+					Score:    len(signals),
+				})
+			}
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return candidates[i].Score > candidates[j].Score
+			})
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"label":           label,
+				"analyzed":        len(issues),
+				"truncated":       truncated,
+				"already_labeled": alreadyLabeled,
+				"candidates":      candidates,
+			}), nil
+		}
+}
+
+// LabelGoodFirstIssues creates a tool to label issues as good first issues in batch.
+func LabelGoodFirstIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("label_good_first_issues",
+			mcp.WithDescription(t("TOOL_LABEL_GOOD_FIRST_ISSUES_DESCRIPTION", "Add the good first issue label to issues of a repository in batch, e.g. candidates returned by find_good_first_issues. The label is created if the repository doesn't have it. By default it only reports the issues that would be labeled: show them to the user and call it again with dry_run set to false once they confirm.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LABEL_GOOD_FIRST_ISSUES_USER_TITLE", "Label good first issues"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Numbers of the issues to label, at most %d", maxLabelIssues)),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("label",
+				mcp.Description(fmt.Sprintf("Label to add (default '%s')", DefaultGoodFirstIssueLabel)),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only report the issues that would be labeled, without labeling them (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rawNumbers, ok := request.GetArguments()["issue_numbers"].([]any)
+			if !ok || len(rawNumbers) == 0 {
+				return mcp.NewToolResultError("issue_numbers must be a non-empty array of numbers"), nil
+			}
+			if len(rawNumbers) > maxLabelIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d issues can be labeled at once", maxLabelIssues)), nil
+			}
+			issueNumbers := make([]int, 0, len(rawNumbers))
+			for _, rawNumber := range rawNumbers {
+				number, ok := rawNumber.(float64)
+				if !ok {
+					return mcp.NewToolResultError("issue_numbers must be a non-empty array of numbers"), nil
+				}
+				issueNumbers = append(issueNumbers, int(number))
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if label == "" {
+				label = DefaultGoodFirstIssueLabel
+			}
+			dryRun, err := OptionalBoolParamWithDefault(request, "dry_run", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Issues are read first, so that pull requests, closed and already labeled issues are reported
+			// rather than labeled
+			skipped := make([]string, len(issueNumbers))
+			errs := fanOut(ctx, issueNumbers, DefaultFanOutConcurrency, func(ctx context.Context, i int, number int) error {
+				issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				switch {
+				case issue.IsPullRequest():
+					skipped[i] = "pull request"
+				case issue.GetState() != "open":
+					skipped[i] = "closed"
+				case hasLabel(issue, label):
+					skipped[i] = "already labeled"
+				}
+				if skipped[i] != "" || dryRun {
+					return nil
+				}
+				_, resp, err = client.Issues.AddLabelsToIssue(ctx, owner, repo, number, []string{label})
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			labeled := []int{}
+			var skips, failures []map[string]any
+			for i, number := range issueNumbers {
+				switch {
+				case errs[i] != nil:
+					failures = append(failures, map[string]any{"issue_number": number, "error": errs[i].Error()})
+				case skipped[i] != "":
+					skips = append(skips, map[string]any{"issue_number": number, "reason": skipped[i]})
+				default:
+					labeled = append(labeled, number)
+				}
+			}
+
+			response := map[string]any{
+				"label":   label,
+				"dry_run": dryRun,
+			}
+			if dryRun {
+				response["would_label"] = labeled
+			} else {
+				response["labeled"] = labeled
+			}
+			if len(skips) > 0 {
+				response["skipped"] = skips
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,284 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func goodFirstIssueLabels(names ...string) []*github.Label {
+	labels := make([]*github.Label, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, &github.Label{Name: github.Ptr(name)})
+	}
+	return labels
+}
+
+func Test_FindGoodFirstIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindGoodFirstIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_good_first_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	issues := []*github.Issue{
+		{
+			Number:  github.Ptr(1),
+			Title:   github.Ptr("Typo in README"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/1"),
+			Labels:  goodFirstIssueLabels("documentation"),
+			Body:    github.Ptr("Steps to reproduce: open the README and read the second paragraph."),
+		},
+		{
+			Number:   github.Ptr(2),
+			Title:    github.Ptr("Rewrite the scheduler"),
+			Body:     github.Ptr(strings.Repeat("a", maxGoodFirstIssueBodyLength+1)),
+			Comments: github.Ptr(12),
+		},
+		{
+			Number: github.Ptr(3),
+			Title:  github.Ptr("Blocked fix"),
+			Labels: goodFirstIssueLabels("bug", "Blocked"),
+			Body:   github.Ptr("To reproduce: run it"),
+		},
+		{
+			Number: github.Ptr(4),
+			Title:  github.Ptr("Already labeled"),
+			Labels: goodFirstIssueLabels("Good First Issue"),
+		},
+		{
+			Number:           github.Ptr(5),
+			Title:            github.Ptr("A pull request"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/5")},
+		},
+		{
+			Number:   github.Ptr(6),
+			Title:    github.Ptr("Crash on empty config"),
+			Labels:   goodFirstIssueLabels("bug"),
+			Body:     github.Ptr("Expected behavior: no crash\n- [ ] handle empty config\n- [ ] add a test"),
+			Comments: github.Ptr(2),
+		},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]any
+		expectError         bool
+		expectedErrMsg      string
+		expectedCandidates  []int
+		expectedFirstSignal []string
+	}{
+		{
+			name: "default score",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"assignee":  "none",
+						"sort":      "updated",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issues),
+					),
+				),
+			),
+			requestArgs:         map[string]any{"owner": "owner", "repo": "repo"},
+			expectedCandidates:  []int{1, 6},
+			expectedFirstSignal: []string{"unassigned", "small", "has_reproduction", "self_contained", "quiet"},
+		},
+		{
+			name: "higher score",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, issues),
+			),
+			requestArgs:         map[string]any{"owner": "owner", "repo": "repo", "min_score": float64(5)},
+			expectedCandidates:  []int{1},
+			expectedFirstSignal: []string{"unassigned", "small", "has_reproduction", "self_contained", "quiet"},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list issues of owner/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := FindGoodFirstIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				Label          string                    `json:"label"`
+				Analyzed       int                       `json:"analyzed"`
+				AlreadyLabeled int                       `json:"already_labeled"`
+				Candidates     []GoodFirstIssueCandidate `json:"candidates"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "good first issue", response.Label)
+			assert.Equal(t, 5, response.Analyzed)
+			assert.Equal(t, 1, response.AlreadyLabeled)
+			numbers := make([]int, 0, len(response.Candidates))
+			for _, candidate := range response.Candidates {
+				numbers = append(numbers, candidate.Number)
+				assert.Equal(t, len(candidate.Signals), candidate.Score)
+			}
+			assert.Equal(t, tc.expectedCandidates, numbers)
+			assert.Equal(t, tc.expectedFirstSignal, response.Candidates[0].Signals)
+		})
+	}
+}
+
+func Test_LabelGoodFirstIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := LabelGoodFirstIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "label_good_first_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	issuesByPath := map[string]*github.Issue{
+		"/repos/owner/repo/issues/1": {Number: github.Ptr(1), State: github.Ptr("open")},
+		"/repos/owner/repo/issues/2": {Number: github.Ptr(2), State: github.Ptr("closed")},
+		"/repos/owner/repo/issues/3": {Number: github.Ptr(3), State: github.Ptr("open"), Labels: goodFirstIssueLabels("good first issue")},
+	}
+	getIssue := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issue, ok := issuesByPath[r.URL.Path]
+		if !ok {
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, issue)(w, r)
+	})
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedLabeled  []int
+		expectedLabelKey string
+		expectedSkipped  int
+		expectedErrors   int
+		expectedCalls    []string
+	}{
+		{
+			name: "dry run",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2), float64(3), float64(4)},
+			},
+			expectedLabeled:  []int{1},
+			expectedLabelKey: "would_label",
+			expectedSkipped:  2,
+			expectedErrors:   1,
+		},
+		{
+			name: "label",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(3)},
+				"label":         "help wanted",
+				"dry_run":       false,
+			},
+			expectedLabeled:  []int{1, 3},
+			expectedLabelKey: "labeled",
+			expectedCalls:    []string{"/repos/owner/repo/issues/1/labels", "/repos/owner/repo/issues/3/labels"},
+		},
+		{
+			name: "invalid issue numbers",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{"one"},
+			},
+			expectError:    true,
+			expectedErrMsg: "issue_numbers must be a non-empty array of numbers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls []string
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposIssuesByOwnerByRepoByIssueNumber, getIssue),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var labels []string
+						assert.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+						assert.Equal(t, []string{"help wanted"}, labels)
+						mu.Lock()
+						calls = append(calls, r.URL.Path)
+						mu.Unlock()
+						mockResponse(t, http.StatusOK, goodFirstIssueLabels("help wanted"))(w, r)
+					}),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			_, handler := LabelGoodFirstIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			labeled := []int{}
+			for _, number := range response[tc.expectedLabelKey].([]any) {
+				labeled = append(labeled, int(number.(float64)))
+			}
+			assert.Equal(t, tc.expectedLabeled, labeled)
+			skipped, _ := response["skipped"].([]any)
+			assert.Len(t, skipped, tc.expectedSkipped)
+			errors, _ := response["errors"].([]any)
+			assert.Len(t, errors, tc.expectedErrors)
+			assert.ElementsMatch(t, tc.expectedCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// graphQLCostErrors are parts of the messages GitHub rejects GraphQL queries with when they ask for too many nodes
+// or take too long to resolve. Asking for fewer nodes at a time gets around them.
+var graphQLCostErrors = []string{
+	"exceeds the maximum limit of",
+	"resource limits for this query exceeded",
+	"this may be the result of a timeout",
+}
+
+// isGraphQLCostError reports whether a GraphQL query failed because it was too costly.
+func isGraphQLCostError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, costError := range graphQLCostErrors {
+		if strings.Contains(message, costError) {
+			return true
+		}
+	}
+	return false
+}
+
+// graphQLQuerier runs GraphQL queries, as githubv4.Client does.
+type graphQLQuerier interface {
+	Query(ctx context.Context, q any, variables map[string]any) error
+}
+
+// graphQLPage is what queryGraphQLPages needs to know of a page of nodes it queried.
+type graphQLPage struct {
+	nodes       int
+	hasNextPage bool
+	endCursor   githubv4.String
+	rateLimit   RateLimitFragment
+}
+
+// graphQLCost is the combined rate limit cost of the queries of a tool call.
+type graphQLCost struct {
+	queries   int
+	rateLimit RateLimitFragment
+}
+
+func (c *graphQLCost) add(rateLimit RateLimitFragment) {
+	c.queries++
+	c.rateLimit.Cost += rateLimit.Cost
+	c.rateLimit.Remaining = rateLimit.Remaining
+	c.rateLimit.ResetAt = rateLimit.ResetAt
+}
+
+// response converts the cost for use in tool responses, with the number of queries if the call needed several.
+func (c graphQLCost) response() map[string]interface{} {
+	response := rateLimitResponse(c.rateLimit)
+	if c.queries > 1 {
+		response["queries"] = c.queries
+	}
+	return response
+}
+
+// queryGraphQLPages queries first nodes of a connection paginated by the $first and $after variables. If GitHub
+// rejects the query as too costly, the page size is halved and the nodes are queried in sequential pages instead,
+// as long as the rate limit leaves room for them. newQuery returns a new value to query into and collect takes the
+// nodes of a page out of it.
+func queryGraphQLPages(ctx context.Context, client graphQLQuerier, vars map[string]any, first int32, newQuery func() any, collect func(query any) graphQLPage) (graphQLCost, error) {
+	var cost graphQLCost
+	pageSize := first
+	for fetched := int32(0); fetched < first; {
+		vars["first"] = githubv4.Int(min(pageSize, first-fetched))
+		query := newQuery()
+		if err := client.Query(ctx, query, vars); err != nil {
+			if isGraphQLCostError(err) && pageSize > 1 {
+				pageSize /= 2
+				continue
+			}
+			return cost, err
+		}
+
+		page := collect(query)
+		cost.add(page.rateLimit)
+		fetched += int32(page.nodes)
+		if !page.hasNextPage || page.nodes == 0 || page.rateLimit.Remaining < page.rateLimit.Cost {
+			break
+		}
+		vars["after"] = githubv4.NewString(page.endCursor)
+	}
+	return cost, nil
+}
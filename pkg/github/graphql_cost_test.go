@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedQuery is the query value of stubQuerier.
+type pagedQuery struct {
+	Nodes     []int
+	HasNext   bool
+	EndCursor githubv4.String
+	RateLimit RateLimitFragment
+}
+
+// stubQuerier serves the nodes 0 to total-1, rejecting queries for more than maxNodes of them as too costly.
+type stubQuerier struct {
+	total     int
+	maxNodes  int
+	remaining int
+	requested []int
+}
+
+func (s *stubQuerier) Query(_ context.Context, q any, vars map[string]any) error {
+	first := int(vars["first"].(githubv4.Int))
+	s.requested = append(s.requested, first)
+	if first > s.maxNodes {
+		return errors.New("By the time this query traverses to the labels connection, it is requesting up to 3,000 possible nodes which exceeds the maximum limit of 500,000.")
+	}
+	start := 0
+	if after, ok := vars["after"].(*githubv4.String); ok && after != nil {
+		_, _ = fmt.Sscanf(string(*after), "cursor-%d", &start)
+	}
+	query := q.(*pagedQuery)
+	for i := start; i < min(start+first, s.total); i++ {
+		query.Nodes = append(query.Nodes, i)
+	}
+	s.remaining -= 2
+	query.HasNext = start+first < s.total
+	query.EndCursor = githubv4.String(fmt.Sprintf("cursor-%d", start+len(query.Nodes)))
+	query.RateLimit = RateLimitFragment{Cost: 2, Remaining: githubv4.Int(s.remaining)}
+	return nil
+}
+
+func Test_queryGraphQLPages(t *testing.T) {
+	tests := []struct {
+		name              string
+		querier           *stubQuerier
+		first             int32
+		expectedNodes     int
+		expectedRequested []int
+		expectedQueries   int
+		expectError       bool
+	}{
+		{
+			name:              "query within limits",
+			querier:           &stubQuerier{total: 50, maxNodes: 100, remaining: 5000},
+			first:             30,
+			expectedNodes:     30,
+			expectedRequested: []int{30},
+			expectedQueries:   1,
+		},
+		{
+			name:              "costly query split into pages",
+			querier:           &stubQuerier{total: 50, maxNodes: 10, remaining: 5000},
+			first:             30,
+			expectedNodes:     30,
+			expectedRequested: []int{30, 15, 7, 7, 7, 7, 2},
+			expectedQueries:   5,
+		},
+		{
+			name:              "split pages stop at the last node",
+			querier:           &stubQuerier{total: 12, maxNodes: 10, remaining: 5000},
+			first:             30,
+			expectedNodes:     12,
+			expectedRequested: []int{30, 15, 7, 7},
+			expectedQueries:   2,
+		},
+		{
+			name:              "split pages stop when the rate limit runs out",
+			querier:           &stubQuerier{total: 50, maxNodes: 10, remaining: 5},
+			first:             30,
+			expectedNodes:     14,
+			expectedRequested: []int{30, 15, 7, 7},
+			expectedQueries:   2,
+		},
+		{
+			name:              "query too costly for a single node",
+			querier:           &stubQuerier{total: 50, maxNodes: 0, remaining: 5000},
+			first:             4,
+			expectedRequested: []int{4, 2, 1},
+			expectError:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var nodes []int
+			vars := map[string]any{"after": (*githubv4.String)(nil)}
+			cost, err := queryGraphQLPages(context.Background(), tc.querier, vars, tc.first,
+				func() any { return &pagedQuery{} },
+				func(query any) graphQLPage {
+					page := query.(*pagedQuery)
+					nodes = append(nodes, page.Nodes...)
+					return graphQLPage{nodes: len(page.Nodes), hasNextPage: page.HasNext, endCursor: page.EndCursor, rateLimit: page.RateLimit}
+				},
+			)
+			assert.Equal(t, tc.expectedRequested, tc.querier.requested)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.True(t, isGraphQLCostError(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, nodes, tc.expectedNodes)
+			for i, node := range nodes {
+				assert.Equal(t, i, node, "nodes must be in order without gaps")
+			}
+			assert.Equal(t, tc.expectedQueries, cost.queries)
+			assert.Equal(t, githubv4.Int(2*tc.expectedQueries), cost.rateLimit.Cost)
+			assert.Equal(t, githubv4.Int(tc.querier.remaining), cost.rateLimit.Remaining)
+		})
+	}
+}
+
+func Test_isGraphQLCostError(t *testing.T) {
+	assert.True(t, isGraphQLCostError(errors.New("Something went wrong while executing your query. This may be the result of a timeout, or it could be a GitHub bug.")))
+	assert.True(t, isGraphQLCostError(errors.New("Resource limits for this query exceeded.")))
+	assert.False(t, isGraphQLCostError(errors.New("Could not resolve to a Repository with the name 'owner/missing'.")))
+	assert.False(t, isGraphQLCostError(errors.New("API rate limit exceeded for user ID 1.")))
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// APIVersionHeader is the header selecting the version of the REST API.
+const APIVersionHeader = "X-GitHub-Api-Version"
+
+// headerNamePattern matches a valid HTTP header name.
+var headerNamePattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// reservedHeaders are the headers the server sets itself, which can't be configured.
+var reservedHeaders = map[string]bool{
+	"Authorization":  true,
+	"Content-Length": true,
+	"Host":           true,
+}
+
+// HTTPHeaders are extra headers to send with the requests to GitHub, such as X-GitHub-Api-Version or the Accept
+// header of a preview API.
+type HTTPHeaders struct {
+	// Headers are sent with all requests.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Toolsets are sent with the requests of the tools of a toolset, keyed by toolset ID, and take precedence over
+	// Headers.
+	Toolsets map[string]map[string]string `json:"toolsets,omitempty"`
+}
+
+// LoadHTTPHeaders reads the extra headers from a JSON file with the shape of HTTPHeaders.
+func LoadHTTPHeaders(path string) (HTTPHeaders, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return HTTPHeaders{}, fmt.Errorf("failed to read HTTP headers: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var headers HTTPHeaders
+	if err := decoder.Decode(&headers); err != nil {
+		return HTTPHeaders{}, fmt.Errorf("failed to parse HTTP headers: %w", err)
+	}
+	return headers, nil
+}
+
+// toHTTPHeader checks the names of headers and converts them to an http.Header.
+func toHTTPHeader(headers map[string]string) (http.Header, error) {
+	header := make(http.Header, len(headers))
+	for name, value := range headers {
+		if !headerNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+		if reservedHeaders[http.CanonicalHeaderKey(name)] {
+			return nil, fmt.Errorf("header %s is set by the server and can't be configured", name)
+		}
+		header.Set(name, value)
+	}
+	return header, nil
+}
+
+// RequestHeaders adds extra headers to the requests to GitHub, those of a toolset only to the requests made by its
+// tools.
+type RequestHeaders struct {
+	global   http.Header
+	toolsets map[string]http.Header
+}
+
+// NewRequestHeaders creates a RequestHeaders adding the given headers. It returns nil if there are none.
+func NewRequestHeaders(headers HTTPHeaders) (*RequestHeaders, error) {
+	if len(headers.Headers) == 0 && len(headers.Toolsets) == 0 {
+		return nil, nil
+	}
+
+	global, err := toHTTPHeader(headers.Headers)
+	if err != nil {
+		return nil, err
+	}
+	h := &RequestHeaders{global: global, toolsets: make(map[string]http.Header, len(headers.Toolsets))}
+	for toolset, toolsetHeaders := range headers.Toolsets {
+		header, err := toHTTPHeader(toolsetHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("toolset %s: %w", toolset, err)
+		}
+		h.toolsets[toolset] = header
+	}
+	return h, nil
+}
+
+// Toolsets returns the IDs of the toolsets with headers of their own, sorted.
+func (h *RequestHeaders) Toolsets() []string {
+	ids := make([]string, 0, len(h.toolsets))
+	for id := range h.toolsets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type toolsetHeadersKey struct{}
+
+// Middleware makes the requests of a tool call carry the headers of the toolset of the tool, given by toolsetOf.
+func (h *RequestHeaders) Middleware(toolsetOf func(tool string) string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if header, ok := h.toolsets[toolsetOf(request.Params.Name)]; ok {
+				ctx = context.WithValue(ctx, toolsetHeadersKey{}, header)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+type requestHeadersTransport struct {
+	headers   *RequestHeaders
+	transport http.RoundTripper
+}
+
+func (t *requestHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers.global {
+		req.Header[name] = values
+	}
+	if header, ok := req.Context().Value(toolsetHeadersKey{}).(http.Header); ok {
+		for name, values := range header {
+			req.Header[name] = values
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// Transport wraps an HTTP transport so its requests carry the extra headers, replacing those the clients set, such
+// as the default API version.
+func (h *RequestHeaders) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &requestHeadersTransport{headers: h, transport: transport}
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadHTTPHeaders(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	headers, err := LoadHTTPHeaders(write("headers.json", `{
+		"headers": {"X-GitHub-Api-Version": "2026-03-10"},
+		"toolsets": {"projects": {"Accept": "application/vnd.github.inertia-preview+json"}}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, HTTPHeaders{
+		Headers:  map[string]string{"X-GitHub-Api-Version": "2026-03-10"},
+		Toolsets: map[string]map[string]string{"projects": {"Accept": "application/vnd.github.inertia-preview+json"}},
+	}, headers)
+
+	_, err = LoadHTTPHeaders(write("unknown.json", `{"header": {"Accept": "application/json"}}`))
+	assert.ErrorContains(t, err, `failed to parse HTTP headers: json: unknown field "header"`)
+
+	_, err = LoadHTTPHeaders(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read HTTP headers")
+}
+
+func Test_NewRequestHeaders(t *testing.T) {
+	headers, err := NewRequestHeaders(HTTPHeaders{})
+	require.NoError(t, err)
+	assert.Nil(t, headers)
+
+	headers, err = NewRequestHeaders(HTTPHeaders{Toolsets: map[string]map[string]string{
+		"projects": {"Accept": "application/json"},
+		"issues":   {"X-Preview": "on"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"issues", "projects"}, headers.Toolsets())
+
+	_, err = NewRequestHeaders(HTTPHeaders{Headers: map[string]string{"Bad Header": "x"}})
+	assert.EqualError(t, err, `invalid header name "Bad Header"`)
+
+	_, err = NewRequestHeaders(HTTPHeaders{Toolsets: map[string]map[string]string{"repos": {"authorization": "token x"}}})
+	assert.EqualError(t, err, "toolset repos: header authorization is set by the server and can't be configured")
+}
+
+func Test_RequestHeaders(t *testing.T) {
+	headers, err := NewRequestHeaders(HTTPHeaders{
+		Headers: map[string]string{
+			"x-github-api-version": "2026-03-10",
+			"X-Team":               "platform",
+		},
+		Toolsets: map[string]map[string]string{
+			"projects": {
+				"Accept": "application/vnd.github.inertia-preview+json",
+				"X-Team": "planning",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var sent http.Header
+	client := github.NewClient(&http.Client{Transport: headers.Transport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req.Header
+		return stubResponse(http.StatusOK, nil, "{}"), nil
+	}))})
+
+	toolsets := map[string]string{"get_project": "projects", "get_me": "context"}
+	handler := headers.Middleware(func(tool string) string { return toolsets[tool] })(
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			_, _, err := client.Users.Get(ctx, "")
+			require.NoError(t, err)
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	call := func(tool string) {
+		request := createMCPRequest(map[string]any{})
+		request.Params.Name = tool
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	// The global headers replace the defaults of the client
+	call("get_me")
+	assert.Equal(t, "2026-03-10", sent.Get(APIVersionHeader))
+	assert.Equal(t, "platform", sent.Get("X-Team"))
+	assert.Equal(t, "application/vnd.github.v3+json", sent.Get("Accept"))
+
+	// The headers of the toolset take precedence
+	call("get_project")
+	assert.Equal(t, "2026-03-10", sent.Get(APIVersionHeader))
+	assert.Equal(t, "planning", sent.Get("X-Team"))
+	assert.Equal(t, "application/vnd.github.inertia-preview+json", sent.Get("Accept"))
+}
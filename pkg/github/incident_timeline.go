@@ -0,0 +1,404 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultIncidentTimelineMaxEvents is the default number of events get_incident_timeline returns.
+	DefaultIncidentTimelineMaxEvents = 500
+	// incidentTimelinePageSize is the number of deployments, workflow runs and alerts of each kind read per
+	// repository.
+	incidentTimelinePageSize = 100
+)
+
+// TimelineEvent is an event of an incident timeline.
+type TimelineEvent struct {
+	Time       string `json:"time"`
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Summary    string `json:"summary"`
+	State      string `json:"state,omitempty"`
+	Actor      string `json:"actor,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	SHA        string `json:"sha,omitempty"`
+	URL        string `json:"url,omitempty"`
+
+	at time.Time
+}
+
+func newTimelineEvent(eventType, repo string, at time.Time, summary string) TimelineEvent {
+	return TimelineEvent{
+		Time:       at.UTC().Format(time.RFC3339),
+		Type:       eventType,
+		Repository: repo,
+		Summary:    summary,
+		at:         at,
+	}
+}
+
+// timelineWindow is the time window of an incident timeline.
+type timelineWindow struct {
+	since, until time.Time
+}
+
+func (w timelineWindow) contains(t time.Time) bool {
+	return !t.Before(w.since) && !t.After(w.until)
+}
+
+// timelineSource reads the events of one type of a repository. It reports whether more events than it read can be
+// in the window.
+type timelineSource func(ctx context.Context, client *github.Client, owner, repo string, window timelineWindow, maxEvents int) ([]TimelineEvent, bool, error)
+
+// incidentTimelineSources are the event types of an incident timeline, in the order they are read.
+var incidentTimelineSources = []struct {
+	eventType string
+	read      timelineSource
+}{
+	{"deployment", deploymentTimelineEvents},
+	{"pull_request", pullRequestTimelineEvents},
+	{"workflow_failure", workflowFailureTimelineEvents},
+	{"alert", alertTimelineEvents},
+}
+
+// deploymentTimelineEvents returns an event for every status of the deployments created in the window.
+func deploymentTimelineEvents(ctx context.Context, client *github.Client, owner, repo string, window timelineWindow, _ int) ([]TimelineEvent, bool, error) {
+	deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+		ListOptions: github.ListOptions{PerPage: incidentTimelinePageSize},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	var events []TimelineEvent
+	// Deployments are listed newest first, so all deployments in the window may not have been read if the oldest
+	// one read is in it.
+	truncated := len(deployments) == incidentTimelinePageSize && window.contains(deployments[len(deployments)-1].GetCreatedAt().Time)
+	for _, deployment := range deployments {
+		if !window.contains(deployment.GetCreatedAt().Time) {
+			continue
+		}
+		statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list statuses of deployment %d: %w", deployment.GetID(), err)
+		}
+		_ = resp.Body.Close()
+
+		created := newTimelineEvent("deployment", repo, deployment.GetCreatedAt().Time,
+			fmt.Sprintf("Deployment of %s to %s created", deployment.GetRef(), deployment.GetEnvironment()))
+		created.State = "created"
+		created.Actor = deployment.GetCreator().GetLogin()
+		created.Ref = deployment.GetRef()
+		created.SHA = deployment.GetSHA()
+		events = append(events, created)
+		for _, status := range statuses {
+			if !window.contains(status.GetCreatedAt().Time) {
+				continue
+			}
+			event := newTimelineEvent("deployment", repo, status.GetCreatedAt().Time,
+				fmt.Sprintf("Deployment of %s to %s: %s", deployment.GetRef(), deployment.GetEnvironment(), status.GetState()))
+			event.State = status.GetState()
+			event.Actor = status.GetCreator().GetLogin()
+			event.Ref = deployment.GetRef()
+			event.SHA = deployment.GetSHA()
+			event.URL = status.GetLogURL()
+			events = append(events, event)
+		}
+	}
+	return events, truncated, nil
+}
+
+// pullRequestTimelineEvents returns an event for every pull request merged in the window.
+func pullRequestTimelineEvents(ctx context.Context, client *github.Client, owner, repo string, window timelineWindow, maxEvents int) ([]TimelineEvent, bool, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr is:merged merged:%s..%s", owner, repo,
+		window.since.UTC().Format(time.RFC3339), window.until.UTC().Format(time.RFC3339))
+	pulls, total, _, err := searchAllIssues(ctx, client, query, maxEvents)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to search merged pull requests: %w", err)
+	}
+
+	events := make([]TimelineEvent, 0, len(pulls))
+	for _, pull := range pulls {
+		event := newTimelineEvent("pull_request", repo, pull.GetPullRequestLinks().GetMergedAt().Time,
+			fmt.Sprintf("#%d %s merged", pull.GetNumber(), pull.GetTitle()))
+		event.State = "merged"
+		event.Actor = pull.GetUser().GetLogin()
+		event.URL = pull.GetHTMLURL()
+		events = append(events, event)
+	}
+	return events, len(pulls) < total, nil
+}
+
+// workflowFailureTimelineEvents returns an event for every workflow run created in the window that failed.
+func workflowFailureTimelineEvents(ctx context.Context, client *github.Client, owner, repo string, window timelineWindow, _ int) ([]TimelineEvent, bool, error) {
+	runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+		Status: "failure",
+		Created: fmt.Sprintf("%s..%s",
+			window.since.UTC().Format(time.RFC3339), window.until.UTC().Format(time.RFC3339)),
+		ListOptions: github.ListOptions{PerPage: incidentTimelinePageSize},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	events := make([]TimelineEvent, 0, len(runs.WorkflowRuns))
+	for _, run := range runs.WorkflowRuns {
+		// The run failed when it was last updated.
+		event := newTimelineEvent("workflow_failure", repo, run.GetUpdatedAt().Time,
+			fmt.Sprintf("%s failed on %s: %s", run.GetName(), run.GetHeadBranch(), run.GetDisplayTitle()))
+		event.State = run.GetConclusion()
+		event.Actor = run.GetActor().GetLogin()
+		event.Ref = run.GetHeadBranch()
+		event.SHA = run.GetHeadSHA()
+		event.URL = run.GetHTMLURL()
+		events = append(events, event)
+	}
+	return events, runs.GetTotalCount() > len(runs.WorkflowRuns), nil
+}
+
+// alertTimelineEvents returns an event for every Dependabot, code scanning and secret scanning alert opened in the
+// window. The alerts of the features that are enabled are returned even if others fail.
+func alertTimelineEvents(ctx context.Context, client *github.Client, owner, repo string, window timelineWindow, _ int) ([]TimelineEvent, bool, error) {
+	var events []TimelineEvent
+	var errs []error
+	// Alerts are listed newest first, so all alerts opened in the window may not have been read if the oldest one
+	// read was opened in it.
+	truncated := false
+	add := func(created time.Time, summary, state, url string) {
+		if window.contains(created) {
+			event := newTimelineEvent("alert", repo, created, summary)
+			event.State = state
+			event.URL = url
+			events = append(events, event)
+		}
+	}
+
+	dependabotAlerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
+		Sort:        github.Ptr("created"),
+		Direction:   github.Ptr("desc"),
+		ListOptions: github.ListOptions{PerPage: incidentTimelinePageSize},
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list Dependabot alerts: %w", err))
+	} else {
+		_ = resp.Body.Close()
+		for _, alert := range dependabotAlerts {
+			add(alert.GetCreatedAt().Time,
+				fmt.Sprintf("Dependabot alert: %s severity %s in %s", alert.GetSecurityAdvisory().GetSeverity(),
+					alert.GetSecurityAdvisory().GetSummary(), alert.GetSecurityVulnerability().GetPackage().GetName()),
+				alert.GetState(), alert.GetHTMLURL())
+		}
+		truncated = truncated || len(dependabotAlerts) == incidentTimelinePageSize &&
+			window.contains(dependabotAlerts[len(dependabotAlerts)-1].GetCreatedAt().Time)
+	}
+
+	codeScanningAlerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: incidentTimelinePageSize},
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list code scanning alerts: %w", err))
+	} else {
+		_ = resp.Body.Close()
+		for _, alert := range codeScanningAlerts {
+			add(alert.GetCreatedAt().Time,
+				fmt.Sprintf("Code scanning alert: %s severity %s", alert.GetRule().GetSeverity(), alert.GetRule().GetDescription()),
+				alert.GetState(), alert.GetHTMLURL())
+		}
+		truncated = truncated || len(codeScanningAlerts) == incidentTimelinePageSize &&
+			window.contains(codeScanningAlerts[len(codeScanningAlerts)-1].GetCreatedAt().Time)
+	}
+
+	secretScanningAlerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, &github.SecretScanningAlertListOptions{
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: incidentTimelinePageSize},
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list secret scanning alerts: %w", err))
+	} else {
+		_ = resp.Body.Close()
+		for _, alert := range secretScanningAlerts {
+			add(alert.GetCreatedAt().Time,
+				fmt.Sprintf("Secret scanning alert: %s", alert.GetSecretTypeDisplayName()),
+				alert.GetState(), alert.GetHTMLURL())
+		}
+		truncated = truncated || len(secretScanningAlerts) == incidentTimelinePageSize &&
+			window.contains(secretScanningAlerts[len(secretScanningAlerts)-1].GetCreatedAt().Time)
+	}
+
+	return events, truncated, errors.Join(errs...)
+}
+
+// GetIncidentTimeline creates a tool to build a timeline of the deployments, merged pull requests, workflow failures
+// and security alerts of a set of repositories in a time window.
+func GetIncidentTimeline(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	eventTypes := make([]string, 0, len(incidentTimelineSources))
+	for _, source := range incidentTimelineSources {
+		eventTypes = append(eventTypes, source.eventType)
+	}
+
+	return mcp.NewTool("get_incident_timeline",
+			mcp.WithDescription(t("TOOL_GET_INCIDENT_TIMELINE_DESCRIPTION", "Build a timeline for an incident review: the deployments and their status changes, merged pull requests, failed workflow runs and security alerts opened in a time window across a set of repositories, as events ordered by time.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_INCIDENT_TIMELINE_USER_TITLE", "Get incident timeline"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description("Names of the repositories of the owner to build the timeline of"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("since",
+				mcp.Required(),
+				mcp.Description("Start of the time window, in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the time window, in ISO 8601 format. Defaults to now"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Description("Types of events to include. Defaults to all"),
+				mcp.Items(map[string]any{
+					"type": "string",
+					"enum": eventTypes,
+				}),
+			),
+			mcp.WithNumber("max_events",
+				mcp.Description(fmt.Sprintf("Maximum number of events to return, earliest first (default %d)", DefaultIncidentTimelineMaxEvents)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			since, err := RequiredParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			types, err := OptionalStringArrayParam(request, "event_types")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxEvents, err := OptionalIntParamWithDefault(request, "max_events", DefaultIncidentTimelineMaxEvents)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxEvents < 1 || maxEvents > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_events must be between 1 and %d", maxExportItems)), nil
+			}
+
+			window := timelineWindow{until: time.Now()}
+			if until != "" {
+				window.until, err = parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until timestamp: %s", err)), nil
+				}
+			}
+			window.since, err = parseISOTimestamp(since)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid since timestamp: %s", err)), nil
+			}
+			if !window.since.Before(window.until) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+
+			for _, eventType := range types {
+				if !slices.Contains(eventTypes, eventType) {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid event type '%s', must be one of %v", eventType, eventTypes)), nil
+				}
+			}
+
+			type sourceRead struct {
+				repo      string
+				eventType string
+				read      timelineSource
+			}
+			var reads []sourceRead
+			for _, source := range incidentTimelineSources {
+				if len(types) > 0 && !slices.Contains(types, source.eventType) {
+					continue
+				}
+				for _, repo := range repositories {
+					reads = append(reads, sourceRead{repo: repo, eventType: source.eventType, read: source.read})
+				}
+			}
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			readEvents := make([][]TimelineEvent, len(reads))
+			readTruncated := make([]bool, len(reads))
+			errs := fanOut(ctx, reads, DefaultFanOutConcurrency, func(ctx context.Context, i int, r sourceRead) error {
+				var err error
+				readEvents[i], readTruncated[i], err = r.read(ctx, client, owner, r.repo, window, maxEvents)
+				return err
+			})
+
+			events := []TimelineEvent{}
+			truncated := false
+			var failures []map[string]string
+			for i, r := range reads {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{
+						"repository": r.repo,
+						"event_type": r.eventType,
+						"error":      errs[i].Error(),
+					})
+				}
+				events = append(events, readEvents[i]...)
+				truncated = truncated || readTruncated[i]
+			}
+			sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+			if len(events) > maxEvents {
+				events = events[:maxEvents]
+				truncated = true
+			}
+
+			response := map[string]any{
+				"owner":  owner,
+				"since":  window.since.UTC().Format(time.RFC3339),
+				"until":  window.until.UTC().Format(time.RFC3339),
+				"events": events,
+				// The timeline is missing events when a repository had more of them than were read.
+				"truncated": truncated,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIncidentTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIncidentTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_incident_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.Contains(t, tool.InputSchema.Properties, "max_events")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repositories", "since"})
+
+	at := func(hhmm string) *github.Timestamp {
+		ts, err := time.Parse(time.RFC3339, "2024-05-01T"+hhmm+":00Z")
+		require.NoError(t, err)
+		return &github.Timestamp{Time: ts}
+	}
+
+	newClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposDeploymentsByOwnerByRepo, []*github.Deployment{
+				{ID: github.Ptr(int64(2)), Ref: github.Ptr("main"), SHA: github.Ptr("bbb"), Environment: github.Ptr("production"),
+					Creator: &github.User{Login: github.Ptr("deployer")}, CreatedAt: at("10:05")},
+				// Before the window, so its statuses are not read.
+				{ID: github.Ptr(int64(1)), Ref: github.Ptr("main"), SHA: github.Ptr("aaa"), Environment: github.Ptr("production"),
+					CreatedAt: at("08:00")},
+			}),
+			mock.WithRequestMatch(mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId, []*github.DeploymentStatus{
+				{State: github.Ptr("success"), CreatedAt: at("10:10"), Creator: &github.User{Login: github.Ptr("deployer")}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				expectQueryParams(t, map[string]string{
+					"q":        "repo:owner/api is:pr is:merged merged:2024-05-01T09:00:00Z..2024-05-01T12:00:00Z",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+						Total: github.Ptr(1),
+						Issues: []*github.Issue{{
+							Number:           github.Ptr(42),
+							Title:            github.Ptr("Add cache"),
+							User:             &github.User{Login: github.Ptr("alice")},
+							PullRequestLinks: &github.PullRequestLinks{MergedAt: at("10:00")},
+						}},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{
+					"status":   "failure",
+					"created":  "2024-05-01T09:00:00Z..2024-05-01T12:00:00Z",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+						TotalCount: github.Ptr(1),
+						WorkflowRuns: []*github.WorkflowRun{{
+							Name:         github.Ptr("CI"),
+							HeadBranch:   github.Ptr("main"),
+							DisplayTitle: github.Ptr("Add cache"),
+							Conclusion:   github.Ptr("failure"),
+							UpdatedAt:    at("10:30"),
+						}},
+					}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposDependabotAlertsByOwnerByRepo, []*github.DependabotAlert{
+				{
+					State:                 github.Ptr("open"),
+					CreatedAt:             at("11:00"),
+					SecurityAdvisory:      &github.DependabotSecurityAdvisory{Severity: github.Ptr("high"), Summary: github.Ptr("Prototype pollution")},
+					SecurityVulnerability: &github.AdvisoryVulnerability{Package: &github.VulnerabilityPackage{Name: github.Ptr("lodash")}},
+				},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCodeScanningAlertsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message": "Code scanning is not enabled"}`))
+				}),
+			),
+			mock.WithRequestMatch(mock.GetReposSecretScanningAlertsByOwnerByRepo, []*github.SecretScanningAlert{}),
+		))
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedEvents []string
+		expectedErrors int
+		truncated      bool
+	}{
+		{
+			name: "all event types",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repositories": []any{"api"},
+				"since":        "2024-05-01T09:00:00Z",
+				"until":        "2024-05-01T12:00:00Z",
+			},
+			expectedEvents: []string{
+				"pull_request #42 Add cache merged",
+				"deployment Deployment of main to production created",
+				"deployment Deployment of main to production: success",
+				"workflow_failure CI failed on main: Add cache",
+				"alert Dependabot alert: high severity Prototype pollution in lodash",
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "selected event types and limit",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repositories": []any{"api"},
+				"since":        "2024-05-01T09:00:00Z",
+				"until":        "2024-05-01T12:00:00Z",
+				"event_types":  []any{"deployment", "workflow_failure"},
+				"max_events":   float64(2),
+			},
+			expectedEvents: []string{
+				"deployment Deployment of main to production created",
+				"deployment Deployment of main to production: success",
+			},
+			truncated: true,
+		},
+		{
+			name: "invalid event type",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repositories": []any{"api"},
+				"since":        "2024-05-01",
+				"event_types":  []any{"outage"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid event type 'outage'",
+		},
+		{
+			name: "since after until",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repositories": []any{"api"},
+				"since":        "2024-05-02",
+				"until":        "2024-05-01",
+			},
+			expectError:    true,
+			expectedErrMsg: "since must be before until",
+		},
+		{
+			name: "missing repositories",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"since": "2024-05-01",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetIncidentTimeline(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Events    []TimelineEvent     `json:"events"`
+				Truncated bool                `json:"truncated"`
+				Errors    []map[string]string `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			var events []string
+			for _, event := range response.Events {
+				assert.Equal(t, "api", event.Repository)
+				events = append(events, event.Type+" "+event.Summary)
+			}
+			assert.Equal(t, tc.expectedEvents, events)
+			assert.Equal(t, tc.truncated, response.Truncated)
+			assert.Len(t, response.Errors, tc.expectedErrors)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// InFlightCall is a tool call that has started and not yet returned.
+type InFlightCall struct {
+	Tool      string    `json:"tool"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// InFlightCalls tracks the running tool calls so that the server can shut down without abandoning them. Once
+// draining, it rejects new calls and waits for the running ones to return. Multi-step writes, such as push_files,
+// are only safe to interrupt between calls.
+type InFlightCalls struct {
+	mu       sync.Mutex
+	draining bool
+	nextID   uint64
+	calls    map[uint64]InFlightCall
+	idle     chan struct{}
+}
+
+// NewInFlightCalls creates an InFlightCalls that accepts calls.
+func NewInFlightCalls() *InFlightCalls {
+	return &InFlightCalls{calls: make(map[uint64]InFlightCall)}
+}
+
+// Middleware returns a tool handler middleware that records the calls it handles, and rejects calls with a tool
+// error once Drain has been called.
+func (c *InFlightCalls) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id, ok := c.start(request.Params.Name)
+			if !ok {
+				return mcp.NewToolResultError("the server is shutting down and no longer accepts tool calls"), nil
+			}
+			defer c.finish(id)
+			return next(ctx, request)
+		}
+	}
+}
+
+func (c *InFlightCalls) start(tool string) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return 0, false
+	}
+	c.nextID++
+	c.calls[c.nextID] = InFlightCall{Tool: tool, StartedAt: time.Now()}
+	return c.nextID, true
+}
+
+func (c *InFlightCalls) finish(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.calls, id)
+	if c.draining && len(c.calls) == 0 && c.idle != nil {
+		close(c.idle)
+		c.idle = nil
+	}
+}
+
+// Drain stops accepting calls and waits until the running calls have returned or ctx is done. It returns the calls
+// that were still running when ctx was done, oldest first, so they can be recorded as abandoned.
+func (c *InFlightCalls) Drain(ctx context.Context) []InFlightCall {
+	c.mu.Lock()
+	c.draining = true
+	if len(c.calls) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	if c.idle == nil {
+		c.idle = make(chan struct{})
+	}
+	idle := c.idle
+	c.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]uint64, 0, len(c.calls))
+	for id := range c.calls {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	running := make([]InFlightCall, 0, len(ids))
+	for _, id := range ids {
+		running = append(running, c.calls[id])
+	}
+	return running
+}
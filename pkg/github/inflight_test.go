@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InFlightCalls(t *testing.T) {
+	newRequest := func(name string) mcp.CallToolRequest {
+		request := createMCPRequest(map[string]any{})
+		request.Params.Name = name
+		return request
+	}
+
+	t.Run("drain waits for running calls", func(t *testing.T) {
+		inFlight := NewInFlightCalls()
+		started, release := make(chan struct{}), make(chan struct{})
+		handler := inFlight.Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			<-release
+			return mcp.NewToolResultText("pushed"), nil
+		})
+
+		resultC := make(chan *mcp.CallToolResult, 1)
+		go func() {
+			result, _ := handler(context.Background(), newRequest("push_files"))
+			resultC <- result
+		}()
+		<-started
+
+		drained := make(chan []InFlightCall, 1)
+		go func() { drained <- inFlight.Drain(context.Background()) }()
+
+		// New calls are rejected once draining has started
+		other := inFlight.Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("accepted"), nil
+		})
+		assert.Eventually(t, func() bool {
+			result, err := other(context.Background(), newRequest("create_branch"))
+			return err == nil && result.IsError
+		}, time.Second, 10*time.Millisecond)
+
+		select {
+		case <-drained:
+			t.Fatal("drain returned before the running call finished")
+		default:
+		}
+
+		close(release)
+		assert.Empty(t, <-drained)
+		assert.Equal(t, "pushed", getTextResult(t, <-resultC).Text)
+	})
+
+	t.Run("drain reports calls still running at the timeout", func(t *testing.T) {
+		inFlight := NewInFlightCalls()
+		started, release := make(chan struct{}), make(chan struct{})
+		defer close(release)
+		handler := inFlight.Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started <- struct{}{}
+			<-release
+			return mcp.NewToolResultText("done"), nil
+		})
+
+		go func() { _, _ = handler(context.Background(), newRequest("push_files")) }()
+		<-started
+		go func() { _, _ = handler(context.Background(), newRequest("create_or_update_file")) }()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		abandoned := inFlight.Drain(ctx)
+
+		require.Len(t, abandoned, 2)
+		assert.Equal(t, "push_files", abandoned[0].Tool)
+		assert.Equal(t, "create_or_update_file", abandoned[1].Tool)
+	})
+
+	t.Run("drain without running calls returns at once", func(t *testing.T) {
+		inFlight := NewInFlightCalls()
+		handler := inFlight.Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+		result, err := handler(context.Background(), newRequest("get_me"))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		assert.Empty(t, inFlight.Drain(context.Background()))
+
+		result, err = handler(context.Background(), newRequest("get_me"))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "shutting down")
+	})
+}
@@ -0,0 +1,278 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultExportMaxItems is the default number of issues or pull requests exported in one call.
+	DefaultExportMaxItems = 500
+	// maxExportItems is the number of results the search API returns for a query at most.
+	maxExportItems = 1000
+)
+
+// ExportedIssue is a row of an issue or pull request export.
+type ExportedIssue struct {
+	Number     int      `json:"number"`
+	Repository string   `json:"repository"`
+	Title      string   `json:"title"`
+	State      string   `json:"state"`
+	Author     string   `json:"author"`
+	Assignees  []string `json:"assignees"`
+	Labels     []string `json:"labels"`
+	Milestone  string   `json:"milestone,omitempty"`
+	Comments   int      `json:"comments"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+	ClosedAt   string   `json:"closed_at,omitempty"`
+	URL        string   `json:"url"`
+}
+
+var exportColumns = []string{"number", "repository", "title", "state", "author", "assignees", "labels", "milestone", "comments", "created_at", "updated_at", "closed_at", "url"}
+
+func (e ExportedIssue) columns() []string {
+	return []string{
+		strconv.Itoa(e.Number),
+		e.Repository,
+		e.Title,
+		e.State,
+		e.Author,
+		strings.Join(e.Assignees, ", "),
+		strings.Join(e.Labels, ", "),
+		e.Milestone,
+		strconv.Itoa(e.Comments),
+		e.CreatedAt,
+		e.UpdatedAt,
+		e.ClosedAt,
+		e.URL,
+	}
+}
+
+func convertToExportedIssue(issue *github.Issue) ExportedIssue {
+	exported := ExportedIssue{
+		Number:     issue.GetNumber(),
+		Repository: strings.TrimPrefix(issue.GetRepositoryURL(), "https://api.github.com/repos/"),
+		Title:      issue.GetTitle(),
+		State:      issue.GetState(),
+		Author:     issue.GetUser().GetLogin(),
+		Assignees:  []string{},
+		Labels:     []string{},
+		Milestone:  issue.GetMilestone().GetTitle(),
+		Comments:   issue.GetComments(),
+		CreatedAt:  formatOptionalTimestamp(issue.CreatedAt),
+		UpdatedAt:  formatOptionalTimestamp(issue.UpdatedAt),
+		ClosedAt:   formatOptionalTimestamp(issue.ClosedAt),
+		URL:        issue.GetHTMLURL(),
+	}
+	// Merged pull requests are reported as such rather than as closed.
+	if issue.GetPullRequestLinks().GetMergedAt() != (github.Timestamp{}) {
+		exported.State = "merged"
+	}
+	for _, assignee := range issue.Assignees {
+		exported.Assignees = append(exported.Assignees, assignee.GetLogin())
+	}
+	for _, label := range issue.Labels {
+		exported.Labels = append(exported.Labels, label.GetName())
+	}
+	return exported
+}
+
+// renderIssueExport renders exported rows in the given format and returns the document with its MIME type.
+func renderIssueExport(rows []ExportedIssue, format string) (string, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "jsonl":
+		encoder := json.NewEncoder(&buf)
+		for _, row := range rows {
+			if err := encoder.Encode(row); err != nil {
+				return "", "", fmt.Errorf("failed to encode row: %w", err)
+			}
+		}
+		return buf.String(), "application/jsonl", nil
+	case "csv":
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write(exportColumns); err != nil {
+			return "", "", fmt.Errorf("failed to write header: %w", err)
+		}
+		for _, row := range rows {
+			if err := writer.Write(row.columns()); err != nil {
+				return "", "", fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return "", "", fmt.Errorf("failed to write csv: %w", err)
+		}
+		return buf.String(), "text/csv", nil
+	case "markdown":
+		buf.WriteString("| " + strings.Join(exportColumns, " | ") + " |\n")
+		buf.WriteString(strings.Repeat("| --- ", len(exportColumns)) + "|\n")
+		for _, row := range rows {
+			cells := row.columns()
+			for i, cell := range cells {
+				cell = strings.ReplaceAll(cell, "|", `\|`)
+				cells[i] = strings.Join(strings.Fields(cell), " ")
+			}
+			buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		return buf.String(), "text/markdown", nil
+	default:
+		return "", "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// ExportIssues creates a tool to export issues or pull requests matching a search query as a document.
+func ExportIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("export_issues",
+			mcp.WithDescription(t("TOOL_EXPORT_ISSUES_DESCRIPTION", "Export the issues or pull requests matching a search query as a JSON lines, CSV or Markdown table document, fetching all result pages server-side. Use this instead of paging through search results when building reports.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPORT_ISSUES_USER_TITLE", "Export issues or pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub issues search syntax, e.g. 'label:bug is:open'"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Whether to export issues or pull requests. Defaults to issue."),
+				mcp.Enum("issue", "pr"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Optional repository owner. If provided with repo, only items of this repository are exported."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Optional repository name. If provided with owner, only items of this repository are exported."),
+			),
+			mcp.WithString("format",
+				mcp.Description("Format of the export. Defaults to markdown."),
+				mcp.Enum("jsonl", "csv", "markdown"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("comments", "reactions", "interactions", "created", "updated"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description(fmt.Sprintf("Maximum number of items to export (default %d, max %d)", DefaultExportMaxItems, maxExportItems)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if itemType == "" {
+				itemType = "issue"
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = "markdown"
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", DefaultExportMaxItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxItems < 1 || maxItems > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_items must be between 1 and %d", maxExportItems)), nil
+			}
+
+			if !hasSpecificFilter(query, "is", itemType) {
+				query = fmt.Sprintf("is:%s %s", itemType, query)
+			}
+			if owner != "" && repo != "" && !hasRepoFilter(query) {
+				query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.SearchOptions{
+				Sort:        sort,
+				Order:       order,
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			rows := make([]ExportedIssue, 0)
+			totalCount := 0
+			for len(rows) < maxItems {
+				result, resp, err := client.Search.Issues(ctx, query, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to search issues",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				totalCount = result.GetTotal()
+				for _, issue := range result.Issues {
+					if len(rows) == maxItems {
+						break
+					}
+					rows = append(rows, convertToExportedIssue(issue))
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			document, mimeType, err := renderIssueExport(rows, format)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			summary := fmt.Sprintf("exported %d of %d matching %ss for query %q as %s", len(rows), totalCount, itemType, query, format)
+			if len(rows) < totalCount {
+				summary += "; narrow the query or raise max_items to export the rest"
+			}
+			extension := map[string]string{"jsonl": "jsonl", "csv": "csv", "markdown": "md"}[format]
+			return mcp.NewToolResultResource(summary, mcp.TextResourceContents{
+				URI:      fmt.Sprintf("export://%ss.%s", itemType, extension),
+				MIMEType: mimeType,
+				Text:     document,
+			}), nil
+		}
+}
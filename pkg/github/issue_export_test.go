@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ExportIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "export_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.Contains(t, tool.InputSchema.Properties, "max_items")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	created := &github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	firstPage := &github.IssuesSearchResult{
+		Total: github.Ptr(3),
+		Issues: []*github.Issue{
+			{
+				Number:        github.Ptr(1),
+				Title:         github.Ptr("Crash on start | regression"),
+				State:         github.Ptr("open"),
+				User:          &github.User{Login: github.Ptr("alice")},
+				Assignees:     []*github.User{{Login: github.Ptr("bob")}},
+				Labels:        []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+				Comments:      github.Ptr(2),
+				CreatedAt:     created,
+				UpdatedAt:     created,
+				HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/1"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			},
+			{
+				Number:        github.Ptr(2),
+				Title:         github.Ptr("Typo in docs"),
+				State:         github.Ptr("closed"),
+				User:          &github.User{Login: github.Ptr("carol")},
+				CreatedAt:     created,
+				UpdatedAt:     created,
+				ClosedAt:      created,
+				HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/2"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			},
+		},
+	}
+	secondPage := &github.IssuesSearchResult{
+		Total: github.Ptr(3),
+		Issues: []*github.Issue{
+			{
+				Number:        github.Ptr(3),
+				Title:         github.Ptr("Flaky test"),
+				State:         github.Ptr("open"),
+				User:          &github.User{Login: github.Ptr("alice")},
+				CreatedAt:     created,
+				UpdatedAt:     created,
+				HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/3"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			},
+		},
+	}
+	searchPages := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			mockResponse(t, http.StatusOK, secondPage)(w, r)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/search/issues?page=2>; rel="next"`)
+		mockResponse(t, http.StatusOK, firstPage)(w, r)
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSummary  string
+		expectedMIMEType string
+		expectedDocument string
+	}{
+		{
+			name: "markdown export across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "repo:owner/repo is:issue label:bug", r.URL.Query().Get("q"))
+						assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+						searchPages(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "label:bug",
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedSummary:  `exported 3 of 3 matching issues for query "repo:owner/repo is:issue label:bug" as markdown`,
+			expectedMIMEType: "text/markdown",
+			expectedDocument: "| number | repository | title | state | author | assignees | labels | milestone | comments | created_at | updated_at | closed_at | url |\n" +
+				"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+				`| 1 | owner/repo | Crash on start \| regression | open | alice | bob | bug, p1 |  | 2 | 2024-01-02T03:04:05Z | 2024-01-02T03:04:05Z |  | https://github.com/owner/repo/issues/1 |` + "\n" +
+				"| 2 | owner/repo | Typo in docs | closed | carol |  |  |  | 0 | 2024-01-02T03:04:05Z | 2024-01-02T03:04:05Z | 2024-01-02T03:04:05Z | https://github.com/owner/repo/issues/2 |\n" +
+				"| 3 | owner/repo | Flaky test | open | alice |  |  |  | 0 | 2024-01-02T03:04:05Z | 2024-01-02T03:04:05Z |  | https://github.com/owner/repo/issues/3 |\n",
+		},
+		{
+			name: "csv export limited by max_items",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					searchPages,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":     "label:bug",
+				"format":    "csv",
+				"max_items": float64(1),
+			},
+			expectedSummary:  `exported 1 of 3 matching issues for query "is:issue label:bug" as csv; narrow the query or raise max_items to export the rest`,
+			expectedMIMEType: "text/csv",
+			expectedDocument: "number,repository,title,state,author,assignees,labels,milestone,comments,created_at,updated_at,closed_at,url\n" +
+				"1,owner/repo,Crash on start | regression,open,alice,bob,\"bug, p1\",,2,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z,,https://github.com/owner/repo/issues/1\n",
+		},
+		{
+			name: "jsonl export of merged pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "is:pr is:merged",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+							Total: github.Ptr(1),
+							Issues: []*github.Issue{
+								{
+									Number:           github.Ptr(7),
+									Title:            github.Ptr("Add feature"),
+									State:            github.Ptr("closed"),
+									User:             &github.User{Login: github.Ptr("dave")},
+									HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/7"),
+									RepositoryURL:    github.Ptr("https://api.github.com/repos/owner/repo"),
+									PullRequestLinks: &github.PullRequestLinks{MergedAt: created},
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":  "is:merged",
+				"type":   "pr",
+				"format": "jsonl",
+			},
+			expectedSummary:  `exported 1 of 1 matching prs for query "is:pr is:merged" as jsonl`,
+			expectedMIMEType: "application/jsonl",
+			expectedDocument: `{"number":7,"repository":"owner/repo","title":"Add feature","state":"merged","author":"dave","assignees":[],"labels":[],"comments":0,"created_at":"","updated_at":"","url":"https://github.com/owner/repo/pull/7"}` + "\n",
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "invalid:",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ExportIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+			assert.Equal(t, tc.expectedSummary, result.Content[0].(mcp.TextContent).Text)
+			resource, ok := result.Content[1].(mcp.EmbeddedResource)
+			require.True(t, ok)
+			contents, ok := resource.Resource.(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedMIMEType, contents.MIMEType)
+			assert.Equal(t, tc.expectedDocument, contents.Text)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -127,6 +126,7 @@ type IssueFragment struct {
 // Common interface for all issue query types
 type IssueQueryResult interface {
 	GetIssueFragment() IssueQueryFragment
+	GetRateLimit() RateLimitFragment
 }
 
 type IssueQueryFragment struct {
@@ -145,6 +145,7 @@ type ListIssuesQuery struct {
 	Repository struct {
 		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 // ListIssuesQueryTypeWithLabels is the query structure for fetching issues with optional label filtering.
@@ -152,6 +153,7 @@ type ListIssuesQueryTypeWithLabels struct {
 	Repository struct {
 		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, labels: $labels, states: $states, orderBy: {field: $orderBy, direction: $direction})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 // ListIssuesQueryWithSince is the query structure for fetching issues without label filtering but with since filtering.
@@ -159,6 +161,7 @@ type ListIssuesQueryWithSince struct {
 	Repository struct {
 		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 // ListIssuesQueryTypeWithLabelsWithSince is the query structure for fetching issues with both label and since filtering.
@@ -166,6 +169,7 @@ type ListIssuesQueryTypeWithLabelsWithSince struct {
 	Repository struct {
 		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, labels: $labels, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
+	RateLimit RateLimitFragment
 }
 
 // Implement the interface for all query types
@@ -173,18 +177,34 @@ func (q *ListIssuesQueryTypeWithLabels) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
+func (q *ListIssuesQueryTypeWithLabels) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *ListIssuesQuery) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
+func (q *ListIssuesQuery) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *ListIssuesQueryWithSince) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
+func (q *ListIssuesQueryWithSince) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func (q *ListIssuesQueryTypeWithLabelsWithSince) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
+func (q *ListIssuesQueryTypeWithLabelsWithSince) GetRateLimit() RateLimitFragment {
+	return q.RateLimit
+}
+
 func getIssueQueryType(hasLabels bool, hasSince bool) any {
 	switch {
 	case hasLabels && hasSince:
@@ -323,12 +343,7 @@ func GetIssue(ctx context.Context, client *github.Client, owner string, repo str
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(issue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal issue: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, issue), nil
 }
 
 func GetIssueComments(ctx context.Context, client *github.Client, owner string, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -353,12 +368,7 @@ func GetIssueComments(ctx context.Context, client *github.Client, owner string,
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(comments)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, comments), nil
 }
 
 func GetSubIssues(ctx context.Context, client *github.Client, owner string, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -388,12 +398,7 @@ func GetSubIssues(ctx context.Context, client *github.Client, owner string, repo
 		return mcp.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(subIssues)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssues), nil
 }
 
 func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
@@ -440,12 +445,7 @@ func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string,
 		"totalCount": int(query.Repository.Issue.Labels.TotalCount),
 	}
 
-	out, err := json.Marshal(response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(out)), nil
+	return MarshalledTextResult(ctx, response), nil
 
 }
 
@@ -487,12 +487,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list issue types: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(issueTypes)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issue types: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, issueTypes), nil
 		}
 }
 
@@ -561,12 +556,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create comment: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(createdComment)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, createdComment), nil
 		}
 }
 
@@ -693,12 +683,7 @@ func AddSubIssue(ctx context.Context, client *github.Client, owner string, repo
 		return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 
 }
 
@@ -725,12 +710,7 @@ func RemoveSubIssue(ctx context.Context, client *github.Client, owner string, re
 		return mcp.NewToolResultError(fmt.Sprintf("failed to remove sub-issue: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 }
 
 func ReprioritizeSubIssue(ctx context.Context, client *github.Client, owner string, repo string, issueNumber int, subIssueID int, afterID int, beforeID int) (*mcp.CallToolResult, error) {
@@ -774,12 +754,7 @@ func ReprioritizeSubIssue(ctx context.Context, client *github.Client, owner stri
 		return mcp.NewToolResultError(fmt.Sprintf("failed to reprioritize sub-issue: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(subIssue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, subIssue), nil
 }
 
 // SearchIssues creates a tool to search for issues.
@@ -1031,12 +1006,7 @@ func CreateIssue(ctx context.Context, client *github.Client, owner string, repo
 		URL: issue.GetHTMLURL(),
 	}
 
-	r, err := json.Marshal(minimalResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, minimalResponse), nil
 }
 
 func UpdateIssue(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner string, repo string, issueNumber int, title string, body string, assignees []string, labels []string, milestoneNum int, issueType string, state string, stateReason string, duplicateOf int) (*mcp.CallToolResult, error) {
@@ -1156,18 +1126,13 @@ func UpdateIssue(ctx context.Context, client *github.Client, gqlClient *githubv4
 		URL: updatedIssue.GetHTMLURL(),
 	}
 
-	r, err := json.Marshal(minimalResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, minimalResponse), nil
 }
 
 // ListIssues creates a tool to list and filter repository issues
 func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_issues",
-			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter, and check 'rateLimit' for the cost of each page and the remaining GraphQL budget. Pages too costly to query at once are queried in several smaller queries.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1333,12 +1298,8 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				vars["since"] = githubv4.DateTime{Time: sinceTime}
 			}
 
-			issueQuery := getIssueQueryType(hasLabels, hasSince)
-			if err := client.Query(ctx, issueQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Extract and convert all issue nodes using the common interface
+			// Extract and convert all issue nodes using the common interface. Issues that are too costly to
+			// query at once are queried in smaller pages, whose page info is combined.
 			var issues []*github.Issue
 			var pageInfo struct {
 				HasNextPage     githubv4.Boolean
@@ -1348,13 +1309,34 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			}
 			var totalCount int
 
-			if queryResult, ok := issueQuery.(IssueQueryResult); ok {
-				fragment := queryResult.GetIssueFragment()
-				for _, issue := range fragment.Nodes {
-					issues = append(issues, fragmentToIssue(issue))
-				}
-				pageInfo = fragment.PageInfo
-				totalCount = fragment.TotalCount
+			cost, err := queryGraphQLPages(ctx, client, vars, *paginationParams.First,
+				func() any { return getIssueQueryType(hasLabels, hasSince) },
+				func(issueQuery any) graphQLPage {
+					queryResult, ok := issueQuery.(IssueQueryResult)
+					if !ok {
+						return graphQLPage{}
+					}
+					fragment := queryResult.GetIssueFragment()
+					if issues == nil {
+						pageInfo.HasPreviousPage = fragment.PageInfo.HasPreviousPage
+						pageInfo.StartCursor = fragment.PageInfo.StartCursor
+					}
+					for _, issue := range fragment.Nodes {
+						issues = append(issues, fragmentToIssue(issue))
+					}
+					pageInfo.HasNextPage = fragment.PageInfo.HasNextPage
+					pageInfo.EndCursor = fragment.PageInfo.EndCursor
+					totalCount = fragment.TotalCount
+					return graphQLPage{
+						nodes:       len(fragment.Nodes),
+						hasNextPage: bool(fragment.PageInfo.HasNextPage),
+						endCursor:   fragment.PageInfo.EndCursor,
+						rateLimit:   queryResult.GetRateLimit(),
+					}
+				},
+			)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Create response with issues
@@ -1367,12 +1349,9 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 					"endCursor":       string(pageInfo.EndCursor),
 				},
 				"totalCount": totalCount,
+				"rateLimit":  cost.response(),
 			}
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issues: %w", err)
-			}
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
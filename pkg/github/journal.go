@@ -0,0 +1,291 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// journalMaxArgumentLength is the number of characters of a string argument that is kept in the journal, so
+	// that file contents and long bodies don't blow up its size.
+	journalMaxArgumentLength = 200
+	// journalMaxResultLength is the number of characters of a tool result that is kept in the journal.
+	journalMaxResultLength = 500
+)
+
+// JournalEntry is a recorded tool call.
+type JournalEntry struct {
+	Time      time.Time      `json:"time"`
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	IsError   bool           `json:"is_error"`
+	Result    string         `json:"result"`
+}
+
+// Journal records the calls of tools that change GitHub in a JSON Lines file, so that an agent can find out what it
+// already did after the server or its client restarted, instead of creating the same issue or pull request again.
+// It keeps at most maxEntries entries, dropping the oldest ones.
+type Journal struct {
+	mu         sync.Mutex
+	path       string
+	maxEntries int
+	entries    []JournalEntry
+	// lines is the number of lines in the file, which grows past maxEntries until the file is compacted
+	lines int
+}
+
+// OpenJournal opens the journal at path, creating it if it doesn't exist, and loads its most recent entries.
+// Lines that can't be parsed, such as a line cut off by a crash, are skipped.
+func OpenJournal(path string, maxEntries int) (*Journal, error) {
+	if maxEntries < 1 {
+		return nil, fmt.Errorf("the journal must keep at least 1 entry, got %d", maxEntries)
+	}
+	j := &Journal{path: path, maxEntries: maxEntries}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		j.lines++
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		j.entries = append(j.entries, entry)
+		if len(j.entries) > maxEntries {
+			j.entries = j.entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	if j.lines > maxEntries {
+		if err := j.compact(); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// Record appends an entry to the journal.
+func (j *Journal) Record(entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = append(j.entries, entry)
+	if len(j.entries) > j.maxEntries {
+		j.entries = j.entries[len(j.entries)-j.maxEntries:]
+	}
+
+	// Rewriting the file on every call would be slow, so it may hold up to twice the number of entries
+	if j.lines >= 2*j.maxEntries {
+		return j.compact()
+	}
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	j.lines++
+	return nil
+}
+
+// compact rewrites the file with the entries kept in memory. Callers other than OpenJournal must hold the lock.
+func (j *Journal) compact() error {
+	tmp := j.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	writer := bufio.NewWriter(file)
+	for _, entry := range j.entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to marshal journal entry: %w", err)
+		}
+		_, _ = writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	j.lines = len(j.entries)
+	return nil
+}
+
+// Recent returns up to limit of the most recent entries, newest first, of the given tool if it isn't empty and
+// recorded at or after since.
+func (j *Journal) Recent(limit int, tool string, since time.Time) []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	recent := []JournalEntry{}
+	for i := len(j.entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		entry := j.entries[i]
+		if entry.Time.Before(since) {
+			break
+		}
+		if tool != "" && entry.Tool != tool {
+			continue
+		}
+		recent = append(recent, entry)
+	}
+	return recent
+}
+
+// Middleware returns a tool handler middleware that records the calls of the tools for which record returns true.
+// A call is recorded after it returns, with its result. Failing to record a call doesn't fail the call.
+func (j *Journal) Middleware(record func(tool string) bool, onError func(error)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if !record(request.Params.Name) {
+				return result, err
+			}
+
+			entry := JournalEntry{
+				Time:      time.Now().UTC(),
+				Tool:      request.Params.Name,
+				Arguments: truncateJournalArguments(request.GetArguments()),
+			}
+			switch {
+			case err != nil:
+				entry.IsError = true
+				entry.Result = truncateJournalString(err.Error(), journalMaxResultLength)
+			case result != nil:
+				entry.IsError = result.IsError
+				for _, content := range result.Content {
+					if text, ok := content.(mcp.TextContent); ok {
+						entry.Result = truncateJournalString(text.Text, journalMaxResultLength)
+						break
+					}
+				}
+			}
+			if recordErr := j.Record(entry); recordErr != nil && onError != nil {
+				onError(recordErr)
+			}
+			return result, err
+		}
+	}
+}
+
+// truncateJournalString shortens s to maxLength characters and redacts the GitHub tokens in it, such as those
+// returned by create_installation_token, as they must not be written to disk.
+func truncateJournalString(s string, maxLength int) string {
+	s = mcplog.RedactTokens(s)
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return fmt.Sprintf("%s... (%d characters)", string(runes[:maxLength]), len(runes))
+}
+
+// truncateJournalArguments copies the arguments of a call with long strings shortened, including those nested in
+// objects and arrays, such as the file contents of push_files.
+func truncateJournalArguments(args map[string]any) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+	truncated := make(map[string]any, len(args))
+	for key, value := range args {
+		truncated[key] = truncateJournalValue(value)
+	}
+	return truncated
+}
+
+func truncateJournalValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return truncateJournalString(v, journalMaxArgumentLength)
+	case map[string]any:
+		return truncateJournalArguments(v)
+	case []any:
+		truncated := make([]any, len(v))
+		for i, item := range v {
+			truncated[i] = truncateJournalValue(item)
+		}
+		return truncated
+	default:
+		return value
+	}
+}
+
+// GetRecentOperations creates a tool to list the tool calls recorded in the journal.
+func GetRecentOperations(journal *Journal, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_recent_operations",
+			mcp.WithDescription(t("TOOL_GET_RECENT_OPERATIONS_DESCRIPTION", "List the most recent calls of tools that change GitHub, newest first, with their arguments and results. The journal survives restarts of the server and the client. Check it after a restart or interruption before creating issues, pull requests or other resources, to avoid creating them twice. Long arguments and results are shortened.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RECENT_OPERATIONS_USER_TITLE", "Get recent operations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of operations to return (max 100). Defaults to 20."),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("tool",
+				mcp.Description("Only return calls of this tool, e.g. 'create_issue'"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return calls made at or after this time, in ISO 8601 format (e.g. 2025-01-15T10:00:00Z)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			limit, err := OptionalIntParamWithDefault(request, "limit", 20)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > 100 {
+				return mcp.NewToolResultError("limit must be between 1 and 100"), nil
+			}
+			tool, err := OptionalParam[string](request, "tool")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var since time.Time
+			if sinceParam != "" {
+				since, err = time.Parse(time.RFC3339, sinceParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since time, use ISO 8601 format (e.g. 2025-01-15T10:00:00Z): %v", err)), nil
+				}
+			}
+
+			return MarshalledTextResult(ctx, journal.Recent(limit, tool, since)), nil
+		}
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -88,12 +87,7 @@ func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc)
 				"description": string(query.Repository.Label.Description),
 			}
 
-			out, err := json.Marshal(label)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal label: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, label), nil
 		}
 }
 
@@ -169,12 +163,7 @@ func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				"totalCount": int(query.Repository.Labels.TotalCount),
 			}
 
-			out, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal labels: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(out)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RollupMilestone is an open milestone of a repository with its progress.
+type RollupMilestone struct {
+	Repository   string `json:"repository"`
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	HTMLURL      string `json:"html_url"`
+	DueOn        string `json:"due_on,omitempty"`
+	OpenIssues   int    `json:"open_issues"`
+	ClosedIssues int    `json:"closed_issues"`
+	// CompletionPercent is the share of closed issues and pull requests, 0 for an empty milestone.
+	CompletionPercent float64 `json:"completion_percent"`
+	Overdue           bool    `json:"overdue"`
+}
+
+// RollupMilestoneTitle aggregates the open milestones sharing a title across repositories, such as a release
+// tracked in each repository it ships from.
+type RollupMilestoneTitle struct {
+	Title             string   `json:"title"`
+	Repositories      []string `json:"repositories"`
+	OpenIssues        int      `json:"open_issues"`
+	ClosedIssues      int      `json:"closed_issues"`
+	CompletionPercent float64  `json:"completion_percent"`
+	// EarliestDueOn is the earliest due date of the milestones, omitted when none has one.
+	EarliestDueOn string `json:"earliest_due_on,omitempty"`
+	// Overdue is true when any of the milestones is overdue.
+	Overdue bool `json:"overdue"`
+}
+
+// completionPercent returns the share of closed items, rounded to a tenth of a percent.
+func completionPercent(open, closed int) float64 {
+	if open+closed == 0 {
+		return 0
+	}
+	return math.Round(float64(closed)/float64(open+closed)*1000) / 10
+}
+
+// listOpenMilestones returns all the open milestones of a repository.
+func listOpenMilestones(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State:       "open",
+		Sort:        "due_on",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var milestones []*github.Milestone
+	for {
+		page, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		milestones = append(milestones, page...)
+		if resp.NextPage == 0 {
+			return milestones, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// rollupMilestonesByTitle aggregates milestones by case-insensitive title, in the order of their earliest due
+// date, milestones without a due date last.
+func rollupMilestonesByTitle(milestones []RollupMilestone) []*RollupMilestoneTitle {
+	var titles []*RollupMilestoneTitle
+	byTitle := map[string]*RollupMilestoneTitle{}
+	for _, milestone := range milestones {
+		key := strings.ToLower(milestone.Title)
+		rollup := byTitle[key]
+		if rollup == nil {
+			rollup = &RollupMilestoneTitle{Title: milestone.Title}
+			byTitle[key] = rollup
+			titles = append(titles, rollup)
+		}
+		rollup.Repositories = append(rollup.Repositories, milestone.Repository)
+		rollup.OpenIssues += milestone.OpenIssues
+		rollup.ClosedIssues += milestone.ClosedIssues
+		rollup.Overdue = rollup.Overdue || milestone.Overdue
+		// Milestones come sorted by due date, so the first one with a due date has the earliest
+		if rollup.EarliestDueOn == "" {
+			rollup.EarliestDueOn = milestone.DueOn
+		}
+	}
+	for _, rollup := range titles {
+		rollup.CompletionPercent = completionPercent(rollup.OpenIssues, rollup.ClosedIssues)
+	}
+	sort.SliceStable(titles, func(i, j int) bool {
+		return dueBefore(titles[i].EarliestDueOn, titles[j].EarliestDueOn)
+	})
+	return titles
+}
+
+// dueBefore orders RFC 3339 due dates, empty ones last.
+func dueBefore(a, b string) bool {
+	if a == "" || b == "" {
+		return a != "" && b == ""
+	}
+	return a < b
+}
+
+// GetMilestoneRollup creates a tool to report the progress of the open milestones of many repositories.
+func GetMilestoneRollup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone_rollup",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_ROLLUP_DESCRIPTION", "Get a roadmap snapshot of the open milestones across repositories of an owner: each milestone with its open and closed issue counts, completion percentage, due date and whether it is overdue, soonest due first, and the milestones aggregated by title across repositories. Archived repositories are skipped. Pagination applies to the organization's repositories when 'repositories' is not provided.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_ROLLUP_USER_TITLE", "Get milestone rollup"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization or user owning the repositories"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to report on. Defaults to the repositories of the owner, which must be an organization."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			names, hasNextPage, resp, err := listOrgRepositoryNames(ctx, client, owner, repos, pagination)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", owner),
+					resp,
+					err,
+				), nil
+			}
+
+			repoMilestones := make([][]*github.Milestone, len(names))
+			errs := fanOut(ctx, names, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				var err error
+				repoMilestones[i], err = listOpenMilestones(ctx, client, owner, repo)
+				return err
+			})
+
+			now := time.Now()
+			milestones := []RollupMilestone{}
+			overdue := 0
+			var failures []map[string]string
+			for i, repo := range names {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"repository": repo, "error": errs[i].Error()})
+					continue
+				}
+				for _, milestone := range repoMilestones[i] {
+					rollup := RollupMilestone{
+						Repository:        repo,
+						Number:            milestone.GetNumber(),
+						Title:             milestone.GetTitle(),
+						HTMLURL:           milestone.GetHTMLURL(),
+						DueOn:             formatOptionalTimestamp(milestone.DueOn),
+						OpenIssues:        milestone.GetOpenIssues(),
+						ClosedIssues:      milestone.GetClosedIssues(),
+						CompletionPercent: completionPercent(milestone.GetOpenIssues(), milestone.GetClosedIssues()),
+						Overdue:           milestone.DueOn != nil && milestone.DueOn.Before(now),
+					}
+					if rollup.Overdue {
+						overdue++
+					}
+					milestones = append(milestones, rollup)
+				}
+			}
+			sort.SliceStable(milestones, func(i, j int) bool {
+				if milestones[i].DueOn != milestones[j].DueOn {
+					return dueBefore(milestones[i].DueOn, milestones[j].DueOn)
+				}
+				return milestones[i].Repository < milestones[j].Repository
+			})
+
+			response := map[string]any{
+				"owner":        owner,
+				"repositories": len(names),
+				"summary": map[string]int{
+					"milestones": len(milestones),
+					"overdue":    overdue,
+				},
+				"milestones":  milestones,
+				"by_title":    rollupMilestonesByTitle(milestones),
+				"hasNextPage": hasNextPage,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
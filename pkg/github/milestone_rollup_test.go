@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMilestoneRollup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestoneRollup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_milestone_rollup", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	past := &github.Timestamp{Time: time.Date(2020, 1, 31, 8, 0, 0, 0, time.UTC)}
+	future := &github.Timestamp{Time: time.Date(2099, 6, 30, 7, 0, 0, 0, time.UTC)}
+	milestonesByRepo := map[string][]*github.Milestone{
+		"web": {
+			{Number: github.Ptr(1), Title: github.Ptr("v1.0"), HTMLURL: github.Ptr("https://github.com/acme/web/milestone/1"), DueOn: past, OpenIssues: github.Ptr(1), ClosedIssues: github.Ptr(3)},
+			{Number: github.Ptr(2), Title: github.Ptr("v2.0"), DueOn: future, OpenIssues: github.Ptr(2), ClosedIssues: github.Ptr(1)},
+		},
+		"api": {
+			{Number: github.Ptr(5), Title: github.Ptr("V2.0"), DueOn: future, OpenIssues: github.Ptr(0), ClosedIssues: github.Ptr(2)},
+			{Number: github.Ptr(6), Title: github.Ptr("Backlog"), OpenIssues: github.Ptr(0), ClosedIssues: github.Ptr(0)},
+		},
+	}
+	milestonesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		for repo, milestones := range milestonesByRepo {
+			if r.URL.Path == "/repos/acme/"+repo+"/milestones" {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(mock.MustMarshal(milestones))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	expectedMilestones := []RollupMilestone{
+		{Repository: "web", Number: 1, Title: "v1.0", HTMLURL: "https://github.com/acme/web/milestone/1", DueOn: "2020-01-31T08:00:00Z", OpenIssues: 1, ClosedIssues: 3, CompletionPercent: 75, Overdue: true},
+		{Repository: "api", Number: 5, Title: "V2.0", DueOn: "2099-06-30T07:00:00Z", ClosedIssues: 2, CompletionPercent: 100},
+		{Repository: "web", Number: 2, Title: "v2.0", DueOn: "2099-06-30T07:00:00Z", OpenIssues: 2, ClosedIssues: 1, CompletionPercent: 33.3},
+		{Repository: "api", Number: 6, Title: "Backlog"},
+	}
+	expectedTitles := []RollupMilestoneTitle{
+		{Title: "v1.0", Repositories: []string{"web"}, OpenIssues: 1, ClosedIssues: 3, CompletionPercent: 75, EarliestDueOn: "2020-01-31T08:00:00Z", Overdue: true},
+		{Title: "V2.0", Repositories: []string{"api", "web"}, OpenIssues: 2, ClosedIssues: 3, CompletionPercent: 60, EarliestDueOn: "2099-06-30T07:00:00Z"},
+		{Title: "Backlog", Repositories: []string{"api"}},
+	}
+
+	type rollupResponse struct {
+		Repositories int                    `json:"repositories"`
+		Summary      map[string]int         `json:"summary"`
+		Milestones   []RollupMilestone      `json:"milestones"`
+		ByTitle      []RollupMilestoneTitle `json:"by_title"`
+		HasNextPage  bool                   `json:"hasNextPage"`
+		Errors       []map[string]string    `json:"errors"`
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse rollupResponse
+	}{
+		{
+			name: "organization repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					[]*github.Repository{
+						{Name: github.Ptr("api")},
+						{Name: github.Ptr("legacy"), Archived: github.Ptr(true)},
+						{Name: github.Ptr("web")},
+					},
+				),
+				mock.WithRequestMatchHandler(mock.GetReposMilestonesByOwnerByRepo, milestonesHandler),
+			),
+			requestArgs: map[string]any{"owner": "acme"},
+			expectedResponse: rollupResponse{
+				Repositories: 2,
+				Summary:      map[string]int{"milestones": 4, "overdue": 1},
+				Milestones:   expectedMilestones,
+				ByTitle:      expectedTitles,
+			},
+		},
+		{
+			name: "given repositories with a failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposMilestonesByOwnerByRepo, milestonesHandler),
+			),
+			requestArgs: map[string]any{"owner": "acme", "repositories": []any{"web", "missing"}},
+			expectedResponse: rollupResponse{
+				Repositories: 2,
+				Summary:      map[string]int{"milestones": 2, "overdue": 1},
+				Milestones:   []RollupMilestone{expectedMilestones[0], expectedMilestones[2]},
+				ByTitle: []RollupMilestoneTitle{
+					expectedTitles[0],
+					{Title: "v2.0", Repositories: []string{"web"}, OpenIssues: 2, ClosedIssues: 1, CompletionPercent: 33.3, EarliestDueOn: "2099-06-30T07:00:00Z"},
+				},
+				Errors: []map[string]string{{"repository": "missing"}},
+			},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "ghost"},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories for organization 'ghost'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMilestoneRollup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response rollupResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			if len(tc.expectedResponse.Errors) > 0 {
+				require.Len(t, response.Errors, len(tc.expectedResponse.Errors))
+				for i, failure := range tc.expectedResponse.Errors {
+					assert.Equal(t, failure["repository"], response.Errors[i]["repository"])
+					assert.Contains(t, response.Errors[i]["error"], "404")
+				}
+				response.Errors, tc.expectedResponse.Errors = nil, nil
+			}
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package github
 
-import "github.com/google/go-github/v74/github"
+import (
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
 
 // MinimalUser is the output type for user and organization search results.
 type MinimalUser struct {
@@ -31,6 +35,7 @@ type MinimalRepository struct {
 	OpenIssues    int      `json:"open_issues_count"`
 	UpdatedAt     string   `json:"updated_at,omitempty"`
 	CreatedAt     string   `json:"created_at,omitempty"`
+	PushedAt      string   `json:"pushed_at,omitempty"`
 	Topics        []string `json:"topics,omitempty"`
 	Private       bool     `json:"private"`
 	Fork          bool     `json:"fork"`
@@ -75,15 +80,26 @@ type MinimalCommitFile struct {
 	Changes   int    `json:"changes,omitempty"`
 }
 
+// MinimalCommitVerification represents the signature verification status of a commit.
+type MinimalCommitVerification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason"`
+	// SignatureType is "gpg", "ssh" or "x509", and empty for unsigned commits.
+	SignatureType string `json:"signature_type,omitempty"`
+	// Signer is the login of the committer, whose key GitHub verified the signature with.
+	Signer string `json:"signer,omitempty"`
+}
+
 // MinimalCommit is the trimmed output type for commit objects.
 type MinimalCommit struct {
-	SHA       string              `json:"sha"`
-	HTMLURL   string              `json:"html_url"`
-	Commit    *MinimalCommitInfo  `json:"commit,omitempty"`
-	Author    *MinimalUser        `json:"author,omitempty"`
-	Committer *MinimalUser        `json:"committer,omitempty"`
-	Stats     *MinimalCommitStats `json:"stats,omitempty"`
-	Files     []MinimalCommitFile `json:"files,omitempty"`
+	SHA          string                     `json:"sha"`
+	HTMLURL      string                     `json:"html_url"`
+	Commit       *MinimalCommitInfo         `json:"commit,omitempty"`
+	Author       *MinimalUser               `json:"author,omitempty"`
+	Committer    *MinimalUser               `json:"committer,omitempty"`
+	Verification *MinimalCommitVerification `json:"verification,omitempty"`
+	Stats        *MinimalCommitStats        `json:"stats,omitempty"`
+	Files        []MinimalCommitFile        `json:"files,omitempty"`
 }
 
 // MinimalRelease is the trimmed output type for release objects.
@@ -106,6 +122,20 @@ type MinimalBranch struct {
 	Protected bool   `json:"protected"`
 }
 
+// MinimalWorkflowRun is the trimmed output type for workflow run objects.
+type MinimalWorkflowRun struct {
+	ID             int64        `json:"id"`
+	Name           string       `json:"name,omitempty"`
+	Event          string       `json:"event,omitempty"`
+	Status         string       `json:"status,omitempty"`
+	HeadBranch     string       `json:"head_branch,omitempty"`
+	HeadSHA        string       `json:"head_sha,omitempty"`
+	HeadRepository string       `json:"head_repository,omitempty"`
+	Actor          *MinimalUser `json:"actor,omitempty"`
+	HTMLURL        string       `json:"html_url"`
+	CreatedAt      string       `json:"created_at,omitempty"`
+}
+
 // MinimalResponse represents a minimal response for all CRUD operations.
 // Success is implicit in the HTTP response status, and all other information
 // can be derived from the URL or fetched separately if needed.
@@ -163,10 +193,10 @@ func convertToMinimalProject(fullProject *github.ProjectV2) *MinimalProject {
 		Title:            github.Ptr(fullProject.GetTitle()),
 		Description:      github.Ptr(fullProject.GetDescription()),
 		Public:           github.Ptr(fullProject.GetPublic()),
-		ClosedAt:         github.Ptr(fullProject.GetClosedAt()),
-		CreatedAt:        github.Ptr(fullProject.GetCreatedAt()),
-		UpdatedAt:        github.Ptr(fullProject.GetUpdatedAt()),
-		DeletedAt:        github.Ptr(fullProject.GetDeletedAt()),
+		ClosedAt:         fullProject.ClosedAt,
+		CreatedAt:        fullProject.CreatedAt,
+		UpdatedAt:        fullProject.UpdatedAt,
+		DeletedAt:        fullProject.DeletedAt,
 		Number:           github.Ptr(fullProject.GetNumber()),
 		ShortDescription: github.Ptr(fullProject.GetShortDescription()),
 		DeletedBy:        convertToMinimalUser(fullProject.GetDeletedBy()),
@@ -210,63 +240,35 @@ func convertToMinimalProjectItem(item *projectV2Item) *MinimalProjectItem {
 }
 
 // convertToMinimalCommit converts a GitHub API RepositoryCommit to MinimalCommit
-func convertToMinimalCommit(commit *github.RepositoryCommit, includeDiffs bool) MinimalCommit {
+func convertToMinimalCommit(commit *github.RepositoryCommit, includeDiffs, includeVerification bool) MinimalCommit {
 	minimalCommit := MinimalCommit{
 		SHA:     commit.GetSHA(),
 		HTMLURL: commit.GetHTMLURL(),
 	}
 
-	if commit.Commit != nil {
+	if gitCommit := commit.GetCommit(); gitCommit != nil {
 		minimalCommit.Commit = &MinimalCommitInfo{
-			Message: commit.Commit.GetMessage(),
-		}
-
-		if commit.Commit.Author != nil {
-			minimalCommit.Commit.Author = &MinimalCommitAuthor{
-				Name:  commit.Commit.Author.GetName(),
-				Email: commit.Commit.Author.GetEmail(),
-			}
-			if commit.Commit.Author.Date != nil {
-				minimalCommit.Commit.Author.Date = commit.Commit.Author.Date.Format("2006-01-02T15:04:05Z")
-			}
-		}
-
-		if commit.Commit.Committer != nil {
-			minimalCommit.Commit.Committer = &MinimalCommitAuthor{
-				Name:  commit.Commit.Committer.GetName(),
-				Email: commit.Commit.Committer.GetEmail(),
-			}
-			if commit.Commit.Committer.Date != nil {
-				minimalCommit.Commit.Committer.Date = commit.Commit.Committer.Date.Format("2006-01-02T15:04:05Z")
-			}
+			Message:   gitCommit.GetMessage(),
+			Author:    convertToMinimalCommitAuthor(gitCommit.GetAuthor()),
+			Committer: convertToMinimalCommitAuthor(gitCommit.GetCommitter()),
 		}
 	}
 
-	if commit.Author != nil {
-		minimalCommit.Author = &MinimalUser{
-			Login:      commit.Author.GetLogin(),
-			ID:         commit.Author.GetID(),
-			ProfileURL: commit.Author.GetHTMLURL(),
-			AvatarURL:  commit.Author.GetAvatarURL(),
-		}
-	}
+	// The author and committer are nil when their email isn't linked to a GitHub account
+	minimalCommit.Author = convertToMinimalUser(commit.GetAuthor())
+	minimalCommit.Committer = convertToMinimalUser(commit.GetCommitter())
 
-	if commit.Committer != nil {
-		minimalCommit.Committer = &MinimalUser{
-			Login:      commit.Committer.GetLogin(),
-			ID:         commit.Committer.GetID(),
-			ProfileURL: commit.Committer.GetHTMLURL(),
-			AvatarURL:  commit.Committer.GetAvatarURL(),
-		}
+	if includeVerification {
+		minimalCommit.Verification = convertToMinimalCommitVerification(commit)
 	}
 
 	// Only include stats and files if includeDiffs is true
-	if includeDiffs {
-		if commit.Stats != nil {
+	if includeDiffs && commit != nil {
+		if stats := commit.Stats; stats != nil {
 			minimalCommit.Stats = &MinimalCommitStats{
-				Additions: commit.Stats.GetAdditions(),
-				Deletions: commit.Stats.GetDeletions(),
-				Total:     commit.Stats.GetTotal(),
+				Additions: stats.GetAdditions(),
+				Deletions: stats.GetDeletions(),
+				Total:     stats.GetTotal(),
 			}
 		}
 
@@ -288,6 +290,51 @@ func convertToMinimalCommit(commit *github.RepositoryCommit, includeDiffs bool)
 	return minimalCommit
 }
 
+// convertToMinimalCommitAuthor converts the git author or committer of a commit to MinimalCommitAuthor
+func convertToMinimalCommitAuthor(author *github.CommitAuthor) *MinimalCommitAuthor {
+	if author == nil {
+		return nil
+	}
+
+	minimalAuthor := &MinimalCommitAuthor{
+		Name:  author.GetName(),
+		Email: author.GetEmail(),
+	}
+	if author.Date != nil {
+		minimalAuthor.Date = author.Date.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalAuthor
+}
+
+// convertToMinimalCommitVerification extracts the signature verification status of a commit. Commits without
+// verification data are reported as unsigned.
+func convertToMinimalCommitVerification(commit *github.RepositoryCommit) *MinimalCommitVerification {
+	verification := commit.GetCommit().GetVerification()
+	minimalVerification := &MinimalCommitVerification{
+		Verified: verification.GetVerified(),
+		Reason:   verification.GetReason(),
+	}
+	if minimalVerification.Reason == "" {
+		minimalVerification.Reason = "unsigned"
+	}
+
+	signature := verification.GetSignature()
+	switch {
+	case strings.HasPrefix(signature, "-----BEGIN PGP SIGNATURE-----"):
+		minimalVerification.SignatureType = "gpg"
+	case strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----"):
+		minimalVerification.SignatureType = "ssh"
+	case strings.HasPrefix(signature, "-----BEGIN SIGNED MESSAGE-----"):
+		minimalVerification.SignatureType = "x509"
+	}
+
+	if minimalVerification.Verified {
+		minimalVerification.Signer = commit.GetCommitter().GetLogin()
+	}
+
+	return minimalVerification
+}
+
 // convertToMinimalBranch converts a GitHub API Branch to MinimalBranch
 func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 	return MinimalBranch{
@@ -296,3 +343,60 @@ func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 		Protected: branch.GetProtected(),
 	}
 }
+
+// convertToMinimalWorkflowRun converts a GitHub API WorkflowRun to MinimalWorkflowRun
+func convertToMinimalWorkflowRun(run *github.WorkflowRun) MinimalWorkflowRun {
+	if run == nil {
+		return MinimalWorkflowRun{}
+	}
+
+	return MinimalWorkflowRun{
+		ID:             run.GetID(),
+		Name:           run.GetName(),
+		Event:          run.GetEvent(),
+		Status:         run.GetStatus(),
+		HeadBranch:     run.GetHeadBranch(),
+		HeadSHA:        run.GetHeadSHA(),
+		HeadRepository: run.GetHeadRepository().GetFullName(),
+		Actor:          convertToMinimalUser(run.GetActor()),
+		HTMLURL:        run.GetHTMLURL(),
+		CreatedAt:      formatOptionalTimestamp(run.CreatedAt),
+	}
+}
+
+// convertToMinimalRepository converts a GitHub API Repository to MinimalRepository
+func convertToMinimalRepository(repo *github.Repository) MinimalRepository {
+	minimalRepo := MinimalRepository{
+		ID:            repo.GetID(),
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		HTMLURL:       repo.GetHTMLURL(),
+		Language:      repo.GetLanguage(),
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		OpenIssues:    repo.GetOpenIssuesCount(),
+		Private:       repo.GetPrivate(),
+		Fork:          repo.GetFork(),
+		Archived:      repo.GetArchived(),
+		DefaultBranch: repo.GetDefaultBranch(),
+	}
+
+	if repo == nil {
+		return minimalRepo
+	}
+	if repo.UpdatedAt != nil {
+		minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.CreatedAt != nil {
+		minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.PushedAt != nil {
+		minimalRepo.PushedAt = repo.PushedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.Topics != nil {
+		minimalRepo.Topics = repo.Topics
+	}
+
+	return minimalRepo
+}
@@ -0,0 +1,259 @@
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadAPIPayload decodes a recorded API response from testdata/api the way go-github does, so fields that were
+// added upstream after the go-github release are ignored rather than rejected.
+func loadAPIPayload[T any](t *testing.T, name string) *T {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "api", name+".json"))
+	require.NoError(t, err)
+
+	var v T
+	require.NoError(t, json.Unmarshal(data, &v))
+	return &v
+}
+
+func mustTimestamp(t *testing.T, value string) *github.Timestamp {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+	return &github.Timestamp{Time: parsed}
+}
+
+func Test_ConvertToMinimalCommit_Contract(t *testing.T) {
+	tests := []struct {
+		name                string
+		payload             string
+		includeDiffs        bool
+		includeVerification bool
+		expected            MinimalCommit
+	}{
+		{
+			name:                "commit with linked users, diff and signature",
+			payload:             "commit",
+			includeDiffs:        true,
+			includeVerification: true,
+			expected: MinimalCommit{
+				SHA:     "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				HTMLURL: "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Commit: &MinimalCommitInfo{
+					Message:   "Fix all the bugs",
+					Author:    &MinimalCommitAuthor{Name: "Monalisa Octocat", Email: "support@github.com", Date: "2011-04-14T16:00:49Z"},
+					Committer: &MinimalCommitAuthor{Name: "GitHub", Email: "noreply@github.com", Date: "2011-04-14T16:00:49Z"},
+				},
+				Author: &MinimalUser{
+					Login:      "octocat",
+					ID:         1,
+					ProfileURL: "https://github.com/octocat",
+					AvatarURL:  "https://github.com/images/error/octocat_happy.gif",
+				},
+				Committer: &MinimalUser{
+					Login:      "web-flow",
+					ID:         19864447,
+					ProfileURL: "https://github.com/web-flow",
+					AvatarURL:  "https://avatars.githubusercontent.com/u/19864447?v=4",
+				},
+				Verification: &MinimalCommitVerification{Verified: true, Reason: "valid", SignatureType: "gpg", Signer: "web-flow"},
+				Stats:        &MinimalCommitStats{Additions: 104, Deletions: 4, Total: 108},
+				Files: []MinimalCommitFile{
+					{Filename: "file1.txt", Status: "added", Additions: 103, Deletions: 21, Changes: 124},
+				},
+			},
+		},
+		{
+			name:    "diff is left out unless requested",
+			payload: "commit",
+			expected: MinimalCommit{
+				SHA:     "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				HTMLURL: "https://github.com/octocat/Hello-World/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Commit: &MinimalCommitInfo{
+					Message:   "Fix all the bugs",
+					Author:    &MinimalCommitAuthor{Name: "Monalisa Octocat", Email: "support@github.com", Date: "2011-04-14T16:00:49Z"},
+					Committer: &MinimalCommitAuthor{Name: "GitHub", Email: "noreply@github.com", Date: "2011-04-14T16:00:49Z"},
+				},
+				Author: &MinimalUser{
+					Login:      "octocat",
+					ID:         1,
+					ProfileURL: "https://github.com/octocat",
+					AvatarURL:  "https://github.com/images/error/octocat_happy.gif",
+				},
+				Committer: &MinimalUser{
+					Login:      "web-flow",
+					ID:         19864447,
+					ProfileURL: "https://github.com/web-flow",
+					AvatarURL:  "https://avatars.githubusercontent.com/u/19864447?v=4",
+				},
+			},
+		},
+		{
+			name:                "unsigned commit by emails without GitHub accounts",
+			payload:             "commit_unlinked_author",
+			includeDiffs:        true,
+			includeVerification: true,
+			expected: MinimalCommit{
+				SHA:     "7638417db6d59f3c431d3e1f261cc637155684cd",
+				HTMLURL: "https://github.com/octocat/Hello-World/commit/7638417db6d59f3c431d3e1f261cc637155684cd",
+				Commit: &MinimalCommitInfo{
+					Message:   "Bump version",
+					Author:    &MinimalCommitAuthor{Name: "Build Bot", Email: "build@example.com", Date: "2024-02-01T09:30:00Z"},
+					Committer: &MinimalCommitAuthor{Name: "Build Bot", Email: "build@example.com", Date: "2024-02-01T09:30:00Z"},
+				},
+				Verification: &MinimalCommitVerification{Verified: false, Reason: "unsigned"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commit := loadAPIPayload[github.RepositoryCommit](t, tc.payload)
+			assert.Equal(t, tc.expected, convertToMinimalCommit(commit, tc.includeDiffs, tc.includeVerification))
+		})
+	}
+}
+
+func Test_ConvertToMinimalBranch_Contract(t *testing.T) {
+	branch := loadAPIPayload[github.Branch](t, "branch")
+	assert.Equal(t, MinimalBranch{
+		Name:      "main",
+		SHA:       "c5b97d5ae6c19d5c5df71a34c7fbeeda2479ccbc",
+		Protected: true,
+	}, convertToMinimalBranch(branch))
+}
+
+func Test_ConvertToMinimalRepository_Contract(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected MinimalRepository
+	}{
+		{
+			name:    "repository with every field set",
+			payload: "repository",
+			expected: MinimalRepository{
+				ID:            1296269,
+				Name:          "Hello-World",
+				FullName:      "octocat/Hello-World",
+				Description:   "This your first repo!",
+				HTMLURL:       "https://github.com/octocat/Hello-World",
+				Language:      "Go",
+				Stars:         80,
+				Forks:         9,
+				OpenIssues:    3,
+				UpdatedAt:     "2011-01-26T19:14:43Z",
+				CreatedAt:     "2011-01-26T19:01:12Z",
+				PushedAt:      "2011-01-26T19:06:43Z",
+				Topics:        []string{"octocat", "atom", "electron", "api"},
+				DefaultBranch: "master",
+			},
+		},
+		{
+			name:    "repository with null and missing fields",
+			payload: "repository_sparse",
+			expected: MinimalRepository{
+				ID:       2,
+				Name:     "empty",
+				FullName: "octocat/empty",
+				HTMLURL:  "https://github.com/octocat/empty",
+				Private:  true,
+				Fork:     true,
+				Archived: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := loadAPIPayload[github.Repository](t, tc.payload)
+			assert.Equal(t, tc.expected, convertToMinimalRepository(repo))
+		})
+	}
+}
+
+func Test_ConvertToMinimalProject_Contract(t *testing.T) {
+	project := loadAPIPayload[github.ProjectV2](t, "project")
+	octocat := &MinimalUser{
+		Login:      "octocat",
+		ID:         1,
+		ProfileURL: "https://github.com/octocat",
+		AvatarURL:  "https://github.com/images/error/octocat_happy.gif",
+	}
+
+	assert.Equal(t, &MinimalProject{
+		ID:               github.Ptr(int64(2)),
+		NodeID:           github.Ptr("MDc6UHJvamVjdDEwMDI2MDM="),
+		Owner:            octocat,
+		Creator:          octocat,
+		Title:            github.Ptr("My Projects"),
+		Description:      github.Ptr("A board to manage my personal projects."),
+		Public:           github.Ptr(true),
+		CreatedAt:        mustTimestamp(t, "2011-04-10T20:09:31Z"),
+		UpdatedAt:        mustTimestamp(t, "2014-03-03T18:58:10Z"),
+		Number:           github.Ptr(2),
+		ShortDescription: github.Ptr(""),
+	}, convertToMinimalProject(project))
+
+	// An open project must not report a closed or deleted date
+	data, err := json.Marshal(convertToMinimalProject(project))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "closed_at")
+	assert.NotContains(t, string(data), "deleted_at")
+	assert.NotContains(t, string(data), "deleted_by")
+}
+
+func Test_ConvertToMinimalProjectItem_Contract(t *testing.T) {
+	item := loadAPIPayload[projectV2Item](t, "project_item")
+	minimalItem := convertToMinimalProjectItem(item)
+	require.NotNil(t, minimalItem)
+
+	assert.Equal(t, github.Ptr(int64(13)), minimalItem.ID)
+	assert.Equal(t, github.Ptr("PVTI_lAAFAQ0"), minimalItem.NodeID)
+	assert.Equal(t, github.Ptr("Issue"), minimalItem.ContentType)
+	assert.Equal(t, github.Ptr("https://api.github.com/users/octocat/projectsV2/2/items/13"), minimalItem.ItemURL)
+	assert.Equal(t, mustTimestamp(t, "2022-04-28T12:00:00Z"), minimalItem.CreatedAt)
+	assert.Nil(t, minimalItem.Creator)
+	assert.Nil(t, minimalItem.ArchivedAt)
+
+	require.Len(t, minimalItem.Fields, 3)
+	assert.Equal(t, "Status", minimalItem.Fields[1].Name)
+	assert.Equal(t, "single_select", minimalItem.Fields[1].DataType)
+	assert.NotNil(t, minimalItem.Fields[1].Value)
+	assert.Nil(t, minimalItem.Fields[2].Value)
+}
+
+func Test_MinimalConverters_NilInputs(t *testing.T) {
+	// Converters are called on elements of API responses, which can be nil or empty objects. None of them may panic.
+	assert.NotPanics(t, func() {
+		assert.Equal(t, MinimalCommit{}, convertToMinimalCommit(nil, true, false))
+		assert.Equal(t, MinimalCommit{Commit: &MinimalCommitInfo{}, Files: []MinimalCommitFile{{}}}, convertToMinimalCommit(&github.RepositoryCommit{
+			Commit: &github.Commit{},
+			Files:  []*github.CommitFile{nil},
+		}, true, false))
+		assert.Equal(t, &MinimalCommitVerification{Reason: "unsigned"}, convertToMinimalCommit(nil, false, true).Verification)
+
+		assert.Equal(t, MinimalBranch{}, convertToMinimalBranch(nil))
+		assert.Equal(t, MinimalBranch{Name: "main"}, convertToMinimalBranch(&github.Branch{Name: github.Ptr("main")}))
+
+		assert.Equal(t, MinimalRepository{}, convertToMinimalRepository(nil))
+		assert.Equal(t, MinimalRepository{}, convertToMinimalRepository(&github.Repository{}))
+
+		assert.Nil(t, convertToMinimalProject(nil))
+		assert.NotNil(t, convertToMinimalProject(&github.ProjectV2{}))
+
+		assert.Nil(t, convertToMinimalProjectItem(nil))
+		assert.NotNil(t, convertToMinimalProjectItem(&projectV2Item{}))
+
+		assert.Nil(t, convertToMinimalUser(nil))
+		assert.Equal(t, MinimalWorkflowRun{}, convertToMinimalWorkflowRun(nil))
+	})
+}
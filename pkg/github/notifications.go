@@ -2,10 +2,10 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -135,16 +135,44 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil
 			}
 
-			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+			// Without owner and repo, the notifications of every repository are listed
+			notifications = slices.DeleteFunc(notifications, func(notification *github.Notification) bool {
+				return !threadAllowed(ctx, notification)
+			})
 
-			return mcp.NewToolResultText(string(r)), nil
+			// Marshal response to JSON
+			return MarshalledTextResult(ctx, notifications), nil
 		}
 }
 
+// threadAllowed reports whether the owner policy of a tool call, if any, allows the repository of a notification
+// thread.
+func threadAllowed(ctx context.Context, thread *github.Notification) bool {
+	repository := thread.GetRepository()
+	return repositoryAllowed(ctx, repository.GetOwner().GetLogin(), repository.GetName())
+}
+
+// checkThreadAllowed returns an error result if the owner policy of a tool call doesn't allow the repository of a
+// notification thread. The thread is only fetched when the call has a policy.
+func checkThreadAllowed(ctx context.Context, client *github.Client, threadID string) *mcp.CallToolResult {
+	if ownerPolicy(ctx) == nil {
+		return nil
+	}
+	thread, resp, err := client.Activity.GetThread(ctx, threadID)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get notification details for ID '%s'", threadID),
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+	if !threadAllowed(ctx, thread) {
+		return mcp.NewToolResultError(fmt.Sprintf("access to %s is not allowed by the configuration of this server", thread.GetRepository().GetFullName()))
+	}
+	return nil
+}
+
 // DismissNotification creates a tool to mark a notification as read/done.
 func DismissNotification(getclient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("dismiss_notification",
@@ -175,6 +203,10 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			if result := checkThreadAllowed(ctx, client, threadID); result != nil {
+				return result, nil
+			}
+
 			var resp *github.Response
 			switch state {
 			case "done":
@@ -249,6 +281,10 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			// Without owner and repo, the notifications of every repository would be marked as read
+			if ownerPolicy(ctx) != nil && (owner == "" || repo == "") {
+				return mcp.NewToolResultError("owner and repo are required to mark notifications as read on this server"), nil
+			}
 
 			var lastReadTime time.Time
 			if lastReadAt != "" {
@@ -332,13 +368,11 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil
 			}
-
-			r, err := json.Marshal(thread)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if !threadAllowed(ctx, thread) {
+				return mcp.NewToolResultError(fmt.Sprintf("access to %s is not allowed by the configuration of this server", thread.GetRepository().GetFullName())), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, thread), nil
 		}
 }
 
@@ -382,6 +416,10 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			if result := checkThreadAllowed(ctx, client, notificationID); result != nil {
+				return result, nil
+			}
+
 			var (
 				resp   *github.Response
 				result any
@@ -420,11 +458,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				return mcp.NewToolResultText("Notification subscription deleted"), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -516,10 +550,140 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 				return mcp.NewToolResultText("Repository subscription deleted"), nil
 			}
 
-			r, err := json.Marshal(result)
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// Repository subscription states reported by get_repository_subscription
+const (
+	RepositorySubscriptionStateWatching    = "watching"
+	RepositorySubscriptionStateIgnoring    = "ignoring"
+	RepositorySubscriptionStateNotWatching = "not_watching"
+)
+
+// RepositorySubscription is the subscription of the authenticated user to the notifications of a repository.
+type RepositorySubscription struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	// State is "watching" when the user gets all notifications of the repository, "ignoring" when they get none,
+	// and "not_watching" when they only get notifications of the conversations they participate in.
+	State     string `json:"state"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// GetRepositorySubscription creates a tool to get the notification subscription of the authenticated user for a repository.
+func GetRepositorySubscription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_subscription",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_SUBSCRIPTION_DESCRIPTION", "Get whether the authenticated user is watching a repository: 'watching' gets all its notifications, 'ignoring' gets none, and 'not_watching' only gets notifications of conversations the user participates in or is mentioned in. Use manage_repository_notification_subscription to change it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_SUBSCRIPTION_USER_TITLE", "Get repository subscription"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The subscription is nil, without an error, when the user isn't watching the repository
+			sub, resp, err := client.Activity.GetRepositorySubscription(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository subscription for %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			subscription := RepositorySubscription{
+				Owner: owner,
+				Repo:  repo,
+				State: RepositorySubscriptionStateNotWatching,
+			}
+			if sub != nil {
+				switch {
+				case sub.GetIgnored():
+					subscription.State = RepositorySubscriptionStateIgnoring
+				case sub.GetSubscribed():
+					subscription.State = RepositorySubscriptionStateWatching
+				}
+				subscription.Reason = sub.GetReason()
+				subscription.CreatedAt = formatOptionalTimestamp(sub.CreatedAt)
+			}
+			return MarshalledTextResult(ctx, subscription), nil
+		}
+}
+
+// ListWatchedRepositories creates a tool to list the repositories watched by the authenticated user or a specified user.
+func ListWatchedRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watched_repositories",
+			mcp.WithDescription(t("TOOL_LIST_WATCHED_REPOSITORIES_DESCRIPTION", "List the repositories a user is watching, i.e. gets all notifications of. Defaults to the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WATCHED_REPOSITORIES_USER_TITLE", "List watched repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username to list watched repositories for. Defaults to the authenticated user."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Activity.ListWatched(ctx, username, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list watched repositories",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			minimalRepos := make([]MinimalRepository, 0, len(repos))
+			for _, repo := range repos {
+				if !repositoryAllowed(ctx, repo.GetOwner().GetLogin(), repo.GetName()) {
+					continue
+				}
+				minimalRepos = append(minimalRepos, convertToMinimalRepository(repo))
 			}
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalRepos), nil
 		}
 }
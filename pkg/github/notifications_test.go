@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -763,3 +764,176 @@ func Test_GetNotificationDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRepositorySubscription(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositorySubscription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_subscription", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	createdAt := github.Timestamp{Time: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name                 string
+		mockedClient         *http.Client
+		expectError          bool
+		expectedErrMsg       string
+		expectedSubscription RepositorySubscription
+	}{
+		{
+			name: "watching",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					&github.Subscription{Subscribed: github.Ptr(true), Ignored: github.Ptr(false), CreatedAt: &createdAt},
+				),
+			),
+			expectedSubscription: RepositorySubscription{Owner: "owner", Repo: "repo", State: "watching", CreatedAt: "2025-05-01T12:00:00Z"},
+		},
+		{
+			name: "ignoring",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					&github.Subscription{Subscribed: github.Ptr(false), Ignored: github.Ptr(true), CreatedAt: &createdAt},
+				),
+			),
+			expectedSubscription: RepositorySubscription{Owner: "owner", Repo: "repo", State: "ignoring", CreatedAt: "2025-05-01T12:00:00Z"},
+		},
+		{
+			name: "not watching",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectedSubscription: RepositorySubscription{Owner: "owner", Repo: "repo", State: "not_watching"},
+		},
+		{
+			name: "api error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository subscription for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositorySubscription(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var subscription RepositorySubscription
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &subscription))
+			assert.Equal(t, tc.expectedSubscription, subscription)
+		})
+	}
+}
+
+func Test_ListWatchedRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchedRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_watched_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	repos := []*github.Repository{
+		{ID: github.Ptr(int64(1)), Name: github.Ptr("hello-world"), FullName: github.Ptr("octocat/hello-world"), StargazersCount: github.Ptr(10)},
+		{ID: github.Ptr(int64(2)), Name: github.Ptr("spoon-knife"), FullName: github.Ptr("octocat/spoon-knife"), Archived: github.Ptr(true)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserSubscriptions,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, repos),
+					),
+				),
+			),
+			requestArgs: map[string]any{"page": float64(2), "perPage": float64(10)},
+		},
+		{
+			name: "other user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersSubscriptionsByUsername,
+					repos,
+				),
+			),
+			requestArgs: map[string]any{"username": "octocat"},
+		},
+		{
+			name: "api error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersSubscriptionsByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"username": "ghost"},
+			expectError:    true,
+			expectedErrMsg: "failed to list watched repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWatchedRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned []MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 2)
+			assert.Equal(t, "octocat/hello-world", returned[0].FullName)
+			assert.Equal(t, 10, returned[0].Stars)
+			assert.True(t, returned[1].Archived)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultOffboardingMaxItems is the default number of assigned issues and pull requests get_offboarding_report
+// lists.
+const DefaultOffboardingMaxItems = 100
+
+// SoleCodeownerRule is a CODEOWNERS rule whose only owner is the departing user.
+type SoleCodeownerRule struct {
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+}
+
+// SoleCodeownerRepository is a repository with CODEOWNERS rules owned by the departing user alone.
+type SoleCodeownerRepository struct {
+	Repository     string              `json:"repository"`
+	CodeownersPath string              `json:"codeowners_path"`
+	Rules          []SoleCodeownerRule `json:"rules"`
+}
+
+// OffboardingItem is an open issue or pull request assigned to the departing user.
+type OffboardingItem struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	HTMLURL    string `json:"html_url"`
+	// SoleAssignee is true when nobody else is assigned, so the item is left without an assignee.
+	SoleAssignee bool `json:"sole_assignee"`
+}
+
+// OffboardingProject is an open project with open items assigned to the departing user alone.
+type OffboardingProject struct {
+	OwnerType     string   `json:"owner_type"`
+	Owner         string   `json:"owner"`
+	ProjectNumber int      `json:"project_number"`
+	ProjectTitle  string   `json:"project_title"`
+	ProjectURL    string   `json:"project_url"`
+	Items         []string `json:"items"`
+}
+
+// soleCodeownerRules returns the rules of a CODEOWNERS file whose only owner is the user.
+func soleCodeownerRules(content, username string) []SoleCodeownerRule {
+	var rules []SoleCodeownerRule
+	for _, rule := range parseCodeowners(content) {
+		if len(rule.Owners) == 1 && strings.EqualFold(rule.Owners[0], "@"+username) {
+			rules = append(rules, SoleCodeownerRule{Line: rule.Line, Pattern: rule.Pattern})
+		}
+	}
+	return rules
+}
+
+// isSoleAssignee reports whether the user is the only assignee of an issue or pull request.
+func isSoleAssignee(issue *github.Issue, username string) bool {
+	return len(issue.Assignees) == 1 && strings.EqualFold(issue.Assignees[0].GetLogin(), username)
+}
+
+// offboardingChecklist lists the handovers the report calls for, in the order of its sections.
+func offboardingChecklist(codeowners []SoleCodeownerRepository, issues, pullRequests []OffboardingItem, projects []OffboardingProject) []string {
+	checklist := []string{}
+	for _, repo := range codeowners {
+		checklist = append(checklist, fmt.Sprintf("Add another owner to %d CODEOWNERS rule(s) in %s (%s)", len(repo.Rules), repo.Repository, repo.CodeownersPath))
+	}
+	if len(issues) > 0 {
+		checklist = append(checklist, fmt.Sprintf("Reassign %d open issue(s)", len(issues)))
+	}
+	if len(pullRequests) > 0 {
+		checklist = append(checklist, fmt.Sprintf("Reassign %d open pull request(s)", len(pullRequests)))
+	}
+	for _, project := range projects {
+		checklist = append(checklist, fmt.Sprintf("Reassign %d open item(s) of project %q", len(project.Items), project.ProjectTitle))
+	}
+	return checklist
+}
+
+// GetOffboardingReport creates a tool to list what a departing user owns across the repositories of an
+// organization.
+func GetOffboardingReport(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_offboarding_report",
+			mcp.WithDescription(t("TOOL_GET_OFFBOARDING_REPORT_DESCRIPTION", "Get an offboarding checklist for a user leaving an organization: the CODEOWNERS rules in its repositories whose only owner is the user, the open issues and pull requests assigned to them, and the open projects with open items assigned to them alone. Ownership through teams isn't expanded. Archived repositories are skipped. Pagination applies to the organization's repositories when 'repositories' is not provided.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_OFFBOARDING_REPORT_USER_TITLE", "Get offboarding report"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the departing user"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to check the CODEOWNERS files of. Defaults to the organization's repositories."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description(fmt.Sprintf("Maximum number of assigned issues and pull requests to list (default %d)", DefaultOffboardingMaxItems)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username = strings.TrimPrefix(username, "@")
+			repos, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", DefaultOffboardingMaxItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxItems < 1 || maxItems > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_items must be between 1 and %d", maxExportItems)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			names, hasNextPage, resp, err := listOrgRepositoryNames(ctx, client, org, repos, pagination)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+
+			assigned, total, resp, err := searchAllIssues(ctx, client, fmt.Sprintf("org:%s assignee:%s is:open", org, username), maxItems)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search the issues assigned to %s", username),
+					resp,
+					err,
+				), nil
+			}
+
+			codeownersPaths := make([]string, len(names))
+			codeownersContents := make([]string, len(names))
+			errs := fanOut(ctx, names, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				var err error
+				codeownersPaths[i], codeownersContents[i], _, err = probeCodeowners(ctx, client, org, repo, "")
+				return err
+			})
+
+			var failures []map[string]string
+			codeowners := []SoleCodeownerRepository{}
+			for i, repo := range names {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"repository": repo, "error": errs[i].Error()})
+					continue
+				}
+				if rules := soleCodeownerRules(codeownersContents[i], username); len(rules) > 0 {
+					codeowners = append(codeowners, SoleCodeownerRepository{
+						Repository:     repo,
+						CodeownersPath: codeownersPaths[i],
+						Rules:          rules,
+					})
+				}
+			}
+
+			issues := []OffboardingItem{}
+			pullRequests := []OffboardingItem{}
+			// Only the items the user is the sole assignee of are left without one in their projects
+			var soleAssigned []*github.Issue
+			for _, issue := range assigned {
+				item := OffboardingItem{
+					Repository:   path.Base(issue.GetRepositoryURL()),
+					Number:       issue.GetNumber(),
+					Title:        issue.GetTitle(),
+					HTMLURL:      issue.GetHTMLURL(),
+					SoleAssignee: isSoleAssignee(issue, username),
+				}
+				if issue.IsPullRequest() {
+					pullRequests = append(pullRequests, item)
+				} else {
+					issues = append(issues, item)
+				}
+				if item.SoleAssignee {
+					soleAssigned = append(soleAssigned, issue)
+				}
+			}
+
+			projectItems := make([][]ContentProjectItem, len(soleAssigned))
+			itemErrs := fanOut(ctx, soleAssigned, DefaultFanOutConcurrency, func(ctx context.Context, i int, issue *github.Issue) error {
+				var err error
+				_, projectItems[i], err = listContentProjectItems(ctx, gqlClient, org, path.Base(issue.GetRepositoryURL()), issue.GetNumber())
+				return err
+			})
+
+			projects := []OffboardingProject{}
+			byProject := map[string]int{}
+			for i, issue := range soleAssigned {
+				ref := fmt.Sprintf("%s#%d", path.Base(issue.GetRepositoryURL()), issue.GetNumber())
+				if itemErrs[i] != nil {
+					failures = append(failures, map[string]string{"item": ref, "error": itemErrs[i].Error()})
+					continue
+				}
+				for _, item := range projectItems[i] {
+					if item.ProjectClosed || item.Archived {
+						continue
+					}
+					key := fmt.Sprintf("%s/%s/%d", item.OwnerType, item.Owner, item.ProjectNumber)
+					j, ok := byProject[key]
+					if !ok {
+						j = len(projects)
+						byProject[key] = j
+						projects = append(projects, OffboardingProject{
+							OwnerType:     item.OwnerType,
+							Owner:         item.Owner,
+							ProjectNumber: item.ProjectNumber,
+							ProjectTitle:  item.ProjectTitle,
+							ProjectURL:    item.ProjectURL,
+						})
+					}
+					projects[j].Items = append(projects[j].Items, ref)
+				}
+			}
+			sort.SliceStable(projects, func(i, j int) bool {
+				return len(projects[i].Items) > len(projects[j].Items)
+			})
+
+			response := map[string]any{
+				"org":                    org,
+				"username":               username,
+				"repositories":           len(names),
+				"checklist":              offboardingChecklist(codeowners, issues, pullRequests, projects),
+				"sole_codeowner":         codeowners,
+				"assigned_issues":        issues,
+				"assigned_pull_requests": pullRequests,
+				"projects":               projects,
+				"truncated":              total > len(assigned),
+				"hasNextPage":            hasNextPage,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
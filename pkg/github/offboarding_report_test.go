@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOffboardingReport(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetOffboardingReport(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_offboarding_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	codeowners := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Encoding: github.Ptr("base64"),
+		// "* @acme/core\n/docs/ @octocat\n*.go @octocat @hubot\n/ci/ @OctoCat # release tooling\n"
+		Content: github.Ptr("KiBAYWNtZS9jb3JlCi9kb2NzLyBAb2N0b2NhdAoqLmdvIEBvY3RvY2F0IEBodWJvdAovY2kvIEBPY3RvQ2F0ICMgcmVsZWFzZSB0b29saW5nCg=="),
+	}
+	// Only web has a CODEOWNERS file, in .github
+	contentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/acme/web/contents/.github/CODEOWNERS" {
+			mockResponse(t, http.StatusOK, codeowners)(w, r)
+			return
+		}
+		mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+	})
+	assigned := &github.IssuesSearchResult{
+		Total: github.Ptr(2),
+		Issues: []*github.Issue{
+			{
+				Number:        github.Ptr(42),
+				Title:         github.Ptr("Fix login"),
+				HTMLURL:       github.Ptr("https://github.com/acme/web/issues/42"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/acme/web"),
+				Assignees:     []*github.User{{Login: github.Ptr("octocat")}},
+			},
+			{
+				Number:           github.Ptr(7),
+				Title:            github.Ptr("Add metrics"),
+				HTMLURL:          github.Ptr("https://github.com/acme/api/pull/7"),
+				RepositoryURL:    github.Ptr("https://api.github.com/repos/acme/api"),
+				Assignees:        []*github.User{{Login: github.Ptr("octocat")}, {Login: github.Ptr("hubot")}},
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/acme/api/pulls/7")},
+			},
+		},
+	}
+	searchHandler := expectQueryParams(t, map[string]string{
+		"q":        "org:acme assignee:octocat is:open",
+		"per_page": "100",
+	}).andThen(mockResponse(t, http.StatusOK, assigned))
+
+	type reportResponse struct {
+		Repositories         int                       `json:"repositories"`
+		Checklist            []string                  `json:"checklist"`
+		SoleCodeowner        []SoleCodeownerRepository `json:"sole_codeowner"`
+		AssignedIssues       []OffboardingItem         `json:"assigned_issues"`
+		AssignedPullRequests []OffboardingItem         `json:"assigned_pull_requests"`
+		Projects             []OffboardingProject      `json:"projects"`
+		Truncated            bool                      `json:"truncated"`
+		HasNextPage          bool                      `json:"hasNextPage"`
+		Errors               []map[string]string       `json:"errors"`
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		gqlClient        *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse reportResponse
+	}{
+		{
+			name: "organization repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					[]*github.Repository{
+						{Name: github.Ptr("api")},
+						{Name: github.Ptr("legacy"), Archived: github.Ptr(true)},
+						{Name: github.Ptr("web")},
+					},
+				),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler),
+			),
+			// Only the issue octocat is the sole assignee of is looked up, the personal project is closed
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(42), contentProjectItemsResponse("Issue")),
+			),
+			requestArgs: map[string]any{"org": "acme", "username": "@octocat"},
+			expectedResponse: reportResponse{
+				Repositories: 2,
+				Checklist: []string{
+					"Add another owner to 2 CODEOWNERS rule(s) in web (.github/CODEOWNERS)",
+					"Reassign 1 open issue(s)",
+					"Reassign 1 open pull request(s)",
+					`Reassign 1 open item(s) of project "Roadmap"`,
+				},
+				SoleCodeowner: []SoleCodeownerRepository{{
+					Repository:     "web",
+					CodeownersPath: ".github/CODEOWNERS",
+					Rules:          []SoleCodeownerRule{{Line: 2, Pattern: "/docs/"}, {Line: 4, Pattern: "/ci/"}},
+				}},
+				AssignedIssues: []OffboardingItem{
+					{Repository: "web", Number: 42, Title: "Fix login", HTMLURL: "https://github.com/acme/web/issues/42", SoleAssignee: true},
+				},
+				AssignedPullRequests: []OffboardingItem{
+					{Repository: "api", Number: 7, Title: "Add metrics", HTMLURL: "https://github.com/acme/api/pull/7"},
+				},
+				Projects: []OffboardingProject{{
+					OwnerType:     "org",
+					Owner:         "acme",
+					ProjectNumber: 3,
+					ProjectTitle:  "Roadmap",
+					ProjectURL:    "https://github.com/orgs/acme/projects/3",
+					Items:         []string{"web#42"},
+				}},
+			},
+		},
+		{
+			name: "project lookup failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(42), githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"issueOrPullRequest": nil},
+				})),
+			),
+			requestArgs: map[string]any{"org": "acme", "username": "octocat", "repositories": []any{"api"}},
+			expectedResponse: reportResponse{
+				Repositories:  1,
+				Checklist:     []string{"Reassign 1 open issue(s)", "Reassign 1 open pull request(s)"},
+				SoleCodeowner: []SoleCodeownerRepository{},
+				AssignedIssues: []OffboardingItem{
+					{Repository: "web", Number: 42, Title: "Fix login", HTMLURL: "https://github.com/acme/web/issues/42", SoleAssignee: true},
+				},
+				AssignedPullRequests: []OffboardingItem{
+					{Repository: "api", Number: 7, Title: "Add metrics", HTMLURL: "https://github.com/acme/api/pull/7"},
+				},
+				Projects: []OffboardingProject{},
+				Errors:   []map[string]string{{"item": "web#42", "error": "no issue or pull request #42 in acme/web"}},
+			},
+		},
+		{
+			name: "truncated assigned items",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+				mock.WithRequestMatch(mock.GetSearchIssues, &github.IssuesSearchResult{
+					Total:  github.Ptr(2),
+					Issues: assigned.Issues[1:],
+				}),
+			),
+			gqlClient:   githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{"org": "acme", "username": "octocat", "repositories": []any{"api"}, "max_items": float64(1)},
+			expectedResponse: reportResponse{
+				Repositories:   1,
+				Checklist:      []string{"Reassign 1 open pull request(s)"},
+				SoleCodeowner:  []SoleCodeownerRepository{},
+				AssignedIssues: []OffboardingItem{},
+				AssignedPullRequests: []OffboardingItem{
+					{Repository: "api", Number: 7, Title: "Add metrics", HTMLURL: "https://github.com/acme/api/pull/7"},
+				},
+				Projects:  []OffboardingProject{},
+				Truncated: true,
+			},
+		},
+		{
+			name: "search failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			gqlClient:      githubv4mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "acme", "username": "ghost", "repositories": []any{"api"}},
+			expectError:    true,
+			expectedErrMsg: "failed to search the issues assigned to ghost",
+		},
+		{
+			name:           "max_items out of range",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			gqlClient:      githubv4mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "acme", "username": "octocat", "max_items": float64(maxExportItems + 1)},
+			expectError:    true,
+			expectedErrMsg: "max_items must be between 1 and 1000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetOffboardingReport(stubGetClientFn(github.NewClient(tc.mockedClient)), stubGetGQLClientFn(githubv4.NewClient(tc.gqlClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response reportResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// offlineQueueMaxPending is the number of operations that may wait to be retried. Calls failing once the queue
+	// is full return their error.
+	offlineQueueMaxPending = 100
+	// offlineQueueMaxFinished is the number of retried operations whose outcome is kept for list_pending_operations.
+	offlineQueueMaxFinished = 50
+	// offlineQueueMaxAge is how long an operation is retried before it's given up.
+	offlineQueueMaxAge = 24 * time.Hour
+)
+
+// Statuses of queued operations.
+const (
+	QueuedOperationPending   = "pending"
+	QueuedOperationSucceeded = "succeeded"
+	QueuedOperationFailed    = "failed"
+)
+
+// QueuedOperation is a tool call that failed because GitHub was unavailable, and is retried until it succeeds.
+type QueuedOperation struct {
+	ID         int            `json:"id"`
+	Tool       string         `json:"tool"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	Status     string         `json:"status"`
+	QueuedAt   time.Time      `json:"queued_at"`
+	Attempts   int            `json:"attempts"`
+	LastError  string         `json:"last_error,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+	Result     string         `json:"result,omitempty"`
+}
+
+type queuedCall struct {
+	QueuedOperation
+	request mcp.CallToolRequest
+	handler server.ToolHandlerFunc
+}
+
+// OfflineQueue queues the calls of write tools that are safe to repeat, such as comments and label changes, when
+// they fail because GitHub returned a server error or couldn't be reached, and retries them in order until they
+// succeed. Without it, an agent working through a transient outage would silently drop that work. The queue is
+// kept in memory: operations still pending when the server stops are retried a last time by Flush, and given up
+// otherwise.
+type OfflineQueue struct {
+	retryInterval time.Duration
+	now           func() time.Time
+	// retrying serializes the retries of Run and Flush, so that an operation is never retried twice at once
+	retrying sync.Mutex
+
+	mu     sync.Mutex
+	nextID int
+	// calls holds the pending operations, oldest first, followed by the most recent finished ones
+	calls []*queuedCall
+}
+
+// NewOfflineQueue creates an OfflineQueue retrying operations every retryInterval. It returns nil if retryInterval
+// isn't positive, which disables the queue.
+func NewOfflineQueue(retryInterval time.Duration) *OfflineQueue {
+	if retryInterval <= 0 {
+		return nil
+	}
+	return &OfflineQueue{retryInterval: retryInterval, now: time.Now}
+}
+
+// offlineQueueable reports whether a call may be queued: only those that can be repeated without harm, which are
+// comments and changes to the labels and state of issues. Comments are included even though one whose request
+// failed after GitHub saved it is posted twice, as losing it is worse. Label definitions aren't label changes, so
+// creating or deleting them isn't queued.
+func offlineQueueable(tool string, args map[string]any) bool {
+	switch tool {
+	case "add_issue_comment", "label_good_first_issues":
+		return true
+	case "issue_write":
+		// Creating an issue twice isn't harmless, setting the state or labels of one is
+		method, _ := args["method"].(string)
+		return method == "update"
+	default:
+		return false
+	}
+}
+
+// isOutageError reports whether err means GitHub is unavailable: it couldn't be reached because of a network failure,
+// such as a failed dial or DNS lookup or a reset connection, or it answered with a server error. Cancelled calls,
+// deadlines and requests the server held back itself, such as during a secondary rate limit pause, aren't outages.
+func isOutageError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= 500
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	// The GraphQL client reports the status of failed requests only in its message
+	return strings.Contains(err.Error(), "non-200 OK status code: 5")
+}
+
+// callOutcome is the outcome of a call, with the GitHub errors it reported in its context.
+type callOutcome struct {
+	result  *mcp.CallToolResult
+	err     error
+	apiErrs []*ghErrors.GitHubAPIError
+	gqlErrs []*ghErrors.GitHubGraphQLError
+}
+
+// callWithOwnErrors calls handler with a context collecting the GitHub errors of this call only, as calls share
+// the errors of their session otherwise, and copies them to ctx afterwards.
+func callWithOwnErrors(ctx context.Context, handler server.ToolHandlerFunc, request mcp.CallToolRequest) callOutcome {
+	callCtx := context.WithValue(ctx, ghErrors.GitHubErrorKey{}, &ghErrors.GitHubCtxErrors{})
+	outcome := callOutcome{}
+	outcome.result, outcome.err = handler(callCtx, request)
+
+	outcome.apiErrs, _ = ghErrors.GetGitHubAPIErrors(callCtx)
+	for _, apiErr := range outcome.apiErrs {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, apiErr.Message, apiErr.Response, apiErr.Err)
+	}
+	outcome.gqlErrs, _ = ghErrors.GetGitHubGraphQLErrors(callCtx)
+	for _, gqlErr := range outcome.gqlErrs {
+		_ = ghErrors.NewGitHubGraphQLErrorResponse(ctx, gqlErr.Message, gqlErr.Err)
+	}
+	return outcome
+}
+
+// outageError returns the error of a call that failed because GitHub was unavailable, or nil if it didn't.
+func (o callOutcome) outageError() error {
+	if o.err != nil {
+		if isOutageError(o.err) {
+			return o.err
+		}
+		return nil
+	}
+	if o.result == nil || !o.result.IsError {
+		return nil
+	}
+	for _, apiErr := range o.apiErrs {
+		if resp := apiErr.Response; resp != nil && resp.Response != nil {
+			if resp.StatusCode >= 500 {
+				return apiErr
+			}
+			continue
+		}
+		if apiErr.Err != nil && isOutageError(apiErr.Err) {
+			return apiErr
+		}
+	}
+	for _, gqlErr := range o.gqlErrs {
+		if gqlErr.Err != nil && isOutageError(gqlErr.Err) {
+			return gqlErr
+		}
+	}
+	return nil
+}
+
+// enqueue adds a call to the queue. It returns false if the queue is full.
+func (q *OfflineQueue) enqueue(request mcp.CallToolRequest, handler server.ToolHandlerFunc, callErr error) (QueuedOperation, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pendingLocked() >= offlineQueueMaxPending {
+		return QueuedOperation{}, false
+	}
+	q.nextID++
+	call := &queuedCall{
+		QueuedOperation: QueuedOperation{
+			ID:        q.nextID,
+			Tool:      request.Params.Name,
+			Arguments: truncateJournalArguments(request.GetArguments()),
+			Status:    QueuedOperationPending,
+			QueuedAt:  q.now().UTC(),
+			Attempts:  1,
+			LastError: truncateJournalString(callErr.Error(), journalMaxResultLength),
+		},
+		request: request,
+		handler: handler,
+	}
+	// Pending operations come first, in the order they're retried
+	pending := q.pendingLocked()
+	q.calls = append(q.calls[:pending], append([]*queuedCall{call}, q.calls[pending:]...)...)
+	return call.QueuedOperation, true
+}
+
+func (q *OfflineQueue) pendingLocked() int {
+	pending := 0
+	for pending < len(q.calls) && q.calls[pending].Status == QueuedOperationPending {
+		pending++
+	}
+	return pending
+}
+
+// Pending returns the number of operations waiting to be retried.
+func (q *OfflineQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pendingLocked()
+}
+
+// Operations returns the pending operations in the order they're retried, followed by the most recently finished
+// ones, newest first.
+func (q *OfflineQueue) Operations() []QueuedOperation {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	operations := make([]QueuedOperation, 0, len(q.calls))
+	for _, call := range q.calls {
+		operations = append(operations, call.QueuedOperation)
+	}
+	return operations
+}
+
+// finish records the outcome of a pending operation and moves it to the front of the finished ones.
+func (q *OfflineQueue) finish(call *queuedCall, status, result string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	finishedAt := q.now().UTC()
+	call.Status = status
+	call.Result = result
+	call.FinishedAt = &finishedAt
+	call.request = mcp.CallToolRequest{}
+	call.handler = nil
+
+	for i, c := range q.calls {
+		if c == call {
+			q.calls = append(q.calls[:i], q.calls[i+1:]...)
+			break
+		}
+	}
+	pending := q.pendingLocked()
+	q.calls = append(q.calls[:pending], append([]*queuedCall{call}, q.calls[pending:]...)...)
+	if len(q.calls) > pending+offlineQueueMaxFinished {
+		q.calls = q.calls[:pending+offlineQueueMaxFinished]
+	}
+}
+
+// Retry retries the pending operations in order. It stops at the first that fails because GitHub is still
+// unavailable, so that later operations don't overtake it, and returns the number of operations that finished.
+func (q *OfflineQueue) Retry(ctx context.Context, logger *slog.Logger) int {
+	q.retrying.Lock()
+	defer q.retrying.Unlock()
+
+	q.mu.Lock()
+	pending := append([]*queuedCall(nil), q.calls[:q.pendingLocked()]...)
+	q.mu.Unlock()
+
+	finished := 0
+	for _, call := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		if age := q.now().Sub(call.QueuedAt); age > offlineQueueMaxAge {
+			q.finish(call, QueuedOperationFailed, fmt.Sprintf("gave up after %d attempts in %s", call.Attempts, age.Round(time.Minute)))
+			logger.Warn("gave up queued operation", "id", call.ID, "tool", call.Tool, "attempts", call.Attempts)
+			finished++
+			continue
+		}
+
+		outcome := callWithOwnErrors(ctx, call.handler, call.request)
+		q.mu.Lock()
+		call.Attempts++
+		q.mu.Unlock()
+		if ctx.Err() != nil {
+			// The call was cut short, so it's still pending
+			break
+		}
+		if outageErr := outcome.outageError(); outageErr != nil {
+			q.mu.Lock()
+			call.LastError = truncateJournalString(outageErr.Error(), journalMaxResultLength)
+			q.mu.Unlock()
+			logger.Info("GitHub still unavailable, keeping queued operations", "id", call.ID, "tool", call.Tool, "error", outageErr)
+			break
+		}
+
+		status, text := QueuedOperationSucceeded, ""
+		switch {
+		case outcome.err != nil:
+			status, text = QueuedOperationFailed, outcome.err.Error()
+		case outcome.result != nil:
+			if outcome.result.IsError {
+				status = QueuedOperationFailed
+			}
+			for _, content := range outcome.result.Content {
+				if textContent, ok := content.(mcp.TextContent); ok {
+					text = textContent.Text
+					break
+				}
+			}
+		}
+		q.finish(call, status, truncateJournalString(text, journalMaxResultLength))
+		logger.Info("retried queued operation", "id", call.ID, "tool", call.Tool, "status", status)
+		finished++
+	}
+	return finished
+}
+
+// Run retries the pending operations every retry interval until ctx is cancelled.
+func (q *OfflineQueue) Run(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(q.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.Retry(ctx, logger)
+		}
+	}
+}
+
+// Flush retries the pending operations a last time, until ctx is done, when the server stops, and gives up the
+// operations still pending. It returns the operations given up, so that they can be logged or recorded and redone
+// once GitHub is available again.
+func (q *OfflineQueue) Flush(ctx context.Context, logger *slog.Logger) []QueuedOperation {
+	q.Retry(ctx, logger)
+
+	q.retrying.Lock()
+	defer q.retrying.Unlock()
+	q.mu.Lock()
+	pending := append([]*queuedCall(nil), q.calls[:q.pendingLocked()]...)
+	q.mu.Unlock()
+
+	dropped := make([]QueuedOperation, 0, len(pending))
+	for _, call := range pending {
+		q.finish(call, QueuedOperationFailed, "given up when the server stopped")
+		q.mu.Lock()
+		dropped = append(dropped, call.QueuedOperation)
+		q.mu.Unlock()
+	}
+	return dropped
+}
+
+// Middleware returns a tool handler middleware that queues the calls of write tools that are safe to repeat when
+// they fail because GitHub is unavailable, and answers them with the ID of the queued operation instead of the
+// error. Queued calls are retried through the middlewares added after it, so it must come before those checking
+// calls, such as the owner policy and the write limiter.
+func (q *OfflineQueue) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !offlineQueueable(request.Params.Name, request.GetArguments()) {
+				return next(ctx, request)
+			}
+
+			outcome := callWithOwnErrors(ctx, next, request)
+			outageErr := outcome.outageError()
+			if outageErr == nil {
+				return outcome.result, outcome.err
+			}
+			operation, ok := q.enqueue(request, next, outageErr)
+			if !ok {
+				return outcome.result, outcome.err
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("GitHub is unavailable (%s). The call was queued as operation %d and will be retried every %s until it succeeds. Don't repeat it, check its outcome with list_pending_operations instead.", operation.LastError, operation.ID, q.retryInterval)), nil
+		}
+	}
+}
+
+// ListPendingOperations creates a tool to list the operations of the offline queue.
+func ListPendingOperations(queue *OfflineQueue, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_operations",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_OPERATIONS_DESCRIPTION", "List the comments, label changes and issue updates that were queued because GitHub was unavailable, with their status: pending while they're retried, then succeeded or failed with the result of the last attempt. Pending operations come first, in the order they're retried, followed by the most recently finished ones.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_OPERATIONS_USER_TITLE", "List pending operations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("status",
+				mcp.Description("Only return operations with this status"),
+				mcp.Enum(QueuedOperationPending, QueuedOperationSucceeded, QueuedOperationFailed),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			operations := []QueuedOperation{}
+			for _, operation := range queue.Operations() {
+				if status == "" || operation.Status == status {
+					operations = append(operations, operation)
+				}
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"pending":        queue.Pending(),
+				"retry_interval": queue.retryInterval.String(),
+				"operations":     operations,
+			}), nil
+		}
+}
@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_offlineQueueable(t *testing.T) {
+	assert.True(t, offlineQueueable("add_issue_comment", nil))
+	assert.True(t, offlineQueueable("label_good_first_issues", nil))
+	assert.False(t, offlineQueueable("label_write", map[string]any{"method": "delete"}), "label definitions aren't label changes")
+	assert.True(t, offlineQueueable("issue_write", map[string]any{"method": "update"}))
+	assert.False(t, offlineQueueable("issue_write", map[string]any{"method": "create"}), "creating an issue twice isn't harmless")
+	assert.False(t, offlineQueueable("push_files", nil))
+}
+
+func Test_isOutageError(t *testing.T) {
+	assert.True(t, isOutageError(&url.Error{Op: "Post", URL: "https://api.github.com/graphql", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}))
+	assert.True(t, isOutageError(&url.Error{Op: "Get", URL: "https://api.github.com/user", Err: &net.DNSError{Err: "no such host", Name: "api.github.com"}}))
+	assert.True(t, isOutageError(&url.Error{Op: "Get", URL: "https://api.github.com/user", Err: syscall.ECONNRESET}))
+	assert.False(t, isOutageError(&url.Error{Op: "Get", URL: "https://api.github.com/user", Err: context.Canceled}))
+	assert.False(t, isOutageError(&url.Error{Op: "Get", URL: "https://api.github.com/user", Err: context.DeadlineExceeded}))
+	assert.False(t, isOutageError(&url.Error{Op: "Post", URL: "https://api.github.com/graphql", Err: errors.New("requests to GitHub are paused for another 30s after a secondary rate limit")}))
+	assert.True(t, isOutageError(errors.New("non-200 OK status code: 503 Service Unavailable body: \"\"")))
+	assert.False(t, isOutageError(errors.New("non-200 OK status code: 401 Unauthorized body: \"\"")))
+	assert.False(t, isOutageError(errors.New("Could not resolve to a Repository with the name 'owner/missing'.")))
+}
+
+func Test_callWithOwnErrors(t *testing.T) {
+	ctx := ghErrors.ContextWithGitHubErrors(context.Background())
+	outcome := callWithOwnErrors(ctx, func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		resp := &github.Response{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update issue", resp, errors.New("503 Service Unavailable")), nil
+	}, createMCPRequest(map[string]any{}))
+
+	outageErr := outcome.outageError()
+	require.Error(t, outageErr)
+	assert.Equal(t, "failed to update issue: 503 Service Unavailable", outageErr.Error())
+
+	// The errors of the call are still reported to the session
+	apiErrs, err := ghErrors.GetGitHubAPIErrors(ctx)
+	require.NoError(t, err)
+	require.Len(t, apiErrs, 1)
+	assert.Equal(t, "failed to update issue", apiErrs[0].Message)
+}
+
+func Test_OfflineQueue(t *testing.T) {
+	assert.Nil(t, NewOfflineQueue(0), "no retry interval must not create a queue")
+
+	queue := NewOfflineQueue(30 * time.Second)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	queue.now = func() time.Time { return now }
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	status := http.StatusBadGateway
+	var comments []string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if status != http.StatusCreated {
+					mockResponse(t, status, map[string]string{"message": http.StatusText(status)})(w, r)
+					return
+				}
+				var comment github.IssueComment
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+				comments = append(comments, comment.GetBody())
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(len(comments))), Body: comment.Body})(w, r)
+			}),
+		),
+	))
+	_, addComment := AddIssueComment(stubGetClientFn(client), translations.NullTranslationHelper)
+	// Stands in for the middlewares checking calls, such as the write limiter
+	checks := 0
+	checked := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		checks++
+		return addComment(ctx, request)
+	}
+	handler := queue.Middleware()(checked)
+	call := func(body string) string {
+		request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42), "body": body})
+		request.Params.Name = "add_issue_comment"
+		result, err := handler(context.Background(), request)
+		if status == http.StatusNotFound {
+			require.Error(t, err)
+			return err.Error()
+		}
+		require.NoError(t, err)
+		if result.IsError {
+			return getErrorResult(t, result).Text
+		}
+		return getTextResult(t, result).Text
+	}
+
+	// Calls failing with a server error are queued
+	assert.Contains(t, call("first"), "queued as operation 1 and will be retried every 30s")
+	assert.Contains(t, call("second"), "queued as operation 2")
+	assert.Equal(t, 2, queue.Pending())
+
+	// Calls failing for another reason aren't
+	status = http.StatusNotFound
+	assert.Contains(t, call("third"), "failed to create comment")
+	assert.Equal(t, 2, queue.Pending())
+
+	// Retries stop at the first operation GitHub is still unavailable for
+	status = http.StatusServiceUnavailable
+	assert.Equal(t, 0, queue.Retry(context.Background(), logger))
+	operations := queue.Operations()
+	require.Len(t, operations, 2)
+	assert.Equal(t, 2, operations[0].Attempts)
+	assert.Equal(t, 1, operations[1].Attempts)
+	assert.Contains(t, operations[0].LastError, "503")
+
+	// Once GitHub is back, the operations are retried in order
+	status = http.StatusCreated
+	now = now.Add(time.Minute)
+	assert.Equal(t, 2, queue.Retry(context.Background(), logger))
+	assert.Equal(t, []string{"first", "second"}, comments)
+	assert.Equal(t, 0, queue.Pending())
+	assert.Equal(t, 6, checks, "retries go through the middlewares after the queue")
+	operations = queue.Operations()
+	require.Len(t, operations, 2)
+	assert.Equal(t, 2, operations[0].ID, "finished operations come newest first")
+	assert.Equal(t, QueuedOperationSucceeded, operations[0].Status)
+	assert.Contains(t, operations[0].Result, `"body":"second"`)
+	assert.Equal(t, now, *operations[0].FinishedAt)
+
+	// Operations are given up after a day
+	status = http.StatusBadGateway
+	assert.Contains(t, call("fourth"), "queued as operation 3")
+	now = now.Add(25 * time.Hour)
+	assert.Equal(t, 1, queue.Retry(context.Background(), logger))
+	operations = queue.Operations()
+	assert.Equal(t, QueuedOperationFailed, operations[0].Status)
+	assert.Contains(t, operations[0].Result, "gave up after 1 attempts")
+	assert.Len(t, comments, 2)
+
+	// When the server stops, pending operations are retried a last time, and given up if GitHub is still unavailable
+	assert.Contains(t, call("fifth"), "queued as operation 4")
+	status = http.StatusCreated
+	assert.Empty(t, queue.Flush(context.Background(), logger))
+	assert.Equal(t, []string{"first", "second", "fifth"}, comments)
+
+	status = http.StatusBadGateway
+	assert.Contains(t, call("sixth"), "queued as operation 5")
+	dropped := queue.Flush(context.Background(), logger)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "add_issue_comment", dropped[0].Tool)
+	assert.Equal(t, "sixth", dropped[0].Arguments["body"])
+	assert.Equal(t, 2, dropped[0].Attempts)
+	assert.Equal(t, 0, queue.Pending())
+	assert.Equal(t, "given up when the server stopped", queue.Operations()[0].Result)
+}
+
+func Test_ListPendingOperations(t *testing.T) {
+	queue := NewOfflineQueue(time.Minute)
+
+	// Verify tool definition once
+	tool, _ := ListPendingOperations(queue, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_pending_operations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	request := createMCPRequest(map[string]any{"issue_number": float64(7), "state": "closed"})
+	request.Params.Name = "issue_write"
+	_, ok := queue.enqueue(request, nil, errors.New("failed to update issue: 502 Bad Gateway"))
+	require.True(t, ok)
+	request.Params.Name = "add_issue_comment"
+	_, ok = queue.enqueue(request, nil, errors.New("failed to create comment: 502 Bad Gateway"))
+	require.True(t, ok)
+	queue.finish(queue.calls[1], QueuedOperationSucceeded, `{"id": 1}`)
+
+	_, handler := ListPendingOperations(queue, translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"status": "pending"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		Pending       int               `json:"pending"`
+		RetryInterval string            `json:"retry_interval"`
+		Operations    []QueuedOperation `json:"operations"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 1, response.Pending)
+	assert.Equal(t, "1m0s", response.RetryInterval)
+	require.Len(t, response.Operations, 1)
+	assert.Equal(t, "issue_write", response.Operations[0].Tool)
+	assert.Equal(t, "closed", response.Operations[0].Arguments["state"])
+	assert.Contains(t, response.Operations[0].LastError, "502 Bad Gateway")
+}
@@ -0,0 +1,952 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// ExternalIdentityAttributes holds the attributes reported by the identity provider for a linked identity.
+type ExternalIdentityAttributes struct {
+	NameID   string   `json:"name_id,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Emails   []string `json:"emails,omitempty"`
+}
+
+// ExternalIdentity maps an organization member to their SAML and SCIM identities.
+type ExternalIdentity struct {
+	GUID  string                      `json:"guid"`
+	Login string                      `json:"login,omitempty"`
+	SAML  *ExternalIdentityAttributes `json:"saml,omitempty"`
+	SCIM  *ExternalIdentityAttributes `json:"scim,omitempty"`
+}
+
+type externalIdentityEmail struct {
+	Value githubv4.String
+}
+
+type externalIdentityNode struct {
+	GUID         githubv4.String
+	SamlIdentity *struct {
+		NameID   githubv4.String
+		Username githubv4.String
+		Emails   []externalIdentityEmail
+	}
+	ScimIdentity *struct {
+		Username githubv4.String
+		Emails   []externalIdentityEmail
+	}
+	User *struct {
+		Login githubv4.String
+	}
+}
+
+func convertExternalIdentityEmails(emails []externalIdentityEmail) []string {
+	if len(emails) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(emails))
+	for _, e := range emails {
+		result = append(result, string(e.Value))
+	}
+	return result
+}
+
+func convertToExternalIdentity(node externalIdentityNode) ExternalIdentity {
+	identity := ExternalIdentity{
+		GUID: string(node.GUID),
+	}
+	if node.User != nil {
+		identity.Login = string(node.User.Login)
+	}
+	if node.SamlIdentity != nil {
+		identity.SAML = &ExternalIdentityAttributes{
+			NameID:   string(node.SamlIdentity.NameID),
+			Username: string(node.SamlIdentity.Username),
+			Emails:   convertExternalIdentityEmails(node.SamlIdentity.Emails),
+		}
+	}
+	if node.ScimIdentity != nil {
+		identity.SCIM = &ExternalIdentityAttributes{
+			Username: string(node.ScimIdentity.Username),
+			Emails:   convertExternalIdentityEmails(node.ScimIdentity.Emails),
+		}
+	}
+	return identity
+}
+
+// ListOrgExternalIdentities creates a tool to map organization members to their SAML/SCIM external identities.
+func ListOrgExternalIdentities(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_external_identities",
+			mcp.WithDescription(t("TOOL_LIST_ORG_EXTERNAL_IDENTITIES_DESCRIPTION", "List the SAML/SCIM external identities linked to members of an organization with SAML single sign-on enabled. Use this to correlate GitHub logins with corporate accounts. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_EXTERNAL_IDENTITIES_USER_TITLE", "List organization external identities"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("login",
+				mcp.Description("Only return the external identity linked to this GitHub login"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			login, err := OptionalParam[string](request, "login")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil
+			}
+
+			var q struct {
+				Organization struct {
+					SamlIdentityProvider *struct {
+						ExternalIdentities struct {
+							Nodes    []externalIdentityNode
+							PageInfo struct {
+								HasNextPage githubv4.Boolean
+								EndCursor   githubv4.String
+							}
+							TotalCount int
+						} `graphql:"externalIdentities(first: $first, after: $after, login: $login)"`
+					}
+				} `graphql:"organization(login: $org)"`
+			}
+			vars := map[string]interface{}{
+				"org":   githubv4.String(org),
+				"first": githubv4.Int(*paginationParams.First),
+				"after": (*githubv4.String)(nil),
+				"login": (*githubv4.String)(nil),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.NewString(githubv4.String(*paginationParams.After))
+			}
+			if login != "" {
+				vars["login"] = githubv4.NewString(githubv4.String(login))
+			}
+
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list external identities", err), nil
+			}
+
+			provider := q.Organization.SamlIdentityProvider
+			if provider == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("organization '%s' does not have a SAML identity provider configured, or the token lacks admin:org scope", org)), nil
+			}
+
+			identities := make([]ExternalIdentity, 0, len(provider.ExternalIdentities.Nodes))
+			for _, node := range provider.ExternalIdentities.Nodes {
+				identities = append(identities, convertToExternalIdentity(node))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"identities": identities,
+				"pageInfo": map[string]any{
+					"hasNextPage": provider.ExternalIdentities.PageInfo.HasNextPage,
+					"endCursor":   string(provider.ExternalIdentities.PageInfo.EndCursor),
+				},
+				"totalCount": provider.ExternalIdentities.TotalCount,
+			}), nil
+		}
+}
+
+// MinimalFineGrainedPAT is the trimmed output type for fine-grained personal access tokens
+// (and pending requests for them) with access to organization resources.
+type MinimalFineGrainedPAT struct {
+	ID                  int64                                  `json:"id"`
+	TokenID             int64                                  `json:"token_id,omitempty"`
+	TokenName           string                                 `json:"token_name,omitempty"`
+	Owner               string                                 `json:"owner,omitempty"`
+	RepositorySelection string                                 `json:"repository_selection,omitempty"`
+	RepositoryCount     int64                                  `json:"repository_count,omitempty"`
+	Permissions         *github.PersonalAccessTokenPermissions `json:"permissions,omitempty"`
+	AccessGrantedAt     string                                 `json:"access_granted_at,omitempty"`
+	RequestedAt         string                                 `json:"requested_at,omitempty"`
+	TokenExpired        bool                                   `json:"token_expired"`
+	TokenExpiresAt      string                                 `json:"token_expires_at,omitempty"`
+	TokenLastUsedAt     string                                 `json:"token_last_used_at,omitempty"`
+}
+
+func formatOptionalTimestamp(ts *github.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
+
+func convertToMinimalFineGrainedPAT(pat *github.PersonalAccessToken) MinimalFineGrainedPAT {
+	return MinimalFineGrainedPAT{
+		ID:                  pat.GetID(),
+		TokenID:             pat.GetTokenID(),
+		TokenName:           pat.GetTokenName(),
+		Owner:               pat.GetOwner().GetLogin(),
+		RepositorySelection: pat.GetRepositorySelection(),
+		Permissions:         pat.Permissions,
+		AccessGrantedAt:     formatOptionalTimestamp(pat.AccessGrantedAt),
+		TokenExpired:        pat.GetTokenExpired(),
+		TokenExpiresAt:      formatOptionalTimestamp(pat.TokenExpiresAt),
+		TokenLastUsedAt:     formatOptionalTimestamp(pat.TokenLastUsedAt),
+	}
+}
+
+// personalAccessTokenRequest extends the go-github webhook payload type with the
+// token fields returned by the REST API list endpoint.
+type personalAccessTokenRequest struct {
+	github.PersonalAccessTokenRequest
+	TokenID   int64  `json:"token_id,omitempty"`
+	TokenName string `json:"token_name,omitempty"`
+}
+
+func convertToMinimalFineGrainedPATRequest(req *personalAccessTokenRequest) MinimalFineGrainedPAT {
+	return MinimalFineGrainedPAT{
+		ID:                  req.GetID(),
+		TokenID:             req.TokenID,
+		TokenName:           req.TokenName,
+		Owner:               req.GetOwner().GetLogin(),
+		RepositorySelection: req.GetRepositorySelection(),
+		RepositoryCount:     req.GetRepositoryCount(),
+		Permissions:         req.PermissionsResult,
+		RequestedAt:         formatOptionalTimestamp(req.CreatedAt),
+		TokenExpired:        req.GetTokenExpired(),
+		TokenExpiresAt:      formatOptionalTimestamp(req.TokenExpiresAt),
+		TokenLastUsedAt:     formatOptionalTimestamp(req.TokenLastUsedAt),
+	}
+}
+
+// ListOrgFineGrainedPATs creates a tool to list fine-grained personal access tokens with access to an organization.
+func ListOrgFineGrainedPATs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_fine_grained_pats",
+			mcp.WithDescription(t("TOOL_LIST_ORG_FINE_GRAINED_PATS_DESCRIPTION", "List approved fine-grained personal access tokens that can access an organization's resources, with their owners, permissions, and expiry. Requires GitHub App authentication with the organization 'Personal access tokens' permission.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_FINE_GRAINED_PATS_USER_TITLE", "List organization fine-grained personal access tokens"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("repository",
+				mcp.Description("Only return tokens that can access this repository (name only, without owner)"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only return tokens granted this permission (e.g. 'contents', 'issues')"),
+			),
+			mcp.WithString("last_used_before",
+				mcp.Description("Only return tokens last used before this time (ISO 8601 timestamp)"),
+			),
+			mcp.WithString("last_used_after",
+				mcp.Description("Only return tokens last used after this time (ISO 8601 timestamp)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repository, err := OptionalParam[string](request, "repository")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lastUsedBefore, err := OptionalParam[string](request, "last_used_before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lastUsedAfter, err := OptionalParam[string](request, "last_used_after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pats, resp, err := client.Organizations.ListFineGrainedPersonalAccessTokens(ctx, org, &github.ListFineGrainedPATOptions{
+				Repository:     repository,
+				Permission:     permission,
+				LastUsedBefore: lastUsedBefore,
+				LastUsedAfter:  lastUsedAfter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list fine-grained personal access tokens for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalFineGrainedPAT, 0, len(pats))
+			for _, pat := range pats {
+				result = append(result, convertToMinimalFineGrainedPAT(pat))
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ListOrgPATRequests creates a tool to list pending requests from fine-grained personal access tokens to access an organization.
+func ListOrgPATRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_pat_requests",
+			mcp.WithDescription(t("TOOL_LIST_ORG_PAT_REQUESTS_DESCRIPTION", "List pending requests from fine-grained personal access tokens to access an organization's resources. Use the returned 'id' with 'review_org_pat_request' to approve or deny a request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_PAT_REQUESTS_USER_TITLE", "List pending organization token requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap this endpoint, so we build the request ourselves.
+			url, err := addOptions(fmt.Sprintf("orgs/%s/personal-access-token-requests", org), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add options to request: %w", err)
+			}
+
+			httpRequest, err := client.NewRequest("GET", url, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var requests []*personalAccessTokenRequest
+			resp, err := client.Do(ctx, httpRequest, &requests)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list personal access token requests for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalFineGrainedPAT, 0, len(requests))
+			for _, r := range requests {
+				result = append(result, convertToMinimalFineGrainedPATRequest(r))
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ReviewOrgPATRequest creates a tool to approve or deny a pending fine-grained personal access token request.
+func ReviewOrgPATRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("review_org_pat_request",
+			mcp.WithDescription(t("TOOL_REVIEW_ORG_PAT_REQUEST_DESCRIPTION", "Approve or deny a pending request from a fine-grained personal access token to access an organization's resources.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_ORG_PAT_REQUEST_USER_TITLE", "Review organization token request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithNumber("request_id",
+				mcp.Required(),
+				mcp.Description("The ID of the pending token request, as returned by 'list_org_pat_requests'"),
+			),
+			mcp.WithString("action",
+				mcp.Required(),
+				mcp.Description("Whether to approve or deny the request"),
+				mcp.Enum("approve", "deny"),
+			),
+			mcp.WithString("reason",
+				mcp.Description("Reason for approving or denying the request (max 1024 characters)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			requestID, err := RequiredInt(request, "request_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			action, err := RequiredParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := OptionalParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Organizations.ReviewPersonalAccessTokenRequest(ctx, org, int64(requestID), github.ReviewPersonalAccessTokenRequestOptions{
+				Action: action,
+				Reason: ToStringPtr(reason),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to %s personal access token request %d", action, requestID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("personal access token request %d: %s succeeded", requestID, action)), nil
+		}
+}
+
+// MinimalCustomRepoRole is the trimmed output type for custom repository roles.
+type MinimalCustomRepoRole struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BaseRole    string   `json:"base_role"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+// ListOrgCustomRepoRoles creates a tool to list the custom repository roles of an organization.
+func ListOrgCustomRepoRoles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_custom_repository_roles",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CUSTOM_REPOSITORY_ROLES_DESCRIPTION", "List the custom repository roles of an organization. Each role extends a base role (read, triage, write or maintain) with additional fine-grained permissions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CUSTOM_REPOSITORY_ROLES_USER_TITLE", "List custom repository roles"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			roles, resp, err := client.Organizations.ListCustomRepoRoles(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list custom repository roles for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalCustomRepoRole, 0, len(roles.CustomRepoRoles))
+			for _, role := range roles.CustomRepoRoles {
+				result = append(result, MinimalCustomRepoRole{
+					ID:          role.GetID(),
+					Name:        role.GetName(),
+					Description: role.GetDescription(),
+					BaseRole:    role.GetBaseRole(),
+					Permissions: role.Permissions,
+					CreatedAt:   formatOptionalTimestamp(role.CreatedAt),
+					UpdatedAt:   formatOptionalTimestamp(role.UpdatedAt),
+				})
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// GetRepositoryPermission creates a tool to get the role a user holds on a repository.
+func GetRepositoryPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_permission",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_PERMISSION_DESCRIPTION", "Get the effective permission of a user on a repository. 'role_name' is the custom repository role when one is assigned, and 'permission' the legacy permission it maps to (admin, write, read or none).")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_PERMISSION_USER_TITLE", "Get repository permission"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get permission of '%s' on '%s/%s'", username, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, map[string]string{
+				"username":   username,
+				"permission": level.GetPermission(),
+				"role_name":  level.GetRoleName(),
+			}), nil
+		}
+}
+
+// repositoryRolePermissions maps the role names shown in the GitHub UI to the permission values of the API.
+// Custom role names are passed through unchanged.
+var repositoryRolePermissions = map[string]string{
+	"read":  "pull",
+	"write": "push",
+}
+
+// AssignRepositoryRole creates a tool to grant a built-in or custom repository role to a user or team.
+func AssignRepositoryRole(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("assign_repository_role",
+			mcp.WithDescription(t("TOOL_ASSIGN_REPOSITORY_ROLE_DESCRIPTION", "Grant a repository role to a user or a team, replacing the role they held. The role is either built-in (read, triage, write, maintain, admin) or the name of a custom repository role of the organization. Users who are not collaborators yet receive an invitation.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ASSIGN_REPOSITORY_ROLE_USER_TITLE", "Assign repository role"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner. For teams, the organization the team belongs to."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("role",
+				mcp.Required(),
+				mcp.Description("Built-in role (read, triage, write, maintain, admin) or custom repository role name"),
+			),
+			mcp.WithString("username",
+				mcp.Description("GitHub username to grant the role to. Provide either username or team_slug."),
+			),
+			mcp.WithString("team_slug",
+				mcp.Description("Slug of the team to grant the role to. Provide either username or team_slug."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := RequiredParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := OptionalParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (username == "") == (teamSlug == "") {
+				return mcp.NewToolResultError("exactly one of username or team_slug must be provided"), nil
+			}
+
+			permission := role
+			if mapped, ok := repositoryRolePermissions[role]; ok {
+				permission = mapped
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if teamSlug != "" {
+				resp, err := client.Teams.AddTeamRepoBySlug(ctx, owner, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{
+					Permission: permission,
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to assign role '%s' to team '%s' on '%s/%s'", role, teamSlug, owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return mcp.NewToolResultText(fmt.Sprintf("team '%s' now has role '%s' on '%s/%s'", teamSlug, role, owner, repo)), nil
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to assign role '%s' to user '%s' on '%s/%s'", role, username, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The API creates an invitation for new collaborators, and updates the role of existing ones.
+			if resp.StatusCode == http.StatusCreated {
+				return mcp.NewToolResultText(fmt.Sprintf("invited user '%s' to '%s/%s' with role '%s' (invitation %d)", username, owner, repo, role, invitation.GetID())), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("user '%s' now has role '%s' on '%s/%s'", username, role, owner, repo)), nil
+		}
+}
+
+// MinimalAppInstallation is the trimmed output type for GitHub App installations on an organization.
+type MinimalAppInstallation struct {
+	ID                  int64                           `json:"id"`
+	AppID               int64                           `json:"app_id"`
+	AppSlug             string                          `json:"app_slug"`
+	RepositorySelection string                          `json:"repository_selection"`
+	Permissions         *github.InstallationPermissions `json:"permissions,omitempty"`
+	Events              []string                        `json:"events,omitempty"`
+	CreatedAt           string                          `json:"created_at,omitempty"`
+	UpdatedAt           string                          `json:"updated_at,omitempty"`
+	SuspendedAt         string                          `json:"suspended_at,omitempty"`
+	SuspendedBy         string                          `json:"suspended_by,omitempty"`
+}
+
+func convertToMinimalAppInstallation(installation *github.Installation) MinimalAppInstallation {
+	return MinimalAppInstallation{
+		ID:                  installation.GetID(),
+		AppID:               installation.GetAppID(),
+		AppSlug:             installation.GetAppSlug(),
+		RepositorySelection: installation.GetRepositorySelection(),
+		Permissions:         installation.Permissions,
+		Events:              installation.Events,
+		CreatedAt:           formatOptionalTimestamp(installation.CreatedAt),
+		UpdatedAt:           formatOptionalTimestamp(installation.UpdatedAt),
+		SuspendedAt:         formatOptionalTimestamp(installation.SuspendedAt),
+		SuspendedBy:         installation.GetSuspendedBy().GetLogin(),
+	}
+}
+
+// ListOrgAppInstallations creates a tool to list the GitHub Apps installed on an organization.
+func ListOrgAppInstallations(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_app_installations",
+			mcp.WithDescription(t("TOOL_LIST_ORG_APP_INSTALLATIONS_DESCRIPTION", "List the GitHub Apps installed on an organization, with the permissions and webhook events granted to each installation and whether it can access all or selected repositories. Use this to inventory third-party integrations. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_APP_INSTALLATIONS_USER_TITLE", "List organization app installations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			installations, resp, err := client.Organizations.ListInstallations(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list app installations for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalAppInstallation, 0, len(installations.Installations))
+			for _, installation := range installations.Installations {
+				result = append(result, convertToMinimalAppInstallation(installation))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count":   installations.GetTotalCount(),
+				"installations": result,
+			}), nil
+		}
+}
+
+// ListOrgWebhooks creates a tool to list the webhooks of an organization.
+func ListOrgWebhooks(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_webhooks",
+			mcp.WithDescription(t("TOOL_LIST_ORG_WEBHOOKS_DESCRIPTION", "List the webhooks of an organization with their payload URLs, subscribed events, whether they are active, signed with a secret or skip TLS certificate verification, and the outcome of their last delivery. Use this to inventory where organization events are sent. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_WEBHOOKS_USER_TITLE", "List organization webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hooks, resp, err := client.Organizations.ListHooks(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list webhooks for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalWebhook, 0, len(hooks))
+			for _, hook := range hooks {
+				result = append(result, convertToMinimalWebhook(hook))
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// ListInstallationRepositories creates a tool to list the repositories an app installation can access.
+func ListInstallationRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_installation_repositories",
+			mcp.WithDescription(t("TOOL_LIST_INSTALLATION_REPOSITORIES_DESCRIPTION", "List the repositories a GitHub App installation can access, limited to those the authenticated user can also access. Use 'list_org_app_installations' to find the installation ID.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_INSTALLATION_REPOSITORIES_USER_TITLE", "List app installation repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("installation_id",
+				mcp.Required(),
+				mcp.Description("The ID of the app installation"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			installationID, err := RequiredInt(request, "installation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Apps.ListUserRepos(ctx, int64(installationID), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories of app installation %d", installationID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalRepository, 0, len(repos.Repositories))
+			for _, repo := range repos.Repositories {
+				if !repositoryAllowed(ctx, repo.GetOwner().GetLogin(), repo.GetName()) {
+					continue
+				}
+				result = append(result, convertToMinimalRepository(repo))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_count":  repos.GetTotalCount(),
+				"repositories": result,
+			}), nil
+		}
+}
+
+// UpdateInstallationRepository creates a tool to grant or revoke an app installation's access to a repository.
+func UpdateInstallationRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_installation_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_INSTALLATION_REPOSITORY_DESCRIPTION", "Add a repository to, or remove it from, a GitHub App installation that has access to selected repositories. Requires a user access token of a user with admin access to the repository and to the installation's organization; installation and app tokens can't change the repositories of an installation.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_INSTALLATION_REPOSITORY_USER_TITLE", "Update app installation repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithNumber("installation_id",
+				mcp.Required(),
+				mcp.Description("The ID of the app installation"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("action",
+				mcp.Required(),
+				mcp.Description("Whether to add the repository to the installation or remove it"),
+				mcp.Enum("add", "remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			installationID, err := RequiredInt(request, "installation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			action, err := RequiredParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if action != "add" && action != "remove" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid action '%s', must be 'add' or 'remove'", action)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The installation endpoints take the repository ID rather than its name.
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if action == "add" {
+				_, resp, err = client.Apps.AddRepository(ctx, int64(installationID), repository.GetID())
+			} else {
+				resp, err = client.Apps.RemoveRepository(ctx, int64(installationID), repository.GetID())
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to %s repository %s/%s for app installation %d", action, owner, repo, installationID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if action == "add" {
+				return mcp.NewToolResultText(fmt.Sprintf("repository %s/%s added to app installation %d", owner, repo, installationID)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("repository %s/%s removed from app installation %d", owner, repo, installationID)), nil
+		}
+}
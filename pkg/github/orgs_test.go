@@ -0,0 +1,1222 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgExternalIdentities(t *testing.T) {
+	t.Parallel()
+
+	tool, _ := ListOrgExternalIdentities(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_external_identities", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "list_org_external_identities tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "login")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	queryStr := "query($after:String$first:Int!$login:String$org:String!){organization(login: $org){samlIdentityProvider{externalIdentities(first: $first, after: $after, login: $login){nodes{guid,samlIdentity{nameId,username,emails{value}},scimIdentity{username,emails{value}},user{login}},pageInfo{hasNextPage,endCursor},totalCount}}}}"
+
+	mockIdentitiesResponse := githubv4mock.DataResponse(map[string]any{
+		"organization": map[string]any{
+			"samlIdentityProvider": map[string]any{
+				"externalIdentities": map[string]any{
+					"nodes": []map[string]any{
+						{
+							"guid": "guid-1",
+							"samlIdentity": map[string]any{
+								"nameId":   "octocat@example.com",
+								"username": "octocat",
+								"emails":   []map[string]any{{"value": "octocat@example.com"}},
+							},
+							"scimIdentity": nil,
+							"user":         map[string]any{"login": "octocat"},
+						},
+						{
+							"guid":         "guid-2",
+							"samlIdentity": nil,
+							"scimIdentity": map[string]any{
+								"username": "hubot",
+								"emails":   []map[string]any{{"value": "hubot@example.com"}},
+							},
+							"user": nil,
+						},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage": true,
+						"endCursor":   "cursor-2",
+					},
+					"totalCount": 5,
+				},
+			},
+		},
+	})
+
+	mockNoProviderResponse := githubv4mock.DataResponse(map[string]any{
+		"organization": map[string]any{
+			"samlIdentityProvider": nil,
+		},
+	})
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		vars               map[string]any
+		response           githubv4mock.GQLResponse
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "successful list",
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			vars: map[string]any{
+				"org":   "octo-org",
+				"first": float64(30),
+				"after": nil,
+				"login": nil,
+			},
+			response: mockIdentitiesResponse,
+		},
+		{
+			name: "filter by login with cursor",
+			requestArgs: map[string]any{
+				"org":     "octo-org",
+				"login":   "octocat",
+				"perPage": float64(10),
+				"after":   "cursor-1",
+			},
+			vars: map[string]any{
+				"org":   "octo-org",
+				"first": float64(10),
+				"after": "cursor-1",
+				"login": "octocat",
+			},
+			response: mockIdentitiesResponse,
+		},
+		{
+			name: "organization without SAML provider",
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			vars: map[string]any{
+				"org":   "octo-org",
+				"first": float64(30),
+				"after": nil,
+				"login": nil,
+			},
+			response:           mockNoProviderResponse,
+			expectToolError:    true,
+			expectedToolErrMsg: "does not have a SAML identity provider configured",
+		},
+		{
+			name: "query fails",
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			vars: map[string]any{
+				"org":   "octo-org",
+				"first": float64(30),
+				"after": nil,
+				"login": nil,
+			},
+			response:           githubv4mock.ErrorResponse("Could not resolve to an Organization"),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to list external identities",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(queryStr, tc.vars, tc.response)
+			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
+			_, handler := ListOrgExternalIdentities(stubGetGQLClientFn(githubv4.NewClient(httpClient)), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				assert.True(t, result.IsError, "expected tool call result to be an error")
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var response struct {
+				Identities []ExternalIdentity `json:"identities"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			require.Len(t, response.Identities, 2)
+			assert.Equal(t, "octocat", response.Identities[0].Login)
+			require.NotNil(t, response.Identities[0].SAML)
+			assert.Equal(t, "octocat@example.com", response.Identities[0].SAML.NameID)
+			assert.Nil(t, response.Identities[0].SCIM)
+			assert.Empty(t, response.Identities[1].Login)
+			require.NotNil(t, response.Identities[1].SCIM)
+			assert.Equal(t, []string{"hubot@example.com"}, response.Identities[1].SCIM.Emails)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "cursor-2", response.PageInfo.EndCursor)
+			assert.Equal(t, 5, response.TotalCount)
+		})
+	}
+}
+
+func Test_ListOrgFineGrainedPATs(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgFineGrainedPATs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_fine_grained_pats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "repository")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockPATs := []*github.PersonalAccessToken{
+		{
+			ID:                  github.Ptr(int64(25381)),
+			TokenID:             github.Ptr(int64(98716)),
+			TokenName:           github.Ptr("deploy-bot"),
+			Owner:               &github.User{Login: github.Ptr("octocat")},
+			RepositorySelection: github.Ptr("subset"),
+			Permissions: &github.PersonalAccessTokenPermissions{
+				Repo: map[string]string{"contents": "write"},
+			},
+			TokenExpired:   github.Ptr(false),
+			TokenExpiresAt: &github.Timestamp{Time: expiresAt},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPersonalAccessTokensByOrg,
+					expectQueryParams(t, map[string]string{
+						"repository": "hello-world",
+						"permission": "contents",
+						"page":       "1",
+						"per_page":   "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPATs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"repository": "hello-world",
+				"permission": "contents",
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPersonalAccessTokensByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by personal access token"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list fine-grained personal access tokens",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgFineGrainedPATs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned []MinimalFineGrainedPAT
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(25381), returned[0].ID)
+			assert.Equal(t, "deploy-bot", returned[0].TokenName)
+			assert.Equal(t, "octocat", returned[0].Owner)
+			assert.Equal(t, "write", returned[0].Permissions.Repo["contents"])
+			assert.Equal(t, "2026-01-02T03:04:05Z", returned[0].TokenExpiresAt)
+			assert.Empty(t, returned[0].TokenLastUsedAt)
+		})
+	}
+}
+
+func Test_ListOrgPATRequests(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgPATRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_pat_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockRequests := []map[string]any{
+		{
+			"id":                   42,
+			"token_id":             7,
+			"token_name":           "ci-token",
+			"owner":                map[string]any{"login": "hubot"},
+			"repository_selection": "all",
+			"permissions_result": map[string]any{
+				"organization": map[string]any{"members": "read"},
+			},
+			"created_at":    "2025-06-01T10:00:00Z",
+			"token_expired": false,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPersonalAccessTokenRequestsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRequests),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPersonalAccessTokenRequestsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list personal access token requests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgPATRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned []MinimalFineGrainedPAT
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(42), returned[0].ID)
+			assert.Equal(t, int64(7), returned[0].TokenID)
+			assert.Equal(t, "ci-token", returned[0].TokenName)
+			assert.Equal(t, "hubot", returned[0].Owner)
+			assert.Equal(t, "read", returned[0].Permissions.Org["members"])
+			assert.Equal(t, "2025-06-01T10:00:00Z", returned[0].RequestedAt)
+		})
+	}
+}
+
+func Test_ReviewOrgPATRequest(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewOrgPATRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "review_org_pat_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "request_id", "action"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "approve with reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPersonalAccessTokenRequestsByOrgByPatRequestId,
+					expectRequestBody(t, map[string]any{
+						"action": "approve",
+						"reason": "needed for release automation",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"request_id": float64(42),
+				"action":     "approve",
+				"reason":     "needed for release automation",
+			},
+			expectedText: "personal access token request 42: approve succeeded",
+		},
+		{
+			name: "deny without reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPersonalAccessTokenRequestsByOrgByPatRequestId,
+					expectRequestBody(t, map[string]any{
+						"action": "deny",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"request_id": float64(43),
+				"action":     "deny",
+			},
+			expectedText: "personal access token request 43: deny succeeded",
+		},
+		{
+			name: "review fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPersonalAccessTokenRequestsByOrgByPatRequestId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"request_id": float64(42),
+				"action":     "approve",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to approve personal access token request 42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewOrgPATRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListOrgCustomRepoRoles(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCustomRepoRoles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_custom_repository_roles", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	roles := &github.OrganizationCustomRepoRoles{
+		TotalCount: github.Ptr(1),
+		CustomRepoRoles: []*github.CustomRepoRoles{
+			{
+				ID:          github.Ptr(int64(8030)),
+				Name:        github.Ptr("security-engineer"),
+				Description: github.Ptr("Write access plus security alerts"),
+				BaseRole:    github.Ptr("write"),
+				Permissions: []string{"delete_alerts_code_scanning", "view_secret_scanning_alerts"},
+				CreatedAt:   &github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list roles",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCustomRepositoryRolesByOrg,
+					roles,
+				),
+			),
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCustomRepositoryRolesByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list custom repository roles for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCustomRepoRoles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{"org": "octo-org"})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalCustomRepoRole
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, MinimalCustomRepoRole{
+				ID:          8030,
+				Name:        "security-engineer",
+				Description: "Write access plus security alerts",
+				BaseRole:    "write",
+				Permissions: []string{"delete_alerts_code_scanning", "view_secret_scanning_alerts"},
+				CreatedAt:   "2024-01-02T03:04:05Z",
+			}, returned[0])
+		})
+	}
+}
+
+func Test_GetRepositoryPermission(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+			&github.RepositoryPermissionLevel{
+				Permission: github.Ptr("write"),
+				RoleName:   github.Ptr("security-engineer"),
+			},
+		),
+	))
+	_, handler := GetRepositoryPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner":    "octo-org",
+		"repo":     "api",
+		"username": "octocat",
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned map[string]string
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, map[string]string{
+		"username":   "octocat",
+		"permission": "write",
+		"role_name":  "security-engineer",
+	}, returned)
+}
+
+func Test_AssignRepositoryRole(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := AssignRepositoryRole(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "assign_repository_role", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "role"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "assign custom role to team",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"permission": "security-engineer",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "octo-org",
+				"repo":      "api",
+				"role":      "security-engineer",
+				"team_slug": "appsec",
+			},
+			expectedText: "team 'appsec' now has role 'security-engineer' on 'octo-org/api'",
+		},
+		{
+			name: "update role of existing collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{
+						"permission": "pull",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "octo-org",
+				"repo":     "api",
+				"role":     "read",
+				"username": "octocat",
+			},
+			expectedText: "user 'octocat' now has role 'read' on 'octo-org/api'",
+		},
+		{
+			name: "invite new collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{ID: github.Ptr(int64(7))}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "octo-org",
+				"repo":     "api",
+				"role":     "triage",
+				"username": "newcomer",
+			},
+			expectedText: "invited user 'newcomer' to 'octo-org/api' with role 'triage' (invitation 7)",
+		},
+		{
+			name:         "both user and team",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "octo-org",
+				"repo":      "api",
+				"role":      "write",
+				"username":  "octocat",
+				"team_slug": "appsec",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of username or team_slug must be provided",
+		},
+		{
+			name: "unknown role",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "octo-org",
+				"repo":     "api",
+				"role":     "nonexistent",
+				"username": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to assign role 'nonexistent' to user 'octocat' on 'octo-org/api'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AssignRepositoryRole(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListOrgAppInstallations(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgAppInstallations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_app_installations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	suspendedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	mockInstallations := &github.OrganizationInstallations{
+		TotalCount: github.Ptr(2),
+		Installations: []*github.Installation{
+			{
+				ID:                  github.Ptr(int64(1)),
+				AppID:               github.Ptr(int64(10)),
+				AppSlug:             github.Ptr("ci-bot"),
+				RepositorySelection: github.Ptr("all"),
+				Permissions: &github.InstallationPermissions{
+					Contents: github.Ptr("write"),
+					Metadata: github.Ptr("read"),
+				},
+				Events: []string{"push", "pull_request"},
+			},
+			{
+				ID:                  github.Ptr(int64(2)),
+				AppID:               github.Ptr(int64(20)),
+				AppSlug:             github.Ptr("old-tracker"),
+				RepositorySelection: github.Ptr("selected"),
+				SuspendedAt:         &github.Timestamp{Time: suspendedAt},
+				SuspendedBy:         &github.User{Login: github.Ptr("octocat")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockInstallations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInstallationsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must be an organization owner"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list app installations for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgAppInstallations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				TotalCount    int                      `json:"total_count"`
+				Installations []MinimalAppInstallation `json:"installations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 2, returned.TotalCount)
+			require.Len(t, returned.Installations, 2)
+			assert.Equal(t, "ci-bot", returned.Installations[0].AppSlug)
+			assert.Equal(t, "all", returned.Installations[0].RepositorySelection)
+			assert.Equal(t, "write", returned.Installations[0].Permissions.GetContents())
+			assert.Equal(t, []string{"push", "pull_request"}, returned.Installations[0].Events)
+			assert.Empty(t, returned.Installations[0].SuspendedAt)
+			assert.Equal(t, "2026-03-04T05:06:07Z", returned.Installations[1].SuspendedAt)
+			assert.Equal(t, "octocat", returned.Installations[1].SuspendedBy)
+		})
+	}
+}
+
+func Test_ListOrgWebhooks(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgWebhooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_webhooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockHooks := []map[string]any{
+		{
+			"id":     1,
+			"name":   "web",
+			"active": true,
+			"events": []string{"push"},
+			"config": map[string]any{
+				"url":          "https://ci.example.com/hook",
+				"content_type": "json",
+				"insecure_ssl": "0",
+				"secret":       "********",
+			},
+			"last_response": map[string]any{"code": 200, "status": "active", "message": "OK"},
+		},
+		{
+			"id":     2,
+			"name":   "web",
+			"active": false,
+			"events": []string{"*"},
+			"config": map[string]any{
+				"url":          "http://legacy.example.com/hook",
+				"content_type": "form",
+				"insecure_ssl": "1",
+			},
+			"last_response": map[string]any{"code": nil, "status": "unused", "message": nil},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedHooks  []MinimalWebhook
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockHooks),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedHooks: []MinimalWebhook{
+				{
+					ID:                 1,
+					URL:                "https://ci.example.com/hook",
+					Active:             true,
+					Events:             []string{"push"},
+					ContentType:        "json",
+					HasSecret:          true,
+					LastResponseCode:   200,
+					LastResponseStatus: "active",
+				},
+				{
+					ID:                 2,
+					URL:                "http://legacy.example.com/hook",
+					Events:             []string{"*"},
+					ContentType:        "form",
+					InsecureSSL:        true,
+					LastResponseStatus: "unused",
+				},
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhooks for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgWebhooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned []MinimalWebhook
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedHooks, returned)
+		})
+	}
+}
+
+func Test_ListInstallationRepositories(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListInstallationRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_installation_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "installation_id")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"installation_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserInstallationsRepositoriesByInstallationId,
+					expectPath(t, "/user/installations/42/repositories").andThen(
+						mockResponse(t, http.StatusOK, &github.ListRepositories{
+							TotalCount: github.Ptr(1),
+							Repositories: []*github.Repository{
+								{ID: github.Ptr(int64(7)), Name: github.Ptr("api"), FullName: github.Ptr("octo-org/api"), Private: github.Ptr(true)},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserInstallationsRepositoriesByInstallationId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "You must authenticate with an access token authorized to a GitHub App"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories of app installation 42",
+		},
+		{
+			name:         "missing installation_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs:  map[string]interface{}{},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListInstallationRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				TotalCount   int                 `json:"total_count"`
+				Repositories []MinimalRepository `json:"repositories"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 1, returned.TotalCount)
+			require.Len(t, returned.Repositories, 1)
+			assert.Equal(t, "octo-org/api", returned.Repositories[0].FullName)
+			assert.True(t, returned.Repositories[0].Private)
+		})
+	}
+}
+
+func Test_UpdateInstallationRepository(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateInstallationRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_installation_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"installation_id", "owner", "repo", "action"})
+
+	getRepository := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposByOwnerByRepo,
+			&github.Repository{ID: github.Ptr(int64(7)), Name: github.Ptr("api")},
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "add repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRepository(),
+				mock.WithRequestMatchHandler(
+					mock.PutUserInstallationsRepositoriesByInstallationIdByRepositoryId,
+					expectPath(t, "/user/installations/42/repositories/7").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+				"owner":           "octo-org",
+				"repo":            "api",
+				"action":          "add",
+			},
+			expectedText: "repository octo-org/api added to app installation 42",
+		},
+		{
+			name: "remove repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRepository(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserInstallationsRepositoriesByInstallationIdByRepositoryId,
+					expectPath(t, "/user/installations/42/repositories/7").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+				"owner":           "octo-org",
+				"repo":            "api",
+				"action":          "remove",
+			},
+			expectedText: "repository octo-org/api removed from app installation 42",
+		},
+		{
+			name: "installation with access to all repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRepository(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserInstallationsRepositoriesByInstallationIdByRepositoryId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Installations with access to all repositories cannot have repositories removed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+				"owner":           "octo-org",
+				"repo":            "api",
+				"action":          "remove",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to remove repository octo-org/api for app installation 42",
+		},
+		{
+			name:         "invalid action",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"installation_id": float64(42),
+				"owner":           "octo-org",
+				"repo":            "api",
+				"action":          "grant",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid action 'grant'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateInstallationRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
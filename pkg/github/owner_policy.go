@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OwnerPolicy restricts the owners and repositories tools may be called on, so that a server can be limited to an
+// organization even if its token has access to more. Owners and repositories are matched case-insensitively against
+// glob patterns, such as "my-org" or "my-org/service-*". Denying takes precedence over allowing.
+type OwnerPolicy struct {
+	allowOwners []string
+	denyOwners  []string
+	allowRepos  []string
+	denyRepos   []string
+}
+
+// policyTarget is an owner, or a repository if repo isn't empty, named in the arguments of a tool call. A wide
+// target is every repository of an owner at once, such as a search qualified with org:, whose results can't be
+// filtered by repository.
+type policyTarget struct {
+	owner string
+	repo  string
+	wide  bool
+}
+
+func (t policyTarget) String() string {
+	if t.repo == "" {
+		return t.owner
+	}
+	return t.owner + "/" + t.repo
+}
+
+// NewOwnerPolicy creates an OwnerPolicy. Owner patterns are owner names, and repository patterns are of the form
+// owner/repo. It returns nil if no patterns are given, which allows every call.
+func NewOwnerPolicy(allowOwners, denyOwners, allowRepos, denyRepos []string) (*OwnerPolicy, error) {
+	if len(allowOwners)+len(denyOwners)+len(allowRepos)+len(denyRepos) == 0 {
+		return nil, nil
+	}
+
+	normalize := func(patterns []string, repo bool) ([]string, error) {
+		normalized := make([]string, 0, len(patterns))
+		for _, pattern := range patterns {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if pattern == "" {
+				continue
+			}
+			if repo != strings.Contains(pattern, "/") || strings.Count(pattern, "/") > 1 {
+				if repo {
+					return nil, fmt.Errorf("invalid repository pattern %q, use owner/repo", pattern)
+				}
+				return nil, fmt.Errorf("invalid owner pattern %q, use the repository patterns to restrict repositories", pattern)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			normalized = append(normalized, pattern)
+		}
+		return normalized, nil
+	}
+
+	var p OwnerPolicy
+	var err error
+	if p.allowOwners, err = normalize(allowOwners, false); err != nil {
+		return nil, err
+	}
+	if p.denyOwners, err = normalize(denyOwners, false); err != nil {
+		return nil, err
+	}
+	if p.allowRepos, err = normalize(allowRepos, true); err != nil {
+		return nil, err
+	}
+	if p.denyRepos, err = normalize(denyRepos, true); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether a call may access the target. Without a repository, an owner is allowed if it's allowed
+// itself or owns one of the allowed repositories, and the repositories of the owner the call goes through are
+// filtered with repositoryAllowed. A wide target must be allowed as an owner, with none of its repositories denied.
+func (p *OwnerPolicy) allowed(target policyTarget) bool {
+	owner, repo := strings.ToLower(target.owner), strings.ToLower(target.repo)
+	fullName := owner + "/" + repo
+
+	if matchesAny(p.denyOwners, owner) || (repo != "" && matchesAny(p.denyRepos, fullName)) {
+		return false
+	}
+	if target.wide {
+		for _, pattern := range p.denyRepos {
+			if ok, _ := path.Match(strings.SplitN(pattern, "/", 2)[0], owner); ok {
+				return false
+			}
+		}
+		return !p.restricted() || matchesAny(p.allowOwners, owner)
+	}
+	if !p.restricted() {
+		return true
+	}
+	if matchesAny(p.allowOwners, owner) {
+		return true
+	}
+	if repo != "" {
+		return matchesAny(p.allowRepos, fullName)
+	}
+	for _, pattern := range p.allowRepos {
+		if ok, _ := path.Match(strings.SplitN(pattern, "/", 2)[0], owner); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// restricted reports whether the policy only allows some owners or repositories.
+func (p *OwnerPolicy) restricted() bool {
+	return len(p.allowOwners) > 0 || len(p.allowRepos) > 0
+}
+
+// ownerArgumentSuffixes are the names of the arguments naming an owner, alone or after a prefix such as in
+// template_owner or content_owner.
+var ownerArgumentSuffixes = []string{"owner", "org", "organization"}
+
+// repositoryArgumentSuffixes are the names of the arguments naming repositories of the owner with the same prefix,
+// such as template_repo for template_owner.
+var repositoryArgumentSuffixes = []string{"repo", "repository", "repository_name", "repositories"}
+
+// searchQualifiers are the search qualifiers limiting a search to a repository, or to every repository of an owner.
+var searchQualifiers = map[string]bool{"repo": true, "org": true, "user": true}
+
+// stringArguments returns a string argument, or the strings of an array argument.
+func stringArguments(value any) []string {
+	switch value := value.(type) {
+	case string:
+		if value != "" {
+			return []string{value}
+		}
+	case []any:
+		var values []string
+		for _, element := range value {
+			if s, ok := element.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	case []string:
+		return value
+	}
+	return nil
+}
+
+// queryTargets lists the repositories and owners a search query is limited to with repo:, org: and user:
+// qualifiers. Qualifier names are matched case-insensitively, excluded qualifiers such as -repo: are ignored.
+func queryTargets(query string) []policyTarget {
+	var targets []policyTarget
+	for _, term := range strings.Fields(query) {
+		name, value, ok := strings.Cut(term, ":")
+		name = strings.ToLower(name)
+		value = strings.Trim(value, `"`)
+		if !ok || !searchQualifiers[name] || value == "" {
+			continue
+		}
+		if name == "repo" {
+			owner, repo, _ := strings.Cut(value, "/")
+			targets = append(targets, policyTarget{owner: owner, repo: repo})
+			continue
+		}
+		targets = append(targets, policyTarget{owner: value, wide: true})
+	}
+	return targets
+}
+
+// argumentPrefix returns the prefix of an argument named by one of the suffixes alone or after a prefix ending in
+// an underscore, such as template_ in template_owner.
+func argumentPrefix(key string, suffixes []string) (string, bool) {
+	for _, suffix := range suffixes {
+		if prefix, ok := strings.CutSuffix(key, suffix); ok && (prefix == "" || strings.HasSuffix(prefix, "_")) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// policyTargets lists the owners and repositories named in the arguments of a tool call:
+//   - owners in arguments named owner, org or organization, optionally after a prefix such as template_, with the
+//     repositories in the arguments named repo, repository, repository_name or repositories after the same prefix;
+//   - full names (owner/repo) in any other argument naming repositories, such as the repositories to unstar;
+//   - the repo:, org: and user: qualifiers of search queries, in arguments named query or ending in _query.
+//
+// searches reports whether the call has a search query.
+func policyTargets(args map[string]any) (targets []policyTarget, searches bool) {
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// ownerOf returns the owner of the repositories named with a prefix: the first of the owner, org and
+	// organization arguments with the same prefix.
+	ownerOf := func(prefix string) string {
+		for _, suffix := range ownerArgumentSuffixes {
+			if owner, _ := args[prefix+suffix].(string); owner != "" {
+				return owner
+			}
+		}
+		return ""
+	}
+
+	for _, key := range keys {
+		value := args[key]
+		if key == "query" || strings.HasSuffix(key, "_query") {
+			if query, ok := value.(string); ok && strings.TrimSpace(query) != "" {
+				searches = true
+				targets = append(targets, queryTargets(query)...)
+			}
+			continue
+		}
+		if _, ok := argumentPrefix(key, ownerArgumentSuffixes); ok {
+			if owner, _ := value.(string); owner != "" {
+				targets = append(targets, policyTarget{owner: owner})
+			}
+			continue
+		}
+		if !strings.Contains(key, "repo") {
+			continue
+		}
+		owner := ""
+		if prefix, ok := argumentPrefix(key, repositoryArgumentSuffixes); ok {
+			owner = ownerOf(prefix)
+		}
+		for _, name := range stringArguments(value) {
+			if owner != "" {
+				targets = append(targets, policyTarget{owner: owner, repo: name})
+			} else if fullOwner, fullRepo, ok := strings.Cut(name, "/"); ok {
+				targets = append(targets, policyTarget{owner: fullOwner, repo: fullRepo})
+			}
+		}
+	}
+	return targets, searches
+}
+
+// ownerFreeTools are the tools that may be called without naming an owner or repository when the policy only allows
+// some owners or repositories: tools that don't access repositories, and tools that check the repositories they
+// access themselves with repositoryAllowed, such as the notification tools.
+var ownerFreeTools = map[string]bool{
+	"get_me":                          true,
+	"get_teams":                       true,
+	"get_tool_catalog":                true,
+	"get_recent_operations":           true,
+	"list_pending_operations":         true,
+	"enable_toolset":                  true,
+	"list_available_toolsets":         true,
+	"get_toolset_tools":               true,
+	"list_gists":                      true,
+	"create_gist":                     true,
+	"update_gist":                     true,
+	"list_global_security_advisories": true,
+	"get_global_security_advisory":    true,
+	// Tools filtering the repositories they access
+	"discover_repositories":            true,
+	"list_notifications":               true,
+	"get_notification_details":         true,
+	"dismiss_notification":             true,
+	"manage_notification_subscription": true,
+	"mark_all_notifications_read":      true,
+	"list_watched_repositories":        true,
+	"list_starred_repositories":        true,
+	"analyze_starred_repositories":     true,
+	"list_installation_repositories":   true,
+}
+
+type ownerPolicyKey struct{}
+
+// ownerPolicy returns the owner policy of a tool call, or nil if there is none.
+func ownerPolicy(ctx context.Context) *OwnerPolicy {
+	p, _ := ctx.Value(ownerPolicyKey{}).(*OwnerPolicy)
+	return p
+}
+
+// repositoryAllowed reports whether the owner policy of a tool call, if any, allows a repository. Tools going
+// through every repository of an owner, searching across them, or listing repositories of any owner, such as
+// notifications, use it to skip the repositories that aren't allowed.
+func repositoryAllowed(ctx context.Context, owner, repo string) bool {
+	p := ownerPolicy(ctx)
+	return p == nil || p.allowed(policyTarget{owner: owner, repo: repo})
+}
+
+// Middleware returns a tool handler middleware that rejects calls naming an owner or repository the policy doesn't
+// allow, before the handler runs. When the policy only allows some owners or repositories, searches must be limited
+// with repo:, org: or user: qualifiers, or by the owner arguments of the tool, and tools that don't name an owner or
+// repository are rejected unless they are in ownerFreeTools.
+func (p *OwnerPolicy) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			targets, searches := policyTargets(request.GetArguments())
+			for _, target := range targets {
+				if !p.allowed(target) {
+					if target.wide {
+						return mcp.NewToolResultError(fmt.Sprintf("searching all repositories of %s is not allowed by the configuration of this server, limit the search with repo: qualifiers", target)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("access to %s is not allowed by the configuration of this server", target)), nil
+				}
+			}
+			if searches && len(targets) == 0 && p.restricted() {
+				return mcp.NewToolResultError("searches must be limited with repo:, org: or user: qualifiers on this server"), nil
+			}
+			if !searches && len(targets) == 0 && p.restricted() && !ownerFreeTools[request.Params.Name] {
+				return mcp.NewToolResultError(fmt.Sprintf("%s must be called with an owner or repository allowed by the configuration of this server", request.Params.Name)), nil
+			}
+			return next(context.WithValue(ctx, ownerPolicyKey{}, p), request)
+		}
+	}
+}
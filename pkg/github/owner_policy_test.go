@@ -0,0 +1,350 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewOwnerPolicy(t *testing.T) {
+	policy, err := NewOwnerPolicy(nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy, "no patterns must not restrict anything")
+
+	_, err = NewOwnerPolicy([]string{"my-org/repo"}, nil, nil, nil)
+	assert.ErrorContains(t, err, "invalid owner pattern")
+
+	_, err = NewOwnerPolicy(nil, nil, []string{"repo"}, nil)
+	assert.ErrorContains(t, err, "invalid repository pattern")
+
+	_, err = NewOwnerPolicy(nil, []string{"[my-org"}, nil, nil)
+	assert.ErrorContains(t, err, "invalid pattern")
+}
+
+func Test_OwnerPolicy_Allowed(t *testing.T) {
+	policy, err := NewOwnerPolicy(
+		[]string{"My-Org"},
+		[]string{"evil-*"},
+		[]string{"partner/shared-*"},
+		[]string{"my-org/secrets"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		target  policyTarget
+		allowed bool
+	}{
+		{policyTarget{owner: "my-org"}, true},
+		{policyTarget{owner: "MY-ORG", repo: "service"}, true},
+		{policyTarget{owner: "my-org", repo: "Secrets"}, false},
+		{policyTarget{owner: "partner", repo: "shared-tools"}, true},
+		{policyTarget{owner: "partner", repo: "internal"}, false},
+		// An owner is allowed without a repository if one of its repositories is
+		{policyTarget{owner: "partner"}, true},
+		{policyTarget{owner: "other-org"}, false},
+		{policyTarget{owner: "other-org", repo: "repo"}, false},
+		// Every repository of an owner at once, only if the owner is allowed itself
+		{policyTarget{owner: "my-org", wide: true}, false},
+		{policyTarget{owner: "partner", wide: true}, false},
+		{policyTarget{owner: "evil-corp", wide: true}, false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.allowed, policy.allowed(tc.target), tc.target.String())
+	}
+
+	// Denying takes precedence, and without allow patterns everything else is allowed
+	policy, err = NewOwnerPolicy(nil, []string{"evil-*"}, nil, []string{"my-org/secrets"})
+	require.NoError(t, err)
+	assert.True(t, policy.allowed(policyTarget{owner: "anyone", repo: "repo"}))
+	assert.False(t, policy.allowed(policyTarget{owner: "Evil-Corp"}))
+	assert.False(t, policy.allowed(policyTarget{owner: "my-org", repo: "secrets"}))
+	assert.True(t, policy.allowed(policyTarget{owner: "anyone", wide: true}))
+	assert.False(t, policy.allowed(policyTarget{owner: "my-org", wide: true}), "a search of my-org would include my-org/secrets")
+}
+
+func Test_policyTargets(t *testing.T) {
+	targets, searches := policyTargets(map[string]any{
+		"owner":                           "my-org",
+		"repo":                            "service",
+		"content_owner":                   "other-org",
+		"content_repo":                    "tracker",
+		"repositories":                    []any{"service", "tools"},
+		"private_vulnerability_reporting": true,
+	})
+	assert.False(t, searches)
+	assert.Equal(t, []policyTarget{
+		{owner: "other-org"},
+		{owner: "other-org", repo: "tracker"},
+		{owner: "my-org"},
+		{owner: "my-org", repo: "service"},
+		{owner: "my-org", repo: "service"},
+		{owner: "my-org", repo: "tools"},
+	}, targets)
+
+	// Full names, and the repositories of org when there is no owner
+	targets, _ = policyTargets(map[string]any{"repositories": []any{"acme/web", "invalid"}})
+	assert.Equal(t, []policyTarget{{owner: "acme", repo: "web"}}, targets)
+	targets, _ = policyTargets(map[string]any{"org": "acme", "repositories": []any{"web"}})
+	assert.Equal(t, []policyTarget{{owner: "acme"}, {owner: "acme", repo: "web"}}, targets)
+
+	targets, searches = policyTargets(map[string]any{"query": `is:open Repo:acme/web org:"partner" -repo:acme/api user:`})
+	assert.True(t, searches)
+	assert.Equal(t, []policyTarget{{owner: "acme", repo: "web"}, {owner: "partner", wide: true}}, targets)
+}
+
+func Test_repositoryAllowed(t *testing.T) {
+	policy, err := NewOwnerPolicy(nil, nil, []string{"my-org/service-*"}, nil)
+	require.NoError(t, err)
+
+	// Without a policy every repository is allowed
+	assert.True(t, repositoryAllowed(context.Background(), "my-org", "internal"))
+
+	var ctx context.Context
+	handler := policy.Middleware()(func(handlerCtx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx = handlerCtx
+		return mcp.NewToolResultText("ok"), nil
+	})
+	_, err = handler(context.Background(), createMCPRequest(map[string]any{"org": "my-org"}))
+	require.NoError(t, err)
+
+	// A call on the owner of an allowed repository runs, and only sees the allowed repositories
+	require.NotNil(t, ctx)
+	assert.True(t, repositoryAllowed(ctx, "my-org", "service-api"))
+	assert.False(t, repositoryAllowed(ctx, "My-Org", "internal"))
+}
+
+func Test_OwnerPolicy_Middleware(t *testing.T) {
+	policy, err := NewOwnerPolicy([]string{"my-org"}, nil, nil, []string{"my-org/secrets"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		tool           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "allowed repository",
+			requestArgs: map[string]interface{}{"owner": "my-org", "repo": "service"},
+		},
+		{
+			name:        "tool that doesn't access repositories",
+			tool:        "get_me",
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name:           "tool without an owner",
+			tool:           "create_repository",
+			requestArgs:    map[string]interface{}{"name": "service"},
+			expectedErrMsg: "create_repository must be called with an owner or repository allowed",
+		},
+		{
+			name:           "unqualified search",
+			requestArgs:    map[string]interface{}{"query": "is:issue"},
+			expectedErrMsg: "searches must be limited with repo:, org: or user: qualifiers",
+		},
+		{
+			name:        "search of an allowed repository",
+			requestArgs: map[string]interface{}{"query": "is:issue repo:my-org/service"},
+		},
+		{
+			name:           "search of an organization with a denied repository",
+			requestArgs:    map[string]interface{}{"query": "is:issue org:my-org"},
+			expectedErrMsg: "searching all repositories of my-org is not allowed",
+		},
+		{
+			name:           "search of a denied repository",
+			requestArgs:    map[string]interface{}{"query": "is:issue repo:my-org/secrets"},
+			expectedErrMsg: "access to my-org/secrets is not allowed",
+		},
+		{
+			name:           "search of another organization",
+			requestArgs:    map[string]interface{}{"query": "is:issue user:other-org"},
+			expectedErrMsg: "searching all repositories of other-org is not allowed",
+		},
+		{
+			name:           "content of another owner",
+			requestArgs:    map[string]interface{}{"owner": "my-org", "project_number": 1, "content_owner": "other-org", "content_repo": "service"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+		{
+			name:           "full names",
+			requestArgs:    map[string]interface{}{"repositories": []any{"my-org/service", "other-org/service"}},
+			expectedErrMsg: "access to other-org/service is not allowed",
+		},
+		{
+			name:           "other owner",
+			requestArgs:    map[string]interface{}{"owner": "other-org", "repo": "service"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+		{
+			name:           "denied repository",
+			requestArgs:    map[string]interface{}{"owner": "my-org", "repo": "secrets"},
+			expectedErrMsg: "access to my-org/secrets is not allowed",
+		},
+		{
+			name:           "organization",
+			requestArgs:    map[string]interface{}{"org": "other-org"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+		{
+			name:           "repositories of an organization",
+			requestArgs:    map[string]interface{}{"org": "my-org", "repositories": []any{"service", "secrets"}},
+			expectedErrMsg: "access to my-org/secrets is not allowed",
+		},
+		{
+			name:           "transfer to another owner",
+			requestArgs:    map[string]interface{}{"owner": "my-org", "repo": "service", "new_owner": "other-org"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+		{
+			name:           "template of another owner",
+			requestArgs:    map[string]interface{}{"owner": "my-org", "template_owner": "other-org", "template_repo": "template"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			handler := policy.Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = true
+				return mcp.NewToolResultText("ok"), nil
+			})
+
+			request := createMCPRequest(tc.requestArgs)
+			request.Params.Name = tc.tool
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				assert.False(t, called, "the handler must not run")
+				return
+			}
+			require.False(t, result.IsError)
+			assert.True(t, called)
+		})
+	}
+}
+
+func Test_OwnerPolicy_OwnerFreeTools(t *testing.T) {
+	policy, err := NewOwnerPolicy([]string{"my-org"}, nil, nil, nil)
+	require.NoError(t, err)
+
+	repository := func(owner, name string) *github.Repository {
+		return &github.Repository{
+			Name:     github.Ptr(name),
+			FullName: github.Ptr(owner + "/" + name),
+			Owner:    &github.User{Login: github.Ptr(owner)},
+		}
+	}
+	allowed, other := repository("my-org", "service"), repository("other-org", "app")
+	notifications := []*github.Notification{
+		{ID: github.Ptr("1"), Repository: allowed},
+		{ID: github.Ptr("2"), Repository: other},
+	}
+	stars := []*github.StarredRepository{{Repository: allowed}, {Repository: other}}
+
+	tests := []struct {
+		name           string
+		tool           func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "notifications of other repositories are skipped",
+			tool:         ListNotifications,
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetNotifications, notifications)),
+			requestArgs:  map[string]any{},
+			expectedText: `"id":"1"`,
+		},
+		{
+			name:           "details of a notification of another repository",
+			tool:           GetNotificationDetails,
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetNotificationsThreadsByThreadId, notifications[1])),
+			requestArgs:    map[string]any{"notificationID": "2"},
+			expectedErrMsg: "access to other-org/app is not allowed",
+		},
+		{
+			// Only fetching the thread is mocked, dismissing it would fail with another error
+			name:           "dismissing a notification of another repository",
+			tool:           DismissNotification,
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetNotificationsThreadsByThreadId, notifications[1])),
+			requestArgs:    map[string]any{"threadID": "2", "state": "done"},
+			expectedErrMsg: "access to other-org/app is not allowed",
+		},
+		{
+			name:           "subscribing to a notification of another repository",
+			tool:           ManageNotificationSubscription,
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetNotificationsThreadsByThreadId, notifications[1])),
+			requestArgs:    map[string]any{"notificationID": "2", "action": "watch"},
+			expectedErrMsg: "access to other-org/app is not allowed",
+		},
+		{
+			name:           "marking every notification as read",
+			tool:           MarkAllNotificationsRead,
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectedErrMsg: "owner and repo are required",
+		},
+		{
+			name:           "marking the notifications of another repository as read",
+			tool:           MarkAllNotificationsRead,
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "other-org", "repo": "app"},
+			expectedErrMsg: "access to other-org is not allowed",
+		},
+		{
+			name:         "watched repositories of other owners are skipped",
+			tool:         ListWatchedRepositories,
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserSubscriptions, []*github.Repository{allowed, other})),
+			requestArgs:  map[string]any{},
+			expectedText: `[{"id":0,"name":"service","full_name":"my-org/service"`,
+		},
+		{
+			name:         "starred repositories of other owners are skipped",
+			tool:         ListStarredRepositories,
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserStarred, stars)),
+			requestArgs:  map[string]any{},
+			expectedText: `[{"id":0,"name":"service","full_name":"my-org/service"`,
+		},
+		{
+			name:         "starred repositories of other owners aren't analyzed",
+			tool:         AnalyzeStarredRepositories,
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserStarred, stars)),
+			requestArgs:  map[string]any{},
+			expectedText: `"analyzed":1`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, handler := tc.tool(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+			request.Params.Name = tool.Name
+
+			result, err := policy.Middleware()(handler)(context.Background(), request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			text := getTextResult(t, result).Text
+			assert.Contains(t, text, tc.expectedText)
+			assert.NotContains(t, text, "other-org")
+		})
+	}
+}
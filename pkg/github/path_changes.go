@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultPathChangesMaxCommits is the default number of commits aggregated by get_path_changes.
+	DefaultPathChangesMaxCommits = 200
+	// maxPathChangesCommits bounds the commits aggregated by get_path_changes, as every commit costs a
+	// request to find its pull requests.
+	maxPathChangesCommits = 500
+	// defaultPathChangesDays is the time window used when since is not given.
+	defaultPathChangesDays = 7
+)
+
+// PathChangeCommit is a commit touching a path, with the pull requests that introduced it.
+type PathChangeCommit struct {
+	SHA          string `json:"sha"`
+	Message      string `json:"message"`
+	Author       string `json:"author"`
+	Date         string `json:"date"`
+	PullRequests []int  `json:"pull_requests,omitempty"`
+}
+
+// PathChangePullRequest is a pull request that introduced commits touching a path.
+type PathChangePullRequest struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Author   string `json:"author"`
+	MergedAt string `json:"merged_at,omitempty"`
+	URL      string `json:"url"`
+	Commits  int    `json:"commits"`
+}
+
+// PathChangeAuthor is an author of commits touching a path.
+type PathChangeAuthor struct {
+	Author     string `json:"author"`
+	Commits    int    `json:"commits"`
+	LastCommit string `json:"last_commit"`
+}
+
+// commitAuthorName returns the GitHub login of a commit's author, falling back to the name in the commit.
+func commitAuthorName(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
+}
+
+// GetPathChanges creates a tool to summarize the commits, pull requests and authors that touched a path.
+func GetPathChanges(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_path_changes",
+			mcp.WithDescription(t("TOOL_GET_PATH_CHANGES_DESCRIPTION", "Get a digest of the changes to a directory or file of a GitHub repository within a time window: the commits touching the path, the pull requests that introduced them and the authors, aggregated across all pages. Use it to follow changes to a subtree of a monorepo.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PATH_CHANGES_USER_TITLE", "Get changes to a path"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path prefix of the subtree, e.g. 'services/billing', or a file path"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Commit SHA, branch or tag name to read history from. Defaults to the default branch of the repository."),
+			),
+			mcp.WithString("since",
+				mcp.Description(fmt.Sprintf("Start of the time window (ISO 8601 timestamp). Defaults to %d days ago.", defaultPathChangesDays)),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the time window (ISO 8601 timestamp). Defaults to now."),
+			),
+			mcp.WithNumber("max_commits",
+				mcp.Description(fmt.Sprintf("Maximum number of commits to aggregate (default %d, max %d)", DefaultPathChangesMaxCommits, maxPathChangesCommits)),
+				mcp.Min(1),
+				mcp.Max(maxPathChangesCommits),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path = strings.Trim(path, "/")
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "max_commits", DefaultPathChangesMaxCommits)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxCommits < 1 || maxCommits > maxPathChangesCommits {
+				return mcp.NewToolResultError(fmt.Sprintf("max_commits must be between 1 and %d", maxPathChangesCommits)), nil
+			}
+
+			untilTime := time.Now()
+			if until != "" {
+				untilTime, err = parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until timestamp: %s", err)), nil
+				}
+			}
+			sinceTime := untilTime.AddDate(0, 0, -defaultPathChangesDays)
+			if since != "" {
+				sinceTime, err = parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since timestamp: %s", err)), nil
+				}
+			}
+			if !sinceTime.Before(untilTime) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.CommitsListOptions{
+				SHA:         sha,
+				Path:        path,
+				Since:       sinceTime,
+				Until:       untilTime,
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			var commits []*github.RepositoryCommit
+			truncated := false
+			for {
+				page, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list commits for path '%s'", path),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, commit := range page {
+					if len(commits) == maxCommits {
+						truncated = true
+						break
+					}
+					commits = append(commits, commit)
+				}
+				if truncated || resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// The commits list API does not link pull requests, so they are looked up for every commit.
+			commitPulls := make([][]*github.PullRequest, len(commits))
+			commitErrs := fanOut(ctx, commits, DefaultFanOutConcurrency, func(ctx context.Context, i int, commit *github.RepositoryCommit) error {
+				pulls, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, commit.GetSHA(), &github.ListOptions{PerPage: 100})
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				commitPulls[i] = pulls
+				return nil
+			})
+
+			resultCommits := make([]PathChangeCommit, 0, len(commits))
+			pullsByNumber := map[int]*PathChangePullRequest{}
+			authorsByName := map[string]*PathChangeAuthor{}
+			var failures []map[string]string
+			for i, commit := range commits {
+				message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+				date := formatOptionalTimestamp(commit.GetCommit().GetAuthor().Date)
+				item := PathChangeCommit{
+					SHA:     commit.GetSHA(),
+					Message: message,
+					Author:  commitAuthorName(commit),
+					Date:    date,
+				}
+
+				if commitErrs[i] != nil {
+					failures = append(failures, map[string]string{"sha": commit.GetSHA(), "error": commitErrs[i].Error()})
+				}
+				for _, pull := range commitPulls[i] {
+					item.PullRequests = append(item.PullRequests, pull.GetNumber())
+					entry, ok := pullsByNumber[pull.GetNumber()]
+					if !ok {
+						entry = &PathChangePullRequest{
+							Number:   pull.GetNumber(),
+							Title:    pull.GetTitle(),
+							State:    pull.GetState(),
+							Author:   pull.GetUser().GetLogin(),
+							MergedAt: formatOptionalTimestamp(pull.MergedAt),
+							URL:      pull.GetHTMLURL(),
+						}
+						pullsByNumber[pull.GetNumber()] = entry
+					}
+					entry.Commits++
+				}
+				resultCommits = append(resultCommits, item)
+
+				author, ok := authorsByName[item.Author]
+				if !ok {
+					author = &PathChangeAuthor{Author: item.Author}
+					authorsByName[item.Author] = author
+				}
+				author.Commits++
+				if date > author.LastCommit {
+					author.LastCommit = date
+				}
+			}
+
+			pulls := make([]PathChangePullRequest, 0, len(pullsByNumber))
+			for _, pull := range pullsByNumber {
+				pulls = append(pulls, *pull)
+			}
+			sort.Slice(pulls, func(i, j int) bool { return pulls[i].Number > pulls[j].Number })
+
+			authors := make([]PathChangeAuthor, 0, len(authorsByName))
+			for _, author := range authorsByName {
+				authors = append(authors, *author)
+			}
+			sort.Slice(authors, func(i, j int) bool {
+				if authors[i].Commits != authors[j].Commits {
+					return authors[i].Commits > authors[j].Commits
+				}
+				return authors[i].Author < authors[j].Author
+			})
+
+			response := map[string]any{
+				"path":          path,
+				"since":         sinceTime.UTC().Format(time.RFC3339),
+				"until":         untilTime.UTC().Format(time.RFC3339),
+				"commits":       resultCommits,
+				"pull_requests": pulls,
+				"authors":       authors,
+				"truncated":     truncated,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPathChanges(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPathChanges(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_path_changes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.Contains(t, tool.InputSchema.Properties, "max_commits")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	commit := func(sha, login, name, message string, day int) *github.RepositoryCommit {
+		c := &github.RepositoryCommit{
+			SHA: github.Ptr(sha),
+			Commit: &github.Commit{
+				Message: github.Ptr(message),
+				Author: &github.CommitAuthor{
+					Name: github.Ptr(name),
+					Date: &github.Timestamp{Time: time.Date(2024, 3, day, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+		}
+		if login != "" {
+			c.Author = &github.User{Login: github.Ptr(login)}
+		}
+		return c
+	}
+	firstPage := []*github.RepositoryCommit{
+		commit("c3", "alice", "Alice", "Tune billing retries\n\nDetails", 5),
+		commit("c2", "bob", "Bob", "Add invoice export", 4),
+	}
+	secondPage := []*github.RepositoryCommit{
+		commit("c1", "", "Build Bot", "Regenerate clients", 3),
+	}
+	pullRequest := &github.PullRequest{
+		Number:   github.Ptr(42),
+		Title:    github.Ptr("Billing improvements"),
+		State:    github.Ptr("closed"),
+		User:     &github.User{Login: github.Ptr("alice")},
+		MergedAt: &github.Timestamp{Time: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/pull/42"),
+	}
+
+	commitPages := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "services/billing", r.URL.Query().Get("path"))
+		assert.Equal(t, "2024-03-01T00:00:00Z", r.URL.Query().Get("since"))
+		assert.Equal(t, "2024-03-08T00:00:00Z", r.URL.Query().Get("until"))
+		if r.URL.Query().Get("page") == "2" {
+			mockResponse(t, http.StatusOK, secondPage)(w, r)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits?page=2>; rel="next"`)
+		mockResponse(t, http.StatusOK, firstPage)(w, r)
+	})
+	commitPulls := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/c1/"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+		case strings.Contains(r.URL.Path, "/c3/"), strings.Contains(r.URL.Path, "/c2/"):
+			mockResponse(t, http.StatusOK, []*github.PullRequest{pullRequest})(w, r)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]any
+		expectedErrors []string
+	}{
+		{
+			name: "aggregates commits, pull requests and authors across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, commitPages),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsPullsByOwnerByRepoByCommitSha, commitPulls),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "/services/billing/",
+				"since": "2024-03-01T00:00:00Z",
+				"until": "2024-03-08T00:00:00Z",
+			},
+			expectedResult: map[string]any{
+				"path":  "services/billing",
+				"since": "2024-03-01T00:00:00Z",
+				"until": "2024-03-08T00:00:00Z",
+				"commits": []any{
+					map[string]any{"sha": "c3", "message": "Tune billing retries", "author": "alice", "date": "2024-03-05T10:00:00Z", "pull_requests": []any{float64(42)}},
+					map[string]any{"sha": "c2", "message": "Add invoice export", "author": "bob", "date": "2024-03-04T10:00:00Z", "pull_requests": []any{float64(42)}},
+					map[string]any{"sha": "c1", "message": "Regenerate clients", "author": "Build Bot", "date": "2024-03-03T10:00:00Z"},
+				},
+				"pull_requests": []any{
+					map[string]any{"number": float64(42), "title": "Billing improvements", "state": "closed", "author": "alice", "merged_at": "2024-03-06T00:00:00Z", "url": "https://github.com/owner/repo/pull/42", "commits": float64(2)},
+				},
+				"authors": []any{
+					map[string]any{"author": "Build Bot", "commits": float64(1), "last_commit": "2024-03-03T10:00:00Z"},
+					map[string]any{"author": "alice", "commits": float64(1), "last_commit": "2024-03-05T10:00:00Z"},
+					map[string]any{"author": "bob", "commits": float64(1), "last_commit": "2024-03-04T10:00:00Z"},
+				},
+				"truncated": false,
+			},
+			expectedErrors: []string{"c1"},
+		},
+		{
+			name: "stops at max_commits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, commitPages),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsPullsByOwnerByRepoByCommitSha, commitPulls),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "services/billing",
+				"since":       "2024-03-01T00:00:00Z",
+				"until":       "2024-03-08T00:00:00Z",
+				"max_commits": float64(1),
+			},
+			expectedResult: map[string]any{
+				"path":  "services/billing",
+				"since": "2024-03-01T00:00:00Z",
+				"until": "2024-03-08T00:00:00Z",
+				"commits": []any{
+					map[string]any{"sha": "c3", "message": "Tune billing retries", "author": "alice", "date": "2024-03-05T10:00:00Z", "pull_requests": []any{float64(42)}},
+				},
+				"pull_requests": []any{
+					map[string]any{"number": float64(42), "title": "Billing improvements", "state": "closed", "author": "alice", "merged_at": "2024-03-06T00:00:00Z", "url": "https://github.com/owner/repo/pull/42", "commits": float64(1)},
+				},
+				"authors": []any{
+					map[string]any{"author": "alice", "commits": float64(1), "last_commit": "2024-03-05T10:00:00Z"},
+				},
+				"truncated": true,
+			},
+		},
+		{
+			name:         "since after until",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "services/billing",
+				"since": "2024-03-08T00:00:00Z",
+				"until": "2024-03-01T00:00:00Z",
+			},
+			expectError:    true,
+			expectedErrMsg: "since must be before until",
+		},
+		{
+			name: "listing commits fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "services/billing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits for path 'services/billing'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPathChanges(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			var failedSHAs []string
+			if failures, ok := response["errors"].([]any); ok {
+				for _, failure := range failures {
+					failedSHAs = append(failedSHAs, failure.(map[string]any)["sha"].(string))
+				}
+			}
+			delete(response, "errors")
+			assert.Equal(t, tc.expectedResult, response)
+			assert.Equal(t, tc.expectedErrors, failedSHAs)
+		})
+	}
+}
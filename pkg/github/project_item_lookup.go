@@ -0,0 +1,336 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectV2FieldName is the name of the project field a field value belongs to.
+type projectV2FieldName struct {
+	Common struct {
+		Name githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// projectV2FieldValueNode is a value of a project item field. Only one of the fragments matches the type of the
+// value, given by Typename. Values of the fields mirroring the issue or pull request, such as labels and
+// assignees, aren't read.
+type projectV2FieldValueNode struct {
+	Typename githubv4.String `graphql:"__typename"`
+	Text     struct {
+		Text  githubv4.String
+		Field projectV2FieldName
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	Number struct {
+		Number githubv4.Float
+		Field  projectV2FieldName
+	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	Date struct {
+		Date  githubv4.String
+		Field projectV2FieldName
+	} `graphql:"... on ProjectV2ItemFieldDateValue"`
+	SingleSelect struct {
+		Name  githubv4.String
+		Field projectV2FieldName
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Iteration struct {
+		Title     githubv4.String
+		StartDate githubv4.String
+		Field     projectV2FieldName
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+}
+
+// fieldValue returns the name of the field of the value and the value, or false for values that aren't read.
+func (n projectV2FieldValueNode) fieldValue() (ProjectItemFieldValue, bool) {
+	switch n.Typename {
+	case "ProjectV2ItemFieldTextValue":
+		return ProjectItemFieldValue{Field: string(n.Text.Field.Common.Name), Value: string(n.Text.Text)}, true
+	case "ProjectV2ItemFieldNumberValue":
+		return ProjectItemFieldValue{Field: string(n.Number.Field.Common.Name), Value: float64(n.Number.Number)}, true
+	case "ProjectV2ItemFieldDateValue":
+		return ProjectItemFieldValue{Field: string(n.Date.Field.Common.Name), Value: string(n.Date.Date)}, true
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return ProjectItemFieldValue{Field: string(n.SingleSelect.Field.Common.Name), Value: string(n.SingleSelect.Name)}, true
+	case "ProjectV2ItemFieldIterationValue":
+		return ProjectItemFieldValue{Field: string(n.Iteration.Field.Common.Name), Value: map[string]string{
+			"title":      string(n.Iteration.Title),
+			"start_date": string(n.Iteration.StartDate),
+		}}, true
+	default:
+		return ProjectItemFieldValue{}, false
+	}
+}
+
+// projectV2ItemNode is an item of a project, read from the issue or pull request it holds.
+type projectV2ItemNode struct {
+	FullDatabaseID githubv4.String `graphql:"fullDatabaseId"`
+	IsArchived     githubv4.Boolean
+	Project        struct {
+		Number githubv4.Int
+		Title  githubv4.String
+		URL    githubv4.URI
+		Closed githubv4.Boolean
+		Owner  struct {
+			Typename     githubv4.String `graphql:"__typename"`
+			Organization struct {
+				Login githubv4.String
+			} `graphql:"... on Organization"`
+			User struct {
+				Login githubv4.String
+			} `graphql:"... on User"`
+		}
+	}
+	FieldValues struct {
+		Nodes []projectV2FieldValueNode
+	} `graphql:"fieldValues(first: 50)"`
+}
+
+// ownerTypeAndLogin returns the owner type of the project of the item, "org" or "user" as the project tools take
+// it, and the login of the owner.
+func (n projectV2ItemNode) ownerTypeAndLogin() (string, string) {
+	if n.Project.Owner.Typename == "Organization" {
+		return "org", string(n.Project.Owner.Organization.Login)
+	}
+	return "user", string(n.Project.Owner.User.Login)
+}
+
+// projectItemsPage is a page of the project items of an issue or pull request, archived ones included.
+type projectItemsPage struct {
+	Nodes    []projectV2ItemNode
+	PageInfo PageInfoFragment
+}
+
+// contentProjectItemsQuery reads the project items of an issue or pull request.
+type contentProjectItemsQuery struct {
+	Repository struct {
+		IssueOrPullRequest struct {
+			Typename githubv4.String `graphql:"__typename"`
+			Issue    struct {
+				ProjectItems projectItemsPage `graphql:"projectItems(first: 50, after: $after, includeArchived: true)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ProjectItems projectItemsPage `graphql:"projectItems(first: 50, after: $after, includeArchived: true)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// ProjectItemFieldValue is the value of a field of a project item.
+type ProjectItemFieldValue struct {
+	Field string `json:"field"`
+	Value any    `json:"value"`
+}
+
+// ContentProjectItem is the item holding an issue or pull request in a project, with its field values.
+type ContentProjectItem struct {
+	// ItemID is the ID get_project_item, update_project_item and delete_project_item take.
+	ItemID        int64                   `json:"item_id"`
+	OwnerType     string                  `json:"owner_type"`
+	Owner         string                  `json:"owner"`
+	ProjectNumber int                     `json:"project_number"`
+	ProjectTitle  string                  `json:"project_title"`
+	ProjectURL    string                  `json:"project_url"`
+	ProjectClosed bool                    `json:"project_closed,omitempty"`
+	Archived      bool                    `json:"archived,omitempty"`
+	Fields        []ProjectItemFieldValue `json:"fields"`
+}
+
+func convertToContentProjectItem(node projectV2ItemNode) ContentProjectItem {
+	ownerType, owner := node.ownerTypeAndLogin()
+	// The ID is a BigInt, which GraphQL returns as a string
+	itemID, _ := strconv.ParseInt(string(node.FullDatabaseID), 10, 64)
+	item := ContentProjectItem{
+		ItemID:        itemID,
+		OwnerType:     ownerType,
+		Owner:         owner,
+		ProjectNumber: int(node.Project.Number),
+		ProjectTitle:  string(node.Project.Title),
+		ProjectURL:    node.Project.URL.String(),
+		ProjectClosed: bool(node.Project.Closed),
+		Archived:      bool(node.IsArchived),
+		Fields:        []ProjectItemFieldValue{},
+	}
+	for _, value := range node.FieldValues.Nodes {
+		if fieldValue, ok := value.fieldValue(); ok {
+			item.Fields = append(item.Fields, fieldValue)
+		}
+	}
+	return item
+}
+
+// listContentProjectItems returns the project items of an issue or pull request, with the type of the content,
+// "Issue" or "PullRequest".
+func listContentProjectItems(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (string, []ContentProjectItem, error) {
+	vars := map[string]any{
+		"owner":  githubv4.String(owner),
+		"repo":   githubv4.String(repo),
+		"number": githubv4.Int(number), // #nosec G115 - issue numbers are always small positive integers
+		"after":  (*githubv4.String)(nil),
+	}
+	var contentType string
+	items := []ContentProjectItem{}
+	for {
+		var query contentProjectItemsQuery
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return "", nil, err
+		}
+		content := query.Repository.IssueOrPullRequest
+		contentType = string(content.Typename)
+		page := content.Issue.ProjectItems
+		switch contentType {
+		case "PullRequest":
+			page = content.PullRequest.ProjectItems
+		case "":
+			return "", nil, fmt.Errorf("no issue or pull request #%d in %s/%s", number, owner, repo)
+		}
+		for _, node := range page.Nodes {
+			items = append(items, convertToContentProjectItem(node))
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = page.PageInfo.EndCursor
+	}
+	return contentType, items, nil
+}
+
+// ListIssueProjectItems creates a tool to list the projects an issue or pull request belongs to.
+func ListIssueProjectItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_project_items",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_PROJECT_ITEMS_DESCRIPTION", "List the Projects an issue or pull request belongs to, with the ID of its item in each project and the values of the item's fields, such as status, iteration and dates. Archived items are included and flagged. Use the item ID and project number with update_project_item to change a field.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_PROJECT_ITEMS_USER_TITLE", "List projects of issue or pull request"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](req, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](req, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(req, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			contentType, items, err := listContentProjectItems(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"content_type":  contentType,
+				"project_items": items,
+			}), nil
+		}
+}
+
+// FindProjectItems creates a tool to find the items of a project holding an issue or pull request.
+func FindProjectItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_project_items",
+			mcp.WithDescription(t("TOOL_FIND_PROJECT_ITEMS_DESCRIPTION", "Find the item of a Project for a user or org that holds an issue or pull request, given the repository and number of the issue or pull request, and return its ID and field values. Returns no items when the issue or pull request isn't in the project.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_PROJECT_ITEMS_USER_TITLE", "Find project items"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner_type",
+				mcp.Required(),
+				mcp.Description("Owner type"),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive."),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("The project's number."),
+			),
+			mcp.WithString("content_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository of the issue or pull request"),
+			),
+			mcp.WithString("content_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository of the issue or pull request"),
+			),
+			mcp.WithNumber("content_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerType, err := RequiredParam[string](req, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](req, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(req, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentOwner, err := RequiredParam[string](req, "content_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentRepo, err := RequiredParam[string](req, "content_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentNumber, err := RequiredInt(req, "content_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			contentType, items, err := listContentProjectItems(ctx, client, contentOwner, contentRepo, contentNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+			}
+
+			found := []ContentProjectItem{}
+			for _, item := range items {
+				if item.OwnerType == ownerType && strings.EqualFold(item.Owner, owner) && item.ProjectNumber == projectNumber {
+					found = append(found, item)
+				}
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"content_type":  contentType,
+				"project_items": found,
+			}), nil
+		}
+}
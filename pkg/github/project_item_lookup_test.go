@@ -0,0 +1,247 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contentProjectItemsResponse(typename string) githubv4mock.GQLResponse {
+	field := func(name string) map[string]any {
+		return map[string]any{"name": name}
+	}
+	projectItems := map[string]any{
+		"nodes": []any{
+			map[string]any{
+				"fullDatabaseId": "1001",
+				"isArchived":     false,
+				"project": map[string]any{
+					"number": 3,
+					"title":  "Roadmap",
+					"url":    "https://github.com/orgs/acme/projects/3",
+					"closed": false,
+					"owner":  map[string]any{"__typename": "Organization", "login": "acme"},
+				},
+				"fieldValues": map[string]any{
+					"nodes": []any{
+						map[string]any{"__typename": "ProjectV2ItemFieldTextValue", "text": "Fix login", "field": field("Title")},
+						map[string]any{"__typename": "ProjectV2ItemFieldSingleSelectValue", "name": "In Progress", "field": field("Status")},
+						map[string]any{"__typename": "ProjectV2ItemFieldNumberValue", "number": 3, "field": field("Estimate")},
+						map[string]any{"__typename": "ProjectV2ItemFieldIterationValue", "title": "Sprint 12", "startDate": "2025-03-03", "field": field("Iteration")},
+						map[string]any{"__typename": "ProjectV2ItemFieldLabelValue"},
+					},
+				},
+			},
+			map[string]any{
+				"fullDatabaseId": "2002",
+				"isArchived":     true,
+				"project": map[string]any{
+					"number": 1,
+					"title":  "Personal",
+					"url":    "https://github.com/users/octocat/projects/1",
+					"closed": true,
+					"owner":  map[string]any{"__typename": "User", "login": "octocat"},
+				},
+				"fieldValues": map[string]any{
+					"nodes": []any{
+						map[string]any{"__typename": "ProjectV2ItemFieldDateValue", "date": "2025-04-01", "field": field("Due")},
+					},
+				},
+			},
+		},
+		"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+	}
+	content := map[string]any{"__typename": typename, "projectItems": projectItems}
+	return githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"issueOrPullRequest": content},
+	})
+}
+
+func contentProjectItemsVars(number int) map[string]any {
+	return map[string]any{
+		"owner":  githubv4.String("acme"),
+		"repo":   githubv4.String("web"),
+		"number": githubv4.Int(number),
+		"after":  (*githubv4.String)(nil),
+	}
+}
+
+var (
+	roadmapProjectItem = ContentProjectItem{
+		ItemID:        1001,
+		OwnerType:     "org",
+		Owner:         "acme",
+		ProjectNumber: 3,
+		ProjectTitle:  "Roadmap",
+		ProjectURL:    "https://github.com/orgs/acme/projects/3",
+		Fields: []ProjectItemFieldValue{
+			{Field: "Title", Value: "Fix login"},
+			{Field: "Status", Value: "In Progress"},
+			{Field: "Estimate", Value: float64(3)},
+			{Field: "Iteration", Value: map[string]any{"title": "Sprint 12", "start_date": "2025-03-03"}},
+		},
+	}
+	personalProjectItem = ContentProjectItem{
+		ItemID:        2002,
+		OwnerType:     "user",
+		Owner:         "octocat",
+		ProjectNumber: 1,
+		ProjectTitle:  "Personal",
+		ProjectURL:    "https://github.com/users/octocat/projects/1",
+		ProjectClosed: true,
+		Archived:      true,
+		Fields:        []ProjectItemFieldValue{{Field: "Due", Value: "2025-04-01"}},
+	}
+)
+
+func Test_ListIssueProjectItems(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListIssueProjectItems(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_issue_project_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name                string
+		gqlClient           *http.Client
+		issueNumber         int
+		expectError         bool
+		expectedErrMsg      string
+		expectedContentType string
+		expectedItems       []ContentProjectItem
+	}{
+		{
+			name: "issue in an org and a user project",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(42), contentProjectItemsResponse("Issue")),
+			),
+			issueNumber:         42,
+			expectedContentType: "Issue",
+			expectedItems:       []ContentProjectItem{roadmapProjectItem, personalProjectItem},
+		},
+		{
+			name: "pull request",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(43), contentProjectItemsResponse("PullRequest")),
+			),
+			issueNumber:         43,
+			expectedContentType: "PullRequest",
+			expectedItems:       []ContentProjectItem{roadmapProjectItem, personalProjectItem},
+		},
+		{
+			name: "no such issue",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(99), githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"issueOrPullRequest": nil},
+				})),
+			),
+			issueNumber:    99,
+			expectError:    true,
+			expectedErrMsg: "no issue or pull request #99 in acme/web",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListIssueProjectItems(stubGetGQLClientFn(githubv4.NewClient(tc.gqlClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "acme",
+				"repo":         "web",
+				"issue_number": float64(tc.issueNumber),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				ContentType  string               `json:"content_type"`
+				ProjectItems []ContentProjectItem `json:"project_items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedContentType, response.ContentType)
+			assert.Equal(t, tc.expectedItems, response.ProjectItems)
+		})
+	}
+}
+
+func Test_FindProjectItems(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := FindProjectItems(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_project_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number", "content_owner", "content_repo", "content_number"})
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		expectedItems []ContentProjectItem
+	}{
+		{
+			name: "item in the project",
+			requestArgs: map[string]any{
+				"owner_type":     "org",
+				"owner":          "ACME",
+				"project_number": float64(3),
+			},
+			expectedItems: []ContentProjectItem{roadmapProjectItem},
+		},
+		{
+			name: "project of another owner type",
+			requestArgs: map[string]any{
+				"owner_type":     "user",
+				"owner":          "acme",
+				"project_number": float64(3),
+			},
+			expectedItems: []ContentProjectItem{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contentProjectItemsQuery{}, contentProjectItemsVars(42), contentProjectItemsResponse("Issue")),
+			))
+			_, handler := FindProjectItems(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"content_owner":  "acme",
+				"content_repo":   "web",
+				"content_number": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				ContentType  string               `json:"content_type"`
+				ProjectItems []ContentProjectItem `json:"project_items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Issue", response.ContentType)
+			assert.Equal(t, tc.expectedItems, response.ProjectItems)
+		})
+	}
+}
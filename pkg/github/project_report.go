@@ -0,0 +1,382 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultProjectReportMaxItems is the default number of project items included in a status report.
+	DefaultProjectReportMaxItems = 500
+	noStatus                     = "No status"
+)
+
+// listProjectItemsPageOptions are the options for one page of project items, the REST API pages them with
+// cursors.
+type listProjectItemsPageOptions struct {
+	listProjectItemsOptions
+	After string `url:"after,omitempty"`
+}
+
+// projectItemReportEntry is a project item as shown in a status report.
+type projectItemReportEntry struct {
+	title     string
+	status    string
+	assignees string
+	iteration string
+	updatedAt time.Time
+}
+
+// projectFieldValueText returns the display text of a project item field value. Values are plain strings
+// and numbers, objects such as single select options, iterations and users, or arrays of those.
+func projectFieldValueText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		for _, key := range []string{"raw", "login", "name", "title", "text"} {
+			if text := projectFieldValueText(v[key]); text != "" {
+				return text
+			}
+		}
+		return ""
+	case []any:
+		texts := make([]string, 0, len(v))
+		for _, element := range v {
+			if text := projectFieldValueText(element); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// findProjectField returns the first field with the given data type, and the given name when name is set.
+func findProjectField(fields []projectV2Field, name, dataType string) *projectV2Field {
+	for i, field := range fields {
+		if field.DataType == dataType && (name == "" || strings.EqualFold(field.Name, name)) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// renderProjectStatusReport renders the Markdown status report of a project.
+func renderProjectStatusReport(title string, statusOrder []string, entries []projectItemReportEntry, hasIteration bool, recentDays int, now time.Time, truncated bool) string {
+	byStatus := make(map[string][]projectItemReportEntry)
+	for _, entry := range entries {
+		byStatus[entry.status] = append(byStatus[entry.status], entry)
+	}
+
+	// Statuses are listed in the order of the field options, followed by values no longer offered and by
+	// items without status.
+	statuses := make([]string, 0, len(byStatus))
+	seen := make(map[string]bool)
+	for _, status := range statusOrder {
+		statuses = append(statuses, status)
+		seen[status] = true
+	}
+	var extra []string
+	for status := range byStatus {
+		if !seen[status] && status != noStatus {
+			extra = append(extra, status)
+		}
+	}
+	sort.Strings(extra)
+	statuses = append(statuses, extra...)
+	if len(byStatus[noStatus]) > 0 {
+		statuses = append(statuses, noStatus)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Status report: %s\n\n", title)
+	fmt.Fprintf(&b, "%d items as of %s.", len(entries), now.Format("2006-01-02"))
+	if truncated {
+		b.WriteString(" The project has more items than were included in this report.")
+	}
+	b.WriteString("\n\n## By status\n\n| Status | Items |\n| --- | --- |\n")
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "| %s | %d |\n", status, len(byStatus[status]))
+	}
+
+	writeList := func(heading string, items []projectItemReportEntry, describe func(projectItemReportEntry) string) {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", heading, len(items))
+		if len(items) == 0 {
+			b.WriteString("None.\n")
+			return
+		}
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s — %s\n", item.title, describe(item))
+		}
+	}
+
+	since := now.AddDate(0, 0, -recentDays)
+	var recent, unassigned, unscheduled []projectItemReportEntry
+	for _, entry := range entries {
+		if entry.updatedAt.After(since) {
+			recent = append(recent, entry)
+		}
+		if entry.assignees == "" {
+			unassigned = append(unassigned, entry)
+		}
+		if entry.iteration == "" {
+			unscheduled = append(unscheduled, entry)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].updatedAt.After(recent[j].updatedAt) })
+
+	writeList(fmt.Sprintf("Recently updated, last %d days", recentDays), recent, func(e projectItemReportEntry) string {
+		return fmt.Sprintf("%s, updated %s", e.status, e.updatedAt.Format("2006-01-02"))
+	})
+	writeList("Without assignee", unassigned, func(e projectItemReportEntry) string { return e.status })
+	if hasIteration {
+		writeList("Without iteration", unscheduled, func(e projectItemReportEntry) string { return e.status })
+	}
+
+	b.WriteString("\n## Items by status\n")
+	for _, status := range statuses {
+		items := byStatus[status]
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s (%d)\n\n", status, len(items))
+		for _, item := range items {
+			details := []string{}
+			if item.assignees != "" {
+				details = append(details, item.assignees)
+			}
+			if item.iteration != "" {
+				details = append(details, item.iteration)
+			}
+			if len(details) == 0 {
+				fmt.Fprintf(&b, "- %s\n", item.title)
+				continue
+			}
+			fmt.Fprintf(&b, "- %s — %s\n", item.title, strings.Join(details, ", "))
+		}
+	}
+	return b.String()
+}
+
+// GetProjectStatusReport creates a tool to build a Markdown status report of a project from its fields and items.
+func GetProjectStatusReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project_status_report",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_STATUS_REPORT_DESCRIPTION", "Get a Markdown status report of a Project for a user or org: items grouped and counted by status, recently updated items, and items without assignee or iteration. Use this instead of listing fields and items when summarizing a project.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PROJECT_STATUS_REPORT_USER_TITLE", "Get project status report"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner_type",
+				mcp.Required(),
+				mcp.Description("Owner type"),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive."),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("The project's number."),
+			),
+			mcp.WithString("status_field",
+				mcp.Description("Name of the single select field holding the item status (default: Status)"),
+			),
+			mcp.WithNumber("recent_days",
+				mcp.Description("Number of days an item counts as recently updated (default: 7)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description(fmt.Sprintf("Maximum number of items to include (default: %d)", DefaultProjectReportMaxItems)),
+				mcp.Min(1),
+			),
+		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](req, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerType, err := RequiredParam[string](req, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(req, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			statusFieldName, err := OptionalParam[string](req, "status_field")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if statusFieldName == "" {
+				statusFieldName = "Status"
+			}
+			recentDays, err := OptionalIntParamWithDefault(req, "recent_days", 7)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(req, "max_items", DefaultProjectReportMaxItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if recentDays < 1 || maxItems < 1 {
+				return mcp.NewToolResultError("recent_days and max_items must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var projectURL string
+			if ownerType == "org" {
+				projectURL = fmt.Sprintf("orgs/%s/projectsV2/%d", owner, projectNumber)
+			} else {
+				projectURL = fmt.Sprintf("users/%s/projectsV2/%d", owner, projectNumber)
+			}
+
+			project := github.ProjectV2{}
+			httpRequest, err := client.NewRequest("GET", projectURL, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err := client.Do(ctx, httpRequest, &project)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get project",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			fieldsURL, err := addOptions(projectURL+"/fields", paginationOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add options to request: %w", err)
+			}
+			fields := []projectV2Field{}
+			httpRequest, err = client.NewRequest("GET", fieldsURL, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err = client.Do(ctx, httpRequest, &fields)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list project fields",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			statusField := findProjectField(fields, statusFieldName, "single_select")
+			if statusField == nil || statusField.ID == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("project has no single select field named '%s'", statusFieldName)), nil
+			}
+			assigneesField := findProjectField(fields, "", "assignees")
+			iterationField := findProjectField(fields, "", "iteration")
+
+			var statusOrder []string
+			for _, option := range statusField.Options {
+				if option == nil {
+					continue
+				}
+				if name := projectFieldValueText(*option); name != "" {
+					statusOrder = append(statusOrder, name)
+				}
+			}
+
+			fieldIDs := []string{strconv.FormatInt(*statusField.ID, 10)}
+			for _, field := range []*projectV2Field{assigneesField, iterationField} {
+				if field != nil && field.ID != nil {
+					fieldIDs = append(fieldIDs, strconv.FormatInt(*field.ID, 10))
+				}
+			}
+
+			opts := listProjectItemsPageOptions{
+				listProjectItemsOptions: listProjectItemsOptions{
+					paginationOptions:     paginationOptions{PerPage: min(maxItems, 100)},
+					fieldSelectionOptions: fieldSelectionOptions{Fields: fieldIDs},
+				},
+			}
+			var entries []projectItemReportEntry
+			truncated := false
+			for {
+				itemsURL, err := addOptions(projectURL+"/items", opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to add options to request: %w", err)
+				}
+				httpRequest, err := client.NewRequest("GET", itemsURL, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				items := []projectV2Item{}
+				resp, err := client.Do(ctx, httpRequest, &items)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						ProjectListFailedError,
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, item := range items {
+					if item.ArchivedAt != nil {
+						continue
+					}
+					if len(entries) == maxItems {
+						truncated = true
+						break
+					}
+					entry := projectItemReportEntry{status: noStatus}
+					if item.Title != nil {
+						entry.title = *item.Title
+					}
+					if item.UpdatedAt != nil {
+						entry.updatedAt = item.UpdatedAt.Time
+					}
+					for _, field := range item.Fields {
+						if field == nil || field.ID == nil {
+							continue
+						}
+						text := projectFieldValueText(field.Value)
+						switch {
+						case *field.ID == *statusField.ID && text != "":
+							entry.status = text
+						case assigneesField != nil && *field.ID == *assigneesField.ID:
+							entry.assignees = text
+						case iterationField != nil && *field.ID == *iterationField.ID:
+							entry.iteration = text
+						}
+					}
+					if entry.title == "" {
+						entry.title = "Untitled item"
+					}
+					entries = append(entries, entry)
+				}
+				if truncated || resp.After == "" {
+					break
+				}
+				opts.After = resp.After
+			}
+
+			report := renderProjectStatusReport(project.GetTitle(), statusOrder, entries, iterationField != nil, recentDays, time.Now(), truncated)
+			return mcp.NewToolResultText(report), nil
+		}
+}
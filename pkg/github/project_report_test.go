@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenderProjectStatusReport(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	entries := []projectItemReportEntry{
+		{title: "Fix login", status: "In Progress", assignees: "alice", iteration: "Sprint 3", updatedAt: now.AddDate(0, 0, -1)},
+		{title: "Write docs", status: "Todo", updatedAt: now.AddDate(0, 0, -20)},
+		{title: "Triage", status: noStatus, assignees: "bob", updatedAt: now.AddDate(0, 0, -2)},
+		{title: "Old column", status: "Blocked", iteration: "Sprint 2", updatedAt: now.AddDate(0, 0, -30)},
+	}
+
+	report := renderProjectStatusReport("Roadmap", []string{"Todo", "In Progress", "Done"}, entries, true, 7, now, false)
+	assert.Equal(t, `# Status report: Roadmap
+
+4 items as of 2024-05-10.
+
+## By status
+
+| Status | Items |
+| --- | --- |
+| Todo | 1 |
+| In Progress | 1 |
+| Done | 0 |
+| Blocked | 1 |
+| No status | 1 |
+
+## Recently updated, last 7 days (2)
+
+- Fix login — In Progress, updated 2024-05-09
+- Triage — No status, updated 2024-05-08
+
+## Without assignee (2)
+
+- Write docs — Todo
+- Old column — Blocked
+
+## Without iteration (2)
+
+- Write docs — Todo
+- Triage — No status
+
+## Items by status
+
+### Todo (1)
+
+- Write docs
+
+### In Progress (1)
+
+- Fix login — alice, Sprint 3
+
+### Blocked (1)
+
+- Old column — Sprint 2
+
+### No status (1)
+
+- Triage — bob
+`, report)
+}
+
+func Test_GetProjectStatusReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetProjectStatusReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_project_status_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner_type")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "project_number")
+	assert.Contains(t, tool.InputSchema.Properties, "status_field")
+	assert.Contains(t, tool.InputSchema.Properties, "recent_days")
+	assert.Contains(t, tool.InputSchema.Properties, "max_items")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number"})
+
+	project := map[string]any{"id": 1, "number": 7, "title": "Roadmap"}
+	fields := []map[string]any{
+		{"id": 101, "name": "Title", "data_type": "title"},
+		{"id": 102, "name": "Status", "data_type": "single_select", "options": []map[string]any{
+			{"id": "a", "name": map[string]any{"raw": "Todo", "html": "Todo"}},
+			{"id": "b", "name": map[string]any{"raw": "Done", "html": "Done"}},
+		}},
+		{"id": 103, "name": "Assignees", "data_type": "assignees"},
+	}
+	recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().AddDate(0, 0, -60).UTC().Format(time.RFC3339)
+	firstPage := []map[string]any{
+		{"id": 1, "title": "Fix login", "updated_at": recent, "fields": []map[string]any{
+			{"id": 102, "name": "Status", "data_type": "single_select", "value": map[string]any{"id": "a", "name": map[string]any{"raw": "Todo", "html": "Todo"}}},
+			{"id": 103, "name": "Assignees", "data_type": "assignees", "value": []map[string]any{{"login": "alice"}, {"login": "bob"}}},
+		}},
+		{"id": 2, "title": "Archived", "updated_at": recent, "archived_at": recent},
+	}
+	secondPage := []map[string]any{
+		{"id": 3, "title": "Ship it", "updated_at": old, "fields": []map[string]any{
+			{"id": 102, "name": "Status", "data_type": "single_select", "value": map[string]any{"id": "b", "name": map[string]any{"raw": "Done", "html": "Done"}}},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedContains []string
+	}{
+		{
+			name: "report across item pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/7", Method: http.MethodGet},
+					project,
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/7/fields", Method: http.MethodGet},
+					expectQueryParams(t, map[string]string{"per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, fields),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/7/items", Method: http.MethodGet},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, []string{"102", "103"}, r.URL.Query()["fields"])
+						if r.URL.Query().Get("after") == "cursor1" {
+							mockResponse(t, http.StatusOK, secondPage)(w, r)
+							return
+						}
+						w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/projectsV2/7/items?after=cursor1>; rel="next"`)
+						mockResponse(t, http.StatusOK, firstPage)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner_type":     "org",
+				"owner":          "octo-org",
+				"project_number": float64(7),
+			},
+			expectedContains: []string{
+				"# Status report: Roadmap",
+				"| Todo | 1 |\n| Done | 1 |\n",
+				"## Recently updated, last 7 days (1)\n\n- Fix login — Todo, updated",
+				"## Without assignee (1)\n\n- Ship it — Done\n",
+				"### Todo (1)\n\n- Fix login — alice, bob\n",
+			},
+		},
+		{
+			name: "missing status field",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.EndpointPattern{Pattern: "/users/{username}/projectsV2/7", Method: http.MethodGet},
+					project,
+				),
+				mock.WithRequestMatch(
+					mock.EndpointPattern{Pattern: "/users/{username}/projectsV2/7/fields", Method: http.MethodGet},
+					fields,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner_type":     "user",
+				"owner":          "octocat",
+				"project_number": float64(7),
+				"status_field":   "Stage",
+			},
+			expectError:    true,
+			expectedErrMsg: "project has no single select field named 'Stage'",
+		},
+		{
+			name: "project not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/7", Method: http.MethodGet},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner_type":     "org",
+				"owner":          "octo-org",
+				"project_number": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get project",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetProjectStatusReport(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			report := getTextResult(t, result).Text
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, report, expected)
+			}
+			assert.NotContains(t, report, "Archived")
+		})
+	}
+}
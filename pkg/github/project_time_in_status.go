@@ -0,0 +1,439 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// DefaultTimeInStatusMaxItems is the default number of project items get_project_time_in_status reads.
+	DefaultTimeInStatusMaxItems = 100
+	// maxTimeInStatusItems is the number of project items get_project_time_in_status reads at most.
+	maxTimeInStatusItems = 500
+)
+
+// statusHistoryContent is the issue or pull request of a project item, with the changes of its status in projects.
+// Only the last 100 changes are read, the time before them is counted in the status the first one changed from.
+type statusHistoryContent struct {
+	Title      githubv4.String
+	Number     githubv4.Int
+	URL        githubv4.URI
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+	TimelineItems struct {
+		Nodes []struct {
+			StatusChanged struct {
+				CreatedAt      githubv4.DateTime
+				PreviousStatus githubv4.String
+				Status         githubv4.String
+				Project        struct {
+					ID githubv4.ID
+				}
+			} `graphql:"... on ProjectV2ItemStatusChangedEvent"`
+		}
+	} `graphql:"timelineItems(last: 100, itemTypes: [PROJECT_V2_ITEM_STATUS_CHANGED_EVENT])"`
+}
+
+// projectStatusHistoryItem is a project item with its current status and the history of its content.
+type projectStatusHistoryItem struct {
+	FullDatabaseID githubv4.String `graphql:"fullDatabaseId"`
+	CreatedAt      githubv4.DateTime
+	IsArchived     githubv4.Boolean
+	Status         struct {
+		SingleSelect struct {
+			Name githubv4.String
+		} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	} `graphql:"fieldValueByName(name: \"Status\")"`
+	Content struct {
+		Typename    githubv4.String      `graphql:"__typename"`
+		Issue       statusHistoryContent `graphql:"... on Issue"`
+		PullRequest statusHistoryContent `graphql:"... on PullRequest"`
+	}
+}
+
+// projectStatusHistoryFragment is a project with the options of its Status field and its items.
+type projectStatusHistoryFragment struct {
+	ID     githubv4.ID
+	Title  githubv4.String
+	Status struct {
+		SingleSelect struct {
+			Options []struct {
+				Name githubv4.String
+			}
+		} `graphql:"... on ProjectV2SingleSelectField"`
+	} `graphql:"field(name: \"Status\")"`
+	Items struct {
+		Nodes    []projectStatusHistoryItem
+		PageInfo PageInfoFragment
+	} `graphql:"items(first: 50, after: $after)"`
+}
+
+// orgProjectStatusHistoryQuery reads the items of a project of an organization with their status history.
+type orgProjectStatusHistoryQuery struct {
+	Organization struct {
+		ProjectV2 *projectStatusHistoryFragment `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $owner)"`
+}
+
+// userProjectStatusHistoryQuery reads the items of a project of a user with their status history.
+type userProjectStatusHistoryQuery struct {
+	User struct {
+		ProjectV2 *projectStatusHistoryFragment `graphql:"projectV2(number: $number)"`
+	} `graphql:"user(login: $owner)"`
+}
+
+// statusChange is a change of the status of a project item.
+type statusChange struct {
+	at       time.Time
+	previous string
+	status   string
+}
+
+// ItemTimeInStatus is the time a project item spent in each status, in hours. The clock stops once the item
+// reaches a done status, so the time in done statuses isn't counted.
+type ItemTimeInStatus struct {
+	ItemID            int64              `json:"item_id"`
+	Title             string             `json:"title"`
+	Repository        string             `json:"repository"`
+	Number            int                `json:"number"`
+	URL               string             `json:"url"`
+	CurrentStatus     string             `json:"current_status"`
+	TimeInStatusHours map[string]float64 `json:"time_in_status_hours"`
+	// LeadTimeHours is the time from the item being added to the project to it reaching a done status.
+	LeadTimeHours *float64 `json:"lead_time_hours,omitempty"`
+	// CycleTimeHours is the time from the first change of the status of the item to it reaching a done status.
+	CycleTimeHours *float64 `json:"cycle_time_hours,omitempty"`
+}
+
+// DurationStats are the median and 90th percentile of durations, in hours.
+type DurationStats struct {
+	Items       int      `json:"items"`
+	MedianHours *float64 `json:"median_hours,omitempty"`
+	P90Hours    *float64 `json:"p90_hours,omitempty"`
+}
+
+func newDurationStats(hours []float64) DurationStats {
+	return DurationStats{
+		Items:       len(hours),
+		MedianHours: percentileHours(hours, 0.5),
+		P90Hours:    percentileHours(hours, 0.9),
+	}
+}
+
+// StatusDurationStats are the durations of the items that spent time in a status.
+type StatusDurationStats struct {
+	Status string `json:"status"`
+	DurationStats
+}
+
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*10) / 10
+}
+
+// timeInStatus computes the time an item added at addedAt spent in each status from the changes of its status,
+// oldest first, until it reached a done status or until now.
+func timeInStatus(addedAt time.Time, currentStatus string, changes []statusChange, done map[string]bool, now time.Time) (map[string]time.Duration, *time.Duration, *time.Duration) {
+	durations := map[string]time.Duration{}
+	status := currentStatus
+	if len(changes) > 0 {
+		status = changes[0].previous
+	}
+	if status == "" {
+		status = noStatus
+	}
+	since := addedAt
+	var firstChange *time.Time
+	var doneAt *time.Time
+	for _, change := range changes {
+		at := change.at
+		if at.Before(since) {
+			at = since
+		}
+		if doneAt == nil {
+			durations[status] += at.Sub(since)
+		}
+		if firstChange == nil {
+			firstChange = &at
+		}
+		status = change.status
+		if status == "" {
+			status = noStatus
+		}
+		since = at
+		switch {
+		case done[status] && doneAt == nil:
+			doneAt = &at
+		case !done[status]:
+			// An item moved back out of done is counted again, until it's done again
+			doneAt = nil
+		}
+	}
+	if doneAt == nil {
+		if done[status] {
+			// Added in a done status
+			doneAt = &since
+		} else {
+			durations[status] += now.Sub(since)
+		}
+	}
+	for status, d := range durations {
+		if d <= 0 {
+			delete(durations, status)
+		}
+	}
+
+	if doneAt == nil {
+		return durations, nil, nil
+	}
+	leadTime := doneAt.Sub(addedAt)
+	if firstChange == nil {
+		return durations, &leadTime, nil
+	}
+	cycleTime := doneAt.Sub(*firstChange)
+	return durations, &leadTime, &cycleTime
+}
+
+// GetProjectTimeInStatus creates a tool to report how long the items of a project spent in each status.
+func GetProjectTimeInStatus(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project_time_in_status",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_TIME_IN_STATUS_DESCRIPTION", "Report how long the issues and pull requests of a Project for a user or org spent in each value of the project's Status field, computed from the history of their status changes. Returns the hours each item spent in each status, its lead time (added to done) and cycle time (first status change to done), and the median and 90th percentile of each across items. The clock of an item stops when it reaches a done status. Draft issues have no history and are skipped.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PROJECT_TIME_IN_STATUS_USER_TITLE", "Get project time in status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner_type",
+				mcp.Required(),
+				mcp.Description("Owner type"),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive."),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("The project's number."),
+			),
+			mcp.WithArray("done_statuses",
+				mcp.Description("Statuses in which an item is done (default [\"Done\"])"),
+				mcp.WithStringItems(),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Whether to include archived items (default false)"),
+			),
+			mcp.WithBoolean("summary_only",
+				mcp.Description("Only return the statistics across items, without the items (default false)"),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description(fmt.Sprintf("Maximum number of items to read (default %d, max %d)", DefaultTimeInStatusMaxItems, maxTimeInStatusItems)),
+				mcp.Min(1),
+				mcp.Max(maxTimeInStatusItems),
+			),
+		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerType, err := RequiredParam[string](req, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](req, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(req, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ownerType != "user" && ownerType != "org" {
+				return mcp.NewToolResultError("owner_type must be either 'user' or 'org'"), nil
+			}
+			doneStatuses, err := OptionalStringArrayParam(req, "done_statuses")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(doneStatuses) == 0 {
+				doneStatuses = []string{"Done"}
+			}
+			includeArchived, err := OptionalBoolParamWithDefault(req, "include_archived", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			summaryOnly, err := OptionalBoolParamWithDefault(req, "summary_only", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(req, "max_items", DefaultTimeInStatusMaxItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxItems < 1 || maxItems > maxTimeInStatusItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_items must be between 1 and %d", maxTimeInStatusItems)), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			done := map[string]bool{}
+			for _, status := range doneStatuses {
+				done[status] = true
+			}
+			now := time.Now()
+			vars := map[string]any{
+				"owner":  githubv4.String(owner),
+				"number": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+				"after":  (*githubv4.String)(nil),
+			}
+
+			var title string
+			var statusOrder []string
+			items := []ItemTimeInStatus{}
+			hoursByStatus := map[string][]float64{}
+			var leadTimes, cycleTimes []float64
+			skippedDrafts := 0
+			truncated := false
+			for {
+				var project *projectStatusHistoryFragment
+				if ownerType == "org" {
+					var query orgProjectStatusHistoryQuery
+					if err := client.Query(ctx, &query, vars); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+					}
+					project = query.Organization.ProjectV2
+				} else {
+					var query userProjectStatusHistoryQuery
+					if err := client.Query(ctx, &query, vars); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+					}
+					project = query.User.ProjectV2
+				}
+				if project == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("project %d of %s not found", projectNumber, owner)), nil
+				}
+				if statusOrder == nil {
+					title = string(project.Title)
+					statusOrder = []string{noStatus}
+					for _, option := range project.Status.SingleSelect.Options {
+						statusOrder = append(statusOrder, string(option.Name))
+					}
+				}
+
+				for _, node := range project.Items.Nodes {
+					if bool(node.IsArchived) && !includeArchived {
+						continue
+					}
+					var content statusHistoryContent
+					switch node.Content.Typename {
+					case "Issue":
+						content = node.Content.Issue
+					case "PullRequest":
+						content = node.Content.PullRequest
+					default:
+						skippedDrafts++
+						continue
+					}
+					if len(items) == maxItems {
+						truncated = true
+						break
+					}
+
+					var changes []statusChange
+					for _, event := range content.TimelineItems.Nodes {
+						// The timeline has the status changes of the content in all projects
+						if event.StatusChanged.Project.ID != project.ID {
+							continue
+						}
+						changes = append(changes, statusChange{
+							at:       event.StatusChanged.CreatedAt.Time,
+							previous: string(event.StatusChanged.PreviousStatus),
+							status:   string(event.StatusChanged.Status),
+						})
+					}
+					sort.SliceStable(changes, func(i, j int) bool { return changes[i].at.Before(changes[j].at) })
+
+					currentStatus := string(node.Status.SingleSelect.Name)
+					if currentStatus == "" {
+						currentStatus = noStatus
+					}
+					durations, leadTime, cycleTime := timeInStatus(node.CreatedAt.Time, currentStatus, changes, done, now)
+
+					// The ID is a BigInt, which GraphQL returns as a string
+					itemID, _ := strconv.ParseInt(string(node.FullDatabaseID), 10, 64)
+					item := ItemTimeInStatus{
+						ItemID:            itemID,
+						Title:             string(content.Title),
+						Repository:        string(content.Repository.NameWithOwner),
+						Number:            int(content.Number),
+						URL:               content.URL.String(),
+						CurrentStatus:     currentStatus,
+						TimeInStatusHours: map[string]float64{},
+					}
+					for status, d := range durations {
+						item.TimeInStatusHours[status] = roundHours(d)
+						hoursByStatus[status] = append(hoursByStatus[status], d.Hours())
+					}
+					if leadTime != nil {
+						hours := roundHours(*leadTime)
+						item.LeadTimeHours = &hours
+						leadTimes = append(leadTimes, leadTime.Hours())
+					}
+					if cycleTime != nil {
+						hours := roundHours(*cycleTime)
+						item.CycleTimeHours = &hours
+						cycleTimes = append(cycleTimes, cycleTime.Hours())
+					}
+					items = append(items, item)
+				}
+				if truncated || !project.Items.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = project.Items.PageInfo.EndCursor
+			}
+
+			// Statuses are listed in the order of the field options, followed by the ones that were removed from it
+			known := map[string]bool{}
+			for _, status := range statusOrder {
+				known[status] = true
+			}
+			var removed []string
+			for status := range hoursByStatus {
+				if !known[status] {
+					removed = append(removed, status)
+				}
+			}
+			sort.Strings(removed)
+			statuses := []StatusDurationStats{}
+			for _, status := range append(statusOrder, removed...) {
+				if hours, ok := hoursByStatus[status]; ok {
+					statuses = append(statuses, StatusDurationStats{Status: status, DurationStats: newDurationStats(hours)})
+				}
+			}
+
+			result := map[string]any{
+				"project_title":  title,
+				"done_statuses":  doneStatuses,
+				"items_analyzed": len(items),
+				"truncated":      truncated,
+				"statuses":       statuses,
+				"lead_time":      newDurationStats(leadTimes),
+				"cycle_time":     newDurationStats(cycleTimes),
+			}
+			if skippedDrafts > 0 {
+				result["skipped_draft_issues"] = skippedDrafts
+			}
+			if !summaryOnly {
+				result["items"] = items
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_timeInStatus(t *testing.T) {
+	added := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return added.AddDate(0, 0, n) }
+	done := map[string]bool{"Done": true}
+	now := day(30)
+
+	t.Run("done item", func(t *testing.T) {
+		durations, leadTime, cycleTime := timeInStatus(added, "Done", []statusChange{
+			{at: day(2), previous: "", status: "Todo"},
+			{at: day(3), previous: "Todo", status: "In Progress"},
+			{at: day(7), previous: "In Progress", status: "Done"},
+		}, done, now)
+		assert.Equal(t, map[string]time.Duration{
+			noStatus:      48 * time.Hour,
+			"Todo":        24 * time.Hour,
+			"In Progress": 96 * time.Hour,
+		}, durations)
+		require.NotNil(t, leadTime)
+		assert.Equal(t, 7*24*time.Hour, *leadTime)
+		require.NotNil(t, cycleTime)
+		assert.Equal(t, 5*24*time.Hour, *cycleTime)
+	})
+
+	t.Run("reopened item", func(t *testing.T) {
+		durations, leadTime, cycleTime := timeInStatus(added, "In Progress", []statusChange{
+			{at: day(1), previous: "Todo", status: "Done"},
+			{at: day(10), previous: "Done", status: "In Progress"},
+		}, done, now)
+		assert.Equal(t, map[string]time.Duration{
+			"Todo":        24 * time.Hour,
+			"In Progress": 20 * 24 * time.Hour,
+		}, durations)
+		assert.Nil(t, leadTime)
+		assert.Nil(t, cycleTime)
+	})
+
+	t.Run("item without changes", func(t *testing.T) {
+		durations, leadTime, cycleTime := timeInStatus(added, "Todo", nil, done, now)
+		assert.Equal(t, map[string]time.Duration{"Todo": 30 * 24 * time.Hour}, durations)
+		assert.Nil(t, leadTime)
+		assert.Nil(t, cycleTime)
+	})
+}
+
+func Test_GetProjectTimeInStatus(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetProjectTimeInStatus(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_project_time_in_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "done_statuses")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number"})
+
+	change := func(at, previous, status, projectID string) map[string]any {
+		return map[string]any{
+			"createdAt":      at,
+			"previousStatus": previous,
+			"status":         status,
+			"project":        map[string]any{"id": projectID},
+		}
+	}
+	content := func(typename string, number int, changes ...any) map[string]any {
+		return map[string]any{
+			"__typename": typename,
+			"title":      "Item " + typename,
+			"number":     number,
+			"url":        "https://github.com/acme/web/issues/1",
+			"repository": map[string]any{"nameWithOwner": "acme/web"},
+			"timelineItems": map[string]any{
+				"nodes": changes,
+			},
+		}
+	}
+	item := func(id string, status string, archived bool, content map[string]any) map[string]any {
+		return map[string]any{
+			"fullDatabaseId":   id,
+			"createdAt":        "2025-03-01T00:00:00Z",
+			"isArchived":       archived,
+			"fieldValueByName": map[string]any{"name": status},
+			"content":          content,
+		}
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"organization": map[string]any{
+			"projectV2": map[string]any{
+				"id":    "PVT_1",
+				"title": "Roadmap",
+				"field": map[string]any{"options": []any{
+					map[string]any{"name": "Todo"},
+					map[string]any{"name": "In Progress"},
+					map[string]any{"name": "Done"},
+				}},
+				"items": map[string]any{
+					"nodes": []any{
+						item("1", "Done", false, content("Issue", 1,
+							change("2025-03-02T00:00:00Z", "Todo", "In Progress", "PVT_1"),
+							change("2025-03-03T00:00:00Z", "", "Backlog", "PVT_other"),
+							change("2025-03-05T00:00:00Z", "In Progress", "Done", "PVT_1"),
+						)),
+						item("2", "Done", false, content("PullRequest", 2,
+							change("2025-03-01T12:00:00Z", "Todo", "In Progress", "PVT_1"),
+							change("2025-03-02T00:00:00Z", "In Progress", "Shipped", "PVT_1"),
+						)),
+						item("3", "Done", true, content("Issue", 3)),
+						item("4", "", false, map[string]any{"__typename": "DraftIssue"}),
+					},
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				},
+			},
+		},
+	})
+	vars := map[string]any{
+		"owner":  githubv4.String("acme"),
+		"number": githubv4.Int(3),
+		"after":  (*githubv4.String)(nil),
+	}
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(orgProjectStatusHistoryQuery{}, vars, response),
+	))
+	_, handler := GetProjectTimeInStatus(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner_type":     "org",
+		"owner":          "acme",
+		"project_number": float64(3),
+		"done_statuses":  []any{"Done", "Shipped"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got struct {
+		ItemsAnalyzed      int                   `json:"items_analyzed"`
+		SkippedDraftIssues int                   `json:"skipped_draft_issues"`
+		Statuses           []StatusDurationStats `json:"statuses"`
+		LeadTime           DurationStats         `json:"lead_time"`
+		CycleTime          DurationStats         `json:"cycle_time"`
+		Items              []ItemTimeInStatus    `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+	assert.Equal(t, 2, got.ItemsAnalyzed, "archived items and draft issues are left out")
+	assert.Equal(t, 1, got.SkippedDraftIssues)
+
+	require.Len(t, got.Items, 2)
+	assert.Equal(t, int64(1), got.Items[0].ItemID)
+	assert.Equal(t, map[string]float64{"Todo": 24, "In Progress": 72}, got.Items[0].TimeInStatusHours, "changes in other projects are ignored")
+	assert.Equal(t, 96.0, *got.Items[0].LeadTimeHours)
+	assert.Equal(t, 72.0, *got.Items[0].CycleTimeHours)
+	assert.Equal(t, map[string]float64{"Todo": 12, "In Progress": 12}, got.Items[1].TimeInStatusHours)
+
+	fl := func(v float64) *float64 { return &v }
+	assert.Equal(t, []StatusDurationStats{
+		{Status: "Todo", DurationStats: DurationStats{Items: 2, MedianHours: fl(18), P90Hours: fl(22.8)}},
+		{Status: "In Progress", DurationStats: DurationStats{Items: 2, MedianHours: fl(42), P90Hours: fl(66)}},
+	}, got.Statuses)
+	assert.Equal(t, DurationStats{Items: 2, MedianHours: fl(60), P90Hours: fl(88.8)}, got.LeadTime)
+	assert.Equal(t, DurationStats{Items: 2, MedianHours: fl(42), P90Hours: fl(66)}, got.CycleTime)
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectWorkflowEffects are what the built-in workflows of a project do, by workflow name. The GraphQL API exposes
+// the name and state of a workflow but not its configuration, such as the status it sets or the filter it applies.
+var projectWorkflowEffects = map[string]string{
+	"Item added to project":          "Sets a field, usually the status, of items when they are added to the project",
+	"Item reopened":                  "Sets a field, usually the status, of items when their issue or pull request is reopened",
+	"Item closed":                    "Sets a field, usually the status to Done, of items when their issue or pull request is closed",
+	"Pull request merged":            "Sets a field, usually the status to Done, of items when their pull request is merged",
+	"Pull request linked to issue":   "Sets a field of items when a pull request is linked to their issue",
+	"Code changes requested":         "Sets a field of items when changes are requested in a review of their pull request",
+	"Code review approved":           "Sets a field of items when their pull request is approved",
+	"Auto-add to project":            "Adds issues and pull requests of a repository matching a filter to the project when they are created or updated",
+	"Auto-add sub-issues to project": "Adds the sub-issues of items to the project",
+	"Auto-archive items":             "Archives items matching a filter, such as closed items not updated for a while",
+	"Auto-close issue":               "Closes the issue of items when their status is set to a value, usually Done",
+}
+
+// projectWorkflowsFragment is a project with its built-in workflows.
+type projectWorkflowsFragment struct {
+	Title     githubv4.String
+	URL       githubv4.URI
+	Workflows struct {
+		Nodes []struct {
+			Number    githubv4.Int
+			Name      githubv4.String
+			Enabled   githubv4.Boolean
+			UpdatedAt githubv4.DateTime
+		}
+		PageInfo PageInfoFragment
+	} `graphql:"workflows(first: 50, after: $after)"`
+}
+
+// orgProjectWorkflowsQuery reads the workflows of a project of an organization.
+type orgProjectWorkflowsQuery struct {
+	Organization struct {
+		ProjectV2 *projectWorkflowsFragment `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $owner)"`
+}
+
+// userProjectWorkflowsQuery reads the workflows of a project of a user.
+type userProjectWorkflowsQuery struct {
+	User struct {
+		ProjectV2 *projectWorkflowsFragment `graphql:"projectV2(number: $number)"`
+	} `graphql:"user(login: $owner)"`
+}
+
+// ProjectWorkflow is a built-in workflow of a project.
+type ProjectWorkflow struct {
+	Number    int    `json:"number"`
+	Name      string `json:"name"`
+	Enabled   bool   `json:"enabled"`
+	UpdatedAt string `json:"updated_at"`
+	// Effect is what the workflow does, for the workflows GitHub provides.
+	Effect string `json:"effect,omitempty"`
+}
+
+// ListProjectWorkflows creates a tool to list the built-in workflows of a project.
+func ListProjectWorkflows(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_workflows",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_WORKFLOWS_DESCRIPTION", "List the built-in workflows of a Project for a user or org, such as the ones setting the status of closed items or adding new issues to the project, with whether they are enabled and what they do. Use it to explain why items move between statuses, and to avoid changing fields that a workflow will change back. The filters and values each workflow is configured with aren't available through the API.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_WORKFLOWS_USER_TITLE", "List project workflows"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner_type",
+				mcp.Required(),
+				mcp.Description("Owner type"),
+				mcp.Enum("user", "org"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive."),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("The project's number."),
+			),
+		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerType, err := RequiredParam[string](req, "owner_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](req, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(req, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ownerType != "user" && ownerType != "org" {
+				return mcp.NewToolResultError("owner_type must be either 'user' or 'org'"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner":  githubv4.String(owner),
+				"number": githubv4.Int(projectNumber), // #nosec G115 - project numbers are always small positive integers
+				"after":  (*githubv4.String)(nil),
+			}
+			var title, url string
+			workflows := []ProjectWorkflow{}
+			for {
+				var project *projectWorkflowsFragment
+				if ownerType == "org" {
+					var query orgProjectWorkflowsQuery
+					if err := client.Query(ctx, &query, vars); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project workflows", err), nil
+					}
+					project = query.Organization.ProjectV2
+				} else {
+					var query userProjectWorkflowsQuery
+					if err := client.Query(ctx, &query, vars); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project workflows", err), nil
+					}
+					project = query.User.ProjectV2
+				}
+				if project == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("project %d of %s not found", projectNumber, owner)), nil
+				}
+
+				title, url = string(project.Title), project.URL.String()
+				for _, node := range project.Workflows.Nodes {
+					workflows = append(workflows, ProjectWorkflow{
+						Number:    int(node.Number),
+						Name:      string(node.Name),
+						Enabled:   bool(node.Enabled),
+						UpdatedAt: node.UpdatedAt.UTC().Format(time.RFC3339),
+						Effect:    projectWorkflowEffects[string(node.Name)],
+					})
+				}
+				if !project.Workflows.PageInfo.HasNextPage {
+					break
+				}
+				vars["after"] = project.Workflows.PageInfo.EndCursor
+			}
+
+			enabled := 0
+			for _, workflow := range workflows {
+				if workflow.Enabled {
+					enabled++
+				}
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"project_title": title,
+				"project_url":   url,
+				"enabled":       enabled,
+				"workflows":     workflows,
+			}), nil
+		}
+}
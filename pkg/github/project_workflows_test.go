@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListProjectWorkflows(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListProjectWorkflows(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_workflows", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number"})
+
+	vars := map[string]any{
+		"owner":  githubv4.String("acme"),
+		"number": githubv4.Int(3),
+		"after":  (*githubv4.String)(nil),
+	}
+	project := map[string]any{
+		"title": "Roadmap",
+		"url":   "https://github.com/orgs/acme/projects/3",
+		"workflows": map[string]any{
+			"nodes": []any{
+				map[string]any{"number": 1, "name": "Item closed", "enabled": true, "updatedAt": "2025-01-10T09:00:00Z"},
+				map[string]any{"number": 2, "name": "Auto-archive items", "enabled": false, "updatedAt": "2025-01-11T09:00:00Z"},
+				map[string]any{"number": 3, "name": "Triage on label", "enabled": true, "updatedAt": "2025-01-12T09:00:00Z"},
+			},
+			"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		gqlClient         *http.Client
+		ownerType         string
+		expectError       bool
+		expectedErrMsg    string
+		expectedWorkflows []ProjectWorkflow
+	}{
+		{
+			name: "organization project",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(orgProjectWorkflowsQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+					"organization": map[string]any{"projectV2": project},
+				})),
+			),
+			ownerType: "org",
+			expectedWorkflows: []ProjectWorkflow{
+				{Number: 1, Name: "Item closed", Enabled: true, UpdatedAt: "2025-01-10T09:00:00Z", Effect: projectWorkflowEffects["Item closed"]},
+				{Number: 2, Name: "Auto-archive items", UpdatedAt: "2025-01-11T09:00:00Z", Effect: projectWorkflowEffects["Auto-archive items"]},
+				{Number: 3, Name: "Triage on label", Enabled: true, UpdatedAt: "2025-01-12T09:00:00Z"},
+			},
+		},
+		{
+			name: "user project not found",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(userProjectWorkflowsQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+					"user": map[string]any{"projectV2": nil},
+				})),
+			),
+			ownerType:      "user",
+			expectError:    true,
+			expectedErrMsg: "project 3 of acme not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListProjectWorkflows(stubGetGQLClientFn(githubv4.NewClient(tc.gqlClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner_type":     tc.ownerType,
+				"owner":          "acme",
+				"project_number": float64(3),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				ProjectTitle string            `json:"project_title"`
+				Enabled      int               `json:"enabled"`
+				Workflows    []ProjectWorkflow `json:"workflows"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Roadmap", response.ProjectTitle)
+			assert.Equal(t, 2, response.Enabled)
+			assert.Equal(t, tc.expectedWorkflows, response.Workflows)
+		})
+	}
+}
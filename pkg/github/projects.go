@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -112,12 +111,7 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list projects: %s", string(body))), nil
 			}
-			r, err := json.Marshal(minimalProjects)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalProjects), nil
 		}
 }
 
@@ -196,12 +190,7 @@ func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			minimalProject := convertToMinimalProject(&project)
-			r, err := json.Marshal(minimalProject)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalProject), nil
 		}
 }
 
@@ -286,12 +275,7 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list project fields: %s", string(body))), nil
 			}
-			r, err := json.Marshal(projectFields)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, projectFields), nil
 		}
 }
 
@@ -369,12 +353,7 @@ func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get project field: %s", string(body))), nil
 			}
-			r, err := json.Marshal(projectField)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, projectField), nil
 		}
 }
 
@@ -483,12 +462,7 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 			for _, item := range projectItems {
 				minimalProjectItems = append(minimalProjectItems, *convertToMinimalProjectItem(&item))
 			}
-			r, err := json.Marshal(minimalProjectItems)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalProjectItems), nil
 		}
 }
 
@@ -589,18 +563,13 @@ func GetProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get project item: %s", string(body))), nil
 			}
-			r, err := json.Marshal(convertToMinimalProjectItem(&projectItem))
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, convertToMinimalProjectItem(&projectItem)), nil
 		}
 }
 
 func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("add_project_item",
-			mcp.WithDescription(t("TOOL_ADD_PROJECT_ITEM_DESCRIPTION", "Add a specific Project item for a user or org")),
+			mcp.WithDescription(t("TOOL_ADD_PROJECT_ITEM_DESCRIPTION", "Add a specific Project item for a user or org. The issue or pull request is given by its numeric ID, or by its repository and number.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_ADD_PROJECT_ITEM_USER_TITLE", "Add project item"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -623,8 +592,16 @@ func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Enum("issue", "pull_request"),
 			),
 			mcp.WithNumber("item_id",
-				mcp.Required(),
-				mcp.Description("The numeric ID of the issue or pull request to add to the project."),
+				mcp.Description("The numeric ID of the issue or pull request to add to the project. Give either item_id, or content_owner, content_repo and content_number."),
+			),
+			mcp.WithString("content_owner",
+				mcp.Description("Owner of the repository of the issue or pull request to add, which can be in another organization than the project."),
+			),
+			mcp.WithString("content_repo",
+				mcp.Description("Name of the repository of the issue or pull request to add."),
+			),
+			mcp.WithNumber("content_number",
+				mcp.Description("Number of the issue or pull request to add."),
 			),
 		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](req, "owner")
@@ -639,10 +616,31 @@ func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			itemID, err := RequiredInt(req, "item_id")
+			itemID, err := OptionalIntParam(req, "item_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentOwner, err := OptionalParam[string](req, "content_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentRepo, err := OptionalParam[string](req, "content_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentNumber, err := OptionalIntParam(req, "content_number")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			hasContent := contentOwner != "" || contentRepo != "" || contentNumber != 0
+			switch {
+			case itemID == 0 && !hasContent:
+				return mcp.NewToolResultError("missing required parameter: item_id, or content_owner, content_repo and content_number"), nil
+			case itemID != 0 && hasContent:
+				return mcp.NewToolResultError("item_id can't be given with content_owner, content_repo and content_number"), nil
+			case hasContent && (contentOwner == "" || contentRepo == "" || contentNumber == 0):
+				return mcp.NewToolResultError("content_owner, content_repo and content_number must be given together"), nil
+			}
 
 			itemType, err := RequiredParam[string](req, "item_type")
 			if err != nil {
@@ -657,6 +655,19 @@ func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			contentID := int64(itemID)
+			if hasContent {
+				var resp *github.Response
+				contentID, resp, err = resolveProjectItemContentID(ctx, client, itemType, contentOwner, contentRepo, contentNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get %s %s/%s#%d", strings.ReplaceAll(itemType, "_", " "), contentOwner, contentRepo, contentNumber),
+						resp,
+						err,
+					), nil
+				}
+			}
+
 			var projectsURL string
 			if ownerType == "org" {
 				projectsURL = fmt.Sprintf("orgs/%s/projectsV2/%d/items", owner, projectNumber)
@@ -665,7 +676,7 @@ func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 
 			newItem := &newProjectItem{
-				ID:   int64(itemID),
+				ID:   contentID,
 				Type: toNewProjectType(itemType),
 			}
 			httpRequest, err := client.NewRequest("POST", projectsURL, newItem)
@@ -691,12 +702,7 @@ func AddProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ProjectAddFailedError, string(body))), nil
 			}
-			r, err := json.Marshal(convertToMinimalProjectItem(&addedItem))
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, convertToMinimalProjectItem(&addedItem)), nil
 		}
 }
 
@@ -796,12 +802,7 @@ func UpdateProjectItem(getClient GetClientFn, t translations.TranslationHelperFu
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ProjectUpdateFailedError, string(body))), nil
 			}
-			r, err := json.Marshal(convertToMinimalProjectItem(&updatedItem))
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, convertToMinimalProjectItem(&updatedItem)), nil
 		}
 }
 
@@ -958,6 +959,29 @@ type listProjectItemsOptions struct {
 	fieldSelectionOptions
 }
 
+// resolveProjectItemContentID returns the ID of the issue or pull request with the given number, which the projects
+// API takes to add it to a project.
+func resolveProjectItemContentID(ctx context.Context, client *github.Client, itemType, owner, repo string, number int) (int64, *github.Response, error) {
+	if itemType == "pull_request" {
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return 0, resp, err
+		}
+		_ = resp.Body.Close()
+		return pr.GetID(), resp, nil
+	}
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return 0, resp, err
+	}
+	_ = resp.Body.Close()
+	// Pull requests are issues too, but are added to projects by their pull request ID
+	if issue.IsPullRequest() {
+		return 0, resp, fmt.Errorf("#%d is a pull request, use item_type pull_request", number)
+	}
+	return issue.GetID(), resp, nil
+}
+
 func toNewProjectType(projType string) string {
 	switch strings.ToLower(projType) {
 	case "issue":
@@ -1061,6 +1085,14 @@ func ManageProjectItemsPrompt(t translations.TranslationHelperFunc) (tool mcp.Pr
 					Role:    "assistant",
 					Content: mcp.NewTextContent(fmt.Sprintf("Absolutely! Here's a complete workflow:\n\n**Step 1: Find your project**\nUse `list_projects` with owner=\"%s\" and owner_type=\"%s\"\n\n**Step 2: Get the Status field ID**\nUse `list_project_fields` with the project_number from step 1\nLook for the field with name=\"Status\" and note its ID (e.g., 198354254)\n\n**Step 3: Query items with the Status field**\nUse `list_project_items` with fields=[\"198354254\"] to see current status values\nOptionally add a query parameter to filter items (e.g., query=\"assignee:@me\")\n\n**Step 4: Update an item's status**\nUse `update_project_item` with:\n- item_id: The ID from the item you want to update\n- updated_field: {\"id\": 198354254, \"value\": \"In Progress\"}\n\nLet me start by listing your projects now.", owner, ownerType)),
 				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("And if I just want an overview of where the project stands?"),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent("Use `get_project_status_report` with the project_number. It returns a Markdown report in one call, with items counted and grouped by status, recently updated items, and items without an assignee or iteration, so there is no need to list fields and items yourself."),
+				},
 			}
 			return &mcp.GetPromptResult{
 				Messages: messages,
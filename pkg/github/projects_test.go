@@ -1000,7 +1000,10 @@ func Test_AddProjectItem(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "project_number")
 	assert.Contains(t, tool.InputSchema.Properties, "item_type")
 	assert.Contains(t, tool.InputSchema.Properties, "item_id")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number", "item_type", "item_id"})
+	assert.Contains(t, tool.InputSchema.Properties, "content_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "content_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "content_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner_type", "owner", "project_number", "item_type"})
 
 	orgItem := map[string]any{
 		"id":           601,
@@ -1096,6 +1099,134 @@ func Test_AddProjectItem(t *testing.T) {
 			expectedContentType:  "PullRequest",
 			expectedCreatorLogin: "hubot",
 		},
+		{
+			name: "success issue by repository and number",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&gh.Issue{ID: gh.Ptr(int64(9876)), Number: gh.Ptr(42)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/{project}/items", Method: http.MethodPost},
+					expectRequestBody(t, map[string]any{"type": "Issue", "id": float64(9876)}).andThen(
+						mockResponse(t, http.StatusCreated, orgItem),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(321),
+				"item_type":      "issue",
+				"content_owner":  "other-org",
+				"content_repo":   "web",
+				"content_number": float64(42),
+			},
+			expectedID:           601,
+			expectedContentType:  "Issue",
+			expectedCreatorLogin: "octocat",
+		},
+		{
+			name: "success pull request by repository and number",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&gh.PullRequest{ID: gh.Ptr(int64(7654)), Number: gh.Ptr(7)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/users/{user}/projectsV2/{project}/items", Method: http.MethodPost},
+					expectRequestBody(t, map[string]any{"type": "PullRequest", "id": float64(7654)}).andThen(
+						mockResponse(t, http.StatusCreated, userItem),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": float64(222),
+				"item_type":      "pull_request",
+				"content_owner":  "octocat",
+				"content_repo":   "hello-world",
+				"content_number": float64(7),
+			},
+			expectedID:           701,
+			expectedContentType:  "PullRequest",
+			expectedCreatorLogin: "hubot",
+		},
+		{
+			name: "issue by number is a pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&gh.Issue{
+						ID:               gh.Ptr(int64(7654)),
+						Number:           gh.Ptr(7),
+						PullRequestLinks: &gh.PullRequestLinks{URL: gh.Ptr("https://api.github.com/repos/octocat/hello-world/pulls/7")},
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": float64(222),
+				"item_type":      "issue",
+				"content_owner":  "octocat",
+				"content_repo":   "hello-world",
+				"content_number": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "#7 is a pull request, use item_type pull_request",
+		},
+		{
+			name: "issue by number not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(321),
+				"item_type":      "issue",
+				"content_owner":  "other-org",
+				"content_repo":   "web",
+				"content_number": float64(404),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue other-org/web#404",
+		},
+		{
+			name:         "item_id with repository and number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"item_type":      "issue",
+				"item_id":        float64(10),
+				"content_owner":  "other-org",
+				"content_repo":   "web",
+				"content_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "item_id can't be given with content_owner, content_repo and content_number",
+		},
+		{
+			name:         "incomplete repository and number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"item_type":      "issue",
+				"content_owner":  "other-org",
+				"content_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "content_owner, content_repo and content_number must be given together",
+		},
 		{
 			name: "api error",
 			mockedClient: mock.NewMockedHTTPClient(
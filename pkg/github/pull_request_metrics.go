@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultPullRequestMetricsDays is the default number of days pull request metrics are computed over.
+	DefaultPullRequestMetricsDays = 30
+	// DefaultPullRequestMetricsMaxPullRequests is the default number of pull requests read for the metrics.
+	DefaultPullRequestMetricsMaxPullRequests = 300
+)
+
+// PullRequestAgeBuckets counts open pull requests by how long they have been open.
+type PullRequestAgeBuckets struct {
+	UnderOneDay       int `json:"under_1_day"`
+	OneToSevenDays    int `json:"1_to_7_days"`
+	SevenToThirtyDays int `json:"7_to_30_days"`
+	OverThirtyDays    int `json:"over_30_days"`
+	TotalOpen         int `json:"total_open"`
+	OldestOpenHours   int `json:"oldest_open_hours"`
+}
+
+func (b *PullRequestAgeBuckets) add(age time.Duration) {
+	switch {
+	case age < 24*time.Hour:
+		b.UnderOneDay++
+	case age < 7*24*time.Hour:
+		b.OneToSevenDays++
+	case age < 30*24*time.Hour:
+		b.SevenToThirtyDays++
+	default:
+		b.OverThirtyDays++
+	}
+	b.TotalOpen++
+	b.OldestOpenHours = max(b.OldestOpenHours, int(age.Hours()))
+}
+
+// PullRequestMetrics are the review and merge metrics of the pull requests of a repository, or of all
+// repositories. Durations are in hours and omitted when no pull request was reviewed or merged.
+type PullRequestMetrics struct {
+	Repository                   string                `json:"repository,omitempty"`
+	Opened                       int                   `json:"opened"`
+	Reviewed                     int                   `json:"reviewed"`
+	Merged                       int                   `json:"merged"`
+	MedianTimeToFirstReviewHours *float64              `json:"median_time_to_first_review_hours,omitempty"`
+	P90TimeToFirstReviewHours    *float64              `json:"p90_time_to_first_review_hours,omitempty"`
+	MedianTimeToMergeHours       *float64              `json:"median_time_to_merge_hours,omitempty"`
+	P90TimeToMergeHours          *float64              `json:"p90_time_to_merge_hours,omitempty"`
+	OpenByAge                    PullRequestAgeBuckets `json:"open_by_age"`
+
+	timesToFirstReview []float64
+	timesToMerge       []float64
+}
+
+func (m *PullRequestMetrics) summarize() {
+	m.MedianTimeToFirstReviewHours = percentileHours(m.timesToFirstReview, 0.5)
+	m.P90TimeToFirstReviewHours = percentileHours(m.timesToFirstReview, 0.9)
+	m.MedianTimeToMergeHours = percentileHours(m.timesToMerge, 0.5)
+	m.P90TimeToMergeHours = percentileHours(m.timesToMerge, 0.9)
+}
+
+// percentileHours returns the p-th percentile of hours, interpolating between the closest values and rounded
+// to a tenth of an hour, or nil when there are no values.
+func percentileHours(hours []float64, p float64) *float64 {
+	if len(hours) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	value := sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+	value = math.Round(value*10) / 10
+	return &value
+}
+
+// issueRepository returns the owner and name of the repository of an issue from a search result.
+func issueRepository(issue *github.Issue) (string, string) {
+	parts := strings.Split(strings.TrimSuffix(issue.GetRepositoryURL(), "/"), "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// searchAllIssues pages through the results of an issue search until maxItems results are read. Results in
+// repositories the owner policy doesn't allow are skipped and not counted in the total.
+func searchAllIssues(ctx context.Context, client *github.Client, query string, maxItems int) ([]*github.Issue, int, *github.Response, error) {
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var issues []*github.Issue
+	total, skipped := 0, 0
+	for len(issues) < maxItems {
+		result, resp, err := client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+		_ = resp.Body.Close()
+
+		total = result.GetTotal()
+		for _, issue := range result.Issues {
+			if len(issues) == maxItems {
+				break
+			}
+			if owner, repo := issueRepository(issue); !repositoryAllowed(ctx, owner, repo) {
+				skipped++
+				continue
+			}
+			issues = append(issues, issue)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, total - skipped, nil, nil
+}
+
+// firstReviewAt returns when a pull request was first reviewed by someone other than its author, or nil.
+func firstReviewAt(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, author string) (*time.Time, error) {
+	reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	var first *time.Time
+	for _, review := range reviews {
+		if review.SubmittedAt == nil || review.GetState() == "PENDING" || strings.EqualFold(review.GetUser().GetLogin(), author) {
+			continue
+		}
+		if first == nil || review.SubmittedAt.Before(*first) {
+			submitted := review.SubmittedAt.Time
+			first = &submitted
+		}
+	}
+	return first, nil
+}
+
+// GetOrgPullRequestMetrics creates a tool to report pull request review and merge times across the
+// repositories of an organization.
+func GetOrgPullRequestMetrics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_pull_request_metrics",
+			mcp.WithDescription(t("TOOL_GET_ORG_PULL_REQUEST_METRICS_DESCRIPTION", "Report pull request service levels per repository of an organization: for pull requests opened in the last days, how many were reviewed and merged with the median and 90th percentile time to first review and time to merge, and the currently open non-draft pull requests by age. Use it to check review SLAs across teams.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_PULL_REQUEST_METRICS_USER_TITLE", "Get organization pull request metrics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("Repository names to report on. Defaults to all repositories of the organization."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("days",
+				mcp.Description(fmt.Sprintf("Report on pull requests opened in this many past days (default %d)", DefaultPullRequestMetricsDays)),
+				mcp.Min(1),
+				mcp.Max(365),
+			),
+			mcp.WithNumber("max_pull_requests",
+				mcp.Description(fmt.Sprintf("Maximum number of opened and of open pull requests to read (default %d)", DefaultPullRequestMetricsMaxPullRequests)),
+				mcp.Min(1),
+				mcp.Max(maxExportItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			days, err := OptionalIntParamWithDefault(request, "days", DefaultPullRequestMetricsDays)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if days < 1 || days > 365 {
+				return mcp.NewToolResultError("days must be between 1 and 365"), nil
+			}
+			maxPullRequests, err := OptionalIntParamWithDefault(request, "max_pull_requests", DefaultPullRequestMetricsMaxPullRequests)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPullRequests < 1 || maxPullRequests > maxExportItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_pull_requests must be between 1 and %d", maxExportItems)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			now := time.Now().UTC()
+			since := now.AddDate(0, 0, -days)
+			scope := "org:" + org
+			for _, repo := range repositories {
+				scope += fmt.Sprintf(" repo:%s/%s", org, repo)
+			}
+
+			opened, openedTotal, resp, err := searchAllIssues(ctx, client, fmt.Sprintf("is:pr %s created:>=%s", scope, since.Format("2006-01-02")), maxPullRequests)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to search opened pull requests",
+					resp,
+					err,
+				), nil
+			}
+			open, openTotal, resp, err := searchAllIssues(ctx, client, fmt.Sprintf("is:pr is:open draft:false %s", scope), maxPullRequests)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to search open pull requests",
+					resp,
+					err,
+				), nil
+			}
+
+			reviewedAt := make([]*time.Time, len(opened))
+			errs := fanOut(ctx, opened, DefaultFanOutConcurrency, func(ctx context.Context, i int, pr *github.Issue) error {
+				var err error
+				reviewedAt[i], err = firstReviewAt(ctx, client, org, path.Base(pr.GetRepositoryURL()), pr.GetNumber(), pr.GetUser().GetLogin())
+				return err
+			})
+
+			totals := &PullRequestMetrics{}
+			byRepo := map[string]*PullRequestMetrics{}
+			metricsFor := func(repo string) *PullRequestMetrics {
+				if byRepo[repo] == nil {
+					byRepo[repo] = &PullRequestMetrics{Repository: repo}
+				}
+				return byRepo[repo]
+			}
+
+			var failures []map[string]string
+			for i, pr := range opened {
+				repo := path.Base(pr.GetRepositoryURL())
+				created := pr.GetCreatedAt().Time
+				var timeToFirstReview, timeToMerge *float64
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{
+						"pull_request": fmt.Sprintf("%s#%d", repo, pr.GetNumber()),
+						"error":        errs[i].Error(),
+					})
+				} else if reviewedAt[i] != nil {
+					hours := reviewedAt[i].Sub(created).Hours()
+					timeToFirstReview = &hours
+				}
+				if merged := pr.GetPullRequestLinks().GetMergedAt(); merged != (github.Timestamp{}) {
+					hours := merged.Sub(created).Hours()
+					timeToMerge = &hours
+				}
+
+				for _, metrics := range []*PullRequestMetrics{totals, metricsFor(repo)} {
+					metrics.Opened++
+					if timeToFirstReview != nil {
+						metrics.Reviewed++
+						metrics.timesToFirstReview = append(metrics.timesToFirstReview, *timeToFirstReview)
+					}
+					if timeToMerge != nil {
+						metrics.Merged++
+						metrics.timesToMerge = append(metrics.timesToMerge, *timeToMerge)
+					}
+				}
+			}
+			for _, pr := range open {
+				age := now.Sub(pr.GetCreatedAt().Time)
+				totals.OpenByAge.add(age)
+				metricsFor(path.Base(pr.GetRepositoryURL())).OpenByAge.add(age)
+			}
+
+			perRepo := make([]*PullRequestMetrics, 0, len(byRepo))
+			for _, metrics := range byRepo {
+				metrics.summarize()
+				perRepo = append(perRepo, metrics)
+			}
+			sort.Slice(perRepo, func(i, j int) bool {
+				return perRepo[i].Repository < perRepo[j].Repository
+			})
+			totals.summarize()
+
+			response := map[string]any{
+				"org":          org,
+				"since":        since.Format(time.RFC3339),
+				"until":        now.Format(time.RFC3339),
+				"totals":       totals,
+				"repositories": perRepo,
+				// The report only covers the pull requests read when a search matched more than max_pull_requests.
+				"truncated": len(opened) < openedTotal || len(open) < openTotal,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgPullRequestMetrics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgPullRequestMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_pull_request_metrics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "days")
+	assert.Contains(t, tool.InputSchema.Properties, "max_pull_requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	now := time.Now().UTC()
+	at := func(ago time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: now.Add(-ago)}
+	}
+	pr := func(repo string, number int, created time.Duration, merged time.Duration) *github.Issue {
+		issue := &github.Issue{
+			Number:           github.Ptr(number),
+			RepositoryURL:    github.Ptr("https://api.github.com/repos/org/" + repo),
+			User:             &github.User{Login: github.Ptr("alice")},
+			CreatedAt:        at(created),
+			PullRequestLinks: &github.PullRequestLinks{},
+		}
+		if merged > 0 {
+			issue.PullRequestLinks.MergedAt = at(merged)
+		}
+		return issue
+	}
+	review := func(login, state string, submitted *github.Timestamp) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.Ptr(login)}, State: github.Ptr(state), SubmittedAt: submitted}
+	}
+	reviews := map[string][]*github.PullRequestReview{
+		"/repos/org/api/pulls/1/reviews": {
+			review("alice", "COMMENTED", at(71*time.Hour)),
+			review("bob", "APPROVED", at(70*time.Hour)),
+		},
+		"/repos/org/api/pulls/2/reviews": {
+			review("bob", "PENDING", nil),
+			review("carol", "CHANGES_REQUESTED", at(44*time.Hour)),
+		},
+		"/repos/org/web/pulls/3/reviews": {},
+	}
+
+	var queries []string
+	searchHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		queries = append(queries, query)
+		if strings.Contains(query, "is:open") {
+			mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+				Total: github.Ptr(3),
+				Issues: []*github.Issue{
+					pr("web", 3, 30*time.Hour, 0),
+					pr("web", 4, 10*time.Hour, 0),
+					pr("api", 9, 40*24*time.Hour, 0),
+				},
+			})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+			Total: github.Ptr(4),
+			Issues: []*github.Issue{
+				pr("api", 1, 72*time.Hour, 62*time.Hour),
+				pr("api", 2, 48*time.Hour, 28*time.Hour),
+				pr("web", 3, 30*time.Hour, 0),
+				pr("web", 4, 10*time.Hour, 0),
+			},
+		})(w, r)
+	})
+	reviewsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		list, ok := reviews[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, list)(w, r)
+	})
+	hours := func(h float64) *float64 { return &h }
+
+	t.Run("metrics per repository", func(t *testing.T) {
+		queries = nil
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler),
+			mock.WithRequestMatchHandler(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, reviewsHandler),
+		))
+		_, handler := GetOrgPullRequestMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+		request := createMCPRequest(map[string]any{
+			"org":          "org",
+			"repositories": []any{"api", "web"},
+			"days":         float64(7),
+		})
+
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		var response struct {
+			Totals       PullRequestMetrics   `json:"totals"`
+			Repositories []PullRequestMetrics `json:"repositories"`
+			Truncated    bool                 `json:"truncated"`
+			Errors       []map[string]string  `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+		assert.Equal(t, []string{
+			"is:pr org:org repo:org/api repo:org/web created:>=" + now.AddDate(0, 0, -7).Format("2006-01-02"),
+			"is:pr is:open draft:false org:org repo:org/api repo:org/web",
+		}, queries)
+
+		assert.Equal(t, PullRequestMetrics{
+			Opened:                       4,
+			Reviewed:                     2,
+			Merged:                       2,
+			MedianTimeToFirstReviewHours: hours(3),
+			P90TimeToFirstReviewHours:    hours(3.8),
+			MedianTimeToMergeHours:       hours(15),
+			P90TimeToMergeHours:          hours(19),
+			OpenByAge: PullRequestAgeBuckets{
+				UnderOneDay:     1,
+				OneToSevenDays:  1,
+				OverThirtyDays:  1,
+				TotalOpen:       3,
+				OldestOpenHours: 960,
+			},
+		}, response.Totals)
+		require.Len(t, response.Repositories, 2)
+		assert.Equal(t, "api", response.Repositories[0].Repository)
+		assert.Equal(t, 2, response.Repositories[0].Reviewed)
+		assert.Equal(t, hours(15), response.Repositories[0].MedianTimeToMergeHours)
+		assert.Equal(t, 1, response.Repositories[0].OpenByAge.OverThirtyDays)
+		assert.Equal(t, "web", response.Repositories[1].Repository)
+		assert.Equal(t, 2, response.Repositories[1].Opened)
+		assert.Equal(t, 0, response.Repositories[1].Merged)
+		assert.Nil(t, response.Repositories[1].MedianTimeToFirstReviewHours)
+		assert.Equal(t, 2, response.Repositories[1].OpenByAge.TotalOpen)
+		assert.False(t, response.Truncated)
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "web#4", response.Errors[0]["pull_request"])
+	})
+
+	t.Run("search fails", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+				}),
+			),
+		))
+		_, handler := GetOrgPullRequestMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+		request := createMCPRequest(map[string]any{
+			"org": "org",
+		})
+
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "failed to search opened pull requests")
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		_, handler := GetOrgPullRequestMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		request := createMCPRequest(map[string]any{
+			"org":  "org",
+			"days": float64(400),
+		})
+
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "days must be between 1 and 365")
+	})
+}
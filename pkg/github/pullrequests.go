@@ -2,10 +2,11 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -36,9 +37,11 @@ Possible options:
  5. get_review_comments - Get the review comments on a pull request. They are comments made on a portion of the unified diff during a pull request review. Use with pagination parameters to control the number of results returned.
  6. get_reviews - Get the reviews on a pull request. When asked for review comments, use get_review_comments method.
  7. get_comments - Get comments on a pull request. Use this if user doesn't specifically want review comments. Use with pagination parameters to control the number of results returned.
+ 8. get_conflicts - Check whether a pull request has merge conflicts with its base branch and, if it does, list the files changed on both branches since they diverged, which are the files that can conflict.
+ 9. get_check_annotations - Get the annotations (file, line, level and message) of the failed check runs on the head commit of a pull request, with annotations on files changed by the pull request first. Use this to locate build, lint or test failures without reading logs.
 `),
 
-				mcp.Enum("get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments"),
+				mcp.Enum("get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments", "get_conflicts", "get_check_annotations"),
 			),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -98,6 +101,10 @@ Possible options:
 				return GetPullRequestReviews(ctx, client, owner, repo, pullNumber)
 			case "get_comments":
 				return GetIssueComments(ctx, client, owner, repo, pullNumber, pagination)
+			case "get_conflicts":
+				return GetPullRequestConflicts(ctx, client, owner, repo, pullNumber)
+			case "get_check_annotations":
+				return GetPullRequestCheckAnnotations(ctx, client, owner, repo, pullNumber)
 			default:
 				return nil, fmt.Errorf("unknown method: %s", method)
 			}
@@ -123,12 +130,7 @@ func GetPullRequest(ctx context.Context, client *github.Client, owner, repo stri
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(pr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, pr), nil
 }
 
 func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -181,7 +183,7 @@ func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, rep
 	}
 
 	// Get combined status for the head SHA
-	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, *pr.Head.SHA, nil)
+	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, pr.GetHead().GetSHA(), nil)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx,
 			"failed to get combined status",
@@ -199,12 +201,7 @@ func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, rep
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(status)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, status), nil
 }
 
 func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -230,12 +227,226 @@ func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(files)
+	return MarshalledTextResult(ctx, files), nil
+}
+
+// maxCompareFiles is the number of files the compare API returns at most.
+const maxCompareFiles = 300
+
+func GetPullRequestConflicts(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request",
+			resp,
+			err,
+		), nil
+	}
+	_ = resp.Body.Close()
+
+	result := map[string]any{
+		"number":          pr.GetNumber(),
+		"base":            pr.GetBase().GetRef(),
+		"head":            pr.GetHead().GetRef(),
+		"mergeable":       pr.Mergeable,
+		"mergeable_state": pr.GetMergeableState(),
+	}
+	switch {
+	case pr.GetState() != "open":
+		result["has_conflicts"] = false
+		result["note"] = fmt.Sprintf("pull request is %s", pr.GetState())
+		return MarshalledTextResult(ctx, result), nil
+	case pr.Mergeable == nil:
+		// GitHub computes mergeability in the background after a push to either branch.
+		result["note"] = "GitHub is still computing whether the pull request can be merged, retry shortly"
+		return MarshalledTextResult(ctx, result), nil
+	case pr.GetMergeable():
+		result["has_conflicts"] = false
+		return MarshalledTextResult(ctx, result), nil
+	}
+	result["has_conflicts"] = true
+
+	// A file can only conflict when both branches changed it since they diverged.
+	headFiles, resp, err := listPullRequestFileNames(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request files",
+			resp,
+			err,
+		), nil
+	}
+
+	comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, pr.GetHead().GetSHA(), pr.GetBase().GetRef(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to compare base branch with pull request head",
+			resp,
+			err,
+		), nil
+	}
+	_ = resp.Body.Close()
+
+	conflicting := []string{}
+	for _, file := range comparison.Files {
+		if headFiles[file.GetFilename()] || (file.GetPreviousFilename() != "" && headFiles[file.GetPreviousFilename()]) {
+			conflicting = append(conflicting, file.GetFilename())
+		}
+	}
+	result["conflicting_files"] = conflicting
+	result["base_commits_ahead"] = comparison.GetAheadBy()
+	if len(comparison.Files) >= maxCompareFiles {
+		result["note"] = fmt.Sprintf("the base branch changed %d or more files, so the list of conflicting files may be incomplete", maxCompareFiles)
+	}
+	return MarshalledTextResult(ctx, result), nil
+}
+
+// listPullRequestFileNames returns the paths of the files changed in a pull request, including the previous
+// paths of renamed files.
+func listPullRequestFileNames(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (map[string]bool, *github.Response, error) {
+	names := map[string]bool{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, file := range files {
+			names[file.GetFilename()] = true
+			if file.GetPreviousFilename() != "" {
+				names[file.GetPreviousFilename()] = true
+			}
+		}
+		if resp.NextPage == 0 {
+			return names, resp, nil
+		}
+		opts.Page = resp.NextPage
 	}
+}
 
-	return mcp.NewToolResultText(string(r)), nil
+// failedCheckConclusions are the check run conclusions get_check_annotations reports annotations for.
+var failedCheckConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"startup_failure": true,
+	"action_required": true,
+}
+
+// CheckAnnotation is an annotation of a failed check run on the head commit of a pull request.
+type CheckAnnotation struct {
+	CheckRun      string `json:"check_run"`
+	Path          string `json:"path"`
+	StartLine     int    `json:"start_line"`
+	EndLine       int    `json:"end_line"`
+	Level         string `json:"level"`
+	Title         string `json:"title,omitempty"`
+	Message       string `json:"message"`
+	InChangedFile bool   `json:"in_changed_file"`
+}
+
+func GetPullRequestCheckAnnotations(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request",
+			resp,
+			err,
+		), nil
+	}
+	_ = resp.Body.Close()
+	headSHA := pr.GetHead().GetSHA()
+
+	var failedRuns []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{Filter: github.Ptr("latest"), ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, headSHA, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to list check runs",
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+		for _, run := range result.CheckRuns {
+			if failedCheckConclusions[run.GetConclusion()] {
+				failedRuns = append(failedRuns, run)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	changedFiles, resp, err := listPullRequestFileNames(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request files",
+			resp,
+			err,
+		), nil
+	}
+
+	runAnnotations := make([][]*github.CheckRunAnnotation, len(failedRuns))
+	errs := fanOut(ctx, failedRuns, DefaultFanOutConcurrency, func(ctx context.Context, i int, run *github.CheckRun) error {
+		opts := &github.ListOptions{PerPage: 100}
+		for {
+			annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, run.GetID(), opts)
+			if err != nil {
+				return err
+			}
+			_ = resp.Body.Close()
+			runAnnotations[i] = append(runAnnotations[i], annotations...)
+			if resp.NextPage == 0 {
+				return nil
+			}
+			opts.Page = resp.NextPage
+		}
+	})
+
+	checkRuns := make([]map[string]any, 0, len(failedRuns))
+	annotations := []CheckAnnotation{}
+	var failures []map[string]string
+	for i, run := range failedRuns {
+		checkRuns = append(checkRuns, map[string]any{
+			"name":        run.GetName(),
+			"conclusion":  run.GetConclusion(),
+			"url":         run.GetHTMLURL(),
+			"summary":     run.GetOutput().GetTitle(),
+			"annotations": len(runAnnotations[i]),
+		})
+		if errs[i] != nil {
+			failures = append(failures, map[string]string{"check_run": run.GetName(), "error": errs[i].Error()})
+			continue
+		}
+		for _, annotation := range runAnnotations[i] {
+			annotations = append(annotations, CheckAnnotation{
+				CheckRun:      run.GetName(),
+				Path:          annotation.GetPath(),
+				StartLine:     annotation.GetStartLine(),
+				EndLine:       annotation.GetEndLine(),
+				Level:         annotation.GetAnnotationLevel(),
+				Title:         annotation.GetTitle(),
+				Message:       annotation.GetMessage(),
+				InChangedFile: changedFiles[annotation.GetPath()],
+			})
+		}
+	}
+	// Annotations on files the pull request changed are the most likely to be caused by it, so they come first.
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].InChangedFile && !annotations[j].InChangedFile
+	})
+
+	result := map[string]any{
+		"head_sha":          headSHA,
+		"failed_check_runs": checkRuns,
+		"annotations":       annotations,
+	}
+	if len(failures) > 0 {
+		result["errors"] = failures
+	}
+	return MarshalledTextResult(ctx, result), nil
 }
 
 func GetPullRequestReviewComments(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -264,12 +475,7 @@ func GetPullRequestReviewComments(ctx context.Context, client *github.Client, ow
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request review comments: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(comments)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, comments), nil
 }
 
 func GetPullRequestReviews(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -291,12 +497,7 @@ func GetPullRequestReviews(ctx context.Context, client *github.Client, owner, re
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
 	}
 
-	r, err := json.Marshal(reviews)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(r)), nil
+	return MarshalledTextResult(ctx, reviews), nil
 }
 
 // CreatePullRequest creates a tool to create a new pull request.
@@ -415,12 +616,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				URL: pr.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalResponse), nil
 		}
 }
 
@@ -692,12 +888,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 				URL: finalPR.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalResponse), nil
 		}
 }
 
@@ -804,12 +995,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(prs)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, prs), nil
 		}
 }
 
@@ -897,12 +1083,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to merge pull request: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -951,10 +1132,17 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+var (
+	// pullRequestBranchUpdatePollInterval is how often update_pull_request_branch checks whether an update finished.
+	pullRequestBranchUpdatePollInterval = 2 * time.Second
+	// pullRequestBranchUpdateTimeout is how long update_pull_request_branch waits for an update to finish.
+	pullRequestBranchUpdateTimeout = 60 * time.Second
+)
+
 // UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
-func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func UpdatePullRequestBranch(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request_branch",
-			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch.")),
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch, by merging the base branch into it or rebasing it onto the base branch. Set wait to get the new head SHA once GitHub has finished the update.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -974,6 +1162,13 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			mcp.WithString("updateMethod",
+				mcp.Description("How to update the branch. Rebase must be allowed in the repository settings. Defaults to merge."),
+				mcp.Enum("merge", "rebase"),
+			),
+			mcp.WithBoolean("wait",
+				mcp.Description(fmt.Sprintf("Wait up to %d seconds for GitHub to finish the update and return the new head SHA. Default is false.", int(pullRequestBranchUpdateTimeout.Seconds()))),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -992,45 +1187,133 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			opts := &github.PullRequestBranchUpdateOptions{}
-			if expectedHeadSHA != "" {
-				opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
+			updateMethod, err := OptionalParam[string](request, "updateMethod")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if updateMethod == "" {
+				updateMethod = "merge"
+			}
+			wait, err := OptionalBoolParamWithDefault(request, "wait", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			result, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, pullNumber, opts)
-			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+
+			// The head before the update is needed to tell when the update finished, and rebasing needs the
+			// node ID of the pull request.
+			var pr *github.PullRequest
+			if wait || updateMethod == "rebase" {
+				var resp *github.Response
+				pr, resp, err = client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request",
+						resp,
+						err,
+					), nil
 				}
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to update pull request branch",
-					resp,
-					err,
-				), nil
+				_ = resp.Body.Close()
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
+			if updateMethod == "rebase" {
+				gqlClient, err := getGQLClient(ctx)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+
+				var mutation struct {
+					UpdatePullRequestBranch struct {
+						PullRequest struct {
+							ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+						}
+					} `graphql:"updatePullRequestBranch(input: $input)"`
+				}
+				input := githubv4.UpdatePullRequestBranchInput{
+					PullRequestID: githubv4.ID(pr.GetNodeID()),
+					UpdateMethod:  github.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+				}
+				if expectedHeadSHA != "" {
+					input.ExpectedHeadOid = github.Ptr(githubv4.GitObjectID(expectedHeadSHA))
+				}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update pull request branch", err), nil
+				}
+			} else {
+				opts := &github.PullRequestBranchUpdateOptions{}
+				if expectedHeadSHA != "" {
+					opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
+				}
+				result, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+					// and it's not a real error.
+					if resp == nil || resp.StatusCode != http.StatusAccepted || !isAcceptedError(err) {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to update pull request branch",
+							resp,
+							err,
+						), nil
+					}
+				} else {
+					defer func() { _ = resp.Body.Close() }()
+
+					if resp.StatusCode != http.StatusAccepted {
+						body, err := io.ReadAll(resp.Body)
+						if err != nil {
+							return nil, fmt.Errorf("failed to read response body: %w", err)
+						}
+						return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
+					}
+
+					if !wait {
+						return MarshalledTextResult(ctx, result), nil
+					}
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if !wait {
+				return mcp.NewToolResultText("Pull request branch update is in progress"), nil
 			}
+			return waitForPullRequestBranchUpdate(ctx, client, owner, repo, pullNumber, pr.GetHead().GetSHA(), updateMethod)
+		}
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// waitForPullRequestBranchUpdate polls a pull request until its head moves away from previousHead.
+func waitForPullRequestBranchUpdate(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, previousHead, updateMethod string) (*mcp.CallToolResult, error) {
+	deadline := time.Now().Add(pullRequestBranchUpdateTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pullRequestBranchUpdatePollInterval):
 		}
+
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get pull request",
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+
+		if head := pr.GetHead().GetSHA(); head != previousHead {
+			return MarshalledTextResult(ctx, map[string]any{
+				"updateMethod":    updateMethod,
+				"previousHeadSha": previousHead,
+				"headSha":         head,
+			}), nil
+		}
+		if time.Now().After(deadline) {
+			return mcp.NewToolResultText(fmt.Sprintf("Pull request branch update is still in progress after %d seconds, the head is still %s", int(pullRequestBranchUpdateTimeout.Seconds()), previousHead)), nil
+		}
+	}
 }
 
 type PullRequestReviewWriteParams struct {
@@ -1274,6 +1274,255 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestConflicts(t *testing.T) {
+	pr := func(mergeable *bool) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			State:          github.Ptr("open"),
+			Mergeable:      mergeable,
+			MergeableState: github.Ptr("dirty"),
+			Base:           &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("base123")},
+			Head:           &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("head456")},
+		}
+	}
+	headFiles := []*github.CommitFile{
+		{Filename: github.Ptr("go.mod")},
+		{Filename: github.Ptr("pkg/new_name.go"), PreviousFilename: github.Ptr("pkg/old_name.go")},
+		{Filename: github.Ptr("README.md")},
+	}
+	comparison := &github.CommitsComparison{
+		AheadBy: github.Ptr(4),
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("go.mod")},
+			{Filename: github.Ptr("pkg/old_name.go")},
+			{Filename: github.Ptr("docs/guide.md")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]any
+	}{
+		{
+			name: "conflicting files changed on both branches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(github.Ptr(false))),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, headFiles),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/compare/head456...main", r.URL.Path)
+						mockResponse(t, http.StatusOK, comparison)(w, r)
+					}),
+				),
+			),
+			expectedResult: map[string]any{
+				"number":             float64(42),
+				"base":               "main",
+				"head":               "feature",
+				"mergeable":          false,
+				"mergeable_state":    "dirty",
+				"has_conflicts":      true,
+				"conflicting_files":  []any{"go.mod", "pkg/old_name.go"},
+				"base_commits_ahead": float64(4),
+			},
+		},
+		{
+			name: "mergeable pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(github.Ptr(true))),
+			),
+			expectedResult: map[string]any{
+				"number":          float64(42),
+				"base":            "main",
+				"head":            "feature",
+				"mergeable":       true,
+				"mergeable_state": "dirty",
+				"has_conflicts":   false,
+			},
+		},
+		{
+			name: "mergeability not computed yet",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(nil)),
+			),
+			expectedResult: map[string]any{
+				"number":          float64(42),
+				"base":            "main",
+				"head":            "feature",
+				"mergeable":       nil,
+				"mergeable_state": "dirty",
+				"note":            "GitHub is still computing whether the pull request can be merged, retry shortly",
+			},
+		},
+		{
+			name: "compare fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(github.Ptr(false))),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, headFiles),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to compare base branch with pull request head",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PullRequestRead(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"method":     "get_conflicts",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetPullRequestCheckAnnotations(t *testing.T) {
+	pr := &github.PullRequest{
+		Number: github.Ptr(42),
+		Head:   &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("head456")},
+	}
+	checkRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Conclusion: github.Ptr("failure"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/1"), Output: &github.CheckRunOutput{Title: github.Ptr("2 problems")}},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("build"), Conclusion: github.Ptr("success")},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("test"), Conclusion: github.Ptr("timed_out"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/3")},
+		},
+	}
+	annotationsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/check-runs/1/annotations":
+			mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+				{Path: github.Ptr("vendor/lib.go"), StartLine: github.Ptr(3), EndLine: github.Ptr(3), AnnotationLevel: github.Ptr("warning"), Message: github.Ptr("exported func should have comment")},
+				{Path: github.Ptr("pkg/server.go"), StartLine: github.Ptr(10), EndLine: github.Ptr(12), AnnotationLevel: github.Ptr("failure"), Title: github.Ptr("errcheck"), Message: github.Ptr("error return value is not checked")},
+			})(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		expectError         bool
+		expectedErrMsg      string
+		expectedRuns        []string
+		expectedAnnotations []CheckAnnotation
+		expectedFailures    int
+	}{
+		{
+			name: "annotations of failed check runs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, []*github.CommitFile{
+					{Filename: github.Ptr("pkg/server.go")},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"filter":   "latest",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, checkRuns),
+					),
+				),
+				mock.WithRequestMatchHandler(mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId, annotationsHandler),
+			),
+			expectedRuns: []string{"lint", "test"},
+			expectedAnnotations: []CheckAnnotation{
+				{CheckRun: "lint", Path: "pkg/server.go", StartLine: 10, EndLine: 12, Level: "failure", Title: "errcheck", Message: "error return value is not checked", InChangedFile: true},
+				{CheckRun: "lint", Path: "vendor/lib.go", StartLine: 3, EndLine: 3, Level: "warning", Message: "exported func should have comment"},
+			},
+			expectedFailures: 1,
+		},
+		{
+			name: "check runs fail to list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PullRequestRead(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"method":     "get_check_annotations",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned struct {
+				HeadSHA         string              `json:"head_sha"`
+				FailedCheckRuns []map[string]any    `json:"failed_check_runs"`
+				Annotations     []CheckAnnotation   `json:"annotations"`
+				Errors          []map[string]string `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "head456", returned.HeadSHA)
+			var runs []string
+			for _, run := range returned.FailedCheckRuns {
+				runs = append(runs, run["name"].(string))
+			}
+			assert.Equal(t, tc.expectedRuns, runs)
+			assert.Equal(t, tc.expectedAnnotations, returned.Annotations)
+			assert.Len(t, returned.Errors, tc.expectedFailures)
+		})
+	}
+}
+
 func Test_GetPullRequestStatus(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1446,7 +1695,7 @@ func Test_GetPullRequestStatus(t *testing.T) {
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "update_pull_request_branch", tool.Name)
@@ -1455,6 +1704,8 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
 	assert.Contains(t, tool.InputSchema.Properties, "expectedHeadSha")
+	assert.Contains(t, tool.InputSchema.Properties, "updateMethod")
+	assert.Contains(t, tool.InputSchema.Properties, "wait")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock update result for success case
@@ -1463,13 +1714,59 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 		URL:     github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
 	}
 
+	// Poll quickly so waiting for the update doesn't slow down the tests.
+	defer func(interval, timeout time.Duration) {
+		pullRequestBranchUpdatePollInterval = interval
+		pullRequestBranchUpdateTimeout = timeout
+	}(pullRequestBranchUpdatePollInterval, pullRequestBranchUpdateTimeout)
+	pullRequestBranchUpdatePollInterval = time.Millisecond
+	pullRequestBranchUpdateTimeout = 20 * time.Millisecond
+
+	// getPullRequest serves the pull request with its head at "old123" for the first requests, then at "new456".
+	getPullRequest := func(updatedAfter int) http.HandlerFunc {
+		requests := 0
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			head := "old123"
+			if updatedAfter > 0 && requests > updatedAfter {
+				head = "new456"
+			}
+			mockResponse(t, http.StatusOK, &github.PullRequest{
+				Number: github.Ptr(42),
+				NodeID: github.Ptr("PR_kwDOA0xdyM50BPaO"),
+				Head:   &github.PullRequestBranch{SHA: github.Ptr(head)},
+			})(w, r)
+		}
+	}
+	rebaseMutation := func(input githubv4.UpdatePullRequestBranchInput, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				UpdatePullRequestBranch struct {
+					PullRequest struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updatePullRequestBranch(input: $input)"`
+			}{},
+			input,
+			nil,
+			response,
+		)
+	}
+	rebased := githubv4mock.DataResponse(map[string]any{
+		"updatePullRequestBranch": map[string]any{
+			"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO"},
+		},
+	})
+
 	tests := []struct {
 		name                 string
 		mockedClient         *http.Client
+		gqlClient            *http.Client
 		requestArgs          map[string]interface{}
 		expectError          bool
 		expectedUpdateResult *github.PullRequestBranchUpdateResponse
 		expectedErrMsg       string
+		expectedText         string
 	}{
 		{
 			name: "successful branch update",
@@ -1491,6 +1788,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 			},
 			expectError:          false,
 			expectedUpdateResult: mockUpdateResult,
+			expectedText:         "is in progress",
 		},
 		{
 			name: "branch update without expected SHA",
@@ -1509,6 +1807,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 			},
 			expectError:          false,
 			expectedUpdateResult: mockUpdateResult,
+			expectedText:         "is in progress",
 		},
 		{
 			name: "branch update fails",
@@ -1529,13 +1828,109 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to update pull request branch",
 		},
+		{
+			name: "rebase branch update",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, getPullRequest(0)),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				rebaseMutation(githubv4.UpdatePullRequestBranchInput{
+					PullRequestID:   githubv4.ID("PR_kwDOA0xdyM50BPaO"),
+					UpdateMethod:    githubv4mock.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+					ExpectedHeadOid: githubv4mock.Ptr(githubv4.GitObjectID("old123")),
+				}, rebased),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"pullNumber":      float64(42),
+				"expectedHeadSha": "old123",
+				"updateMethod":    "rebase",
+			},
+			expectedText: "is in progress",
+		},
+		{
+			name: "rebase not allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, getPullRequest(0)),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				rebaseMutation(githubv4.UpdatePullRequestBranchInput{
+					PullRequestID: githubv4.ID("PR_kwDOA0xdyM50BPaO"),
+					UpdateMethod:  githubv4mock.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+				}, githubv4mock.ErrorResponse("Rebase is not allowed for this repository")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"updateMethod": "rebase",
+			},
+			expectError:    true,
+			expectedErrMsg: "Rebase is not allowed",
+		},
+		{
+			name: "merge and wait for new head",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, getPullRequest(2)),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusAccepted, mockUpdateResult),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"wait":       true,
+			},
+			expectedText: `{"headSha":"new456","previousHeadSha":"old123","updateMethod":"merge"}`,
+		},
+		{
+			name: "rebase and wait for new head",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, getPullRequest(1)),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				rebaseMutation(githubv4.UpdatePullRequestBranchInput{
+					PullRequestID: githubv4.ID("PR_kwDOA0xdyM50BPaO"),
+					UpdateMethod:  githubv4mock.Ptr(githubv4.PullRequestBranchUpdateMethodRebase),
+				}, rebased),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"updateMethod": "rebase",
+				"wait":         true,
+			},
+			expectedText: `{"headSha":"new456","previousHeadSha":"old123","updateMethod":"rebase"}`,
+		},
+		{
+			name: "wait times out",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, getPullRequest(0)),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusAccepted, mockUpdateResult),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"wait":       true,
+			},
+			expectedText: "still in progress",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1558,7 +1953,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			assert.Contains(t, textContent.Text, "is in progress")
+			assert.Contains(t, textContent.Text, tc.expectedText)
 		})
 	}
 }
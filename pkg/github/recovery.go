@@ -0,0 +1,34 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RecoverToolPanics returns a tool handler middleware that turns a panic in a tool handler into a tool error result.
+// Without it, a panic, such as a nil pointer dereference on a field the API left out, kills the stdio server and
+// with it the whole client session. The panic value and stack are logged, and the model only sees that the tool
+// failed, so it can carry on with other tools.
+func RecoverToolPanics(logger *slog.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error("tool handler panicked",
+						"tool", request.Params.Name,
+						"panic", fmt.Sprint(r),
+						"stack", string(debug.Stack()),
+					)
+					result = mcp.NewToolResultError(fmt.Sprintf("internal error in tool %s: %v. This is a bug in the server, please report it", request.Params.Name, r))
+					err = nil
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecoverToolPanics(t *testing.T) {
+	var logs bytes.Buffer
+	middleware := RecoverToolPanics(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	request := createMCPRequest(map[string]any{})
+	request.Params.Name = "get_repository"
+
+	t.Run("panic becomes a tool error", func(t *testing.T) {
+		logs.Reset()
+		handler := middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var repository *github.Repository
+			return mcp.NewToolResultText(*repository.DefaultBranch), nil
+		})
+
+		var result *mcp.CallToolResult
+		var err error
+		require.NotPanics(t, func() {
+			result, err = handler(context.Background(), request)
+		})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "internal error in tool get_repository")
+		assert.Contains(t, errorContent.Text, "nil pointer dereference")
+
+		assert.Contains(t, logs.String(), "tool handler panicked")
+		assert.Contains(t, logs.String(), "tool=get_repository")
+		assert.Contains(t, logs.String(), "recovery_test.go")
+	})
+
+	t.Run("results and errors pass through", func(t *testing.T) {
+		logs.Reset()
+		handler := middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", getTextResult(t, result).Text)
+
+		handlerErr := errors.New("failed to get GitHub client")
+		handler = middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, handlerErr
+		})
+		result, err = handler(context.Background(), request)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, handlerErr)
+		assert.Empty(t, logs.String())
+	})
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultReleaseDownloadReleases is the default number of releases get_release_download_stats reads.
+	DefaultReleaseDownloadReleases = 10
+	// maxReleaseDownloadReleases is the number of releases get_release_download_stats reads at most.
+	maxReleaseDownloadReleases = 100
+)
+
+// ReleaseAssetDownloads is the number of downloads of a release asset.
+type ReleaseAssetDownloads struct {
+	Name      string `json:"name"`
+	Downloads int    `json:"downloads"`
+	Size      int    `json:"size"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ReleaseDownloads is the number of downloads of the assets of a release, most downloaded first.
+type ReleaseDownloads struct {
+	Tag         string                  `json:"tag"`
+	Name        string                  `json:"name,omitempty"`
+	PublishedAt string                  `json:"published_at,omitempty"`
+	Prerelease  bool                    `json:"prerelease,omitempty"`
+	Downloads   int                     `json:"downloads"`
+	Assets      []ReleaseAssetDownloads `json:"assets,omitempty"`
+}
+
+func convertToReleaseDownloads(release *github.RepositoryRelease, totalsOnly bool) ReleaseDownloads {
+	downloads := ReleaseDownloads{
+		Tag:         release.GetTagName(),
+		Name:        release.GetName(),
+		PublishedAt: formatOptionalTimestamp(release.PublishedAt),
+		Prerelease:  release.GetPrerelease(),
+	}
+	for _, asset := range release.Assets {
+		downloads.Downloads += asset.GetDownloadCount()
+		if !totalsOnly {
+			downloads.Assets = append(downloads.Assets, ReleaseAssetDownloads{
+				Name:      asset.GetName(),
+				Downloads: asset.GetDownloadCount(),
+				Size:      asset.GetSize(),
+				UpdatedAt: formatOptionalTimestamp(asset.UpdatedAt),
+			})
+		}
+	}
+	sort.SliceStable(downloads.Assets, func(i, j int) bool {
+		return downloads.Assets[i].Downloads > downloads.Assets[j].Downloads
+	})
+	return downloads
+}
+
+// GetReleaseDownloadStats creates a tool to report the downloads of the assets of the releases of a repository.
+func GetReleaseDownloadStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_download_stats",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DOWNLOAD_STATS_DESCRIPTION", "Get the download counts of the assets of the most recent releases of a GitHub repository, or of a single release, with the total of each release, to report the adoption of each release and artifact. Releases are returned newest first, so the totals read as a time series by publish date. Downloads of the source code archives GitHub generates aren't counted by GitHub.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_DOWNLOAD_STATS_USER_TITLE", "Get release download statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Description("Only report the release with this tag, e.g. 'v1.0.0'"),
+			),
+			mcp.WithNumber("releases",
+				mcp.Description(fmt.Sprintf("Number of most recent releases to report (default %d, max %d)", DefaultReleaseDownloadReleases, maxReleaseDownloadReleases)),
+				mcp.Min(1),
+				mcp.Max(maxReleaseDownloadReleases),
+			),
+			mcp.WithBoolean("include_prereleases",
+				mcp.Description("Whether to report prereleases (default true)"),
+			),
+			mcp.WithBoolean("totals_only",
+				mcp.Description("Only report the total downloads of each release, without its assets (default false)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := OptionalParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "releases", DefaultReleaseDownloadReleases)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > maxReleaseDownloadReleases {
+				return mcp.NewToolResultError(fmt.Sprintf("releases must be between 1 and %d", maxReleaseDownloadReleases)), nil
+			}
+			includePrereleases, err := OptionalBoolParamWithDefault(request, "include_prereleases", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			totalsOnly, err := OptionalBoolParamWithDefault(request, "totals_only", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var releases []*github.RepositoryRelease
+			if tag != "" {
+				release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get release by tag: %s", tag),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				releases = append(releases, release)
+			} else {
+				opts := &github.ListOptions{PerPage: 100}
+				for len(releases) < limit {
+					page, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to list releases",
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					for _, release := range page {
+						// Drafts can't be downloaded yet
+						if release.GetDraft() || (release.GetPrerelease() && !includePrereleases) {
+							continue
+						}
+						if len(releases) < limit {
+							releases = append(releases, release)
+						}
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			total := 0
+			result := make([]ReleaseDownloads, 0, len(releases))
+			for _, release := range releases {
+				downloads := convertToReleaseDownloads(release, totalsOnly)
+				total += downloads.Downloads
+				result = append(result, downloads)
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"downloads": total,
+				"releases":  result,
+			}), nil
+		}
+}
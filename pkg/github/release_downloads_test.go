@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetReleaseDownloadStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReleaseDownloadStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release_download_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "releases")
+	assert.Contains(t, tool.InputSchema.Properties, "totals_only")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	published := func(day int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2025, 3, day, 12, 0, 0, 0, time.UTC)}
+	}
+	asset := func(name string, downloads int) *github.ReleaseAsset {
+		return &github.ReleaseAsset{Name: github.Ptr(name), DownloadCount: github.Ptr(downloads), Size: github.Ptr(1024)}
+	}
+	releases := []*github.RepositoryRelease{
+		{TagName: github.Ptr("v2.1.0"), Draft: github.Ptr(true), Assets: []*github.ReleaseAsset{asset("app-linux.tar.gz", 0)}},
+		{TagName: github.Ptr("v2.0.0-rc.1"), Prerelease: github.Ptr(true), PublishedAt: published(20), Assets: []*github.ReleaseAsset{asset("app-linux.tar.gz", 5)}},
+		{TagName: github.Ptr("v1.1.0"), PublishedAt: published(10), Assets: []*github.ReleaseAsset{asset("app-darwin.zip", 40), asset("app-linux.tar.gz", 120)}},
+		{TagName: github.Ptr("v1.0.0"), PublishedAt: published(1), Assets: []*github.ReleaseAsset{asset("app-linux.tar.gz", 300)}},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedDownloads int
+		expectedReleases  []ReleaseDownloads
+	}{
+		{
+			name: "most recent releases without drafts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepo, releases),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"releases": float64(2),
+			},
+			expectedDownloads: 165,
+			expectedReleases: []ReleaseDownloads{
+				{
+					Tag: "v2.0.0-rc.1", PublishedAt: "2025-03-20T12:00:00Z", Prerelease: true, Downloads: 5,
+					Assets: []ReleaseAssetDownloads{{Name: "app-linux.tar.gz", Downloads: 5, Size: 1024}},
+				},
+				{
+					Tag: "v1.1.0", PublishedAt: "2025-03-10T12:00:00Z", Downloads: 160,
+					Assets: []ReleaseAssetDownloads{
+						{Name: "app-linux.tar.gz", Downloads: 120, Size: 1024},
+						{Name: "app-darwin.zip", Downloads: 40, Size: 1024},
+					},
+				},
+			},
+		},
+		{
+			name: "totals of releases without prereleases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepo, releases),
+			),
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"include_prereleases": false,
+				"totals_only":         true,
+			},
+			expectedDownloads: 460,
+			expectedReleases: []ReleaseDownloads{
+				{Tag: "v1.1.0", PublishedAt: "2025-03-10T12:00:00Z", Downloads: 160},
+				{Tag: "v1.0.0", PublishedAt: "2025-03-01T12:00:00Z", Downloads: 300},
+			},
+		},
+		{
+			name: "single release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesTagsByOwnerByRepoByTag, releases[3]),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag":         "v1.0.0",
+				"totals_only": true,
+			},
+			expectedDownloads: 300,
+			expectedReleases: []ReleaseDownloads{
+				{Tag: "v1.0.0", PublishedAt: "2025-03-01T12:00:00Z", Downloads: 300},
+			},
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v9.9.9",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get release by tag: v9.9.9",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReleaseDownloadStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				Downloads int                `json:"downloads"`
+				Releases  []ReleaseDownloads `json:"releases"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedDownloads, response.Downloads)
+			assert.Equal(t, tc.expectedReleases, response.Releases)
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -41,6 +44,9 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Description("Whether to include file diffs and stats in the response. Default is true."),
 				mcp.DefaultBool(true),
 			),
+			mcp.WithBoolean("include_verification",
+				mcp.Description("Whether to include the signature verification status of the commit. Default is false."),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -60,6 +66,10 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includeVerification, err := OptionalBoolParamWithDefault(request, "include_verification", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -93,14 +103,260 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 
 			// Convert to minimal commit
-			minimalCommit := convertToMinimalCommit(commit, includeDiff)
+			minimalCommit := convertToMinimalCommit(commit, includeDiff, includeVerification)
+
+			return MarshalledTextResult(ctx, minimalCommit), nil
+		}
+}
+
+// DiffStats is the per-file change summary between two refs or for a single commit, without patch text.
+type DiffStats struct {
+	Files        []MinimalCommitFile `json:"files"`
+	TotalFiles   int                 `json:"total_files"`
+	Additions    int                 `json:"additions"`
+	Deletions    int                 `json:"deletions"`
+	Changes      int                 `json:"changes"`
+	Status       string              `json:"status,omitempty"`
+	AheadBy      int                 `json:"ahead_by,omitempty"`
+	BehindBy     int                 `json:"behind_by,omitempty"`
+	TotalCommits int                 `json:"total_commits,omitempty"`
+}
+
+// convertToDiffStats summarizes changed files, dropping their patches.
+func convertToDiffStats(files []*github.CommitFile) DiffStats {
+	stats := DiffStats{Files: make([]MinimalCommitFile, 0, len(files))}
+	for _, file := range files {
+		stats.Files = append(stats.Files, MinimalCommitFile{
+			Filename:  file.GetFilename(),
+			Status:    file.GetStatus(),
+			Additions: file.GetAdditions(),
+			Deletions: file.GetDeletions(),
+			Changes:   file.GetChanges(),
+		})
+		stats.Additions += file.GetAdditions()
+		stats.Deletions += file.GetDeletions()
+		stats.Changes += file.GetChanges()
+	}
+	stats.TotalFiles = len(stats.Files)
+	return stats
+}
+
+// GetDiffStats creates a tool to get per-file change statistics between two refs or for a commit.
+func GetDiffStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_diff_stats",
+			mcp.WithDescription(t("TOOL_GET_DIFF_STATS_DESCRIPTION", "Get per-file additions, deletions and status for a commit, or between two refs, without patch text. Use it to understand the shape of a change before fetching diffs. Provide either 'sha', or both 'base' and 'head'.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DIFF_STATS_USER_TITLE", "Get diff statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Commit SHA, branch name, or tag name to get the changes of a single commit"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Base ref (branch, tag or SHA) to compare from"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Head ref (branch, tag or SHA) to compare to"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := OptionalParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if sha != "" && (base != "" || head != "") {
+				return mcp.NewToolResultError("provide either sha, or base and head, not both"), nil
+			}
+			if sha == "" && (base == "" || head == "") {
+				return mcp.NewToolResultError("either sha, or both base and head must be provided"), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var stats DiffStats
+			if sha != "" {
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get commit: %s", sha),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				stats = convertToDiffStats(commit.Files)
+			} else {
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to compare %s...%s", base, head),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				stats = convertToDiffStats(comparison.Files)
+				stats.Status = comparison.GetStatus()
+				stats.AheadBy = comparison.GetAheadBy()
+				stats.BehindBy = comparison.GetBehindBy()
+				stats.TotalCommits = comparison.GetTotalCommits()
+			}
+
+			return MarshalledTextResult(ctx, stats), nil
+		}
+}
+
+// RefComparison is the compact comparison of two refs.
+type RefComparison struct {
+	Status       string              `json:"status"`
+	AheadBy      int                 `json:"ahead_by"`
+	BehindBy     int                 `json:"behind_by"`
+	TotalCommits int                 `json:"total_commits"`
+	MergeBaseSHA string              `json:"merge_base_sha,omitempty"`
+	HTMLURL      string              `json:"html_url,omitempty"`
+	Commits      []MinimalCommit     `json:"commits"`
+	Stats        *MinimalCommitStats `json:"stats,omitempty"`
+	Files        []MinimalCommitFile `json:"files,omitempty"`
+}
+
+// CompareRefs creates a tool to compare two refs of a repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two refs of a GitHub repository (base...head): whether head is ahead of, behind or diverged from base and by how many commits, the commits in head that aren't in base, and the files they change. Use it to prepare a release or check what merging a branch brings in. Commits are paginated, oldest first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base ref (branch, tag or SHA) to compare from. Use owner:branch to compare across forks."),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head ref (branch, tag or SHA) to compare to. Use owner:branch to compare across forks."),
+			),
+			mcp.WithBoolean("include_diff",
+				mcp.Description("Whether to include the changed files and stats in the response. Default is true."),
+				mcp.DefaultBool(true),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeDiff, err := OptionalBoolParamWithDefault(request, "include_diff", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
 
-			r, err := json.Marshal(minimalCommit)
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := RefComparison{
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				TotalCommits: comparison.GetTotalCommits(),
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				HTMLURL:      comparison.GetHTMLURL(),
+				Commits:      make([]MinimalCommit, 0, len(comparison.Commits)),
+			}
+			for _, commit := range comparison.Commits {
+				result.Commits = append(result.Commits, convertToMinimalCommit(commit, false, false))
+			}
+			if includeDiff {
+				stats := convertToDiffStats(comparison.Files)
+				result.Files = stats.Files
+				result.Stats = &MinimalCommitStats{
+					Additions: stats.Additions,
+					Deletions: stats.Deletions,
+					Total:     stats.Changes,
+				}
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -126,6 +382,9 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("author",
 				mcp.Description("Author username or email address to filter commits by"),
 			),
+			mcp.WithBoolean("include_verification",
+				mcp.Description("Whether to include the signature verification status of each commit. Default is false."),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -145,6 +404,10 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includeVerification, err := OptionalBoolParamWithDefault(request, "include_verification", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -188,15 +451,143 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			// Convert to minimal commits
 			minimalCommits := make([]MinimalCommit, len(commits))
 			for i, commit := range commits {
-				minimalCommits[i] = convertToMinimalCommit(commit, false)
+				minimalCommits[i] = convertToMinimalCommit(commit, false, includeVerification)
+			}
+
+			return MarshalledTextResult(ctx, minimalCommits), nil
+		}
+}
+
+// ListUnverifiedCommits creates a tool to audit the commits of a branch for signatures GitHub could not verify.
+func ListUnverifiedCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_unverified_commits",
+			mcp.WithDescription(t("TOOL_LIST_UNVERIFIED_COMMITS_DESCRIPTION", "List the commits of a branch, over a time window, whose signature is missing or could not be verified by GitHub. Use it to audit signed-commit policies. Returns the unverified commits with their verification reason, and a count per reason.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_UNVERIFIED_COMMITS_USER_TITLE", "List unverified commits"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to audit. If not provided, uses the default branch of the repository."),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only audit commits made at or after this time (ISO 8601 timestamp). Defaults to 30 days ago."),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only audit commits made before this time (ISO 8601 timestamp). Defaults to now."),
+			),
+			mcp.WithNumber("max_pages",
+				mcp.Description("Maximum number of pages of 100 commits to audit. Defaults to 5."),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			untilParam, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxPages, err := OptionalIntParamWithDefault(request, "max_pages", 5)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.CommitsListOptions{
+				SHA:         branch,
+				Since:       time.Now().AddDate(0, 0, -30),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			if sinceParam != "" {
+				opts.Since, err = parseISOTimestamp(sinceParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse since: %s", err.Error())), nil
+				}
+			}
+			if untilParam != "" {
+				opts.Until, err = parseISOTimestamp(untilParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse until: %s", err.Error())), nil
+				}
 			}
 
-			r, err := json.Marshal(minimalCommits)
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			unverified := []MinimalCommit{}
+			byReason := map[string]int{}
+			scanned := 0
+			truncated := false
+			for page := 1; ; page++ {
+				if page > maxPages {
+					truncated = true
+					break
+				}
+				opts.Page = page
+				commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list commits: %s", branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, commit := range commits {
+					scanned++
+					minimalCommit := convertToMinimalCommit(commit, false, true)
+					if minimalCommit.Verification.Verified {
+						continue
+					}
+					byReason[minimalCommit.Verification.Reason]++
+					unverified = append(unverified, minimalCommit)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+			}
+
+			response := map[string]any{
+				"repository":      fmt.Sprintf("%s/%s", owner, repo),
+				"since":           opts.Since.UTC().Format(time.RFC3339),
+				"scannedCommits":  scanned,
+				"unverifiedCount": len(unverified),
+				"byReason":        byReason,
+				"commits":         unverified,
+				"truncated":       truncated,
+			}
+			if branch != "" {
+				response["branch"] = branch
+			}
+			if !opts.Until.IsZero() {
+				response["until"] = opts.Until.UTC().Format(time.RFC3339)
+			}
+
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -268,19 +659,14 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				minimalBranches = append(minimalBranches, convertToMinimalBranch(branch))
 			}
 
-			r, err := json.Marshal(minimalBranches)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalBranches), nil
 		}
 }
 
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
 func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
-			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Creating a file that already exists fails with its current SHA, so it can be updated instead. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -309,9 +695,18 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Branch to create/update the file in"),
 			),
+			mcp.WithString("from_branch",
+				mcp.Description("Branch to create branch from if it does not exist yet. When omitted, branch must already exist."),
+			),
 			mcp.WithString("sha",
 				mcp.Description("Required if updating an existing file. The blob SHA of the file being replaced."),
 			),
+			mcp.WithBoolean("force_latest",
+				mcp.Description("Write over the file as it currently is at the head of the branch, resolving its current SHA instead of failing when sha is missing or stale. Changes made since sha was read are overwritten. Default is false."),
+			),
+			mcp.WithBoolean("include_diff",
+				mcp.Description("Whether to include a unified diff of the replaced file against the new content in the response, so the change can be reviewed without comparing commits. Default is false."),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -354,15 +749,101 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			if sha != "" {
-				opts.SHA = github.Ptr(sha)
+			forceLatest, err := OptionalBoolParamWithDefault(request, "force_latest", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fromBranch, err := OptionalParam[string](request, "from_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeDiff, err := OptionalBoolParamWithDefault(request, "include_diff", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Create or update the file
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			var notes []string
+			written := false
+			if fromBranch != "" {
+				baseRef, exists, resp, err := getBranchHead(ctx, client, owner, repo, branch, fromBranch)
+				if isEmptyRepository(resp, err) {
+					return emptyRepositoryError(owner, repo, false), nil
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get branch reference",
+						resp,
+						err,
+					), nil
+				}
+				if !exists {
+					// The contents API cannot create branches, so the branch is created at the head of from_branch
+					// first. A branch created meanwhile by someone else isn't written to, as it may hold anything.
+					_, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+						Ref:    github.Ptr("refs/heads/" + branch),
+						Object: &github.GitObject{SHA: baseRef.Object.SHA},
+					})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to create branch '%s'", branch),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					notes = append(notes, fmt.Sprintf("created branch '%s' from '%s'", branch, fromBranch))
+
+					// The branch is deleted again if the file isn't written, so a failed call leaves nothing behind
+					defer func() {
+						if written {
+							return
+						}
+						if resp, err := client.Git.DeleteRef(context.WithoutCancel(ctx), owner, repo, "refs/heads/"+branch); err == nil {
+							_ = resp.Body.Close()
+						}
+					}()
+				}
+			}
+
+			// Check the branch head before writing, as the API answers a missing or stale SHA with a generic error.
+			if sha == "" || forceLatest {
+				currentSHA, exists, resp, err := getCurrentFileSHA(ctx, client, owner, repo, path, branch)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to check whether '%s' exists on branch '%s'", path, branch),
+						resp,
+						err,
+					), nil
+				}
+				switch {
+				case !exists:
+					sha = ""
+				case sha == "" && !forceLatest:
+					return mcp.NewToolResultError(fmt.Sprintf("file '%s' already exists on branch '%s' with SHA %s. To update it, call again with sha set to %s", path, branch, currentSHA, currentSHA)), nil
+				case sha != currentSHA:
+					if sha != "" {
+						notes = append(notes, fmt.Sprintf("sha %s was stale, the file was updated from its current SHA %s", sha, currentSHA))
+					}
+					sha = currentSHA
+				}
+			}
+			if sha != "" {
+				opts.SHA = github.Ptr(sha)
+			}
+
+			// The diff is taken before writing, from the blob being replaced rather than whatever the branch holds
+			// once the write is done.
+			var diff string
+			if includeDiff {
+				diff = fileUpdateDiff(ctx, client, owner, repo, path, sha, content)
+			}
+
+			// Create or update the file
 			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -380,14 +861,86 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				}
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
 			}
+			written = true
 
-			r, err := json.Marshal(fileContent)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result := MarshalledTextResult(ctx, fileContent)
+			for _, note := range notes {
+				result.Content = append(result.Content, mcp.NewTextContent(note))
 			}
+			if diff != "" {
+				result.Content = append(result.Content, mcp.NewTextContent(diff))
+			}
+			return result, nil
+		}
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// maxFileUpdateDiffLength is the number of characters of the diff create_or_update_file returns.
+const maxFileUpdateDiffLength = 20000
+
+// fileUpdateDiff returns the unified diff of a file from the blob with the given SHA, or from nothing if sha is
+// empty, to its new content. Failing to diff doesn't fail the write, so the reason is returned instead.
+func fileUpdateDiff(ctx context.Context, client *github.Client, owner, repo, path, sha, content string) string {
+	fromName, from := "/dev/null", ""
+	if sha != "" {
+		blob, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, sha)
+		if err != nil {
+			return fmt.Sprintf("no diff: failed to get the replaced content of '%s': %v", path, err)
+		}
+		_ = resp.Body.Close()
+		if !utf8.Valid(blob) {
+			return fmt.Sprintf("no diff: '%s' is a binary file", path)
 		}
+		fromName, from = "a/"+path, string(blob)
+	}
+
+	diff, ok := unifiedDiff(fromName, "b/"+path, from, content)
+	switch {
+	case !ok:
+		return fmt.Sprintf("no diff: '%s' differs in too many lines to diff", path)
+	case diff == "":
+		return fmt.Sprintf("no diff: the content of '%s' is unchanged", path)
+	case len(diff) > maxFileUpdateDiffLength:
+		return diff[:maxFileUpdateDiffLength] + "\n... (diff truncated)"
+	}
+	return diff
+}
+
+// getBranchHead returns the reference of a branch and whether it exists. When the branch does not exist and
+// fromBranch is set, the reference of fromBranch is returned instead so the branch can be created from it.
+func getBranchHead(ctx context.Context, client *github.Client, owner, repo, branch, fromBranch string) (*github.Reference, bool, *github.Response, error) {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err == nil {
+		_ = resp.Body.Close()
+		return ref, true, nil, nil
+	}
+	if fromBranch == "" || resp == nil || resp.StatusCode != http.StatusNotFound {
+		return nil, false, resp, err
+	}
+	_ = resp.Body.Close()
+
+	ref, resp, err = client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+	if err != nil {
+		return nil, false, resp, fmt.Errorf("failed to get reference of '%s': %w", fromBranch, err)
+	}
+	_ = resp.Body.Close()
+	return ref, false, nil, nil
+}
+
+// getCurrentFileSHA returns the blob SHA of a file at the head of a branch, and whether the file exists.
+func getCurrentFileSHA(ctx context.Context, client *github.Client, owner, repo, path, branch string) (string, bool, *github.Response, error) {
+	fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return "", false, nil, nil
+	}
+	if err != nil {
+		return "", false, resp, err
+	}
+	_ = resp.Body.Close()
+	if fileContent == nil && dirContent != nil {
+		return "", false, resp, fmt.Errorf("path '%s' is a directory", path)
+	}
+	return fileContent.GetSHA(), true, nil, nil
 }
 
 // CreateRepository creates a tool to create a new GitHub repository.
@@ -472,12 +1025,306 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				URL: createdRepo.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
+			return MarshalledTextResult(ctx, minimalResponse), nil
+		}
+}
+
+// MinimalRepositorySettings is the output type of update_repository, with the settings the tool can change.
+type MinimalRepositorySettings struct {
+	FullName            string `json:"full_name"`
+	HTMLURL             string `json:"html_url"`
+	Description         string `json:"description"`
+	Visibility          string `json:"visibility"`
+	DefaultBranch       string `json:"default_branch"`
+	AllowMergeCommit    bool   `json:"allow_merge_commit"`
+	AllowSquashMerge    bool   `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool   `json:"allow_rebase_merge"`
+	DeleteBranchOnMerge bool   `json:"delete_branch_on_merge"`
+	HasIssues           bool   `json:"has_issues"`
+	HasWiki             bool   `json:"has_wiki"`
+	HasProjects         bool   `json:"has_projects"`
+}
+
+func convertToMinimalRepositorySettings(repo *github.Repository) MinimalRepositorySettings {
+	return MinimalRepositorySettings{
+		FullName:            repo.GetFullName(),
+		HTMLURL:             repo.GetHTMLURL(),
+		Description:         repo.GetDescription(),
+		Visibility:          repo.GetVisibility(),
+		DefaultBranch:       repo.GetDefaultBranch(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+		HasProjects:         repo.GetHasProjects(),
+	}
+}
+
+// UpdateRepository creates a tool to update the settings of an existing repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings of an existing GitHub repository, such as its description, visibility, default branch, allowed merge methods and enabled features. Only the given settings change. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description. An empty string removes it"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Repository visibility. 'internal' is only available to organizations on GitHub Enterprise"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			mcp.WithString("default_branch",
+				mcp.Description("Name of an existing branch to make the default branch"),
+			),
+			mcp.WithBoolean("allow_merge_commit",
+				mcp.Description("Allow merging pull requests with a merge commit"),
+			),
+			mcp.WithBoolean("allow_squash_merge",
+				mcp.Description("Allow squash merging pull requests"),
+			),
+			mcp.WithBoolean("allow_rebase_merge",
+				mcp.Description("Allow rebase merging pull requests"),
+			),
+			mcp.WithBoolean("delete_branch_on_merge",
+				mcp.Description("Delete head branches automatically when pull requests are merged"),
+			),
+			mcp.WithBoolean("has_issues",
+				mcp.Description("Enable issues"),
+			),
+			mcp.WithBoolean("has_wiki",
+				mcp.Description("Enable the wiki"),
+			),
+			mcp.WithBoolean("has_projects",
+				mcp.Description("Enable projects"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &github.Repository{}
+			empty := true
+			for param, field := range map[string]**string{
+				"description":    &update.Description,
+				"visibility":     &update.Visibility,
+				"default_branch": &update.DefaultBranch,
+			} {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					empty = false
+				}
+			}
+			for param, field := range map[string]**bool{
+				"allow_merge_commit":     &update.AllowMergeCommit,
+				"allow_squash_merge":     &update.AllowSquashMerge,
+				"allow_rebase_merge":     &update.AllowRebaseMerge,
+				"delete_branch_on_merge": &update.DeleteBranchOnMerge,
+				"has_issues":             &update.HasIssues,
+				"has_wiki":               &update.HasWiki,
+				"has_projects":           &update.HasProjects,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					empty = false
+				}
+			}
+			if empty {
+				return mcp.NewToolResultError("no repository settings to update were given"), nil
+			}
+			if update.DefaultBranch != nil && *update.DefaultBranch == "" {
+				return mcp.NewToolResultError("default_branch can't be empty"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updatedRepo, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalRepositorySettings(updatedRepo)), nil
+		}
+}
+
+// RepositoryAdmin creates a tool to archive, unarchive, transfer, rename and delete a repository.
+func RepositoryAdmin(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("repository_admin",
+			mcp.WithDescription(t("TOOL_REPOSITORY_ADMIN_DESCRIPTION", "Archive, unarchive, transfer, rename or delete a GitHub repository. Requires admin access to the repository. Deleting a repository can't be undone and requires confirm to be set to the full name of the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REPOSITORY_ADMIN_USER_TITLE", "Archive, transfer, rename or delete repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("method",
+				mcp.Required(),
+				mcp.Description(`The operation to perform on the repository.
+Options are:
+- 'archive' - make the repository read-only.
+- 'unarchive' - make an archived repository writable again.
+- 'transfer' - transfer the repository to new_owner, optionally renaming it to new_name. Transfers to a user must be accepted by that user.
+- 'rename' - rename the repository to new_name. GitHub redirects the old name to the new one.
+- 'delete' - permanently delete the repository. Requires confirm.
+`),
+				mcp.Enum("archive", "unarchive", "transfer", "rename", "delete"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_owner",
+				mcp.Description("Username or organization to transfer the repository to. Required for 'transfer'"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the repository. Required for 'rename', optional for 'transfer'"),
+			),
+			mcp.WithString("confirm",
+				mcp.Description("Full name of the repository (owner/repo) to confirm its deletion. Required for 'delete'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			method, err := RequiredParam[string](request, "method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := OptionalParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirm, err := OptionalParam[string](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var update *github.Repository
+			switch method {
+			case "archive":
+				update = &github.Repository{Archived: github.Ptr(true)}
+			case "unarchive":
+				update = &github.Repository{Archived: github.Ptr(false)}
+			case "rename":
+				if newName == "" {
+					return mcp.NewToolResultError("new_name is required for rename"), nil
+				}
+				update = &github.Repository{Name: github.Ptr(newName)}
+			case "transfer":
+				if newOwner == "" {
+					return mcp.NewToolResultError("new_owner is required for transfer"), nil
+				}
+			case "delete":
+				fullName := owner + "/" + repo
+				if !strings.EqualFold(confirm, fullName) {
+					return mcp.NewToolResultError(fmt.Sprintf("deleting a repository can't be undone, set confirm to %q to delete it", fullName)), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: archive, unarchive, transfer, rename, delete", method)), nil
+			}
+
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			switch method {
+			case "transfer":
+				transfer := github.TransferRequest{NewOwner: newOwner}
+				if newName != "" {
+					transfer.NewName = github.Ptr(newName)
+				}
+				transferred, resp, err := client.Repositories.Transfer(ctx, owner, repo, transfer)
+				if err != nil {
+					// The transfer usually continues in the background, which GitHub reports with 202 Accepted
+					if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+						target := newName
+						if target == "" {
+							target = repo
+						}
+						return mcp.NewToolResultText(fmt.Sprintf("Transfer of %s/%s to %s/%s is in progress. Transfers to a user complete once the user accepts them.", owner, repo, newOwner, target)), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to transfer repository %s/%s to %s", owner, repo, newOwner),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(ctx, convertToMinimalRepository(transferred)), nil
+
+			case "delete":
+				resp, err := client.Repositories.Delete(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to delete repository %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return mcp.NewToolResultText(fmt.Sprintf("Repository %s/%s deleted", owner, repo)), nil
+
+			default:
+				updated, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to %s repository %s/%s", method, owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(ctx, convertToMinimalRepository(updated)), nil
+			}
 		}
 }
 
@@ -507,6 +1354,24 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			mcp.WithString("sha",
 				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
 			),
+			mcp.WithBoolean("render_notebook",
+				mcp.Description("Return Jupyter notebooks (.ipynb) as a readable Markdown sequence of cells with their text outputs instead of raw JSON. Binary outputs such as images are omitted. Default is false."),
+			),
+			mcp.WithBoolean("split_front_matter",
+				mcp.Description("Return the YAML front matter of Markdown files separately from the document body. Default is false."),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line of a text file to return, counting from 1. Use it with end_line to read a slice of a large file"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of a text file to return. Defaults to the end of the file"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description("Number of lines to also return before start_line and after end_line. Default is 0."),
+				mcp.Min(0),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -529,6 +1394,35 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			renderNotebookCells, err := OptionalBoolParamWithDefault(request, "render_notebook", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			separateFrontMatter, err := OptionalBoolParamWithDefault(request, "split_front_matter", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var lines lineRange
+			if lines.Start, err = OptionalIntParam(request, "start_line"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if lines.End, err = OptionalIntParam(request, "end_line"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if lines.Context, err = OptionalIntParam(request, "context_lines"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sliced := lines.Start != 0 || lines.End != 0
+			switch {
+			case lines.Start < 0 || lines.End < 0 || lines.Context < 0:
+				return mcp.NewToolResultError("start_line, end_line and context_lines can't be negative"), nil
+			case lines.End != 0 && lines.Start > lines.End:
+				return mcp.NewToolResultError("start_line must not be after end_line"), nil
+			case lines.Context != 0 && !sliced:
+				return mcp.NewToolResultError("context_lines requires start_line or end_line"), nil
+			case sliced && (renderNotebookCells || separateFrontMatter):
+				return mcp.NewToolResultError("start_line and end_line can't be combined with render_notebook or split_front_matter"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -582,7 +1476,7 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 					if err != nil {
 						return mcp.NewToolResultError("failed to read response body"), nil
 					}
-					contentType := resp.Header.Get("Content-Type")
+					contentType := detectContentType(path, resp.Header.Get("Content-Type"), body)
 
 					var resourceURI string
 					switch {
@@ -603,14 +1497,48 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 						}
 					}
 
-					// Determine if content is text or binary
-					isTextContent := strings.HasPrefix(contentType, "text/") ||
-						contentType == "application/json" ||
-						contentType == "application/xml" ||
-						strings.HasSuffix(contentType, "+json") ||
-						strings.HasSuffix(contentType, "+xml")
+					ext := strings.ToLower(filepath.Ext(path))
+					if renderNotebookCells && ext == ".ipynb" {
+						rendered, cells, err := renderNotebook(body)
+						if err != nil {
+							return mcp.NewToolResultError(err.Error()), nil
+						}
+						result := mcp.TextResourceContents{
+							URI:      resourceURI,
+							Text:     rendered,
+							MIMEType: "text/markdown",
+						}
+						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded notebook with %d cells (SHA: %s)", cells, fileSHA), result), nil
+					}
+
+					if separateFrontMatter && (ext == ".md" || ext == ".markdown" || ext == ".mdx") {
+						if frontMatter, document, ok := splitFrontMatter(string(body)); ok {
+							result := mcp.TextResourceContents{
+								URI:      resourceURI,
+								Text:     document,
+								MIMEType: "text/markdown",
+							}
+							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)\n\nFront matter:\n```yaml\n%s```", fileSHA, frontMatter), result), nil
+						}
+					}
+
+					if sliced {
+						if !isTextContentType(contentType) {
+							return mcp.NewToolResultError(fmt.Sprintf("start_line and end_line only apply to text files, %s is %s", path, contentType)), nil
+						}
+						slice, first, last, total, err := sliceLines(string(body), lines)
+						if err != nil {
+							return mcp.NewToolResultError(err.Error()), nil
+						}
+						result := mcp.TextResourceContents{
+							URI:      resourceURI,
+							Text:     slice,
+							MIMEType: contentType,
+						}
+						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded lines %d-%d of %d of text file (SHA: %s)", first, last, total, fileSHA), result), nil
+					}
 
-					if isTextContent {
+					if isTextContentType(contentType) {
 						result := mcp.TextResourceContents{
 							URI:      resourceURI,
 							Text:     string(body),
@@ -645,11 +1573,7 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
 				if err == nil && resp.StatusCode == http.StatusOK {
 					defer func() { _ = resp.Body.Close() }()
-					r, err := json.Marshal(dirContent)
-					if err != nil {
-						return mcp.NewToolResultError("failed to marshal response"), nil
-					}
-					return mcp.NewToolResultText(string(r)), nil
+					return MarshalledTextResult(ctx, dirContent), nil
 				}
 			}
 
@@ -758,12 +1682,79 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				URL: forkedRepo.GetHTMLURL(),
 			}
 
-			r, err := json.Marshal(minimalResponse)
+			return MarshalledTextResult(ctx, minimalResponse), nil
+		}
+}
+
+// ListForks creates a tool to list the forks of a repository.
+func ListForks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_forks",
+			mcp.WithDescription(t("TOOL_LIST_FORKS_DESCRIPTION", "List the forks of a GitHub repository. Sort by newest, oldest, stargazers or watchers, and compare pushed_at to find forks that are still actively developed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FORKS_USER_TITLE", "List forks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort order of the forks. Defaults to newest."),
+				mcp.Enum("newest", "oldest", "stargazers", "watchers"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryListForksOptions{
+				Sort: sort,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			forks, resp, err := client.Repositories.ListForks(ctx, owner, repo, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list forks",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalForks := make([]MinimalRepository, 0, len(forks))
+			for _, fork := range forks {
+				minimalForks = append(minimalForks, convertToMinimalRepository(fork))
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalForks), nil
 		}
 }
 
@@ -837,7 +1828,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get base commit",
@@ -866,7 +1857,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			// Create a new tree with the deletion
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), treeEntries)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create tree",
@@ -934,12 +1925,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				"content": nil,
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, response), nil
 		}
 }
 
@@ -966,6 +1952,9 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			mcp.WithString("from_branch",
 				mcp.Description("Source branch (defaults to repo default)"),
 			),
+			mcp.WithBoolean("initialize",
+				mcp.Description("If the repository is empty, create an initial commit with a README on the default branch and branch from it. Default is false."),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -984,6 +1973,10 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			initialize, err := OptionalBoolParamWithDefault(request, "initialize", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -1005,11 +1998,32 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				}
 				defer func() { _ = resp.Body.Close() }()
 
-				fromBranch = *repository.DefaultBranch
+				fromBranch = repository.GetDefaultBranch()
 			}
 
 			// Get SHA of source branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			if isEmptyRepository(resp, err) {
+				if !initialize {
+					return emptyRepositoryError(owner, repo, true), nil
+				}
+				defaultBranch, initResp, initErr := initializeEmptyRepository(ctx, client, owner, repo)
+				if initErr != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to initialize empty repository",
+						initResp,
+						initErr,
+					), nil
+				}
+
+				// The default branch is the only branch after initialization
+				fromBranch = defaultBranch
+				ref, resp, err = client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+				if err == nil && branch == fromBranch {
+					defer func() { _ = resp.Body.Close() }()
+					return MarshalledTextResult(ctx, ref), nil
+				}
+			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get reference",
@@ -1035,19 +2049,213 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(createdRef)
+			return MarshalledTextResult(ctx, createdRef), nil
+		}
+}
+
+// RenameBranch creates a tool to rename a branch.
+func RenameBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rename_branch",
+			mcp.WithDescription(t("TOOL_RENAME_BRANCH_DESCRIPTION", "Rename a branch of a GitHub repository, e.g. to migrate the default branch from master to main. GitHub retargets the open pull requests based on the branch, updates its branch protection rules, redirects the old name in URLs and, when it is the default branch, updates the default branch. Renaming the default branch requires admin access to the repository. Use update_repository to make another existing branch the default branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RENAME_BRANCH_USER_TITLE", "Rename branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Current name of the branch"),
+			),
+			mcp.WithString("new_name",
+				mcp.Required(),
+				mcp.Description("New name of the branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := RequiredParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if newName == branch {
+				return mcp.NewToolResultError("new_name must be different from branch"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			renamed, resp, err := client.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to rename branch %s to %s", branch, newName),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// Read the default branch back, as GitHub changes it when the default branch is renamed
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"previous_name":  branch,
+				"branch":         convertToMinimalBranch(renamed),
+				"default_branch": repository.GetDefaultBranch(),
+			}), nil
+		}
+}
+
+// inlineTreeContentLimit is the size above which pushed files are uploaded as blobs rather than inlined in the
+// tree request, keeping that request small.
+const inlineTreeContentLimit = 512 * 1024
+
+// pushFile is a file to push, as parsed from the push_files arguments.
+type pushFile struct {
+	path      string
+	operation string
+	content   string
+	encoding  string
+	mode      string
+}
+
+// parsePushFiles validates the files argument of push_files, applying the default operation, encoding and mode.
+func parsePushFiles(filesObj []interface{}) ([]pushFile, error) {
+	files := make([]pushFile, 0, len(filesObj))
+	seen := make(map[string]bool, len(filesObj))
+	for _, file := range filesObj {
+		fileMap, ok := file.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("each file must be an object with path and content")
+		}
+
+		path, ok := fileMap["path"].(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("each file must have a path")
+		}
+		if seen[path] {
+			return nil, fmt.Errorf("%s is listed more than once", path)
+		}
+		seen[path] = true
+
+		operation := "add"
+		if value, ok := fileMap["operation"]; ok {
+			operation, ok = value.(string)
+			if !ok || (operation != "add" && operation != "modify" && operation != "delete") {
+				return nil, fmt.Errorf("operation of %s must be add, modify or delete", path)
+			}
+		}
+		if operation == "delete" {
+			files = append(files, pushFile{path: path, operation: operation})
+			continue
+		}
+
+		content, ok := fileMap["content"].(string)
+		if !ok {
+			return nil, fmt.Errorf("each file must have content")
+		}
+
+		encoding := "utf-8"
+		if value, ok := fileMap["encoding"]; ok {
+			encoding, ok = value.(string)
+			if !ok || (encoding != "utf-8" && encoding != "base64") {
+				return nil, fmt.Errorf("encoding of %s must be utf-8 or base64", path)
+			}
+		}
+		if encoding == "base64" {
+			if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+				return nil, fmt.Errorf("content of %s is not valid base64: %w", path, err)
+			}
+		}
+
+		mode := "100644" // Regular file mode
+		if value, ok := fileMap["mode"]; ok {
+			mode, ok = value.(string)
+			if !ok || (mode != "100644" && mode != "100755" && mode != "120000") {
+				return nil, fmt.Errorf("mode of %s must be 100644, 100755 or 120000", path)
+			}
+		}
+
+		files = append(files, pushFile{path: path, operation: operation, content: content, encoding: encoding, mode: mode})
+	}
+	return files, nil
+}
+
+// checkPushFiles checks the operations of files against the files of the base tree: add must not overwrite a file,
+// and modify and delete must name one.
+func checkPushFiles(files []pushFile, exists map[string]bool) error {
+	for _, file := range files {
+		switch {
+		case file.operation == "add" && exists[file.path]:
+			return fmt.Errorf("%s already exists, use operation modify to change it", file.path)
+		case file.operation == "modify" && !exists[file.path]:
+			return fmt.Errorf("%s does not exist, use operation add to create it", file.path)
+		case file.operation == "delete" && !exists[file.path]:
+			return fmt.Errorf("%s does not exist, so it can't be deleted", file.path)
+		}
+	}
+	return nil
+}
+
+// baseTreeFiles returns which of the paths of files are files in the tree of a commit. The paths are looked up one
+// by one when the tree is too large to be listed at once.
+func baseTreeFiles(ctx context.Context, client *github.Client, owner, repo string, commit *github.Commit, files []pushFile) (map[string]bool, *github.Response, error) {
+	exists := make(map[string]bool, len(files))
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, commit.GetTree().GetSHA(), true)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	if !tree.GetTruncated() {
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" {
+				exists[entry.GetPath()] = true
 			}
+		}
+		return exists, nil, nil
+	}
 
-			return mcp.NewToolResultText(string(r)), nil
+	for _, file := range files {
+		_, found, resp, err := getCurrentFileSHA(ctx, client, owner, repo, file.path, commit.GetSHA())
+		if err != nil {
+			return nil, resp, err
 		}
+		exists[file.path] = found
+	}
+	return exists, nil, nil
 }
 
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit. Files can be added, modified or deleted in the same commit. Binary files are supported with base64 encoded content.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -1064,25 +2272,46 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Branch to push to"),
 			),
+			mcp.WithString("from_branch",
+				mcp.Description("Branch to create branch from if it does not exist yet. The branch is created together with the commit. When omitted, branch must already exist."),
+			),
+			mcp.WithBoolean("initialize",
+				mcp.Description("If the repository is empty, create an initial commit with a README on the default branch first, and push on top of it. Default is false."),
+			),
 			mcp.WithArray("files",
 				mcp.Required(),
 				mcp.Items(
 					map[string]interface{}{
 						"type":                 "object",
 						"additionalProperties": false,
-						"required":             []string{"path", "content"},
+						"required":             []string{"path"},
 						"properties": map[string]interface{}{
 							"path": map[string]interface{}{
 								"type":        "string",
 								"description": "path to the file",
 							},
+							"operation": map[string]interface{}{
+								"type":        "string",
+								"description": "what to do with the file: add (default) creates a file that must not exist yet, modify replaces the content of an existing file, delete removes an existing file",
+								"enum":        []string{"add", "modify", "delete"},
+							},
 							"content": map[string]interface{}{
 								"type":        "string",
-								"description": "file content",
+								"description": "file content, required unless the operation is delete",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"description": "encoding of content: utf-8 for text (default), base64 for binary files such as images",
+								"enum":        []string{"utf-8", "base64"},
+							},
+							"mode": map[string]interface{}{
+								"type":        "string",
+								"description": "file mode: 100644 for a regular file (default), 100755 for an executable, 120000 for a symlink whose content is the target path",
+								"enum":        []string{"100644", "100755", "120000"},
 							},
 						},
 					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("Array of file objects to push, each object with path (string), content (string), and optionally operation, encoding and mode"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
@@ -1106,20 +2335,51 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			fromBranch, err := OptionalParam[string](request, "from_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			initialize, err := OptionalBoolParamWithDefault(request, "initialize", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// Parse files parameter - this should be an array of objects with path and content
 			filesObj, ok := request.GetArguments()["files"].([]interface{})
 			if !ok {
 				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
 			}
+			files, err := parsePushFiles(filesObj)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			// Get the reference for the branch, or for from_branch if the branch is to be created
+			var notes []string
+			ref, branchExists, resp, err := getBranchHead(ctx, client, owner, repo, branch, fromBranch)
+			if isEmptyRepository(resp, err) {
+				if !initialize {
+					return emptyRepositoryError(owner, repo, true), nil
+				}
+				defaultBranch, initResp, initErr := initializeEmptyRepository(ctx, client, owner, repo)
+				if initErr != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to initialize empty repository",
+						initResp,
+						initErr,
+					), nil
+				}
+				notes = append(notes, fmt.Sprintf("initialized empty repository with a README on '%s'", defaultBranch))
+
+				// The default branch is the only branch after initialization
+				fromBranch = defaultBranch
+				ref, branchExists, resp, err = getBranchHead(ctx, client, owner, repo, branch, fromBranch)
+			}
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get branch reference",
@@ -1127,10 +2387,9 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 					err,
 				), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get base commit",
@@ -1140,36 +2399,64 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			// Check the operations against the files of the branch, as the tree API would overwrite a file being
+			// added and silently skip a missing one being deleted
+			exists, treeResp, err := baseTreeFiles(ctx, client, owner, repo, baseCommit, files)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base tree",
+					treeResp,
+					err,
+				), nil
+			}
+			if err := checkPushFiles(files, exists); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// Create tree entries for all files
 			var entries []*github.TreeEntry
 
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+			for _, file := range files {
+				// An entry without SHA or content removes the path from the base tree.
+				if file.operation == "delete" {
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(file.path),
+						Mode: github.Ptr("100644"),
+						Type: github.Ptr("blob"),
+					})
+					continue
 				}
 
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
+				entry := &github.TreeEntry{
+					Path: github.Ptr(file.path),
+					Mode: github.Ptr(file.mode),
+					Type: github.Ptr("blob"),
 				}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
+				// Trees only take inline UTF-8 content, binary and large files are uploaded as blobs first.
+				if file.encoding == "base64" || len(file.content) > inlineTreeContentLimit {
+					blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+						Content:  github.Ptr(file.content),
+						Encoding: github.Ptr(file.encoding),
+					})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to create blob for %s", file.path),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					entry.SHA = blob.SHA
+				} else {
+					entry.Content = github.Ptr(file.content)
 				}
 
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
+				entries = append(entries, entry)
 			}
 
 			// Create a new tree with the file entries
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create tree",
@@ -1195,6 +2482,29 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			// Create the branch at the new commit, so it never exists without it
+			if !branchExists {
+				createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+					Ref:    github.Ptr("refs/heads/" + branch),
+					Object: &github.GitObject{SHA: newCommit.SHA},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create branch '%s'", branch),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				notes = append(notes, fmt.Sprintf("created branch '%s' from '%s'", branch, fromBranch))
+				result := MarshalledTextResult(ctx, createdRef)
+				for _, note := range notes {
+					result.Content = append(result.Content, mcp.NewTextContent(note))
+				}
+				return result, nil
+			}
+
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
 			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
@@ -1207,12 +2517,11 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(updatedRef)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result := MarshalledTextResult(ctx, updatedRef)
+			for _, note := range notes {
+				result.Content = append(result.Content, mcp.NewTextContent(note))
 			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return result, nil
 		}
 }
 
@@ -1276,12 +2585,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, tags), nil
 		}
 }
 
@@ -1345,7 +2649,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			}
 
 			// Then get the tag object
-			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, ref.GetObject().GetSHA())
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get tag object",
@@ -1363,12 +2667,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag object: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(tagObj)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, tagObj), nil
 		}
 }
 
@@ -1428,12 +2727,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(releases)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, releases), nil
 		}
 }
 
@@ -1483,12 +2777,7 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get latest release: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(release)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, release), nil
 		}
 }
 
@@ -1549,12 +2838,7 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get release by tag: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(release)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, release), nil
 		}
 }
 
@@ -1781,6 +3065,9 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 			minimalRepos := make([]MinimalRepository, 0, len(repos))
 			for _, starredRepo := range repos {
 				repo := starredRepo.Repository
+				if !repositoryAllowed(ctx, repo.GetOwner().GetLogin(), repo.GetName()) {
+					continue
+				}
 				minimalRepo := MinimalRepository{
 					ID:            repo.GetID(),
 					Name:          repo.GetName(),
@@ -1804,12 +3091,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 				minimalRepos = append(minimalRepos, minimalRepo)
 			}
 
-			r, err := json.Marshal(minimalRepos)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal starred repositories: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, minimalRepos), nil
 		}
 }
 
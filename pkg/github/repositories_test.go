@@ -66,6 +66,7 @@ func Test_GetFileContents(t *testing.T) {
 		expectedResult interface{}
 		expectedErrMsg string
 		expectStatus   int
+		expectedNote   string
 	}{
 		{
 			name: "successful text content fetch",
@@ -202,6 +203,238 @@ func Test_GetFileContents(t *testing.T) {
 				MIMEType: "application/pdf",
 			},
 		},
+		{
+			name: "source file served as octet-stream is returned as text",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("main.go"),
+							Path: github.Ptr("main.go"),
+							SHA:  github.Ptr("go123"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/octet-stream")
+						_, _ = w.Write([]byte("package main\n"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/main.go",
+				Text:     "package main\n",
+				MIMEType: "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name: "notebook rendered as cells",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("analysis.ipynb"),
+							Path: github.Ptr("analysis.ipynb"),
+							SHA:  github.Ptr("abc123"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, _ = w.Write([]byte(`{"cells": [{"cell_type": "code", "execution_count": 1, "source": ["print(1)"], "outputs": [{"output_type": "stream", "text": ["1\n"]}]}], "metadata": {"kernelspec": {"language": "python"}}}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"path":            "analysis.ipynb",
+				"ref":             "refs/heads/main",
+				"render_notebook": true,
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/analysis.ipynb",
+				Text:     "## Cell 1 (code, execution 1)\n\n```python\nprint(1)\n```\n\nOutput:\n```\n1\n```\n",
+				MIMEType: "text/markdown",
+			},
+		},
+		{
+			name: "markdown front matter split from body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("index.md"),
+							Path: github.Ptr("docs/index.md"),
+							SHA:  github.Ptr("abc123"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, _ = w.Write([]byte("---\ntitle: Docs\n---\n# Docs\n"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"path":               "docs/index.md",
+				"ref":                "refs/heads/main",
+				"split_front_matter": true,
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/docs/index.md",
+				Text:     "# Docs\n",
+				MIMEType: "text/markdown",
+			},
+			expectedNote: "successfully downloaded text file (SHA: abc123)\n\nFront matter:\n```yaml\ntitle: Docs\n```",
+		},
+		{
+			name: "line range of text file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("main.go"),
+						Path: github.Ptr("main.go"),
+						SHA:  github.Ptr("abc123"),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, _ = w.Write([]byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(1)\n}\n"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"path":          "main.go",
+				"ref":           "refs/heads/main",
+				"start_line":    float64(6),
+				"end_line":      float64(6),
+				"context_lines": float64(1),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/main.go",
+				Text:     "func main() {\n\tfmt.Println(1)\n}\n",
+				MIMEType: "text/plain; charset=utf-8",
+			},
+			expectedNote: "successfully downloaded lines 5-7 of 7 of text file (SHA: abc123)",
+		},
+		{
+			name: "line range of binary file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("test.png"),
+						Path: github.Ptr("test.png"),
+						SHA:  github.Ptr("def456"),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "image/png")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "test.png",
+				"ref":        "refs/heads/main",
+				"start_line": float64(1),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewToolResultError("start_line and end_line only apply to text files, test.png is image/png").Content[0],
+		},
+		{
+			name:         "start line after end line",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(10),
+				"end_line":   float64(2),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewToolResultError("start_line must not be after end_line").Content[0],
+		},
 		{
 			name: "successful directory content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -304,6 +537,9 @@ func Test_GetFileContents(t *testing.T) {
 			case mcp.TextResourceContents:
 				textResource := getTextResourceResult(t, result)
 				assert.Equal(t, expected, textResource)
+				if tc.expectedNote != "" {
+					assert.Equal(t, tc.expectedNote, result.Content[0].(mcp.TextContent).Text)
+				}
 			case mcp.BlobResourceContents:
 				blobResource := getBlobResourceResult(t, result)
 				assert.Equal(t, expected, blobResource)
@@ -429,38 +665,37 @@ func Test_ForkRepository(t *testing.T) {
 	}
 }
 
-func Test_CreateBranch(t *testing.T) {
+func Test_ListForks(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListForks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_branch", tool.Name)
+	assert.Equal(t, "list_forks", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
-
-	// Setup mock repository for default branch test
-	mockRepo := &github.Repository{
-		DefaultBranch: github.Ptr("main"),
-	}
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	// Setup mock reference for from_branch tests
-	mockSourceRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123def456"),
+	mockForks := []*github.Repository{
+		{
+			ID:              github.Ptr(int64(1)),
+			Name:            github.Ptr("repo"),
+			FullName:        github.Ptr("alice/repo"),
+			HTMLURL:         github.Ptr("https://github.com/alice/repo"),
+			StargazersCount: github.Ptr(42),
+			Fork:            github.Ptr(true),
+			PushedAt:        &github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
 		},
-	}
-
-	// Setup mock created reference
-	mockCreatedRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/new-feature"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123def456"),
+		{
+			ID:       github.Ptr(int64(2)),
+			Name:     github.Ptr("repo"),
+			FullName: github.Ptr("bob/repo"),
+			HTMLURL:  github.Ptr("https://github.com/bob/repo"),
+			Fork:     github.Ptr(true),
 		},
 	}
 
@@ -469,87 +704,235 @@ func Test_CreateBranch(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedRef    *github.Reference
 		expectedErrMsg string
+		expectedForks  []MinimalRepository
 	}{
 		{
-			name: "successful branch creation with from_branch",
+			name: "list forks by stargazers",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockSourceRef,
-				),
-				mock.WithRequestMatch(
-					mock.PostReposGitRefsByOwnerByRepo,
-					mockCreatedRef,
+				mock.WithRequestMatchHandler(
+					mock.GetReposForksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sort":     "stargazers",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockForks),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":       "owner",
-				"repo":        "repo",
-				"branch":      "new-feature",
-				"from_branch": "main",
+				"owner":   "owner",
+				"repo":    "repo",
+				"sort":    "stargazers",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedForks: []MinimalRepository{
+				{ID: 1, Name: "repo", FullName: "alice/repo", HTMLURL: "https://github.com/alice/repo", Stars: 42, Fork: true, PushedAt: "2024-05-01T12:00:00Z"},
+				{ID: 2, Name: "repo", FullName: "bob/repo", HTMLURL: "https://github.com/bob/repo", Fork: true},
 			},
-			expectError: false,
-			expectedRef: mockCreatedRef,
 		},
 		{
-			name: "successful branch creation with default branch",
+			name: "no forks",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
-					mock.GetReposByOwnerByRepo,
-					mockRepo,
-				),
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockSourceRef,
-				),
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitRefsByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"ref": "refs/heads/new-feature",
-						"sha": "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockCreatedRef),
-					),
+					mock.GetReposForksByOwnerByRepo,
+					[]*github.Repository{},
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "new-feature",
+				"owner": "owner",
+				"repo":  "repo",
 			},
-			expectError: false,
-			expectedRef: mockCreatedRef,
+			expectedForks: []MinimalRepository{},
 		},
 		{
-			name: "fail to get repository",
+			name: "repository not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposByOwnerByRepo,
+					mock.GetReposForksByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Repository not found"}`))
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "nonexistent-repo",
-				"branch": "new-feature",
+				"owner": "owner",
+				"repo":  "missing",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get repository",
+			expectedErrMsg: "failed to list forks",
 		},
-		{
-			name: "fail to get reference",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
-					}),
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListForks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var forks []MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &forks))
+			assert.Equal(t, tc.expectedForks, forks)
+		})
+	}
+}
+
+func Test_CreateBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "initialize")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	// Setup mock repository for default branch test
+	mockRepo := &github.Repository{
+		DefaultBranch: github.Ptr("main"),
+	}
+
+	// Setup mock reference for from_branch tests
+	mockSourceRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123def456"),
+		},
+	}
+
+	// Setup mock created reference
+	mockCreatedRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/new-feature"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123def456"),
+		},
+	}
+
+	// An empty repository has no refs until its first commit is created
+	emptyRepositoryRefs := func() http.HandlerFunc {
+		initialized := false
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				initialized = true
+				mockResponse(t, http.StatusCreated, &github.RepositoryContentResponse{})(w, r)
+				return
+			}
+			if !initialized {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message": "Git Repository is empty."}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, mockSourceRef)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRef    *github.Reference
+		expectedErrMsg string
+	}{
+		{
+			name: "successful branch creation with from_branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockSourceRef,
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockCreatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "new-feature",
+				"from_branch": "main",
+			},
+			expectError: false,
+			expectedRef: mockCreatedRef,
+		},
+		{
+			name: "successful branch creation with default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockSourceRef,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/new-feature",
+						"sha": "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockCreatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "new-feature",
+			},
+			expectError: false,
+			expectedRef: mockCreatedRef,
+		},
+		{
+			name: "fail to get repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Repository not found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "nonexistent-repo",
+				"branch": "new-feature",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+		{
+			name: "fail to get reference",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -561,6 +944,68 @@ func Test_CreateBranch(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to get reference",
 		},
+		{
+			name: "empty repository without initialize",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					emptyRepositoryRefs(),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "new-feature",
+			},
+			expectError:    true,
+			expectedErrMsg: "repository 'owner/repo' is empty",
+		},
+		{
+			name: "empty repository with initialize",
+			mockedClient: func() *http.Client {
+				refs := emptyRepositoryRefs()
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatch(
+						mock.GetReposByOwnerByRepo,
+						mockRepo,
+						mockRepo,
+					),
+					mock.WithRequestMatchHandler(
+						mock.GetReposGitRefByOwnerByRepoByRef,
+						refs,
+					),
+					mock.WithRequestMatchHandler(
+						mock.PutReposContentsByOwnerByRepoByPath,
+						expectRequestBody(t, map[string]interface{}{
+							"message": "Initial commit",
+							"content": "IyByZXBvCg==",
+							"branch":  "main",
+						}).andThen(refs),
+					),
+					mock.WithRequestMatchHandler(
+						mock.PostReposGitRefsByOwnerByRepo,
+						expectRequestBody(t, map[string]interface{}{
+							"ref": "refs/heads/new-feature",
+							"sha": "abc123def456",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, mockCreatedRef),
+						),
+					),
+				)
+			}(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "new-feature",
+				"initialize": true,
+			},
+			expectError: false,
+			expectedRef: mockCreatedRef,
+		},
 		{
 			name: "fail to create branch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -624,6 +1069,112 @@ func Test_CreateBranch(t *testing.T) {
 	}
 }
 
+func Test_RenameBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RenameBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "rename_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "new_name"})
+
+	renamedBranch := &github.Branch{
+		Name:      github.Ptr("main"),
+		Commit:    &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+		Protected: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name                  string
+		mockedClient          *http.Client
+		requestArgs           map[string]any
+		expectError           bool
+		expectedErrMsg        string
+		expectedDefaultBranch string
+	}{
+		{
+			name: "rename default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{"new_name": "main"}).andThen(
+						mockResponse(t, http.StatusCreated, renamedBranch),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branch":   "master",
+				"new_name": "main",
+			},
+			expectedDefaultBranch: "main",
+		},
+		{
+			name:         "same name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branch":   "main",
+				"new_name": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "new_name must be different from branch",
+		},
+		{
+			name: "rename fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"branch":   "master",
+				"new_name": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to rename branch master to main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RenameBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				PreviousName  string        `json:"previous_name"`
+				Branch        MinimalBranch `json:"branch"`
+				DefaultBranch string        `json:"default_branch"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "master", response.PreviousName)
+			assert.Equal(t, MinimalBranch{Name: "main", SHA: "abc123", Protected: true}, response.Branch)
+			assert.Equal(t, tc.expectedDefaultBranch, response.DefaultBranch)
+		})
+	}
+}
+
 func Test_GetCommit(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -635,6 +1186,7 @@ func Test_GetCommit(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "include_verification")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
 
 	mockCommit := &github.RepositoryCommit{
@@ -646,10 +1198,18 @@ func Test_GetCommit(t *testing.T) {
 				Email: github.Ptr("test@example.com"),
 				Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
 			},
+			Verification: &github.SignatureVerification{
+				Verified:  github.Ptr(true),
+				Reason:    github.Ptr("valid"),
+				Signature: github.Ptr("-----BEGIN PGP SIGNATURE-----\n...\n-----END PGP SIGNATURE-----"),
+			},
 		},
 		Author: &github.User{
 			Login: github.Ptr("testuser"),
 		},
+		Committer: &github.User{
+			Login: github.Ptr("web-flow"),
+		},
 		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
 		Stats: &github.CommitStats{
 			Additions: github.Ptr(10),
@@ -669,12 +1229,13 @@ func Test_GetCommit(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedCommit *github.RepositoryCommit
-		expectedErrMsg string
+		name                 string
+		mockedClient         *http.Client
+		requestArgs          map[string]interface{}
+		expectError          bool
+		expectedCommit       *github.RepositoryCommit
+		expectedVerification *MinimalCommitVerification
+		expectedErrMsg       string
 	}{
 		{
 			name: "successful commit fetch",
@@ -693,23 +1254,46 @@ func Test_GetCommit(t *testing.T) {
 			expectedCommit: mockCommit,
 		},
 		{
-			name: "commit fetch fails",
+			name: "successful commit fetch with verification",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposCommitsByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mockResponse(t, http.StatusOK, mockCommit),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-				"sha":   "nonexistent-sha",
+				"owner":                "owner",
+				"repo":                 "repo",
+				"sha":                  "abc123def456",
+				"include_verification": true,
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get commit",
+			expectError:    false,
+			expectedCommit: mockCommit,
+			expectedVerification: &MinimalCommitVerification{
+				Verified:      true,
+				Reason:        "valid",
+				SignatureType: "gpg",
+				Signer:        "web-flow",
+			},
+		},
+		{
+			name: "commit fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "nonexistent-sha",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get commit",
 		},
 	}
 
@@ -749,6 +1333,366 @@ func Test_GetCommit(t *testing.T) {
 			assert.Equal(t, *tc.expectedCommit.Commit.Message, *returnedCommit.Commit.Message)
 			assert.Equal(t, *tc.expectedCommit.Author.Login, *returnedCommit.Author.Login)
 			assert.Equal(t, *tc.expectedCommit.HTMLURL, *returnedCommit.HTMLURL)
+
+			var minimalCommit MinimalCommit
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &minimalCommit))
+			assert.Equal(t, tc.expectedVerification, minimalCommit.Verification)
+		})
+	}
+}
+
+func Test_GetDiffStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDiffStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_diff_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	files := []*github.CommitFile{
+		{
+			Filename:  github.Ptr("main.go"),
+			Status:    github.Ptr("modified"),
+			Additions: github.Ptr(10),
+			Deletions: github.Ptr(2),
+			Changes:   github.Ptr(12),
+			Patch:     github.Ptr("@@ -1,2 +1,10 @@"),
+		},
+		{
+			Filename:  github.Ptr("README.md"),
+			Status:    github.Ptr("added"),
+			Additions: github.Ptr(5),
+			Changes:   github.Ptr(5),
+			Patch:     github.Ptr("@@ -0,0 +1,5 @@"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedStats  DiffStats
+	}{
+		{
+			name: "commit stats",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					&github.RepositoryCommit{SHA: github.Ptr("abc123"), Files: files},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			expectError: false,
+			expectedStats: DiffStats{
+				Files: []MinimalCommitFile{
+					{Filename: "main.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+					{Filename: "README.md", Status: "added", Additions: 5, Changes: 5},
+				},
+				TotalFiles: 2,
+				Additions:  15,
+				Deletions:  2,
+				Changes:    17,
+			},
+		},
+		{
+			name: "comparison stats",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/main...feature").andThen(
+						mockResponse(t, http.StatusOK, &github.CommitsComparison{
+							Status:       github.Ptr("ahead"),
+							AheadBy:      github.Ptr(3),
+							TotalCommits: github.Ptr(3),
+							Files:        files[:1],
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "feature",
+			},
+			expectError: false,
+			expectedStats: DiffStats{
+				Files: []MinimalCommitFile{
+					{Filename: "main.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+				},
+				TotalFiles:   1,
+				Additions:    10,
+				Deletions:    2,
+				Changes:      12,
+				Status:       "ahead",
+				AheadBy:      3,
+				TotalCommits: 3,
+			},
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare main...missing",
+		},
+		{
+			name:         "missing head",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "either sha, or both base and head must be provided",
+		},
+		{
+			name:         "sha and refs together",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"base":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "provide either sha, or base and head, not both",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDiffStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, "@@")
+
+			var stats DiffStats
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &stats))
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "include_diff")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	comparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(1),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/main...feature"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:     github.Ptr("abc123"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+				Commit:  &github.Commit{Message: github.Ptr("Add feature")},
+				Author:  &github.User{Login: github.Ptr("alice")},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Fix tests")},
+			},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12), Patch: github.Ptr("@@ -1,2 +1,10 @@")},
+			{Filename: github.Ptr("main_test.go"), Status: github.Ptr("added"), Additions: github.Ptr(5), Changes: github.Ptr(5)},
+		},
+	}
+	expectedCommits := []MinimalCommit{
+		{
+			SHA:     "abc123",
+			HTMLURL: "https://github.com/owner/repo/commit/abc123",
+			Commit:  &MinimalCommitInfo{Message: "Add feature"},
+			Author:  &MinimalUser{Login: "alice"},
+		},
+		{
+			SHA:    "def456",
+			Commit: &MinimalCommitInfo{Message: "Fix tests"},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedComparison RefComparison
+	}{
+		{
+			name: "compare with files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/main...feature").andThen(
+						mockResponse(t, http.StatusOK, comparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "feature",
+			},
+			expectedComparison: RefComparison{
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     1,
+				TotalCommits: 2,
+				MergeBaseSHA: "base123",
+				HTMLURL:      "https://github.com/owner/repo/compare/main...feature",
+				Commits:      expectedCommits,
+				Stats:        &MinimalCommitStats{Additions: 15, Deletions: 2, Total: 17},
+				Files: []MinimalCommitFile{
+					{Filename: "main.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+					{Filename: "main_test.go", Status: "added", Additions: 5, Changes: 5},
+				},
+			},
+		},
+		{
+			name: "compare without files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "2"}).andThen(
+						mockResponse(t, http.StatusOK, comparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"base":         "main",
+				"head":         "feature",
+				"include_diff": false,
+				"page":         float64(2),
+				"perPage":      float64(2),
+			},
+			expectedComparison: RefComparison{
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     1,
+				TotalCommits: 2,
+				MergeBaseSHA: "base123",
+				HTMLURL:      "https://github.com/owner/repo/compare/main...feature",
+				Commits:      expectedCommits,
+			},
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare main...missing",
+		},
+		{
+			name:         "missing head",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: head",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var comparison RefComparison
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comparison))
+			assert.Equal(t, tc.expectedComparison, comparison)
 		})
 	}
 }
@@ -978,42 +1922,230 @@ func Test_ListCommits(t *testing.T) {
 				// Files and stats are never included in list_commits
 				assert.Nil(t, commit.Files)
 				assert.Nil(t, commit.Stats)
+				assert.Nil(t, commit.Verification)
 			}
 		})
 	}
 }
 
-func Test_CreateOrUpdateFile(t *testing.T) {
+func Test_ListUnverifiedCommits(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListUnverifiedCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.Equal(t, "list_unverified_commits", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "content")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.Contains(t, tool.InputSchema.Properties, "max_pages")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	// Setup mock file content response
-	mockFileResponse := &github.RepositoryContentResponse{
-		Content: &github.RepositoryContent{
-			Name:        github.Ptr("example.md"),
-			Path:        github.Ptr("docs/example.md"),
-			SHA:         github.Ptr("abc123def456"),
-			Size:        github.Ptr(42),
-			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
-			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA: github.Ptr("aaa111"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Signed commit"),
+				Verification: &github.SignatureVerification{
+					Verified:  github.Ptr(true),
+					Reason:    github.Ptr("valid"),
+					Signature: github.Ptr("-----BEGIN SSH SIGNATURE-----\n...\n-----END SSH SIGNATURE-----"),
+				},
+			},
+			Committer: &github.User{Login: github.Ptr("signer")},
 		},
-		Commit: github.Commit{
-			SHA:     github.Ptr("def456abc789"),
-			Message: github.Ptr("Add example file"),
-			Author: &github.CommitAuthor{
+		{
+			SHA: github.Ptr("bbb222"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Unsigned commit"),
+				Verification: &github.SignatureVerification{
+					Verified: github.Ptr(false),
+					Reason:   github.Ptr("unsigned"),
+				},
+			},
+		},
+		{
+			SHA: github.Ptr("ccc333"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Commit signed with an unknown key"),
+				Verification: &github.SignatureVerification{
+					Verified:  github.Ptr(false),
+					Reason:    github.Ptr("unknown_key"),
+					Signature: github.Ptr("-----BEGIN PGP SIGNATURE-----\n...\n-----END PGP SIGNATURE-----"),
+				},
+			},
+			Committer: &github.User{Login: github.Ptr("someone")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult func(t *testing.T, response map[string]any)
+	}{
+		{
+			name: "lists unverified commits in window",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sha":      "main",
+						"since":    "2024-01-01T00:00:00Z",
+						"until":    "2024-02-01T00:00:00Z",
+						"page":     "1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"since":  "2024-01-01",
+				"until":  "2024-02-01T00:00:00Z",
+			},
+			expectedResult: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, "main", response["branch"])
+				assert.Equal(t, "2024-01-01T00:00:00Z", response["since"])
+				assert.Equal(t, "2024-02-01T00:00:00Z", response["until"])
+				assert.Equal(t, float64(3), response["scannedCommits"])
+				assert.Equal(t, float64(2), response["unverifiedCount"])
+				assert.Equal(t, map[string]any{"unsigned": float64(1), "unknown_key": float64(1)}, response["byReason"])
+				assert.Equal(t, false, response["truncated"])
+
+				commits, ok := response["commits"].([]any)
+				require.True(t, ok)
+				require.Len(t, commits, 2)
+				unsigned := commits[0].(map[string]any)
+				assert.Equal(t, "bbb222", unsigned["sha"])
+				assert.Equal(t, map[string]any{"verified": false, "reason": "unsigned"}, unsigned["verification"])
+				unknownKey := commits[1].(map[string]any)
+				assert.Equal(t, map[string]any{"verified": false, "reason": "unknown_key", "signature_type": "gpg"}, unknownKey["verification"])
+			},
+		},
+		{
+			name: "stops after max pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits?page=2>; rel="next"`)
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(mockCommits)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"max_pages": float64(1),
+			},
+			expectedResult: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, float64(3), response["scannedCommits"])
+				assert.Equal(t, true, response["truncated"])
+				assert.NotContains(t, response, "branch")
+			},
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "last week",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to parse since",
+		},
+		{
+			name: "commits fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUnverifiedCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			tc.expectedResult(t, response)
+		})
+	}
+}
+
+func Test_CreateOrUpdateFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "force_latest")
+	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "include_diff")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+
+	// Setup mock file content response
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Name:        github.Ptr("example.md"),
+			Path:        github.Ptr("docs/example.md"),
+			SHA:         github.Ptr("abc123def456"),
+			Size:        github.Ptr(42),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
+			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
+		},
+		Commit: github.Commit{
+			SHA:     github.Ptr("def456abc789"),
+			Message: github.Ptr("Add example file"),
+			Author: &github.CommitAuthor{
 				Name:  github.Ptr("Test User"),
 				Email: github.Ptr("test@example.com"),
 				Date:  &github.Timestamp{Time: time.Now()},
@@ -1022,87 +2154,747 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		},
 	}
 
+	// The file as it currently is at the head of the branch
+	mockCurrentFile := &github.RepositoryContent{
+		Type: github.Ptr("file"),
+		Name: github.Ptr("example.md"),
+		Path: github.Ptr("docs/example.md"),
+		SHA:  github.Ptr("fff999eee888"),
+	}
+	fileNotFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+	var deletedRefs []string
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedContent *github.RepositoryContentResponse
+		expectedNote    string
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful file creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(fileNotFound),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example\n\nThis is an example file.",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful file update with SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Updated Example\n\nThis file has been updated.",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "file creation conflicts with existing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockCurrentFile,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "file 'docs/example.md' already exists on branch 'main' with SHA fff999eee888. To update it, call again with sha set to fff999eee888",
+		},
+		{
+			name: "force latest replaces stale SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockCurrentFile,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBFeGFtcGxl",
+						"branch":  "main",
+						"sha":     "fff999eee888",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"path":         "docs/example.md",
+				"content":      "# Example",
+				"message":      "Update example file",
+				"branch":       "main",
+				"sha":          "abc123def456",
+				"force_latest": true,
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+			expectedNote:    "sha abc123def456 was stale, the file was updated from its current SHA fff999eee888",
+		},
+		{
+			name: "creates missing branch from from_branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/git/ref/heads/docs-update" {
+							fileNotFound(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						})(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/docs-update",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/heads/docs-update"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					fileNotFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxl",
+						"branch":  "docs-update",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "docs/example.md",
+				"content":     "# Example",
+				"message":     "Add example file",
+				"branch":      "docs-update",
+				"from_branch": "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+			expectedNote:    "created branch 'docs-update' from 'main'",
+		},
+		{
+			name: "force latest creates missing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					fileNotFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxl",
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"path":         "docs/example.md",
+				"content":      "# Example",
+				"message":      "Add example file",
+				"branch":       "main",
+				"sha":          "abc123def456",
+				"force_latest": true,
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "update with diff of the replaced blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/abc123def456").andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							_, _ = w.Write([]byte("# Example\n\nOld text.\n"))
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, mockFileResponse),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"path":         "docs/example.md",
+				"content":      "# Example\n\nNew text.\n",
+				"message":      "Update example file",
+				"branch":       "main",
+				"sha":          "abc123def456",
+				"include_diff": true,
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+			expectedNote:    "--- a/docs/example.md\n+++ b/docs/example.md\n@@ -1,3 +1,3 @@\n # Example\n \n-Old text.\n+New text.\n",
+		},
+		{
+			name: "creation with diff from nothing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					fileNotFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusCreated, mockFileResponse),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"path":         "docs/example.md",
+				"content":      "# Example",
+				"message":      "Add example file",
+				"branch":       "main",
+				"include_diff": true,
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+			expectedNote:    "--- /dev/null\n+++ b/docs/example.md\n@@ -0,0 +1,1 @@\n+# Example\n",
+		},
+		{
+			name: "file creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					fileNotFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "#Invalid Content",
+				"message": "Invalid request",
+				"branch":  "nonexistent-branch",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create/update file",
+		},
+		{
+			name: "deletes the created branch when the write fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/git/ref/heads/docs-update" {
+							fileNotFound(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						})(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitRefsByOwnerByRepo,
+					&github.Reference{Ref: github.Ptr("refs/heads/docs-update")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					fileNotFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						deletedRefs = append(deletedRefs, r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "docs/example.md",
+				"content":     "# Example",
+				"message":     "Add example file",
+				"branch":      "docs-update",
+				"from_branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create/update file",
+		},
+		{
+			name: "branch created meanwhile",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/git/ref/heads/docs-update" {
+							fileNotFound(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						})(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reference already exists"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "docs/example.md",
+				"content":     "# Example",
+				"message":     "Add example file",
+				"branch":      "docs-update",
+				"from_branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create branch 'docs-update'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// A note follows the file content when the stale SHA was replaced, the branch was created or a diff was asked for
+			if tc.expectedNote != "" {
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, tc.expectedNote, result.Content[1].(mcp.TextContent).Text)
+				result.Content = result.Content[:1]
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedContent github.RepositoryContentResponse
+			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+			require.NoError(t, err)
+
+			// Verify content
+			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
+			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
+			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
+
+			// Verify commit
+			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
+			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+		})
+	}
+
+	// Only the branch created for the failed write is deleted
+	assert.Equal(t, []string{"/repos/owner/repo/git/refs/heads/docs-update"}, deletedRefs)
+}
+
+func Test_CreateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "organization")
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	// Setup mock repository response
+	mockRepo := &github.Repository{
+		Name:        github.Ptr("test-repo"),
+		Description: github.Ptr("Test repository"),
+		Private:     github.Ptr(true),
+		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
+		CreatedAt:   &github.Timestamp{Time: time.Now()},
+		Owner: &github.User{
+			Login: github.Ptr("testuser"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repository creation with all parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"description": "Test repository",
+						"private":     true,
+						"auto_init":   true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":        "test-repo",
+				"description": "Test repository",
+				"private":     true,
+				"autoInit":    true,
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "successful repository creation in organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/orgs/testorg/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"description": "Test repository",
+						"private":     false,
+						"auto_init":   true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":         "test-repo",
+				"description":  "Test repository",
+				"organization": "testorg",
+				"private":      false,
+				"autoInit":     true,
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "successful repository creation with minimal parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"auto_init":   false,
+						"description": "",
+						"private":     false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "test-repo",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "repository creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "invalid-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the minimal result
+			var returnedRepo MinimalResponse
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
+			assert.NoError(t, err)
+
+			// Verify repository details
+			assert.Equal(t, tc.expectedRepo.GetHTMLURL(), returnedRepo.URL)
+		})
+	}
+}
+
+func Test_UpdateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "default_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_squash_merge")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_branch_on_merge")
+	assert.Contains(t, tool.InputSchema.Properties, "has_wiki")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		FullName:            github.Ptr("owner/repo"),
+		HTMLURL:             github.Ptr("https://github.com/owner/repo"),
+		Description:         github.Ptr("Updated description"),
+		Visibility:          github.Ptr("private"),
+		DefaultBranch:       github.Ptr("main"),
+		AllowMergeCommit:    github.Ptr(false),
+		AllowSquashMerge:    github.Ptr(true),
+		AllowRebaseMerge:    github.Ptr(true),
+		DeleteBranchOnMerge: github.Ptr(true),
+		HasIssues:           github.Ptr(true),
+		HasWiki:             github.Ptr(false),
+		HasProjects:         github.Ptr(false),
+	}
+
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedContent *github.RepositoryContentResponse
-		expectedErrMsg  string
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedSettings MinimalRepositorySettings
+		expectedErrMsg   string
 	}{
 		{
-			name: "successful file creation",
+			name: "update only the given settings",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
+					mock.PatchReposByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"message": "Add example file",
-						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
-						"branch":  "main",
+						"description":            "Updated description",
+						"visibility":             "private",
+						"allow_merge_commit":     false,
+						"delete_branch_on_merge": true,
+						"has_wiki":               false,
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
+						mockResponse(t, http.StatusOK, mockRepo),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Example\n\nThis is an example file.",
-				"message": "Add example file",
-				"branch":  "main",
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"description":            "Updated description",
+				"visibility":             "private",
+				"allow_merge_commit":     false,
+				"delete_branch_on_merge": true,
+				"has_wiki":               false,
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:      false,
+			expectedSettings: convertToMinimalRepositorySettings(mockRepo),
 		},
 		{
-			name: "successful file update with SHA",
+			name: "change default branch",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
+					mock.PatchReposByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"message": "Update example file",
-						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
-						"branch":  "main",
-						"sha":     "abc123def456",
+						"default_branch": "main",
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
+						mockResponse(t, http.StatusOK, mockRepo),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Updated Example\n\nThis file has been updated.",
-				"message": "Update example file",
-				"branch":  "main",
-				"sha":     "abc123def456",
+				"owner":          "owner",
+				"repo":           "repo",
+				"default_branch": "main",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:      false,
+			expectedSettings: convertToMinimalRepositorySettings(mockRepo),
 		},
 		{
-			name: "file creation fails",
+			name:         "no settings given",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "no repository settings to update were given",
+		},
+		{
+			name:         "empty default branch",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"default_branch": "",
+			},
+			expectError:    true,
+			expectedErrMsg: "default_branch can't be empty",
+		},
+		{
+			name: "update fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
+					mock.PatchReposByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "#Invalid Content",
-				"message": "Invalid request",
-				"branch":  "nonexistent-branch",
+				"owner":              "owner",
+				"repo":               "repo",
+				"allow_rebase_merge": false,
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create/update file",
+			expectedErrMsg: "failed to update repository owner/repo",
 		},
 	}
 
@@ -1110,7 +2902,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1119,62 +2911,52 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			result, err := handler(context.Background(), request)
 
 			// Verify results
+			require.NoError(t, err)
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedContent github.RepositoryContentResponse
-			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+			var returnedSettings MinimalRepositorySettings
+			err = json.Unmarshal([]byte(textContent.Text), &returnedSettings)
 			require.NoError(t, err)
-
-			// Verify content
-			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
-			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
-			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
-
-			// Verify commit
-			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
-			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+			assert.Equal(t, tc.expectedSettings, returnedSettings)
 		})
 	}
 }
 
-func Test_CreateRepository(t *testing.T) {
+func Test_RepositoryAdmin(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := RepositoryAdmin(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_repository", tool.Name)
+	assert.Equal(t, "repository_admin", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "name")
-	assert.Contains(t, tool.InputSchema.Properties, "description")
-	assert.Contains(t, tool.InputSchema.Properties, "organization")
-	assert.Contains(t, tool.InputSchema.Properties, "private")
-	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
-
-	// Setup mock repository response
-	mockRepo := &github.Repository{
-		Name:        github.Ptr("test-repo"),
-		Description: github.Ptr("Test repository"),
-		Private:     github.Ptr(true),
-		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
-		CreatedAt:   &github.Timestamp{Time: time.Now()},
-		Owner: &github.User{
-			Login: github.Ptr("testuser"),
-		},
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, tool.InputSchema.Properties, "method")
+	assert.Contains(t, tool.InputSchema.Properties, "new_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.Contains(t, tool.InputSchema.Properties, "confirm")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"method", "owner", "repo"})
+
+	archivedRepo := &github.Repository{
+		Name:     github.Ptr("repo"),
+		FullName: github.Ptr("owner/repo"),
+		Archived: github.Ptr(true),
+	}
+	renamedRepo := &github.Repository{
+		Name:     github.Ptr("new-repo"),
+		FullName: github.Ptr("owner/new-repo"),
+	}
+	transferredRepo := &github.Repository{
+		Name:     github.Ptr("repo"),
+		FullName: github.Ptr("new-org/repo"),
 	}
 
 	tests := []struct {
@@ -1183,106 +2965,202 @@ func Test_CreateRepository(t *testing.T) {
 		requestArgs    map[string]interface{}
 		expectError    bool
 		expectedRepo   *github.Repository
+		expectedText   string
 		expectedErrMsg string
 	}{
 		{
-			name: "successful repository creation with all parameters",
+			name: "archive repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.PatchReposByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"description": "Test repository",
-						"private":     true,
-						"auto_init":   true,
+						"archived": true,
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, archivedRepo),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name":        "test-repo",
-				"description": "Test repository",
-				"private":     true,
-				"autoInit":    true,
+				"method": "archive",
+				"owner":  "owner",
+				"repo":   "repo",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectedRepo: archivedRepo,
 		},
 		{
-			name: "successful repository creation in organization",
+			name: "unarchive repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/orgs/testorg/repos",
-						Method:  "POST",
-					},
+					mock.PatchReposByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"description": "Test repository",
-						"private":     false,
-						"auto_init":   true,
+						"archived": false,
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo")}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name":         "test-repo",
-				"description":  "Test repository",
-				"organization": "testorg",
-				"private":      false,
-				"autoInit":     true,
+				"method": "unarchive",
+				"owner":  "owner",
+				"repo":   "repo",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectedRepo: &github.Repository{FullName: github.Ptr("owner/repo")},
 		},
 		{
-			name: "successful repository creation with minimal parameters",
+			name: "rename repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.PatchReposByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"auto_init":   false,
-						"description": "",
-						"private":     false,
+						"name": "new-repo",
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, renamedRepo),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "test-repo",
+				"method":   "rename",
+				"owner":    "owner",
+				"repo":     "repo",
+				"new_name": "new-repo",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectedRepo: renamedRepo,
 		},
 		{
-			name: "repository creation fails",
+			name:         "rename without new name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "rename",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "new_name is required for rename",
+		},
+		{
+			name: "transfer repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"new_owner": "new-org",
+					}).andThen(
+						mockResponse(t, http.StatusOK, transferredRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":    "transfer",
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectedRepo: transferredRepo,
+		},
+		{
+			name: "transfer in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"new_owner": "someone",
+						"new_name":  "their-repo",
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, transferredRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":    "transfer",
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "someone",
+				"new_name":  "their-repo",
+			},
+			expectedText: "Transfer of owner/repo to someone/their-repo is in progress",
+		},
+		{
+			name:         "transfer without new owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "transfer",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "new_owner is required for transfer",
+		},
+		{
+			name: "delete repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+						w.WriteHeader(http.StatusNoContent)
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "invalid-repo",
+				"method":  "delete",
+				"owner":   "owner",
+				"repo":    "repo",
+				"confirm": "owner/repo",
+			},
+			expectedText: "Repository owner/repo deleted",
+		},
+		{
+			name:         "delete without confirmation",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "delete",
+				"owner":  "owner",
+				"repo":   "repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create repository",
+			expectedErrMsg: `set confirm to "owner/repo" to delete it`,
+		},
+		{
+			name:         "delete with confirmation of another repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method":  "delete",
+				"owner":   "owner",
+				"repo":    "repo",
+				"confirm": "owner/other-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: `set confirm to "owner/repo" to delete it`,
+		},
+		{
+			name: "delete fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "delete",
+				"owner":   "owner",
+				"repo":    "repo",
+				"confirm": "owner/repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete repository owner/repo",
+		},
+		{
+			name:         "unknown method",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "fork",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown method: fork",
 		},
 	}
 
@@ -1290,7 +3168,7 @@ func Test_CreateRepository(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := RepositoryAdmin(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1299,27 +3177,25 @@ func Test_CreateRepository(t *testing.T) {
 			result, err := handler(context.Background(), request)
 
 			// Verify results
+			require.NoError(t, err)
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the minimal result
-			var returnedRepo MinimalResponse
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
-			assert.NoError(t, err)
-
-			// Verify repository details
-			assert.Equal(t, tc.expectedRepo.GetHTMLURL(), returnedRepo.URL)
+			if tc.expectedText != "" {
+				assert.Contains(t, textContent.Text, tc.expectedText)
+				return
+			}
+
+			var returnedRepo MinimalRepository
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
+			require.NoError(t, err)
+			assert.Equal(t, convertToMinimalRepository(tc.expectedRepo), returnedRepo)
 		})
 	}
 }
@@ -1337,6 +3213,8 @@ func Test_PushFiles(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "files")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "initialize")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
 
 	// Setup mock objects
@@ -1355,6 +3233,17 @@ func Test_PushFiles(t *testing.T) {
 		},
 	}
 
+	// The tree of the branch before the push
+	mockBaseTree := &github.Tree{
+		SHA: github.Ptr("def456"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("docs"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("pkg"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("pkg/old.go"), Type: github.Ptr("blob")},
+		},
+	}
+
 	mockTree := &github.Tree{
 		SHA: github.Ptr("ghi789"),
 	}
@@ -1380,6 +3269,7 @@ func Test_PushFiles(t *testing.T) {
 		requestArgs    map[string]interface{}
 		expectError    bool
 		expectedRef    *github.Reference
+		expectedNote   string
 		expectedErrMsg string
 	}{
 		{
@@ -1395,6 +3285,11 @@ func Test_PushFiles(t *testing.T) {
 					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
 					mockCommit,
 				),
+				// Get base tree
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockBaseTree,
+				),
 				// Create tree
 				mock.WithRequestMatchHandler(
 					mock.PostReposGitTreesByOwnerByRepo,
@@ -1446,8 +3341,9 @@ func Test_PushFiles(t *testing.T) {
 				"branch": "main",
 				"files": []interface{}{
 					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# Updated README\n\nThis is an updated README file.",
+						"path":      "README.md",
+						"operation": "modify",
+						"content":   "# Updated README\n\nThis is an updated README file.",
 					},
 					map[string]interface{}{
 						"path":    "docs/example.md",
@@ -1459,6 +3355,300 @@ func Test_PushFiles(t *testing.T) {
 			expectError: false,
 			expectedRef: mockUpdatedRef,
 		},
+		{
+			name: "successful push of binary and executable files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Get base tree
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockBaseTree,
+				),
+				// Binary content is uploaded as a blob
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "assets/logo.png",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob123",
+							},
+							map[string]interface{}{
+								"path":    "scripts/build.sh",
+								"mode":    "100755",
+								"type":    "blob",
+								"content": "#!/bin/sh\nmake\n",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "assets/logo.png",
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					},
+					map[string]interface{}{
+						"path":    "scripts/build.sh",
+						"content": "#!/bin/sh\nmake\n",
+						"mode":    "100755",
+					},
+				},
+				"message": "Add logo and build script",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "successful push creating branch from from_branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/git/ref/heads/feature" {
+							w.WriteHeader(http.StatusNotFound)
+							_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+							return
+						}
+						mockResponse(t, http.StatusOK, mockRef)(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Get base tree
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockBaseTree,
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitTreesByOwnerByRepo,
+					mockTree,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add feature",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				// The branch is created pointing at the new commit
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/feature",
+						"sha": "jkl012",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/heads/feature"),
+							Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "feature",
+				"from_branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "feature.go",
+						"content": "package feature",
+					},
+				},
+				"message": "Add feature",
+			},
+			expectError: false,
+			expectedRef: &github.Reference{
+				Ref:    github.Ptr("refs/heads/feature"),
+				Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+			},
+			expectedNote: "created branch 'feature' from 'main'",
+		},
+		{
+			name: "successful push of mixed changeset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Get base tree
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockBaseTree,
+				),
+				// Deleted files are sent with a null sha
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "pkg/new.go",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "package pkg",
+							},
+							map[string]interface{}{
+								"path":    "README.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Updated README",
+							},
+							map[string]interface{}{
+								"path": "pkg/old.go",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "pkg/new.go",
+						"content": "package pkg",
+					},
+					map[string]interface{}{
+						"path":      "README.md",
+						"operation": "modify",
+						"content":   "# Updated README",
+					},
+					map[string]interface{}{
+						"path":      "pkg/old.go",
+						"operation": "delete",
+					},
+				},
+				"message": "Move old.go to new.go",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "fails on empty repository without initialize",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Git Repository is empty."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Add README",
+			},
+			expectError:    true,
+			expectedErrMsg: "Call again with initialize set to true",
+		},
+		{
+			name:         "fails when a path is listed twice",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# Updated README",
+					},
+					map[string]interface{}{
+						"path":      "README.md",
+						"operation": "delete",
+					},
+				},
+				"message": "Update README",
+			},
+			expectError:    true,
+			expectedErrMsg: "README.md is listed more than once",
+		},
+		{
+			name:         "fails when base64 content is invalid",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "assets/logo.png",
+						"content":  "not base64!",
+						"encoding": "base64",
+					},
+				},
+				"message": "Add logo",
+			},
+			expectError:    true,
+			expectedErrMsg: "content of assets/logo.png is not valid base64",
+		},
 		{
 			name:         "fails when files parameter is invalid",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1596,6 +3786,11 @@ func Test_PushFiles(t *testing.T) {
 					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
 					mockCommit,
 				),
+				// Get base tree
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockBaseTree,
+				),
 				// Fail to create tree
 				mock.WithRequestMatchHandler(
 					mock.PostReposGitTreesByOwnerByRepo,
@@ -1608,8 +3803,9 @@ func Test_PushFiles(t *testing.T) {
 				"branch": "main",
 				"files": []interface{}{
 					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
+						"path":      "README.md",
+						"operation": "modify",
+						"content":   "# README",
 					},
 				},
 				"message": "Update file",
@@ -1617,6 +3813,39 @@ func Test_PushFiles(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to create tree",
 		},
+		{
+			name: "fails to add an existing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectPath(t, "/repos/owner/repo/git/trees/def456").andThen(
+						mockResponse(t, http.StatusOK, mockBaseTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Add README",
+			},
+			expectError:    true,
+			expectedErrMsg: "README.md already exists, use operation modify to change it",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1651,6 +3880,13 @@ func Test_PushFiles(t *testing.T) {
 			require.NoError(t, err)
 			require.False(t, result.IsError)
 
+			// A note follows the reference when the branch was created
+			if tc.expectedNote != "" {
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, tc.expectedNote, result.Content[1].(mcp.TextContent).Text)
+				result.Content = result.Content[:1]
+			}
+
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
@@ -1665,6 +3901,22 @@ func Test_PushFiles(t *testing.T) {
 	}
 }
 
+func Test_checkPushFiles(t *testing.T) {
+	exists := map[string]bool{"README.md": true, "pkg/old.go": true}
+
+	assert.NoError(t, checkPushFiles([]pushFile{
+		{path: "pkg/new.go", operation: "add"},
+		{path: "README.md", operation: "modify"},
+		{path: "pkg/old.go", operation: "delete"},
+	}, exists))
+	assert.EqualError(t, checkPushFiles([]pushFile{{path: "README.md", operation: "add"}}, exists),
+		"README.md already exists, use operation modify to change it")
+	assert.EqualError(t, checkPushFiles([]pushFile{{path: "docs/guide.md", operation: "modify"}}, exists),
+		"docs/guide.md does not exist, use operation add to create it")
+	assert.EqualError(t, checkPushFiles([]pushFile{{path: "pkg/gone.go", operation: "delete"}}, exists),
+		"pkg/gone.go does not exist, so it can't be deleted")
+}
+
 func Test_ListBranches(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
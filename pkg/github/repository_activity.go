@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryActivity is a change to a ref of a repository, as returned by the repository activity API.
+type RepositoryActivity struct {
+	ID           int64        `json:"id"`
+	Ref          string       `json:"ref"`
+	ActivityType string       `json:"activity_type"`
+	Before       string       `json:"before"`
+	After        string       `json:"after"`
+	Timestamp    string       `json:"timestamp"`
+	Actor        *MinimalUser `json:"actor,omitempty"`
+}
+
+// repositoryActivity is the API representation of a repository activity. go-github does not wrap this endpoint.
+type repositoryActivity struct {
+	ID           int64             `json:"id"`
+	Before       string            `json:"before"`
+	After        string            `json:"after"`
+	Ref          string            `json:"ref"`
+	Timestamp    *github.Timestamp `json:"timestamp"`
+	ActivityType string            `json:"activity_type"`
+	Actor        *github.User      `json:"actor"`
+}
+
+func ListRepositoryActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_activity",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_ACTIVITY_DESCRIPTION", "List the changes to the branches and tags of a GitHub repository: pushes, force pushes, branch creations and deletions, and merges, with the actor who made them and when. Use it to answer questions like 'who force-pushed to main and when?'.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_ACTIVITY_USER_TITLE", "List repository activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list activity on this ref, e.g. 'refs/heads/main' or 'main'"),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Only list activity by this GitHub username"),
+			),
+			mcp.WithString("activity_type",
+				mcp.Description("Only list activity of this type"),
+				mcp.Enum("push", "force_push", "branch_creation", "branch_deletion", "pr_merge", "merge_queue_merge"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("Only list activity within this period, counting back from now"),
+				mcp.Enum("day", "week", "month", "quarter", "year"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction of the activity by time. Defaults to desc."),
+				mcp.Enum("asc", "desc"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := url.Values{}
+			for _, name := range []string{"ref", "actor", "activity_type", "time_period", "direction"} {
+				value, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					query.Set(name, value)
+				}
+			}
+			query.Set("per_page", strconv.Itoa(pagination.PerPage))
+			if pagination.After != "" {
+				query.Set("after", pagination.After)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/activity?%s", owner, repo, query.Encode()), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var activities []*repositoryActivity
+			resp, err := client.Do(ctx, req, &activities)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list activity for repository '%s/%s'", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]RepositoryActivity, 0, len(activities))
+			for _, activity := range activities {
+				item := RepositoryActivity{
+					ID:           activity.ID,
+					Ref:          activity.Ref,
+					ActivityType: activity.ActivityType,
+					Before:       activity.Before,
+					After:        activity.After,
+				}
+				if activity.Timestamp != nil {
+					item.Timestamp = activity.Timestamp.Format("2006-01-02T15:04:05Z")
+				}
+				if activity.Actor != nil {
+					item.Actor = &MinimalUser{
+						Login:      activity.Actor.GetLogin(),
+						ID:         activity.Actor.GetID(),
+						ProfileURL: activity.Actor.GetHTMLURL(),
+						AvatarURL:  activity.Actor.GetAvatarURL(),
+					}
+				}
+				result = append(result, item)
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"activity": result,
+				"pageInfo": map[string]any{
+					"hasNextPage": resp.After != "",
+					"endCursor":   resp.After,
+				},
+			}), nil
+		}
+}
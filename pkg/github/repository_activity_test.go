@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_activity", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "actor")
+	assert.Contains(t, tool.InputSchema.Properties, "activity_type")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockActivity := []map[string]any{
+		{
+			"id":            int64(1296269),
+			"node_id":       "MDEwOlJlcG9zaXRvcnkxMjk2MjY5",
+			"before":        "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"after":         "827efc6d56897b048c772eb4087f854f46256132",
+			"ref":           "refs/heads/main",
+			"timestamp":     "2024-03-01T12:00:00Z",
+			"activity_type": "force_push",
+			"actor": map[string]any{
+				"login":    "octocat",
+				"id":       1,
+				"html_url": "https://github.com/octocat",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list force pushes to main",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActivityByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref":           "refs/heads/main",
+						"activity_type": "force_push",
+						"time_period":   "week",
+						"per_page":      "10",
+						"after":         "cursor1",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/activity?after=cursor2>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							_ = json.NewEncoder(w).Encode(mockActivity)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ref":           "refs/heads/main",
+				"activity_type": "force_push",
+				"time_period":   "week",
+				"perPage":       float64(10),
+				"after":         "cursor1",
+			},
+		},
+		{
+			name: "list activity fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActivityByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list activity for repository 'owner/repo'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Activity []RepositoryActivity `json:"activity"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.Len(t, response.Activity, 1)
+			activity := response.Activity[0]
+			assert.Equal(t, "force_push", activity.ActivityType)
+			assert.Equal(t, "refs/heads/main", activity.Ref)
+			assert.Equal(t, "2024-03-01T12:00:00Z", activity.Timestamp)
+			assert.Equal(t, "827efc6d56897b048c772eb4087f854f46256132", activity.After)
+			require.NotNil(t, activity.Actor)
+			assert.Equal(t, "octocat", activity.Actor.Login)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "cursor2", response.PageInfo.EndCursor)
+		})
+	}
+}
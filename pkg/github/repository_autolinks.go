@@ -0,0 +1,290 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxAutolinkRepositories is the number of repositories create_autolink adds an autolink reference to in one call.
+const maxAutolinkRepositories = 100
+
+// autolinkNumberPlaceholder is the placeholder GitHub replaces with the reference in the URL template of an autolink.
+const autolinkNumberPlaceholder = "<num>"
+
+// AutolinkResult is the outcome of adding an autolink reference to a repository. Status is "created", "exists" when
+// the repository already has the same autolink reference, or "failed".
+type AutolinkResult struct {
+	Repository string `json:"repository"`
+	Status     string `json:"status"`
+	AutolinkID int64  `json:"autolink_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// addAutolink adds the autolink reference to the repository unless it already has it. A different autolink
+// reference with the same key prefix is reported as an error rather than replaced.
+func addAutolink(ctx context.Context, client *github.Client, owner, repo string, opts *github.AutolinkOptions) AutolinkResult {
+	result := AutolinkResult{Repository: owner + "/" + repo}
+	autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+	if err != nil {
+		result.Status = "failed"
+		result.Error = fmt.Sprintf("failed to list autolinks: %s", err)
+		return result
+	}
+	_ = resp.Body.Close()
+	for _, autolink := range autolinks {
+		if !strings.EqualFold(autolink.GetKeyPrefix(), opts.GetKeyPrefix()) {
+			continue
+		}
+		result.AutolinkID = autolink.GetID()
+		if autolink.GetURLTemplate() == opts.GetURLTemplate() && autolink.GetIsAlphanumeric() == opts.GetIsAlphanumeric() {
+			result.Status = "exists"
+			return result
+		}
+		result.Status = "failed"
+		result.Error = fmt.Sprintf("key prefix %s is already used by autolink %d with URL template %s", autolink.GetKeyPrefix(), autolink.GetID(), autolink.GetURLTemplate())
+		return result
+	}
+
+	autolink, resp, err := client.Repositories.AddAutolink(ctx, owner, repo, opts)
+	if err != nil {
+		result.Status = "failed"
+		result.Error = fmt.Sprintf("failed to create autolink: %s", err)
+		return result
+	}
+	_ = resp.Body.Close()
+	result.Status = "created"
+	result.AutolinkID = autolink.GetID()
+	return result
+}
+
+// ListAutolinks creates a tool to list the autolink references of a repository.
+func ListAutolinks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_autolinks",
+			mcp.WithDescription(t("TOOL_LIST_AUTOLINKS_DESCRIPTION", "List the autolink references of a GitHub repository, which turn references like JIRA-123 in issues, pull requests and commit messages into links to an external system. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_AUTOLINKS_USER_TITLE", "List autolink references"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list autolinks",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, autolinks), nil
+		}
+}
+
+// CreateAutolink creates a tool to add an autolink reference to a set of repositories.
+func CreateAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_autolink",
+			mcp.WithDescription(t("TOOL_CREATE_AUTOLINK_DESCRIPTION", "Add an autolink reference to one or more repositories of an owner, so that references like JIRA-123 link to an external system. Repositories that already have the same autolink reference are left as they are, so the tool can be run again to roll an autolink out to new repositories. A repository using the key prefix for a different URL template is reported as failed. Requires admin access to the repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AUTOLINK_USER_TITLE", "Create autolink reference"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Names of the repositories of the owner to add the autolink reference to, at most %d", maxAutolinkRepositories)),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Required(),
+				mcp.Description("Prefix of the references to link, e.g. 'JIRA-' to link JIRA-123"),
+			),
+			mcp.WithString("url_template",
+				mcp.Required(),
+				mcp.Description("URL the references link to, with <num> where the reference goes, e.g. 'https://jira.example.com/browse/JIRA-<num>'"),
+			),
+			mcp.WithBoolean("is_alphanumeric",
+				mcp.Description("Whether the references can contain letters as well as numbers (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(repositories) > maxAutolinkRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be given", maxAutolinkRepositories)), nil
+			}
+			keyPrefix, err := RequiredParam[string](request, "key_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			urlTemplate, err := RequiredParam[string](request, "url_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !strings.Contains(urlTemplate, autolinkNumberPlaceholder) {
+				return mcp.NewToolResultError(fmt.Sprintf("url_template must contain %s where the reference goes", autolinkNumberPlaceholder)), nil
+			}
+			isAlphanumeric, err := OptionalBoolParamWithDefault(request, "is_alphanumeric", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.AutolinkOptions{
+				KeyPrefix:      github.Ptr(keyPrefix),
+				URLTemplate:    github.Ptr(urlTemplate),
+				IsAlphanumeric: github.Ptr(isAlphanumeric),
+			}
+			results := make([]AutolinkResult, len(repositories))
+			errs := fanOut(ctx, repositories, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				results[i] = addAutolink(ctx, client, owner, repo, opts)
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					results[i] = AutolinkResult{Repository: owner + "/" + repositories[i], Status: "failed", Error: err.Error()}
+				}
+			}
+
+			summary := map[string]int{"repositories": len(results)}
+			for _, result := range results {
+				summary[result.Status]++
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"summary":      summary,
+				"repositories": results,
+			}), nil
+		}
+}
+
+// DeleteAutolink creates a tool to delete an autolink reference of a repository.
+func DeleteAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_autolink",
+			mcp.WithDescription(t("TOOL_DELETE_AUTOLINK_DESCRIPTION", "Delete an autolink reference of a GitHub repository, given its ID or its key prefix. References already linked in issues and pull requests stop being links. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_AUTOLINK_USER_TITLE", "Delete autolink reference"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("autolink_id",
+				mcp.Description("ID of the autolink reference to delete, as returned by list_autolinks"),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Description("Key prefix of the autolink reference to delete, instead of its ID"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			autolinkID, err := OptionalIntParam(request, "autolink_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyPrefix, err := OptionalParam[string](request, "key_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (autolinkID == 0) == (keyPrefix == "") {
+				return mcp.NewToolResultError("exactly one of autolink_id and key_prefix must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			id := int64(autolinkID)
+			if keyPrefix != "" {
+				autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list autolinks",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, autolink := range autolinks {
+					if strings.EqualFold(autolink.GetKeyPrefix(), keyPrefix) {
+						id = autolink.GetID()
+						break
+					}
+				}
+				if id == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("no autolink with key prefix %s in %s/%s", keyPrefix, owner, repo)), nil
+				}
+			}
+
+			resp, err := client.Repositories.DeleteAutolink(ctx, owner, repo, id)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete autolink %d", id),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted autolink %d from %s/%s", id, owner, repo)), nil
+		}
+}
@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAutolinks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAutolinks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_autolinks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	autolinks := []*github.Autolink{
+		{ID: github.Ptr(int64(1)), KeyPrefix: github.Ptr("JIRA-"), URLTemplate: github.Ptr("https://jira.example.com/browse/JIRA-<num>"), IsAlphanumeric: github.Ptr(true)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, autolinks),
+			),
+		},
+		{
+			name: "no admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutolinksByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list autolinks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAutolinks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned []*github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, autolinks, returned)
+		})
+	}
+}
+
+func Test_CreateAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "is_alphanumeric")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repositories", "key_prefix", "url_template"})
+
+	const urlTemplate = "https://jira.example.com/browse/JIRA-<num>"
+	existing := map[string][]*github.Autolink{
+		"api": {{ID: github.Ptr(int64(7)), KeyPrefix: github.Ptr("JIRA-"), URLTemplate: github.Ptr(urlTemplate), IsAlphanumeric: github.Ptr(true)}},
+		"web": {{ID: github.Ptr(int64(9)), KeyPrefix: github.Ptr("jira-"), URLTemplate: github.Ptr("https://old.example.com/<num>"), IsAlphanumeric: github.Ptr(true)}},
+	}
+	repoOf := func(r *http.Request) string {
+		return strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/"), "/")[0]
+	}
+
+	var mu sync.Mutex
+	var created []string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposAutolinksByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				autolinks := existing[repoOf(r)]
+				if autolinks == nil {
+					autolinks = []*github.Autolink{}
+				}
+				mockResponse(t, http.StatusOK, autolinks)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposAutolinksByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"key_prefix":      "JIRA-",
+				"url_template":    urlTemplate,
+				"is_alphanumeric": true,
+			}).andThen(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				created = append(created, repoOf(r))
+				mockResponse(t, http.StatusCreated, &github.Autolink{ID: github.Ptr(int64(11)), KeyPrefix: github.Ptr("JIRA-"), URLTemplate: github.Ptr(urlTemplate)})(w, r)
+			}),
+		),
+	))
+	_, handler := CreateAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	t.Run("rolls out to the repositories without it", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repositories": []any{"api", "web", "docs"},
+			"key_prefix":   "JIRA-",
+			"url_template": urlTemplate,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			Summary      map[string]int   `json:"summary"`
+			Repositories []AutolinkResult `json:"repositories"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, map[string]int{"repositories": 3, "created": 1, "exists": 1, "failed": 1}, response.Summary)
+		require.Len(t, response.Repositories, 3)
+		assert.Equal(t, AutolinkResult{Repository: "owner/api", Status: "exists", AutolinkID: 7}, response.Repositories[0])
+		assert.Equal(t, "failed", response.Repositories[1].Status)
+		assert.Contains(t, response.Repositories[1].Error, "already used by autolink 9")
+		assert.Equal(t, AutolinkResult{Repository: "owner/docs", Status: "created", AutolinkID: 11}, response.Repositories[2])
+		assert.Equal(t, []string{"docs"}, created)
+	})
+
+	t.Run("URL template without placeholder", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repositories": []any{"api"},
+			"key_prefix":   "JIRA-",
+			"url_template": "https://jira.example.com/browse/",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "url_template must contain <num>")
+	})
+}
+
+func Test_DeleteAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, tool.InputSchema.Properties, "autolink_id")
+	assert.Contains(t, tool.InputSchema.Properties, "key_prefix")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	autolinks := []*github.Autolink{
+		{ID: github.Ptr(int64(7)), KeyPrefix: github.Ptr("JIRA-"), URLTemplate: github.Ptr("https://jira.example.com/browse/JIRA-<num>")},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name:         "delete by ID",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "autolink_id": float64(7)},
+			expectedText: "deleted autolink 7 from owner/repo",
+		},
+		{
+			name:         "delete by key prefix",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "key_prefix": "jira-"},
+			expectedText: "deleted autolink 7 from owner/repo",
+		},
+		{
+			name:           "unknown key prefix",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "key_prefix": "ZD-"},
+			expectError:    true,
+			expectedErrMsg: "no autolink with key prefix ZD- in owner/repo",
+		},
+		{
+			name:           "both ID and key prefix",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "autolink_id": float64(7), "key_prefix": "JIRA-"},
+			expectError:    true,
+			expectedErrMsg: "exactly one of autolink_id and key_prefix must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, autolinks),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/autolinks/7", r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			))
+			_, handler := DeleteAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
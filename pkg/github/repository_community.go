@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CommunityFile is a community health file GitHub looks for in a repository.
+type CommunityFile struct {
+	File    string `json:"file"`
+	Present bool   `json:"present"`
+	URL     string `json:"url,omitempty"`
+}
+
+// DetectedLicense is the license GitHub detected in the license file of a repository.
+type DetectedLicense struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id"`
+	Path   string `json:"path"`
+}
+
+// CommunityProfile is the community profile of a repository.
+type CommunityProfile struct {
+	HealthPercentage      int             `json:"health_percentage"`
+	Description           string          `json:"description,omitempty"`
+	Documentation         string          `json:"documentation,omitempty"`
+	ContentReportsEnabled bool            `json:"content_reports_enabled"`
+	UpdatedAt             string          `json:"updated_at,omitempty"`
+	Files                 []CommunityFile `json:"files"`
+	// Missing are the files of Files the repository doesn't have.
+	Missing []string `json:"missing"`
+	// License is nil when the repository has no license file, or GitHub couldn't detect the license in it.
+	License *DetectedLicense `json:"license"`
+}
+
+func convertToCommunityProfile(metrics *github.CommunityHealthMetrics) CommunityProfile {
+	profile := CommunityProfile{
+		HealthPercentage:      metrics.GetHealthPercentage(),
+		Description:           metrics.GetDescription(),
+		Documentation:         metrics.GetDocumentation(),
+		ContentReportsEnabled: metrics.GetContentReportsEnabled(),
+		UpdatedAt:             formatOptionalTimestamp(metrics.UpdatedAt),
+		Files:                 []CommunityFile{},
+		Missing:               []string{},
+	}
+	files := metrics.GetFiles()
+	if files == nil {
+		files = &github.CommunityHealthFiles{}
+	}
+	for _, file := range []struct {
+		name   string
+		metric *github.Metric
+	}{
+		{"readme", files.Readme},
+		{"license", files.License},
+		{"code_of_conduct", files.CodeOfConduct},
+		{"contributing", files.Contributing},
+		{"issue_template", files.IssueTemplate},
+		{"pull_request_template", files.PullRequestTemplate},
+	} {
+		communityFile := CommunityFile{File: file.name, Present: file.metric != nil}
+		if file.metric != nil {
+			communityFile.URL = file.metric.GetHTMLURL()
+		} else {
+			profile.Missing = append(profile.Missing, file.name)
+		}
+		profile.Files = append(profile.Files, communityFile)
+	}
+	return profile
+}
+
+// GetCommunityProfile creates a tool to get the community profile and license of a repository.
+func GetCommunityProfile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_community_profile",
+			mcp.WithDescription(t("TOOL_GET_COMMUNITY_PROFILE_DESCRIPTION", "Get the community profile of a public GitHub repository: its health percentage, which of the README, LICENSE, CODE_OF_CONDUCT, CONTRIBUTING, issue template and pull request template files it has, and the license GitHub detected. Use it to audit the open source hygiene of repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMUNITY_PROFILE_USER_TITLE", "Get community profile"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			metrics, resp, err := client.Repositories.GetCommunityHealthMetrics(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get community profile",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			profile := convertToCommunityProfile(metrics)
+
+			license, resp, err := client.Repositories.License(ctx, owner, repo)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				profile.License = &DetectedLicense{
+					Key:    license.GetLicense().GetKey(),
+					Name:   license.GetLicense().GetName(),
+					SPDXID: license.GetLicense().GetSPDXID(),
+					Path:   license.GetPath(),
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The repository has no license file
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get license",
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(ctx, profile), nil
+		}
+}
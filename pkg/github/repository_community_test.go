@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCommunityProfile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommunityProfile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_community_profile", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	metrics := &github.CommunityHealthMetrics{
+		HealthPercentage: github.Ptr(57),
+		Description:      github.Ptr("A web framework"),
+		UpdatedAt:        &github.Timestamp{Time: time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)},
+		Files: &github.CommunityHealthFiles{
+			Readme:       &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/README.md")},
+			License:      &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/LICENSE")},
+			Contributing: &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/CONTRIBUTING.md")},
+		},
+	}
+	files := []CommunityFile{
+		{File: "readme", Present: true, URL: "https://github.com/owner/repo/blob/main/README.md"},
+		{File: "license", Present: true, URL: "https://github.com/owner/repo/blob/main/LICENSE"},
+		{File: "code_of_conduct"},
+		{File: "contributing", Present: true, URL: "https://github.com/owner/repo/blob/main/CONTRIBUTING.md"},
+		{File: "issue_template"},
+		{File: "pull_request_template"},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedProfile CommunityProfile
+	}{
+		{
+			name: "profile with detected license",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommunityProfileByOwnerByRepo, metrics),
+				mock.WithRequestMatch(mock.GetReposLicenseByOwnerByRepo, &github.RepositoryLicense{
+					Path:    github.Ptr("LICENSE"),
+					License: &github.License{Key: github.Ptr("mit"), Name: github.Ptr("MIT License"), SPDXID: github.Ptr("MIT")},
+				}),
+			),
+			expectedProfile: CommunityProfile{
+				HealthPercentage: 57,
+				Description:      "A web framework",
+				UpdatedAt:        "2025-02-01T08:00:00Z",
+				Files:            files,
+				Missing:          []string{"code_of_conduct", "issue_template", "pull_request_template"},
+				License:          &DetectedLicense{Key: "mit", Name: "MIT License", SPDXID: "MIT", Path: "LICENSE"},
+			},
+		},
+		{
+			name: "profile without license",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommunityProfileByOwnerByRepo, &github.CommunityHealthMetrics{HealthPercentage: github.Ptr(0)}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectedProfile: CommunityProfile{
+				Files: []CommunityFile{
+					{File: "readme"}, {File: "license"}, {File: "code_of_conduct"},
+					{File: "contributing"}, {File: "issue_template"}, {File: "pull_request_template"},
+				},
+				Missing: []string{"readme", "license", "code_of_conduct", "contributing", "issue_template", "pull_request_template"},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommunityProfileByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get community profile",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommunityProfile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var profile CommunityProfile
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &profile))
+			assert.Equal(t, tc.expectedProfile, profile)
+		})
+	}
+}
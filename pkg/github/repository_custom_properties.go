@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryCustomProperties are the custom property values of a repository, by property name. A value is a
+// string, a list of strings for multi-select properties, or null when unset.
+type RepositoryCustomProperties struct {
+	Repository string         `json:"repository"`
+	Properties map[string]any `json:"properties"`
+}
+
+// customPropertyValuesByName maps custom property values by property name.
+func customPropertyValuesByName(values []*github.CustomPropertyValue) map[string]any {
+	properties := make(map[string]any, len(values))
+	for _, value := range values {
+		properties[value.PropertyName] = value.Value
+	}
+	return properties
+}
+
+// parseCustomPropertyValues converts the properties argument, mapping property names to values, to the values the
+// API takes, sorted by property name.
+func parseCustomPropertyValues(arg map[string]any) ([]*github.CustomPropertyValue, error) {
+	names := make([]string, 0, len(arg))
+	for name := range arg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]*github.CustomPropertyValue, 0, len(names))
+	for _, name := range names {
+		var value any
+		switch v := arg[name].(type) {
+		case nil:
+		case string:
+			value = v
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("value of property %s must be a string, an array of strings or null", name)
+				}
+				items = append(items, s)
+			}
+			value = items
+		default:
+			return nil, fmt.Errorf("value of property %s must be a string, an array of strings or null", name)
+		}
+		values = append(values, &github.CustomPropertyValue{PropertyName: name, Value: value})
+	}
+	return values, nil
+}
+
+// ListOrgCustomProperties creates a tool to list the custom property schemas of an organization.
+func ListOrgCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_custom_properties",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CUSTOM_PROPERTIES_DESCRIPTION", "List the custom properties an organization defines for its repositories, with their value type, whether they are required, their default and allowed values, and who can edit their values.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CUSTOM_PROPERTIES_USER_TITLE", "List organization custom properties"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			properties, resp, err := client.Organizations.GetAllCustomProperties(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list custom properties for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, properties), nil
+		}
+}
+
+// ListOrgRepositoryCustomProperties creates a tool to list the custom property values of the repositories of an
+// organization.
+func ListOrgRepositoryCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_repository_custom_properties",
+			mcp.WithDescription(t("TOOL_LIST_ORG_REPOSITORY_CUSTOM_PROPERTIES_DESCRIPTION", "List the custom property values of the repositories of an organization, optionally filtered with a repository search query such as 'props.team:platform' to find the repositories with a property value.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_REPOSITORY_CUSTOM_PROPERTIES_USER_TITLE", "List organization repository custom properties"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("repository_query",
+				mcp.Description("Only list the repositories matching this repository search query, e.g. 'props.environment:production'"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "repository_query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repoValues, resp, err := client.Organizations.ListCustomPropertyValues(ctx, org, &github.ListCustomPropertyValuesOptions{
+				RepositoryQuery: query,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repository custom properties for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			repositories := make([]RepositoryCustomProperties, 0, len(repoValues))
+			for _, repo := range repoValues {
+				if !repositoryAllowed(ctx, org, repo.RepositoryName) {
+					continue
+				}
+				repositories = append(repositories, RepositoryCustomProperties{
+					Repository: repo.RepositoryName,
+					Properties: customPropertyValuesByName(repo.Properties),
+				})
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"repositories": repositories,
+				"hasNextPage":  resp.NextPage != 0,
+			}), nil
+		}
+}
+
+// GetRepositoryCustomProperties creates a tool to get the custom property values of a repository.
+func GetRepositoryCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_custom_properties",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTIES_DESCRIPTION", "Get the values of the custom properties of a GitHub repository, by property name. Use list_org_custom_properties for the properties the organization defines.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTIES_USER_TITLE", "Get repository custom properties"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get custom properties of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, RepositoryCustomProperties{
+				Repository: repo,
+				Properties: customPropertyValuesByName(values),
+			}), nil
+		}
+}
+
+// SetRepositoryCustomProperties creates a tool to set custom property values of a repository.
+func SetRepositoryCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repository_custom_properties",
+			mcp.WithDescription(t("TOOL_SET_REPOSITORY_CUSTOM_PROPERTIES_DESCRIPTION", "Set values of custom properties of a GitHub repository. Properties that are not given are left unchanged, and a null value unsets a property. Values must be allowed by the organization's property schema, see list_org_custom_properties. Returns all the property values of the repository after the change.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPOSITORY_CUSTOM_PROPERTIES_USER_TITLE", "Set repository custom properties"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithObject("properties",
+				mcp.Required(),
+				mcp.Description("Property values by property name: a string, an array of strings for multi-select properties, or null to unset the property. Example: {\"team\": \"platform\", \"environment\": null}"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			propertiesArg, ok := request.GetArguments()["properties"].(map[string]any)
+			if !ok || len(propertiesArg) == 0 {
+				return mcp.NewToolResultError("properties must be a non-empty object"), nil
+			}
+			values, err := parseCustomPropertyValues(propertiesArg)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repo, values)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set custom properties of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			current, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get custom properties of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, RepositoryCustomProperties{
+				Repository: repo,
+				Properties: customPropertyValuesByName(current),
+			}), nil
+		}
+}
@@ -0,0 +1,358 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	schemas := []*github.CustomProperty{
+		{
+			PropertyName:  github.Ptr("environment"),
+			ValueType:     "single_select",
+			Required:      github.Ptr(true),
+			DefaultValue:  github.Ptr("development"),
+			AllowedValues: []string{"development", "production"},
+		},
+		{PropertyName: github.Ptr("team"), ValueType: "string"},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedSchemas []*github.CustomProperty
+	}{
+		{
+			name: "list schemas",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsPropertiesSchemaByOrg, schemas),
+			),
+			expectedSchemas: schemas,
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list custom properties for organization 'acme'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "acme"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned []*github.CustomProperty
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedSchemas, returned)
+		})
+	}
+}
+
+func Test_ListOrgRepositoryCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgRepositoryCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_repository_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	repoValues := []*github.RepoCustomPropertyValue{
+		{
+			RepositoryName:     "web",
+			RepositoryFullName: "acme/web",
+			Properties: []*github.CustomPropertyValue{
+				{PropertyName: "environment", Value: "production"},
+				{PropertyName: "languages", Value: []string{"go", "ts"}},
+				{PropertyName: "team", Value: nil},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []RepositoryCustomProperties
+	}{
+		{
+			name: "filtered repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesValuesByOrg,
+					expectQueryParams(t, map[string]string{
+						"repository_query": "props.environment:production",
+						"page":             "2",
+						"per_page":         "10",
+					}).andThen(mockResponse(t, http.StatusOK, repoValues)),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":              "acme",
+				"repository_query": "props.environment:production",
+				"page":             float64(2),
+				"perPage":          float64(10),
+			},
+			expectedRepos: []RepositoryCustomProperties{{
+				Repository: "web",
+				Properties: map[string]any{
+					"environment": "production",
+					"languages":   []any{"go", "ts"},
+					"team":        nil,
+				},
+			}},
+		},
+		{
+			name: "invalid query",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesValuesByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs:    map[string]any{"org": "acme", "repository_query": "props.:"},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository custom properties for organization 'acme'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgRepositoryCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				Repositories []RepositoryCustomProperties `json:"repositories"`
+				HasNextPage  bool                         `json:"hasNextPage"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedRepos, response.Repositories)
+			assert.False(t, response.HasNextPage)
+		})
+	}
+}
+
+func Test_GetRepositoryCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectError        bool
+		expectedErrMsg     string
+		expectedProperties RepositoryCustomProperties
+	}{
+		{
+			name: "get values",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{
+						{PropertyName: "environment", Value: "production"},
+						{PropertyName: "languages", Value: []string{"go"}},
+					},
+				),
+			),
+			expectedProperties: RepositoryCustomProperties{
+				Repository: "web",
+				Properties: map[string]any{"environment": "production", "languages": []any{"go"}},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get custom properties of acme/web",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "acme", "repo": "web"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned RepositoryCustomProperties
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedProperties, returned)
+		})
+	}
+}
+
+func Test_SetRepositoryCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepositoryCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repository_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "properties"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		properties         any
+		expectError        bool
+		expectedErrMsg     string
+		expectedProperties RepositoryCustomProperties
+	}{
+		{
+			name: "set and unset values",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "environment", "value": nil},
+							map[string]any{"property_name": "languages", "value": []any{"go", "ts"}},
+							map[string]any{"property_name": "team", "value": "platform"},
+						},
+					}).andThen(mockResponse(t, http.StatusNoContent, "")),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{
+						{PropertyName: "environment", Value: nil},
+						{PropertyName: "languages", Value: []string{"go", "ts"}},
+						{PropertyName: "team", Value: "platform"},
+					},
+				),
+			),
+			properties: map[string]any{
+				"team":        "platform",
+				"languages":   []any{"go", "ts"},
+				"environment": nil,
+			},
+			expectedProperties: RepositoryCustomProperties{
+				Repository: "web",
+				Properties: map[string]any{"environment": nil, "languages": []any{"go", "ts"}, "team": "platform"},
+			},
+		},
+		{
+			name: "value not allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			properties:     map[string]any{"environment": "staging"},
+			expectError:    true,
+			expectedErrMsg: "failed to set custom properties of acme/web",
+		},
+		{
+			name:           "invalid value",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			properties:     map[string]any{"public": true},
+			expectError:    true,
+			expectedErrMsg: "value of property public must be a string, an array of strings or null",
+		},
+		{
+			name:           "empty properties",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			properties:     map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "properties must be a non-empty object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepositoryCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "acme",
+				"repo":       "web",
+				"properties": tc.properties,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned RepositoryCustomProperties
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedProperties, returned)
+		})
+	}
+}
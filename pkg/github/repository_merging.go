@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MergeBranch creates a tool to merge a branch, tag or commit into a branch.
+func MergeBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("merge_branch",
+			mcp.WithDescription(t("TOOL_MERGE_BRANCH_DESCRIPTION", "Merge a branch, tag or commit into a branch of a GitHub repository with a merge commit, without opening a pull request. Reports when there is nothing to merge, and when the merge conflicts, in which case it must be resolved in a pull request or locally.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MERGE_BRANCH_USER_TITLE", "Merge branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Name of the branch to merge into"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to merge"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Message of the merge commit. Defaults to GitHub's message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitMessage, err := OptionalParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			mergeRequest := &github.RepositoryMergeRequest{
+				Base: github.Ptr(base),
+				Head: github.Ptr(head),
+			}
+			if commitMessage != "" {
+				mergeRequest.CommitMessage = github.Ptr(commitMessage)
+			}
+			commit, resp, err := client.Repositories.Merge(ctx, owner, repo, mergeRequest)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return mcp.NewToolResultError(fmt.Sprintf("merging %s into %s conflicts, resolve the conflicts in a pull request", head, base)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to merge %s into %s", head, base),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// GitHub answers with no content when the base already contains the head
+			if resp.StatusCode == http.StatusNoContent {
+				return MarshalledTextResult(ctx, map[string]any{
+					"status": "already_merged",
+					"base":   base,
+					"head":   head,
+				}), nil
+			}
+			return MarshalledTextResult(ctx, map[string]any{
+				"status":   "merged",
+				"base":     base,
+				"head":     head,
+				"sha":      commit.GetSHA(),
+				"html_url": commit.GetHTMLURL(),
+				"message":  commit.GetCommit().GetMessage(),
+			}), nil
+		}
+}
+
+// SyncFork creates a tool to sync a branch of a fork with its upstream repository.
+func SyncFork(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sync_fork",
+			mcp.WithDescription(t("TOOL_SYNC_FORK_DESCRIPTION", "Sync a branch of a forked repository with the same branch of its upstream repository, fast-forwarding or merging the upstream changes like the 'Sync fork' button. Fails when the branch has conflicting changes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SYNC_FORK_USER_TITLE", "Sync fork"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the fork"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the fork"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch of the fork to sync. Defaults to the fork's default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if branch == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if !repository.GetFork() {
+					return mcp.NewToolResultError(fmt.Sprintf("%s/%s is not a fork", owner, repo)), nil
+				}
+				branch = repository.GetDefaultBranch()
+			}
+
+			result, resp, err := client.Repositories.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{
+				Branch: github.Ptr(branch),
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s has changes conflicting with upstream, merge them in a pull request", branch, owner, repo)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to sync branch %s of %s/%s with upstream", branch, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// merge_type is "none" when the branch was already up to date, otherwise "fast-forward" or "merge"
+			return MarshalledTextResult(ctx, map[string]any{
+				"branch":      branch,
+				"merge_type":  result.GetMergeType(),
+				"base_branch": result.GetBaseBranch(),
+				"message":     result.GetMessage(),
+			}), nil
+		}
+}
@@ -0,0 +1,248 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MergeBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MergeBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "merge_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name: "merged",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"base":           "release",
+						"head":           "main",
+						"commit_message": "Merge main into release",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepositoryCommit{
+							SHA:     github.Ptr("abc123"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+							Commit:  &github.Commit{Message: github.Ptr("Merge main into release")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"base":           "release",
+				"head":           "main",
+				"commit_message": "Merge main into release",
+			},
+			expected: map[string]any{
+				"status":   "merged",
+				"base":     "release",
+				"head":     "main",
+				"sha":      "abc123",
+				"html_url": "https://github.com/owner/repo/commit/abc123",
+				"message":  "Merge main into release",
+			},
+		},
+		{
+			name: "nothing to merge",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "base": "release", "head": "main"},
+			expected: map[string]any{
+				"status": "already_merged",
+				"base":   "release",
+				"head":   "main",
+			},
+		},
+		{
+			name: "conflict",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Merge Conflict"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "base": "release", "head": "main"},
+			expectError:    true,
+			expectedErrMsg: "merging main into release conflicts",
+		},
+		{
+			name: "missing head",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Head does not exist"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "base": "release", "head": "gone"},
+			expectError:    true,
+			expectedErrMsg: "failed to merge gone into release",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MergeBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func Test_SyncFork(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SyncFork(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_fork", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]any
+	}{
+		{
+			name: "default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{Fork: github.Ptr(true), DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"branch": "main"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("Successfully fetched and fast-forwarded from upstream upstream:main."),
+							MergeType:  github.Ptr("fast-forward"),
+							BaseBranch: github.Ptr("upstream:main"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "octocat", "repo": "fork"},
+			expected: map[string]any{
+				"branch":      "main",
+				"merge_type":  "fast-forward",
+				"base_branch": "upstream:main",
+				"message":     "Successfully fetched and fast-forwarded from upstream upstream:main.",
+			},
+		},
+		{
+			name: "given branch already up to date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"branch": "dev"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("This branch is not behind the upstream upstream:dev."),
+							MergeType:  github.Ptr("none"),
+							BaseBranch: github.Ptr("upstream:dev"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "octocat", "repo": "fork", "branch": "dev"},
+			expected: map[string]any{
+				"branch":      "dev",
+				"merge_type":  "none",
+				"base_branch": "upstream:dev",
+				"message":     "This branch is not behind the upstream upstream:dev.",
+			},
+		},
+		{
+			name: "not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{Fork: github.Ptr(false), DefaultBranch: github.Ptr("main")},
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octocat", "repo": "hello-world"},
+			expectError:    true,
+			expectedErrMsg: "octocat/hello-world is not a fork",
+		},
+		{
+			name: "conflict",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "There are merge conflicts"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octocat", "repo": "fork", "branch": "main"},
+			expectError:    true,
+			expectedErrMsg: "branch main of octocat/fork has changes conflicting with upstream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SyncFork(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
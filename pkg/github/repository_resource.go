@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"mime"
 	"net/http"
 	"path/filepath"
 	"strconv"
@@ -146,21 +145,18 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		case err != nil:
 			return nil, fmt.Errorf("failed to get raw content: %w", err)
 		case resp.StatusCode == http.StatusOK:
-			ext := filepath.Ext(path)
-			mimeType := resp.Header.Get("Content-Type")
-			if ext == ".md" {
-				mimeType = "text/markdown"
-			} else if mimeType == "" {
-				mimeType = mime.TypeByExtension(ext)
-			}
-
 			content, err := io.ReadAll(resp.Body)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read file content: %w", err)
 			}
 
+			mimeType := detectContentType(path, resp.Header.Get("Content-Type"), content)
+			if filepath.Ext(path) == ".md" {
+				mimeType = "text/markdown"
+			}
+
 			switch {
-			case strings.HasPrefix(mimeType, "text"), strings.HasPrefix(mimeType, "application"):
+			case isTextContentType(mimeType):
 				return []mcp.ResourceContents{
 					mcp.TextResourceContents{
 						URI:      request.Params.URI,
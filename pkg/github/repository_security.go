@@ -0,0 +1,268 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositorySecuritySettings is the security configuration of a repository. The status fields hold
+// "enabled" or "disabled", and are empty when the token is not allowed to see them.
+type RepositorySecuritySettings struct {
+	AdvancedSecurity                string `json:"advanced_security,omitempty"`
+	SecretScanning                  string `json:"secret_scanning,omitempty"`
+	SecretScanningPushProtection    string `json:"secret_scanning_push_protection,omitempty"`
+	SecretScanningValidityChecks    string `json:"secret_scanning_validity_checks,omitempty"`
+	DependabotAlerts                bool   `json:"dependabot_alerts"`
+	DependabotSecurityUpdates       bool   `json:"dependabot_security_updates"`
+	DependabotSecurityUpdatesPaused bool   `json:"dependabot_security_updates_paused"`
+	PrivateVulnerabilityReporting   bool   `json:"private_vulnerability_reporting"`
+}
+
+// getRepositorySecuritySettings gathers the security settings of a repository, which are spread across several
+// endpoints. On failure it returns a description of the setting that could not be read.
+func getRepositorySecuritySettings(ctx context.Context, client *github.Client, owner, repo string) (*RepositorySecuritySettings, string, *github.Response, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, "failed to get repository", resp, err
+	}
+	_ = resp.Body.Close()
+
+	settings := &RepositorySecuritySettings{}
+	if sa := repository.GetSecurityAndAnalysis(); sa != nil {
+		settings.AdvancedSecurity = sa.GetAdvancedSecurity().GetStatus()
+		settings.SecretScanning = sa.GetSecretScanning().GetStatus()
+		settings.SecretScanningPushProtection = sa.GetSecretScanningPushProtection().GetStatus()
+		settings.SecretScanningValidityChecks = sa.GetSecretScanningValidityChecks().GetStatus()
+	}
+
+	settings.DependabotAlerts, resp, err = client.Repositories.GetVulnerabilityAlerts(ctx, owner, repo)
+	if err != nil {
+		return nil, "failed to get Dependabot alerts status", resp, err
+	}
+	_ = resp.Body.Close()
+
+	fixes, resp, err := client.Repositories.GetAutomatedSecurityFixes(ctx, owner, repo)
+	if err != nil {
+		return nil, "failed to get Dependabot security updates status", resp, err
+	}
+	_ = resp.Body.Close()
+	settings.DependabotSecurityUpdates = fixes.GetEnabled()
+	settings.DependabotSecurityUpdatesPaused = fixes.GetPaused()
+
+	settings.PrivateVulnerabilityReporting, resp, err = client.Repositories.IsPrivateReportingEnabled(ctx, owner, repo)
+	if err != nil {
+		return nil, "failed to get private vulnerability reporting status", resp, err
+	}
+	_ = resp.Body.Close()
+
+	return settings, "", nil, nil
+}
+
+func GetRepositorySecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_security_settings",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_SECURITY_SETTINGS_DESCRIPTION", "Get the security settings of a GitHub repository: GitHub Advanced Security, secret scanning, push protection, Dependabot alerts and security updates, and private vulnerability reporting.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_SECURITY_SETTINGS_USER_TITLE", "Get repository security settings"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			settings, msg, resp, err := getRepositorySecuritySettings(ctx, client, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, msg, resp, err), nil
+			}
+
+			return MarshalledTextResult(ctx, settings), nil
+		}
+}
+
+// securityAnalysisStatus converts a boolean toggle to the status expected by the security_and_analysis API.
+func securityAnalysisStatus(enabled bool) *string {
+	if enabled {
+		return github.Ptr("enabled")
+	}
+	return github.Ptr("disabled")
+}
+
+func UpdateRepositorySecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository_security_settings",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_SECURITY_SETTINGS_DESCRIPTION", "Enable or disable security features of a GitHub repository. Only the settings provided are changed. Requires admin access to the repository. Returns the resulting settings.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REPOSITORY_SECURITY_SETTINGS_USER_TITLE", "Update repository security settings"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("advanced_security",
+				mcp.Description("Enable GitHub Advanced Security. Required by secret scanning on private repositories."),
+			),
+			mcp.WithBoolean("secret_scanning",
+				mcp.Description("Enable secret scanning"),
+			),
+			mcp.WithBoolean("secret_scanning_push_protection",
+				mcp.Description("Enable secret scanning push protection, which blocks pushes containing secrets"),
+			),
+			mcp.WithBoolean("secret_scanning_validity_checks",
+				mcp.Description("Enable validity checks for detected secrets"),
+			),
+			mcp.WithBoolean("dependabot_alerts",
+				mcp.Description("Enable Dependabot alerts and the dependency graph"),
+			),
+			mcp.WithBoolean("dependabot_security_updates",
+				mcp.Description("Enable Dependabot security updates. Requires Dependabot alerts."),
+			),
+			mcp.WithBoolean("private_vulnerability_reporting",
+				mcp.Description("Enable private vulnerability reporting"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			analysis := &github.SecurityAndAnalysis{}
+			analysisUpdateNeeded := false
+			if enabled, ok, err := OptionalParamOK[bool](request, "advanced_security"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				analysis.AdvancedSecurity = &github.AdvancedSecurity{Status: securityAnalysisStatus(enabled)}
+				analysisUpdateNeeded = true
+			}
+			if enabled, ok, err := OptionalParamOK[bool](request, "secret_scanning"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				analysis.SecretScanning = &github.SecretScanning{Status: securityAnalysisStatus(enabled)}
+				analysisUpdateNeeded = true
+			}
+			if enabled, ok, err := OptionalParamOK[bool](request, "secret_scanning_push_protection"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				analysis.SecretScanningPushProtection = &github.SecretScanningPushProtection{Status: securityAnalysisStatus(enabled)}
+				analysisUpdateNeeded = true
+			}
+			if enabled, ok, err := OptionalParamOK[bool](request, "secret_scanning_validity_checks"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				analysis.SecretScanningValidityChecks = &github.SecretScanningValidityChecks{Status: securityAnalysisStatus(enabled)}
+				analysisUpdateNeeded = true
+			}
+			dependabotAlerts, dependabotAlertsProvided, err := OptionalParamOK[bool](request, "dependabot_alerts")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			securityUpdates, securityUpdatesProvided, err := OptionalParamOK[bool](request, "dependabot_security_updates")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			privateReporting, privateReportingProvided, err := OptionalParamOK[bool](request, "private_vulnerability_reporting")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !analysisUpdateNeeded && !dependabotAlertsProvided && !securityUpdatesProvided && !privateReportingProvided {
+				return mcp.NewToolResultError("at least one security setting must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Dependabot security updates depend on Dependabot alerts, so alerts are enabled first and disabled last.
+			if dependabotAlertsProvided && dependabotAlerts {
+				resp, err := client.Repositories.EnableVulnerabilityAlerts(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to enable Dependabot alerts", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if analysisUpdateNeeded {
+				_, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{SecurityAndAnalysis: analysis})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update security and analysis settings", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if securityUpdatesProvided {
+				var resp *github.Response
+				if securityUpdates {
+					resp, err = client.Repositories.EnableAutomatedSecurityFixes(ctx, owner, repo)
+				} else {
+					resp, err = client.Repositories.DisableAutomatedSecurityFixes(ctx, owner, repo)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update Dependabot security updates", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if dependabotAlertsProvided && !dependabotAlerts {
+				resp, err := client.Repositories.DisableVulnerabilityAlerts(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to disable Dependabot alerts", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if privateReportingProvided {
+				var resp *github.Response
+				if privateReporting {
+					resp, err = client.Repositories.EnablePrivateReporting(ctx, owner, repo)
+				} else {
+					resp, err = client.Repositories.DisablePrivateReporting(ctx, owner, repo)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update private vulnerability reporting", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			settings, msg, resp, err := getRepositorySecuritySettings(ctx, client, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, msg, resp, err), nil
+			}
+
+			return MarshalledTextResult(ctx, settings), nil
+		}
+}
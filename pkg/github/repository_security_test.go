@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// securitySettingsReadMocks mocks the endpoints read by getRepositorySecuritySettings.
+func securitySettingsReadMocks() []mock.MockBackendOption {
+	return []mock.MockBackendOption{
+		mock.WithRequestMatch(
+			mock.GetReposByOwnerByRepo,
+			&github.Repository{
+				Name: github.Ptr("repo"),
+				SecurityAndAnalysis: &github.SecurityAndAnalysis{
+					AdvancedSecurity:             &github.AdvancedSecurity{Status: github.Ptr("enabled")},
+					SecretScanning:               &github.SecretScanning{Status: github.Ptr("enabled")},
+					SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: github.Ptr("disabled")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+			&github.AutomatedSecurityFixes{Enabled: github.Ptr(true), Paused: github.Ptr(false)},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+			map[string]bool{"enabled": false},
+		),
+	}
+}
+
+func Test_GetRepositorySecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositorySecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings RepositorySecuritySettings
+	}{
+		{
+			name:         "successful settings fetch",
+			mockedClient: mock.NewMockedHTTPClient(securitySettingsReadMocks()...),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedSettings: RepositorySecuritySettings{
+				AdvancedSecurity:             "enabled",
+				SecretScanning:               "enabled",
+				SecretScanningPushProtection: "disabled",
+				DependabotAlerts:             true,
+				DependabotSecurityUpdates:    true,
+			},
+		},
+		{
+			name: "repository fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositorySecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings RepositorySecuritySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
+
+func Test_UpdateRepositorySecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepositorySecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "advanced_security")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_push_protection")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_validity_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "dependabot_alerts")
+	assert.Contains(t, tool.InputSchema.Properties, "dependabot_security_updates")
+	assert.Contains(t, tool.InputSchema.Properties, "private_vulnerability_reporting")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "enable push protection and Dependabot",
+			mockedClient: mock.NewMockedHTTPClient(append(securitySettingsReadMocks(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"security_and_analysis": map[string]any{
+							"secret_scanning":                 map[string]any{"status": "enabled"},
+							"secret_scanning_push_protection": map[string]any{"status": "enabled"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{Name: github.Ptr("repo")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposVulnerabilityAlertsByOwnerByRepo,
+					noContent,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposAutomatedSecurityFixesByOwnerByRepo,
+					noContent,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPrivateVulnerabilityReportingByOwnerByRepo,
+					noContent,
+				),
+			)...),
+			requestArgs: map[string]interface{}{
+				"owner":                           "owner",
+				"repo":                            "repo",
+				"secret_scanning":                 true,
+				"secret_scanning_push_protection": true,
+				"dependabot_alerts":               true,
+				"dependabot_security_updates":     true,
+				"private_vulnerability_reporting": true,
+			},
+			expectError: false,
+		},
+		{
+			name: "disable Dependabot security updates",
+			mockedClient: mock.NewMockedHTTPClient(append(securitySettingsReadMocks(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutomatedSecurityFixesByOwnerByRepo,
+					noContent,
+				),
+			)...),
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"dependabot_security_updates": false,
+			},
+			expectError: false,
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Secret scanning is not available for this repository"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"secret_scanning": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update security and analysis settings",
+		},
+		{
+			name:         "no settings provided",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one security setting must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepositorySecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings RepositorySecuritySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, "enabled", settings.SecretScanning)
+		})
+	}
+}
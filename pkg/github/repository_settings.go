@@ -0,0 +1,530 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxSettingsProfileRepositories is the number of repositories apply_repository_settings_profile applies a profile
+// to in one call, which keeps a call within the hourly rate limit.
+const maxSettingsProfileRepositories = 200
+
+// RepositorySettingsProfile is the settings a set of repositories must have. Settings that are not set are left
+// as they are.
+type RepositorySettingsProfile struct {
+	AllowMergeCommit             *bool                    `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge             *bool                    `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge             *bool                    `json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge               *bool                    `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge          *bool                    `json:"delete_branch_on_merge,omitempty"`
+	Topics                       *[]string                `json:"topics,omitempty"`
+	SecretScanning               *bool                    `json:"secret_scanning,omitempty"`
+	SecretScanningPushProtection *bool                    `json:"secret_scanning_push_protection,omitempty"`
+	DependabotAlerts             *bool                    `json:"dependabot_alerts,omitempty"`
+	DependabotSecurityUpdates    *bool                    `json:"dependabot_security_updates,omitempty"`
+	DefaultBranchProtection      *BranchProtectionProfile `json:"default_branch_protection,omitempty"`
+}
+
+// BranchProtectionProfile is the protection the default branch of a set of repositories must have. Settings that
+// are not set keep the branch's current protection.
+type BranchProtectionProfile struct {
+	RequiredApprovingReviewCount *int      `json:"required_approving_review_count,omitempty"`
+	RequireCodeOwnerReviews      *bool     `json:"require_code_owner_reviews,omitempty"`
+	DismissStaleReviews          *bool     `json:"dismiss_stale_reviews,omitempty"`
+	EnforceAdmins                *bool     `json:"enforce_admins,omitempty"`
+	RequiredStatusChecks         *[]string `json:"required_status_checks,omitempty"`
+	StrictStatusChecks           *bool     `json:"strict_status_checks,omitempty"`
+}
+
+// SettingChange is a setting of a repository that differs from the profile.
+type SettingChange struct {
+	Setting string `json:"setting"`
+	Current any    `json:"current"`
+	Desired any    `json:"desired"`
+}
+
+// RepositorySettingsResult is the outcome of applying a settings profile to a repository.
+type RepositorySettingsResult struct {
+	Repository string          `json:"repository"`
+	Changes    []SettingChange `json:"changes"`
+	Applied    bool            `json:"applied"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// repositorySettingsProfileSchema is the JSON schema of RepositorySettingsProfile.
+var repositorySettingsProfileSchema = map[string]any{
+	"allow_merge_commit":              map[string]any{"type": "boolean", "description": "Allow merge commits"},
+	"allow_squash_merge":              map[string]any{"type": "boolean", "description": "Allow squash merging"},
+	"allow_rebase_merge":              map[string]any{"type": "boolean", "description": "Allow rebase merging"},
+	"allow_auto_merge":                map[string]any{"type": "boolean", "description": "Allow auto-merge"},
+	"delete_branch_on_merge":          map[string]any{"type": "boolean", "description": "Delete head branches when pull requests are merged"},
+	"topics":                          map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Topics, replacing the current ones"},
+	"secret_scanning":                 map[string]any{"type": "boolean", "description": "Enable secret scanning"},
+	"secret_scanning_push_protection": map[string]any{"type": "boolean", "description": "Enable secret scanning push protection"},
+	"dependabot_alerts":               map[string]any{"type": "boolean", "description": "Enable Dependabot alerts"},
+	"dependabot_security_updates":     map[string]any{"type": "boolean", "description": "Enable Dependabot security updates"},
+	"default_branch_protection": map[string]any{
+		"type":        "object",
+		"description": "Protection of the default branch. Protection settings not given are kept",
+		"properties": map[string]any{
+			"required_approving_review_count": map[string]any{"type": "number", "minimum": 0, "maximum": 6, "description": "Number of approving reviews required to merge"},
+			"require_code_owner_reviews":      map[string]any{"type": "boolean", "description": "Require a review from code owners"},
+			"dismiss_stale_reviews":           map[string]any{"type": "boolean", "description": "Dismiss approvals when new commits are pushed"},
+			"enforce_admins":                  map[string]any{"type": "boolean", "description": "Enforce the protection for administrators"},
+			"required_status_checks":          map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Status checks required to pass before merging"},
+			"strict_status_checks":            map[string]any{"type": "boolean", "description": "Require branches to be up to date before merging"},
+		},
+	},
+}
+
+// parseRepositorySettingsProfile converts the profile argument of a tool call, rejecting unknown settings so that
+// a misspelled setting isn't silently ignored.
+func parseRepositorySettingsProfile(arg any) (*RepositorySettingsProfile, error) {
+	raw, err := json.Marshal(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	profile := &RepositorySettingsProfile{}
+	if err := decoder.Decode(profile); err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+	if bp := profile.DefaultBranchProtection; bp != nil && bp.RequiredApprovingReviewCount != nil &&
+		(*bp.RequiredApprovingReviewCount < 0 || *bp.RequiredApprovingReviewCount > 6) {
+		return nil, fmt.Errorf("invalid profile: required_approving_review_count must be between 0 and 6")
+	}
+	if *profile == (RepositorySettingsProfile{}) {
+		return nil, fmt.Errorf("the profile must contain at least one setting")
+	}
+	return profile, nil
+}
+
+// protectionRequest converts the protection of a branch to the request that keeps it unchanged.
+func protectionRequest(protection *github.Protection) *github.ProtectionRequest {
+	request := &github.ProtectionRequest{}
+	if protection == nil {
+		return request
+	}
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: checks.Strict, Checks: checks.Checks}
+		if checks.Checks == nil {
+			request.RequiredStatusChecks.Contexts = checks.Contexts
+		}
+	}
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+		if restrictions := reviews.DismissalRestrictions; restrictions != nil {
+			users, teams, apps := actorNames(restrictions.Users, restrictions.Teams, restrictions.Apps)
+			request.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{Users: &users, Teams: &teams, Apps: &apps}
+		}
+		if allowances := reviews.BypassPullRequestAllowances; allowances != nil {
+			users, teams, apps := actorNames(allowances.Users, allowances.Teams, allowances.Apps)
+			request.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{Users: users, Teams: teams, Apps: apps}
+		}
+	}
+	if enforceAdmins := protection.EnforceAdmins; enforceAdmins != nil {
+		request.EnforceAdmins = enforceAdmins.Enabled
+	}
+	if restrictions := protection.Restrictions; restrictions != nil {
+		users, teams, apps := actorNames(restrictions.Users, restrictions.Teams, restrictions.Apps)
+		request.Restrictions = &github.BranchRestrictionsRequest{Users: users, Teams: teams, Apps: apps}
+	}
+	if setting := protection.RequireLinearHistory; setting != nil {
+		request.RequireLinearHistory = github.Ptr(setting.Enabled)
+	}
+	if setting := protection.AllowForcePushes; setting != nil {
+		request.AllowForcePushes = github.Ptr(setting.Enabled)
+	}
+	if setting := protection.AllowDeletions; setting != nil {
+		request.AllowDeletions = github.Ptr(setting.Enabled)
+	}
+	if setting := protection.RequiredConversationResolution; setting != nil {
+		request.RequiredConversationResolution = github.Ptr(setting.Enabled)
+	}
+	if setting := protection.BlockCreations; setting != nil {
+		request.BlockCreations = setting.Enabled
+	}
+	if setting := protection.LockBranch; setting != nil {
+		request.LockBranch = setting.Enabled
+	}
+	if setting := protection.AllowForkSyncing; setting != nil {
+		request.AllowForkSyncing = setting.Enabled
+	}
+	return request
+}
+
+// actorNames returns the user logins, team slugs and app slugs a protection setting applies to, as non-nil slices
+// so that they serialize as empty lists.
+func actorNames(users []*github.User, teams []*github.Team, apps []*github.App) ([]string, []string, []string) {
+	userLogins, teamSlugs, appSlugs := []string{}, []string{}, []string{}
+	for _, user := range users {
+		userLogins = append(userLogins, user.GetLogin())
+	}
+	for _, team := range teams {
+		teamSlugs = append(teamSlugs, team.GetSlug())
+	}
+	for _, app := range apps {
+		appSlugs = append(appSlugs, app.GetSlug())
+	}
+	return userLogins, teamSlugs, appSlugs
+}
+
+// statusCheckNames returns the names of the status checks a branch protection requires, sorted.
+func statusCheckNames(checks *github.RequiredStatusChecks) []string {
+	names := []string{}
+	if checks == nil {
+		return names
+	}
+	if checks.Checks != nil {
+		for _, check := range *checks.Checks {
+			names = append(names, check.Context)
+		}
+	} else if checks.Contexts != nil {
+		names = append(names, *checks.Contexts...)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// planBranchProtection returns the changes the profile makes to the protection of a branch, and the request that
+// applies them.
+func planBranchProtection(protection *github.Protection, profile *BranchProtectionProfile) ([]SettingChange, *github.ProtectionRequest) {
+	request := protectionRequest(protection)
+	var changes []SettingChange
+
+	if profile.RequiredApprovingReviewCount != nil || profile.RequireCodeOwnerReviews != nil || profile.DismissStaleReviews != nil {
+		current := request.RequiredPullRequestReviews
+		if current == nil {
+			current = &github.PullRequestReviewsEnforcementRequest{}
+		}
+		desired := *current
+		if profile.RequiredApprovingReviewCount != nil {
+			desired.RequiredApprovingReviewCount = *profile.RequiredApprovingReviewCount
+		}
+		if profile.RequireCodeOwnerReviews != nil {
+			desired.RequireCodeOwnerReviews = *profile.RequireCodeOwnerReviews
+		}
+		if profile.DismissStaleReviews != nil {
+			desired.DismissStaleReviews = *profile.DismissStaleReviews
+		}
+		if request.RequiredPullRequestReviews == nil && profile.RequiredApprovingReviewCount != nil {
+			changes = append(changes, SettingChange{"default_branch_protection.required_approving_review_count", nil, desired.RequiredApprovingReviewCount})
+		} else if desired.RequiredApprovingReviewCount != current.RequiredApprovingReviewCount {
+			changes = append(changes, SettingChange{"default_branch_protection.required_approving_review_count", current.RequiredApprovingReviewCount, desired.RequiredApprovingReviewCount})
+		}
+		if desired.RequireCodeOwnerReviews != current.RequireCodeOwnerReviews {
+			changes = append(changes, SettingChange{"default_branch_protection.require_code_owner_reviews", current.RequireCodeOwnerReviews, desired.RequireCodeOwnerReviews})
+		}
+		if desired.DismissStaleReviews != current.DismissStaleReviews {
+			changes = append(changes, SettingChange{"default_branch_protection.dismiss_stale_reviews", current.DismissStaleReviews, desired.DismissStaleReviews})
+		}
+		request.RequiredPullRequestReviews = &desired
+	}
+
+	if profile.EnforceAdmins != nil && *profile.EnforceAdmins != request.EnforceAdmins {
+		changes = append(changes, SettingChange{"default_branch_protection.enforce_admins", request.EnforceAdmins, *profile.EnforceAdmins})
+		request.EnforceAdmins = *profile.EnforceAdmins
+	}
+
+	if profile.RequiredStatusChecks != nil || profile.StrictStatusChecks != nil {
+		var currentChecks []string
+		currentStrict := false
+		if request.RequiredStatusChecks != nil {
+			currentChecks = statusCheckNames(request.RequiredStatusChecks)
+			currentStrict = request.RequiredStatusChecks.Strict
+		}
+		desiredChecks := currentChecks
+		if profile.RequiredStatusChecks != nil {
+			desiredChecks = slices.Clone(*profile.RequiredStatusChecks)
+			sort.Strings(desiredChecks)
+		}
+		desiredStrict := currentStrict
+		if profile.StrictStatusChecks != nil {
+			desiredStrict = *profile.StrictStatusChecks
+		}
+		if request.RequiredStatusChecks == nil || !slices.Equal(currentChecks, desiredChecks) {
+			changes = append(changes, SettingChange{"default_branch_protection.required_status_checks", currentChecks, desiredChecks})
+		}
+		if desiredStrict != currentStrict {
+			changes = append(changes, SettingChange{"default_branch_protection.strict_status_checks", currentStrict, desiredStrict})
+		}
+		if desiredChecks == nil {
+			desiredChecks = []string{}
+		}
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: desiredStrict, Contexts: &desiredChecks}
+	}
+	return changes, request
+}
+
+// applySettingsProfile compares a repository with a settings profile, and unless dryRun is set, changes the
+// settings that differ.
+func applySettingsProfile(ctx context.Context, client *github.Client, owner, repo string, profile *RepositorySettingsProfile, dryRun bool) ([]SettingChange, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	changes := []SettingChange{}
+	edit := &github.Repository{}
+	editNeeded := false
+	for _, setting := range []struct {
+		name    string
+		desired *bool
+		current bool
+		field   **bool
+	}{
+		{"allow_merge_commit", profile.AllowMergeCommit, repository.GetAllowMergeCommit(), &edit.AllowMergeCommit},
+		{"allow_squash_merge", profile.AllowSquashMerge, repository.GetAllowSquashMerge(), &edit.AllowSquashMerge},
+		{"allow_rebase_merge", profile.AllowRebaseMerge, repository.GetAllowRebaseMerge(), &edit.AllowRebaseMerge},
+		{"allow_auto_merge", profile.AllowAutoMerge, repository.GetAllowAutoMerge(), &edit.AllowAutoMerge},
+		{"delete_branch_on_merge", profile.DeleteBranchOnMerge, repository.GetDeleteBranchOnMerge(), &edit.DeleteBranchOnMerge},
+	} {
+		if setting.desired != nil && *setting.desired != setting.current {
+			changes = append(changes, SettingChange{setting.name, setting.current, *setting.desired})
+			*setting.field = setting.desired
+			editNeeded = true
+		}
+	}
+
+	var topics []string
+	if profile.Topics != nil {
+		current := slices.Clone(repository.Topics)
+		sort.Strings(current)
+		topics = slices.Clone(*profile.Topics)
+		sort.Strings(topics)
+		if !slices.Equal(current, topics) {
+			if current == nil {
+				current = []string{}
+			}
+			changes = append(changes, SettingChange{"topics", current, topics})
+		} else {
+			topics = nil
+		}
+	}
+
+	var dependabotAlerts, securityUpdates *bool
+	if profile.SecretScanning != nil || profile.SecretScanningPushProtection != nil || profile.DependabotAlerts != nil || profile.DependabotSecurityUpdates != nil {
+		security, msg, _, err := getRepositorySecuritySettings(ctx, client, owner, repo) //nolint:bodyclose // Response bodies are closed in getRepositorySecuritySettings
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", msg, err)
+		}
+		analysis := &github.SecurityAndAnalysis{}
+		if desired := profile.SecretScanning; desired != nil && *securityAnalysisStatus(*desired) != security.SecretScanning {
+			changes = append(changes, SettingChange{"secret_scanning", security.SecretScanning, *securityAnalysisStatus(*desired)})
+			analysis.SecretScanning = &github.SecretScanning{Status: securityAnalysisStatus(*desired)}
+			edit.SecurityAndAnalysis = analysis
+			editNeeded = true
+		}
+		if desired := profile.SecretScanningPushProtection; desired != nil && *securityAnalysisStatus(*desired) != security.SecretScanningPushProtection {
+			changes = append(changes, SettingChange{"secret_scanning_push_protection", security.SecretScanningPushProtection, *securityAnalysisStatus(*desired)})
+			analysis.SecretScanningPushProtection = &github.SecretScanningPushProtection{Status: securityAnalysisStatus(*desired)}
+			edit.SecurityAndAnalysis = analysis
+			editNeeded = true
+		}
+		if desired := profile.DependabotAlerts; desired != nil && *desired != security.DependabotAlerts {
+			changes = append(changes, SettingChange{"dependabot_alerts", security.DependabotAlerts, *desired})
+			dependabotAlerts = desired
+		}
+		if desired := profile.DependabotSecurityUpdates; desired != nil && *desired != security.DependabotSecurityUpdates {
+			changes = append(changes, SettingChange{"dependabot_security_updates", security.DependabotSecurityUpdates, *desired})
+			securityUpdates = desired
+		}
+	}
+
+	var protection *github.ProtectionRequest
+	if profile.DefaultBranchProtection != nil {
+		current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, repository.GetDefaultBranch())
+		if err != nil && !errors.Is(err, github.ErrBranchNotProtected) {
+			return nil, fmt.Errorf("failed to get protection of branch %s: %w", repository.GetDefaultBranch(), err)
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		protectionChanges, request := planBranchProtection(current, profile.DefaultBranchProtection)
+		if len(protectionChanges) > 0 {
+			changes = append(changes, protectionChanges...)
+			protection = request
+		}
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	// Dependabot security updates depend on Dependabot alerts, so alerts are enabled first and disabled last.
+	if dependabotAlerts != nil && *dependabotAlerts {
+		resp, err := client.Repositories.EnableVulnerabilityAlerts(ctx, owner, repo)
+		if err != nil {
+			return changes, fmt.Errorf("failed to enable Dependabot alerts: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if editNeeded {
+		_, resp, err := client.Repositories.Edit(ctx, owner, repo, edit)
+		if err != nil {
+			return changes, fmt.Errorf("failed to update repository settings: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if topics != nil {
+		_, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+		if err != nil {
+			return changes, fmt.Errorf("failed to replace topics: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if securityUpdates != nil {
+		var resp *github.Response
+		if *securityUpdates {
+			resp, err = client.Repositories.EnableAutomatedSecurityFixes(ctx, owner, repo)
+		} else {
+			resp, err = client.Repositories.DisableAutomatedSecurityFixes(ctx, owner, repo)
+		}
+		if err != nil {
+			return changes, fmt.Errorf("failed to update Dependabot security updates: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if dependabotAlerts != nil && !*dependabotAlerts {
+		resp, err := client.Repositories.DisableVulnerabilityAlerts(ctx, owner, repo)
+		if err != nil {
+			return changes, fmt.Errorf("failed to disable Dependabot alerts: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if protection != nil {
+		_, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, repository.GetDefaultBranch(), protection)
+		if err != nil {
+			return changes, fmt.Errorf("failed to update protection of branch %s: %w", repository.GetDefaultBranch(), err)
+		}
+		_ = resp.Body.Close()
+	}
+	return changes, nil
+}
+
+// ApplyRepositorySettingsProfile creates a tool to enforce a settings profile across a set of repositories.
+func ApplyRepositorySettingsProfile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_repository_settings_profile",
+			mcp.WithDescription(t("TOOL_APPLY_REPOSITORY_SETTINGS_PROFILE_DESCRIPTION", "Apply a settings profile, covering merge options, topics, security features and the protection of the default branch, to a list of repositories of an owner. Only the settings in the profile are changed. Defaults to a dry run that reports the changes each repository needs without making them; set dry_run to false to apply them. Returns the changes and the outcome for every repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_REPOSITORY_SETTINGS_PROFILE_USER_TITLE", "Apply repository settings profile"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Names of the repositories of the owner to apply the profile to, at most %d", maxSettingsProfileRepositories)),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithObject("profile",
+				mcp.Required(),
+				mcp.Description("The settings the repositories must have. Settings that are not given are left unchanged"),
+				mcp.Properties(repositorySettingsProfileSchema),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only report the changes each repository needs, without making them (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(repositories) > maxSettingsProfileRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be given", maxSettingsProfileRepositories)), nil
+			}
+			rawProfile, ok := request.GetArguments()["profile"]
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: profile"), nil
+			}
+			profile, err := parseRepositorySettingsProfile(rawProfile)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, err := OptionalBoolParamWithDefault(request, "dry_run", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := make([]RepositorySettingsResult, len(repositories))
+			errs := fanOut(ctx, repositories, DefaultFanOutConcurrency, func(ctx context.Context, i int, repo string) error {
+				changes, err := applySettingsProfile(ctx, client, owner, repo, profile, dryRun)
+				results[i].Changes = changes
+				if err == nil {
+					results[i].Applied = !dryRun && len(changes) > 0
+				}
+				return err
+			})
+			for i, repo := range repositories {
+				results[i].Repository = owner + "/" + repo
+				if results[i].Changes == nil {
+					results[i].Changes = []SettingChange{}
+				}
+				if errs[i] != nil {
+					results[i].Error = errs[i].Error()
+				}
+			}
+
+			compliant, changed, failed := 0, 0, 0
+			for _, result := range results {
+				switch {
+				case result.Error != "":
+					failed++
+				case len(result.Changes) == 0:
+					compliant++
+				default:
+					changed++
+				}
+			}
+			summary := map[string]int{
+				"repositories": len(results),
+				"compliant":    compliant,
+				"failed":       failed,
+			}
+			if dryRun {
+				summary["would_change"] = changed
+			} else {
+				summary["changed"] = changed
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"dry_run":      dryRun,
+				"summary":      summary,
+				"repositories": results,
+			}), nil
+		}
+}
@@ -0,0 +1,298 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApplyRepositorySettingsProfile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyRepositorySettingsProfile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_repository_settings_profile", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "profile")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repositories", "profile"})
+
+	repositories := map[string]*github.Repository{
+		"/repos/org/api": {
+			Name:                github.Ptr("api"),
+			DefaultBranch:       github.Ptr("main"),
+			AllowMergeCommit:    github.Ptr(true),
+			DeleteBranchOnMerge: github.Ptr(false),
+			Topics:              []string{"go"},
+		},
+		"/repos/org/web": {
+			Name:                github.Ptr("web"),
+			DefaultBranch:       github.Ptr("trunk"),
+			AllowMergeCommit:    github.Ptr(false),
+			DeleteBranchOnMerge: github.Ptr(true),
+			Topics:              []string{"service", "go"},
+		},
+	}
+	protections := map[string]*github.Protection{
+		"/repos/org/api/branches/main/protection": {
+			RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true, Contexts: &[]string{"build"}},
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+				RequiredApprovingReviewCount: 1,
+				DismissStaleReviews:          true,
+			},
+			EnforceAdmins:        &github.AdminEnforcement{Enabled: false},
+			RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+			Restrictions: &github.BranchRestrictions{
+				Users: []*github.User{{Login: github.Ptr("alice")}},
+				Teams: []*github.Team{{Slug: github.Ptr("release")}},
+			},
+		},
+		"/repos/org/web/branches/trunk/protection": {
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+			EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+		},
+	}
+	alertsEnabled := map[string]bool{
+		"/repos/org/api/vulnerability-alerts": false,
+		"/repos/org/web/vulnerability-alerts": true,
+	}
+
+	var mu sync.Mutex
+	var writes []string
+	recordWrite := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		writes = append(writes, r.Method+" "+r.URL.Path)
+	}
+	noContent := func(w http.ResponseWriter, r *http.Request) {
+		recordWrite(r)
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				repo, ok := repositories[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, repo)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if alertsEnabled[r.URL.Path] {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Vulnerability alerts are disabled."}`))
+			}),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+			&github.AutomatedSecurityFixes{Enabled: github.Ptr(false)},
+			&github.AutomatedSecurityFixes{Enabled: github.Ptr(false)},
+			&github.AutomatedSecurityFixes{Enabled: github.Ptr(false)},
+			&github.AutomatedSecurityFixes{Enabled: github.Ptr(false)},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+			map[string]bool{"enabled": false},
+			map[string]bool{"enabled": false},
+			map[string]bool{"enabled": false},
+			map[string]bool{"enabled": false},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, protections[r.URL.Path])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PatchReposByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"allow_merge_commit":     false,
+				"delete_branch_on_merge": true,
+			}).andThen(func(w http.ResponseWriter, r *http.Request) {
+				recordWrite(r)
+				mockResponse(t, http.StatusOK, repositories["/repos/org/api"])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PutReposTopicsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"names": []any{"go", "service"},
+			}).andThen(func(w http.ResponseWriter, r *http.Request) {
+				recordWrite(r)
+				mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "service"}})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PutReposVulnerabilityAlertsByOwnerByRepo,
+			http.HandlerFunc(noContent),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				recordWrite(r)
+				var body github.ProtectionRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				// The profile's settings are changed, and the rest of the protection is kept.
+				assert.Equal(t, 2, body.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+				assert.True(t, body.RequiredPullRequestReviews.DismissStaleReviews)
+				assert.True(t, body.EnforceAdmins)
+				assert.Equal(t, &[]string{"build"}, body.RequiredStatusChecks.Contexts)
+				assert.True(t, body.RequiredStatusChecks.Strict)
+				assert.True(t, body.GetRequireLinearHistory())
+				assert.Equal(t, []string{"alice"}, body.Restrictions.Users)
+				assert.Equal(t, []string{"release"}, body.Restrictions.Teams)
+				mockResponse(t, http.StatusOK, &github.Protection{})(w, r)
+			}),
+		),
+	))
+
+	profile := map[string]any{
+		"allow_merge_commit":     false,
+		"delete_branch_on_merge": true,
+		"topics":                 []any{"service", "go"},
+		"dependabot_alerts":      true,
+		"default_branch_protection": map[string]any{
+			"required_approving_review_count": float64(2),
+			"enforce_admins":                  true,
+		},
+	}
+	apiChanges := []SettingChange{
+		{Setting: "allow_merge_commit", Current: true, Desired: false},
+		{Setting: "delete_branch_on_merge", Current: false, Desired: true},
+		{Setting: "topics", Current: []any{"go"}, Desired: []any{"go", "service"}},
+		{Setting: "dependabot_alerts", Current: false, Desired: true},
+		{Setting: "default_branch_protection.required_approving_review_count", Current: float64(1), Desired: float64(2)},
+		{Setting: "default_branch_protection.enforce_admins", Current: false, Desired: true},
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedResults []RepositorySettingsResult
+		expectedSummary map[string]int
+		expectedWrites  []string
+	}{
+		{
+			name: "dry run",
+			requestArgs: map[string]any{
+				"owner":        "org",
+				"repositories": []any{"api", "web", "missing"},
+				"profile":      profile,
+			},
+			expectedResults: []RepositorySettingsResult{
+				{Repository: "org/api", Changes: apiChanges},
+				{Repository: "org/web", Changes: []SettingChange{}},
+				{Repository: "org/missing", Changes: []SettingChange{}, Error: "failed to get repository"},
+			},
+			expectedSummary: map[string]int{"repositories": 3, "compliant": 1, "would_change": 1, "failed": 1},
+		},
+		{
+			name: "apply",
+			requestArgs: map[string]any{
+				"owner":        "org",
+				"repositories": []any{"api", "web"},
+				"profile":      profile,
+				"dry_run":      false,
+			},
+			expectedResults: []RepositorySettingsResult{
+				{Repository: "org/api", Changes: apiChanges, Applied: true},
+				{Repository: "org/web", Changes: []SettingChange{}},
+			},
+			expectedSummary: map[string]int{"repositories": 2, "compliant": 1, "changed": 1, "failed": 0},
+			expectedWrites: []string{
+				"PUT /repos/org/api/vulnerability-alerts",
+				"PATCH /repos/org/api",
+				"PUT /repos/org/api/topics",
+				"PUT /repos/org/api/branches/main/protection",
+			},
+		},
+		{
+			name: "unknown setting",
+			requestArgs: map[string]any{
+				"owner":        "org",
+				"repositories": []any{"api"},
+				"profile":      map[string]any{"allow_merge_commits": false},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid profile: json: unknown field "allow_merge_commits"`,
+		},
+		{
+			name: "empty profile",
+			requestArgs: map[string]any{
+				"owner":        "org",
+				"repositories": []any{"api"},
+				"profile":      map[string]any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "the profile must contain at least one setting",
+		},
+		{
+			name: "missing repositories",
+			requestArgs: map[string]any{
+				"owner":   "org",
+				"profile": profile,
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writes = nil
+			_, handler := ApplyRepositorySettingsProfile(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				DryRun       bool                       `json:"dry_run"`
+				Summary      map[string]int             `json:"summary"`
+				Repositories []RepositorySettingsResult `json:"repositories"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.requestArgs["dry_run"] == nil, response.DryRun)
+			assert.Equal(t, tc.expectedSummary, response.Summary)
+			for i, result := range response.Repositories {
+				if result.Error != "" {
+					assert.Contains(t, result.Error, tc.expectedResults[i].Error)
+					response.Repositories[i].Error = tc.expectedResults[i].Error
+				}
+			}
+			assert.Equal(t, tc.expectedResults, response.Repositories)
+			assert.Equal(t, tc.expectedWrites, writes)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalStargazer is a user who starred a repository, with when they starred it.
+type MinimalStargazer struct {
+	MinimalUser
+	StarredAt string `json:"starred_at,omitempty"`
+}
+
+// ListStargazers creates a tool to list the users who starred a repository.
+func ListStargazers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_stargazers",
+			mcp.WithDescription(t("TOOL_LIST_STARGAZERS_DESCRIPTION", "List the users who starred a repository with when they starred it, oldest stars first. Use it to analyze the star growth of a repository over time.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_STARGAZERS_USER_TITLE", "List stargazers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stargazers, resp, err := client.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list stargazers of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			minimalStargazers := make([]MinimalStargazer, 0, len(stargazers))
+			for _, stargazer := range stargazers {
+				user := stargazer.GetUser()
+				if user == nil {
+					continue
+				}
+				minimalStargazers = append(minimalStargazers, MinimalStargazer{
+					MinimalUser: *convertToMinimalUser(user),
+					StarredAt:   formatOptionalTimestamp(stargazer.StarredAt),
+				})
+			}
+			return MarshalledTextResult(ctx, minimalStargazers), nil
+		}
+}
+
+// ListWatchers creates a tool to list the users watching a repository.
+func ListWatchers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watchers",
+			mcp.WithDescription(t("TOOL_LIST_WATCHERS_DESCRIPTION", "List the users watching a repository, i.e. getting all its notifications. These are the subscribers of the repository, not its stargazers, which the API also counts as watchers_count.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WATCHERS_USER_TITLE", "List watchers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			watchers, resp, err := client.Activity.ListWatchers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list watchers of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			minimalWatchers := make([]MinimalUser, 0, len(watchers))
+			for _, watcher := range watchers {
+				if user := convertToMinimalUser(watcher); user != nil {
+					minimalWatchers = append(minimalWatchers, *user)
+				}
+			}
+			return MarshalledTextResult(ctx, minimalWatchers), nil
+		}
+}
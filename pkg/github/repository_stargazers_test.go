@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStargazers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStargazers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stargazers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	stargazers := []*github.Stargazer{
+		{
+			StarredAt: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+			User:      &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+		},
+		{
+			StarredAt: &github.Timestamp{Time: time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)},
+			User:      &github.User{Login: github.Ptr("hubot"), ID: github.Ptr(int64(2))},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedStargazers []MinimalStargazer
+	}{
+		{
+			name: "stargazers with starred_at",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"page": "3", "per_page": "2"}).andThen(
+						mockResponse(t, http.StatusOK, stargazers),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "octo-org", "repo": "web", "page": float64(3), "perPage": float64(2)},
+			expectedStargazers: []MinimalStargazer{
+				{MinimalUser: MinimalUser{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"}, StarredAt: "2025-01-02T03:04:05Z"},
+				{MinimalUser: MinimalUser{Login: "hubot", ID: 2}, StarredAt: "2025-02-03T04:05:06Z"},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org", "repo": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list stargazers of octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListStargazers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned []MinimalStargazer
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedStargazers, returned)
+		})
+	}
+}
+
+func Test_ListWatchers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_watchers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedWatchers []MinimalUser
+	}{
+		{
+			name: "watchers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscribersByOwnerByRepo,
+					[]*github.User{
+						{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1")},
+					},
+				),
+			),
+			expectedWatchers: []MinimalUser{
+				{Login: "octocat", ID: 1, AvatarURL: "https://avatars.githubusercontent.com/u/1"},
+			},
+		},
+		{
+			name: "no watchers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscribersByOwnerByRepo,
+					[]*github.User{},
+				),
+			),
+			expectedWatchers: []MinimalUser{},
+		},
+		{
+			name: "api error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscribersByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "boom"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list watchers of octo-org/web",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWatchers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "octo-org",
+				"repo":  "web",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned []MinimalUser
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedWatchers, returned)
+		})
+	}
+}
@@ -0,0 +1,553 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// statsComputingResult is returned when GitHub answers a statistics request with 202 Accepted,
+// which means the statistics are being computed in the background.
+func statsComputingResult(owner, repo string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("GitHub is computing statistics for %s/%s, retry in a few seconds", owner, repo))
+}
+
+// WeeklyCommits is the number of commits per day of a week, starting on Sunday.
+type WeeklyCommits struct {
+	Week  string `json:"week"`
+	Days  []int  `json:"days"`
+	Total int    `json:"total"`
+}
+
+// CommitActivity is the compact commit activity of a repository.
+type CommitActivity struct {
+	// PunchCard holds the number of commits per hour of the day, indexed by [day][hour] with Sunday as day 0.
+	PunchCard     [7][24]int      `json:"punch_card"`
+	BusiestDay    string          `json:"busiest_day"`
+	BusiestHour   int             `json:"busiest_hour"`
+	WeeklyCommits []WeeklyCommits `json:"weekly_commits"`
+	TotalCommits  int             `json:"total_commits"`
+}
+
+// GetCommitActivity creates a tool to get the punch card and weekly commit activity of a repository.
+func GetCommitActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_activity",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_ACTIVITY_DESCRIPTION", "Get when a repository is most active: an hourly punch card of commits per day of the week and hour of the day, and the number of commits per day over the last weeks.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_ACTIVITY_USER_TITLE", "Get commit activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("weeks",
+				mcp.Description("Number of most recent weeks of commit activity to return (max 52). Defaults to 12."),
+				mcp.Min(1),
+				mcp.Max(52),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParamWithDefault(request, "weeks", 12)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if weeks < 1 || weeks > 52 {
+				return mcp.NewToolResultError("weeks must be between 1 and 52"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			cards, resp, err := client.Repositories.ListPunchCard(ctx, owner, repo)
+			if err != nil {
+				if isAcceptedError(err) {
+					return statsComputingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get punch card", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			weekly, resp, err := client.Repositories.ListCommitActivity(ctx, owner, repo)
+			if err != nil {
+				if isAcceptedError(err) {
+					return statsComputingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get commit activity", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			activity := CommitActivity{WeeklyCommits: []WeeklyCommits{}}
+			dayTotals := [7]int{}
+			hourTotals := [24]int{}
+			for _, card := range cards {
+				day, hour := card.GetDay(), card.GetHour()
+				if day < 0 || day > 6 || hour < 0 || hour > 23 {
+					continue
+				}
+				activity.PunchCard[day][hour] = card.GetCommits()
+				dayTotals[day] += card.GetCommits()
+				hourTotals[hour] += card.GetCommits()
+			}
+			busiestDay := 0
+			for day := range dayTotals {
+				if dayTotals[day] > dayTotals[busiestDay] {
+					busiestDay = day
+				}
+			}
+			activity.BusiestDay = time.Weekday(busiestDay).String()
+			for hour := range hourTotals {
+				if hourTotals[hour] > hourTotals[activity.BusiestHour] {
+					activity.BusiestHour = hour
+				}
+			}
+
+			// The API returns the last year of activity, oldest week first.
+			for _, week := range lastWeeks(weekly, weeks) {
+				activity.WeeklyCommits = append(activity.WeeklyCommits, WeeklyCommits{
+					Week:  week.GetWeek().Format(time.DateOnly),
+					Days:  week.Days,
+					Total: week.GetTotal(),
+				})
+				activity.TotalCommits += week.GetTotal()
+			}
+
+			return MarshalledTextResult(ctx, activity), nil
+		}
+}
+
+// ContributorActivity is the activity of a single contributor over a time window.
+type ContributorActivity struct {
+	Login     string `json:"login"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// DirectoryOwnership estimates how concentrated the knowledge of a directory is. The bus factor is the
+// smallest number of authors who together made more than half of the commits touching the directory.
+type DirectoryOwnership struct {
+	Directory  string                `json:"directory"`
+	Commits    int                   `json:"commits"`
+	BusFactor  int                   `json:"bus_factor"`
+	TopAuthors []ContributorActivity `json:"top_authors"`
+	Truncated  bool                  `json:"truncated,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// commitAuthorLogin returns the GitHub login of a commit author, falling back to the git author name
+// for commits not linked to a GitHub account.
+func commitAuthorLogin(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
+}
+
+// contributorStatistics sums the weekly statistics of every contributor since a time, most active first.
+// Contributors listed more than once, such as commits of deleted accounts which have no login, are merged.
+func contributorStatistics(stats []*github.ContributorStats, since time.Time) []ContributorStatistics {
+	contributors := []ContributorStatistics{}
+	index := map[string]int{}
+	for _, stat := range stats {
+		login := stat.GetAuthor().GetLogin()
+		i, ok := index[login]
+		if !ok {
+			i = len(contributors)
+			index[login] = i
+			contributors = append(contributors, ContributorStatistics{ContributorActivity: ContributorActivity{Login: login}})
+		}
+		contributors[i].TotalCommits += stat.GetTotal()
+		for _, week := range stat.Weeks {
+			if week.GetWeek().Before(since) {
+				continue
+			}
+			contributors[i].Commits += week.GetCommits()
+			contributors[i].Additions += week.GetAdditions()
+			contributors[i].Deletions += week.GetDeletions()
+		}
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return contributors[i].Login < contributors[j].Login
+	})
+	return contributors
+}
+
+// directoryOwnership counts the authors of the most recent commits touching dir since the given time.
+func directoryOwnership(ctx context.Context, client *github.Client, owner, repo, dir string, since time.Time, maxCommits int) DirectoryOwnership {
+	ownership := DirectoryOwnership{Directory: dir, TopAuthors: []ContributorActivity{}}
+	counts := map[string]int{}
+	opts := &github.CommitsListOptions{
+		Path:        dir,
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: min(maxCommits, 100)},
+	}
+	for {
+		commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if err != nil {
+			ownership.Error = err.Error()
+			return ownership
+		}
+		_ = resp.Body.Close()
+		for _, commit := range commits {
+			if ownership.Commits == maxCommits {
+				ownership.Truncated = true
+				break
+			}
+			counts[commitAuthorLogin(commit)]++
+			ownership.Commits++
+		}
+		if ownership.Truncated || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	authors := make([]ContributorActivity, 0, len(counts))
+	for login, commits := range counts {
+		authors = append(authors, ContributorActivity{Login: login, Commits: commits})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Login < authors[j].Login
+	})
+
+	covered := 0
+	for _, author := range authors {
+		if covered*2 > ownership.Commits {
+			break
+		}
+		covered += author.Commits
+		ownership.BusFactor++
+	}
+	ownership.TopAuthors = authors[:min(len(authors), 3)]
+	return ownership
+}
+
+// GetContributorInsights creates a tool to rank contributors and estimate the bus factor of directories.
+func GetContributorInsights(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contributor_insights",
+			mcp.WithDescription(t("TOOL_GET_CONTRIBUTOR_INSIGHTS_DESCRIPTION", "Get the top contributors of a repository over recent weeks, and a bus factor estimate for each subdirectory of a path: the smallest number of authors who made more than half of the commits touching it. A bus factor of 1 means a single person holds most of the knowledge.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTRIBUTOR_INSIGHTS_USER_TITLE", "Get contributor insights"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("weeks",
+				mcp.Description("Number of most recent weeks to analyze. Defaults to 12."),
+				mcp.Min(1),
+				mcp.Max(52),
+			),
+			mcp.WithString("path",
+				mcp.Description("Directory whose subdirectories are analyzed for bus factor. Defaults to the repository root."),
+			),
+			mcp.WithNumber("top",
+				mcp.Description("Number of contributors to return in the leaderboard. Defaults to 10."),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_commits_per_directory",
+				mcp.Description("Maximum number of recent commits to inspect per directory. Defaults to 100."),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := OptionalIntParamWithDefault(request, "weeks", 12)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if weeks < 1 || weeks > 52 {
+				return mcp.NewToolResultError("weeks must be between 1 and 52"), nil
+			}
+			dir, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			top, err := OptionalIntParamWithDefault(request, "top", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "max_commits_per_directory", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if top < 1 || maxCommits < 1 {
+				return mcp.NewToolResultError("top and max_commits_per_directory must be positive"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			since := time.Now().AddDate(0, 0, -7*weeks)
+
+			stats, resp, err := client.Repositories.ListContributorsStats(ctx, owner, repo)
+			if err != nil {
+				if isAcceptedError(err) {
+					return statsComputingResult(owner, repo), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get contributor statistics", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			leaderboard := make([]ContributorActivity, 0, top)
+			for _, contributor := range contributorStatistics(stats, since) {
+				if contributor.Commits == 0 || len(leaderboard) == top {
+					break
+				}
+				leaderboard = append(leaderboard, contributor.ContributorActivity)
+			}
+
+			_, contents, resp, err := client.Repositories.GetContents(ctx, owner, repo, dir, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list directory '%s'", dir), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			dirs := []string{}
+			for _, entry := range contents {
+				if entry.GetType() == "dir" {
+					dirs = append(dirs, entry.GetPath())
+				}
+			}
+			if len(dirs) == 0 {
+				// A directory without subdirectories is analyzed as a whole.
+				dirs = append(dirs, path.Clean("/" + dir)[1:])
+			}
+
+			ownership := make([]DirectoryOwnership, len(dirs))
+			errs := fanOut(ctx, dirs, DefaultFanOutConcurrency, func(ctx context.Context, i int, dir string) error {
+				ownership[i] = directoryOwnership(ctx, client, owner, repo, dir, since, maxCommits)
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					ownership[i] = DirectoryOwnership{Directory: dirs[i], TopAuthors: []ContributorActivity{}, Error: err.Error()}
+				}
+			}
+			sort.SliceStable(ownership, func(i, j int) bool {
+				return ownership[i].Commits > ownership[j].Commits
+			})
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"since":            since.Format(time.DateOnly),
+				"top_contributors": leaderboard,
+				"directories":      ownership,
+			}), nil
+		}
+}
+
+// ContributorStatistics is the activity of a contributor over a time window and their commits of all time.
+type ContributorStatistics struct {
+	ContributorActivity
+	TotalCommits int `json:"total_commits"`
+}
+
+// WeeklyCodeFrequency is the number of lines added and deleted in a repository in a week.
+type WeeklyCodeFrequency struct {
+	Week      string `json:"week"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// Participation is the number of commits per week, oldest first, by everyone and by the repository owner.
+type Participation struct {
+	All        []int `json:"all"`
+	Owner      []int `json:"owner"`
+	AllTotal   int   `json:"all_total"`
+	OwnerTotal int   `json:"owner_total"`
+}
+
+// RepositoryStats is the compact statistics of a repository over recent weeks. Computing lists the statistics
+// GitHub is still computing, which can be requested again in a few seconds.
+type RepositoryStats struct {
+	Since         string                  `json:"since"`
+	Contributors  []ContributorStatistics `json:"contributors,omitempty"`
+	CodeFrequency []WeeklyCodeFrequency   `json:"code_frequency,omitempty"`
+	Participation *Participation          `json:"participation,omitempty"`
+	Computing     []string                `json:"computing,omitempty"`
+}
+
+// lastWeeks returns the last n items of a weekly series.
+func lastWeeks[T any](series []T, n int) []T {
+	if len(series) > n {
+		return series[len(series)-n:]
+	}
+	return series
+}
+
+// GetRepositoryStats creates a tool to get the contributor statistics, code frequency and participation of a
+// repository.
+func GetRepositoryStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_stats",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_STATS_DESCRIPTION", "Get statistics of a repository over recent weeks for a health report: the commits, additions and deletions of each contributor, the lines added and deleted per week (code_frequency), and the commits per week by everyone and by the owner (participation). GitHub computes statistics in the background: those not ready yet are listed in 'computing' and can be requested again in a few seconds. code_frequency isn't available for repositories with 10,000 or more commits.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_STATS_USER_TITLE", "Get repository statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("stats",
+				mcp.Description("Statistics to get. Defaults to all of them."),
+				mcp.Items(map[string]any{
+					"type": "string",
+					"enum": []string{"contributors", "code_frequency", "participation"},
+				}),
+			),
+			mcp.WithNumber("weeks",
+				mcp.Description("Number of most recent weeks to get statistics for (max 52). Defaults to 12."),
+				mcp.Min(1),
+				mcp.Max(52),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stats, err := OptionalStringArrayParam(request, "stats")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(stats) == 0 {
+				stats = []string{"contributors", "code_frequency", "participation"}
+			}
+			// Each statistic is requested and reported once, however often it's listed
+			listed := map[string]bool{}
+			stats = slices.DeleteFunc(stats, func(stat string) bool {
+				duplicate := listed[stat]
+				listed[stat] = true
+				return duplicate
+			})
+			for _, stat := range stats {
+				if stat != "contributors" && stat != "code_frequency" && stat != "participation" {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown statistics: %s. Supported statistics are: contributors, code_frequency, participation", stat)), nil
+				}
+			}
+			weeks, err := OptionalIntParamWithDefault(request, "weeks", 12)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if weeks < 1 || weeks > 52 {
+				return mcp.NewToolResultError("weeks must be between 1 and 52"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			since := time.Now().AddDate(0, 0, -7*weeks)
+			result := RepositoryStats{Since: since.Format(time.DateOnly)}
+			for _, stat := range stats {
+				var resp *github.Response
+				switch stat {
+				case "contributors":
+					var contributors []*github.ContributorStats
+					contributors, resp, err = client.Repositories.ListContributorsStats(ctx, owner, repo)
+					if err != nil {
+						break
+					}
+					result.Contributors = contributorStatistics(contributors, since)
+				case "code_frequency":
+					var frequency []*github.WeeklyStats
+					frequency, resp, err = client.Repositories.ListCodeFrequency(ctx, owner, repo)
+					if err != nil {
+						break
+					}
+					result.CodeFrequency = []WeeklyCodeFrequency{}
+					for _, week := range lastWeeks(frequency, weeks) {
+						// Deletions are reported as negative numbers
+						result.CodeFrequency = append(result.CodeFrequency, WeeklyCodeFrequency{
+							Week:      week.GetWeek().UTC().Format(time.DateOnly),
+							Additions: week.GetAdditions(),
+							Deletions: -week.GetDeletions(),
+						})
+					}
+				case "participation":
+					var participation *github.RepositoryParticipation
+					participation, resp, err = client.Repositories.ListParticipation(ctx, owner, repo)
+					if err != nil {
+						break
+					}
+					result.Participation = &Participation{
+						All:   lastWeeks(participation.All, weeks),
+						Owner: lastWeeks(participation.Owner, weeks),
+					}
+					for _, commits := range result.Participation.All {
+						result.Participation.AllTotal += commits
+					}
+					for _, commits := range result.Participation.Owner {
+						result.Participation.OwnerTotal += commits
+					}
+				}
+
+				if err != nil {
+					if isAcceptedError(err) {
+						result.Computing = append(result.Computing, stat)
+						continue
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get %s statistics", stat), resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+			if len(result.Computing) == len(stats) {
+				return statsComputingResult(owner, repo), nil
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
@@ -0,0 +1,517 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCommitActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_activity", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "weeks")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// The punch card endpoint returns [day, hour, commits] triples.
+	punchCard := [][]int{
+		{1, 9, 4},
+		{2, 14, 10},
+		{5, 14, 3},
+	}
+	commitActivity := []*github.WeeklyCommitActivity{
+		{Week: &github.Timestamp{Time: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)}, Days: []int{0, 1, 2, 0, 0, 0, 0}, Total: github.Ptr(3)},
+		{Week: &github.Timestamp{Time: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)}, Days: []int{0, 4, 10, 0, 0, 3, 0}, Total: github.Ptr(17)},
+	}
+
+	accepted := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedActivity func(t *testing.T, activity CommitActivity)
+	}{
+		{
+			name: "successful activity fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposStatsPunchCardByOwnerByRepo,
+					punchCard,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposStatsCommitActivityByOwnerByRepo,
+					commitActivity,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"weeks": float64(1),
+			},
+			expectError: false,
+			expectedActivity: func(t *testing.T, activity CommitActivity) {
+				assert.Equal(t, 4, activity.PunchCard[1][9])
+				assert.Equal(t, 10, activity.PunchCard[2][14])
+				assert.Equal(t, 0, activity.PunchCard[0][0])
+				assert.Equal(t, "Tuesday", activity.BusiestDay)
+				assert.Equal(t, 14, activity.BusiestHour)
+				require.Len(t, activity.WeeklyCommits, 1)
+				assert.Equal(t, "2024-01-14", activity.WeeklyCommits[0].Week)
+				assert.Equal(t, []int{0, 4, 10, 0, 0, 3, 0}, activity.WeeklyCommits[0].Days)
+				assert.Equal(t, 17, activity.TotalCommits)
+			},
+		},
+		{
+			name: "statistics are being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsPunchCardByOwnerByRepo,
+					accepted,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "GitHub is computing statistics for owner/repo",
+		},
+		{
+			name: "punch card fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsPunchCardByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get punch card",
+		},
+		{
+			name:         "weeks out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"weeks": float64(60),
+			},
+			expectError:    true,
+			expectedErrMsg: "weeks must be between 1 and 52",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommitActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var activity CommitActivity
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &activity))
+			tc.expectedActivity(t, activity)
+		})
+	}
+}
+
+func Test_GetContributorInsights(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetContributorInsights(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contributor_insights", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "weeks")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "top")
+	assert.Contains(t, tool.InputSchema.Properties, "max_commits_per_directory")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	recentWeek := &github.Timestamp{Time: time.Now().AddDate(0, 0, -7)}
+	oldWeek := &github.Timestamp{Time: time.Now().AddDate(-1, 0, 0)}
+	contributorStats := []*github.ContributorStats{
+		{
+			Author: &github.Contributor{Login: github.Ptr("alice")},
+			Weeks: []*github.WeeklyStats{
+				{Week: recentWeek, Commits: github.Ptr(5), Additions: github.Ptr(100), Deletions: github.Ptr(10)},
+				{Week: oldWeek, Commits: github.Ptr(50)},
+			},
+		},
+		{
+			Author: &github.Contributor{Login: github.Ptr("bob")},
+			Weeks: []*github.WeeklyStats{
+				{Week: recentWeek, Commits: github.Ptr(8), Additions: github.Ptr(20), Deletions: github.Ptr(5)},
+			},
+		},
+		{
+			Author: &github.Contributor{Login: github.Ptr("carol")},
+			Weeks: []*github.WeeklyStats{
+				{Week: oldWeek, Commits: github.Ptr(3)},
+			},
+		},
+	}
+
+	commitBy := func(login string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{Author: &github.User{Login: github.Ptr(login)}}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "leaderboard and bus factor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					contributorStats,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					[]*github.RepositoryContent{
+						{Type: github.Ptr("dir"), Path: github.Ptr("cmd")},
+						{Type: github.Ptr("dir"), Path: github.Ptr("pkg")},
+						{Type: github.Ptr("file"), Path: github.Ptr("README.md")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var commits []*github.RepositoryCommit
+						switch r.URL.Query().Get("path") {
+						case "cmd":
+							commits = []*github.RepositoryCommit{commitBy("alice"), commitBy("alice"), commitBy("alice"), commitBy("bob")}
+						case "pkg":
+							commits = []*github.RepositoryCommit{
+								commitBy("alice"), commitBy("bob"), commitBy("carol"), commitBy("dave"),
+								{Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.Ptr("Eve")}}},
+							}
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(commits)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+		},
+		{
+			name: "statistics are being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusAccepted)
+						_, _ = w.Write([]byte(`{}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "GitHub is computing statistics for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetContributorInsights(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TopContributors []ContributorActivity `json:"top_contributors"`
+				Directories     []DirectoryOwnership  `json:"directories"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+			assert.Equal(t, []ContributorActivity{
+				{Login: "bob", Commits: 8, Additions: 20, Deletions: 5},
+				{Login: "alice", Commits: 5, Additions: 100, Deletions: 10},
+			}, response.TopContributors)
+
+			require.Len(t, response.Directories, 2)
+			assert.Equal(t, "pkg", response.Directories[0].Directory)
+			assert.Equal(t, 5, response.Directories[0].Commits)
+			assert.Equal(t, 3, response.Directories[0].BusFactor)
+			assert.Len(t, response.Directories[0].TopAuthors, 3)
+			assert.Equal(t, "cmd", response.Directories[1].Directory)
+			assert.Equal(t, 4, response.Directories[1].Commits)
+			assert.Equal(t, 1, response.Directories[1].BusFactor)
+			assert.Equal(t, "alice", response.Directories[1].TopAuthors[0].Login)
+		})
+	}
+}
+
+func Test_GetRepositoryStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "stats")
+	assert.Contains(t, tool.InputSchema.Properties, "weeks")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	recentWeek := time.Now().AddDate(0, 0, -7).Truncate(24 * time.Hour)
+	oldWeek := time.Now().AddDate(-1, 0, 0).Truncate(24 * time.Hour)
+	contributors := []*github.ContributorStats{
+		{
+			Author: &github.Contributor{Login: github.Ptr("alice")},
+			Total:  github.Ptr(50),
+			Weeks: []*github.WeeklyStats{
+				{Week: &github.Timestamp{Time: oldWeek}, Commits: github.Ptr(48), Additions: github.Ptr(1000), Deletions: github.Ptr(100)},
+				{Week: &github.Timestamp{Time: recentWeek}, Commits: github.Ptr(2), Additions: github.Ptr(20), Deletions: github.Ptr(5)},
+			},
+		},
+		{
+			Author: &github.Contributor{Login: github.Ptr("bob")},
+			Total:  github.Ptr(7),
+			Weeks: []*github.WeeklyStats{
+				{Week: &github.Timestamp{Time: recentWeek}, Commits: github.Ptr(7), Additions: github.Ptr(300), Deletions: github.Ptr(40)},
+			},
+		},
+	}
+	// The code frequency endpoint returns [week, additions, deletions] triples, with negative deletions.
+	codeFrequency := [][]int64{
+		{oldWeek.Unix(), 1000, -100},
+		{recentWeek.Unix(), 320, -45},
+	}
+	participation := &github.RepositoryParticipation{
+		All:   []int{5, 1, 3, 9},
+		Owner: []int{1, 0, 0, 2},
+	}
+	accepted := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedStats  func(t *testing.T, stats RepositoryStats)
+	}{
+		{
+			name: "all statistics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposStatsContributorsByOwnerByRepo, contributors),
+				mock.WithRequestMatch(mock.GetReposStatsCodeFrequencyByOwnerByRepo, codeFrequency),
+				mock.WithRequestMatch(mock.GetReposStatsParticipationByOwnerByRepo, participation),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"weeks": float64(2),
+			},
+			expectedStats: func(t *testing.T, stats RepositoryStats) {
+				assert.Equal(t, []ContributorStatistics{
+					{ContributorActivity: ContributorActivity{Login: "bob", Commits: 7, Additions: 300, Deletions: 40}, TotalCommits: 7},
+					{ContributorActivity: ContributorActivity{Login: "alice", Commits: 2, Additions: 20, Deletions: 5}, TotalCommits: 50},
+				}, stats.Contributors)
+				assert.Equal(t, []WeeklyCodeFrequency{
+					{Week: oldWeek.UTC().Format(time.DateOnly), Additions: 1000, Deletions: 100},
+					{Week: recentWeek.UTC().Format(time.DateOnly), Additions: 320, Deletions: 45},
+				}, stats.CodeFrequency)
+				assert.Equal(t, &Participation{All: []int{3, 9}, Owner: []int{0, 2}, AllTotal: 12, OwnerTotal: 2}, stats.Participation)
+				assert.Empty(t, stats.Computing)
+			},
+		},
+		{
+			name: "duplicates are reported once",
+			mockedClient: mock.NewMockedHTTPClient(
+				// A single response: the statistics must be requested once
+				mock.WithRequestMatch(mock.GetReposStatsContributorsByOwnerByRepo, append(contributors,
+					&github.ContributorStats{
+						Total: github.Ptr(3),
+						Weeks: []*github.WeeklyStats{{Week: &github.Timestamp{Time: recentWeek}, Commits: github.Ptr(3)}},
+					},
+					&github.ContributorStats{
+						Total: github.Ptr(1),
+						Weeks: []*github.WeeklyStats{{Week: &github.Timestamp{Time: recentWeek}, Commits: github.Ptr(1)}},
+					},
+				)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"stats": []interface{}{"contributors", "contributors"},
+			},
+			expectedStats: func(t *testing.T, stats RepositoryStats) {
+				// Deleted accounts have no login and are merged into one entry
+				assert.Equal(t, []ContributorStatistics{
+					{ContributorActivity: ContributorActivity{Login: "bob", Commits: 7, Additions: 300, Deletions: 40}, TotalCommits: 7},
+					{ContributorActivity: ContributorActivity{Login: "", Commits: 4}, TotalCommits: 4},
+					{ContributorActivity: ContributorActivity{Login: "alice", Commits: 2, Additions: 20, Deletions: 5}, TotalCommits: 50},
+				}, stats.Contributors)
+				assert.Nil(t, stats.CodeFrequency)
+				assert.Nil(t, stats.Participation)
+			},
+		},
+		{
+			name: "statistics being computed are listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposStatsContributorsByOwnerByRepo, accepted),
+				mock.WithRequestMatch(mock.GetReposStatsParticipationByOwnerByRepo, participation),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"stats": []interface{}{"contributors", "participation"},
+			},
+			expectedStats: func(t *testing.T, stats RepositoryStats) {
+				assert.Equal(t, []string{"contributors"}, stats.Computing)
+				assert.Nil(t, stats.Contributors)
+				assert.Nil(t, stats.CodeFrequency)
+				require.NotNil(t, stats.Participation)
+				assert.Equal(t, 18, stats.Participation.AllTotal)
+			},
+		},
+		{
+			name: "all statistics being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposStatsCodeFrequencyByOwnerByRepo, accepted),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"stats": []interface{}{"code_frequency"},
+			},
+			expectError:    true,
+			expectedErrMsg: "GitHub is computing statistics for owner/repo",
+		},
+		{
+			name: "too many commits for code frequency",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsCodeFrequencyByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository has too many commits"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"stats": []interface{}{"code_frequency"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get code_frequency statistics",
+		},
+		{
+			name:         "unknown statistics",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"stats": []interface{}{"punch_card"},
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown statistics: punch_card",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var stats RepositoryStats
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &stats))
+			tc.expectedStats(t, stats)
+		})
+	}
+}
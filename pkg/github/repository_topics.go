@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxRepositoryTopics is the number of topics GitHub allows on a repository.
+const maxRepositoryTopics = 20
+
+// topicPattern matches a valid topic: lowercase letters, numbers and hyphens, starting with a letter or number and
+// at most 50 characters long.
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// normalizeTopics lowercases and trims the topics, drops duplicates and checks them against the rules GitHub applies,
+// so that a bad topic is reported by name instead of as a generic validation failure.
+func normalizeTopics(topics []string) ([]string, error) {
+	normalized := make([]string, 0, len(topics))
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if !topicPattern.MatchString(topic) {
+			return nil, fmt.Errorf("invalid topic %q: topics must start with a letter or number, contain only letters, numbers and hyphens, and have at most 50 characters", topic)
+		}
+		if seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		normalized = append(normalized, topic)
+	}
+	if len(normalized) > maxRepositoryTopics {
+		return nil, fmt.Errorf("a repository can have at most %d topics, got %d", maxRepositoryTopics, len(normalized))
+	}
+	return normalized, nil
+}
+
+// GetRepositoryTopics creates a tool to get the topics of a repository.
+func GetRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_topics",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TOPICS_DESCRIPTION", "Get the topics of a GitHub repository, returned with the repository in its minimal format. The topics field is left out when the repository has no topics")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TOPICS_USER_TITLE", "Get repository topics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalRepository(repository)), nil
+		}
+}
+
+// ReplaceRepositoryTopics creates a tool to replace all topics of a repository.
+func ReplaceRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("replace_repository_topics",
+			mcp.WithDescription(t("TOOL_REPLACE_REPOSITORY_TOPICS_DESCRIPTION", "Replace all topics of a GitHub repository with the given topics. Topics are lowercased and deduplicated, and a repository can have at most 20. Pass an empty list to remove all topics. Returns the repository in its minimal format with its new topics")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REPLACE_REPOSITORY_TOPICS_USER_TITLE", "Replace repository topics"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("topics",
+				mcp.Required(),
+				mcp.Description("The complete list of topics the repository should have. Topics not in the list are removed"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// An empty list is valid and removes all topics, so only a missing parameter is an error
+			if _, ok := request.GetArguments()["topics"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: topics"), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			topics, err = normalizeTopics(topics)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to replace topics of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("topics of %s/%s were replaced, but failed to get the repository", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalRepository(repository)), nil
+		}
+}
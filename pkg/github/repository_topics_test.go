@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		ID:       github.Ptr(int64(1)),
+		Name:     github.Ptr("repo"),
+		FullName: github.Ptr("owner/repo"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo"),
+		Topics:   []string{"go", "mcp"},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTopics []string
+		expectedErrMsg string
+	}{
+		{
+			name: "get topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedTopics: []string{"go", "mcp"},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository owner/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedRepo MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedRepo))
+			assert.Equal(t, "owner/repo", returnedRepo.FullName)
+			assert.Equal(t, tc.expectedTopics, returnedRepo.Topics)
+		})
+	}
+}
+
+func Test_ReplaceRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplaceRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "replace_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "topics"})
+
+	updatedRepo := &github.Repository{
+		FullName: github.Ptr("owner/repo"),
+		Topics:   []string{"go", "github-mcp"},
+	}
+	clearedRepo := &github.Repository{
+		FullName: github.Ptr("owner/repo"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTopics []string
+		expectedErrMsg string
+	}{
+		{
+			name: "replace topics with normalized names",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"go", "github-mcp"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "github-mcp"}}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					updatedRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"Go", " github-mcp ", "go"},
+			},
+			expectError:    false,
+			expectedTopics: []string{"go", "github-mcp"},
+		},
+		{
+			name: "empty list removes all topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{}}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					clearedRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{},
+			},
+			expectError:    false,
+			expectedTopics: nil,
+		},
+		{
+			name:         "missing topics",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: topics",
+		},
+		{
+			name:         "invalid topic",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"good", "not valid"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid topic "not valid"`,
+		},
+		{
+			name: "replace fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"go"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to replace topics of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplaceRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedRepo MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedRepo))
+			assert.Equal(t, tc.expectedTopics, returnedRepo.Topics)
+		})
+	}
+}
+
+func Test_NormalizeTopics(t *testing.T) {
+	topics, err := normalizeTopics([]string{"Go", "go", "  cli-tools "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "cli-tools"}, topics)
+
+	_, err = normalizeTopics([]string{"-leading-hyphen"})
+	assert.ErrorContains(t, err, `invalid topic "-leading-hyphen"`)
+
+	tooMany := make([]string, 0, maxRepositoryTopics+1)
+	for i := 0; i <= maxRepositoryTopics; i++ {
+		tooMany = append(tooMany, "topic-"+string(rune('a'+i)))
+	}
+	_, err = normalizeTopics(tooMany)
+	assert.ErrorContains(t, err, "at most 20 topics, got 21")
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TrafficPoint is the number of views or clones of a repository in one day or week.
+type TrafficPoint struct {
+	Date    string `json:"date"`
+	Count   int    `json:"count"`
+	Uniques int    `json:"uniques"`
+}
+
+// TrafficSeries is the total and the time series of the views or clones of a repository.
+type TrafficSeries struct {
+	Count   int            `json:"count"`
+	Uniques int            `json:"uniques"`
+	Series  []TrafficPoint `json:"series"`
+}
+
+// TrafficReferrer is a site that referred visitors to a repository.
+type TrafficReferrer struct {
+	Referrer string `json:"referrer"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+// TrafficPath is a page of a repository and the number of its views.
+type TrafficPath struct {
+	Path    string `json:"path"`
+	Title   string `json:"title,omitempty"`
+	Count   int    `json:"count"`
+	Uniques int    `json:"uniques"`
+}
+
+// RepositoryTraffic is the compact traffic of a repository over the last 14 days.
+type RepositoryTraffic struct {
+	Per          string            `json:"per"`
+	Views        TrafficSeries     `json:"views"`
+	Clones       TrafficSeries     `json:"clones"`
+	TopReferrers []TrafficReferrer `json:"top_referrers"`
+	TopPaths     []TrafficPath     `json:"top_paths"`
+}
+
+func convertToTrafficSeries(count, uniques int, data []*github.TrafficData) TrafficSeries {
+	series := TrafficSeries{Count: count, Uniques: uniques, Series: make([]TrafficPoint, 0, len(data))}
+	for _, point := range data {
+		series.Series = append(series.Series, TrafficPoint{
+			Date:    point.GetTimestamp().Format(time.DateOnly),
+			Count:   point.GetCount(),
+			Uniques: point.GetUniques(),
+		})
+	}
+	return series
+}
+
+// GetRepositoryTraffic creates a tool to get the views, clones, top referrers and top paths of a repository.
+func GetRepositoryTraffic(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_traffic",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TRAFFIC_DESCRIPTION", "Get the traffic of a repository over the last 14 days: views and clones per day or week with their unique visitors and cloners, and the top 10 referring sites and most viewed paths. Useful to see how adoption of a repository is trending. Requires push access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TRAFFIC_USER_TITLE", "Get repository traffic"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("per",
+				mcp.Description("Whether to count views and clones per day or per week. Defaults to day."),
+				mcp.Enum("day", "week"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			per, err := OptionalParam[string](request, "per")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if per == "" {
+				per = "day"
+			}
+			if per != "day" && per != "week" {
+				return mcp.NewToolResultError("per must be day or week"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.TrafficBreakdownOptions{Per: per}
+
+			views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get traffic views", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get traffic clones", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			referrers, resp, err := client.Repositories.ListTrafficReferrers(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get top referrers", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			paths, resp, err := client.Repositories.ListTrafficPaths(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get top paths", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			traffic := RepositoryTraffic{
+				Per:          per,
+				Views:        convertToTrafficSeries(views.GetCount(), views.GetUniques(), views.Views),
+				Clones:       convertToTrafficSeries(clones.GetCount(), clones.GetUniques(), clones.Clones),
+				TopReferrers: make([]TrafficReferrer, 0, len(referrers)),
+				TopPaths:     make([]TrafficPath, 0, len(paths)),
+			}
+			for _, referrer := range referrers {
+				traffic.TopReferrers = append(traffic.TopReferrers, TrafficReferrer{
+					Referrer: referrer.GetReferrer(),
+					Count:    referrer.GetCount(),
+					Uniques:  referrer.GetUniques(),
+				})
+			}
+			for _, p := range paths {
+				traffic.TopPaths = append(traffic.TopPaths, TrafficPath{
+					Path:    p.GetPath(),
+					Title:   p.GetTitle(),
+					Count:   p.GetCount(),
+					Uniques: p.GetUniques(),
+				})
+			}
+
+			return MarshalledTextResult(ctx, traffic), nil
+		}
+}
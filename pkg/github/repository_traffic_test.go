@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTraffic(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTraffic(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_traffic", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "per")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	day := func(d int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)}
+	}
+	views := &github.TrafficViews{
+		Count:   github.Ptr(30),
+		Uniques: github.Ptr(12),
+		Views: []*github.TrafficData{
+			{Timestamp: day(1), Count: github.Ptr(10), Uniques: github.Ptr(5)},
+			{Timestamp: day(2), Count: github.Ptr(20), Uniques: github.Ptr(9)},
+		},
+	}
+	clones := &github.TrafficClones{
+		Count:   github.Ptr(4),
+		Uniques: github.Ptr(2),
+		Clones: []*github.TrafficData{
+			{Timestamp: day(2), Count: github.Ptr(4), Uniques: github.Ptr(2)},
+		},
+	}
+	referrers := []*github.TrafficReferrer{
+		{Referrer: github.Ptr("Google"), Count: github.Ptr(8), Uniques: github.Ptr(3)},
+	}
+	paths := []*github.TrafficPath{
+		{Path: github.Ptr("/owner/repo"), Title: github.Ptr("owner/repo"), Count: github.Ptr(25), Uniques: github.Ptr(10)},
+	}
+	forbidden := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Must have push access to repository"}`))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedTraffic RepositoryTraffic
+	}{
+		{
+			name: "traffic per week",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+						mockResponse(t, http.StatusOK, views),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficClonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+						mockResponse(t, http.StatusOK, clones),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+					referrers,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularPathsByOwnerByRepo,
+					paths,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"per":   "week",
+			},
+			expectedTraffic: RepositoryTraffic{
+				Per: "week",
+				Views: TrafficSeries{Count: 30, Uniques: 12, Series: []TrafficPoint{
+					{Date: "2024-03-01", Count: 10, Uniques: 5},
+					{Date: "2024-03-02", Count: 20, Uniques: 9},
+				}},
+				Clones: TrafficSeries{Count: 4, Uniques: 2, Series: []TrafficPoint{
+					{Date: "2024-03-02", Count: 4, Uniques: 2},
+				}},
+				TopReferrers: []TrafficReferrer{{Referrer: "Google", Count: 8, Uniques: 3}},
+				TopPaths:     []TrafficPath{{Path: "/owner/repo", Title: "owner/repo", Count: 25, Uniques: 10}},
+			},
+		},
+		{
+			name: "no traffic defaults to per day",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "day"}).andThen(
+						mockResponse(t, http.StatusOK, &github.TrafficViews{Count: github.Ptr(0), Uniques: github.Ptr(0)}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficClonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per": "day"}).andThen(
+						mockResponse(t, http.StatusOK, &github.TrafficClones{Count: github.Ptr(0), Uniques: github.Ptr(0)}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+					[]*github.TrafficReferrer{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposTrafficPopularPathsByOwnerByRepo,
+					[]*github.TrafficPath{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTraffic: RepositoryTraffic{
+				Per:          "day",
+				Views:        TrafficSeries{Series: []TrafficPoint{}},
+				Clones:       TrafficSeries{Series: []TrafficPoint{}},
+				TopReferrers: []TrafficReferrer{},
+				TopPaths:     []TrafficPath{},
+			},
+		},
+		{
+			name:         "invalid per",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"per":   "month",
+			},
+			expectError:    true,
+			expectedErrMsg: "per must be day or week",
+		},
+		{
+			name: "no push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					forbidden,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get traffic views",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTraffic(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var traffic RepositoryTraffic
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &traffic))
+			assert.Equal(t, tc.expectedTraffic, traffic)
+		})
+	}
+}
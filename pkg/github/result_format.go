@@ -0,0 +1,306 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ResultFormat is how the JSON results of tools are written.
+type ResultFormat string
+
+const (
+	// ResultFormatCompact writes JSON without whitespace, which takes the fewest tokens. It's the default.
+	ResultFormatCompact ResultFormat = "compact"
+	// ResultFormatPretty writes indented JSON.
+	ResultFormatPretty ResultFormat = "pretty"
+	// ResultFormatMarkdown writes objects as Markdown lists and lists of objects as Markdown tables, for clients
+	// showing results to people.
+	ResultFormatMarkdown ResultFormat = "markdown"
+)
+
+// ParseResultFormat parses a result format, compact if empty.
+func ParseResultFormat(s string) (ResultFormat, error) {
+	switch format := ResultFormat(strings.ToLower(s)); format {
+	case "":
+		return ResultFormatCompact, nil
+	case ResultFormatCompact, ResultFormatPretty, ResultFormatMarkdown:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown result format %q, must be one of compact, pretty or markdown", s)
+	}
+}
+
+// jsonField is a field of a JSON object.
+type jsonField struct {
+	Key   string
+	Value any
+}
+
+// jsonObject is a JSON object keeping the order of its fields, which are in the order the tools give them in.
+type jsonObject []jsonField
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// decodeOrdered decodes the next JSON value, with objects as jsonObject and numbers as json.Number.
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		object := jsonObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, jsonField{Key: key.(string), Value: value})
+		}
+		_, err := dec.Token()
+		return object, err
+	case json.Delim('['):
+		array := []any{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := dec.Token()
+		return array, err
+	default:
+		return tok, nil
+	}
+}
+
+// markdownScalar writes a JSON value on one line, nested values as compact JSON.
+func markdownScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.Join(strings.Fields(v), " ")
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// isScalar reports whether a JSON value is neither an object nor an array.
+func isScalar(v any) bool {
+	switch v.(type) {
+	case jsonObject, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+// objectsOf returns the elements of a non-empty JSON array whose elements are all objects, or false.
+func objectsOf(v any) ([]jsonObject, bool) {
+	array, ok := v.([]any)
+	if !ok || len(array) == 0 {
+		return nil, false
+	}
+	objects := make([]jsonObject, 0, len(array))
+	for _, element := range array {
+		object, ok := element.(jsonObject)
+		if !ok {
+			return nil, false
+		}
+		objects = append(objects, object)
+	}
+	return objects, true
+}
+
+// writeMarkdownTable writes objects as a table, with a column per field in the order fields first appear.
+func writeMarkdownTable(b *strings.Builder, objects []jsonObject) {
+	var columns []string
+	seen := map[string]bool{}
+	for _, object := range objects {
+		for _, field := range object {
+			if !seen[field.Key] {
+				seen[field.Key] = true
+				columns = append(columns, field.Key)
+			}
+		}
+	}
+	cell := func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	}
+
+	b.WriteString("|")
+	for _, column := range columns {
+		b.WriteString(" " + cell(column) + " |")
+	}
+	b.WriteString("\n|")
+	for range columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, object := range objects {
+		values := make(map[string]any, len(object))
+		for _, field := range object {
+			values[field.Key] = field.Value
+		}
+		b.WriteString("|")
+		for _, column := range columns {
+			b.WriteString(" " + cell(markdownScalar(values[column])) + " |")
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeMarkdownList writes a JSON value as a nested list. Lists of objects directly in the top-level object are
+// written as tables after the list.
+func writeMarkdownList(b *strings.Builder, v any, indent string, tables *[]jsonField) {
+	switch v := v.(type) {
+	case jsonObject:
+		for _, field := range v {
+			if objects, ok := objectsOf(field.Value); ok && tables != nil {
+				*tables = append(*tables, jsonField{Key: field.Key, Value: objects})
+				continue
+			}
+			switch value := field.Value.(type) {
+			case jsonObject:
+				fmt.Fprintf(b, "%s- **%s**:\n", indent, field.Key)
+				writeMarkdownList(b, value, indent+"  ", nil)
+			case []any:
+				items := make([]string, 0, len(value))
+				allScalars := true
+				for _, item := range value {
+					allScalars = allScalars && isScalar(item)
+					items = append(items, markdownScalar(item))
+				}
+				if allScalars {
+					fmt.Fprintf(b, "%s- **%s**: %s\n", indent, field.Key, strings.Join(items, ", "))
+				} else {
+					fmt.Fprintf(b, "%s- **%s**:\n", indent, field.Key)
+					writeMarkdownList(b, value, indent+"  ", nil)
+				}
+			default:
+				fmt.Fprintf(b, "%s- **%s**: %s\n", indent, field.Key, markdownScalar(value))
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if isScalar(item) {
+				fmt.Fprintf(b, "%s- %s\n", indent, markdownScalar(item))
+				continue
+			}
+			fmt.Fprintf(b, "%s-\n", indent)
+			writeMarkdownList(b, item, indent+"  ", nil)
+		}
+	default:
+		fmt.Fprintf(b, "%s%s\n", indent, markdownScalar(v))
+	}
+}
+
+// renderMarkdown writes a JSON value as Markdown.
+func renderMarkdown(v any) string {
+	var b strings.Builder
+	if objects, ok := objectsOf(v); ok {
+		writeMarkdownTable(&b, objects)
+		return b.String()
+	}
+
+	var tables []jsonField
+	writeMarkdownList(&b, v, "", &tables)
+	for _, table := range tables {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "**%s**:\n\n", table.Key)
+		writeMarkdownTable(&b, table.Value.([]jsonObject))
+	}
+	return b.String()
+}
+
+// formatJSON rewrites a JSON object or array in a format. ok is false when the text isn't one.
+func formatJSON(text string, format ResultFormat) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+
+	switch format {
+	case ResultFormatPretty:
+		var b bytes.Buffer
+		if err := json.Indent(&b, []byte(trimmed), "", "  "); err != nil {
+			return "", false
+		}
+		return b.String(), true
+	case ResultFormatMarkdown:
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		dec.UseNumber()
+		v, err := decodeOrdered(dec)
+		if err != nil || dec.More() {
+			return "", false
+		}
+		return renderMarkdown(v), true
+	default:
+		var b bytes.Buffer
+		if err := json.Compact(&b, []byte(trimmed)); err != nil {
+			return "", false
+		}
+		return b.String(), true
+	}
+}
+
+type resultFormatKey struct{}
+
+// resultFormat returns the format the results of a tool call are written in, compact unless the server was
+// configured with another.
+func resultFormat(ctx context.Context) ResultFormat {
+	if format, ok := ctx.Value(resultFormatKey{}).(ResultFormat); ok {
+		return format
+	}
+	return ResultFormatCompact
+}
+
+// UseResultFormat returns a tool handler middleware that makes format the output format of the calls, in which
+// MarshalledTextResult writes the results of the tools.
+func UseResultFormat(format ResultFormat) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, resultFormatKey{}, format), request)
+		}
+	}
+}
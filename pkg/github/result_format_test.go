@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseResultFormat(t *testing.T) {
+	format, err := ParseResultFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, ResultFormatCompact, format)
+
+	format, err = ParseResultFormat("Markdown")
+	require.NoError(t, err)
+	assert.Equal(t, ResultFormatMarkdown, format)
+
+	_, err = ParseResultFormat("yaml")
+	assert.EqualError(t, err, `unknown result format "yaml", must be one of compact, pretty or markdown`)
+}
+
+func Test_formatJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		format       ResultFormat
+		expectedText string
+		expectedOK   bool
+	}{
+		{
+			name:         "pretty",
+			text:         `{"name":"web","topics":["go"]}`,
+			format:       ResultFormatPretty,
+			expectedText: "{\n  \"name\": \"web\",\n  \"topics\": [\n    \"go\"\n  ]\n}",
+			expectedOK:   true,
+		},
+		{
+			name:         "compact",
+			text:         "{\n  \"name\": \"web\"\n}\n",
+			format:       ResultFormatCompact,
+			expectedText: `{"name":"web"}`,
+			expectedOK:   true,
+		},
+		{
+			name:   "markdown table",
+			text:   `[{"number":42,"title":"Fix | login","labels":["bug"]},{"number":7,"state":"open"}]`,
+			format: ResultFormatMarkdown,
+			expectedText: "| number | title | labels | state |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 42 | Fix \\| login | [\"bug\"] |  |\n" +
+				"| 7 |  |  | open |\n",
+			expectedOK: true,
+		},
+		{
+			name:   "markdown object with nested values and tables",
+			text:   `{"repository":"acme/web","archived":false,"owner":{"login":"acme","type":"Organization"},"topics":["go","mcp"],"items":[{"number":1,"title":"One\nline"}],"hasNextPage":true}`,
+			format: ResultFormatMarkdown,
+			expectedText: "- **repository**: acme/web\n" +
+				"- **archived**: false\n" +
+				"- **owner**:\n" +
+				"  - **login**: acme\n" +
+				"  - **type**: Organization\n" +
+				"- **topics**: go, mcp\n" +
+				"- **hasNextPage**: true\n" +
+				"\n**items**:\n\n" +
+				"| number | title |\n" +
+				"| --- | --- |\n" +
+				"| 1 | One line |\n",
+			expectedOK: true,
+		},
+		{
+			name:         "markdown list of values",
+			text:         `["main",{"name":"dev"}]`,
+			format:       ResultFormatMarkdown,
+			expectedText: "- main\n-\n  - **name**: dev\n",
+			expectedOK:   true,
+		},
+		{
+			name:   "plain text",
+			text:   "Repository acme/web deleted",
+			format: ResultFormatPretty,
+		},
+		{
+			name:   "invalid JSON",
+			text:   `{"name": `,
+			format: ResultFormatMarkdown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			text, ok := formatJSON(tc.text, tc.format)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedText, text)
+		})
+	}
+}
+
+func Test_MarshalledTextResult(t *testing.T) {
+	report := map[string]any{"name": "web", "content": `{"a":1}`}
+
+	// Calls without an output format get compact JSON
+	assert.Equal(t, `{"content":"{\"a\":1}","name":"web"}`, getTextResult(t, MarshalledTextResult(context.Background(), report)).Text)
+
+	ctx := context.WithValue(context.Background(), resultFormatKey{}, ResultFormatMarkdown)
+	assert.Equal(t, "- **content**: {\"a\":1}\n- **name**: web\n", getTextResult(t, MarshalledTextResult(ctx, report)).Text)
+}
+
+func Test_UseResultFormat(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			&github.Issue{Number: github.Ptr(42), Title: github.Ptr("Fix login")},
+		),
+	))
+	_, issueRead := IssueRead(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	handler := UseResultFormat(ResultFormatPretty)(issueRead)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"method":       "get",
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"number\": 42,\n  \"title\": \"Fix login\"\n}", getTextResult(t, result).Text)
+}
@@ -0,0 +1,301 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ReviewerLoad is the number of open pull requests a user has been requested to review.
+type ReviewerLoad struct {
+	Login              string `json:"login"`
+	OpenReviewRequests int    `json:"open_review_requests"`
+}
+
+// listReviewCandidates returns the given users followed by the members of the team, without duplicates
+// or excluded users.
+func listReviewCandidates(ctx context.Context, client *github.Client, org, teamSlug string, users, exclude []string) ([]string, *github.Response, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, login := range exclude {
+		excluded[strings.ToLower(login)] = true
+	}
+	var candidates []string
+	add := func(login string) {
+		if !excluded[strings.ToLower(login)] {
+			excluded[strings.ToLower(login)] = true
+			candidates = append(candidates, login)
+		}
+	}
+	for _, login := range users {
+		add(login)
+	}
+	if teamSlug == "" {
+		return candidates, nil, nil
+	}
+
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, member := range members {
+			add(member.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return candidates, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// getReviewLoads counts the open pull requests in the organization each user has been requested to review,
+// and sorts the users from least to most loaded.
+func getReviewLoads(ctx context.Context, client *github.Client, org string, logins []string) ([]ReviewerLoad, []map[string]string) {
+	loads := make([]ReviewerLoad, len(logins))
+	errs := fanOut(ctx, logins, DefaultFanOutConcurrency, func(ctx context.Context, i int, login string) error {
+		query := fmt.Sprintf("is:pr is:open archived:false org:%s review-requested:%s", org, login)
+		result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		loads[i] = ReviewerLoad{Login: login, OpenReviewRequests: result.GetTotal()}
+		return nil
+	})
+
+	counted := make([]ReviewerLoad, 0, len(logins))
+	var failures []map[string]string
+	for i, login := range logins {
+		if errs[i] != nil {
+			failures = append(failures, map[string]string{"login": login, "error": errs[i].Error()})
+			continue
+		}
+		counted = append(counted, loads[i])
+	}
+	sort.SliceStable(counted, func(i, j int) bool {
+		return counted[i].OpenReviewRequests < counted[j].OpenReviewRequests
+	})
+	return counted, failures
+}
+
+// GetReviewLoad creates a tool to show how many open review requests each member of a team has.
+func GetReviewLoad(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_review_load",
+			mcp.WithDescription(t("TOOL_GET_REVIEW_LOAD_DESCRIPTION", "Show how many open pull requests in an organization each member of a team, or each of the given users, has been requested to review, from least to most loaded, and suggest the least loaded reviewer. Use request_least_loaded_reviewer to request a review from them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REVIEW_LOAD_USER_TITLE", "Get review load"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization to count open review requests in"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Description("Slug of the team whose members are candidate reviewers"),
+			),
+			mcp.WithArray("users",
+				mcp.Description("Usernames of candidate reviewers, in addition to the team members"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Usernames to leave out, e.g. people who are out of office"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := OptionalParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, err := OptionalStringArrayParam(request, "users")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			exclude, err := OptionalStringArrayParam(request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if teamSlug == "" && len(users) == 0 {
+				return mcp.NewToolResultError("either team_slug or users must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			candidates, resp, err := listReviewCandidates(ctx, client, org, teamSlug, users, exclude)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+
+			loads, failures := getReviewLoads(ctx, client, org, candidates)
+			response := map[string]any{
+				"org":       org,
+				"reviewers": loads,
+			}
+			if len(loads) > 0 {
+				response["suggested_reviewer"] = loads[0].Login
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
+
+// RequestLeastLoadedReviewer creates a tool to request a review on a pull request from the candidate
+// reviewer with the fewest open review requests.
+func RequestLeastLoadedReviewer(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("request_least_loaded_reviewer",
+			mcp.WithDescription(t("TOOL_REQUEST_LEAST_LOADED_REVIEWER_DESCRIPTION", "Request a review on a pull request from the member of a team, or one of the given users, with the fewest open review requests in the repository owner's organization. The pull request author and users already requested are skipped.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_LEAST_LOADED_REVIEWER_USER_TITLE", "Request least loaded reviewer"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, the organization to count open review requests in"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Description("Slug of the team whose members are candidate reviewers"),
+			),
+			mcp.WithArray("users",
+				mcp.Description("Usernames of candidate reviewers, in addition to the team members"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Usernames to leave out, e.g. people who are out of office"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := OptionalParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, err := OptionalStringArrayParam(request, "users")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			exclude, err := OptionalStringArrayParam(request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if teamSlug == "" && len(users) == 0 {
+				return mcp.NewToolResultError("either team_slug or users must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			exclude = append(exclude, pr.GetUser().GetLogin())
+			for _, reviewer := range pr.RequestedReviewers {
+				exclude = append(exclude, reviewer.GetLogin())
+			}
+			candidates, resp, err := listReviewCandidates(ctx, client, owner, teamSlug, users, exclude)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of team %s/%s", owner, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+
+			loads, failures := getReviewLoads(ctx, client, owner, candidates)
+			if len(loads) == 0 {
+				if len(failures) > 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to count open review requests of any candidate: %s", failures[0]["error"])), nil
+				}
+				return mcp.NewToolResultError("no candidate reviewers left after excluding the author, requested reviewers and excluded users"), nil
+			}
+
+			reviewer := loads[0].Login
+			_, resp, err = client.PullRequests.RequestReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers: []string{reviewer},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to request review from %s", reviewer),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			response := map[string]any{
+				"requested_reviewer": reviewer,
+				"pull_request":       pr.GetHTMLURL(),
+				"reviewers":          loads,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
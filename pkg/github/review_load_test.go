@@ -0,0 +1,288 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reviewRequestsHandler serves the number of open review requests of each user in loads.
+func reviewRequestsHandler(t *testing.T, loads map[string]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		assert.True(t, strings.HasPrefix(query, "is:pr is:open archived:false org:org review-requested:"), query)
+		login := strings.TrimPrefix(query, "is:pr is:open archived:false org:org review-requested:")
+		total, ok := loads[login]
+		if !ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(total)})(w, r)
+	}
+}
+
+func Test_GetReviewLoad(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReviewLoad(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_review_load", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "users")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	teamMembers := []*github.User{
+		{Login: github.Ptr("alice")},
+		{Login: github.Ptr("bob")},
+		{Login: github.Ptr("carol")},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedReviewers []ReviewerLoad
+		expectedSuggested string
+		expectedFailures  int
+	}{
+		{
+			name: "team members sorted by load",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsTeamsMembersByOrgByTeamSlug, teamMembers),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					reviewRequestsHandler(t, map[string]int{"alice": 4, "bob": 1, "carol": 2, "dave": 1}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":       "org",
+				"team_slug": "reviewers",
+				"users":     []any{"dave"},
+				"exclude":   []any{"Carol"},
+			},
+			expectedReviewers: []ReviewerLoad{
+				{Login: "dave", OpenReviewRequests: 1},
+				{Login: "bob", OpenReviewRequests: 1},
+				{Login: "alice", OpenReviewRequests: 4},
+			},
+			expectedSuggested: "dave",
+		},
+		{
+			name: "failed searches are reported",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					reviewRequestsHandler(t, map[string]int{"alice": 3}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":   "org",
+				"users": []any{"alice", "ghost"},
+			},
+			expectedReviewers: []ReviewerLoad{{Login: "alice", OpenReviewRequests: 3}},
+			expectedSuggested: "alice",
+			expectedFailures:  1,
+		},
+		{
+			name:         "no candidates",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "either team_slug or users must be provided",
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":       "org",
+				"team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list members of team org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReviewLoad(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Reviewers         []ReviewerLoad      `json:"reviewers"`
+				SuggestedReviewer string              `json:"suggested_reviewer"`
+				Errors            []map[string]string `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedReviewers, response.Reviewers)
+			assert.Equal(t, tc.expectedSuggested, response.SuggestedReviewer)
+			assert.Len(t, response.Errors, tc.expectedFailures)
+		})
+	}
+}
+
+func Test_RequestLeastLoadedReviewer(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RequestLeastLoadedReviewer(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "request_least_loaded_reviewer", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "users")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pr := &github.PullRequest{
+		Number:             github.Ptr(42),
+		HTMLURL:            github.Ptr("https://github.com/org/repo/pull/42"),
+		User:               &github.User{Login: github.Ptr("alice")},
+		RequestedReviewers: []*github.User{{Login: github.Ptr("bob")}},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedRequested string
+	}{
+		{
+			name: "requests least loaded team member",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					[]*github.User{
+						{Login: github.Ptr("alice")},
+						{Login: github.Ptr("bob")},
+						{Login: github.Ptr("carol")},
+						{Login: github.Ptr("dave")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					reviewRequestsHandler(t, map[string]int{"carol": 5, "dave": 2}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"reviewers": []any{"dave"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, pr),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "org",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"team_slug":  "reviewers",
+			},
+			expectedRequested: "dave",
+		},
+		{
+			name: "only author and requested reviewers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+			),
+			requestArgs: map[string]any{
+				"owner":      "org",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"users":      []any{"alice", "bob"},
+			},
+			expectError:    true,
+			expectedErrMsg: "no candidate reviewers left",
+		},
+		{
+			name: "review request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					reviewRequestsHandler(t, map[string]int{"erin": 0}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reviews may only be requested from collaborators."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "org",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"users":      []any{"erin"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to request review from erin",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RequestLeastLoadedReviewer(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				RequestedReviewer string         `json:"requested_reviewer"`
+				PullRequest       string         `json:"pull_request"`
+				Reviewers         []ReviewerLoad `json:"reviewers"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedRequested, response.RequestedReviewer)
+			assert.Equal(t, "https://github.com/org/repo/pull/42", response.PullRequest)
+		})
+	}
+}
@@ -0,0 +1,839 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalRuleset is the trimmed output type for rulesets in lists. The list endpoints don't return the conditions
+// and rules of a ruleset, get_ruleset does.
+type MinimalRuleset struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Target      string `json:"target,omitempty"`
+	SourceType  string `json:"source_type,omitempty"`
+	Source      string `json:"source"`
+	Enforcement string `json:"enforcement"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+}
+
+func convertToMinimalRuleset(ruleset *github.RepositoryRuleset) MinimalRuleset {
+	minimal := MinimalRuleset{
+		ID:          ruleset.GetID(),
+		Name:        ruleset.Name,
+		Source:      ruleset.Source,
+		Enforcement: string(ruleset.Enforcement),
+		UpdatedAt:   formatOptionalTimestamp(ruleset.UpdatedAt),
+	}
+	if ruleset.Target != nil {
+		minimal.Target = string(*ruleset.Target)
+	}
+	if ruleset.SourceType != nil {
+		minimal.SourceType = string(*ruleset.SourceType)
+	}
+	if ruleset.Links != nil && ruleset.Links.HTML != nil {
+		minimal.HTMLURL = ruleset.Links.HTML.GetHRef()
+	}
+	return minimal
+}
+
+// RuleSuite is the evaluation of the rulesets of a repository against a push. go-github does not wrap the rule
+// suite endpoints, so this is decoded straight from the API response.
+type RuleSuite struct {
+	ID               int64            `json:"id"`
+	ActorName        string           `json:"actor_name,omitempty"`
+	BeforeSHA        string           `json:"before_sha,omitempty"`
+	AfterSHA         string           `json:"after_sha,omitempty"`
+	Ref              string           `json:"ref,omitempty"`
+	RepositoryName   string           `json:"repository_name,omitempty"`
+	PushedAt         string           `json:"pushed_at,omitempty"`
+	Result           string           `json:"result"`
+	EvaluationResult string           `json:"evaluation_result,omitempty"`
+	RuleEvaluations  []RuleEvaluation `json:"rule_evaluations,omitempty"`
+}
+
+// RuleEvaluation is the outcome of a single rule in a rule suite.
+type RuleEvaluation struct {
+	RuleSource struct {
+		Type string `json:"type"`
+		ID   int64  `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"rule_source"`
+	Enforcement string `json:"enforcement"`
+	Result      string `json:"result"`
+	RuleType    string `json:"rule_type"`
+	Details     string `json:"details,omitempty"`
+}
+
+type ruleSuiteListOptions struct {
+	Ref             string `url:"ref,omitempty"`
+	RepositoryName  string `url:"repository_name,omitempty"`
+	TimePeriod      string `url:"time_period,omitempty"`
+	ActorName       string `url:"actor_name,omitempty"`
+	RuleSuiteResult string `url:"rule_suite_result,omitempty"`
+	github.ListOptions
+}
+
+// rulesetRuleTypes are the rule types a ruleset can contain. go-github silently drops rules of other types, so they
+// are checked before a ruleset is sent.
+var rulesetRuleTypes = []github.RepositoryRuleType{
+	github.RulesetRuleTypeCreation,
+	github.RulesetRuleTypeUpdate,
+	github.RulesetRuleTypeDeletion,
+	github.RulesetRuleTypeRequiredLinearHistory,
+	github.RulesetRuleTypeMergeQueue,
+	github.RulesetRuleTypeRequiredDeployments,
+	github.RulesetRuleTypeRequiredSignatures,
+	github.RulesetRuleTypePullRequest,
+	github.RulesetRuleTypeRequiredStatusChecks,
+	github.RulesetRuleTypeNonFastForward,
+	github.RulesetRuleTypeCommitMessagePattern,
+	github.RulesetRuleTypeCommitAuthorEmailPattern,
+	github.RulesetRuleTypeCommitterEmailPattern,
+	github.RulesetRuleTypeBranchNamePattern,
+	github.RulesetRuleTypeTagNamePattern,
+	github.RulesetRuleTypeFilePathRestriction,
+	github.RulesetRuleTypeMaxFilePathLength,
+	github.RulesetRuleTypeFileExtensionRestriction,
+	github.RulesetRuleTypeMaxFileSize,
+	github.RulesetRuleTypeWorkflows,
+	github.RulesetRuleTypeCodeScanning,
+}
+
+// rulesetScope addresses the rulesets of a repository, or of an organization when repo is empty.
+type rulesetScope struct {
+	owner string
+	repo  string
+}
+
+// WithRulesetScope adds the parameters selecting the repository or organization whose rulesets a tool manages.
+func WithRulesetScope() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner, or the organization login when 'repo' is omitted"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description("Repository name. Omit to manage the organization's rulesets"),
+		)(tool)
+	}
+}
+
+func rulesetScopeParams(request mcp.CallToolRequest) (rulesetScope, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return rulesetScope{}, err
+	}
+	repo, err := OptionalParam[string](request, "repo")
+	if err != nil {
+		return rulesetScope{}, err
+	}
+	return rulesetScope{owner: owner, repo: repo}, nil
+}
+
+func (r rulesetScope) String() string {
+	if r.repo == "" {
+		return fmt.Sprintf("organization '%s'", r.owner)
+	}
+	return fmt.Sprintf("repository '%s/%s'", r.owner, r.repo)
+}
+
+func (r rulesetScope) list(ctx context.Context, client *github.Client, includesParents bool, opts github.ListOptions) ([]*github.RepositoryRuleset, *github.Response, error) {
+	if r.repo == "" {
+		return client.Organizations.GetAllRepositoryRulesets(ctx, r.owner, &opts)
+	}
+	return client.Repositories.GetAllRulesets(ctx, r.owner, r.repo, &github.RepositoryListRulesetsOptions{
+		IncludesParents: github.Ptr(includesParents),
+		ListOptions:     opts,
+	})
+}
+
+func (r rulesetScope) get(ctx context.Context, client *github.Client, rulesetID int64, includesParents bool) (*github.RepositoryRuleset, *github.Response, error) {
+	if r.repo == "" {
+		return client.Organizations.GetRepositoryRuleset(ctx, r.owner, rulesetID)
+	}
+	return client.Repositories.GetRuleset(ctx, r.owner, r.repo, rulesetID, includesParents)
+}
+
+func (r rulesetScope) create(ctx context.Context, client *github.Client, ruleset github.RepositoryRuleset) (*github.RepositoryRuleset, *github.Response, error) {
+	if r.repo == "" {
+		return client.Organizations.CreateRepositoryRuleset(ctx, r.owner, ruleset)
+	}
+	return client.Repositories.CreateRuleset(ctx, r.owner, r.repo, ruleset)
+}
+
+// update replaces a ruleset. go-github omits empty bypass actors from the request, so clearing them takes the
+// dedicated calls.
+func (r rulesetScope) update(ctx context.Context, client *github.Client, rulesetID int64, ruleset github.RepositoryRuleset) (*github.RepositoryRuleset, *github.Response, error) {
+	clearBypassActors := ruleset.BypassActors != nil && len(ruleset.BypassActors) == 0
+	if r.repo != "" {
+		if clearBypassActors {
+			return client.Repositories.UpdateRulesetNoBypassActor(ctx, r.owner, r.repo, rulesetID, ruleset)
+		}
+		return client.Repositories.UpdateRuleset(ctx, r.owner, r.repo, rulesetID, ruleset)
+	}
+
+	updated, resp, err := client.Organizations.UpdateRepositoryRuleset(ctx, r.owner, rulesetID, ruleset)
+	if err != nil || !clearBypassActors {
+		return updated, resp, err
+	}
+	_ = resp.Body.Close()
+	resp, err = client.Organizations.UpdateRepositoryRulesetClearBypassActor(ctx, r.owner, rulesetID)
+	if err != nil {
+		return nil, resp, err
+	}
+	updated.BypassActors = nil
+	return updated, resp, nil
+}
+
+func (r rulesetScope) delete(ctx context.Context, client *github.Client, rulesetID int64) (*github.Response, error) {
+	if r.repo == "" {
+		return client.Organizations.DeleteRepositoryRuleset(ctx, r.owner, rulesetID)
+	}
+	return client.Repositories.DeleteRuleset(ctx, r.owner, r.repo, rulesetID)
+}
+
+func (r rulesetScope) ruleSuitesURL() string {
+	if r.repo == "" {
+		return fmt.Sprintf("orgs/%s/rulesets/rule-suites", r.owner)
+	}
+	return fmt.Sprintf("repos/%s/%s/rulesets/rule-suites", r.owner, r.repo)
+}
+
+// WithRulesetSettings adds the parameters describing the content of a ruleset.
+func WithRulesetSettings(required bool) mcp.ToolOption {
+	var requiredOpt mcp.PropertyOption = func(map[string]any) {}
+	if required {
+		requiredOpt = mcp.Required()
+	}
+	ruleTypes := make([]string, 0, len(rulesetRuleTypes))
+	for _, ruleType := range rulesetRuleTypes {
+		ruleTypes = append(ruleTypes, string(ruleType))
+	}
+	return func(tool *mcp.Tool) {
+		mcp.WithString("name",
+			requiredOpt,
+			mcp.Description("Name of the ruleset"),
+		)(tool)
+		mcp.WithString("target",
+			mcp.Description("What the ruleset applies to. 'push' rulesets restrict the files that can be pushed to the repository and its forks"),
+			mcp.Enum("branch", "tag", "push"),
+		)(tool)
+		mcp.WithString("enforcement",
+			mcp.Description("Whether the ruleset is enforced. 'evaluate' only records rule suites without blocking, and is only available to organizations on GitHub Enterprise"),
+			mcp.Enum("active", "evaluate", "disabled"),
+		)(tool)
+		mcp.WithObject("conditions",
+			mcp.Description("Which refs and repositories the ruleset applies to. Repository rulesets use 'ref_name', where '~DEFAULT_BRANCH' and '~ALL' are accepted. Organization rulesets also need one of 'repository_name', 'repository_id' or 'repository_property'"),
+			mcp.Properties(map[string]any{
+				"ref_name": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"include": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"exclude": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					},
+				},
+				"repository_name": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"include":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"exclude":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"protected": map[string]any{"type": "boolean"},
+					},
+				},
+			}),
+		)(tool)
+		mcp.WithArray("rules",
+			requiredOpt,
+			mcp.Description("The rules of the ruleset, each with a 'type' and, for the types that take them, 'parameters' as in the GitHub REST API. Push rules are 'file_path_restriction', 'max_file_path_length', 'file_extension_restriction' and 'max_file_size'. Required workflows use the 'workflows' type and are only available in organization rulesets"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"type": map[string]any{
+						"type": "string",
+						"enum": ruleTypes,
+					},
+					"parameters": map[string]any{
+						"type": "object",
+					},
+				},
+				"required": []string{"type"},
+			}),
+		)(tool)
+		mcp.WithArray("bypass_actors",
+			mcp.Description("The actors that can bypass the ruleset. Pass an empty list to remove every bypass actor"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"actor_id": map[string]any{
+						"type":        "number",
+						"description": "ID of the team, app, deploy key or repository role. Omit for organization admins",
+					},
+					"actor_type": map[string]any{
+						"type": "string",
+						"enum": []string{"Integration", "OrganizationAdmin", "RepositoryRole", "Team", "DeployKey"},
+					},
+					"bypass_mode": map[string]any{
+						"type": "string",
+						"enum": []string{"always", "pull_request"},
+					},
+				},
+				"required": []string{"actor_type"},
+			}),
+		)(tool)
+	}
+}
+
+// decodeStrict converts a tool argument to v through JSON, rejecting unknown fields.
+func decodeStrict(name string, arg any, v any) error {
+	raw, err := json.Marshal(arg)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+func parseRulesetRules(arg any) (*github.RepositoryRulesetRules, error) {
+	var rules []struct {
+		Type       github.RepositoryRuleType `json:"type"`
+		Parameters json.RawMessage           `json:"parameters,omitempty"`
+	}
+	if err := decodeStrict("rules", arg, &rules); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[github.RepositoryRuleType]bool, len(rules))
+	for _, rule := range rules {
+		known := false
+		for _, ruleType := range rulesetRuleTypes {
+			known = known || rule.Type == ruleType
+		}
+		if !known {
+			return nil, fmt.Errorf("invalid rules: unknown rule type '%s'", rule.Type)
+		}
+		if seen[rule.Type] {
+			return nil, fmt.Errorf("invalid rules: the '%s' rule is given more than once", rule.Type)
+		}
+		seen[rule.Type] = true
+	}
+
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	parsed := &github.RepositoryRulesetRules{}
+	if err := json.Unmarshal(raw, parsed); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return parsed, nil
+}
+
+// rulesetSettings are the settings of a ruleset given to a tool. Nil fields were not given.
+type rulesetSettings struct {
+	name         *string
+	target       *github.RulesetTarget
+	enforcement  *github.RulesetEnforcement
+	conditions   *github.RepositoryRulesetConditions
+	rules        *github.RepositoryRulesetRules
+	bypassActors []*github.BypassActor
+}
+
+func rulesetSettingsParams(request mcp.CallToolRequest) (rulesetSettings, error) {
+	var settings rulesetSettings
+	name, ok, err := OptionalParamOK[string](request, "name")
+	if err != nil {
+		return settings, err
+	}
+	if ok {
+		settings.name = &name
+	}
+	target, ok, err := OptionalParamOK[string](request, "target")
+	if err != nil {
+		return settings, err
+	}
+	if ok {
+		settings.target = github.Ptr(github.RulesetTarget(target))
+	}
+	enforcement, ok, err := OptionalParamOK[string](request, "enforcement")
+	if err != nil {
+		return settings, err
+	}
+	if ok {
+		settings.enforcement = github.Ptr(github.RulesetEnforcement(enforcement))
+	}
+
+	args := request.GetArguments()
+	if arg, ok := args["conditions"]; ok && arg != nil {
+		settings.conditions = &github.RepositoryRulesetConditions{}
+		if err := decodeStrict("conditions", arg, settings.conditions); err != nil {
+			return settings, err
+		}
+	}
+	if arg, ok := args["rules"]; ok && arg != nil {
+		if settings.rules, err = parseRulesetRules(arg); err != nil {
+			return settings, err
+		}
+	}
+	if arg, ok := args["bypass_actors"]; ok && arg != nil {
+		settings.bypassActors = []*github.BypassActor{}
+		if err := decodeStrict("bypass_actors", arg, &settings.bypassActors); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
+
+func (s rulesetSettings) isEmpty() bool {
+	return s.name == nil && s.target == nil && s.enforcement == nil && s.conditions == nil && s.rules == nil && s.bypassActors == nil
+}
+
+// apply sets the given settings on a ruleset, keeping the others.
+func (s rulesetSettings) apply(ruleset *github.RepositoryRuleset) {
+	if s.name != nil {
+		ruleset.Name = *s.name
+	}
+	if s.target != nil {
+		ruleset.Target = s.target
+	}
+	if s.enforcement != nil {
+		ruleset.Enforcement = *s.enforcement
+	}
+	if s.conditions != nil {
+		ruleset.Conditions = s.conditions
+	}
+	if s.rules != nil {
+		ruleset.Rules = s.rules
+	}
+	if s.bypassActors != nil {
+		ruleset.BypassActors = s.bypassActors
+	}
+}
+
+// ListRulesets creates a tool to list the rulesets of a repository or organization.
+func ListRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_RULESETS_DESCRIPTION", "List the rulesets of a repository, or of an organization when 'repo' is omitted, with their target and enforcement. Rulesets are the successor of branch protection. Use 'get_ruleset' to see the conditions and rules of a ruleset.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULESETS_USER_TITLE", "List rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithRulesetScope(),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("For a repository, also list the organization and enterprise rulesets that apply to it (default true)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, err := OptionalBoolParamWithDefault(request, "includes_parents", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rulesets, resp, err := scope.list(ctx, client, includesParents, github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list rulesets for %s", scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalRuleset, 0, len(rulesets))
+			for _, ruleset := range rulesets {
+				result = append(result, convertToMinimalRuleset(ruleset))
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// GetRuleset creates a tool to get a ruleset of a repository or organization.
+func GetRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ruleset",
+			mcp.WithDescription(t("TOOL_GET_RULESET_DESCRIPTION", "Get a ruleset of a repository, or of an organization when 'repo' is omitted, with its conditions, rules and bypass actors.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULESET_USER_TITLE", "Get ruleset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithRulesetScope(),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("For a repository, also look up the organization and enterprise rulesets that apply to it (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, err := OptionalBoolParamWithDefault(request, "includes_parents", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := scope.get(ctx, client, int64(rulesetID), includesParents)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get ruleset %d of %s", rulesetID, scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, ruleset), nil
+		}
+}
+
+// ListRuleSuites creates a tool to list the evaluations of rulesets against recent pushes.
+func ListRuleSuites(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rule_suites",
+			mcp.WithDescription(t("TOOL_LIST_RULE_SUITES_DESCRIPTION", "List the rule suites of a repository, or of an organization when 'repo' is omitted. A rule suite is the evaluation of the rulesets against a push, with whether it passed, failed or was bypassed. Use 'get_rule_suite' to see the outcome of each rule.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULE_SUITES_USER_TITLE", "List rule suites"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithRulesetScope(),
+			mcp.WithString("ref",
+				mcp.Description("Only list rule suites of pushes to this ref, such as 'refs/heads/main'"),
+			),
+			mcp.WithString("repository_name",
+				mcp.Description("For an organization, only list rule suites of this repository"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("How far back to list rule suites (default 'day')"),
+				mcp.Enum("hour", "day", "week", "month"),
+			),
+			mcp.WithString("actor_name",
+				mcp.Description("Only list rule suites of pushes by this user"),
+			),
+			mcp.WithString("rule_suite_result",
+				mcp.Description("Only list rule suites with this result (default 'all')"),
+				mcp.Enum("pass", "fail", "bypass", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := &ruleSuiteListOptions{}
+			if opts.Ref, err = OptionalParam[string](request, "ref"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if opts.RepositoryName, err = OptionalParam[string](request, "repository_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if opts.RepositoryName != "" && scope.repo != "" {
+				return mcp.NewToolResultError("repository_name can only be given for an organization"), nil
+			}
+			if opts.TimePeriod, err = OptionalParam[string](request, "time_period"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if opts.ActorName, err = OptionalParam[string](request, "actor_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if opts.RuleSuiteResult, err = OptionalParam[string](request, "rule_suite_result"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts.ListOptions = github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap this endpoint, so we build the request ourselves.
+			url, err := addOptions(scope.ruleSuitesURL(), opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add options to request: %w", err)
+			}
+
+			httpRequest, err := client.NewRequest("GET", url, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			suites := []RuleSuite{}
+			resp, err := client.Do(ctx, httpRequest, &suites)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list rule suites for %s", scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, suites), nil
+		}
+}
+
+// GetRuleSuite creates a tool to get the evaluation of each rule of a rule suite.
+func GetRuleSuite(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_rule_suite",
+			mcp.WithDescription(t("TOOL_GET_RULE_SUITE_DESCRIPTION", "Get a rule suite of a repository, or of an organization when 'repo' is omitted, with the ruleset, enforcement, result and details of each rule evaluated against the push.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULE_SUITE_USER_TITLE", "Get rule suite"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithRulesetScope(),
+			mcp.WithNumber("rule_suite_id",
+				mcp.Required(),
+				mcp.Description("The ID of the rule suite, as returned by 'list_rule_suites'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ruleSuiteID, err := RequiredInt(request, "rule_suite_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap this endpoint, so we build the request ourselves.
+			httpRequest, err := client.NewRequest("GET", fmt.Sprintf("%s/%d", scope.ruleSuitesURL(), ruleSuiteID), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var suite RuleSuite
+			resp, err := client.Do(ctx, httpRequest, &suite)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get rule suite %d of %s", ruleSuiteID, scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, suite), nil
+		}
+}
+
+// CreateRuleset creates a tool to create a ruleset for a repository or organization.
+func CreateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_ruleset",
+			mcp.WithDescription(t("TOOL_CREATE_RULESET_DESCRIPTION", "Create a ruleset for a repository, or for an organization when 'repo' is omitted. Rulesets can protect branches and tags, restrict pushes, and, for organizations, require workflows to pass. Create it with 'evaluate' enforcement first to see its effect with 'list_rule_suites' before enforcing it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RULESET_USER_TITLE", "Create ruleset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithRulesetScope(),
+			WithRulesetSettings(true),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			settings, err := rulesetSettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if settings.name == nil || *settings.name == "" {
+				return mcp.NewToolResultError("missing required parameter: name"), nil
+			}
+			if settings.rules == nil {
+				return mcp.NewToolResultError("missing required parameter: rules"), nil
+			}
+
+			ruleset := github.RepositoryRuleset{
+				Target:      github.Ptr(github.RulesetTargetBranch),
+				Enforcement: github.RulesetEnforcementActive,
+			}
+			settings.apply(&ruleset)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := scope.create(ctx, client, ruleset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create ruleset for %s", scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, created), nil
+		}
+}
+
+// UpdateRuleset creates a tool to update a ruleset of a repository or organization.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ruleset",
+			mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Update a ruleset of a repository, or of an organization when 'repo' is omitted. Only the given settings change. 'conditions', 'rules' and 'bypass_actors' replace the current ones as a whole, so include the rules to keep.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RULESET_USER_TITLE", "Update ruleset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithRulesetScope(),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+			WithRulesetSettings(false),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			settings, err := rulesetSettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if settings.isEmpty() {
+				return mcp.NewToolResultError("no ruleset settings to update were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := scope.get(ctx, client, int64(rulesetID), false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get ruleset %d of %s", rulesetID, scope),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// Only send the writable fields, the rest of the ruleset is set by GitHub
+			ruleset := github.RepositoryRuleset{
+				Name:         current.Name,
+				Target:       current.Target,
+				Enforcement:  current.Enforcement,
+				BypassActors: current.BypassActors,
+				Conditions:   current.Conditions,
+				Rules:        current.Rules,
+			}
+			settings.apply(&ruleset)
+
+			updated, resp, err := scope.update(ctx, client, int64(rulesetID), ruleset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update ruleset %d of %s", rulesetID, scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, updated), nil
+		}
+}
+
+// DeleteRuleset creates a tool to delete a ruleset of a repository or organization.
+func DeleteRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_ruleset",
+			mcp.WithDescription(t("TOOL_DELETE_RULESET_DESCRIPTION", "Delete a ruleset of a repository, or of an organization when 'repo' is omitted. The refs it protected are no longer protected by it. Set its enforcement to 'disabled' with 'update_ruleset' instead to keep it for later.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RULESET_USER_TITLE", "Delete ruleset"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithRulesetScope(),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := rulesetScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := scope.delete(ctx, client, int64(rulesetID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete ruleset %d of %s", rulesetID, scope),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("ruleset %d of %s deleted", rulesetID, scope)), nil
+		}
+}
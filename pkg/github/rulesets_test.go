@@ -0,0 +1,741 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockRuleset() *github.RepositoryRuleset {
+	return &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+		Source:      "octo-org/api",
+		Enforcement: github.RulesetEnforcementActive,
+		BypassActors: []*github.BypassActor{
+			{
+				ActorID:    github.Ptr(int64(7)),
+				ActorType:  github.Ptr(github.BypassActorTypeTeam),
+				BypassMode: github.Ptr(github.BypassModeAlways),
+			},
+		},
+		Links: &github.RepositoryRulesetLinks{
+			HTML: &github.RepositoryRulesetLink{HRef: github.Ptr("https://github.com/octo-org/api/rules/42")},
+		},
+		Conditions: &github.RepositoryRulesetConditions{
+			RefName: &github.RepositoryRulesetRefConditionParameters{
+				Include: []string{"~DEFAULT_BRANCH"},
+				Exclude: []string{},
+			},
+		},
+		Rules: &github.RepositoryRulesetRules{
+			Deletion: &github.EmptyRuleParameters{},
+		},
+		UpdatedAt: &github.Timestamp{Time: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+}
+
+func Test_ListRulesets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "includes_parents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	expected := []MinimalRuleset{
+		{
+			ID:          42,
+			Name:        "main",
+			Target:      "branch",
+			SourceType:  "Repository",
+			Source:      "octo-org/api",
+			Enforcement: "active",
+			UpdatedAt:   "2025-03-01T12:00:00Z",
+			HTMLURL:     "https://github.com/octo-org/api/rules/42",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository rulesets without parents",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"includes_parents": "false", "page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryRuleset{mockRuleset()}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "octo-org",
+				"repo":             "api",
+				"includes_parents": false,
+			},
+		},
+		{
+			name: "organization rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsRulesetsByOrg,
+					expectPath(t, "/orgs/octo-org/rulesets").andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryRuleset{mockRuleset()}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list rulesets for repository 'octo-org/api'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, expected, returned)
+		})
+	}
+}
+
+func Test_GetRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ruleset", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "ruleset_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectQueryParams(t, map[string]string{"includes_parents": "true"}).andThen(
+				mockResponse(t, http.StatusOK, mockRuleset()),
+			),
+		),
+	))
+	_, handler := GetRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":      "octo-org",
+		"repo":       "api",
+		"ruleset_id": float64(42),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, "main", returned.Name)
+	require.NotNil(t, returned.Rules)
+	assert.NotNil(t, returned.Rules.Deletion)
+	require.Len(t, returned.BypassActors, 1)
+	assert.Equal(t, int64(7), returned.BypassActors[0].GetActorID())
+}
+
+func Test_ListRuleSuites(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRuleSuites(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rule_suites", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "rule_suite_result")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	suites := []RuleSuite{
+		{
+			ID:               21,
+			ActorName:        "octocat",
+			BeforeSHA:        "abc",
+			AfterSHA:         "def",
+			Ref:              "refs/heads/main",
+			RepositoryName:   "api",
+			PushedAt:         "2025-03-01T12:00:00Z",
+			Result:           "fail",
+			EvaluationResult: "fail",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "failed pushes to a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsRuleSuitesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref":               "refs/heads/main",
+						"time_period":       "week",
+						"rule_suite_result": "fail",
+						"page":              "1",
+						"per_page":          "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, suites),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":             "octo-org",
+				"repo":              "api",
+				"ref":               "refs/heads/main",
+				"time_period":       "week",
+				"rule_suite_result": "fail",
+			},
+		},
+		{
+			name: "organization rule suites of a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsRulesetsRuleSuitesByOrg,
+					expectQueryParams(t, map[string]string{
+						"repository_name": "api",
+						"actor_name":      "octocat",
+						"page":            "1",
+						"per_page":        "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, suites),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "octo-org",
+				"repository_name": "api",
+				"actor_name":      "octocat",
+			},
+		},
+		{
+			name:         "repository name for a repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "octo-org",
+				"repo":            "api",
+				"repository_name": "web",
+			},
+			expectError:    true,
+			expectedErrMsg: "repository_name can only be given for an organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRuleSuites(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []RuleSuite
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, suites, returned)
+		})
+	}
+}
+
+func Test_GetRuleSuite(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleSuite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rule_suite", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "rule_suite_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsRuleSuitesByOwnerByRepoByRuleSuiteId,
+			expectPath(t, "/repos/octo-org/api/rulesets/rule-suites/21").andThen(
+				mockResponse(t, http.StatusOK, `{
+					"id": 21,
+					"actor_id": 1,
+					"actor_name": "octocat",
+					"ref": "refs/heads/main",
+					"result": "fail",
+					"evaluation_result": "fail",
+					"rule_evaluations": [
+						{
+							"rule_source": {"type": "ruleset", "id": 42, "name": "main"},
+							"enforcement": "active",
+							"result": "fail",
+							"rule_type": "non_fast_forward",
+							"details": "Cannot force-push to this branch"
+						}
+					]
+				}`),
+			),
+		),
+	))
+	_, handler := GetRuleSuite(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":         "octo-org",
+		"repo":          "api",
+		"rule_suite_id": float64(21),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned RuleSuite
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, int64(21), returned.ID)
+	require.Len(t, returned.RuleEvaluations, 1)
+	evaluation := returned.RuleEvaluations[0]
+	assert.Equal(t, "main", evaluation.RuleSource.Name)
+	assert.Equal(t, int64(42), evaluation.RuleSource.ID)
+	assert.Equal(t, "non_fast_forward", evaluation.RuleType)
+	assert.Equal(t, "Cannot force-push to this branch", evaluation.Details)
+}
+
+func Test_CreateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ruleset", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "conditions")
+	assert.Contains(t, tool.InputSchema.Properties, "bypass_actors")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "name", "rules"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "branch ruleset with defaults",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"conditions": map[string]any{
+							"ref_name": map[string]any{
+								"include": []any{"~DEFAULT_BRANCH"},
+								"exclude": []any{},
+							},
+						},
+						"rules": []any{
+							map[string]any{"type": "deletion"},
+							map[string]any{"type": "required_linear_history"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRuleset()),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+				"name":  "main",
+				"conditions": map[string]any{
+					"ref_name": map[string]any{
+						"include": []any{"~DEFAULT_BRANCH"},
+						"exclude": []any{},
+					},
+				},
+				"rules": []any{
+					map[string]any{"type": "deletion"},
+					map[string]any{"type": "required_linear_history"},
+				},
+			},
+		},
+		{
+			name: "organization push ruleset in evaluate mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsRulesetsByOrg,
+					expectRequestBody(t, map[string]any{
+						"name":        "no binaries",
+						"target":      "push",
+						"source":      "",
+						"enforcement": "evaluate",
+						"bypass_actors": []any{
+							map[string]any{"actor_type": "OrganizationAdmin", "bypass_mode": "always"},
+						},
+						"rules": []any{
+							map[string]any{
+								"type":       "file_extension_restriction",
+								"parameters": map[string]any{"restricted_file_extensions": []any{".exe"}},
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRuleset()),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"name":        "no binaries",
+				"target":      "push",
+				"enforcement": "evaluate",
+				"bypass_actors": []any{
+					map[string]any{"actor_type": "OrganizationAdmin", "bypass_mode": "always"},
+				},
+				"rules": []any{
+					map[string]any{
+						"type":       "file_extension_restriction",
+						"parameters": map[string]any{"restricted_file_extensions": []any{".exe"}},
+					},
+				},
+			},
+		},
+		{
+			name:         "unknown rule type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+				"name":  "main",
+				"rules": []any{map[string]any{"type": "no_force_push"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown rule type 'no_force_push'",
+		},
+		{
+			name:         "duplicate rule",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+				"name":  "main",
+				"rules": []any{
+					map[string]any{"type": "deletion"},
+					map[string]any{"type": "deletion"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "the 'deletion' rule is given more than once",
+		},
+		{
+			name:         "unknown condition",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"repo":       "api",
+				"name":       "main",
+				"conditions": map[string]any{"branch_name": map[string]any{}},
+				"rules":      []any{map[string]any{"type": "deletion"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid conditions",
+		},
+		{
+			name:         "missing rules",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+				"name":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: rules",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, int64(42), returned.GetID())
+		})
+	}
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "ruleset_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "change enforcement keeps the rest",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					expectQueryParams(t, map[string]string{"includes_parents": "false"}).andThen(
+						mockResponse(t, http.StatusOK, mockRuleset()),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectRequestBody(t, map[string]any{
+						"name":        "main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "disabled",
+						"bypass_actors": []any{
+							map[string]any{"actor_id": float64(7), "actor_type": "Team", "bypass_mode": "always"},
+						},
+						"conditions": map[string]any{
+							"ref_name": map[string]any{
+								"include": []any{"~DEFAULT_BRANCH"},
+								"exclude": []any{},
+							},
+						},
+						"rules": []any{
+							map[string]any{"type": "deletion"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuleset()),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"repo":        "api",
+				"ruleset_id":  float64(42),
+				"enforcement": "disabled",
+			},
+		},
+		{
+			name: "clear bypass actors",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					mockRuleset(),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, []any{}, body["bypass_actors"])
+						mockResponse(t, http.StatusOK, mockRuleset())(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "octo-org",
+				"repo":          "api",
+				"ruleset_id":    float64(42),
+				"bypass_actors": []any{},
+			},
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"repo":       "api",
+				"ruleset_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "no ruleset settings to update were given",
+		},
+		{
+			name: "ruleset not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsRulesetsByOrgByRulesetId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"ruleset_id": float64(42),
+				"name":       "renamed",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get ruleset 42 of organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, int64(42), returned.GetID())
+		})
+	}
+}
+
+func Test_DeleteRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_ruleset", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "ruleset_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "organization ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsRulesetsByOrgByRulesetId,
+					expectPath(t, "/orgs/octo-org/rulesets/42").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"ruleset_id": float64(42),
+			},
+			expectedText: "ruleset 42 of organization 'octo-org' deleted",
+		},
+		{
+			name: "ruleset not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposRulesetsByOwnerByRepoByRulesetId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "octo-org",
+				"repo":       "api",
+				"ruleset_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete ruleset 42 of repository 'octo-org/api'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
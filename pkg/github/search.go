@@ -2,9 +2,11 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"sort"
+	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -93,37 +95,10 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 			}
 
 			// Return either minimal or full response based on parameter
-			var r []byte
 			if minimalOutput {
 				minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
 				for _, repo := range result.Repositories {
-					minimalRepo := MinimalRepository{
-						ID:            repo.GetID(),
-						Name:          repo.GetName(),
-						FullName:      repo.GetFullName(),
-						Description:   repo.GetDescription(),
-						HTMLURL:       repo.GetHTMLURL(),
-						Language:      repo.GetLanguage(),
-						Stars:         repo.GetStargazersCount(),
-						Forks:         repo.GetForksCount(),
-						OpenIssues:    repo.GetOpenIssuesCount(),
-						Private:       repo.GetPrivate(),
-						Fork:          repo.GetFork(),
-						Archived:      repo.GetArchived(),
-						DefaultBranch: repo.GetDefaultBranch(),
-					}
-
-					if repo.UpdatedAt != nil {
-						minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.CreatedAt != nil {
-						minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.Topics != nil {
-						minimalRepo.Topics = repo.Topics
-					}
-
-					minimalRepos = append(minimalRepos, minimalRepo)
+					minimalRepos = append(minimalRepos, convertToMinimalRepository(repo))
 				}
 
 				minimalResult := &MinimalSearchRepositoriesResult{
@@ -132,18 +107,215 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 					Items:             minimalRepos,
 				}
 
-				r, err = json.Marshal(minimalResult)
+				return MarshalledTextResult(ctx, minimalResult), nil
+			}
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// DiscoveredRepository is a repository found by discover_repositories, with the reasons it matched.
+type DiscoveredRepository struct {
+	MinimalRepository
+	Score     float64  `json:"score"`
+	MatchedBy []string `json:"matched_by"`
+}
+
+// discoveryScore ranks a candidate repository. Matching several discovery strategies weighs the most,
+// then query terms found in the name, topics and description, and finally popularity.
+func discoveryScore(repo *github.Repository, terms []string, strategies int) float64 {
+	score := 10 * float64(strategies)
+	name := strings.ToLower(repo.GetName())
+	description := strings.ToLower(repo.GetDescription())
+	for _, term := range terms {
+		if strings.Contains(name, term) {
+			score += 3
+		}
+		for _, topic := range repo.Topics {
+			if strings.Contains(topic, term) {
+				score += 2
+				break
+			}
+		}
+		if strings.Contains(description, term) {
+			score++
+		}
+	}
+	return score + math.Log10(float64(repo.GetStargazersCount())+1)
+}
+
+// DiscoverRepositories creates a tool that combines several repository searches into a single ranked list.
+func DiscoverRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("discover_repositories",
+			mcp.WithDescription(t("TOOL_DISCOVER_REPOSITORIES_DESCRIPTION", "Discover repositories that do something, e.g. 'find libraries in our org that parse YAML'. Combines a keyword search over names, descriptions and READMEs, topic searches, and organization listing, then deduplicates and ranks the results. Prefer search_repositories when you know the exact search query.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISCOVER_REPOSITORIES_USER_TITLE", "Discover repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Description("Keywords describing what the repositories do, e.g. 'yaml parser'"),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("Repository topics to search for, e.g. ['yaml', 'parser']"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("org",
+				mcp.Description("Restrict discovery to an organization or user account"),
+			),
+			mcp.WithString("language",
+				mcp.Description("Restrict discovery to a primary language, e.g. 'go'"),
+			),
+			mcp.WithNumber("min_stars",
+				mcp.Description("Minimum number of stars"),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Include archived repositories (default: false)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of repositories to return. Defaults to 20."),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			language, err := OptionalParam[string](request, "language")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			minStars, err := OptionalIntParam(request, "min_stars")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeArchived, err := OptionalParam[bool](request, "include_archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 20)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if query == "" && len(topics) == 0 && org == "" {
+				return mcp.NewToolResultError("at least one of query, topics or org must be provided"), nil
+			}
+
+			qualifiers := ""
+			if org != "" {
+				qualifiers += " user:" + org
+			}
+			if language != "" {
+				qualifiers += " language:" + language
+			}
+			if minStars > 0 {
+				qualifiers += fmt.Sprintf(" stars:>=%d", minStars)
+			}
+			if !includeArchived {
+				qualifiers += " archived:false"
+			}
+
+			type strategy struct {
+				name  string
+				query string
+			}
+			strategies := []strategy{}
+			if query != "" {
+				strategies = append(strategies, strategy{name: "keywords", query: query + " in:name,description,readme" + qualifiers})
+			}
+			for _, topic := range topics {
+				strategies = append(strategies, strategy{name: "topic:" + topic, query: "topic:" + topic + qualifiers})
+			}
+			if len(strategies) == 0 {
+				// Only an organization was given, list its repositories through search to apply the filters.
+				strategies = append(strategies, strategy{name: "org:" + org, query: strings.TrimSpace(qualifiers)})
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			type searchResult struct {
+				repos []*github.Repository
+				resp  *github.Response
+			}
+			results := make([]searchResult, len(strategies))
+			errs := fanOut(ctx, strategies, DefaultFanOutConcurrency, func(ctx context.Context, i int, s strategy) error {
+				result, resp, err := client.Search.Repositories(ctx, s.query, &github.SearchOptions{
+					ListOptions: github.ListOptions{PerPage: 50},
+				})
 				if err != nil {
-					return nil, fmt.Errorf("failed to marshal minimal response: %w", err)
+					results[i] = searchResult{resp: resp}
+					return err
 				}
-			} else {
-				r, err = json.Marshal(result)
-				if err != nil {
-					return nil, fmt.Errorf("failed to marshal full response: %w", err)
+				_ = resp.Body.Close()
+				results[i] = searchResult{repos: result.Repositories}
+				return nil
+			})
+
+			candidates := map[string]*github.Repository{}
+			matchedBy := map[string][]string{}
+			order := []string{}
+			for i, result := range results {
+				if errs[i] != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to search repositories with query '%s'", strategies[i].query),
+						result.resp,
+						errs[i],
+					), nil
+				}
+				for _, repo := range result.repos {
+					if !repositoryAllowed(ctx, repo.GetOwner().GetLogin(), repo.GetName()) {
+						continue
+					}
+					name := repo.GetFullName()
+					if _, ok := candidates[name]; !ok {
+						candidates[name] = repo
+						order = append(order, name)
+					}
+					matchedBy[name] = append(matchedBy[name], strategies[i].name)
 				}
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			terms := strings.Fields(strings.ToLower(query))
+			discovered := make([]DiscoveredRepository, 0, len(candidates))
+			for _, name := range order {
+				repo := candidates[name]
+				discovered = append(discovered, DiscoveredRepository{
+					MinimalRepository: convertToMinimalRepository(repo),
+					Score:             math.Round(discoveryScore(repo, terms, len(matchedBy[name]))*100) / 100,
+					MatchedBy:         matchedBy[name],
+				})
+			}
+			sort.SliceStable(discovered, func(i, j int) bool {
+				if discovered[i].Score != discovered[j].Score {
+					return discovered[i].Score > discovered[j].Score
+				}
+				return discovered[i].Stars > discovered[j].Stars
+			})
+
+			totalCandidates := len(discovered)
+			if len(discovered) > limit {
+				discovered = discovered[:limit]
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"total_candidates": totalCandidates,
+				"items":            discovered,
+			}), nil
 		}
 }
 
@@ -218,12 +390,7 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, result), nil
 		}
 }
 
@@ -307,11 +474,7 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 			minimalResp.IncompleteResults = *result.IncompleteResults
 		}
 
-		r, err := json.Marshal(minimalResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal response: %w", err)
-		}
-		return mcp.NewToolResultText(string(r)), nil
+		return MarshalledTextResult(ctx, minimalResp), nil
 	}
 }
 
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// searchCacheMaxEntries is the number of search results SearchCache keeps, dropping the oldest ones.
+const searchCacheMaxEntries = 100
+
+// cachedSearchTools are the tools whose results SearchCache caches. They share the search API, which has the
+// tightest rate limit.
+var cachedSearchTools = map[string]bool{
+	"search_code":          true,
+	"search_issues":        true,
+	"search_pull_requests": true,
+	"search_repositories":  true,
+}
+
+type searchCacheEntry struct {
+	result   *mcp.CallToolResult
+	storedAt time.Time
+}
+
+// SearchCache caches the results of search tools for a short time, as agents often repeat the same search across
+// turns. Searches are the same if their queries are once normalized and their other arguments are equal.
+type SearchCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]searchCacheEntry
+}
+
+// NewSearchCache creates a SearchCache keeping results for ttl. It returns nil if ttl isn't positive.
+func NewSearchCache(ttl time.Duration) *SearchCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &SearchCache{ttl: ttl, now: time.Now, entries: map[string]searchCacheEntry{}}
+}
+
+// searchQualifierNames are the qualifiers of the GitHub search syntax, whose names are case insensitive. Their values
+// and other terms are kept as they are, as they may be case sensitive, such as paths in code searches.
+var searchQualifierNames = map[string]bool{
+	"app": true, "archived": true, "assignee": true, "author": true, "author-date": true, "author-email": true,
+	"author-name": true, "base": true, "closed": true, "commenter": true, "comments": true, "committer": true,
+	"committer-date": true, "committer-email": true, "committer-name": true, "content": true, "created": true,
+	"draft": true, "enterprise": true, "extension": true, "filename": true, "followers": true, "fork": true,
+	"forks": true, "good-first-issues": true, "hash": true, "head": true, "help-wanted-issues": true, "in": true,
+	"interactions": true, "involves": true, "is": true, "label": true, "language": true, "license": true,
+	"linked": true, "location": true, "merge": true, "merged": true, "mentions": true, "milestone": true,
+	"mirror": true, "no": true, "org": true, "parent": true, "path": true, "project": true, "pushed": true,
+	"reactions": true, "repo": true, "repos": true, "review": true, "review-requested": true, "reviewed-by": true,
+	"size": true, "sort": true, "stars": true, "state": true, "status": true, "symbol": true, "team": true,
+	"team-review-requested": true, "topic": true, "topics": true, "tree": true, "type": true, "updated": true,
+	"user": true,
+}
+
+// normalizeSearchQuery makes equivalent queries equal: it collapses whitespace and lowercases the names of
+// qualifiers, leaving their values and other terms as they are. As the terms of a query without boolean operators
+// or parentheses are all required, their order doesn't matter and they're sorted, keeping quoted phrases whole.
+func normalizeSearchQuery(query string) string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range strings.TrimSpace(query) {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+
+	ordered := false
+	for i, t := range terms {
+		if key, value, ok := strings.Cut(t, ":"); ok && searchQualifierNames[strings.ToLower(strings.TrimPrefix(key, "-"))] {
+			terms[i] = strings.ToLower(key) + ":" + value
+		}
+		if t == "OR" || t == "AND" || t == "NOT" || strings.ContainsAny(t, "()") {
+			ordered = true
+		}
+	}
+	if !ordered {
+		sort.Strings(terms)
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchCacheKey returns the key of a search, with the defaults of the pagination arguments filled in.
+func searchCacheKey(tool string, args map[string]any) string {
+	normalized := make(map[string]any, len(args)+2)
+	for key, value := range args {
+		normalized[key] = value
+	}
+	if query, ok := normalized["query"].(string); ok {
+		normalized["query"] = normalizeSearchQuery(query)
+	}
+	if _, ok := normalized["page"]; !ok {
+		normalized["page"] = float64(1)
+	}
+	if _, ok := normalized["perPage"]; !ok {
+		normalized["perPage"] = float64(30)
+	}
+	// Maps are marshalled with their keys sorted
+	key, _ := json.Marshal(normalized)
+	return tool + " " + string(key)
+}
+
+// get returns the cached result of a search and its age.
+func (c *SearchCache) get(key string) (*mcp.CallToolResult, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	age := c.now().Sub(entry.storedAt)
+	if age >= c.ttl {
+		delete(c.entries, key)
+		return nil, 0, false
+	}
+	return entry.result, age, true
+}
+
+func (c *SearchCache) put(key string, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= searchCacheMaxEntries {
+		oldestKey, oldest := "", now
+		for k, entry := range c.entries {
+			if now.Sub(entry.storedAt) >= c.ttl {
+				delete(c.entries, k)
+				continue
+			}
+			if entry.storedAt.Before(oldest) {
+				oldestKey, oldest = k, entry.storedAt
+			}
+		}
+		if len(c.entries) >= searchCacheMaxEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = searchCacheEntry{result: result, storedAt: now}
+}
+
+// Middleware returns a tool handler middleware that answers repeated searches from the cache. A cached result is
+// followed by a note with its age. Failed searches aren't cached.
+func (c *SearchCache) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !cachedSearchTools[request.Params.Name] {
+				return next(ctx, request)
+			}
+
+			key := searchCacheKey(request.Params.Name, request.GetArguments())
+			if cached, age, ok := c.get(key); ok {
+				result := *cached
+				result.Content = append(append([]mcp.Content{}, cached.Content...),
+					mcp.NewTextContent(fmt.Sprintf("cached result from %s ago, the search is repeated after %s", age.Round(time.Second), c.ttl)))
+				return &result, nil
+			}
+
+			result, err := next(ctx, request)
+			if err == nil && result != nil && !result.IsError {
+				c.put(key, result)
+			}
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_normalizeSearchQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"  bug   Repo:owner/repo is:open ", "bug is:open repo:owner/repo"},
+		{`is:open "flaky test"  label:ci`, `"flaky test" is:open label:ci`},
+		{"label:bug OR label:ci", "label:bug OR label:ci"},
+		{"(language:go) fmt", "(language:go) fmt"},
+		{"Label:Bug -REPO:Owner/Repo path:Docs/API", "-repo:Owner/Repo label:Bug path:Docs/API"},
+		{"TODO:Later Readme", "Readme TODO:Later"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, normalizeSearchQuery(tc.query), tc.query)
+	}
+
+	assert.Equal(t, normalizeSearchQuery("is:open repo:owner/repo bug"), normalizeSearchQuery("bug  REPO:owner/repo is:open"))
+}
+
+func Test_SearchCache_Middleware(t *testing.T) {
+	assert.Nil(t, NewSearchCache(0), "a zero TTL must disable the cache")
+
+	cache := NewSearchCache(time.Minute)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	handler := cache.Middleware()(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		if request.GetArguments()["query"] == "fail" {
+			return mcp.NewToolResultError("failed to search"), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("result %d", calls)), nil
+	})
+	call := func(tool string, args map[string]any) *mcp.CallToolResult {
+		request := createMCPRequest(args)
+		request.Params.Name = tool
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("search_issues", map[string]any{"query": "is:open bug"})
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "result 1", getTextResult(t, result).Text)
+
+	// An equivalent query with the default page is answered from the cache, with its age
+	now = now.Add(20 * time.Second)
+	result = call("search_issues", map[string]any{"query": "bug  is:open", "page": float64(1)})
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "result 1", result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, "cached result from 20s ago, the search is repeated after 1m0s", result.Content[1].(mcp.TextContent).Text)
+	assert.Equal(t, 1, calls)
+
+	// The note isn't added to the cached result itself
+	result = call("search_issues", map[string]any{"query": "is:open bug"})
+	assert.Len(t, result.Content, 2)
+
+	// Other pages, tools and expired results are searched again
+	call("search_issues", map[string]any{"query": "is:open bug", "page": float64(2)})
+	call("search_code", map[string]any{"query": "is:open bug"})
+	assert.Equal(t, 3, calls)
+	now = now.Add(time.Minute)
+	result = call("search_issues", map[string]any{"query": "is:open bug"})
+	assert.Len(t, result.Content, 1)
+	assert.Equal(t, 4, calls)
+
+	// Failed searches and other tools aren't cached
+	call("search_repositories", map[string]any{"query": "fail"})
+	call("search_repositories", map[string]any{"query": "fail"})
+	call("list_issues", map[string]any{"owner": "owner"})
+	call("list_issues", map[string]any{"owner": "owner"})
+	assert.Equal(t, 8, calls)
+}
+
+func Test_SearchCache_Eviction(t *testing.T) {
+	cache := NewSearchCache(time.Minute)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i <= searchCacheMaxEntries; i++ {
+		now = now.Add(time.Millisecond)
+		cache.put(fmt.Sprintf("key %d", i), mcp.NewToolResultText("result"))
+	}
+	assert.Len(t, cache.entries, searchCacheMaxEntries)
+	_, _, ok := cache.get("key 0")
+	assert.False(t, ok, "the oldest result must be dropped")
+	_, _, ok = cache.get(fmt.Sprintf("key %d", searchCacheMaxEntries))
+	assert.True(t, ok)
+}
@@ -228,6 +228,165 @@ func Test_SearchRepositories_FullOutput(t *testing.T) {
 	assert.Equal(t, *mockSearchResult.Repositories[0].Name, *returnedResult.Repositories[0].Name)
 }
 
+func Test_DiscoverRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DiscoverRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "discover_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "language")
+	assert.Contains(t, tool.InputSchema.Properties, "min_stars")
+	assert.Contains(t, tool.InputSchema.Properties, "include_archived")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	yamlParser := &github.Repository{
+		FullName:        github.Ptr("octo-org/yaml-parser"),
+		Name:            github.Ptr("yaml-parser"),
+		Description:     github.Ptr("Fast YAML parser"),
+		Topics:          []string{"yaml", "parser"},
+		StargazersCount: github.Ptr(10),
+	}
+	configLoader := &github.Repository{
+		FullName:        github.Ptr("octo-org/config-loader"),
+		Name:            github.Ptr("config-loader"),
+		Description:     github.Ptr("Loads configuration files, including yaml"),
+		StargazersCount: github.Ptr(500),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []string
+	}{
+		{
+			name: "keywords and topics are merged and ranked",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						result := &github.RepositoriesSearchResult{}
+						switch r.URL.Query().Get("q") {
+						case "yaml in:name,description,readme user:octo-org language:go archived:false":
+							result.Repositories = []*github.Repository{configLoader, yamlParser}
+						case "topic:yaml user:octo-org language:go archived:false":
+							result.Repositories = []*github.Repository{yamlParser}
+						default:
+							w.WriteHeader(http.StatusUnprocessableEntity)
+							_, _ = w.Write([]byte(`{"message": "unexpected query"}`))
+							return
+						}
+						result.Total = github.Ptr(len(result.Repositories))
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(result)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":    "yaml",
+				"topics":   []any{"yaml"},
+				"org":      "octo-org",
+				"language": "go",
+			},
+			expectError:   false,
+			expectedRepos: []string{"octo-org/yaml-parser", "octo-org/config-loader"},
+		},
+		{
+			name: "organization only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					expectQueryParams(t, map[string]string{
+						"q":        "user:octo-org stars:>=100",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+							Total:        github.Ptr(1),
+							Repositories: []*github.Repository{configLoader},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"min_stars":        float64(100),
+				"include_archived": true,
+			},
+			expectError:   false,
+			expectedRepos: []string{"octo-org/config-loader"},
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "yaml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search repositories with query 'yaml in:name,description,readme archived:false'",
+		},
+		{
+			name:           "nothing to discover",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "at least one of query, topics or org must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DiscoverRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TotalCandidates int                    `json:"total_candidates"`
+				Items           []DiscoveredRepository `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, len(tc.expectedRepos), response.TotalCandidates)
+			require.Len(t, response.Items, len(tc.expectedRepos))
+			for i, name := range tc.expectedRepos {
+				assert.Equal(t, name, response.Items[i].FullName)
+				assert.NotEmpty(t, response.Items[i].MatchedBy)
+			}
+		})
+	}
+}
+
 func Test_SearchCode(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultSecondaryRateLimitDelay is how long requests are paused after a secondary rate limit response that doesn't
+// advise a delay, as GitHub recommends waiting at least a minute.
+const defaultSecondaryRateLimitDelay = time.Minute
+
+// SecondaryRateLimiter pauses all requests to GitHub once one of them hits a secondary rate limit, until the delay
+// GitHub advised has passed. Requests made while paused would only extend the penalty, so they wait for the pause
+// to end, or fail at once if it ends after the longest time a request may wait. A request that hit the limit is
+// retried once after the pause, if it can wait that long.
+type SecondaryRateLimiter struct {
+	maxWait time.Duration
+	now     func() time.Time
+	sleep   func(ctx context.Context, d time.Duration) error
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewSecondaryRateLimiter creates a SecondaryRateLimiter whose requests wait at most maxWait for a pause to end.
+func NewSecondaryRateLimiter(maxWait time.Duration) *SecondaryRateLimiter {
+	return &SecondaryRateLimiter{maxWait: maxWait, now: time.Now, sleep: sleepContext}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pause pauses requests for d, unless they're already paused for longer.
+func (l *SecondaryRateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// remainingPause returns how long requests are still paused for.
+func (l *SecondaryRateLimiter) remainingPause() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return max(l.pausedUntil.Sub(l.now()), 0)
+}
+
+// wait waits for the pause of requests to end.
+func (l *SecondaryRateLimiter) wait(ctx context.Context) error {
+	remaining := l.remainingPause()
+	if remaining == 0 {
+		return nil
+	}
+	if remaining > l.maxWait {
+		return fmt.Errorf("requests to GitHub are paused for another %s after hitting a secondary rate limit, try again later", remaining.Round(time.Second))
+	}
+	return l.sleep(ctx, remaining)
+}
+
+// secondaryRateLimitDelay returns the delay GitHub advises after a response, and whether it's a secondary rate limit
+// response. These are 403 or 429 responses that either advise a delay with Retry-After or say they're about a
+// secondary rate limit.
+func secondaryRateLimitDelay(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	// Peek at the message without consuming the body
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || !strings.Contains(strings.ToLower(string(body)), "secondary rate limit") {
+		return 0, false
+	}
+	return defaultSecondaryRateLimitDelay, true
+}
+
+type secondaryRateLimitTransport struct {
+	limiter   *SecondaryRateLimiter
+	transport http.RoundTripper
+}
+
+func (t *secondaryRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retried := false; ; retried = true {
+		if err := t.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		delay, limited := secondaryRateLimitDelay(resp)
+		if !limited {
+			return resp, nil
+		}
+		t.limiter.pause(delay)
+
+		if retried || delay > t.limiter.maxWait || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+// Transport wraps an HTTP transport so its requests are paused along with all others of the limiter.
+func (l *SecondaryRateLimiter) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &secondaryRateLimitTransport{limiter: l, transport: transport}
+}
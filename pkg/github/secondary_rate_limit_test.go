@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func stubResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func Test_secondaryRateLimitDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		resp          *http.Response
+		expectedDelay time.Duration
+		expectLimited bool
+	}{
+		{
+			name:          "retry after header",
+			resp:          stubResponse(http.StatusForbidden, http.Header{"Retry-After": {"30"}}, ""),
+			expectedDelay: 30 * time.Second,
+			expectLimited: true,
+		},
+		{
+			name:          "message without delay",
+			resp:          stubResponse(http.StatusTooManyRequests, nil, `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`),
+			expectedDelay: time.Minute,
+			expectLimited: true,
+		},
+		{
+			name: "permission error",
+			resp: stubResponse(http.StatusForbidden, nil, `{"message": "Resource not accessible by integration"}`),
+		},
+		{
+			name: "success",
+			resp: stubResponse(http.StatusOK, http.Header{"Retry-After": {"30"}}, "{}"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, limited := secondaryRateLimitDelay(tc.resp)
+			assert.Equal(t, tc.expectLimited, limited)
+			assert.Equal(t, tc.expectedDelay, delay)
+
+			// The body is still readable
+			_, err := io.ReadAll(tc.resp.Body)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_SecondaryRateLimiter_Transport(t *testing.T) {
+	limiter := NewSecondaryRateLimiter(time.Minute)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	var slept []time.Duration
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		now = now.Add(d)
+		return nil
+	}
+
+	var requests []string
+	responses := map[string][]*http.Response{}
+	transport := limiter.Transport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var body string
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+		}
+		requests = append(requests, req.URL.Path+" "+body)
+		next := responses[req.URL.Path][0]
+		responses[req.URL.Path] = responses[req.URL.Path][1:]
+		return next, nil
+	}))
+	do := func(method, path, body string) *http.Response {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, err := http.NewRequest(method, "https://api.github.com"+path, reader)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// A request hitting the limit pauses for the advised delay and is retried with its body
+	responses["/repos/owner/repo/issues"] = []*http.Response{
+		stubResponse(http.StatusForbidden, http.Header{"Retry-After": {"20"}}, ""),
+		stubResponse(http.StatusCreated, nil, "{}"),
+	}
+	resp := do(http.MethodPost, "/repos/owner/repo/issues", `{"title":"bug"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{`/repos/owner/repo/issues {"title":"bug"}`, `/repos/owner/repo/issues {"title":"bug"}`}, requests)
+	assert.Equal(t, []time.Duration{20 * time.Second}, slept)
+
+	// A request hitting the limit again isn't retried a second time
+	responses["/user"] = []*http.Response{
+		stubResponse(http.StatusForbidden, http.Header{"Retry-After": {"10"}}, ""),
+		stubResponse(http.StatusForbidden, http.Header{"Retry-After": {"10"}}, ""),
+	}
+	resp = do(http.MethodGet, "/user", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Len(t, requests, 4)
+
+	// Other requests wait for the pause to end
+	responses["/repos/owner/repo"] = []*http.Response{stubResponse(http.StatusOK, nil, "{}")}
+	resp = do(http.MethodGet, "/repos/owner/repo", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{20 * time.Second, 10 * time.Second, 10 * time.Second}, slept)
+
+	// A pause longer than requests may wait makes them fail at once, and the request hitting it isn't retried
+	responses["/repos/owner/repo/pulls"] = []*http.Response{
+		stubResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"300"}}, ""),
+	}
+	resp = do(http.MethodGet, "/repos/owner/repo/pulls", "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "paused for another 5m0s after hitting a secondary rate limit")
+	assert.Len(t, requests, 6)
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -173,12 +172,7 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list advisories: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, advisories), nil
 		}
 }
 
@@ -262,12 +256,7 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository advisories: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, advisories), nil
 		}
 }
 
@@ -307,12 +296,7 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get advisory: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(advisory)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal advisory: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, advisory), nil
 		}
 }
 
@@ -387,11 +371,6 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization repository advisories: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(advisories)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal advisories: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(ctx, advisories), nil
 		}
 }
@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	securityAlertTypeDependabot     = "dependabot"
+	securityAlertTypeCodeScanning   = "code_scanning"
+	securityAlertTypeSecretScanning = "secret_scanning"
+)
+
+// RepositorySecurityOverview aggregates the open security alerts of a single repository.
+type RepositorySecurityOverview struct {
+	Repository     string         `json:"repository"`
+	OpenAlerts     int            `json:"open_alerts"`
+	Severity       map[string]int `json:"severity"`
+	Dependabot     int            `json:"dependabot"`
+	CodeScanning   int            `json:"code_scanning"`
+	SecretScanning int            `json:"secret_scanning"`
+}
+
+// securityAlertSummary is the repository and severity of a single alert, which is all the overview needs.
+type securityAlertSummary struct {
+	repository string
+	severity   string
+}
+
+// securityAlertPage fetches a page of open alerts of a given type. It returns the alerts and the response,
+// whose cursor or page number is used to fetch the next page.
+type securityAlertPage func(ctx context.Context, client *github.Client, org string, opts github.ListOptions, cursor github.ListCursorOptions) ([]securityAlertSummary, *github.Response, error)
+
+var securityAlertPages = map[string]securityAlertPage{
+	securityAlertTypeDependabot: func(ctx context.Context, client *github.Client, org string, opts github.ListOptions, cursor github.ListCursorOptions) ([]securityAlertSummary, *github.Response, error) {
+		alerts, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, &github.ListAlertsOptions{
+			State:             github.Ptr("open"),
+			ListOptions:       opts,
+			ListCursorOptions: cursor,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		summaries := make([]securityAlertSummary, 0, len(alerts))
+		for _, alert := range alerts {
+			severity := alert.GetSecurityAdvisory().GetSeverity()
+			if severity == "" {
+				severity = alert.GetSecurityVulnerability().GetSeverity()
+			}
+			summaries = append(summaries, securityAlertSummary{repository: alert.GetRepository().GetFullName(), severity: severity})
+		}
+		return summaries, resp, nil
+	},
+	securityAlertTypeCodeScanning: func(ctx context.Context, client *github.Client, org string, opts github.ListOptions, cursor github.ListCursorOptions) ([]securityAlertSummary, *github.Response, error) {
+		alerts, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, &github.AlertListOptions{
+			State:             "open",
+			ListOptions:       opts,
+			ListCursorOptions: cursor,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		summaries := make([]securityAlertSummary, 0, len(alerts))
+		for _, alert := range alerts {
+			// Security rules carry a CVSS-like level, other rules only an error/warning/note severity.
+			severity := alert.GetRule().GetSecuritySeverityLevel()
+			if severity == "" {
+				severity = alert.GetRule().GetSeverity()
+			}
+			summaries = append(summaries, securityAlertSummary{repository: alert.GetRepository().GetFullName(), severity: severity})
+		}
+		return summaries, resp, nil
+	},
+	securityAlertTypeSecretScanning: func(ctx context.Context, client *github.Client, org string, opts github.ListOptions, cursor github.ListCursorOptions) ([]securityAlertSummary, *github.Response, error) {
+		alerts, resp, err := client.SecretScanning.ListAlertsForOrg(ctx, org, &github.SecretScanningAlertListOptions{
+			State:             "open",
+			ListOptions:       opts,
+			ListCursorOptions: cursor,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		summaries := make([]securityAlertSummary, 0, len(alerts))
+		for _, alert := range alerts {
+			// Secret scanning alerts have no severity, every exposed secret is treated as critical.
+			summaries = append(summaries, securityAlertSummary{repository: alert.GetRepository().GetFullName(), severity: "critical"})
+		}
+		return summaries, resp, nil
+	},
+}
+
+// securityAlertResult holds the outcome of collecting the open alerts of a single type.
+type securityAlertResult struct {
+	alerts    []securityAlertSummary
+	truncated bool
+	resp      *github.Response
+	err       error
+}
+
+// collectSecurityAlerts pages through the open alerts of an organization, following either the cursor or the
+// page number returned by the API, and stops after maxPages pages. The alerts of repositories the owner policy
+// doesn't allow are skipped.
+func collectSecurityAlerts(ctx context.Context, client *github.Client, org string, fetch securityAlertPage, maxPages int) securityAlertResult {
+	var result securityAlertResult
+	opts := github.ListOptions{PerPage: 100}
+	cursor := github.ListCursorOptions{}
+	for page := 0; page < maxPages; page++ {
+		alerts, resp, err := fetch(ctx, client, org, opts, cursor)
+		if err != nil {
+			return securityAlertResult{resp: resp, err: err}
+		}
+		_ = resp.Body.Close()
+		for _, alert := range alerts {
+			owner, repo, _ := strings.Cut(alert.repository, "/")
+			if repositoryAllowed(ctx, owner, repo) {
+				result.alerts = append(result.alerts, alert)
+			}
+		}
+
+		switch {
+		case resp.After != "":
+			cursor.After = resp.After
+		case resp.NextPage != 0:
+			opts.Page = resp.NextPage
+		default:
+			return result
+		}
+	}
+	result.truncated = true
+	return result
+}
+
+func GetOrgSecurityOverview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_security_overview",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECURITY_OVERVIEW_DESCRIPTION", "Aggregate the open Dependabot, code scanning and secret scanning alerts of an organization per repository, with severity counts. Repositories are ranked worst first: by critical, then high severity alerts, then total open alerts. Exposed secrets count as critical.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_SECURITY_OVERVIEW_USER_TITLE", "Get organization security overview"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithArray("alert_types",
+				mcp.Description("Alert types to aggregate. Defaults to all of them."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+						"enum": []string{securityAlertTypeDependabot, securityAlertTypeCodeScanning, securityAlertTypeSecretScanning},
+					},
+				),
+			),
+			mcp.WithNumber("max_pages",
+				mcp.Description("Maximum number of pages of 100 alerts to fetch per alert type. Defaults to 10."),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of repositories to return. Defaults to 30."),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertTypes, err := OptionalStringArrayParam(request, "alert_types")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxPages, err := OptionalIntParamWithDefault(request, "max_pages", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if len(alertTypes) == 0 {
+				alertTypes = []string{securityAlertTypeDependabot, securityAlertTypeCodeScanning, securityAlertTypeSecretScanning}
+			}
+			for _, alertType := range alertTypes {
+				if _, ok := securityAlertPages[alertType]; !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("unsupported alert type: %s", alertType)), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := make([]securityAlertResult, len(alertTypes))
+			alertErrs := fanOut(ctx, alertTypes, DefaultFanOutConcurrency, func(ctx context.Context, i int, alertType string) error {
+				results[i] = collectSecurityAlerts(ctx, client, org, securityAlertPages[alertType], maxPages)
+				return results[i].err
+			})
+			for i, err := range alertErrs {
+				if err != nil {
+					results[i].err = err
+				}
+			}
+
+			overviews := map[string]*RepositorySecurityOverview{}
+			errs := map[string]string{}
+			truncated := map[string]bool{}
+			for i, alertType := range alertTypes {
+				result := results[i]
+				if result.err != nil {
+					// Fail only when every alert type failed, a single disabled product should not hide the others.
+					if len(alertTypes) == 1 {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to list %s alerts for organization '%s'", alertType, org),
+							result.resp,
+							result.err,
+						), nil
+					}
+					errs[alertType] = result.err.Error()
+					continue
+				}
+				if result.truncated {
+					truncated[alertType] = true
+				}
+				for _, alert := range result.alerts {
+					overview, ok := overviews[alert.repository]
+					if !ok {
+						overview = &RepositorySecurityOverview{Repository: alert.repository, Severity: map[string]int{}}
+						overviews[alert.repository] = overview
+					}
+					overview.OpenAlerts++
+					overview.Severity[alert.severity]++
+					switch alertType {
+					case securityAlertTypeDependabot:
+						overview.Dependabot++
+					case securityAlertTypeCodeScanning:
+						overview.CodeScanning++
+					case securityAlertTypeSecretScanning:
+						overview.SecretScanning++
+					}
+				}
+			}
+			if len(errs) == len(alertTypes) {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list security alerts for organization '%s': %v", org, errs)), nil
+			}
+
+			repositories := make([]*RepositorySecurityOverview, 0, len(overviews))
+			for _, overview := range overviews {
+				repositories = append(repositories, overview)
+			}
+			sort.Slice(repositories, func(i, j int) bool {
+				a, b := repositories[i], repositories[j]
+				if a.Severity["critical"] != b.Severity["critical"] {
+					return a.Severity["critical"] > b.Severity["critical"]
+				}
+				if a.Severity["high"] != b.Severity["high"] {
+					return a.Severity["high"] > b.Severity["high"]
+				}
+				if a.OpenAlerts != b.OpenAlerts {
+					return a.OpenAlerts > b.OpenAlerts
+				}
+				return a.Repository < b.Repository
+			})
+
+			totalRepositories := len(repositories)
+			if len(repositories) > limit {
+				repositories = repositories[:limit]
+			}
+
+			response := map[string]any{
+				"org":               org,
+				"repositories":      repositories,
+				"totalRepositories": totalRepositories,
+			}
+			if len(truncated) > 0 {
+				response["truncated"] = truncated
+			}
+			if len(errs) > 0 {
+				response["errors"] = errs
+			}
+
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgSecurityOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecurityOverview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_security_overview", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "alert_types")
+	assert.Contains(t, tool.InputSchema.Properties, "max_pages")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	api := &github.Repository{FullName: github.Ptr("octo-org/api")}
+	web := &github.Repository{FullName: github.Ptr("octo-org/web")}
+
+	dependabotAlerts := []*github.DependabotAlert{
+		{Repository: web, SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("high")}},
+		{Repository: web, SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("low")}},
+		{Repository: api, SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("medium")}},
+	}
+	codeScanningAlerts := []*github.Alert{
+		{Repository: api, Rule: &github.Rule{Severity: github.Ptr("error"), SecuritySeverityLevel: github.Ptr("critical")}},
+		{Repository: web, Rule: &github.Rule{Severity: github.Ptr("warning")}},
+	}
+	secretScanningAlerts := []*github.SecretScanningAlert{
+		{Repository: api},
+	}
+
+	forbidden := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Advanced Security must be enabled"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []RepositorySecurityOverview
+		expectedErrors map[string]string
+	}{
+		{
+			name: "aggregates all alert types",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":    "open",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, dependabotAlerts),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					codeScanningAlerts,
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					secretScanningAlerts,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError: false,
+			expectedRepos: []RepositorySecurityOverview{
+				{
+					Repository:     "octo-org/api",
+					OpenAlerts:     3,
+					Severity:       map[string]int{"critical": 2, "medium": 1},
+					Dependabot:     1,
+					CodeScanning:   1,
+					SecretScanning: 1,
+				},
+				{
+					Repository:   "octo-org/web",
+					OpenAlerts:   3,
+					Severity:     map[string]int{"high": 1, "low": 1, "warning": 1},
+					Dependabot:   2,
+					CodeScanning: 1,
+				},
+			},
+		},
+		{
+			name: "disabled product is reported without failing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsDependabotAlertsByOrg,
+					dependabotAlerts[:1],
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					forbidden,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":         "octo-org",
+				"alert_types": []any{"dependabot", "code_scanning"},
+			},
+			expectError: false,
+			expectedRepos: []RepositorySecurityOverview{
+				{
+					Repository: "octo-org/web",
+					OpenAlerts: 1,
+					Severity:   map[string]int{"high": 1},
+					Dependabot: 1,
+				},
+			},
+			expectedErrors: map[string]string{
+				"code_scanning": "Advanced Security must be enabled",
+			},
+		},
+		{
+			name: "single alert type fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					forbidden,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":         "octo-org",
+				"alert_types": []any{"secret_scanning"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list secret_scanning alerts for organization 'octo-org'",
+		},
+		{
+			name:         "unsupported alert type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":         "octo-org",
+				"alert_types": []any{"license"},
+			},
+			expectError:    true,
+			expectedErrMsg: "unsupported alert type: license",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgSecurityOverview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Repositories      []RepositorySecurityOverview `json:"repositories"`
+				TotalRepositories int                          `json:"totalRepositories"`
+				Errors            map[string]string            `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedRepos, response.Repositories)
+			assert.Equal(t, len(tc.expectedRepos), response.TotalRepositories)
+			require.Len(t, response.Errors, len(tc.expectedErrors))
+			for alertType, msg := range tc.expectedErrors {
+				assert.Contains(t, response.Errors[alertType], msg)
+			}
+		})
+	}
+}
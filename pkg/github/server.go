@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -333,11 +334,19 @@ func (p PaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, error) {
 	return cursor.ToGraphQLParams()
 }
 
-func MarshalledTextResult(v any) *mcp.CallToolResult {
+// MarshalledTextResult returns a result with v as JSON text, written in the output format of the server, compact
+// unless it was configured with another. Tools write their JSON results with it rather than marshalling them
+// themselves, so that all of them follow the output format.
+func MarshalledTextResult(ctx context.Context, v any) *mcp.CallToolResult {
 	data, err := json.Marshal(v)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("failed to marshal text result to json", err)
 	}
 
+	if format := resultFormat(ctx); format != ResultFormatCompact {
+		if text, ok := formatJSON(string(data), format); ok {
+			return mcp.NewToolResultText(text)
+		}
+	}
 	return mcp.NewToolResultText(string(data))
 }
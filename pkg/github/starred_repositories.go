@@ -0,0 +1,311 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultStarredStaleDays is the default number of days without a push after which a starred repository is
+	// suggested for unstarring.
+	DefaultStarredStaleDays = 365
+	// maxAnalyzedStars is the number of starred repositories analyze_starred_repositories reads at most.
+	maxAnalyzedStars = 1000
+	// minCrowdedTopicStars is the number of starred repositories a topic needs to be reported as crowded.
+	minCrowdedTopicStars = 3
+	// maxCrowdedTopics is the number of crowded topics reported at most.
+	maxCrowdedTopics = 20
+	// maxUnstarRepositories is the number of repositories unstar_repositories unstars in one call at most.
+	maxUnstarRepositories = 100
+)
+
+// UnstarCandidate is a starred repository suggested for unstarring.
+type UnstarCandidate struct {
+	FullName  string `json:"full_name"`
+	HTMLURL   string `json:"html_url"`
+	Stars     int    `json:"stars"`
+	StarredAt string `json:"starred_at,omitempty"`
+	PushedAt  string `json:"pushed_at,omitempty"`
+	// Reasons are "archived" when the repository is read-only, and "stale" when it wasn't pushed to for the
+	// number of days asked for.
+	Reasons []string `json:"reasons"`
+}
+
+// CrowdedTopic is a topic shared by several starred repositories.
+type CrowdedTopic struct {
+	Topic string `json:"topic"`
+	// Repositories are the full names of the starred repositories with the topic, most starred first.
+	Repositories []string `json:"repositories"`
+}
+
+// unstarReasons returns why a starred repository is suggested for unstarring, if it is.
+func unstarReasons(repo *github.Repository, staleBefore time.Time) []string {
+	var reasons []string
+	if repo.GetArchived() {
+		reasons = append(reasons, "archived")
+	}
+	if repo.PushedAt != nil && repo.PushedAt.Before(staleBefore) {
+		reasons = append(reasons, "stale")
+	}
+	return reasons
+}
+
+// crowdedTopics returns the topics shared by at least minCrowdedTopicStars starred repositories, the most shared
+// first.
+func crowdedTopics(stars []*github.StarredRepository) []CrowdedTopic {
+	byTopic := map[string][]*github.Repository{}
+	for _, star := range stars {
+		for _, topic := range star.GetRepository().Topics {
+			byTopic[topic] = append(byTopic[topic], star.GetRepository())
+		}
+	}
+
+	topics := []CrowdedTopic{}
+	for topic, repos := range byTopic {
+		if len(repos) < minCrowdedTopicStars {
+			continue
+		}
+		sort.SliceStable(repos, func(i, j int) bool {
+			return repos[i].GetStargazersCount() > repos[j].GetStargazersCount()
+		})
+		names := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			names = append(names, repo.GetFullName())
+		}
+		topics = append(topics, CrowdedTopic{Topic: topic, Repositories: names})
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if len(topics[i].Repositories) != len(topics[j].Repositories) {
+			return len(topics[i].Repositories) > len(topics[j].Repositories)
+		}
+		return topics[i].Topic < topics[j].Topic
+	})
+	if len(topics) > maxCrowdedTopics {
+		topics = topics[:maxCrowdedTopics]
+	}
+	return topics
+}
+
+// AnalyzeStarredRepositories creates a tool to suggest which repositories the authenticated user could unstar.
+func AnalyzeStarredRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_starred_repositories",
+			mcp.WithDescription(t("TOOL_ANALYZE_STARRED_REPOSITORIES_DESCRIPTION", "Analyze the repositories the authenticated user starred and suggest candidates to unstar: archived repositories and repositories nobody pushed to for a number of days. Topics shared by several starred repositories are listed with their repositories, most starred first, to help pick the ones worth keeping. Use unstar_repositories to unstar the chosen ones.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ANALYZE_STARRED_REPOSITORIES_USER_TITLE", "Analyze starred repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("stale_days",
+				mcp.Description(fmt.Sprintf("Number of days without a push after which a repository is suggested (default %d)", DefaultStarredStaleDays)),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Number of starred repositories to analyze, most recently starred first (default and max %d)", maxAnalyzedStars)),
+				mcp.Min(1),
+				mcp.Max(maxAnalyzedStars),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			staleDays, err := OptionalIntParamWithDefault(request, "stale_days", DefaultStarredStaleDays)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if staleDays < 1 {
+				return mcp.NewToolResultError("stale_days must be at least 1"), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", maxAnalyzedStars)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > maxAnalyzedStars {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxAnalyzedStars)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var stars []*github.StarredRepository
+			opts := &github.ActivityListStarredOptions{
+				Sort:        "created",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: min(limit, 100)},
+			}
+			truncated := false
+			for {
+				page, resp, err := client.Activity.ListStarred(ctx, "", opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list starred repositories",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				stars = append(stars, page...)
+				if len(stars) >= limit {
+					truncated = len(stars) > limit || resp.NextPage != 0
+					stars = stars[:limit]
+					break
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			stars = slices.DeleteFunc(stars, func(star *github.StarredRepository) bool {
+				return !repositoryAllowed(ctx, star.GetRepository().GetOwner().GetLogin(), star.GetRepository().GetName())
+			})
+
+			staleBefore := time.Now().AddDate(0, 0, -staleDays)
+			candidates := []UnstarCandidate{}
+			archived, stale := 0, 0
+			for _, star := range stars {
+				repo := star.GetRepository()
+				reasons := unstarReasons(repo, staleBefore)
+				if len(reasons) == 0 {
+					continue
+				}
+				for _, reason := range reasons {
+					switch reason {
+					case "archived":
+						archived++
+					case "stale":
+						stale++
+					}
+				}
+				candidates = append(candidates, UnstarCandidate{
+					FullName:  repo.GetFullName(),
+					HTMLURL:   repo.GetHTMLURL(),
+					Stars:     repo.GetStargazersCount(),
+					StarredAt: formatOptionalTimestamp(star.StarredAt),
+					PushedAt:  formatOptionalTimestamp(repo.PushedAt),
+					Reasons:   reasons,
+				})
+			}
+
+			topics := crowdedTopics(stars)
+			return MarshalledTextResult(ctx, map[string]any{
+				"analyzed":  len(stars),
+				"truncated": truncated,
+				"summary": map[string]int{
+					"candidates":     len(candidates),
+					"archived":       archived,
+					"stale":          stale,
+					"crowded_topics": len(topics),
+				},
+				"candidates":     candidates,
+				"crowded_topics": topics,
+			}), nil
+		}
+}
+
+// UnstarRepositories creates a tool to unstar repositories in batch.
+func UnstarRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unstar_repositories",
+			mcp.WithDescription(t("TOOL_UNSTAR_REPOSITORIES_DESCRIPTION", "Unstar repositories of the authenticated user in batch, e.g. candidates returned by analyze_starred_repositories. By default it only reports which of the repositories are starred and would be unstarred: show them to the user and call it again with dry_run set to false once they confirm.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UNSTAR_REPOSITORIES_USER_TITLE", "Unstar repositories"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Full names (owner/repo) of the repositories to unstar, at most %d", maxUnstarRepositories)),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only report the repositories that would be unstarred, without unstarring them (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(repositories) > maxUnstarRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be unstarred at once", maxUnstarRepositories)), nil
+			}
+			for _, fullName := range repositories {
+				if owner, repo, ok := strings.Cut(fullName, "/"); !ok || owner == "" || repo == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid repository %q, use the owner/repo format", fullName)), nil
+				}
+			}
+			dryRun, err := OptionalBoolParamWithDefault(request, "dry_run", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			starred := make([]bool, len(repositories))
+			errs := fanOut(ctx, repositories, DefaultFanOutConcurrency, func(ctx context.Context, i int, fullName string) error {
+				owner, repo, _ := strings.Cut(fullName, "/")
+				isStarred, resp, err := client.Activity.IsStarred(ctx, owner, repo)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				starred[i] = isStarred
+				if !isStarred || dryRun {
+					return nil
+				}
+				resp, err = client.Activity.Unstar(ctx, owner, repo)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			unstarred := []string{}
+			notStarred := []string{}
+			var failures []map[string]string
+			for i, fullName := range repositories {
+				switch {
+				case errs[i] != nil:
+					failures = append(failures, map[string]string{"repository": fullName, "error": errs[i].Error()})
+				case !starred[i]:
+					notStarred = append(notStarred, fullName)
+				default:
+					unstarred = append(unstarred, fullName)
+				}
+			}
+
+			response := map[string]any{
+				"dry_run": dryRun,
+			}
+			if dryRun {
+				response["would_unstar"] = unstarred
+			} else {
+				response["unstarred"] = unstarred
+			}
+			if len(notStarred) > 0 {
+				response["not_starred"] = notStarred
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,236 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AnalyzeStarredRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AnalyzeStarredRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "analyze_starred_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "stale_days")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	recent := &github.Timestamp{Time: time.Now().AddDate(0, 0, -10)}
+	old := &github.Timestamp{Time: time.Now().AddDate(-3, 0, 0)}
+	star := func(fullName string, stars int, pushedAt *github.Timestamp, archived bool, topics ...string) *github.StarredRepository {
+		return &github.StarredRepository{
+			StarredAt: recent,
+			Repository: &github.Repository{
+				FullName:        github.Ptr(fullName),
+				StargazersCount: github.Ptr(stars),
+				PushedAt:        pushedAt,
+				Archived:        github.Ptr(archived),
+				Topics:          topics,
+			},
+		}
+	}
+	stars := []*github.StarredRepository{
+		star("acme/http", 900, recent, false, "http-client", "go"),
+		star("acme/old-http", 50, old, false, "http-client"),
+		star("acme/frozen", 300, old, true, "http-client", "go"),
+		star("acme/cli", 10, recent, false, "go"),
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		expectedPerPage    string
+		expectedAnalyzed   int
+		expectedTruncated  bool
+		expectedCandidates []UnstarCandidate
+		expectedTopics     []CrowdedTopic
+	}{
+		{
+			name:             "archived and stale repositories suggested",
+			requestArgs:      map[string]any{},
+			expectedPerPage:  "100",
+			expectedAnalyzed: 4,
+			expectedCandidates: []UnstarCandidate{
+				{FullName: "acme/old-http", Stars: 50, Reasons: []string{"stale"}},
+				{FullName: "acme/frozen", Stars: 300, Reasons: []string{"archived", "stale"}},
+			},
+			expectedTopics: []CrowdedTopic{
+				{Topic: "go", Repositories: []string{"acme/http", "acme/frozen", "acme/cli"}},
+				{Topic: "http-client", Repositories: []string{"acme/http", "acme/frozen", "acme/old-http"}},
+			},
+		},
+		{
+			name:              "limited to the most recent stars",
+			requestArgs:       map[string]any{"limit": float64(2), "stale_days": float64(5000)},
+			expectedPerPage:   "2",
+			expectedAnalyzed:  2,
+			expectedTruncated: true,
+			expectedTopics:    []CrowdedTopic{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarred,
+					expectQueryParams(t, map[string]string{
+						"sort":      "created",
+						"direction": "desc",
+						"per_page":  tc.expectedPerPage,
+					}).andThen(mockResponse(t, http.StatusOK, stars)),
+				),
+			))
+			_, handler := AnalyzeStarredRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Analyzed      int               `json:"analyzed"`
+				Truncated     bool              `json:"truncated"`
+				Candidates    []UnstarCandidate `json:"candidates"`
+				CrowdedTopics []CrowdedTopic    `json:"crowded_topics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedAnalyzed, response.Analyzed)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+			require.Len(t, response.Candidates, len(tc.expectedCandidates))
+			for i, expected := range tc.expectedCandidates {
+				assert.Equal(t, expected.FullName, response.Candidates[i].FullName)
+				assert.Equal(t, expected.Stars, response.Candidates[i].Stars)
+				assert.Equal(t, expected.Reasons, response.Candidates[i].Reasons)
+				assert.NotEmpty(t, response.Candidates[i].PushedAt)
+			}
+			assert.Equal(t, tc.expectedTopics, response.CrowdedTopics)
+		})
+	}
+}
+
+func Test_UnstarRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnstarRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unstar_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"repositories"})
+
+	newClient := func(unstarred *[]string) *http.Client {
+		var mu sync.Mutex
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetUserStarredByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, "/acme/gone"):
+						w.WriteHeader(http.StatusNotFound)
+					case strings.HasSuffix(r.URL.Path, "/acme/broken"):
+						w.WriteHeader(http.StatusInternalServerError)
+					default:
+						w.WriteHeader(http.StatusNoContent)
+					}
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteUserStarredByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					defer mu.Unlock()
+					*unstarred = append(*unstarred, strings.TrimPrefix(r.URL.Path, "/user/starred/"))
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedUnstarred []string
+		expectedResponse  map[string]any
+	}{
+		{
+			name: "dry run by default",
+			requestArgs: map[string]any{
+				"repositories": []any{"acme/old-http", "acme/gone", "acme/broken"},
+			},
+			expectedResponse: map[string]any{
+				"dry_run":      true,
+				"would_unstar": []any{"acme/old-http"},
+				"not_starred":  []any{"acme/gone"},
+			},
+		},
+		{
+			name: "unstar once confirmed",
+			requestArgs: map[string]any{
+				"repositories": []any{"acme/old-http", "acme/frozen", "acme/gone"},
+				"dry_run":      false,
+			},
+			expectedUnstarred: []string{"acme/frozen", "acme/old-http"},
+			expectedResponse: map[string]any{
+				"dry_run":     false,
+				"unstarred":   []any{"acme/old-http", "acme/frozen"},
+				"not_starred": []any{"acme/gone"},
+			},
+		},
+		{
+			name: "invalid repository name",
+			requestArgs: map[string]any{
+				"repositories": []any{"old-http"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid repository "old-http"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var unstarred []string
+			client := github.NewClient(newClient(&unstarred))
+			_, handler := UnstarRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			errs, _ := response["errors"].([]any)
+			delete(response, "errors")
+			assert.Equal(t, tc.expectedResponse, response)
+			assert.ElementsMatch(t, tc.expectedUnstarred, unstarred)
+			if tc.requestArgs["dry_run"] == nil {
+				require.Len(t, errs, 1)
+				assert.Equal(t, "acme/broken", errs[0].(map[string]any)["repository"])
+			}
+		})
+	}
+}
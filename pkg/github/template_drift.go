@@ -0,0 +1,261 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxTemplateDriftDiffLength is the number of characters of a file's diff get_template_drift returns.
+const maxTemplateDriftDiffLength = 20000
+
+// defaultTemplateDriftPaths are the files get_template_drift compares when no paths are given. Paths ending in a
+// slash match every file in the directory.
+var defaultTemplateDriftPaths = []string{
+	".github/workflows/",
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".github/dependabot.yml",
+	".github/pull_request_template.md",
+	".editorconfig",
+	".golangci.yml",
+	".golangci.yaml",
+	".eslintrc",
+	".eslintrc.json",
+	".eslintrc.js",
+	".eslintrc.yml",
+	"eslint.config.js",
+	".prettierrc",
+	".prettierrc.json",
+	".markdownlint.json",
+	".pre-commit-config.yaml",
+	"ruff.toml",
+	".flake8",
+}
+
+// TemplateDrift is a compared file that differs between a repository and its template.
+type TemplateDrift struct {
+	Path string `json:"path"`
+	// Status is "modified", "missing" if the repository doesn't have the template's file, or "extra" if the
+	// template doesn't have the repository's file.
+	Status        string `json:"status"`
+	Diff          string `json:"diff,omitempty"`
+	DiffTruncated bool   `json:"diff_truncated,omitempty"`
+}
+
+// matchesDriftPath reports whether a file is one of the paths to compare.
+func matchesDriftPath(paths []string, file string) bool {
+	for _, p := range paths {
+		if strings.HasSuffix(p, "/") && strings.HasPrefix(file, p) || file == p {
+			return true
+		}
+	}
+	return false
+}
+
+// driftTreeFiles returns the blob SHAs of the files to compare of a repository's default branch, keyed by path.
+func driftTreeFiles(ctx context.Context, client *github.Client, repository *github.Repository, paths []string) (map[string]string, *github.Response, error) {
+	tree, resp, err := client.Git.GetTree(ctx, repository.GetOwner().GetLogin(), repository.GetName(), repository.GetDefaultBranch(), true)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get git tree of %s: %w", repository.GetFullName(), err)
+	}
+	_ = resp.Body.Close()
+	files := map[string]string{}
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" && matchesDriftPath(paths, entry.GetPath()) {
+			files[entry.GetPath()] = entry.GetSHA()
+		}
+	}
+	return files, resp, nil
+}
+
+// getFileText returns the content of a file of a repository's default branch.
+func getFileText(ctx context.Context, client *github.Client, repository *github.Repository, path string) (string, error) {
+	file, _, resp, err := client.Repositories.GetContents(ctx, repository.GetOwner().GetLogin(), repository.GetName(), path,
+		&github.RepositoryContentGetOptions{Ref: repository.GetDefaultBranch()})
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s of %s: %w", path, repository.GetFullName(), err)
+	}
+	_ = resp.Body.Close()
+	return file.GetContent()
+}
+
+// GetTemplateDrift creates a tool to compare the key files of a repository against a template repository.
+func GetTemplateDrift(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_template_drift",
+			mcp.WithDescription(t("TOOL_GET_TEMPLATE_DRIFT_DESCRIPTION", "Compare the key files of a repository, such as its GitHub Actions workflows, CODEOWNERS, Dependabot and linter configurations, against a template repository, and report the files that drifted with a unified diff from the template to the repository. The template defaults to the repository the repository was created from.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TEMPLATE_DRIFT_USER_TITLE", "Get template drift"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("template_owner",
+				mcp.Description("Owner of the template repository. Required with template_repo if the repository wasn't created from a template"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Files to compare, paths ending in '/' matching every file in a directory. Defaults to the workflows, CODEOWNERS, Dependabot, pull request template, editor and common linter configuration files"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithBoolean("include_diffs",
+				mcp.Description("Include the diff of each modified file (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateOwner, err := OptionalParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := OptionalParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (templateOwner == "") != (templateRepo == "") {
+				return mcp.NewToolResultError("template_owner and template_repo must be provided together"), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 {
+				paths = defaultTemplateDriftPaths
+			}
+			includeDiffs, err := OptionalBoolParamWithDefault(request, "include_diffs", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if templateOwner == "" {
+				if repository.TemplateRepository == nil {
+					return mcp.NewToolResultError("the repository wasn't created from a template, provide template_owner and template_repo"), nil
+				}
+				templateOwner = repository.GetTemplateRepository().GetOwner().GetLogin()
+				templateRepo = repository.GetTemplateRepository().GetName()
+			}
+			template, resp, err := client.Repositories.Get(ctx, templateOwner, templateRepo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get template repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			repoFiles, resp, err := driftTreeFiles(ctx, client, repository, paths)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository files", resp, err), nil
+			}
+			templateFiles, resp, err := driftTreeFiles(ctx, client, template, paths)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list template files", resp, err), nil
+			}
+
+			// Files with the same blob SHA have the same content, so only the others are read.
+			inSync := 0
+			drifts := []*TemplateDrift{}
+			for path, sha := range templateFiles {
+				switch repoSHA, ok := repoFiles[path]; {
+				case !ok:
+					drifts = append(drifts, &TemplateDrift{Path: path, Status: "missing"})
+				case repoSHA == sha:
+					inSync++
+				default:
+					drifts = append(drifts, &TemplateDrift{Path: path, Status: "modified"})
+				}
+			}
+			for path := range repoFiles {
+				if _, ok := templateFiles[path]; !ok {
+					drifts = append(drifts, &TemplateDrift{Path: path, Status: "extra"})
+				}
+			}
+			sort.Slice(drifts, func(i, j int) bool { return drifts[i].Path < drifts[j].Path })
+
+			errs := make([]error, len(drifts))
+			if includeDiffs {
+				errs = fanOut(ctx, drifts, DefaultFanOutConcurrency, func(ctx context.Context, _ int, drift *TemplateDrift) error {
+					if drift.Status != "modified" {
+						return nil
+					}
+					templateText, err := getFileText(ctx, client, template, drift.Path)
+					if err != nil {
+						return err
+					}
+					repoText, err := getFileText(ctx, client, repository, drift.Path)
+					if err != nil {
+						return err
+					}
+					diff, ok := unifiedDiff(template.GetFullName()+"/"+drift.Path, repository.GetFullName()+"/"+drift.Path, templateText, repoText)
+					if !ok {
+						return fmt.Errorf("%s differs in too many lines to diff", drift.Path)
+					}
+					if len(diff) > maxTemplateDriftDiffLength {
+						diff = diff[:maxTemplateDriftDiffLength]
+						drift.DiffTruncated = true
+					}
+					drift.Diff = diff
+					return nil
+				})
+			}
+
+			var failures []map[string]string
+			for i, drift := range drifts {
+				if errs[i] != nil {
+					failures = append(failures, map[string]string{"path": drift.Path, "error": errs[i].Error()})
+				}
+			}
+
+			response := map[string]any{
+				"repository": repository.GetFullName(),
+				"template":   template.GetFullName(),
+				"in_sync":    inSync,
+				"drifted":    drifts,
+			}
+			if len(failures) > 0 {
+				response["errors"] = failures
+			}
+			return MarshalledTextResult(ctx, response), nil
+		}
+}
@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetTemplateDrift(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetTemplateDrift(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_template_drift", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "template_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "template_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "include_diffs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	repository := func(owner, name, branch string, template *github.Repository) *github.Repository {
+		return &github.Repository{
+			Owner:              &github.User{Login: github.Ptr(owner)},
+			Name:               github.Ptr(name),
+			FullName:           github.Ptr(owner + "/" + name),
+			DefaultBranch:      github.Ptr(branch),
+			TemplateRepository: template,
+		}
+	}
+	template := repository("org", "template", "main", nil)
+	repositories := map[string]*github.Repository{
+		"/repos/org/service":  repository("org", "service", "trunk", template),
+		"/repos/org/template": template,
+		"/repos/org/scratch":  repository("org", "scratch", "main", nil),
+	}
+	blob := func(path, sha string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(path), SHA: github.Ptr(sha), Type: github.Ptr("blob")}
+	}
+	trees := map[string]*github.Tree{
+		"/repos/org/template/git/trees/main": {Entries: []*github.TreeEntry{
+			blob(".github/workflows/ci.yml", "ci-template"),
+			blob(".github/workflows/release.yml", "release"),
+			blob(".editorconfig", "editorconfig"),
+			blob("README.md", "readme-template"),
+		}},
+		"/repos/org/service/git/trees/trunk": {Entries: []*github.TreeEntry{
+			blob(".github/workflows/ci.yml", "ci-service"),
+			blob(".github/workflows/deploy.yml", "deploy"),
+			blob(".editorconfig", "editorconfig"),
+			blob("README.md", "readme-service"),
+		}},
+	}
+	contents := map[string]string{
+		"/repos/org/template/contents/.github/workflows/ci.yml": "name: CI\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n",
+		"/repos/org/service/contents/.github/workflows/ci.yml":  "name: CI\non: push\njobs:\n  test:\n    runs-on: self-hosted\n",
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				repo, ok := repositories[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, repo)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, trees[r.URL.Path])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Both files are read from their repository's default branch.
+				assert.Equal(t, map[string]string{
+					"/repos/org/template/contents/.github/workflows/ci.yml": "main",
+					"/repos/org/service/contents/.github/workflows/ci.yml":  "trunk",
+				}[r.URL.Path], r.URL.Query().Get("ref"))
+				mockResponse(t, http.StatusOK, &github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(contents[r.URL.Path]))),
+				})(w, r)
+			}),
+		),
+	))
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedDrift  []TemplateDrift
+		expectedInSync int
+	}{
+		{
+			name: "drift from the repository's template",
+			requestArgs: map[string]any{
+				"owner": "org",
+				"repo":  "service",
+			},
+			expectedDrift: []TemplateDrift{
+				{
+					Path:   ".github/workflows/ci.yml",
+					Status: "modified",
+					Diff: strings.Join([]string{
+						"--- org/template/.github/workflows/ci.yml",
+						"+++ org/service/.github/workflows/ci.yml",
+						"@@ -2,4 +2,4 @@",
+						" on: push",
+						" jobs:",
+						"   test:",
+						"-    runs-on: ubuntu-latest",
+						"+    runs-on: self-hosted",
+						"",
+					}, "\n"),
+				},
+				{Path: ".github/workflows/deploy.yml", Status: "extra"},
+				{Path: ".github/workflows/release.yml", Status: "missing"},
+			},
+			expectedInSync: 1,
+		},
+		{
+			name: "given paths without diffs",
+			requestArgs: map[string]any{
+				"owner":          "org",
+				"repo":           "service",
+				"template_owner": "org",
+				"template_repo":  "template",
+				"paths":          []any{"README.md", ".github/workflows/ci.yml"},
+				"include_diffs":  false,
+			},
+			expectedDrift: []TemplateDrift{
+				{Path: ".github/workflows/ci.yml", Status: "modified"},
+				{Path: "README.md", Status: "modified"},
+			},
+		},
+		{
+			name: "repository not created from a template",
+			requestArgs: map[string]any{
+				"owner": "org",
+				"repo":  "scratch",
+			},
+			expectError:    true,
+			expectedErrMsg: "the repository wasn't created from a template",
+		},
+		{
+			name: "template not found",
+			requestArgs: map[string]any{
+				"owner":          "org",
+				"repo":           "service",
+				"template_owner": "org",
+				"template_repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get template repository",
+		},
+		{
+			name: "template owner without name",
+			requestArgs: map[string]any{
+				"owner":          "org",
+				"repo":           "service",
+				"template_owner": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "template_owner and template_repo must be provided together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetTemplateDrift(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Template string          `json:"template"`
+				InSync   int             `json:"in_sync"`
+				Drifted  []TemplateDrift `json:"drifted"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "org/template", response.Template)
+			assert.Equal(t, tc.expectedInSync, response.InSync)
+			assert.Equal(t, tc.expectedDrift, response.Drifted)
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package github
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxArtifactSize is the size of the artifact archives tools download and parse at most.
+	maxArtifactSize = 50 * 1024 * 1024
+	// maxArtifactFileSize is the uncompressed size of a file read from an artifact archive at most.
+	maxArtifactFileSize = 20 * 1024 * 1024
+	// DefaultTestReportMaxFailures is the default number of failed tests get_test_report returns.
+	DefaultTestReportMaxFailures = 50
+	// maxTestFailureMessageLength is the length failure messages and output are truncated to, keeping the end.
+	maxTestFailureMessageLength = 2000
+)
+
+// artifactFile is a file read from a workflow run artifact.
+type artifactFile struct {
+	Name    string
+	Content []byte
+}
+
+// downloadArtifactFiles downloads a workflow run artifact and returns the files in it accepted by include.
+func downloadArtifactFiles(ctx context.Context, client *github.Client, owner, repo string, artifactID int64, include func(name string) bool) ([]artifactFile, *github.Response, error) {
+	url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get artifact download URL: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create artifact download request: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, &github.Response{Response: httpResp}, fmt.Errorf("failed to download artifact: HTTP %d", httpResp.StatusCode)
+	}
+
+	archive, err := io.ReadAll(io.LimitReader(httpResp.Body, maxArtifactSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	if len(archive) > maxArtifactSize {
+		return nil, nil, fmt.Errorf("artifact is larger than %d MB", maxArtifactSize/1024/1024)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read artifact archive: %w", err)
+	}
+
+	var files []artifactFile
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !include(file.Name) {
+			continue
+		}
+		if file.UncompressedSize64 > maxArtifactFileSize {
+			return nil, nil, fmt.Errorf("'%s' in the artifact is larger than %d MB", file.Name, maxArtifactFileSize/1024/1024)
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read '%s' in the artifact: %w", file.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxArtifactFileSize))
+		_ = rc.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read '%s' in the artifact: %w", file.Name, err)
+		}
+		files = append(files, artifactFile{Name: file.Name, Content: content})
+	}
+	return files, nil, nil
+}
+
+// FailedTest is a failed test read from a test report.
+type FailedTest struct {
+	Name            string  `json:"name"`
+	Suite           string  `json:"suite,omitempty"`
+	File            string  `json:"file,omitempty"`
+	Message         string  `json:"message,omitempty"`
+	Details         string  `json:"details,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	ReportFile      string  `json:"report_file"`
+}
+
+// TestReportSummary counts the tests of one or more test reports.
+type TestReportSummary struct {
+	Tests           int     `json:"tests"`
+	Passed          int     `json:"passed"`
+	Failed          int     `json:"failed"`
+	Skipped         int     `json:"skipped"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// truncateStart shortens s to maxTestFailureMessageLength by dropping its start, where test output is least useful.
+func truncateStart(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxTestFailureMessageLength {
+		return s
+	}
+	return "…" + s[len(s)-maxTestFailureMessageLength:]
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string       `xml:"name,attr"`
+	Classname string       `xml:"classname,attr"`
+	File      string       `xml:"file,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *struct{}    `xml:"skipped"`
+}
+
+// junitSuite is a testsuite or testsuites element, which both can contain test suites.
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string       `xml:"name,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+	Cases   []junitCase  `xml:"testcase"`
+}
+
+// parseJUnitReport reads the failed tests of a JUnit XML report.
+func parseJUnitReport(name string, content []byte) ([]FailedTest, TestReportSummary, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, TestReportSummary{}, fmt.Errorf("failed to parse JUnit report: %w", err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, TestReportSummary{}, fmt.Errorf("not a JUnit report: root element is <%s>", root.XMLName.Local)
+	}
+
+	var failed []FailedTest
+	var summary TestReportSummary
+	var walk func(suite junitSuite)
+	walk = func(suite junitSuite) {
+		for _, testCase := range suite.Cases {
+			duration, _ := strconv.ParseFloat(testCase.Time, 64)
+			summary.Tests++
+			summary.DurationSeconds += duration
+			result := testCase.Failure
+			if result == nil {
+				result = testCase.Error
+			}
+			switch {
+			case result != nil:
+				summary.Failed++
+				suiteName := testCase.Classname
+				if suiteName == "" {
+					suiteName = suite.Name
+				}
+				message := result.Message
+				if message == "" {
+					message = result.Type
+				}
+				failed = append(failed, FailedTest{
+					Name:            testCase.Name,
+					Suite:           suiteName,
+					File:            testCase.File,
+					Message:         truncateStart(message),
+					Details:         truncateStart(result.Text),
+					DurationSeconds: duration,
+					ReportFile:      name,
+				})
+			case testCase.Skipped != nil:
+				summary.Skipped++
+			default:
+				summary.Passed++
+			}
+		}
+		for _, child := range suite.Suites {
+			walk(child)
+		}
+	}
+	walk(root)
+	return failed, summary, nil
+}
+
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// parseGoTestJSON reads the failed tests of the output of 'go test -json'.
+func parseGoTestJSON(name string, content []byte) ([]FailedTest, TestReportSummary, error) {
+	type testKey struct{ pkg, test string }
+	output := map[testKey]*strings.Builder{}
+	outputOf := func(key testKey) string {
+		if output[key] == nil {
+			return ""
+		}
+		return output[key].String()
+	}
+	var failed []FailedTest
+	var summary TestReportSummary
+	failedPackages := map[string]bool{}
+	events := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxArtifactFileSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var event goTestEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.Action == "" {
+			continue
+		}
+		events++
+		key := testKey{event.Package, event.Test}
+		switch event.Action {
+		case "output":
+			if output[key] == nil {
+				output[key] = &strings.Builder{}
+			}
+			output[key].WriteString(event.Output)
+		case "pass", "fail", "skip":
+			if event.Test == "" {
+				// A package that failed without a failed test didn't build or panicked outside of a test.
+				if event.Action == "fail" && !failedPackages[event.Package] {
+					failed = append(failed, FailedTest{
+						Name:            event.Package,
+						Suite:           event.Package,
+						Message:         "package failed",
+						Details:         truncateStart(outputOf(key)),
+						DurationSeconds: event.Elapsed,
+						ReportFile:      name,
+					})
+					summary.Failed++
+				}
+				summary.DurationSeconds += event.Elapsed
+				continue
+			}
+			summary.Tests++
+			switch event.Action {
+			case "pass":
+				summary.Passed++
+			case "skip":
+				summary.Skipped++
+			case "fail":
+				summary.Failed++
+				failedPackages[event.Package] = true
+				failed = append(failed, FailedTest{
+					Name:            event.Test,
+					Suite:           event.Package,
+					Message:         "test failed",
+					Details:         truncateStart(outputOf(key)),
+					DurationSeconds: event.Elapsed,
+					ReportFile:      name,
+				})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, TestReportSummary{}, fmt.Errorf("failed to read go test output: %w", err)
+	}
+	if events == 0 {
+		return nil, TestReportSummary{}, fmt.Errorf("not a go test JSON report")
+	}
+	return failed, summary, nil
+}
+
+// isTestReportFile reports whether a file in an artifact can be a JUnit or go test JSON report.
+func isTestReportFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".xml", ".json", ".jsonl":
+		return true
+	}
+	return false
+}
+
+// GetTestReport creates a tool to read the failed tests from the test reports in a workflow run artifact.
+func GetTestReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_test_report",
+			mcp.WithDescription(t("TOOL_GET_TEST_REPORT_DESCRIPTION", "Download a workflow run artifact containing test reports, JUnit XML or 'go test -json' output, and return the failed tests with their messages, output and durations, and the number of passed, failed and skipped tests. Use list_workflow_run_artifacts to find the artifact.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TEST_REPORT_USER_TITLE", "Get test report"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the artifact"),
+			),
+			mcp.WithNumber("max_failures",
+				mcp.Description(fmt.Sprintf("Maximum number of failed tests to return (default %d)", DefaultTestReportMaxFailures)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID, err := RequiredInt(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxFailures, err := OptionalIntParamWithDefault(request, "max_failures", DefaultTestReportMaxFailures)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxFailures < 1 {
+				return mcp.NewToolResultError("max_failures must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			files, resp, err := downloadArtifactFiles(ctx, client, owner, repo, int64(artifactID), isTestReportFile)
+			if err != nil {
+				if resp != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to download artifact", resp, err), nil
+				}
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var failed []FailedTest
+			var summary TestReportSummary
+			reports := []string{}
+			for _, file := range files {
+				parse := parseGoTestJSON
+				if strings.EqualFold(path.Ext(file.Name), ".xml") {
+					parse = parseJUnitReport
+				}
+				// Files that aren't test reports, such as other JSON or XML files, are skipped.
+				fileFailed, fileSummary, err := parse(file.Name, file.Content)
+				if err != nil {
+					continue
+				}
+				reports = append(reports, file.Name)
+				failed = append(failed, fileFailed...)
+				summary.Tests += fileSummary.Tests
+				summary.Passed += fileSummary.Passed
+				summary.Failed += fileSummary.Failed
+				summary.Skipped += fileSummary.Skipped
+				summary.DurationSeconds += fileSummary.DurationSeconds
+			}
+			if len(reports) == 0 {
+				return mcp.NewToolResultError("the artifact contains no JUnit XML or go test JSON reports"), nil
+			}
+
+			summary.DurationSeconds = math.Round(summary.DurationSeconds*1000) / 1000
+			truncated := len(failed) > maxFailures
+			if truncated {
+				failed = failed[:maxFailures]
+			}
+			if failed == nil {
+				failed = []FailedTest{}
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"artifact_id":  artifactID,
+				"reports":      reports,
+				"summary":      summary,
+				"failed_tests": failed,
+				"truncated":    truncated,
+			}), nil
+		}
+}
@@ -0,0 +1,249 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zipArchive creates a zip archive of the given files, in order of their names.
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_parseJUnitReport(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="api" tests="3">
+    <testcase classname="com.example.ApiTest" name="testCreate" time="0.25" file="src/test/ApiTest.java">
+      <failure message="expected 201 but was 500" type="AssertionError">at ApiTest.testCreate(ApiTest.java:42)</failure>
+    </testcase>
+    <testcase classname="com.example.ApiTest" name="testList" time="0.5"/>
+    <testcase classname="com.example.ApiTest" name="testDelete"><skipped/></testcase>
+    <testsuite name="nested">
+      <testcase name="testTimeout" time="30"><error type="TimeoutException"/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+	failed, summary, err := parseJUnitReport("reports/api.xml", []byte(report))
+	require.NoError(t, err)
+	assert.Equal(t, TestReportSummary{Tests: 4, Passed: 1, Failed: 2, Skipped: 1, DurationSeconds: 30.75}, summary)
+	assert.Equal(t, []FailedTest{
+		{
+			Name:            "testCreate",
+			Suite:           "com.example.ApiTest",
+			File:            "src/test/ApiTest.java",
+			Message:         "expected 201 but was 500",
+			Details:         "at ApiTest.testCreate(ApiTest.java:42)",
+			DurationSeconds: 0.25,
+			ReportFile:      "reports/api.xml",
+		},
+		{
+			Name:            "testTimeout",
+			Suite:           "nested",
+			Message:         "TimeoutException",
+			DurationSeconds: 30,
+			ReportFile:      "reports/api.xml",
+		},
+	}, failed)
+
+	_, _, err = parseJUnitReport("pom.xml", []byte(`<project><modelVersion>4.0.0</modelVersion></project>`))
+	assert.ErrorContains(t, err, "not a JUnit report")
+}
+
+func Test_parseGoTestJSON(t *testing.T) {
+	output := `{"Action":"run","Package":"example.com/cache","Test":"TestGet"}
+{"Action":"output","Package":"example.com/cache","Test":"TestGet","Output":"=== RUN   TestGet\n"}
+{"Action":"output","Package":"example.com/cache","Test":"TestGet","Output":"    cache_test.go:12: got 1, want 2\n"}
+{"Action":"fail","Package":"example.com/cache","Test":"TestGet","Elapsed":0.01}
+{"Action":"pass","Package":"example.com/cache","Test":"TestPut","Elapsed":0.02}
+{"Action":"skip","Package":"example.com/cache","Test":"TestEvict","Elapsed":0}
+{"Action":"fail","Package":"example.com/cache","Elapsed":0.5}
+{"Action":"output","Package":"example.com/broken","Output":"broken.go:3:1: syntax error\n"}
+{"Action":"fail","Package":"example.com/broken","Elapsed":0}
+`
+
+	failed, summary, err := parseGoTestJSON("test.json", []byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, TestReportSummary{Tests: 3, Passed: 1, Failed: 2, Skipped: 1, DurationSeconds: 0.5}, summary)
+	assert.Equal(t, []FailedTest{
+		{
+			Name:            "TestGet",
+			Suite:           "example.com/cache",
+			Message:         "test failed",
+			Details:         "=== RUN   TestGet\n    cache_test.go:12: got 1, want 2",
+			DurationSeconds: 0.01,
+			ReportFile:      "test.json",
+		},
+		{
+			Name:       "example.com/broken",
+			Suite:      "example.com/broken",
+			Message:    "package failed",
+			Details:    "broken.go:3:1: syntax error",
+			ReportFile: "test.json",
+		},
+	}, failed)
+
+	_, _, err = parseGoTestJSON("package.json", []byte(`{"name": "app"}`))
+	assert.ErrorContains(t, err, "not a go test JSON report")
+}
+
+func Test_GetTestReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetTestReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_test_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
+	assert.Contains(t, tool.InputSchema.Properties, "max_failures")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+
+	reports := zipArchive(t, map[string]string{
+		"junit/api.xml": `<testsuite name="api"><testcase name="testCreate" time="1.5"><failure message="boom"/></testcase><testcase name="testList"/></testsuite>`,
+		"go-test.json":  `{"Action":"fail","Package":"example.com/cache","Test":"TestGet","Elapsed":0.2}` + "\n",
+		"coverage.out":  "mode: set\n",
+		"config.xml":    `<configuration/>`,
+	})
+	noReports := zipArchive(t, map[string]string{"build.log": "ok\n"})
+	downloads := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/empty.zip" {
+			_, _ = w.Write(noReports)
+			return
+		}
+		_, _ = w.Write(reports)
+	}))
+	defer downloads.Close()
+
+	artifactHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/artifacts/1/zip":
+			w.Header().Set("Location", downloads.URL+"/reports.zip")
+			w.WriteHeader(http.StatusFound)
+		case "/repos/owner/repo/actions/artifacts/2/zip":
+			w.Header().Set("Location", downloads.URL+"/empty.zip")
+			w.WriteHeader(http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedFailed []string
+		expectedTotal  TestReportSummary
+		truncated      bool
+	}{
+		{
+			name: "failed tests of all reports",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(1),
+			},
+			expectedFailed: []string{"TestGet", "testCreate"},
+			expectedTotal:  TestReportSummary{Tests: 3, Passed: 1, Failed: 2, DurationSeconds: 1.5},
+		},
+		{
+			name: "limited failures",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"artifact_id":  float64(1),
+				"max_failures": float64(1),
+			},
+			expectedFailed: []string{"TestGet"},
+			expectedTotal:  TestReportSummary{Tests: 3, Passed: 1, Failed: 2, DurationSeconds: 1.5},
+			truncated:      true,
+		},
+		{
+			name: "artifact without reports",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "the artifact contains no JUnit XML or go test JSON reports",
+		},
+		{
+			name: "artifact not found",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to download artifact",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat, artifactHandler),
+			))
+			_, handler := GetTestReport(stubGetClientFn(client), translations.NullTranslationHelper)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response struct {
+				Reports     []string          `json:"reports"`
+				Summary     TestReportSummary `json:"summary"`
+				FailedTests []FailedTest      `json:"failed_tests"`
+				Truncated   bool              `json:"truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, []string{"go-test.json", "junit/api.xml"}, response.Reports)
+			assert.Equal(t, tc.expectedTotal, response.Summary)
+			var failed []string
+			for _, test := range response.FailedTests {
+				failed = append(failed, test.Name)
+			}
+			assert.Equal(t, tc.expectedFailed, failed)
+			assert.Equal(t, tc.truncated, response.Truncated)
+		})
+	}
+}
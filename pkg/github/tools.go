@@ -99,6 +99,14 @@ var (
 		ID:          "stargazers",
 		Description: "GitHub Stargazers related tools",
 	}
+	ToolsetMetadataWebhooks = ToolsetMetadata{
+		ID:          "webhooks",
+		Description: "GitHub Webhooks related tools",
+	}
+	ToolsetMetadataDeployments = ToolsetMetadata{
+		ID:          "deployments",
+		Description: "GitHub Deployments and environments related tools",
+	}
 	ToolsetMetadataDynamic = ToolsetMetadata{
 		ID:          "dynamic",
 		Description: "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.",
@@ -128,6 +136,8 @@ func AvailableTools() []ToolsetMetadata {
 		ToolsetMetadataSecurityAdvisories,
 		ToolsetMetadataProjects,
 		ToolsetMetadataStargazers,
+		ToolsetMetadataWebhooks,
+		ToolsetMetadataDeployments,
 		ToolsetMetadataDynamic,
 		ToolsetLabels,
 	}
@@ -155,7 +165,7 @@ func GetDefaultToolsetIDs() []string {
 	}
 }
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, getAppClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Define all available features with their default state (disabled)
@@ -163,24 +173,74 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	repos := toolsets.NewToolset(ToolsetMetadataRepos.ID, ToolsetMetadataRepos.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
+			toolsets.NewServerTool(DiscoverRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(RecoverDeletedFile(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(ListUnverifiedCommits(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryActivity(getClient, t)),
+			toolsets.NewServerTool(GetPathChanges(getClient, t)),
+			toolsets.NewServerTool(GetFileHistory(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(GetDiffStats(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t)),
+			toolsets.NewServerTool(GetCombinedStatus(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(ListForks(getClient, t)),
+			toolsets.NewServerTool(ListStaleBranches(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
+			toolsets.NewServerTool(ListRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRuleset(getClient, t)),
+			toolsets.NewServerTool(ListRuleSuites(getClient, t)),
+			toolsets.NewServerTool(GetRuleSuite(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
 			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GetReleaseDownloadStats(getClient, t)),
+			toolsets.NewServerTool(ListAttestations(getClient, t)),
+			toolsets.NewServerTool(CheckArtifactProvenance(getClient, t)),
+			toolsets.NewServerTool(GetRepositorySecuritySettings(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryCustomProperties(getClient, t)),
+			toolsets.NewServerTool(GetCommunityProfile(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
+			toolsets.NewServerTool(GetCodeownersCoverage(getClient, t)),
+			toolsets.NewServerTool(GetCodeowners(getClient, t)),
+			toolsets.NewServerTool(GetTemplateDrift(getClient, t)),
+			toolsets.NewServerTool(GetCommitActivity(getClient, t)),
+			toolsets.NewServerTool(GetContributorInsights(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryStats(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryTraffic(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
+			toolsets.NewServerTool(RepositoryAdmin(getClient, t)),
+			toolsets.NewServerTool(ReplaceRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(SetRepositoryCustomProperties(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(RenameBranch(getClient, t)),
+			toolsets.NewServerTool(MergeBranch(getClient, t)),
+			toolsets.NewServerTool(SyncFork(getClient, t)),
+			toolsets.NewServerTool(DeleteBranches(getClient, t)),
+			toolsets.NewServerTool(CreateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(DeleteBranchProtection(getClient, t)),
+			toolsets.NewServerTool(CreateRuleset(getClient, t)),
+			toolsets.NewServerTool(UpdateRuleset(getClient, t)),
+			toolsets.NewServerTool(DeleteRuleset(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(UpdateRepositorySecuritySettings(getClient, t)),
+			toolsets.NewServerTool(ApplyRepositorySettingsProfile(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -194,6 +254,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(IssueRead(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
+			toolsets.NewServerTool(ExportIssues(getClient, t)),
+			toolsets.NewServerTool(GetMilestoneRollup(getClient, t)),
+			toolsets.NewServerTool(FindGoodFirstIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 			toolsets.NewServerTool(GetLabel(getGQLClient, t)),
 		).
@@ -202,6 +265,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
 			toolsets.NewServerTool(SubIssueWrite(getClient, t)),
+			toolsets.NewServerTool(LabelGoodFirstIssues(getClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
@@ -209,23 +273,50 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	users := toolsets.NewToolset(ToolsetMetadataUsers.ID, ToolsetMetadataUsers.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(GetContributorContext(getClient, t)),
 		)
 	orgs := toolsets.NewToolset(ToolsetMetadataOrgs.ID, ToolsetMetadataOrgs.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(ListOrgExternalIdentities(getGQLClient, t)),
+			toolsets.NewServerTool(ListOrgFineGrainedPATs(getClient, t)),
+			toolsets.NewServerTool(ListOrgPATRequests(getClient, t)),
+			toolsets.NewServerTool(ListOrgCustomRepoRoles(getClient, t)),
+			toolsets.NewServerTool(ListOrgCustomProperties(getClient, t)),
+			toolsets.NewServerTool(ListOrgRepositoryCustomProperties(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryPermission(getClient, t)),
+			toolsets.NewServerTool(ListOrgAppInstallations(getClient, t)),
+			toolsets.NewServerTool(ListOrgWebhooks(getClient, t)),
+			toolsets.NewServerTool(ListInstallationRepositories(getClient, t)),
+			toolsets.NewServerTool(GetOffboardingReport(getClient, getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ReviewOrgPATRequest(getClient, t)),
+			toolsets.NewServerTool(AssignRepositoryRole(getClient, t)),
+			toolsets.NewServerTool(UpdateInstallationRepository(getClient, t)),
 		)
+	// Minting installation tokens needs a client authenticated as a GitHub App, which is only available
+	// when the server is configured with the app's credentials.
+	if getAppClient != nil {
+		orgs.AddWriteTools(
+			toolsets.NewServerTool(CreateInstallationToken(getAppClient, t)),
+		)
+	}
 	pullRequests := toolsets.NewToolset(ToolsetMetadataPullRequests.ID, ToolsetMetadataPullRequests.Description).
 		AddReadTools(
 			toolsets.NewServerTool(PullRequestRead(getClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
+			toolsets.NewServerTool(GetReviewLoad(getClient, t)),
+			toolsets.NewServerTool(GetOrgPullRequestMetrics(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
-			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(RequestLeastLoadedReviewer(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(PullRequestReviewWrite(getGQLClient, t)),
@@ -235,6 +326,13 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(GetCodeScanningDefaultSetup(getClient, t)),
+			toolsets.NewServerTool(ListOrgCodeScanningDefaultSetup(getClient, t)),
+			toolsets.NewServerTool(GetOrgSecurityOverview(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateCodeScanningDefaultSetup(getClient, t)),
+			toolsets.NewServerTool(UpdateOrgCodeScanningDefaultSetup(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset(ToolsetMetadataSecretProtection.ID, ToolsetMetadataSecretProtection.Description).
 		AddReadTools(
@@ -245,12 +343,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetDependabotAlert(getClient, t)),
 			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(GetDependencyLicenseReport(getClient, t)),
+			toolsets.NewServerTool(FindOrgPackageDependents(getClient, getGQLClient, t)),
 		)
 
 	notifications := toolsets.NewToolset(ToolsetMetadataNotifications.ID, ToolsetMetadataNotifications.Description).
 		AddReadTools(
 			toolsets.NewServerTool(ListNotifications(getClient, t)),
 			toolsets.NewServerTool(GetNotificationDetails(getClient, t)),
+			toolsets.NewServerTool(GetRepositorySubscription(getClient, t)),
+			toolsets.NewServerTool(ListWatchedRepositories(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(DismissNotification(getClient, t)),
@@ -275,9 +377,23 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t, contentWindowSize)),
+			toolsets.NewServerTool(FindFlakyTests(getClient, t, contentWindowSize)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
+			toolsets.NewServerTool(GetTestReport(getClient, t)),
+			toolsets.NewServerTool(GetCoverageReport(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ListOrgSecretsInventory(getClient, t)),
+			toolsets.NewServerTool(ListActionsSecrets(getClient, t)),
+			toolsets.NewServerTool(ListActionsVariables(getClient, t)),
+			toolsets.NewServerTool(GetOIDCSubjectClaim(getClient, t)),
+			toolsets.NewServerTool(ListDeploymentProtectionRules(getClient, t)),
+			toolsets.NewServerTool(GetDeployStatus(getClient, t)),
+			toolsets.NewServerTool(GetIncidentTimeline(getClient, t)),
+			toolsets.NewServerTool(ListPendingWorkflowApprovals(getClient, t)),
+			toolsets.NewServerTool(ListCheckRuns(getClient, t)),
+			toolsets.NewServerTool(GetCheckRun(getClient, t)),
+			toolsets.NewServerTool(ListCheckSuites(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
@@ -285,6 +401,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(SetOIDCSubjectClaim(getClient, t)),
+			toolsets.NewServerTool(ManageDeploymentProtectionRule(getClient, t)),
+			toolsets.NewServerTool(ApproveWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(CreateCheckRun(getClient, t)),
+			toolsets.NewServerTool(UpdateCheckRun(getClient, t)),
 		)
 
 	securityAdvisories := toolsets.NewToolset(ToolsetMetadataSecurityAdvisories.ID, ToolsetMetadataSecurityAdvisories.Description).
@@ -303,6 +425,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetMe(getClient, t)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
+			toolsets.NewServerTool(GetToolCatalog(tsg, t)),
 		)
 
 	gists := toolsets.NewToolset(ToolsetMetadataGists.ID, ToolsetMetadataGists.Description).
@@ -321,7 +444,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListProjectFields(getClient, t)),
 			toolsets.NewServerTool(GetProjectField(getClient, t)),
 			toolsets.NewServerTool(ListProjectItems(getClient, t)),
+			toolsets.NewServerTool(GetProjectStatusReport(getClient, t)),
+			toolsets.NewServerTool(GetProjectTimeInStatus(getGQLClient, t)),
 			toolsets.NewServerTool(GetProjectItem(getClient, t)),
+			toolsets.NewServerTool(ListIssueProjectItems(getGQLClient, t)),
+			toolsets.NewServerTool(FindProjectItems(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectWorkflows(getGQLClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(AddProjectItem(getClient, t)),
@@ -333,10 +461,40 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	stargazers := toolsets.NewToolset(ToolsetMetadataStargazers.ID, ToolsetMetadataStargazers.Description).
 		AddReadTools(
 			toolsets.NewServerTool(ListStarredRepositories(getClient, t)),
+			toolsets.NewServerTool(AnalyzeStarredRepositories(getClient, t)),
+			toolsets.NewServerTool(ListStargazers(getClient, t)),
+			toolsets.NewServerTool(ListWatchers(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(StarRepository(getClient, t)),
 			toolsets.NewServerTool(UnstarRepository(getClient, t)),
+			toolsets.NewServerTool(UnstarRepositories(getClient, t)),
+		)
+	webhooks := toolsets.NewToolset(ToolsetMetadataWebhooks.ID, ToolsetMetadataWebhooks.Description).
+		AddReadTools(
+			toolsets.NewServerTool(ListWebhooks(getClient, t)),
+			toolsets.NewServerTool(GetWebhook(getClient, t)),
+			toolsets.NewServerTool(ListWebhookDeliveries(getClient, t)),
+			toolsets.NewServerTool(GetWebhookDelivery(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateWebhook(getClient, t)),
+			toolsets.NewServerTool(UpdateWebhook(getClient, t)),
+			toolsets.NewServerTool(DeleteWebhook(getClient, t)),
+			toolsets.NewServerTool(PingWebhook(getClient, t)),
+			toolsets.NewServerTool(RedeliverWebhookDelivery(getClient, t)),
+		)
+	deployments := toolsets.NewToolset(ToolsetMetadataDeployments.ID, ToolsetMetadataDeployments.Description).
+		AddReadTools(
+			toolsets.NewServerTool(ListDeployments(getClient, t)),
+			toolsets.NewServerTool(ListEnvironments(getClient, t)),
+			toolsets.NewServerTool(GetEnvironment(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateDeployment(getClient, t)),
+			toolsets.NewServerTool(CreateDeploymentStatus(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateEnvironment(getClient, t)),
+			toolsets.NewServerTool(DeleteEnvironment(getClient, t)),
 		)
 	labels := toolsets.NewToolset(ToolsetLabels.ID, ToolsetLabels.Description).
 		AddReadTools(
@@ -367,6 +525,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(securityAdvisories)
 	tsg.AddToolset(projects)
 	tsg.AddToolset(stargazers)
+	tsg.AddToolset(webhooks)
+	tsg.AddToolset(deployments)
 	tsg.AddToolset(labels)
 
 	return tsg
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// unifiedDiffContext is the number of unchanged lines shown around the changes of a diff.
+	unifiedDiffContext = 3
+	// maxUnifiedDiffCells bounds the size of the table used to diff the lines that differ between two texts.
+	maxUnifiedDiffCells = 4_000_000
+)
+
+// diffLine is a line of a diff: kept (' '), removed ('-') or added ('+').
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// diffLines returns the edit script turning the lines from into the lines to, based on their longest common
+// subsequence. It returns false if the texts differ in too many lines to diff.
+func diffLines(from, to []string) ([]diffLine, bool) {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	a, b := from[prefix:len(from)-suffix], to[prefix:len(to)-suffix]
+	if (len(a)+1)*(len(b)+1) > maxUnifiedDiffCells {
+		return nil, false
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(from)+len(to))
+	for _, line := range from[:prefix] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for _, line := range from[len(from)-suffix:] {
+		lines = append(lines, diffLine{' ', line})
+	}
+	return lines, true
+}
+
+// splitLines splits a text into its lines, without the newline ending the last one.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// unifiedDiff returns the unified diff between two texts, or an empty string if their lines are equal. It returns false if
+// the texts differ in too many lines to diff.
+func unifiedDiff(fromName, toName, from, to string) (string, bool) {
+	fromLines, toLines := splitLines(from), splitLines(to)
+	if slices.Equal(fromLines, toLines) {
+		return "", true
+	}
+	lines, ok := diffLines(fromLines, toLines)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(lines); {
+		// Find the next change, and extend the hunk over the changes separated by few enough kept lines.
+		first := start
+		for first < len(lines) && lines[first].kind == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for k := first; k < len(lines) && k-last <= 2*unifiedDiffContext; k++ {
+			if lines[k].kind != ' ' {
+				last = k
+			}
+		}
+		hunkStart := max(first-unifiedDiffContext, start)
+		hunkEnd := min(last+unifiedDiffContext+1, len(lines))
+
+		fromLine, toLine := 1, 1
+		for _, line := range lines[:hunkStart] {
+			if line.kind != '+' {
+				fromLine++
+			}
+			if line.kind != '-' {
+				toLine++
+			}
+		}
+		fromCount, toCount := 0, 0
+		for _, line := range lines[hunkStart:hunkEnd] {
+			if line.kind != '+' {
+				fromCount++
+			}
+			if line.kind != '-' {
+				toCount++
+			}
+		}
+		// An empty range starts at the line before it.
+		if fromCount == 0 {
+			fromLine--
+		}
+		if toCount == 0 {
+			toLine--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+		for _, line := range lines[hunkStart:hunkEnd] {
+			b.WriteByte(line.kind)
+			b.WriteString(line.text)
+			b.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return b.String(), true
+}
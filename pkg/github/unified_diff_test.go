@@ -0,0 +1,86 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "equal",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			expected: "",
+		},
+		{
+			name: "changed line",
+			from: "name: CI\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n",
+			to:   "name: CI\non: push\njobs:\n  test:\n    runs-on: ubuntu-24.04\n",
+			expected: `--- a
++++ b
+@@ -2,4 +2,4 @@
+ on: push
+ jobs:
+   test:
+-    runs-on: ubuntu-latest
++    runs-on: ubuntu-24.04
+`,
+		},
+		{
+			name: "separate hunks",
+			from: strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, "\n"),
+			to:   strings.Join([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "12"}, "\n"),
+			expected: `--- a
++++ b
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -8,5 +9,4 @@
+ 8
+ 9
+ 10
+-11
+ 12
+`,
+		},
+		{
+			name: "added to empty",
+			from: "",
+			to:   "x",
+			expected: `--- a
++++ b
+@@ -0,0 +1,1 @@
++x
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, ok := unifiedDiff("a", "b", tc.from, tc.to)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, diff)
+		})
+	}
+
+	// Texts that differ in too many lines are not diffed.
+	many := func(prefix string) string {
+		lines := make([]string, 3000)
+		for i := range lines {
+			lines[i] = prefix + strings.Repeat("x", i)
+		}
+		return strings.Join(lines, "\n")
+	}
+	_, ok := unifiedDiff("a", "b", many("a"), many("b"))
+	assert.False(t, ok)
+}
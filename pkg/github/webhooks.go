@@ -0,0 +1,809 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalWebhook is the trimmed output type for repository and organization webhooks. The secret itself is never
+// returned by the API, only whether one is set.
+type MinimalWebhook struct {
+	ID                 int64    `json:"id"`
+	URL                string   `json:"url"`
+	Active             bool     `json:"active"`
+	Events             []string `json:"events"`
+	ContentType        string   `json:"content_type,omitempty"`
+	InsecureSSL        bool     `json:"insecure_ssl"`
+	HasSecret          bool     `json:"has_secret"`
+	LastResponseCode   int      `json:"last_response_code,omitempty"`
+	LastResponseStatus string   `json:"last_response_status,omitempty"`
+	CreatedAt          string   `json:"created_at,omitempty"`
+	UpdatedAt          string   `json:"updated_at,omitempty"`
+}
+
+func convertToMinimalWebhook(hook *github.Hook) MinimalWebhook {
+	webhook := MinimalWebhook{
+		ID:        hook.GetID(),
+		URL:       hook.GetConfig().GetURL(),
+		Active:    hook.GetActive(),
+		Events:    hook.Events,
+		CreatedAt: formatOptionalTimestamp(hook.CreatedAt),
+		UpdatedAt: formatOptionalTimestamp(hook.UpdatedAt),
+	}
+	if config := hook.GetConfig(); config != nil {
+		webhook.ContentType = config.GetContentType()
+		webhook.InsecureSSL = config.GetInsecureSSL() == "1"
+		webhook.HasSecret = config.GetSecret() != ""
+	}
+	// The last response code is decoded from JSON as a number.
+	if code, ok := hook.LastResponse["code"].(float64); ok {
+		webhook.LastResponseCode = int(code)
+	}
+	if status, ok := hook.LastResponse["status"].(string); ok {
+		webhook.LastResponseStatus = status
+	}
+	return webhook
+}
+
+// MinimalWebhookDelivery is the trimmed output type for webhook deliveries. The request and response are only
+// set for a single delivery.
+type MinimalWebhookDelivery struct {
+	ID          int64                   `json:"id"`
+	GUID        string                  `json:"guid"`
+	Event       string                  `json:"event"`
+	Action      string                  `json:"action,omitempty"`
+	Status      string                  `json:"status"`
+	StatusCode  int                     `json:"status_code"`
+	Redelivery  bool                    `json:"redelivery"`
+	Duration    float64                 `json:"duration"`
+	DeliveredAt string                  `json:"delivered_at,omitempty"`
+	Request     *MinimalWebhookExchange `json:"request,omitempty"`
+	Response    *MinimalWebhookExchange `json:"response,omitempty"`
+}
+
+// MinimalWebhookExchange holds the headers and payload of a webhook delivery's request or response.
+type MinimalWebhookExchange struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload *json.RawMessage  `json:"payload,omitempty"`
+}
+
+func convertToMinimalWebhookDelivery(delivery *github.HookDelivery) MinimalWebhookDelivery {
+	result := MinimalWebhookDelivery{
+		ID:          delivery.GetID(),
+		GUID:        delivery.GetGUID(),
+		Event:       delivery.GetEvent(),
+		Action:      delivery.GetAction(),
+		Status:      delivery.GetStatus(),
+		StatusCode:  delivery.GetStatusCode(),
+		Redelivery:  delivery.GetRedelivery(),
+		DeliveredAt: formatOptionalTimestamp(delivery.DeliveredAt),
+	}
+	if delivery.Duration != nil {
+		result.Duration = *delivery.Duration
+	}
+	if delivery.Request != nil {
+		result.Request = &MinimalWebhookExchange{Headers: delivery.Request.Headers, Payload: delivery.Request.RawPayload}
+	}
+	if delivery.Response != nil {
+		result.Response = &MinimalWebhookExchange{Headers: delivery.Response.Headers, Payload: delivery.Response.RawPayload}
+	}
+	return result
+}
+
+// webhookDeliveryFailed reports whether a delivery didn't reach the receiver or wasn't acknowledged with a
+// successful status code.
+func webhookDeliveryFailed(delivery *github.HookDelivery) bool {
+	code := delivery.GetStatusCode()
+	return code == 0 || code >= 400
+}
+
+// webhookTarget addresses the webhooks of a repository, or of an organization when repo is empty. Repository and
+// organization webhooks share the same API, so the webhook tools serve both through it.
+type webhookTarget struct {
+	owner string
+	repo  string
+}
+
+// WithWebhookTarget adds the parameters selecting the repository or organization whose webhooks a tool manages.
+func WithWebhookTarget() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner, or the organization login when 'repo' is omitted"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description("Repository name. Omit to manage the organization's webhooks"),
+		)(tool)
+	}
+}
+
+func webhookTargetParams(request mcp.CallToolRequest) (webhookTarget, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return webhookTarget{}, err
+	}
+	repo, err := OptionalParam[string](request, "repo")
+	if err != nil {
+		return webhookTarget{}, err
+	}
+	return webhookTarget{owner: owner, repo: repo}, nil
+}
+
+func (w webhookTarget) String() string {
+	if w.repo == "" {
+		return fmt.Sprintf("organization '%s'", w.owner)
+	}
+	return fmt.Sprintf("repository '%s/%s'", w.owner, w.repo)
+}
+
+func (w webhookTarget) list(ctx context.Context, client *github.Client, opts *github.ListOptions) ([]*github.Hook, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.ListHooks(ctx, w.owner, opts)
+	}
+	return client.Repositories.ListHooks(ctx, w.owner, w.repo, opts)
+}
+
+func (w webhookTarget) get(ctx context.Context, client *github.Client, hookID int64) (*github.Hook, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.GetHook(ctx, w.owner, hookID)
+	}
+	return client.Repositories.GetHook(ctx, w.owner, w.repo, hookID)
+}
+
+func (w webhookTarget) create(ctx context.Context, client *github.Client, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.CreateHook(ctx, w.owner, hook)
+	}
+	return client.Repositories.CreateHook(ctx, w.owner, w.repo, hook)
+}
+
+func (w webhookTarget) edit(ctx context.Context, client *github.Client, hookID int64, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.EditHook(ctx, w.owner, hookID, hook)
+	}
+	return client.Repositories.EditHook(ctx, w.owner, w.repo, hookID, hook)
+}
+
+func (w webhookTarget) editConfig(ctx context.Context, client *github.Client, hookID int64, config *github.HookConfig) (*github.HookConfig, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.EditHookConfiguration(ctx, w.owner, hookID, config)
+	}
+	return client.Repositories.EditHookConfiguration(ctx, w.owner, w.repo, hookID, config)
+}
+
+func (w webhookTarget) delete(ctx context.Context, client *github.Client, hookID int64) (*github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.DeleteHook(ctx, w.owner, hookID)
+	}
+	return client.Repositories.DeleteHook(ctx, w.owner, w.repo, hookID)
+}
+
+func (w webhookTarget) ping(ctx context.Context, client *github.Client, hookID int64) (*github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.PingHook(ctx, w.owner, hookID)
+	}
+	return client.Repositories.PingHook(ctx, w.owner, w.repo, hookID)
+}
+
+func (w webhookTarget) listDeliveries(ctx context.Context, client *github.Client, hookID int64, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.ListHookDeliveries(ctx, w.owner, hookID, opts)
+	}
+	return client.Repositories.ListHookDeliveries(ctx, w.owner, w.repo, hookID, opts)
+}
+
+func (w webhookTarget) getDelivery(ctx context.Context, client *github.Client, hookID, deliveryID int64) (*github.HookDelivery, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.GetHookDelivery(ctx, w.owner, hookID, deliveryID)
+	}
+	return client.Repositories.GetHookDelivery(ctx, w.owner, w.repo, hookID, deliveryID)
+}
+
+func (w webhookTarget) redeliver(ctx context.Context, client *github.Client, hookID, deliveryID int64) (*github.HookDelivery, *github.Response, error) {
+	if w.repo == "" {
+		return client.Organizations.RedeliverHookDelivery(ctx, w.owner, hookID, deliveryID)
+	}
+	return client.Repositories.RedeliverHookDelivery(ctx, w.owner, w.repo, hookID, deliveryID)
+}
+
+// ListWebhooks creates a tool to list the webhooks of a repository or organization.
+func ListWebhooks(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhooks",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOKS_DESCRIPTION", "List the webhooks of a repository, or of an organization when 'repo' is omitted, with their payload URLs, subscribed events, whether they are active, and the outcome of their last delivery. Requires admin access to the repository or organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOKS_USER_TITLE", "List webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithWebhookTarget(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hooks, resp, err := target.list(ctx, client, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list webhooks for %s", target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalWebhook, 0, len(hooks))
+			for _, hook := range hooks {
+				result = append(result, convertToMinimalWebhook(hook))
+			}
+
+			return MarshalledTextResult(ctx, result), nil
+		}
+}
+
+// GetWebhook creates a tool to get a webhook of a repository or organization.
+func GetWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_webhook",
+			mcp.WithDescription(t("TOOL_GET_WEBHOOK_DESCRIPTION", "Get a webhook of a repository, or of an organization when 'repo' is omitted, with its configuration and the outcome of its last delivery.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WEBHOOK_USER_TITLE", "Get webhook"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hook, resp, err := target.get(ctx, client, int64(hookID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get webhook %d of %s", hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalWebhook(hook)), nil
+		}
+}
+
+// ListWebhookDeliveries creates a tool to list the recent deliveries of a webhook.
+func ListWebhookDeliveries(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhook_deliveries",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOK_DELIVERIES_DESCRIPTION", "List the recent deliveries of a repository or organization webhook, newest first, with the event, the response status code and how long the delivery took. Use 'get_webhook_delivery' to inspect the request and response of a delivery and 'redeliver_webhook_delivery' to retry it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOK_DELIVERIES_USER_TITLE", "List webhook deliveries"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+			mcp.WithBoolean("failed_only",
+				mcp.Description("Only return the deliveries that failed or were answered with an error status code, among the deliveries of the page"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			failedOnly, err := OptionalParam[bool](request, "failed_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deliveries, resp, err := target.listDeliveries(ctx, client, int64(hookID), &github.ListCursorOptions{
+				PerPage: pagination.PerPage,
+				Cursor:  pagination.After,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list deliveries of webhook %d of %s", hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalWebhookDelivery, 0, len(deliveries))
+			for _, delivery := range deliveries {
+				if failedOnly && !webhookDeliveryFailed(delivery) {
+					continue
+				}
+				result = append(result, convertToMinimalWebhookDelivery(delivery))
+			}
+
+			return MarshalledTextResult(ctx, map[string]any{
+				"deliveries": result,
+				"pageInfo": map[string]any{
+					"hasNextPage": resp.Cursor != "",
+					"endCursor":   resp.Cursor,
+				},
+			}), nil
+		}
+}
+
+// GetWebhookDelivery creates a tool to get a webhook delivery with its request and response.
+func GetWebhookDelivery(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_webhook_delivery",
+			mcp.WithDescription(t("TOOL_GET_WEBHOOK_DELIVERY_DESCRIPTION", "Get a delivery of a repository or organization webhook, with the headers and payload of the request GitHub sent and of the response it received. Use this to debug why a receiver rejected an event.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WEBHOOK_DELIVERY_USER_TITLE", "Get webhook delivery"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+			mcp.WithNumber("delivery_id",
+				mcp.Required(),
+				mcp.Description("The ID of the delivery"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deliveryID, err := RequiredInt(request, "delivery_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			delivery, resp, err := target.getDelivery(ctx, client, int64(hookID), int64(deliveryID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get delivery %d of webhook %d of %s", deliveryID, hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalWebhookDelivery(delivery)), nil
+		}
+}
+
+// CreateWebhook creates a tool to create a webhook on a repository or organization.
+func CreateWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_webhook",
+			mcp.WithDescription(t("TOOL_CREATE_WEBHOOK_DESCRIPTION", "Create a webhook on a repository, or on an organization when 'repo' is omitted, that sends the selected events to a payload URL. GitHub sends a ping event once the webhook is created.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_WEBHOOK_USER_TITLE", "Create webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithWebhookTarget(),
+			mcp.WithString("url",
+				mcp.Required(),
+				mcp.Description("The URL the payloads are delivered to"),
+			),
+			mcp.WithArray("events",
+				mcp.Description("The events that trigger the webhook, e.g. 'push' or 'pull_request'. Use '*' for all events. Defaults to 'push'"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("The media type of the payloads. Defaults to 'json'"),
+				mcp.Enum("json", "form"),
+			),
+			mcp.WithString("secret",
+				mcp.Description("Secret used to sign the payloads in the X-Hub-Signature-256 header"),
+			),
+			mcp.WithBoolean("insecure_ssl",
+				mcp.Description("Skip verifying the TLS certificate of the payload URL. Not recommended"),
+			),
+			mcp.WithBoolean("active",
+				mcp.Description("Whether the webhook delivers events. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			url, err := RequiredParam[string](request, "url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(events) == 0 {
+				events = []string{"push"}
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if contentType == "" {
+				contentType = "json"
+			}
+			secret, err := OptionalParam[string](request, "secret")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			insecureSSL, err := OptionalParam[bool](request, "insecure_ssl")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			active, err := OptionalBoolParamWithDefault(request, "active", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			config := &github.HookConfig{
+				URL:         github.Ptr(url),
+				ContentType: github.Ptr(contentType),
+				InsecureSSL: github.Ptr(insecureSSLValue(insecureSSL)),
+			}
+			if secret != "" {
+				config.Secret = github.Ptr(secret)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hook, resp, err := target.create(ctx, client, &github.Hook{
+				Config: config,
+				Events: events,
+				Active: github.Ptr(active),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create webhook for %s", target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalWebhook(hook)), nil
+		}
+}
+
+// insecureSSLValue converts whether to skip TLS verification to the string the webhooks API expects.
+func insecureSSLValue(insecure bool) string {
+	if insecure {
+		return "1"
+	}
+	return "0"
+}
+
+// UpdateWebhook creates a tool to update a webhook of a repository or organization.
+func UpdateWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_webhook",
+			mcp.WithDescription(t("TOOL_UPDATE_WEBHOOK_DESCRIPTION", "Update a webhook of a repository, or of an organization when 'repo' is omitted. Only the given settings change; the secret is kept unless a new one is given.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_WEBHOOK_USER_TITLE", "Update webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+			mcp.WithString("url",
+				mcp.Description("New URL the payloads are delivered to"),
+			),
+			mcp.WithArray("events",
+				mcp.Description("New events that trigger the webhook, replacing the current ones"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("New media type of the payloads"),
+				mcp.Enum("json", "form"),
+			),
+			mcp.WithString("secret",
+				mcp.Description("New secret used to sign the payloads"),
+			),
+			mcp.WithBoolean("insecure_ssl",
+				mcp.Description("Whether to skip verifying the TLS certificate of the payload URL"),
+			),
+			mcp.WithBoolean("active",
+				mcp.Description("Whether the webhook delivers events"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Settings of the webhook and of its configuration are updated through separate endpoints, as
+			// editing the webhook with a configuration drops the secret unless it's given again.
+			hook := &github.Hook{}
+			config := &github.HookConfig{}
+			updateHook, updateConfig := false, false
+
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(events) > 0 {
+				hook.Events = events
+				updateHook = true
+			}
+			if _, ok := request.GetArguments()["active"]; ok {
+				active, err := OptionalParam[bool](request, "active")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				hook.Active = github.Ptr(active)
+				updateHook = true
+			}
+			for param, field := range map[string]**string{
+				"url":          &config.URL,
+				"content_type": &config.ContentType,
+				"secret":       &config.Secret,
+			} {
+				value, err := OptionalParam[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					*field = github.Ptr(value)
+					updateConfig = true
+				}
+			}
+			if _, ok := request.GetArguments()["insecure_ssl"]; ok {
+				insecureSSL, err := OptionalParam[bool](request, "insecure_ssl")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				config.InsecureSSL = github.Ptr(insecureSSLValue(insecureSSL))
+				updateConfig = true
+			}
+			if !updateHook && !updateConfig {
+				return mcp.NewToolResultError("no webhook settings to update were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if updateConfig {
+				_, resp, err := target.editConfig(ctx, client, int64(hookID), config)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to update configuration of webhook %d of %s", hookID, target),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			var updated *github.Hook
+			var resp *github.Response
+			if updateHook {
+				updated, resp, err = target.edit(ctx, client, int64(hookID), hook)
+			} else {
+				updated, resp, err = target.get(ctx, client, int64(hookID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update webhook %d of %s", hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ctx, convertToMinimalWebhook(updated)), nil
+		}
+}
+
+// DeleteWebhook creates a tool to delete a webhook of a repository or organization.
+func DeleteWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_webhook",
+			mcp.WithDescription(t("TOOL_DELETE_WEBHOOK_DESCRIPTION", "Delete a webhook of a repository, or of an organization when 'repo' is omitted. Events are no longer delivered to its payload URL.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_WEBHOOK_USER_TITLE", "Delete webhook"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := target.delete(ctx, client, int64(hookID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete webhook %d of %s", hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("webhook %d of %s deleted", hookID, target)), nil
+		}
+}
+
+// PingWebhook creates a tool to send a ping event to a webhook.
+func PingWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("ping_webhook",
+			mcp.WithDescription(t("TOOL_PING_WEBHOOK_DESCRIPTION", "Send a ping event to a repository or organization webhook to check that its payload URL is reachable. Use 'list_webhook_deliveries' to see the outcome.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PING_WEBHOOK_USER_TITLE", "Ping webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := target.ping(ctx, client, int64(hookID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to ping webhook %d of %s", hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("ping event sent to webhook %d of %s", hookID, target)), nil
+		}
+}
+
+// RedeliverWebhookDelivery creates a tool to redeliver a webhook delivery.
+func RedeliverWebhookDelivery(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("redeliver_webhook_delivery",
+			mcp.WithDescription(t("TOOL_REDELIVER_WEBHOOK_DELIVERY_DESCRIPTION", "Redeliver a delivery of a repository or organization webhook, sending the same payload to the webhook's payload URL again. Use this to retry failed deliveries once the receiver is fixed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REDELIVER_WEBHOOK_DELIVERY_USER_TITLE", "Redeliver webhook delivery"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithWebhookTarget(),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+			mcp.WithNumber("delivery_id",
+				mcp.Required(),
+				mcp.Description("The ID of the delivery to redeliver"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			target, err := webhookTargetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deliveryID, err := RequiredInt(request, "delivery_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API accepts the redelivery with a 202 and sends it asynchronously, which go-github reports
+			// as an acceptedError.
+			_, resp, err := target.redeliver(ctx, client, int64(hookID), int64(deliveryID))
+			if err != nil && !(resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err)) {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to redeliver delivery %d of webhook %d of %s", deliveryID, hookID, target),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("delivery %d of webhook %d of %s queued for redelivery", deliveryID, hookID, target)), nil
+		}
+}
@@ -0,0 +1,703 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListWebhooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWebhooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	hooks := []*github.Hook{
+		{
+			ID:     github.Ptr(int64(1)),
+			Active: github.Ptr(true),
+			Events: []string{"push", "pull_request"},
+			Config: &github.HookConfig{
+				URL:         github.Ptr("https://ci.example.com/hook"),
+				ContentType: github.Ptr("json"),
+				InsecureSSL: github.Ptr("0"),
+				Secret:      github.Ptr("********"),
+			},
+			LastResponse: map[string]any{"code": float64(200), "status": "active"},
+		},
+	}
+	expected := []MinimalWebhook{
+		{
+			ID:                 1,
+			URL:                "https://ci.example.com/hook",
+			Active:             true,
+			Events:             []string{"push", "pull_request"},
+			ContentType:        "json",
+			HasSecret:          true,
+			LastResponseCode:   200,
+			LastResponseStatus: "active",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, hooks),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "octo-org",
+				"repo":    "api",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+		},
+		{
+			name: "organization webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksByOrg,
+					expectPath(t, "/orgs/octo-org/hooks").andThen(
+						mockResponse(t, http.StatusOK, hooks),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+			},
+		},
+		{
+			name: "not a repository admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhooks for repository 'octo-org/api'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWebhooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalWebhook
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, expected, returned)
+		})
+	}
+}
+
+func Test_GetWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_webhook", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposHooksByOwnerByRepoByHookId,
+			expectPath(t, "/repos/octo-org/api/hooks/7").andThen(
+				mockResponse(t, http.StatusOK, &github.Hook{
+					ID:     github.Ptr(int64(7)),
+					Active: github.Ptr(false),
+					Events: []string{"*"},
+					Config: &github.HookConfig{
+						URL:         github.Ptr("http://legacy.example.com"),
+						ContentType: github.Ptr("form"),
+						InsecureSSL: github.Ptr("1"),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "octo-org",
+		"repo":    "api",
+		"hook_id": float64(7),
+	}))
+	require.NoError(t, err)
+
+	var returned MinimalWebhook
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, MinimalWebhook{
+		ID:          7,
+		URL:         "http://legacy.example.com",
+		Events:      []string{"*"},
+		ContentType: "form",
+		InsecureSSL: true,
+	}, returned)
+}
+
+func Test_ListWebhookDeliveries(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWebhookDeliveries(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhook_deliveries", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "failed_only")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	deliveredAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	deliveries := []*github.HookDelivery{
+		{
+			ID:          github.Ptr(int64(101)),
+			GUID:        github.Ptr("guid-101"),
+			Event:       github.Ptr("push"),
+			Status:      github.Ptr("OK"),
+			StatusCode:  github.Ptr(200),
+			Duration:    github.Ptr(0.25),
+			DeliveredAt: &github.Timestamp{Time: deliveredAt},
+		},
+		{
+			ID:          github.Ptr(int64(102)),
+			GUID:        github.Ptr("guid-102"),
+			Event:       github.Ptr("pull_request"),
+			Action:      github.Ptr("opened"),
+			Status:      github.Ptr("Invalid HTTP Response: 500"),
+			StatusCode:  github.Ptr(500),
+			Redelivery:  github.Ptr(true),
+			Duration:    github.Ptr(1.5),
+			DeliveredAt: &github.Timestamp{Time: deliveredAt},
+		},
+		{
+			ID:          github.Ptr(int64(103)),
+			GUID:        github.Ptr("guid-103"),
+			Event:       github.Ptr("push"),
+			Status:      github.Ptr("failed to connect to host"),
+			StatusCode:  github.Ptr(0),
+			DeliveredAt: &github.Timestamp{Time: deliveredAt},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectedIDs        []int64
+		expectedHasNext    bool
+		expectedNextCursor string
+	}{
+		{
+			name: "repository webhook deliveries with a next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					expectQueryParams(t, map[string]string{"cursor": "v1_100", "per_page": "3"}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/octo-org/api/hooks/7/deliveries?cursor=v1_103&per_page=3>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							_ = json.NewEncoder(w).Encode(deliveries)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "octo-org",
+				"repo":    "api",
+				"hook_id": float64(7),
+				"perPage": float64(3),
+				"after":   "v1_100",
+			},
+			expectedIDs:        []int64{101, 102, 103},
+			expectedHasNext:    true,
+			expectedNextCursor: "v1_103",
+		},
+		{
+			name: "failed organization webhook deliveries",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsHooksDeliveriesByOrgByHookId,
+					expectPath(t, "/orgs/octo-org/hooks/7/deliveries").andThen(
+						mockResponse(t, http.StatusOK, deliveries),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "octo-org",
+				"hook_id":     float64(7),
+				"failed_only": true,
+			},
+			expectedIDs: []int64{102, 103},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWebhookDeliveries(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			var returned struct {
+				Deliveries []MinimalWebhookDelivery `json:"deliveries"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+
+			ids := make([]int64, 0, len(returned.Deliveries))
+			for _, delivery := range returned.Deliveries {
+				ids = append(ids, delivery.ID)
+				assert.Nil(t, delivery.Request)
+				assert.Equal(t, "2026-10-16T09:30:00Z", delivery.DeliveredAt)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.Equal(t, tc.expectedHasNext, returned.PageInfo.HasNextPage)
+			assert.Equal(t, tc.expectedNextCursor, returned.PageInfo.EndCursor)
+		})
+	}
+}
+
+func Test_GetWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWebhookDelivery(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_webhook_delivery", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id", "delivery_id"})
+
+	requestPayload := json.RawMessage(`{"ref":"refs/heads/main"}`)
+	responsePayload := json.RawMessage(`"internal error"`)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsHooksDeliveriesByOrgByHookIdByDeliveryId,
+			expectPath(t, "/orgs/octo-org/hooks/7/deliveries/102").andThen(
+				mockResponse(t, http.StatusOK, &github.HookDelivery{
+					ID:         github.Ptr(int64(102)),
+					GUID:       github.Ptr("guid-102"),
+					Event:      github.Ptr("push"),
+					Status:     github.Ptr("Invalid HTTP Response: 500"),
+					StatusCode: github.Ptr(500),
+					Request: &github.HookRequest{
+						Headers:    map[string]string{"X-GitHub-Event": "push"},
+						RawPayload: &requestPayload,
+					},
+					Response: &github.HookResponse{
+						Headers:    map[string]string{"Content-Type": "text/plain"},
+						RawPayload: &responsePayload,
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetWebhookDelivery(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "octo-org",
+		"hook_id":     float64(7),
+		"delivery_id": float64(102),
+	}))
+	require.NoError(t, err)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, float64(500), returned["status_code"])
+	assert.Equal(t, map[string]any{
+		"headers": map[string]any{"X-GitHub-Event": "push"},
+		"payload": map[string]any{"ref": "refs/heads/main"},
+	}, returned["request"])
+	assert.Equal(t, map[string]any{
+		"headers": map[string]any{"Content-Type": "text/plain"},
+		"payload": "internal error",
+	}, returned["response"])
+}
+
+func Test_CreateWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_webhook", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "events")
+	assert.Contains(t, tool.InputSchema.Properties, "secret")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "url"})
+
+	created := &github.Hook{
+		ID:     github.Ptr(int64(8)),
+		Active: github.Ptr(true),
+		Events: []string{"push"},
+		Config: &github.HookConfig{
+			URL:         github.Ptr("https://ci.example.com/hook"),
+			ContentType: github.Ptr("json"),
+			InsecureSSL: github.Ptr("0"),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+	}{
+		{
+			name: "repository webhook with defaults",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name": "web",
+						"config": map[string]any{
+							"url":          "https://ci.example.com/hook",
+							"content_type": "json",
+							"insecure_ssl": "0",
+						},
+						"events": []any{"push"},
+						"active": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, created),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "octo-org",
+				"repo":  "api",
+				"url":   "https://ci.example.com/hook",
+			},
+		},
+		{
+			name: "inactive organization webhook with a secret",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsHooksByOrg,
+					expectRequestBody(t, map[string]any{
+						"name": "web",
+						"config": map[string]any{
+							"url":          "https://ci.example.com/hook",
+							"content_type": "form",
+							"insecure_ssl": "1",
+							"secret":       "s3cret",
+						},
+						"events": []any{"repository", "member"},
+						"active": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, created),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"url":          "https://ci.example.com/hook",
+				"events":       []any{"repository", "member"},
+				"content_type": "form",
+				"secret":       "s3cret",
+				"insecure_ssl": true,
+				"active":       false,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			var returned MinimalWebhook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(8), returned.ID)
+		})
+	}
+}
+
+func Test_UpdateWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_webhook", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	updated := &github.Hook{
+		ID:     github.Ptr(int64(7)),
+		Active: github.Ptr(false),
+		Events: []string{"push"},
+		Config: &github.HookConfig{URL: github.Ptr("https://new.example.com/hook")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "configuration and webhook settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposHooksConfigByOwnerByRepoByHookId,
+					expectRequestBody(t, map[string]any{
+						"url":          "https://new.example.com/hook",
+						"insecure_ssl": "0",
+					}).andThen(
+						mockResponse(t, http.StatusOK, updated.Config),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposHooksByOwnerByRepoByHookId,
+					expectRequestBody(t, map[string]any{
+						"active": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updated),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repo":         "api",
+				"hook_id":      float64(7),
+				"url":          "https://new.example.com/hook",
+				"insecure_ssl": false,
+				"active":       false,
+			},
+		},
+		{
+			name: "organization webhook configuration only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsHooksConfigByOrgByHookId,
+					expectRequestBody(t, map[string]any{
+						"secret": "rotated",
+					}).andThen(
+						mockResponse(t, http.StatusOK, updated.Config),
+					),
+				),
+				mock.WithRequestMatch(mock.GetOrgsHooksByOrgByHookId, updated),
+			),
+			requestArgs: map[string]any{
+				"owner":   "octo-org",
+				"hook_id": float64(7),
+				"secret":  "rotated",
+			},
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "octo-org",
+				"hook_id": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "no webhook settings to update were given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned MinimalWebhook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "https://new.example.com/hook", returned.URL)
+			assert.False(t, returned.Active)
+		})
+	}
+}
+
+func Test_DeleteWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_webhook", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposHooksByOwnerByRepoByHookId,
+			expectPath(t, "/repos/octo-org/api/hooks/7").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "octo-org",
+		"repo":    "api",
+		"hook_id": float64(7),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "webhook 7 of repository 'octo-org/api' deleted", getTextResult(t, result).Text)
+}
+
+func Test_PingWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PingWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "ping_webhook", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostOrgsHooksPingsByOrgByHookId,
+			expectPath(t, "/orgs/octo-org/hooks/7/pings").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := PingWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "octo-org",
+		"hook_id": float64(7),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "ping event sent to webhook 7 of organization 'octo-org'", getTextResult(t, result).Text)
+}
+
+func Test_RedeliverWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RedeliverWebhookDelivery(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "redeliver_webhook_delivery", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id", "delivery_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "redelivery accepted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIdByDeliveryId,
+					expectPath(t, "/repos/octo-org/api/hooks/7/deliveries/102/attempts").andThen(
+						mockResponse(t, http.StatusAccepted, map[string]any{}),
+					),
+				),
+			),
+		},
+		{
+			name: "delivery not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIdByDeliveryId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to redeliver delivery 102 of webhook 7 of repository 'octo-org/api'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RedeliverWebhookDelivery(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "octo-org",
+				"repo":        "api",
+				"hook_id":     float64(7),
+				"delivery_id": float64(102),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, "delivery 102 of webhook 7 of repository 'octo-org/api' queued for redelivery", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WriteLimits caps the calls of tools that change GitHub, to limit the damage an agent stuck in a loop can do.
+// A zero value disables a limit.
+type WriteLimits struct {
+	// MaxWritesPerMinute is the number of write tool calls allowed in any minute.
+	MaxWritesPerMinute int
+	// MaxFilesPerCommit is the number of files a single call may change, such as the files of push_files.
+	MaxFilesPerCommit int
+	// MaxDeletesPerSession is the number of items, such as files, branches or repositories, the delete tools and
+	// the delete operations of push_files may delete while the server runs.
+	MaxDeletesPerSession int
+}
+
+// WriteLimiter enforces WriteLimits on tool calls.
+type WriteLimiter struct {
+	limits WriteLimits
+	now    func() time.Time
+
+	mu sync.Mutex
+	// writes holds the start times of the write calls in the last minute, oldest first
+	writes  []time.Time
+	deletes int
+}
+
+// NewWriteLimiter creates a WriteLimiter. It returns nil if no limit is set.
+func NewWriteLimiter(limits WriteLimits) *WriteLimiter {
+	if limits.MaxWritesPerMinute <= 0 && limits.MaxFilesPerCommit <= 0 && limits.MaxDeletesPerSession <= 0 {
+		return nil
+	}
+	return &WriteLimiter{limits: limits, now: time.Now}
+}
+
+// deletedItems returns the number of items a call deletes: the branches of delete_branches unless it's a dry run, the
+// files push_files deletes, one for the other delete_ tools and for the delete method of consolidated tools such as
+// repository_admin, and none otherwise.
+func deletedItems(tool string, args map[string]any) int {
+	if method, _ := args["method"].(string); method == "delete" {
+		return 1
+	}
+	if tool == "push_files" {
+		files, _ := args["files"].([]any)
+		deletes := 0
+		for _, file := range files {
+			if file, ok := file.(map[string]any); ok && file["operation"] == "delete" {
+				deletes++
+			}
+		}
+		return deletes
+	}
+	if !strings.HasPrefix(tool, "delete_") {
+		return 0
+	}
+	if branches, ok := args["branches"].([]any); ok {
+		if dryRun, ok := args["dry_run"].(bool); !ok || dryRun {
+			return 0
+		}
+		return len(branches)
+	}
+	return 1
+}
+
+// allow checks a write call against the limits and, if it's allowed, counts it. It returns why the call isn't
+// allowed otherwise.
+func (l *WriteLimiter) allow(tool string, args map[string]any) string {
+	if files, ok := args["files"].([]any); ok && l.limits.MaxFilesPerCommit > 0 && len(files) > l.limits.MaxFilesPerCommit {
+		return fmt.Sprintf("%s would change %d files, but this server allows at most %d files per commit. Split the change into smaller commits.", tool, len(files), l.limits.MaxFilesPerCommit)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	deletes := deletedItems(tool, args)
+	if deletes > 0 && l.limits.MaxDeletesPerSession > 0 && l.deletes+deletes > l.limits.MaxDeletesPerSession {
+		return fmt.Sprintf("%s would delete %d items, but this server allows at most %d deletions per session and %d were already made. Ask the user to confirm and restart the server to delete more.", tool, deletes, l.limits.MaxDeletesPerSession, l.deletes)
+	}
+
+	now := l.now()
+	if l.limits.MaxWritesPerMinute > 0 {
+		cutoff := now.Add(-time.Minute)
+		expired := 0
+		for expired < len(l.writes) && !l.writes[expired].After(cutoff) {
+			expired++
+		}
+		l.writes = l.writes[expired:]
+		if len(l.writes) >= l.limits.MaxWritesPerMinute {
+			retryIn := l.writes[0].Add(time.Minute).Sub(now).Round(time.Second)
+			return fmt.Sprintf("this server allows at most %d write operations per minute, retry %s in %s", l.limits.MaxWritesPerMinute, tool, retryIn)
+		}
+		l.writes = append(l.writes, now)
+	}
+	l.deletes += deletes
+	return ""
+}
+
+// Middleware returns a tool handler middleware that rejects the calls of the tools for which isWrite returns true
+// once they exceed the limits. Calls count against the limits when they start, whether they succeed or not, so
+// that a loop of failing calls is stopped too.
+func (l *WriteLimiter) Middleware(isWrite func(tool string) bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !isWrite(request.Params.Name) {
+				return next(ctx, request)
+			}
+			if reason := l.allow(request.Params.Name, request.GetArguments()); reason != "" {
+				return mcp.NewToolResultError(reason), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewWriteLimiter(t *testing.T) {
+	assert.Nil(t, NewWriteLimiter(WriteLimits{}), "no limits must not create a limiter")
+	assert.NotNil(t, NewWriteLimiter(WriteLimits{MaxFilesPerCommit: 10}))
+}
+
+func Test_WriteLimiter_Middleware(t *testing.T) {
+	limiter := NewWriteLimiter(WriteLimits{MaxWritesPerMinute: 3, MaxFilesPerCommit: 2, MaxDeletesPerSession: 3})
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	writeTools := map[string]bool{"push_files": true, "delete_branches": true, "delete_file": true, "repository_admin": true}
+	calls := 0
+	handler := limiter.Middleware(func(tool string) bool { return writeTools[tool] })(
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText("ok"), nil
+		})
+	call := func(tool string, args map[string]any) *mcp.CallToolResult {
+		request := createMCPRequest(args)
+		request.Params.Name = tool
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Too many files are rejected without counting as a write
+	result := call("push_files", map[string]any{"files": []any{map[string]any{}, map[string]any{}, map[string]any{}}})
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "push_files would change 3 files, but this server allows at most 2 files per commit")
+	assert.Equal(t, 0, calls)
+
+	// Read tools aren't limited
+	for i := 0; i < 5; i++ {
+		require.False(t, call("get_file_contents", map[string]any{}).IsError)
+	}
+
+	require.False(t, call("push_files", map[string]any{"files": []any{map[string]any{}, map[string]any{}}}).IsError)
+	require.False(t, call("delete_branches", map[string]any{"branches": []any{"a", "b"}, "dry_run": false}).IsError)
+
+	// Deleting a repository would exceed the deletions left, while deleting a file would not
+	result = call("repository_admin", map[string]any{"method": "delete"})
+	require.False(t, result.IsError)
+	now = now.Add(30 * time.Second)
+	result = call("delete_file", map[string]any{"path": "README.md"})
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "at most 3 deletions per session and 3 were already made")
+
+	// The 3 writes in the last minute hit the rate limit until the first one is a minute old
+	result = call("repository_admin", map[string]any{"method": "archive"})
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "at most 3 write operations per minute, retry repository_admin in 30s")
+
+	now = now.Add(31 * time.Second)
+	require.False(t, call("repository_admin", map[string]any{"method": "archive"}).IsError)
+	assert.Equal(t, 5+4, calls)
+}
+
+func Test_WriteLimiter_PushFilesDeletes(t *testing.T) {
+	limiter := NewWriteLimiter(WriteLimits{MaxDeletesPerSession: 2})
+	handler := limiter.Middleware(func(tool string) bool { return tool == "push_files" })(
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	call := func(files ...map[string]any) *mcp.CallToolResult {
+		args := map[string]any{"files": []any{}}
+		for _, file := range files {
+			args["files"] = append(args["files"].([]any), file)
+		}
+		request := createMCPRequest(args)
+		request.Params.Name = "push_files"
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	deleteFile := func(path string) map[string]any { return map[string]any{"path": path, "operation": "delete"} }
+
+	// Only the deleted files count toward the deletions of the session
+	require.False(t, call(map[string]any{"path": "a.txt", "content": "a"}, deleteFile("old.txt")).IsError)
+
+	result := call(deleteFile("b.txt"), deleteFile("c.txt"))
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "push_files would delete 2 items, but this server allows at most 2 deletions per session and 1 were already made")
+
+	require.False(t, call(deleteFile("b.txt")).IsError)
+	require.True(t, call(deleteFile("c.txt")).IsError)
+}
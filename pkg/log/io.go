@@ -2,10 +2,20 @@ package log
 
 import (
 	"io"
+	"regexp"
 
 	"log/slog"
 )
 
+// githubTokenPattern matches GitHub access tokens, such as the installation tokens minted by tools, which must
+// not end up in the log.
+var githubTokenPattern = regexp.MustCompile(`\b(gh[pousr]_|github_pat_)[A-Za-z0-9_]+`)
+
+// RedactTokens replaces the GitHub access tokens in data, keeping their prefix to show the kind of token.
+func RedactTokens(data string) string {
+	return githubTokenPattern.ReplaceAllString(data, "${1}[REDACTED]")
+}
+
 // IOLogger is a wrapper around io.Reader and io.Writer that can be used
 // to log the data being read and written from the underlying streams
 type IOLogger struct {
@@ -30,7 +40,7 @@ func (l *IOLogger) Read(p []byte) (n int, err error) {
 	}
 	n, err = l.reader.Read(p)
 	if n > 0 {
-		l.logger.Info("[stdin]: received bytes", "count", n, "data", string(p[:n]))
+		l.logger.Info("[stdin]: received bytes", "count", n, "data", RedactTokens(string(p[:n])))
 	}
 	return n, err
 }
@@ -40,6 +50,6 @@ func (l *IOLogger) Write(p []byte) (n int, err error) {
 	if l.writer == nil {
 		return 0, io.ErrClosedPipe
 	}
-	l.logger.Info("[stdout]: sending bytes", "count", len(p), "data", string(p))
+	l.logger.Info("[stdout]: sending bytes", "count", len(p), "data", RedactTokens(string(p)))
 	return l.writer.Write(p)
 }
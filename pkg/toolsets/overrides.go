@@ -0,0 +1,114 @@
+package toolsets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolOverride replaces parts of the definition of a tool, so that teams can tune the guidance it gives the model,
+// e.g. to add organization specific conventions to the description of a write tool.
+type ToolOverride struct {
+	// Description replaces the description of the tool.
+	Description *string `json:"description,omitempty"`
+	// AppendDescription is appended to the description of the tool, after Description if both are set.
+	AppendDescription string `json:"append_description,omitempty"`
+	// Annotations replace the annotations that are set.
+	Annotations *ToolAnnotationsOverride `json:"annotations,omitempty"`
+}
+
+// ToolAnnotationsOverride holds the tool annotations an override can replace. The read-only hint is left out, as
+// it decides whether a tool is offered in read-only mode.
+type ToolAnnotationsOverride struct {
+	Title           *string `json:"title,omitempty"`
+	DestructiveHint *bool   `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool   `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool   `json:"openWorldHint,omitempty"`
+}
+
+// LoadToolOverrides reads the tool overrides from a JSON file, an object mapping tool names to their override.
+func LoadToolOverrides(path string) (map[string]ToolOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool overrides: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var overrides map[string]ToolOverride
+	if err := decoder.Decode(&overrides); err != nil {
+		if strings.Contains(err.Error(), `unknown field "readOnlyHint"`) {
+			return nil, fmt.Errorf("failed to parse tool overrides: the readOnlyHint annotation can't be overridden")
+		}
+		return nil, fmt.Errorf("failed to parse tool overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// apply applies the override to the tool.
+func (o ToolOverride) apply(tool *mcp.Tool) {
+	if o.Description != nil {
+		tool.Description = *o.Description
+	}
+	if o.AppendDescription != "" {
+		if tool.Description == "" {
+			tool.Description = o.AppendDescription
+		} else {
+			tool.Description += " " + o.AppendDescription
+		}
+	}
+	if a := o.Annotations; a != nil {
+		if a.Title != nil {
+			tool.Annotations.Title = *a.Title
+		}
+		if a.DestructiveHint != nil {
+			tool.Annotations.DestructiveHint = a.DestructiveHint
+		}
+		if a.IdempotentHint != nil {
+			tool.Annotations.IdempotentHint = a.IdempotentHint
+		}
+		if a.OpenWorldHint != nil {
+			tool.Annotations.OpenWorldHint = a.OpenWorldHint
+		}
+	}
+}
+
+// applyToolOverrides applies the overrides to the tools of the toolset, and marks the tools it found in applied.
+func (t *Toolset) applyToolOverrides(overrides map[string]ToolOverride, applied map[string]bool) {
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools} {
+		for i := range tools {
+			if override, ok := overrides[tools[i].Tool.Name]; ok {
+				override.apply(&tools[i].Tool)
+				applied[tools[i].Tool.Name] = true
+			}
+		}
+	}
+}
+
+// ApplyToolOverrides applies the overrides to the tools of every toolset, including the write tools of read-only
+// toolsets. It must be called before the tools are registered, and fails if an override names an unknown tool, so
+// that typos don't go unnoticed.
+func (tg *ToolsetGroup) ApplyToolOverrides(overrides map[string]ToolOverride) error {
+	applied := make(map[string]bool, len(overrides))
+	for _, toolset := range tg.Toolsets {
+		toolset.applyToolOverrides(overrides, applied)
+	}
+
+	var unknown []string
+	for name := range overrides {
+		if !applied[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown tools: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package toolsets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func newTestTool(name, description string, readOnly bool) server.ServerTool {
+	return NewServerTool(
+		mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        name,
+				ReadOnlyHint: &readOnly,
+			}),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, nil
+		},
+	)
+}
+
+func writeOverrides(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write overrides: %v", err)
+	}
+	return path
+}
+
+func TestLoadToolOverrides(t *testing.T) {
+	path := writeOverrides(t, `{
+		"create_or_update_file": {
+			"append_description": "Never commit to main directly.",
+			"annotations": {"title": "Commit a file", "destructiveHint": true}
+		},
+		"get_me": {"description": "Who am I?"}
+	}`)
+
+	overrides, err := LoadToolOverrides(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(overrides) != 2 {
+		t.Fatalf("Expected 2 overrides, got %d", len(overrides))
+	}
+	override := overrides["create_or_update_file"]
+	if override.AppendDescription != "Never commit to main directly." {
+		t.Errorf("Unexpected appended description %q", override.AppendDescription)
+	}
+	if override.Annotations == nil || *override.Annotations.Title != "Commit a file" || !*override.Annotations.DestructiveHint {
+		t.Errorf("Unexpected annotations %+v", override.Annotations)
+	}
+	if *overrides["get_me"].Description != "Who am I?" {
+		t.Errorf("Unexpected description %q", *overrides["get_me"].Description)
+	}
+}
+
+func TestLoadToolOverridesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "invalid JSON",
+			content:     `{`,
+			expectedErr: "failed to parse tool overrides",
+		},
+		{
+			name:        "unknown field",
+			content:     `{"get_me": {"descripton": "typo"}}`,
+			expectedErr: `unknown field "descripton"`,
+		},
+		{
+			name:        "read-only hint",
+			content:     `{"get_me": {"annotations": {"readOnlyHint": false}}}`,
+			expectedErr: "the readOnlyHint annotation can't be overridden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadToolOverrides(writeOverrides(t, tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	if _, err := LoadToolOverrides(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestApplyToolOverrides(t *testing.T) {
+	tsg := NewToolsetGroup(true)
+	toolset := NewToolset("repos", "Repositories").
+		AddReadTools(newTestTool("get_file_contents", "Get a file.", true)).
+		AddWriteTools(newTestTool("create_or_update_file", "Write a file.", false))
+	tsg.AddToolset(toolset)
+
+	replaced := "Get the contents of a file."
+	destructive := true
+	err := tsg.ApplyToolOverrides(map[string]ToolOverride{
+		"get_file_contents": {
+			Description:       &replaced,
+			AppendDescription: "Prefer the default branch.",
+		},
+		// Write tools of read-only toolsets can be overridden too
+		"create_or_update_file": {
+			AppendDescription: "Never commit to main directly.",
+			Annotations:       &ToolAnnotationsOverride{DestructiveHint: &destructive},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	readTool := toolset.readTools[0].Tool
+	if readTool.Description != "Get the contents of a file. Prefer the default branch." {
+		t.Errorf("Unexpected description %q", readTool.Description)
+	}
+	if readTool.Annotations.Title != "get_file_contents" {
+		t.Errorf("Expected the title to be kept, got %q", readTool.Annotations.Title)
+	}
+
+	writeTool := toolset.writeTools[0].Tool
+	if writeTool.Description != "Write a file. Never commit to main directly." {
+		t.Errorf("Unexpected description %q", writeTool.Description)
+	}
+	if writeTool.Annotations.DestructiveHint == nil || !*writeTool.Annotations.DestructiveHint {
+		t.Error("Expected the destructive hint to be set")
+	}
+	if *writeTool.Annotations.ReadOnlyHint {
+		t.Error("Expected the read-only hint to be kept")
+	}
+}
+
+func TestApplyToolOverridesUnknownTools(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("repos", "Repositories").
+		AddReadTools(newTestTool("get_file_contents", "Get a file.", true)))
+
+	description := "unused"
+	err := tsg.ApplyToolOverrides(map[string]ToolOverride{
+		"get_file_contents": {Description: &description},
+		"get_file_content":  {Description: &description},
+		"create_file":       {Description: &description},
+	})
+	if err == nil || err.Error() != "unknown tools: create_file, get_file_content" {
+		t.Errorf("Expected an error naming the unknown tools, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package toolsets
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// charsPerToken is the average number of characters per token of English text and JSON, which is close enough to
+// the tokenizers of the common models to compare the cost of tools.
+const charsPerToken = 4
+
+// EstimateToolTokens estimates the number of tokens the definition of a tool takes up in the context of a model,
+// from the length of its JSON serialization as sent in the tools/list response.
+func EstimateToolTokens(tool mcp.Tool) int {
+	data, err := json.Marshal(tool)
+	if err != nil {
+		return 0
+	}
+	return (len(data) + charsPerToken - 1) / charsPerToken
+}
+
+// ToolTokenEstimate is the estimated context cost of a tool.
+type ToolTokenEstimate struct {
+	Name     string `json:"name"`
+	ReadOnly bool   `json:"read_only"`
+	Tokens   int    `json:"estimated_tokens"`
+}
+
+// ToolsetTokenEstimate is the estimated context cost of the tools a toolset offers, given the read-only setting.
+type ToolsetTokenEstimate struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Enabled     bool                `json:"enabled"`
+	Tokens      int                 `json:"estimated_tokens"`
+	Tools       []ToolTokenEstimate `json:"tools"`
+}
+
+// TokenEstimates estimates the context cost of every toolset of the group, sorted by toolset name.
+func (tg *ToolsetGroup) TokenEstimates() []ToolsetTokenEstimate {
+	estimates := make([]ToolsetTokenEstimate, 0, len(tg.Toolsets))
+	for _, toolset := range tg.Toolsets {
+		estimate := ToolsetTokenEstimate{
+			Name:        toolset.Name,
+			Description: toolset.Description,
+			Enabled:     tg.IsEnabled(toolset.Name),
+			Tools:       []ToolTokenEstimate{},
+		}
+		for _, tool := range toolset.GetAvailableTools() {
+			tokens := EstimateToolTokens(tool.Tool)
+			estimate.Tools = append(estimate.Tools, ToolTokenEstimate{
+				Name:     tool.Tool.Name,
+				ReadOnly: tool.Tool.Annotations.ReadOnlyHint != nil && *tool.Tool.Annotations.ReadOnlyHint,
+				Tokens:   tokens,
+			})
+			estimate.Tokens += tokens
+		}
+		estimates = append(estimates, estimate)
+	}
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].Name < estimates[j].Name })
+	return estimates
+}
@@ -0,0 +1,62 @@
+package toolsets
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEstimateToolTokens(t *testing.T) {
+	tool := newTestTool("get_me", "Get details of the authenticated user.", true).Tool
+	data, err := json.Marshal(tool)
+	if err != nil {
+		t.Fatalf("failed to marshal tool: %v", err)
+	}
+
+	tokens := EstimateToolTokens(tool)
+	if tokens*charsPerToken < len(data) || (tokens-1)*charsPerToken >= len(data) {
+		t.Errorf("Expected %d characters to round up to %d tokens, got %d", len(data), (len(data)+3)/4, tokens)
+	}
+
+	longer := newTestTool("get_me", "Get details of the authenticated user, including their plan and two-factor status.", true).Tool
+	if EstimateToolTokens(longer) <= tokens {
+		t.Error("Expected a longer description to cost more tokens")
+	}
+}
+
+func TestTokenEstimates(t *testing.T) {
+	tsg := NewToolsetGroup(true)
+	tsg.AddToolset(NewToolset("repos", "Repositories").
+		AddReadTools(newTestTool("get_file_contents", "Get a file.", true)).
+		AddWriteTools(newTestTool("create_or_update_file", "Write a file.", false)))
+	tsg.AddToolset(NewToolset("issues", "Issues").
+		AddReadTools(
+			newTestTool("get_issue", "Get an issue.", true),
+			newTestTool("list_issues", "List issues.", true),
+		))
+	if err := tsg.EnableToolset("repos"); err != nil {
+		t.Fatalf("failed to enable toolset: %v", err)
+	}
+
+	estimates := tsg.TokenEstimates()
+	if len(estimates) != 2 {
+		t.Fatalf("Expected 2 toolsets, got %d", len(estimates))
+	}
+
+	issues, repos := estimates[0], estimates[1]
+	if issues.Name != "issues" || repos.Name != "repos" {
+		t.Fatalf("Expected toolsets sorted by name, got %s and %s", issues.Name, repos.Name)
+	}
+	if issues.Enabled || !repos.Enabled {
+		t.Errorf("Unexpected enabled states: issues %t, repos %t", issues.Enabled, repos.Enabled)
+	}
+	if len(issues.Tools) != 2 || issues.Tokens != issues.Tools[0].Tokens+issues.Tools[1].Tokens {
+		t.Errorf("Expected the issues estimate to sum its 2 tools, got %+v", issues)
+	}
+	// Write tools aren't offered in read-only mode, so they cost nothing
+	if len(repos.Tools) != 1 || repos.Tools[0].Name != "get_file_contents" || !repos.Tools[0].ReadOnly {
+		t.Errorf("Expected only the read tool of repos, got %+v", repos.Tools)
+	}
+	if repos.Tokens != repos.Tools[0].Tokens || repos.Tokens == 0 {
+		t.Errorf("Unexpected repos estimate %d", repos.Tokens)
+	}
+}
@@ -114,6 +114,10 @@ func (t *Toolset) GetAvailableResourceTemplates() []server.ServerResourceTemplat
 	return t.resourceTemplates
 }
 
+func (t *Toolset) GetAvailablePrompts() []server.ServerPrompt {
+	return t.prompts
+}
+
 func (t *Toolset) RegisterResourcesTemplates(s *server.MCPServer) {
 	if !t.Enabled {
 		return
@@ -2,9 +2,12 @@ package translations
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -17,6 +20,54 @@ func NullTranslationHelper(_ string, defaultValue string) string {
 }
 
 func TranslationHelper() (TranslationHelperFunc, func()) {
+	return newTranslationHelper(nil)
+}
+
+// LocaleTranslationHelper is like TranslationHelper, but translates the keys the overrides don't cover with the
+// translations of a locale, read from the JSON file named after it in localesDir, such as "locales/ja.json". A
+// regional locale without a file of its own, such as "pt-BR", uses the file of its language, "pt.json". Keys the
+// locale doesn't translate keep their default value. An empty locale loads no translations.
+func LocaleTranslationHelper(locale, localesDir string) (TranslationHelperFunc, func(), error) {
+	if locale == "" {
+		t, dump := newTranslationHelper(nil)
+		return t, dump, nil
+	}
+	localeMap, err := loadLocale(locale, localesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, dump := newTranslationHelper(localeMap)
+	return t, dump, nil
+}
+
+// loadLocale reads the translations of a locale, keyed by their uppercase key.
+func loadLocale(locale, localesDir string) (map[string]string, error) {
+	candidates := []string{locale}
+	if language, _, ok := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); ok {
+		candidates = append(candidates, language)
+	}
+	for _, candidate := range candidates {
+		data, err := os.ReadFile(filepath.Join(localesDir, candidate+".json"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading translations of locale %s: %v", locale, err)
+		}
+		var translations map[string]string
+		if err := json.Unmarshal(data, &translations); err != nil {
+			return nil, fmt.Errorf("error parsing translations of locale %s: %v", locale, err)
+		}
+		localeMap := make(map[string]string, len(translations))
+		for key, value := range translations {
+			localeMap[strings.ToUpper(key)] = value
+		}
+		return localeMap, nil
+	}
+	return nil, fmt.Errorf("no translations for locale %s found in %s", locale, localesDir)
+}
+
+func newTranslationHelper(localeMap map[string]string) (TranslationHelperFunc, func()) {
 	var translationKeyMap = map[string]string{}
 	v := viper.New()
 
@@ -45,6 +96,9 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 				return value
 			}
 
+			if value, exists := localeMap[key]; exists {
+				defaultValue = value
+			}
 			v.SetDefault(key, defaultValue)
 			translationKeyMap[key] = v.GetString(key)
 			return translationKeyMap[key]
@@ -0,0 +1,96 @@
+package translations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleTranslationHelper(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ja.json"), []byte(`{
+		"tool_get_me_description": "認証されたユーザーの詳細を取得します",
+		"TOOL_GET_ME_USER_TITLE": "自分のプロフィール"
+	}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pt-BR.json"), []byte(`{"TOOL_GET_ME_USER_TITLE": "Meu perfil"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{`), 0600))
+
+	tests := []struct {
+		name           string
+		locale         string
+		expectedErrMsg string
+		expected       map[string]string
+	}{
+		{
+			name:   "no locale",
+			locale: "",
+			expected: map[string]string{
+				"TOOL_GET_ME_DESCRIPTION": "Get details of the authenticated user",
+				"TOOL_GET_ME_USER_TITLE":  "Get my user profile",
+			},
+		},
+		{
+			name:   "locale file",
+			locale: "ja",
+			expected: map[string]string{
+				"TOOL_GET_ME_DESCRIPTION": "認証されたユーザーの詳細を取得します",
+				"TOOL_GET_ME_USER_TITLE":  "自分のプロフィール",
+			},
+		},
+		{
+			name:   "regional locale falls back to its language",
+			locale: "ja_JP",
+			expected: map[string]string{
+				"TOOL_GET_ME_USER_TITLE": "自分のプロフィール",
+			},
+		},
+		{
+			name:   "untranslated keys keep their default",
+			locale: "pt-BR",
+			expected: map[string]string{
+				"TOOL_GET_ME_DESCRIPTION": "Get details of the authenticated user",
+				"TOOL_GET_ME_USER_TITLE":  "Meu perfil",
+			},
+		},
+		{
+			name:           "missing locale",
+			locale:         "fr",
+			expectedErrMsg: "no translations for locale fr found",
+		},
+		{
+			name:           "invalid locale file",
+			locale:         "broken",
+			expectedErrMsg: "error parsing translations of locale broken",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			translate, _, err := LocaleTranslationHelper(tc.locale, dir)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			defaults := map[string]string{
+				"TOOL_GET_ME_DESCRIPTION": "Get details of the authenticated user",
+				"TOOL_GET_ME_USER_TITLE":  "Get my user profile",
+			}
+			for key, expected := range tc.expected {
+				assert.Equal(t, expected, translate(key, defaults[key]))
+			}
+		})
+	}
+
+	t.Run("environment overrides take precedence over the locale", func(t *testing.T) {
+		t.Setenv("GITHUB_MCP_TOOL_GET_ME_USER_TITLE", "Override")
+		translate, _, err := LocaleTranslationHelper("ja", dir)
+		require.NoError(t, err)
+		assert.Equal(t, "Override", translate("TOOL_GET_ME_USER_TITLE", "Get my user profile"))
+	})
+}